@@ -10,12 +10,7 @@ import (
 	"time"
 	"unicode"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/metadata"
-	"google.golang.org/protobuf/types/known/timestamppb"
-
-	pb "github.com/ztdp/agents/text-processor/proto/api"
+	"neuromesh/pkg/agentsdk"
 )
 
 // Config holds agent configuration
@@ -27,112 +22,40 @@ type Config struct {
 }
 
 // AINativeAgent implements the AI-native text processing agent
+// The agent SDK owns the connection to the orchestrator; this type only processes instructions
 type AINativeAgent struct {
-	config     Config
-	client     pb.OrchestrationServiceClient
-	conn       *grpc.ClientConn
-	sessionID  string
-	registered bool
+	config Config
+	sdk    *agentsdk.Agent
 }
 
 // NewAINativeAgent creates a new AI-native agent
 func NewAINativeAgent(config Config) *AINativeAgent {
-	return &AINativeAgent{
-		config: config,
-	}
+	a := &AINativeAgent{config: config}
+	a.sdk = agentsdk.New(agentsdk.Config{
+		AgentID:             config.AgentID,
+		Name:                config.Name,
+		Type:                "text-processor",
+		Version:             "1.0.0",
+		OrchestratorAddress: config.OrchestratorAddress,
+		ReconnectInterval:   config.ReconnectInterval,
+	}, a)
+	return a
 }
 
 // Start connects to the orchestrator and begins operation
 func (a *AINativeAgent) Start(ctx context.Context) error {
 	log.Printf("🔌 Connecting to orchestrator at %s", a.config.OrchestratorAddress)
-
-	// Connect to orchestrator
-	conn, err := grpc.Dial(a.config.OrchestratorAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		return fmt.Errorf("failed to connect to orchestrator: %w", err)
-	}
-
-	a.conn = conn
-	a.client = pb.NewOrchestrationServiceClient(conn)
-
-	// Register with orchestrator
-	if err := a.register(ctx); err != nil {
-		return fmt.Errorf("failed to register: %w", err)
-	}
-
-	// Start dedicated infrastructure processes (heartbeat, status)
-	if err := a.StartInfrastructure(ctx); err != nil {
-		return fmt.Errorf("failed to start infrastructure: %w", err)
-	}
-
-	// Start AI conversation stream (separate from infrastructure)
-	if err := a.startConversationStream(ctx); err != nil {
-		return fmt.Errorf("failed to start AI conversation stream: %w", err)
-	}
-
-	log.Printf("✅ AI-native text processing agent started successfully")
-	log.Printf("🎯 Agent %s ready for AI instructions!", a.config.AgentID)
-	log.Printf("🔗 Connected to orchestrator at %s", a.config.OrchestratorAddress)
-	log.Printf("🤖 Capabilities: word-count, text-analysis, character-count")
-	return nil
+	return a.sdk.Start(ctx)
 }
 
 // Stop gracefully shuts down the agent
 func (a *AINativeAgent) Stop(ctx context.Context) error {
-	if a.registered {
-		_ = a.unregister(ctx)
-	}
-
-	if a.conn != nil {
-		return a.conn.Close()
-	}
-
-	return nil
+	return a.sdk.Stop(ctx)
 }
 
-// register registers the agent with the orchestrator
-func (a *AINativeAgent) register(ctx context.Context) error {
-	capabilities := a.getCapabilities()
-
-	req := &pb.RegisterAgentRequest{
-		AgentId:      a.config.AgentID,
-		Name:         a.config.Name,
-		Type:         "text-processor",
-		Capabilities: capabilities,
-		Version:      "1.0.0",
-	}
-
-	resp, err := a.client.RegisterAgent(ctx, req)
-	if err != nil {
-		return fmt.Errorf("registration failed: %w", err)
-	}
-
-	if !resp.Success {
-		return fmt.Errorf("registration rejected: %s", resp.Message)
-	}
-
-	a.sessionID = resp.SessionId
-	a.registered = true
-
-	log.Printf("🎯 Registered with session ID: %s", a.sessionID)
-	return nil
-}
-
-// unregister unregisters the agent from the orchestrator
-func (a *AINativeAgent) unregister(ctx context.Context) error {
-	req := &pb.UnregisterAgentRequest{
-		AgentId:   a.config.AgentID,
-		SessionId: a.sessionID,
-		Reason:    "Graceful shutdown",
-	}
-
-	_, err := a.client.UnregisterAgent(ctx, req)
-	return err
-}
-
-// getCapabilities returns the agent's capabilities in the new format
-func (a *AINativeAgent) getCapabilities() []*pb.AgentCapability {
-	return []*pb.AgentCapability{
+// Capabilities returns the capabilities registered with the orchestrator
+func (a *AINativeAgent) Capabilities() []agentsdk.Capability {
+	return []agentsdk.Capability{
 		{
 			Name:        "word-count",
 			Description: "Count the number of words in text",
@@ -151,46 +74,137 @@ func (a *AINativeAgent) getCapabilities() []*pb.AgentCapability {
 			Inputs:      []string{"text"},
 			Outputs:     []string{"character_count"},
 		},
+		{
+			Name:        "detect-language",
+			Description: "Detect the language text is written in",
+			Inputs:      []string{"text"},
+			Outputs:     []string{"language", "language_name", "confidence"},
+		},
+		{
+			Name:        "sentiment-analysis",
+			Description: "Score whether English text expresses positive or negative sentiment",
+			Inputs:      []string{"text"},
+			Outputs:     []string{"sentiment", "sentiment_score"},
+		},
 	}
 }
 
+// actionContextKey is the instruction context key carrying the capability name of the plan step
+const actionContextKey = "action"
+
+// HandleInstruction processes an instruction received from the orchestrator
+// The result carries the computed values as structured data alongside the natural language answer
+func (a *AINativeAgent) HandleInstruction(ctx context.Context, instruction agentsdk.Instruction) (agentsdk.Result, error) {
+	action, _ := instruction.Context[actionContextKey].(string)
+	content, data := a.process(instruction.Content, action, instruction.Parameters)
+	return agentsdk.Result{Content: content, Data: data}, nil
+}
+
 // ProcessInstruction handles natural language instructions from AI orchestrator
 func (a *AINativeAgent) ProcessInstruction(instruction string) string {
+	return a.ProcessInstructionWithParameters(instruction, nil)
+}
+
+// ProcessInstructionWithParameters handles an instruction whose text to process may be given as parameters["text"]
+func (a *AINativeAgent) ProcessInstructionWithParameters(instruction string, parameters map[string]interface{}) string {
+	content, _ := a.process(instruction, "", parameters)
+	return content
+}
+
+// process performs an instruction, returning the natural language answer and the structured values behind it
+// The declared capability named by action decides what to do; the instruction text is only read when action names none
+func (a *AINativeAgent) process(instruction, action string, parameters map[string]interface{}) (string, map[string]interface{}) {
 	log.Printf("📥 Processing AI instruction: %s", instruction)
 
-	// Extract text from natural language instruction
-	text := a.extractTextFromInstruction(instruction)
-	log.Printf("📝 Extracted text: '%s'", text)
+	text, structured := a.resolveText(instruction, parameters)
+	if structured {
+		log.Printf("📝 Text from parameters: '%s'", text)
+	} else {
+		log.Printf("📝 Extracted text: '%s'", text)
+	}
 
-	// Determine what the AI wants us to do
-	instructionLower := strings.ToLower(instruction)
+	capability := action
+	if !a.hasCapability(capability) {
+		capability = capabilityFromInstruction(instruction)
+	}
 
-	if strings.Contains(instructionLower, "count") && strings.Contains(instructionLower, "word") {
-		count := a.countWords(text)
-		response := fmt.Sprintf(`The text "%s" contains %d words.`, text, count)
+	switch capability {
+	case "sentiment-analysis":
+		sentiment, score := a.analyzeSentiment(text)
+		response := fmt.Sprintf(`The sentiment of "%s" is %s (score %.2f).`, text, sentiment, score)
 		log.Printf("✅ Response: %s", response)
-		return response
-	}
+		return response, map[string]interface{}{"sentiment": sentiment, "sentiment_score": score}
 
-	if strings.Contains(instructionLower, "analyze") || strings.Contains(instructionLower, "analysis") {
+	case "detect-language":
+		detected, confidence := a.detectLanguage(text)
+		response := fmt.Sprintf(`The text "%s" is written in %s (%s, confidence %.0f%%).`, text, detected.Name, detected.Code, confidence*100)
+		log.Printf("✅ Response: %s", response)
+		return response, map[string]interface{}{"language": detected.Code, "language_name": detected.Name, "confidence": confidence}
+
+	case "text-analysis":
 		analysis := a.analyzeText(text)
 		response := fmt.Sprintf("Analysis of \"%s\": %s", text, analysis)
 		log.Printf("✅ Response: %s", response)
-		return response
-	}
+		return response, map[string]interface{}{"analysis_report": analysis}
 
-	if strings.Contains(instructionLower, "character") && strings.Contains(instructionLower, "count") {
+	case "character-count":
 		count := len(text)
 		response := fmt.Sprintf(`The text "%s" contains %d characters.`, text, count)
 		log.Printf("✅ Response: %s", response)
-		return response
+		return response, map[string]interface{}{"character_count": count}
+
+	default:
+		return a.wordCountResult(text)
+	}
+}
+
+// hasCapability reports whether name is one of the capabilities the agent declares
+func (a *AINativeAgent) hasCapability(name string) bool {
+	for _, capability := range a.Capabilities() {
+		if capability.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// capabilityFromInstruction guesses the capability a legacy instruction without an action asks for from its wording
+func capabilityFromInstruction(instruction string) string {
+	instructionLower := strings.ToLower(instruction)
+
+	switch {
+	case strings.Contains(instructionLower, "count") && strings.Contains(instructionLower, "word"):
+		return "word-count"
+	// Checked before general analysis, since "sentiment analysis" also asks for an analysis
+	case strings.Contains(instructionLower, "sentiment"):
+		return "sentiment-analysis"
+	case strings.Contains(instructionLower, "language"):
+		return "detect-language"
+	case strings.Contains(instructionLower, "analyze") || strings.Contains(instructionLower, "analysis"):
+		return "text-analysis"
+	case strings.Contains(instructionLower, "character") && strings.Contains(instructionLower, "count"):
+		return "character-count"
+	default:
+		// Word count is the most common request
+		return "word-count"
 	}
+}
 
-	// Default: word count (most common request)
+// wordCountResult answers a word count request
+func (a *AINativeAgent) wordCountResult(text string) (string, map[string]interface{}) {
 	count := a.countWords(text)
 	response := fmt.Sprintf(`The text "%s" contains %d words.`, text, count)
 	log.Printf("✅ Response: %s", response)
-	return response
+	return response, map[string]interface{}{"word_count": count}
+}
+
+// resolveText returns the text to process, reading the structured "text" parameter when present
+// Only legacy instructions without it fall back to parsing the instruction; the flag reports which path was used
+func (a *AINativeAgent) resolveText(instruction string, parameters map[string]interface{}) (string, bool) {
+	if text, ok := parameters["text"].(string); ok {
+		return text, true
+	}
+	return a.extractTextFromInstruction(instruction), false
 }
 
 // extractTextFromInstruction parses natural language to find text to process
@@ -260,212 +274,8 @@ func (a *AINativeAgent) analyzeText(text string) string {
 	return fmt.Sprintf("%d words, %d characters, %d letters", wordCount, charCount, letterCount)
 }
 
-// createCompletionMessage creates a completion message for the orchestrator
-func (a *AINativeAgent) createCompletionMessage(instructionID, correlationID, content string, success bool, errorMsg string) *pb.CompletionMessage {
-	completion := &pb.CompletionMessage{
-		CompletionId:  fmt.Sprintf("completion-%s-%d", a.config.AgentID, time.Now().Unix()),
-		CorrelationId: correlationID,
-		InstructionId: instructionID,
-		AgentId:       a.config.AgentID,
-		Success:       success,
-		Content:       content,
-		Timestamp:     timestamppb.Now(),
-	}
-
-	if !success {
-		completion.ErrorMessage = errorMsg
-	}
-
-	return completion
-}
-
-// Legacy heartbeat methods - DEPRECATED in favor of dedicated infrastructure processes
-// StartHeartbeat - DEPRECATED: Use StartInfrastructure() instead
+// StartHeartbeat - DEPRECATED: heartbeats are scheduled by the agent SDK once Start registers the agent
 func (a *AINativeAgent) StartHeartbeat(ctx context.Context, notificationChan chan<- bool) error {
-	log.Printf("⚠️ DEPRECATED: StartHeartbeat called - use StartInfrastructure() instead")
-	// For backward compatibility, start the infrastructure
-	return a.StartInfrastructure(ctx)
-}
-
-// Legacy heartbeat methods - REMOVED in favor of dedicated infrastructure processes
-
-// processConversationMessage handles ONLY AI conversation messages (instructions/completions)
-func (a *AINativeAgent) processConversationMessage(msg *pb.ConversationMessage) *pb.ConversationMessage {
-	log.Printf("📨 Processing AI conversation message: %s (type: %v)", msg.MessageId, msg.Type)
-
-	switch msg.Type {
-	case pb.MessageType_MESSAGE_TYPE_INSTRUCTION:
-		// Process the AI instruction and create a completion response
-		result := a.ProcessInstruction(msg.Content)
-
-		// Create completion message using existing method
-		completion := a.createCompletionMessage(msg.MessageId, msg.CorrelationId, result, true, "")
-
-		// Convert to conversation message format
-		return &pb.ConversationMessage{
-			MessageId:     completion.CompletionId,
-			CorrelationId: msg.CorrelationId,
-			FromId:        a.config.AgentID,
-			ToId:          "orchestrator",
-			Type:          pb.MessageType_MESSAGE_TYPE_COMPLETION,
-			Content:       completion.Content,
-			Context:       completion.ResultData,
-			Timestamp:     completion.Timestamp,
-		}
-
-	default:
-		log.Printf("⚠️ Unexpected message type in conversation stream: %v (infrastructure messages should use dedicated endpoints)", msg.Type)
-		return nil
-	}
-}
-
-// startConversationStream opens and maintains a PURE AI conversation stream
-func (a *AINativeAgent) startConversationStream(ctx context.Context) error {
-	log.Printf("🔄 Starting AI conversation stream for agent %s", a.config.AgentID)
-
-	// Create context with agent ID in metadata (no identification message needed!)
-	md := metadata.New(map[string]string{
-		"agent-id": a.config.AgentID,
-	})
-	streamCtx := metadata.NewOutgoingContext(ctx, md)
-
-	// Open conversation stream with agent ID in metadata
-	stream, err := a.client.OpenConversation(streamCtx)
-	if err != nil {
-		return fmt.Errorf("failed to open conversation stream: %v", err)
-	}
-
-	log.Printf("✅ AI conversation stream established for agent %s", a.config.AgentID)
-
-	// Listen ONLY for AI instruction messages (no identification message needed)
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				log.Printf("🛑 AI conversation stream context cancelled for agent %s", a.config.AgentID)
-				return
-			default:
-				// Receive AI instruction from orchestrator
-				msg, err := stream.Recv()
-				if err != nil {
-					log.Printf("❌ Error receiving AI message from stream: %v", err)
-					return
-				}
-
-				log.Printf("🧠 Received AI instruction: %s", msg.MessageId)
-
-				// Process the AI instruction
-				response := a.processConversationMessage(msg)
-				if response != nil {
-					// Send completion response back to AI
-					if err := stream.Send(response); err != nil {
-						log.Printf("❌ Failed to send AI response: %v", err)
-						return
-					}
-					log.Printf("🧠 Sent AI completion: %s", response.MessageId)
-				}
-			}
-		}
-	}()
-
+	log.Printf("⚠️ DEPRECATED: StartHeartbeat called - heartbeats are sent by the agent SDK after Start")
 	return nil
 }
-
-// StartInfrastructure starts all dedicated infrastructure processes
-func (a *AINativeAgent) StartInfrastructure(ctx context.Context) error {
-	log.Printf("🔧 Starting infrastructure processes for agent %s", a.config.AgentID)
-
-	// Start heartbeat process
-	if err := a.startHeartbeatProcess(ctx); err != nil {
-		return fmt.Errorf("failed to start heartbeat process: %w", err)
-	}
-
-	// Start status monitoring process
-	if err := a.startStatusProcess(ctx); err != nil {
-		return fmt.Errorf("failed to start status process: %w", err)
-	}
-
-	log.Printf("✅ All infrastructure processes started for agent %s", a.config.AgentID)
-	return nil
-}
-
-// startHeartbeatProcess starts a dedicated heartbeat process using the dedicated endpoint
-func (a *AINativeAgent) startHeartbeatProcess(ctx context.Context) error {
-	go func() {
-		ticker := time.NewTicker(30 * time.Second)
-		defer ticker.Stop()
-
-		log.Printf("💓 Starting dedicated heartbeat process for agent %s", a.config.AgentID)
-
-		// Send immediate first heartbeat
-		a.sendInfrastructureHeartbeat(ctx)
-
-		for {
-			select {
-			case <-ticker.C:
-				a.sendInfrastructureHeartbeat(ctx)
-			case <-ctx.Done():
-				log.Printf("💓 Heartbeat process stopped for agent %s", a.config.AgentID)
-				return
-			}
-		}
-	}()
-
-	return nil
-}
-
-// startStatusProcess starts a dedicated status update process
-func (a *AINativeAgent) startStatusProcess(ctx context.Context) error {
-	go func() {
-		log.Printf("🔧 Starting dedicated status process for agent %s", a.config.AgentID)
-
-		// Send initial status
-		a.sendStatusUpdate(ctx, pb.AgentStatus_AGENT_STATUS_HEALTHY)
-
-		// Listen for status changes (for now, just healthy)
-		// In the future, this could monitor agent health and send updates
-		<-ctx.Done()
-		log.Printf("🔧 Status process stopped for agent %s", a.config.AgentID)
-	}()
-
-	return nil
-}
-
-// sendInfrastructureHeartbeat sends heartbeat using dedicated Heartbeat endpoint
-func (a *AINativeAgent) sendInfrastructureHeartbeat(ctx context.Context) {
-	if a.client != nil {
-		heartbeatReq := &pb.HeartbeatRequest{
-			AgentId:   a.config.AgentID,
-			SessionId: a.sessionID,
-			Status:    pb.AgentStatus_AGENT_STATUS_HEALTHY,
-		}
-
-		_, err := a.client.Heartbeat(ctx, heartbeatReq)
-		if err != nil {
-			log.Printf("❌ Infrastructure heartbeat failed for agent %s: %v", a.config.AgentID, err)
-			return
-		}
-
-		log.Printf("💓 Infrastructure heartbeat sent for agent %s", a.config.AgentID)
-	}
-}
-
-// sendStatusUpdate sends status using dedicated UpdateAgentStatus endpoint
-func (a *AINativeAgent) sendStatusUpdate(ctx context.Context, status pb.AgentStatus) {
-	if a.client != nil {
-		statusReq := &pb.UpdateAgentStatusRequest{
-			AgentId:   a.config.AgentID,
-			SessionId: a.sessionID,
-			Status:    status,
-			Timestamp: timestamppb.Now(),
-		}
-
-		_, err := a.client.UpdateAgentStatus(ctx, statusReq)
-		if err != nil {
-			log.Printf("❌ Status update failed for agent %s: %v", a.config.AgentID, err)
-			return
-		}
-
-		log.Printf("🔧 Status update sent for agent %s: %v", a.config.AgentID, status)
-	}
-}
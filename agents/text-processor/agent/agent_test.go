@@ -5,7 +5,7 @@ import (
 	"testing"
 	"time"
 
-	pb "github.com/ztdp/agents/text-processor/proto/api"
+	"neuromesh/pkg/agentsdk"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -76,34 +76,100 @@ func TestAINativeAgent_ProcessInstruction(t *testing.T) {
 		assert.Contains(t, result, "2 words")
 	})
 
-	t.Run("should handle conversation stream messages", func(t *testing.T) {
-		// Test that the agent can process instruction messages from a conversation stream
-		// This tests the integration between stream message handling and instruction processing
-
-		instruction := `Count the words in "Hello world"`
-		expectedContent := `The text "Hello world" contains 2 words.`
-
-		// Create a mock conversation message
-		msg := &pb.ConversationMessage{
-			MessageId:     "test-msg-1",
-			CorrelationId: "test-corr-1",
-			FromId:        "orchestrator",
-			ToId:          agent.config.AgentID,
-			Type:          pb.MessageType_MESSAGE_TYPE_INSTRUCTION,
-			Content:       instruction,
-			Context:       nil,
+	t.Run("should handle instructions dispatched by the agent SDK", func(t *testing.T) {
+		// The SDK delivers stream instructions to HandleInstruction and replies with its result
+		result, err := agent.HandleInstruction(context.Background(), agentsdk.Instruction{
+			ID:            "test-msg-1",
+			CorrelationID: "test-corr-1",
+			Content:       `Count the words in "Hello world"`,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, `The text "Hello world" contains 2 words.`, result.Content)
+		assert.Equal(t, map[string]interface{}{"word_count": 2}, result.Data)
+	})
+
+	t.Run("should return structured data for each capability", func(t *testing.T) {
+		testCases := []struct {
+			instruction string
+			expected    map[string]interface{}
+		}{
+			{instruction: `Count the characters in "Hello"`, expected: map[string]interface{}{"character_count": 5}},
+			{instruction: `Analyze "Hello"`, expected: map[string]interface{}{"analysis_report": "1 words, 5 characters, 5 letters"}},
+			{instruction: `Detect the language of "Привет"`, expected: map[string]interface{}{"language": "ru", "language_name": "Russian", "confidence": 1.0}},
+			{instruction: `Run a sentiment analysis of "great"`, expected: map[string]interface{}{"sentiment": sentimentPositive, "sentiment_score": 1.0}},
 		}
 
-		// Process the message (this should call ProcessInstruction internally)
-		response := agent.processConversationMessage(msg)
+		for _, tc := range testCases {
+			result, err := agent.HandleInstruction(context.Background(), agentsdk.Instruction{Content: tc.instruction})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.Data, tc.instruction)
+		}
+	})
+}
+
+func TestAINativeAgent_HandleInstruction_RoutesOnAction(t *testing.T) {
+	agent := NewAINativeAgent(Config{
+		AgentID:             "test-agent",
+		Name:                "Test Agent",
+		OrchestratorAddress: "localhost:50051",
+	})
+
+	t.Run("runs the capability the step declares whatever the wording", func(t *testing.T) {
+		// The wording asks for a word count, but the plan step's action is the character count
+		result, err := agent.HandleInstruction(context.Background(), agentsdk.Instruction{
+			Content:    `Count the words in "Hello"`,
+			Parameters: map[string]interface{}{"text": "Hello"},
+			Context:    map[string]interface{}{"action": "character-count"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"character_count": 5}, result.Data)
+	})
+
+	t.Run("falls back to the wording for an unknown action", func(t *testing.T) {
+		result, err := agent.HandleInstruction(context.Background(), agentsdk.Instruction{
+			Content: `Run a sentiment analysis of "great"`,
+			Context: map[string]interface{}{"action": "summarize"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"sentiment": sentimentPositive, "sentiment_score": 1.0}, result.Data)
+	})
+}
+
+func TestAINativeAgent_ProcessInstructionWithParameters(t *testing.T) {
+	agent := NewAINativeAgent(Config{
+		AgentID:             "test-agent",
+		Name:                "Test Agent",
+		OrchestratorAddress: "localhost:50051",
+	})
+
+	// The quoted text in the instruction is a decoy the regex path would pick up
+	instruction := `Count the words in "decoy"`
+	parameters := map[string]interface{}{"text": "structured text with five words"}
+
+	t.Run("reads the text parameter without the regex fallback", func(t *testing.T) {
+		text, structured := agent.resolveText(instruction, parameters)
+		assert.True(t, structured, "structured parameters must not fall back to parsing the instruction")
+		assert.Equal(t, "structured text with five words", text)
+
+		response := agent.ProcessInstructionWithParameters(instruction, parameters)
+		assert.Equal(t, `The text "structured text with five words" contains 5 words.`, response)
+	})
 
-		// Verify the response is a completion message
-		assert.NotNil(t, response)
-		assert.Equal(t, pb.MessageType_MESSAGE_TYPE_COMPLETION, response.Type)
-		assert.Equal(t, agent.config.AgentID, response.FromId)
-		assert.Equal(t, "orchestrator", response.ToId)
-		assert.Equal(t, "test-corr-1", response.CorrelationId)
-		assert.Equal(t, expectedContent, response.Content)
+	t.Run("reads parameters of SDK instructions", func(t *testing.T) {
+		result, err := agent.HandleInstruction(context.Background(), agentsdk.Instruction{
+			ID:         "test-msg-2",
+			Content:    instruction,
+			Parameters: parameters,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `The text "structured text with five words" contains 5 words.`, result.Content)
+	})
+
+	t.Run("falls back to the instruction for legacy messages", func(t *testing.T) {
+		text, structured := agent.resolveText(instruction, nil)
+		assert.False(t, structured)
+		assert.Equal(t, "decoy", text)
 	})
 }
 
@@ -246,6 +312,77 @@ func TestAINativeAgent_AnalyzeText(t *testing.T) {
 	}
 }
 
+func TestAINativeAgent_DetectLanguage(t *testing.T) {
+	agent := NewAINativeAgent(Config{AgentID: "test-agent", Name: "Test Agent", OrchestratorAddress: "localhost:50051"})
+
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "English", text: "The weather is nice and it is warm in the garden", expected: "en"},
+		{name: "Spanish", text: "El perro está en la casa y es muy grande", expected: "es"},
+		{name: "French", text: "Je ne sais pas pourquoi le chat est dans la cuisine", expected: "fr"},
+		{name: "German", text: "Das ist nicht der Hund, den ich gesehen habe", expected: "de"},
+		{name: "Danish", text: "Jeg er glad for det og har det meget godt", expected: "da"},
+		{name: "Russian by script", text: "Привет, как дела?", expected: "ru"},
+		{name: "Japanese kanji with kana", text: "日本語を勉強しています", expected: "ja"},
+		{name: "Chinese by script", text: "你好世界", expected: "zh"},
+		{name: "no letters", text: "123 456!", expected: "und"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			detected, confidence := agent.detectLanguage(tc.text)
+			assert.Equal(t, tc.expected, detected.Code)
+			if tc.expected != "und" {
+				assert.Greater(t, confidence, 0.0)
+			}
+		})
+	}
+
+	t.Run("answers language instructions", func(t *testing.T) {
+		result := agent.ProcessInstructionWithParameters("Detect the language of the text", map[string]interface{}{"text": "Привет мир"})
+		assert.Equal(t, `The text "Привет мир" is written in Russian (ru, confidence 100%).`, result)
+	})
+}
+
+func TestAINativeAgent_AnalyzeSentiment(t *testing.T) {
+	agent := NewAINativeAgent(Config{AgentID: "test-agent", Name: "Test Agent", OrchestratorAddress: "localhost:50051"})
+
+	testCases := []struct {
+		name      string
+		text      string
+		sentiment string
+	}{
+		{name: "clearly positive", text: "I love this product, it is excellent and works great!", sentiment: sentimentPositive},
+		{name: "clearly negative", text: "This is the worst service, terrible and useless.", sentiment: sentimentNegative},
+		{name: "negated positive", text: "The support was not helpful", sentiment: sentimentNegative},
+		{name: "mixed evenly", text: "The food was good but the service was bad", sentiment: sentimentNeutral},
+		{name: "no sentiment words", text: "The meeting is on Tuesday", sentiment: sentimentNeutral},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sentiment, score := agent.analyzeSentiment(tc.text)
+			assert.Equal(t, tc.sentiment, sentiment)
+			switch tc.sentiment {
+			case sentimentPositive:
+				assert.Greater(t, score, 0.0)
+			case sentimentNegative:
+				assert.Less(t, score, 0.0)
+			default:
+				assert.Zero(t, score)
+			}
+		})
+	}
+
+	t.Run("answers sentiment analysis instructions", func(t *testing.T) {
+		result := agent.ProcessInstruction(`Run a sentiment analysis of "What a wonderful day"`)
+		assert.Equal(t, `The sentiment of "What a wonderful day" is positive (score 1.00).`, result)
+	})
+}
+
 func TestAINativeAgent_GetCapabilities(t *testing.T) {
 	config := Config{
 		AgentID:             "test-agent",
@@ -254,9 +391,9 @@ func TestAINativeAgent_GetCapabilities(t *testing.T) {
 	}
 	agent := NewAINativeAgent(config)
 
-	capabilities := agent.getCapabilities()
+	capabilities := agent.Capabilities()
 
-	require.Len(t, capabilities, 3)
+	require.Len(t, capabilities, 5)
 
 	// Check that we have the expected capabilities
 	capabilityNames := make([]string, len(capabilities))
@@ -267,6 +404,8 @@ func TestAINativeAgent_GetCapabilities(t *testing.T) {
 	assert.Contains(t, capabilityNames, "word-count")
 	assert.Contains(t, capabilityNames, "text-analysis")
 	assert.Contains(t, capabilityNames, "character-count")
+	assert.Contains(t, capabilityNames, "detect-language")
+	assert.Contains(t, capabilityNames, "sentiment-analysis")
 
 	// Check descriptions are present
 	for _, cap := range capabilities {
@@ -289,8 +428,8 @@ func TestNewAINativeAgent(t *testing.T) {
 	assert.Equal(t, config.AgentID, agent.config.AgentID)
 	assert.Equal(t, config.Name, agent.config.Name)
 	assert.Equal(t, config.OrchestratorAddress, agent.config.OrchestratorAddress)
-	assert.False(t, agent.registered)
-	assert.Empty(t, agent.sessionID)
+	assert.Equal(t, config.AgentID, agent.sdk.Config().AgentID)
+	assert.Empty(t, agent.sdk.SessionID(), "the agent is not registered before Start")
 }
 
 // TDD RED: Test for agent heartbeat functionality
@@ -0,0 +1,108 @@
+package agent
+
+import (
+	"strings"
+	"unicode"
+)
+
+// language identifies a detected language by ISO 639-1 code and English name
+type language struct {
+	Code string
+	Name string
+}
+
+// unknownLanguage is reported when the text gives no usable signal
+var unknownLanguage = language{Code: "und", Name: "Unknown"}
+
+// scriptLanguages maps scripts that identify a language on their own
+// Kana is checked before Han so Japanese text mixing kanji and kana is not reported as Chinese
+var scriptLanguages = []struct {
+	script   *unicode.RangeTable
+	language language
+}{
+	{unicode.Hiragana, language{"ja", "Japanese"}},
+	{unicode.Katakana, language{"ja", "Japanese"}},
+	{unicode.Hangul, language{"ko", "Korean"}},
+	{unicode.Han, language{"zh", "Chinese"}},
+	{unicode.Cyrillic, language{"ru", "Russian"}},
+	{unicode.Greek, language{"el", "Greek"}},
+	{unicode.Arabic, language{"ar", "Arabic"}},
+	{unicode.Hebrew, language{"he", "Hebrew"}},
+	{unicode.Devanagari, language{"hi", "Hindi"}},
+	{unicode.Thai, language{"th", "Thai"}},
+}
+
+// stopwords lists frequent function words of languages written in Latin script
+var stopwords = map[language][]string{
+	{"en", "English"}:    {"the", "and", "is", "are", "of", "to", "in", "it", "that", "this", "with", "for", "was", "you", "not", "have"},
+	{"es", "Spanish"}:    {"el", "la", "los", "las", "y", "es", "de", "que", "en", "un", "una", "por", "con", "para", "está", "muy"},
+	{"fr", "French"}:     {"le", "la", "les", "et", "est", "de", "des", "que", "un", "une", "dans", "pour", "pas", "avec", "je", "très"},
+	{"de", "German"}:     {"der", "die", "das", "und", "ist", "nicht", "ein", "eine", "ich", "mit", "zu", "den", "von", "auf", "sehr", "sind"},
+	{"it", "Italian"}:    {"il", "la", "gli", "e", "è", "di", "che", "un", "una", "per", "non", "con", "sono", "molto", "della", "questo"},
+	{"pt", "Portuguese"}: {"o", "a", "os", "as", "e", "é", "de", "que", "um", "uma", "não", "com", "para", "em", "muito", "isso"},
+	{"nl", "Dutch"}:      {"de", "het", "een", "en", "is", "van", "niet", "dat", "ik", "met", "zijn", "voor", "op", "ook", "heel", "maar"},
+	{"da", "Danish"}:     {"og", "er", "det", "en", "et", "ikke", "jeg", "at", "til", "med", "på", "af", "den", "har", "meget", "som"},
+}
+
+// detectLanguage identifies the language of text from its script, falling back to stopword frequency for Latin script
+// The second result is the share of the evidence that supports the detected language
+func (a *AINativeAgent) detectLanguage(text string) (language, float64) {
+	scriptCounts := make(map[language]int)
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		for _, candidate := range scriptLanguages {
+			if unicode.Is(candidate.script, r) {
+				scriptCounts[candidate.language]++
+				break
+			}
+		}
+	}
+	if letters == 0 {
+		return unknownLanguage, 0
+	}
+
+	// Any kana makes the text Japanese, whatever share of it is kanji
+	if scriptCounts[language{"ja", "Japanese"}] > 0 {
+		return language{"ja", "Japanese"}, 1
+	}
+	for _, candidate := range scriptLanguages {
+		if count := scriptCounts[candidate.language]; count*2 > letters {
+			return candidate.language, float64(count) / float64(letters)
+		}
+	}
+
+	return detectLatinLanguage(text)
+}
+
+// detectLatinLanguage picks the language whose stopwords occur most often in text
+func detectLatinLanguage(text string) (language, float64) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+
+	best, bestHits, totalHits := unknownLanguage, 0, 0
+	for candidate, candidateStopwords := range stopwords {
+		hits := 0
+		for _, word := range words {
+			for _, stopword := range candidateStopwords {
+				if word == stopword {
+					hits++
+					break
+				}
+			}
+		}
+		totalHits += hits
+		// Ties are broken by code so detection does not depend on map order
+		if hits > bestHits || (hits == bestHits && hits > 0 && candidate.Code < best.Code) {
+			best, bestHits = candidate, hits
+		}
+	}
+	if bestHits == 0 {
+		return unknownLanguage, 0
+	}
+	return best, float64(bestHits) / float64(totalHits)
+}
@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Sentiment labels reported by sentiment analysis
+const (
+	sentimentPositive = "positive"
+	sentimentNegative = "negative"
+	sentimentNeutral  = "neutral"
+)
+
+// sentimentLexicon scores English words that carry a clear positive or negative sentiment
+var sentimentLexicon = map[string]int{
+	"good": 1, "great": 2, "excellent": 2, "amazing": 2, "wonderful": 2, "fantastic": 2, "love": 2, "loved": 2,
+	"like": 1, "liked": 1, "happy": 1, "glad": 1, "nice": 1, "best": 2, "awesome": 2, "enjoy": 1, "enjoyed": 1,
+	"pleased": 1, "perfect": 2, "helpful": 1, "beautiful": 1, "recommend": 1, "fast": 1, "easy": 1,
+	"bad": -1, "terrible": -2, "awful": -2, "horrible": -2, "hate": -2, "hated": -2, "worst": -2, "poor": -1,
+	"sad": -1, "angry": -1, "disappointed": -2, "disappointing": -2, "broken": -1, "useless": -2, "slow": -1,
+	"annoying": -1, "ugly": -1, "wrong": -1, "fail": -1, "failed": -1, "problem": -1, "difficult": -1,
+}
+
+// sentimentNegators invert the sentiment of the word that follows them
+var sentimentNegators = map[string]bool{
+	"not": true, "no": true, "never": true, "don't": true, "doesn't": true, "didn't": true, "isn't": true, "wasn't": true,
+}
+
+// analyzeSentiment scores text from -1 (negative) to 1 (positive) using the sentiment lexicon
+// Words without sentiment are ignored, so text without any lexicon words is neutral
+func (a *AINativeAgent) analyzeSentiment(text string) (string, float64) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+
+	score, weight := 0, 0
+	negated := false
+	for _, word := range words {
+		if sentimentNegators[word] {
+			negated = true
+			continue
+		}
+		value, ok := sentimentLexicon[word]
+		if !ok {
+			continue
+		}
+		if negated {
+			value = -value
+			negated = false
+		}
+		score += value
+		weight += max(value, -value)
+	}
+
+	if weight == 0 {
+		return sentimentNeutral, 0
+	}
+	normalized := float64(score) / float64(weight)
+	switch {
+	case normalized > 0:
+		return sentimentPositive, normalized
+	case normalized < 0:
+		return sentimentNegative, normalized
+	default:
+		return sentimentNeutral, 0
+	}
+}
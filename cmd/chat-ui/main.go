@@ -1,19 +1,35 @@
 package main
 
 import (
-	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
 	"io"
 	"log"
+	"mime"
+	"net"
 	"net/http"
-	"time"
+	"net/url"
+	"os"
+	"strings"
 )
 
 // ChatServer handles HTTP requests and makes API calls to WebBFF
 type ChatServer struct {
-	webBFFURL string
+	webBFF *webBFFClient
+}
+
+// NewChatServer creates a chat server that calls the WebBFF at webBFFURL
+func NewChatServer(webBFFURL string) *ChatServer {
+	return &ChatServer{
+		webBFF: newWebBFFClient(webBFFURL),
+	}
+}
+
+// SetAuthToken authenticates every WebBFF call with token as a bearer token
+func (cs *ChatServer) SetAuthToken(token string) {
+	cs.webBFF.authToken = token
 }
 
 // ChatRequest represents the request to WebBFF API
@@ -33,13 +49,17 @@ type ChatResponse struct {
 
 func main() {
 	// 🎯 REFACTORED: Chat UI as standalone service that calls WebBFF API
-	chatServer := &ChatServer{
-		webBFFURL: "http://localhost:8081", // WebBFF API URL
+	chatServer := NewChatServer("http://localhost:8081") // WebBFF API URL
+	if token := os.Getenv("WEBBFF_AUTH_TOKEN"); token != "" {
+		chatServer.SetAuthToken(token)
 	}
 
 	// Setup routes
 	http.HandleFunc("/", chatServer.handleHome)
 	http.HandleFunc("/conversation", chatServer.handleConversation)
+	http.HandleFunc("/conversation/stream", chatServer.handleConversationStream)
+	http.HandleFunc("/conversation/history", chatServer.handleConversationHistory)
+	http.HandleFunc("/session", chatServer.handleSession)
 
 	fmt.Println("🚀 AI Orchestrator Chat UI starting on http://localhost:8080")
 	fmt.Println("🌐 Connecting to WebBFF API at http://localhost:8081")
@@ -69,6 +89,7 @@ func (cs *ChatServer) handleHome(w http.ResponseWriter, r *http.Request) {
         .message-header { font-size: 12px; color: #666; margin-bottom: 8px; font-weight: bold; }
         .message-content { line-height: 1.5; white-space: pre-wrap; }
         .typing { color: #2563eb; font-style: italic; }
+        .intent-badge { display: inline-block; margin-left: 8px; padding: 2px 8px; background: #ede9fe; color: #6d28d9; border-radius: 10px; font-size: 11px; font-weight: normal; }
         .input-container { padding: 20px; background: #f8f9fa; border-top: 1px solid #eee; }
         .input-group { display: flex; gap: 10px; }
         .message-input { flex: 1; padding: 12px; border: 1px solid #ddd; border-radius: 5px; font-size: 16px; }
@@ -134,13 +155,25 @@ What would you like to do today?</div>
     </div>
 
     <script>
-        let conversationId = 'web-user-' + Date.now();
+        // Keep the session across page loads so the WebBFF resolves the same durable conversation
+        // New session IDs are issued by the WebBFF so they cannot be guessed
+        let conversationId = localStorage.getItem('conversationId');
+        const sessionReady = conversationId ? Promise.resolve(conversationId) : fetch('/session', { method: 'POST' })
+            .then(function(response) {
+                if (!response.ok) throw new Error('Failed to start session: ' + response.statusText);
+                return response.json();
+            })
+            .then(function(session) {
+                conversationId = session.session_id;
+                localStorage.setItem('conversationId', conversationId);
+                return conversationId;
+            });
         
         function setMessage(text) {
             document.getElementById('messageInput').value = text;
         }
 
-        function addMessage(type, content, sender = '') {
+        function addMessage(type, content, sender = '', intent = '') {
             const chatContainer = document.getElementById('chatContainer');
             const messageDiv = document.createElement('div');
             messageDiv.className = 'message ' + type + '-message';
@@ -148,6 +181,13 @@ What would you like to do today?</div>
             const headerDiv = document.createElement('div');
             headerDiv.className = 'message-header';
             headerDiv.textContent = sender || (type === 'user' ? '👤 You' : '🤖 AI Orchestrator');
+            if (intent) {
+                // Show what the AI understood the request to be
+                const badge = document.createElement('span');
+                badge.className = 'intent-badge';
+                badge.textContent = intent;
+                headerDiv.appendChild(badge);
+            }
             
             const contentDiv = document.createElement('div');
             contentDiv.className = 'message-content';
@@ -194,7 +234,8 @@ What would you like to do today?</div>
             messageInput.value = '';
 
             try {
-                const response = await fetch('/conversation', {
+                await sessionReady;
+                const response = await fetch('/conversation/stream', {
                     method: 'POST',
                     headers: {
                         'Content-Type': 'application/x-www-form-urlencoded',
@@ -206,13 +247,25 @@ What would you like to do today?</div>
                     throw new Error('Failed to send message: ' + response.statusText);
                 }
 
-                const result = await response.text();
-                
-                // Remove thinking message
-                thinkingMsg.remove();
-                
-                // Add AI response
-                addMessage('ai', result);
+                // Append progress live as the orchestrator streams Server-Sent Events
+                const progressContent = thinkingMsg.querySelector('.message-content');
+                progressContent.textContent = '';
+                const result = await readEventStream(response, function(eventName, data) {
+                    const line = describeProgress(eventName, data);
+                    if (line) {
+                        progressContent.textContent += line + '\n';
+                        document.getElementById('chatContainer').scrollTop = document.getElementById('chatContainer').scrollHeight;
+                    }
+                });
+
+                // Keep progress trail, then add the final AI response
+                thinkingMsg.classList.remove('typing');
+                thinkingMsg.querySelector('.message-header').textContent = '⚙️ Orchestration progress';
+                if (result && result.error) {
+                    addMessage('system', 'Error: ' + result.error);
+                } else {
+                    addMessage('ai', result ? result.content : 'No response received', '', result ? result.intent : '');
+                }
                 
                 setStatus('✅ Connected to AI orchestrator', 'connected');
                 
@@ -231,9 +284,71 @@ What would you like to do today?</div>
             }
         }
         
-        // Focus input on load
+        // readEventStream parses a text/event-stream body, reporting progress events and returning the final response
+        async function readEventStream(response, onProgress) {
+            const reader = response.body.getReader();
+            const decoder = new TextDecoder();
+            let buffer = '';
+            let finalResponse = null;
+
+            while (true) {
+                const { value, done } = await reader.read();
+                if (done) break;
+                buffer += decoder.decode(value, { stream: true });
+
+                let boundary;
+                while ((boundary = buffer.indexOf('\n\n')) >= 0) {
+                    const block = buffer.slice(0, boundary);
+                    buffer = buffer.slice(boundary + 2);
+
+                    let eventName = 'message';
+                    let data = '';
+                    block.split('\n').forEach(function(line) {
+                        if (line.startsWith('event: ')) eventName = line.slice(7);
+                        if (line.startsWith('data: ')) data += line.slice(6);
+                    });
+                    const payload = data ? JSON.parse(data) : {};
+
+                    if (eventName === 'response' || eventName === 'error') {
+                        finalResponse = payload;
+                    } else {
+                        onProgress(eventName, payload);
+                    }
+                }
+            }
+
+            return finalResponse;
+        }
+
+        function describeProgress(eventName, data) {
+            switch (eventName) {
+                case 'decision_made': return '🧠 Decision: ' + data.message;
+                case 'agent_event_sent': return '📤 Sent to ' + data.agent_id + ': ' + data.message;
+                case 'agent_responded': return '📥 ' + data.agent_id + ' responded: ' + data.message;
+                case 'final_answer': return '✅ Final answer ready';
+                default: return '';
+            }
+        }
+
+        // loadHistory restores the messages of the session's conversation after a reload
+        async function loadHistory() {
+            if (!conversationId) return;
+            try {
+                const response = await fetch('/conversation/history?conversation_id=' + encodeURIComponent(conversationId));
+                if (!response.ok) return;
+                const history = await response.json();
+                (history.messages || []).forEach(function(msg) {
+                    addMessage(msg.role === 'user' ? 'user' : 'ai', msg.content);
+                });
+            } catch (error) {
+                console.log('Could not load conversation history:', error);
+            }
+        }
+
+        // Focus input and restore history on load
         window.onload = function() {
             document.getElementById('messageInput').focus();
+            loadHistory();
         };
     </script>
 </body>
@@ -246,7 +361,7 @@ What would you like to do today?</div>
 // handleConversation handles real-time conversation via WebBFF API
 func (cs *ChatServer) handleConversation(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		writeConversationError(w, r, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
@@ -254,12 +369,18 @@ func (cs *ChatServer) handleConversation(w http.ResponseWriter, r *http.Request)
 	conversationID := r.FormValue("conversation_id")
 
 	if message == "" {
-		http.Error(w, "Message is required", http.StatusBadRequest)
+		writeConversationError(w, r, "Message is required", http.StatusBadRequest)
 		return
 	}
 
 	if conversationID == "" {
-		conversationID = fmt.Sprintf("web-user-%d", time.Now().UnixNano())
+		sessionID, err := cs.newSessionID(r)
+		if err != nil {
+			log.Printf("❌ Failed to start WebBFF session: %v", err)
+			writeConversationError(w, r, "Failed to connect to AI service", http.StatusInternalServerError)
+			return
+		}
+		conversationID = sessionID
 	}
 
 	log.Printf("🔄 Processing message via WebBFF API: %s (session: %s)", message, conversationID)
@@ -274,15 +395,25 @@ func (cs *ChatServer) handleConversation(w http.ResponseWriter, r *http.Request)
 	jsonData, err := json.Marshal(chatReq)
 	if err != nil {
 		log.Printf("❌ Failed to marshal request: %v", err)
-		http.Error(w, "Failed to process request", http.StatusInternalServerError)
+		writeConversationError(w, r, "Failed to process request", http.StatusInternalServerError)
 		return
 	}
 
 	// Make HTTP request to WebBFF
-	resp, err := http.Post(cs.webBFFURL+"/api/chat", "application/json", bytes.NewBuffer(jsonData))
+	resp, err := cs.webBFF.postJSON(r.Context(), "/api/chat", jsonData)
+	if errors.Is(err, errCircuitOpen) {
+		log.Printf("⚠️ WebBFF circuit open, failing fast")
+		writeConversationError(w, r, "The AI service is temporarily unavailable. Please try again in a moment.", http.StatusServiceUnavailable)
+		return
+	}
 	if err != nil {
 		log.Printf("❌ WebBFF API call failed: %v", err)
-		http.Error(w, "Failed to connect to AI service", http.StatusInternalServerError)
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			writeConversationError(w, r, "The AI service took too long to respond. Please try again.", http.StatusGatewayTimeout)
+			return
+		}
+		writeConversationError(w, r, "Failed to connect to AI service", http.StatusInternalServerError)
 		return
 	}
 	defer resp.Body.Close()
@@ -291,14 +422,14 @@ func (cs *ChatServer) handleConversation(w http.ResponseWriter, r *http.Request)
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		log.Printf("❌ Failed to read WebBFF response: %v", err)
-		http.Error(w, "Failed to read AI response", http.StatusInternalServerError)
+		writeConversationError(w, r, "Failed to read AI response", http.StatusInternalServerError)
 		return
 	}
 
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
 		log.Printf("❌ WebBFF API returned status %d: %s", resp.StatusCode, string(body))
-		http.Error(w, "AI service error", http.StatusInternalServerError)
+		writeConversationError(w, r, "AI service error", http.StatusInternalServerError)
 		return
 	}
 
@@ -306,13 +437,197 @@ func (cs *ChatServer) handleConversation(w http.ResponseWriter, r *http.Request)
 	var chatResp ChatResponse
 	if err := json.Unmarshal(body, &chatResp); err != nil {
 		log.Printf("❌ Failed to parse WebBFF response: %v", err)
-		http.Error(w, "Failed to parse AI response", http.StatusInternalServerError)
+		writeConversationError(w, r, "Failed to parse AI response", http.StatusInternalServerError)
 		return
 	}
 
 	log.Printf("✅ WebBFF response: %s", chatResp.Content)
 
+	// JSON clients get the full response, including the intent and any error
+	if acceptsJSON(r) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(chatResp)
+		return
+	}
+
 	// Return the AI response
 	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 	fmt.Fprint(w, chatResp.Content)
 }
+
+// acceptsJSON reports whether the client asked for a JSON response in its Accept header
+func acceptsJSON(r *http.Request) bool {
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && mediaType == "application/json" {
+			return true
+		}
+	}
+	return false
+}
+
+// writeConversationError reports a failure as a ChatResponse for JSON clients and as plain text otherwise
+func writeConversationError(w http.ResponseWriter, r *http.Request, message string, status int) {
+	if !acceptsJSON(r) {
+		http.Error(w, message, status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ChatResponse{Success: false, Error: message})
+}
+
+// handleConversationStream proxies the WebBFF Server-Sent Events stream so the browser sees progress live
+func (cs *ChatServer) handleConversationStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	message := r.FormValue("message")
+	conversationID := r.FormValue("conversation_id")
+
+	if message == "" {
+		http.Error(w, "Message is required", http.StatusBadRequest)
+		return
+	}
+
+	if conversationID == "" {
+		sessionID, err := cs.newSessionID(r)
+		if err != nil {
+			log.Printf("❌ Failed to start WebBFF session: %v", err)
+			http.Error(w, "Failed to connect to AI service", http.StatusInternalServerError)
+			return
+		}
+		conversationID = sessionID
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("🔄 Streaming message via WebBFF API: %s (session: %s)", message, conversationID)
+
+	jsonData, err := json.Marshal(ChatRequest{
+		SessionID: conversationID,
+		Message:   message,
+	})
+	if err != nil {
+		log.Printf("❌ Failed to marshal request: %v", err)
+		http.Error(w, "Failed to process request", http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := cs.webBFF.stream(r.Context(), "/api/chat/stream", jsonData)
+	if err != nil {
+		log.Printf("❌ WebBFF stream call failed: %v", err)
+		http.Error(w, "Failed to connect to AI service", http.StatusInternalServerError)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		log.Printf("❌ WebBFF stream API returned status %d: %s", resp.StatusCode, string(body))
+		http.Error(w, "AI service error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	// Relay each chunk as soon as it arrives
+	buf := make([]byte, 4096)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			if _, err := w.Write(buf[:n]); err != nil {
+				log.Printf("❌ Failed to relay stream to browser: %v", err)
+				return
+			}
+			flusher.Flush()
+		}
+		if readErr != nil {
+			if readErr != io.EOF {
+				log.Printf("❌ Failed to read WebBFF stream: %v", readErr)
+			}
+			return
+		}
+	}
+}
+
+// handleConversationHistory proxies the WebBFF session conversation so the browser can restore it after a reload
+func (cs *ChatServer) handleConversationHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	conversationID := r.URL.Query().Get("conversation_id")
+	if conversationID == "" {
+		http.Error(w, "conversation_id is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := cs.webBFF.get(r.Context(), "/api/sessions/"+url.PathEscape(conversationID)+"/conversation")
+	if err != nil {
+		log.Printf("❌ WebBFF history call failed: %v", err)
+		http.Error(w, "Failed to connect to AI service", http.StatusInternalServerError)
+		return
+	}
+	defer resp.Body.Close()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.StatusCode)
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		log.Printf("❌ Failed to relay conversation history: %v", err)
+	}
+}
+
+// handleSession asks the WebBFF for a new session ID so the browser never picks a guessable one
+func (cs *ChatServer) handleSession(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sessionID, err := cs.newSessionID(r)
+	if err != nil {
+		log.Printf("❌ Failed to start WebBFF session: %v", err)
+		http.Error(w, "Failed to connect to AI service", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(SessionResponse{SessionID: sessionID})
+}
+
+// SessionResponse carries a session ID issued by the WebBFF
+type SessionResponse struct {
+	SessionID string `json:"session_id"`
+}
+
+// newSessionID creates a session in the WebBFF and returns its ID
+func (cs *ChatServer) newSessionID(r *http.Request) (string, error) {
+	resp, err := cs.webBFF.postJSON(r.Context(), "/api/sessions", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("webbff returned status %d", resp.StatusCode)
+	}
+	var session SessionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return "", fmt.Errorf("failed to decode session: %w", err)
+	}
+	if session.SessionID == "" {
+		return "", errors.New("webbff returned an empty session ID")
+	}
+	return session.SessionID, nil
+}
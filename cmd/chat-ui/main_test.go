@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// newTestChatServer points a chat server at a fake WebBFF that answers every chat with a deployment reply
+func newTestChatServer(t *testing.T) *ChatServer {
+	webBFF := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ChatResponse{
+			Success:   true,
+			Content:   "Deploying the app now.",
+			SessionID: "session-1",
+			Intent:    "deployment",
+		})
+	}))
+	t.Cleanup(webBFF.Close)
+	return NewChatServer(webBFF.URL)
+}
+
+func conversationRequest(accept string) *http.Request {
+	form := url.Values{"message": {"Deploy the app"}, "conversation_id": {"session-1"}}
+	req := httptest.NewRequest(http.MethodPost, "/conversation", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	return req
+}
+
+func TestHandleConversation_ReturnsJSONWhenAccepted(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestChatServer(t).handleConversation(rec, conversationRequest("text/html, application/json;q=0.9"))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, expected 200", rec.Code)
+	}
+	if contentType := rec.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Content-Type = %q, expected application/json", contentType)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	expected := map[string]interface{}{
+		"success":    true,
+		"content":    "Deploying the app now.",
+		"session_id": "session-1",
+		"intent":     "deployment",
+	}
+	for key, value := range expected {
+		if body[key] != value {
+			t.Errorf("%s = %v, expected %v", key, body[key], value)
+		}
+	}
+	if _, ok := body["error"]; ok {
+		t.Errorf("successful response carries an error field: %v", body["error"])
+	}
+}
+
+func TestHandleConversation_ReturnsTextForFormPosts(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestChatServer(t).handleConversation(rec, conversationRequest(""))
+
+	if contentType := rec.Header().Get("Content-Type"); contentType != "text/plain; charset=utf-8" {
+		t.Errorf("Content-Type = %q, expected text/plain", contentType)
+	}
+	if rec.Body.String() != "Deploying the app now." {
+		t.Errorf("body = %q, expected the reply text", rec.Body.String())
+	}
+}
+
+func TestHandleConversation_ReportsJSONErrors(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/conversation", strings.NewReader(""))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	rec := httptest.NewRecorder()
+
+	newTestChatServer(t).handleConversation(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, expected 400", rec.Code)
+	}
+	var body ChatResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("error response is not JSON: %v", err)
+	}
+	if body.Success || body.Error != "Message is required" {
+		t.Errorf("body = %+v, expected a failed response explaining the missing message", body)
+	}
+}
+
+func TestHandleSession_UsesWebBFFIssuedSessionID(t *testing.T) {
+	var authorization string
+	webBFF := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/sessions" {
+			t.Errorf("unexpected WebBFF call %s %s", r.Method, r.URL.Path)
+		}
+		authorization = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(SessionResponse{SessionID: "3f6c1a2e-issued"})
+	}))
+	defer webBFF.Close()
+
+	chatServer := NewChatServer(webBFF.URL)
+	chatServer.SetAuthToken("ui-token")
+	rec := httptest.NewRecorder()
+	chatServer.handleSession(rec, httptest.NewRequest(http.MethodPost, "/session", nil))
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, expected 201", rec.Code)
+	}
+	var session SessionResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &session); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	if session.SessionID != "3f6c1a2e-issued" {
+		t.Errorf("session_id = %q, expected the WebBFF-issued ID", session.SessionID)
+	}
+	if authorization != "Bearer ui-token" {
+		t.Errorf("Authorization = %q, expected the configured bearer token", authorization)
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultWebBFFTimeout bounds one call to the WebBFF, which waits for the AI to answer
+	defaultWebBFFTimeout = 60 * time.Second
+
+	// defaultWebBFFRetries is how many times a 502 or 503 from the WebBFF is retried
+	defaultWebBFFRetries = 2
+
+	// defaultWebBFFRetryDelay is the pause before the first retry; later retries wait proportionally longer
+	defaultWebBFFRetryDelay = 200 * time.Millisecond
+
+	// defaultBreakerThreshold is how many failed calls in a row open the circuit
+	defaultBreakerThreshold = 5
+
+	// defaultBreakerCooldown is how long an open circuit fast-fails before the WebBFF is tried again
+	defaultBreakerCooldown = 30 * time.Second
+)
+
+// errCircuitOpen is returned without calling the WebBFF while it is considered down
+var errCircuitOpen = errors.New("webbff circuit open")
+
+// webBFFClient calls the WebBFF with a timeout, retries gateway errors and stops calling while it keeps failing
+type webBFFClient struct {
+	baseURL      string
+	httpClient   *http.Client
+	streamClient *http.Client // bounds the wait for response headers only, so a long stream is not cut off
+	maxRetries   int
+	retryDelay   time.Duration
+	breaker      *circuitBreaker
+	authToken    string // Bearer token sent with every call when set
+}
+
+// newWebBFFClient creates a client for the WebBFF at baseURL with the default timeout, retries and breaker
+func newWebBFFClient(baseURL string) *webBFFClient {
+	return &webBFFClient{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: defaultWebBFFTimeout},
+		streamClient: &http.Client{Transport: &http.Transport{
+			Proxy:                 http.ProxyFromEnvironment,
+			ResponseHeaderTimeout: defaultWebBFFTimeout,
+		}},
+		maxRetries: defaultWebBFFRetries,
+		retryDelay: defaultWebBFFRetryDelay,
+		breaker:    newCircuitBreaker(defaultBreakerThreshold, defaultBreakerCooldown),
+	}
+}
+
+// get fetches the WebBFF path without retrying; failures count against the circuit breaker
+func (c *webBFFClient) get(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.send(c.httpClient, req)
+}
+
+// stream posts body to a WebBFF streaming path and returns as soon as the response headers arrive
+func (c *webBFFClient) stream(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	return c.send(c.streamClient, req)
+}
+
+// send makes a single call through the circuit breaker
+func (c *webBFFClient) send(client *http.Client, req *http.Request) (*http.Response, error) {
+	if !c.breaker.allow() {
+		return nil, errCircuitOpen
+	}
+	c.authorize(req)
+
+	resp, err := client.Do(req)
+	if err != nil || resp.StatusCode >= http.StatusInternalServerError {
+		c.breaker.recordFailure()
+	} else {
+		c.breaker.recordSuccess()
+	}
+	return resp, err
+}
+
+// postJSON posts body to the WebBFF path, retrying 502 and 503 responses
+// Transport errors, timeouts and 5xx responses count against the circuit breaker
+func (c *webBFFClient) postJSON(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	if !c.breaker.allow() {
+		return nil, errCircuitOpen
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		c.authorize(req)
+
+		resp, err := c.httpClient.Do(req)
+		if err == nil && isRetryableStatus(resp.StatusCode) && attempt < c.maxRetries {
+			resp.Body.Close()
+			select {
+			case <-time.After(c.retryDelay * time.Duration(attempt+1)):
+				continue
+			case <-ctx.Done():
+				c.breaker.recordFailure()
+				return nil, ctx.Err()
+			}
+		}
+
+		if err != nil || resp.StatusCode >= http.StatusInternalServerError {
+			c.breaker.recordFailure()
+		} else {
+			c.breaker.recordSuccess()
+		}
+		return resp, err
+	}
+}
+
+// authorize adds the configured bearer token to a WebBFF request
+func (c *webBFFClient) authorize(req *http.Request) {
+	if c.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+}
+
+// isRetryableStatus reports whether a WebBFF status is a transient gateway error worth retrying
+func isRetryableStatus(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable
+}
+
+// circuitBreaker opens after consecutive failures and lets a single trial call through once the cooldown has passed
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool // a trial call is in flight; everyone else keeps fast-failing until it reports back
+}
+
+// newCircuitBreaker creates a closed breaker that opens after threshold failures in a row
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow reports whether a call may be made; it is false while the circuit is open
+// After the cooldown only the first caller is let through as the trial call
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.probing || b.now().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// recordSuccess closes the circuit
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.probing = false
+}
+
+// recordFailure counts a failed call, opening the circuit for the cooldown once the threshold is reached
+// A failed trial call after the cooldown opens it again straight away
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.probing = false
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeWebBFF is a WebBFF whose behaviour the test switches between slow, flaky and healthy
+type fakeWebBFF struct {
+	mu           sync.Mutex
+	slow         bool
+	failuresLeft int
+	calls        int
+}
+
+func (f *fakeWebBFF) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.calls++
+	slow := f.slow
+	fail := f.failuresLeft > 0
+	if fail {
+		f.failuresLeft--
+	}
+	f.mu.Unlock()
+
+	if slow {
+		select {
+		case <-time.After(300 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if fail {
+		http.Error(w, "upstream unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	json.NewEncoder(w).Encode(ChatResponse{Success: true, Content: "All good.", SessionID: "session-1"})
+}
+
+func (f *fakeWebBFF) set(slow bool, failures int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.slow = slow
+	f.failuresLeft = failures
+}
+
+func (f *fakeWebBFF) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+// newResilienceTestServer wires a chat server to the fake WebBFF with short timeouts and a controllable clock
+func newResilienceTestServer(t *testing.T, fake *fakeWebBFF, now *time.Time) *ChatServer {
+	webBFF := httptest.NewServer(fake)
+	t.Cleanup(webBFF.Close)
+
+	cs := NewChatServer(webBFF.URL)
+	cs.webBFF.httpClient.Timeout = 50 * time.Millisecond
+	cs.webBFF.retryDelay = time.Millisecond
+	cs.webBFF.breaker = newCircuitBreaker(2, time.Minute)
+	cs.webBFF.breaker.now = func() time.Time { return *now }
+	return cs
+}
+
+func converse(cs *ChatServer) (int, ChatResponse) {
+	rec := httptest.NewRecorder()
+	cs.handleConversation(rec, conversationRequest("application/json"))
+	var body ChatResponse
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	return rec.Code, body
+}
+
+func TestHandleConversation_SurvivesSlowThenFlakyThenHealthyWebBFF(t *testing.T) {
+	now := time.Now()
+	fake := &fakeWebBFF{}
+	cs := newResilienceTestServer(t, fake, &now)
+
+	// Slow: each call is cut off by the client timeout instead of hanging the browser
+	fake.set(true, 0)
+	for i := 0; i < 2; i++ {
+		started := time.Now()
+		code, _ := converse(cs)
+		if code != http.StatusGatewayTimeout {
+			t.Fatalf("slow call %d: status = %d, expected 504", i, code)
+		}
+		if elapsed := time.Since(started); elapsed > 250*time.Millisecond {
+			t.Fatalf("slow call %d took %v, expected the client timeout to cut it short", i, elapsed)
+		}
+	}
+
+	// The circuit is now open: calls fail fast without reaching the WebBFF
+	callsBefore := fake.callCount()
+	code, body := converse(cs)
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("open circuit: status = %d, expected 503", code)
+	}
+	if body.Error != "The AI service is temporarily unavailable. Please try again in a moment." {
+		t.Errorf("open circuit: error = %q, expected the friendly unavailable message", body.Error)
+	}
+	if fake.callCount() != callsBefore {
+		t.Errorf("open circuit reached the WebBFF %d times", fake.callCount()-callsBefore)
+	}
+
+	// Flaky: after the cooldown a trial call goes through and retries past the 503s
+	now = now.Add(2 * time.Minute)
+	fake.set(false, 2)
+	callsBefore = fake.callCount()
+	code, body = converse(cs)
+	if code != http.StatusOK || body.Content != "All good." {
+		t.Fatalf("flaky call: status = %d, body = %+v, expected the retried reply", code, body)
+	}
+	if calls := fake.callCount() - callsBefore; calls != 3 {
+		t.Errorf("flaky call reached the WebBFF %d times, expected 3", calls)
+	}
+
+	// Healthy: calls succeed and the circuit stays closed
+	fake.set(false, 0)
+	code, _ = converse(cs)
+	if code != http.StatusOK {
+		t.Fatalf("healthy call: status = %d, expected 200", code)
+	}
+	if !cs.webBFF.breaker.allow() {
+		t.Error("circuit still open after the WebBFF recovered")
+	}
+}
+
+func TestWebBFFClient_GivesUpAfterBoundedRetries(t *testing.T) {
+	now := time.Now()
+	fake := &fakeWebBFF{}
+	cs := newResilienceTestServer(t, fake, &now)
+	fake.set(false, 10)
+
+	code, _ := converse(cs)
+
+	if code != http.StatusInternalServerError {
+		t.Errorf("status = %d, expected 500 once retries are exhausted", code)
+	}
+	if calls := fake.callCount(); calls != defaultWebBFFRetries+1 {
+		t.Errorf("WebBFF called %d times, expected %d", calls, defaultWebBFFRetries+1)
+	}
+}
+
+func TestCircuitBreaker_LetsOneTrialCallThroughAfterCooldown(t *testing.T) {
+	now := time.Now()
+	breaker := newCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+	breaker.recordFailure()
+	breaker.recordFailure()
+
+	now = now.Add(2 * time.Minute)
+	if !breaker.allow() {
+		t.Fatal("trial call refused after the cooldown")
+	}
+	if breaker.allow() {
+		t.Fatal("second caller let through while the trial call is in flight")
+	}
+
+	// A failed trial call reopens the circuit for another cooldown
+	breaker.recordFailure()
+	if breaker.allow() {
+		t.Fatal("circuit not reopened after the trial call failed")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if !breaker.allow() {
+		t.Fatal("trial call refused after the second cooldown")
+	}
+	breaker.recordSuccess()
+	if !breaker.allow() || !breaker.allow() {
+		t.Error("circuit not closed after the trial call succeeded")
+	}
+}
+
+func TestHandleConversationHistory_FailsFastWhileCircuitOpen(t *testing.T) {
+	now := time.Now()
+	fake := &fakeWebBFF{}
+	cs := newResilienceTestServer(t, fake, &now)
+	fake.set(false, 10)
+
+	history := func() int {
+		rec := httptest.NewRecorder()
+		cs.handleConversationHistory(rec, httptest.NewRequest(http.MethodGet, "/conversation/history?conversation_id=session-1", nil))
+		return rec.Code
+	}
+	history()
+	history()
+
+	callsBefore := fake.callCount()
+	if code := history(); code != http.StatusInternalServerError {
+		t.Errorf("open circuit: status = %d, expected 500", code)
+	}
+	if fake.callCount() != callsBefore {
+		t.Errorf("open circuit reached the WebBFF %d times", fake.callCount()-callsBefore)
+	}
+}
+
+func TestHandleConversationStream_OutlivesTheCallTimeout(t *testing.T) {
+	webBFF := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		for _, chunk := range []string{"data: one\n\n", "data: two\n\n"} {
+			w.Write([]byte(chunk))
+			w.(http.Flusher).Flush()
+			time.Sleep(100 * time.Millisecond)
+		}
+	}))
+	t.Cleanup(webBFF.Close)
+
+	cs := NewChatServer(webBFF.URL)
+	cs.webBFF.httpClient.Timeout = 50 * time.Millisecond
+	cs.webBFF.streamClient.Transport.(*http.Transport).ResponseHeaderTimeout = 50 * time.Millisecond
+
+	rec := httptest.NewRecorder()
+	req := conversationRequest("text/event-stream")
+	cs.handleConversationStream(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, expected 200", rec.Code)
+	}
+	if body := rec.Body.String(); body != "data: one\n\ndata: two\n\n" {
+		t.Errorf("body = %q, expected both chunks relayed", body)
+	}
+}
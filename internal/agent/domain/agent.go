@@ -12,7 +12,7 @@ type AgentStatus string
 
 const (
 	AgentStatusOnline       AgentStatus = "online"
-	AgentStatusOffline      AgentStatus = "offline"
+	AgentStatusOffline      AgentStatus = "offline" // Agent stopped heartbeating past the stale threshold
 	AgentStatusBusy         AgentStatus = "busy"
 	AgentStatusMaintenance  AgentStatus = "maintenance"
 	AgentStatusDisconnected AgentStatus = "disconnected"  // Agent missed heartbeat threshold
@@ -25,6 +25,19 @@ type AgentCapability struct {
 	Name        string            `json:"name"`
 	Description string            `json:"description"`
 	Parameters  map[string]string `json:"parameters,omitempty"`
+	Inputs      []string          `json:"inputs,omitempty"`  // Required input parameter names
+	Outputs     []string          `json:"outputs,omitempty"` // Output names the capability produces
+
+	// TimeoutSeconds is how long the capability may take to complete; zero means the orchestrator's default
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+}
+
+// Timeout returns how long to wait for the capability to complete, or zero when it declares no timeout
+func (c *AgentCapability) Timeout() time.Duration {
+	if c.TimeoutSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(c.TimeoutSeconds) * time.Second
 }
 
 // Agent represents an agent in the system with full type safety and validation
@@ -38,6 +51,7 @@ type Agent struct {
 	CreatedAt    time.Time         `json:"created_at"`
 	UpdatedAt    time.Time         `json:"updated_at"`
 	LastSeen     time.Time         `json:"last_seen"`
+	LastBusyAt   time.Time         `json:"last_busy_at"`
 }
 
 // Agent business rules and validation
@@ -48,6 +62,8 @@ var (
 	ErrInvalidStatus           = errors.New("invalid agent status")
 	ErrNoCapabilities          = errors.New("agent must have at least one capability")
 	ErrInvalidCapability       = errors.New("capability name must be non-empty")
+	ErrMissingInput            = errors.New("missing required capability input")
+	ErrUnexpectedInput         = errors.New("unexpected capability input")
 )
 
 // agentIDPattern defines valid agent ID format
@@ -115,18 +131,60 @@ func (a *Agent) Validate() error {
 
 // IsValid checks if the agent status is valid
 func (s AgentStatus) IsValid() bool {
-	return s == AgentStatusOnline || s == AgentStatusOffline ||
-		s == AgentStatusBusy || s == AgentStatusMaintenance
+	switch s {
+	case AgentStatusOnline, AgentStatusOffline, AgentStatusBusy, AgentStatusMaintenance,
+		AgentStatusDisconnected, AgentStatusError, AgentStatusShuttingDown:
+		return true
+	default:
+		return false
+	}
 }
 
 // Validate enforces business rules for capabilities
 func (c *AgentCapability) Validate() error {
-	if c.Name == "" {
+	if c.Name == "" || c.TimeoutSeconds < 0 {
 		return ErrInvalidCapability
 	}
 	return nil
 }
 
+// ValidateInputs checks provided parameters against the capability's declared inputs and parameters
+// Capabilities that declare neither accept any parameters
+func (c *AgentCapability) ValidateInputs(provided map[string]interface{}) error {
+	if len(c.Inputs) == 0 && len(c.Parameters) == 0 {
+		return nil
+	}
+
+	allowed := make(map[string]bool, len(c.Inputs)+len(c.Parameters))
+	for _, input := range c.Inputs {
+		allowed[input] = true
+		if _, ok := provided[input]; !ok {
+			return fmt.Errorf("%w: %s requires %s", ErrMissingInput, c.Name, input)
+		}
+	}
+	for name := range c.Parameters {
+		allowed[name] = true
+	}
+
+	for name := range provided {
+		if !allowed[name] {
+			return fmt.Errorf("%w: %s does not accept %s", ErrUnexpectedInput, c.Name, name)
+		}
+	}
+
+	return nil
+}
+
+// GetCapability returns the named capability, or nil if the agent does not provide it
+func (a *Agent) GetCapability(capabilityName string) *AgentCapability {
+	for i := range a.Capabilities {
+		if a.Capabilities[i].Name == capabilityName {
+			return &a.Capabilities[i]
+		}
+	}
+	return nil
+}
+
 // UpdateStatus updates the agent status with validation
 func (a *Agent) UpdateStatus(status AgentStatus) error {
 	if !status.IsValid() {
@@ -185,6 +243,10 @@ func (a *Agent) ToMap() map[string]interface{} {
 			"name":        cap.Name,
 			"description": cap.Description,
 			"parameters":  cap.Parameters,
+			"inputs":      cap.Inputs,
+			"outputs":     cap.Outputs,
+
+			"timeout_seconds": cap.TimeoutSeconds,
 		}
 	}
 
@@ -244,6 +306,9 @@ func AgentFromMap(data map[string]interface{}) (*Agent, error) {
 				if params, ok := capMap["parameters"].(map[string]string); ok {
 					capability.Parameters = params
 				}
+				capability.Inputs = toStringSlice(capMap["inputs"])
+				capability.Outputs = toStringSlice(capMap["outputs"])
+				capability.TimeoutSeconds = toInt(capMap["timeout_seconds"])
 				agent.Capabilities = append(agent.Capabilities, capability)
 			}
 		}
@@ -285,3 +350,35 @@ func AgentFromMap(data map[string]interface{}) (*Agent, error) {
 
 	return agent, nil
 }
+
+// toInt reads a whole number stored by the graph, which may come back as any numeric type
+func toInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
+// toStringSlice converts stored list values to a string slice
+func toStringSlice(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				result = append(result, str)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
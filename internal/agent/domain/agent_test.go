@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -323,3 +324,131 @@ func TestNewAgent_ValidationError(t *testing.T) {
 		t.Errorf("NewAgent() with no capabilities error = %v, expected %v", err, ErrNoCapabilities)
 	}
 }
+
+func TestAgentStatus_IsValid(t *testing.T) {
+	validStatuses := []AgentStatus{
+		AgentStatusOnline,
+		AgentStatusOffline,
+		AgentStatusBusy,
+		AgentStatusMaintenance,
+		AgentStatusDisconnected,
+		AgentStatusError,
+		AgentStatusShuttingDown,
+	}
+
+	for _, status := range validStatuses {
+		if !status.IsValid() {
+			t.Errorf("AgentStatus(%q).IsValid() = false, expected true", status)
+		}
+	}
+
+	if AgentStatus("invalid").IsValid() {
+		t.Errorf("AgentStatus(\"invalid\").IsValid() = true, expected false")
+	}
+}
+
+func TestAgentCapability_ValidateInputs(t *testing.T) {
+	capability := AgentCapability{
+		Name:       "word-count",
+		Inputs:     []string{"text"},
+		Parameters: map[string]string{"language": "optional language code"},
+	}
+
+	tests := []struct {
+		name     string
+		provided map[string]interface{}
+		wantErr  error
+	}{
+		{name: "required input only", provided: map[string]interface{}{"text": "hello"}},
+		{name: "required and optional", provided: map[string]interface{}{"text": "hello", "language": "en"}},
+		{name: "missing required input", provided: map[string]interface{}{"language": "en"}, wantErr: ErrMissingInput},
+		{name: "extra parameter", provided: map[string]interface{}{"text": "hello", "format": "json"}, wantErr: ErrUnexpectedInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := capability.ValidateInputs(tt.provided)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("AgentCapability.ValidateInputs() error = %v, expected %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAgentCapability_ValidateInputs_Undeclared(t *testing.T) {
+	capability := AgentCapability{Name: "free-form"}
+
+	if err := capability.ValidateInputs(map[string]interface{}{"anything": 1}); err != nil {
+		t.Errorf("AgentCapability.ValidateInputs() error = %v, expected nil for undeclared inputs", err)
+	}
+}
+
+func TestAgent_MapRoundTrip_PreservesCapabilityInputs(t *testing.T) {
+	agent, err := NewAgent("agent-1", "Agent", "", []AgentCapability{
+		{Name: "word-count", Inputs: []string{"text"}, Outputs: []string{"count"}},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent() error = %v", err)
+	}
+
+	data := agent.ToMap()
+	// Simulate storage decoding lists as []interface{}
+	data["capabilities"] = []interface{}{map[string]interface{}{
+		"name":    "word-count",
+		"inputs":  []interface{}{"text"},
+		"outputs": []interface{}{"count"},
+	}}
+
+	restored, err := AgentFromMap(data)
+	if err != nil {
+		t.Fatalf("AgentFromMap() error = %v", err)
+	}
+
+	capability := restored.GetCapability("word-count")
+	if capability == nil {
+		t.Fatalf("restored agent is missing capability word-count")
+	}
+	if len(capability.Inputs) != 1 || capability.Inputs[0] != "text" {
+		t.Errorf("capability.Inputs = %v, expected [text]", capability.Inputs)
+	}
+	if len(capability.Outputs) != 1 || capability.Outputs[0] != "count" {
+		t.Errorf("capability.Outputs = %v, expected [count]", capability.Outputs)
+	}
+}
+
+func TestAgent_MapRoundTrip_PreservesCapabilityTimeout(t *testing.T) {
+	agent, err := NewAgent("agent-1", "Agent", "", []AgentCapability{
+		{Name: "deploy", TimeoutSeconds: 300},
+		{Name: "word-count"},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent() error = %v", err)
+	}
+
+	data := agent.ToMap()
+	// Simulate storage decoding whole numbers as int64
+	data["capabilities"] = []interface{}{
+		map[string]interface{}{"name": "deploy", "timeout_seconds": int64(300)},
+		map[string]interface{}{"name": "word-count"},
+	}
+
+	restored, err := AgentFromMap(data)
+	if err != nil {
+		t.Fatalf("AgentFromMap() error = %v", err)
+	}
+
+	if timeout := restored.GetCapability("deploy").Timeout(); timeout != 5*time.Minute {
+		t.Errorf("deploy Timeout() = %v, expected 5m", timeout)
+	}
+	if timeout := restored.GetCapability("word-count").Timeout(); timeout != 0 {
+		t.Errorf("word-count Timeout() = %v, expected 0 for an undeclared timeout", timeout)
+	}
+}
+
+func TestAgentCapability_Validate_NegativeTimeout(t *testing.T) {
+	capability := AgentCapability{Name: "deploy", TimeoutSeconds: -1}
+
+	if err := capability.Validate(); err != ErrInvalidCapability {
+		t.Errorf("AgentCapability.Validate() error = %v, expected %v", err, ErrInvalidCapability)
+	}
+}
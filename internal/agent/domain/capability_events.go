@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// CapabilitiesChangedEvent announces that a re-registered agent added or dropped capabilities
+type CapabilitiesChangedEvent struct {
+	AgentID    string    `json:"agent_id"`
+	Added      []string  `json:"added,omitempty"`
+	Removed    []string  `json:"removed,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// CapabilityChangeListener is notified when an agent's set of capabilities changes
+type CapabilityChangeListener interface {
+	CapabilitiesChanged(ctx context.Context, event *CapabilitiesChangedEvent)
+}
+
+// NewCapabilitiesChangedEvent compares an agent's previous and current capabilities by name
+// It returns false when the agent neither added nor dropped a capability
+func NewCapabilitiesChangedEvent(agentID string, previous, current []AgentCapability) (*CapabilitiesChangedEvent, bool) {
+	previousNames := make(map[string]bool, len(previous))
+	for _, capability := range previous {
+		previousNames[capability.Name] = true
+	}
+	currentNames := make(map[string]bool, len(current))
+	for _, capability := range current {
+		currentNames[capability.Name] = true
+	}
+
+	event := &CapabilitiesChangedEvent{AgentID: agentID, OccurredAt: time.Now()}
+	for _, capability := range current {
+		if !previousNames[capability.Name] {
+			event.Added = append(event.Added, capability.Name)
+		}
+	}
+	for _, capability := range previous {
+		if !currentNames[capability.Name] {
+			event.Removed = append(event.Removed, capability.Name)
+		}
+	}
+
+	return event, len(event.Added) > 0 || len(event.Removed) > 0
+}
@@ -1,6 +1,28 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// DefaultAgentPageLimit is the page size used when ListOptions leaves Limit unset
+const DefaultAgentPageLimit = 50
+
+// ErrInvalidCursor is returned when a page cursor was not issued by the registry
+var ErrInvalidCursor = errors.New("invalid page cursor")
+
+// ListOptions pages agent listings
+type ListOptions struct {
+	Limit  int    // Maximum agents returned; zero means DefaultAgentPageLimit
+	Cursor string // NextCursor of the previous page; empty starts at the first page
+}
+
+// AgentPage is one page of agents, ordered by ID
+type AgentPage struct {
+	Agents     []*Agent `json:"agents"`
+	NextCursor string   `json:"next_cursor,omitempty"` // Empty on the last page
+	Total      int      `json:"total"`                 // Number of agents across all pages
+}
 
 // AgentRegistry defines the interface for agent registration and discovery
 // This is different from AgentRepository - Registry is for service discovery, Repository is for persistence
@@ -32,6 +54,6 @@ type AgentRegistry interface {
 	// IsAgentHealthy checks if an agent is healthy and responsive
 	IsAgentHealthy(ctx context.Context, agentID string) (bool, error)
 
-	// MonitorAgentHealth checks all agents and marks disconnected ones as such
+	// MonitorAgentHealth marks agents that stopped heartbeating as offline
 	MonitorAgentHealth(ctx context.Context) error
 }
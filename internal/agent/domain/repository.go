@@ -31,3 +31,9 @@ type AgentRepository interface {
 	// Update last seen timestamp
 	UpdateLastSeen(ctx context.Context, id string) error
 }
+
+// CapabilityIndex keeps the capability nodes linked to an agent in line with its registered capabilities
+// The nodes are what capability lookups traverse, so every registration must go through it
+type CapabilityIndex interface {
+	SyncCapabilities(ctx context.Context, agent *Agent) error
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+// AgentWorkload describes how busy an agent currently is
+type AgentWorkload struct {
+	AgentID     string      `json:"agent_id"`
+	Status      AgentStatus `json:"status"`
+	ActiveSteps int         `json:"active_steps"`
+}
+
+// WorkloadCounter reports the number of in-flight execution steps per agent
+type WorkloadCounter interface {
+	GetActiveStepCountByAgent(ctx context.Context) (map[string]int, error)
+}
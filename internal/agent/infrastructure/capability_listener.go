@@ -0,0 +1,26 @@
+package infrastructure
+
+import (
+	"context"
+
+	"neuromesh/internal/agent/domain"
+	"neuromesh/internal/logging"
+)
+
+// LoggingCapabilityListener records capability changes in the orchestrator log
+type LoggingCapabilityListener struct {
+	logger logging.Logger
+}
+
+// NewLoggingCapabilityListener creates a listener that logs each capability change
+func NewLoggingCapabilityListener(logger logging.Logger) *LoggingCapabilityListener {
+	return &LoggingCapabilityListener{logger: logger}
+}
+
+// CapabilitiesChanged logs the capabilities an agent added and dropped
+func (l *LoggingCapabilityListener) CapabilitiesChanged(ctx context.Context, event *domain.CapabilitiesChangedEvent) {
+	l.logger.Info("Agent capabilities changed",
+		"agent_id", event.AgentID,
+		"added", event.Added,
+		"removed", event.Removed)
+}
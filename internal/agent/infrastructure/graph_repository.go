@@ -2,16 +2,22 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"neuromesh/internal/agent/domain"
 	"neuromesh/internal/graph"
+	"neuromesh/internal/ids"
 )
 
+// Ensure GraphAgentRepository maintains the capability nodes registrations are indexed by
+var _ domain.CapabilityIndex = (*GraphAgentRepository)(nil)
+
 // GraphAgentRepository implements the AgentRepository interface using the graph backend
 type GraphAgentRepository struct {
-	graph graph.Graph
+	graph              graph.Graph
+	capabilityListener domain.CapabilityChangeListener
 }
 
 // NewGraphAgentRepository creates a new graph-based agent repository
@@ -21,6 +27,11 @@ func NewGraphAgentRepository(g graph.Graph) *GraphAgentRepository {
 	}
 }
 
+// SetCapabilityChangeListener registers a listener told when an update adds or drops agent capabilities
+func (r *GraphAgentRepository) SetCapabilityChangeListener(listener domain.CapabilityChangeListener) {
+	r.capabilityListener = listener
+}
+
 // EnsureSchema ensures that the required schema for Agent domain is in place
 func (r *GraphAgentRepository) EnsureSchema(ctx context.Context) error {
 	// Define Agent domain schema requirements
@@ -38,31 +49,9 @@ func (r *GraphAgentRepository) EnsureSchema(ctx context.Context) error {
 		return fmt.Errorf("failed to create index for agent.status: %w", err)
 	}
 
-	// Capability node constraints and indexes
-	// Check if constraint already exists before trying to create it
-	hasConstraint, err := r.graph.HasUniqueConstraint(ctx, "capability", "name")
-	if err != nil {
-		return fmt.Errorf("failed to check existing capability.name constraint: %w", err)
-	}
-
-	if !hasConstraint {
-		// Check if there's an existing index that needs to be dropped first
-		hasIndex, err := r.graph.HasIndex(ctx, "capability", "name")
-		if err != nil {
-			return fmt.Errorf("failed to check existing capability.name index: %w", err)
-		}
-
-		if hasIndex {
-			// Drop the existing index so we can create the constraint
-			if err := r.dropIndex(ctx, "capability", "name"); err != nil {
-				return fmt.Errorf("failed to drop existing capability.name index: %w", err)
-			}
-		}
-
-		// Now create the unique constraint
-		if err := r.graph.CreateUniqueConstraint(ctx, "capability", "name"); err != nil {
-			return fmt.Errorf("failed to create unique constraint for capability.name: %w", err)
-		}
+	// Every agent links its own capability nodes, so agents sharing a capability repeat its name
+	if err := r.graph.CreateIndex(ctx, "capability", "name"); err != nil {
+		return fmt.Errorf("failed to create index for capability.name: %w", err)
 	}
 
 	// Ensure HAS_CAPABILITY relationship type exists in the schema
@@ -119,37 +108,19 @@ func (r *GraphAgentRepository) Create(ctx context.Context, agent *domain.Agent)
 	}
 
 	// Convert domain model to graph data
-	data := agent.ToMap()
+	data, err := agentProperties(agent)
+	if err != nil {
+		return err
+	}
 
-	// Store in graph with proper node type
-	nodeID := fmt.Sprintf("agent:%s", agent.ID)
+	nodeID := ids.Agent(agent.ID)
 
 	// Create the agent node
 	if err := r.graph.AddNode(ctx, "agent", nodeID, data); err != nil {
 		return fmt.Errorf("failed to create agent node: %w", err)
 	}
 
-	// Add capability relationships
-	for _, capability := range agent.Capabilities {
-		capabilityNodeID := fmt.Sprintf("capability:%s:%s", agent.ID, capability.Name)
-		capabilityData := map[string]interface{}{
-			"name":        capability.Name,
-			"description": capability.Description,
-			"parameters":  capability.Parameters,
-		}
-
-		// Create capability node
-		if err := r.graph.AddNode(ctx, "capability", capabilityNodeID, capabilityData); err != nil {
-			return fmt.Errorf("failed to create capability node: %w", err)
-		}
-
-		// Create relationship
-		if err := r.graph.AddEdge(ctx, "agent", nodeID, "capability", capabilityNodeID, "HAS_CAPABILITY", nil); err != nil {
-			return fmt.Errorf("failed to create capability relationship: %w", err)
-		}
-	}
-
-	return nil
+	return r.addCapabilities(ctx, agent)
 }
 
 // GetByID retrieves an agent by its ID from the graph
@@ -158,16 +129,16 @@ func (r *GraphAgentRepository) GetByID(ctx context.Context, id string) (*domain.
 		return nil, fmt.Errorf("agent ID cannot be empty")
 	}
 
-	nodeID := fmt.Sprintf("agent:%s", id)
+	nodeID := ids.Agent(id)
 
 	// Get agent node data
 	node, err := r.graph.GetNode(ctx, "agent", nodeID)
-	if err != nil {
+	if err != nil && !errors.Is(err, graph.ErrNodeNotFound) {
 		return nil, fmt.Errorf("failed to get agent node: %w", err)
 	}
 
 	if node == nil {
-		return nil, fmt.Errorf("agent not found: %s", id)
+		return nil, fmt.Errorf("agent not found: %s: %w", id, graph.ErrNodeNotFound)
 	}
 
 	// Get capabilities using graph traversal
@@ -188,6 +159,7 @@ func (r *GraphAgentRepository) GetByID(ctx context.Context, id string) (*domain.
 	if err != nil {
 		return nil, fmt.Errorf("failed to deserialize agent: %w", err)
 	}
+	agent.Metadata = decodeAgentMetadata(node["metadata"])
 
 	return agent, nil
 }
@@ -200,6 +172,58 @@ func (r *GraphAgentRepository) GetAll(ctx context.Context) ([]*domain.Agent, err
 		return nil, fmt.Errorf("failed to get agent nodes: %w", err)
 	}
 
+	return r.agentsFromNodes(ctx, nodes), nil
+}
+
+// GetByStatus retrieves agents by their status
+func (r *GraphAgentRepository) GetByStatus(ctx context.Context, status domain.AgentStatus) ([]*domain.Agent, error) {
+	nodes, err := r.graph.QueryNodes(ctx, "agent", map[string]interface{}{"status": string(status)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent nodes by status: %w", err)
+	}
+
+	return r.agentsFromNodes(ctx, nodes), nil
+}
+
+// GetByCapability retrieves agents that have a specific capability
+// The graph is traversed from the capability nodes, so only matching agents are loaded
+func (r *GraphAgentRepository) GetByCapability(ctx context.Context, capabilityName string) ([]*domain.Agent, error) {
+	nodes, err := r.graph.QueryNodesByRelated(ctx, "agent", "HAS_CAPABILITY", "capability", map[string]interface{}{"name": capabilityName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent nodes by capability: %w", err)
+	}
+
+	return r.agentsFromNodes(ctx, nodes), nil
+}
+
+// agentProperties converts an agent to node properties, storing its metadata as JSON like every map-valued property
+func agentProperties(agent *domain.Agent) (map[string]interface{}, error) {
+	data := agent.ToMap()
+	metadata, err := graph.EncodeMapProperty(agent.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode agent metadata: %w", err)
+	}
+	data["metadata"] = metadata
+	return data, nil
+}
+
+// decodeAgentMetadata reads the string metadata of an agent node, ignoring non-string and undecodable values
+func decodeAgentMetadata(value interface{}) map[string]string {
+	metadata := make(map[string]string)
+	decoded, err := graph.DecodeMapProperty(value)
+	if err != nil {
+		return metadata
+	}
+	for key, v := range decoded {
+		if str, ok := v.(string); ok {
+			metadata[key] = str
+		}
+	}
+	return metadata
+}
+
+// agentsFromNodes loads the capabilities of each agent node and converts it to the domain model
+func (r *GraphAgentRepository) agentsFromNodes(ctx context.Context, nodes []map[string]interface{}) []*domain.Agent {
 	agents := make([]*domain.Agent, 0, len(nodes))
 	for _, node := range nodes {
 		// Extract agent ID from node data
@@ -209,8 +233,7 @@ func (r *GraphAgentRepository) GetAll(ctx context.Context) ([]*domain.Agent, err
 		}
 
 		// Get capabilities for this agent
-		nodeID := fmt.Sprintf("agent:%s", agentID)
-		capabilities, err := r.getAgentCapabilities(ctx, nodeID)
+		capabilities, err := r.getAgentCapabilities(ctx, agentID)
 		if err != nil {
 			continue // Skip agents with capability errors
 		}
@@ -227,50 +250,12 @@ func (r *GraphAgentRepository) GetAll(ctx context.Context) ([]*domain.Agent, err
 		if err != nil {
 			continue // Skip invalid agents
 		}
+		agent.Metadata = decodeAgentMetadata(node["metadata"])
 
 		agents = append(agents, agent)
 	}
 
-	return agents, nil
-}
-
-// GetByStatus retrieves agents by their status
-func (r *GraphAgentRepository) GetByStatus(ctx context.Context, status domain.AgentStatus) ([]*domain.Agent, error) {
-	// Get all agents and filter by status
-	allAgents, err := r.GetAll(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	var filteredAgents []*domain.Agent
-	for _, agent := range allAgents {
-		if agent.Status == status {
-			filteredAgents = append(filteredAgents, agent)
-		}
-	}
-
-	return filteredAgents, nil
-}
-
-// GetByCapability retrieves agents that have a specific capability
-func (r *GraphAgentRepository) GetByCapability(ctx context.Context, capabilityName string) ([]*domain.Agent, error) {
-	// Get all agents and filter by capability
-	allAgents, err := r.GetAll(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	var filteredAgents []*domain.Agent
-	for _, agent := range allAgents {
-		for _, capability := range agent.Capabilities {
-			if capability.Name == capabilityName {
-				filteredAgents = append(filteredAgents, agent)
-				break
-			}
-		}
-	}
-
-	return filteredAgents, nil
+	return agents
 }
 
 // Update updates an existing agent in the graph
@@ -279,25 +264,36 @@ func (r *GraphAgentRepository) Update(ctx context.Context, agent *domain.Agent)
 		return fmt.Errorf("invalid agent: %w", err)
 	}
 
-	nodeID := fmt.Sprintf("agent:%s", agent.ID)
+	nodeID := ids.Agent(agent.ID)
 
 	// Check if agent exists
 	existing, err := r.graph.GetNode(ctx, "agent", nodeID)
-	if err != nil {
+	if err != nil && !errors.Is(err, graph.ErrNodeNotFound) {
 		return fmt.Errorf("failed to check existing agent: %w", err)
 	}
 	if existing == nil {
-		return fmt.Errorf("agent not found: %s", agent.ID)
+		return fmt.Errorf("agent not found: %s: %w", agent.ID, graph.ErrNodeNotFound)
 	}
 
 	// Update agent node
-	data := agent.ToMap()
+	data, err := agentProperties(agent)
+	if err != nil {
+		return err
+	}
 	if err := r.graph.UpdateNode(ctx, "agent", nodeID, data); err != nil {
 		return fmt.Errorf("failed to update agent node: %w", err)
 	}
 
-	// TODO: Handle capability updates (remove old, add new)
-	// For now, we'll just update the main agent properties
+	return r.SyncCapabilities(ctx, agent)
+}
+
+// SyncCapabilities reconciles the agent's capability nodes and tells the listener which capabilities changed
+func (r *GraphAgentRepository) SyncCapabilities(ctx context.Context, agent *domain.Agent) error {
+	previous, err := r.reconcileCapabilities(ctx, agent)
+	if err != nil {
+		return err
+	}
+	r.notifyCapabilityChanges(ctx, agent, previous)
 
 	return nil
 }
@@ -308,7 +304,7 @@ func (r *GraphAgentRepository) Delete(ctx context.Context, id string) error {
 		return fmt.Errorf("agent ID cannot be empty")
 	}
 
-	nodeID := fmt.Sprintf("agent:%s", id)
+	nodeID := ids.Agent(id)
 
 	// Get and remove capability nodes and edges
 	edges, err := r.graph.GetEdges(ctx, "agent", nodeID)
@@ -345,7 +341,7 @@ func (r *GraphAgentRepository) UpdateStatus(ctx context.Context, id string, stat
 		return fmt.Errorf("invalid status: %s", status)
 	}
 
-	nodeID := fmt.Sprintf("agent:%s", id)
+	nodeID := ids.Agent(id)
 
 	// Update just the status property
 	properties := map[string]interface{}{
@@ -365,7 +361,7 @@ func (r *GraphAgentRepository) UpdateLastSeen(ctx context.Context, id string) er
 		return fmt.Errorf("agent ID cannot be empty")
 	}
 
-	nodeID := fmt.Sprintf("agent:%s", id)
+	nodeID := ids.Agent(id)
 
 	// Update just the last_seen property
 	properties := map[string]interface{}{
@@ -379,10 +375,119 @@ func (r *GraphAgentRepository) UpdateLastSeen(ctx context.Context, id string) er
 	return nil
 }
 
+// addCapabilities creates capability nodes and HAS_CAPABILITY relationships for the agent
+func (r *GraphAgentRepository) addCapabilities(ctx context.Context, agent *domain.Agent) error {
+	for _, capability := range agent.Capabilities {
+		if err := r.addCapability(ctx, agent.ID, capability); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addCapability creates one capability node and links it to the agent node
+func (r *GraphAgentRepository) addCapability(ctx context.Context, agentID string, capability domain.AgentCapability) error {
+	capabilityNodeID := ids.Capability(agentID, capability.Name)
+
+	// Create capability node; created_at lets GC spare it until the relationship below exists
+	properties := capabilityProperties(capability)
+	properties["created_at"] = time.Now().UTC()
+	if err := r.graph.AddNode(ctx, "capability", capabilityNodeID, properties); err != nil {
+		return fmt.Errorf("failed to create capability node: %w", err)
+	}
+
+	// Create relationship
+	if err := r.graph.AddEdge(ctx, "agent", ids.Agent(agentID), "capability", capabilityNodeID, "HAS_CAPABILITY", nil); err != nil {
+		return fmt.Errorf("failed to create capability relationship: %w", err)
+	}
+
+	return nil
+}
+
+// capabilityProperties converts a capability to the properties of its graph node
+func capabilityProperties(capability domain.AgentCapability) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        capability.Name,
+		"description": capability.Description,
+		"parameters":  capability.Parameters,
+		"inputs":      capability.Inputs,
+		"outputs":     capability.Outputs,
+
+		"timeout_seconds": capability.TimeoutSeconds,
+	}
+}
+
+// reconcileCapabilities brings the agent's capability nodes in line with its current capabilities
+// Kept capabilities are updated in place, dropped ones are deleted and new ones are linked
+// It returns the capabilities linked to the agent before reconciling
+func (r *GraphAgentRepository) reconcileCapabilities(ctx context.Context, agent *domain.Agent) ([]domain.AgentCapability, error) {
+	edges, err := r.graph.GetEdgesWithTargets(ctx, "agent", ids.Agent(agent.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get capability edges: %w", err)
+	}
+
+	var previous []domain.AgentCapability
+	existing := make(map[string]string) // Capability name to node ID
+	for _, edge := range edges {
+		if edgeType, ok := edge["type"].(string); !ok || edgeType != "HAS_CAPABILITY" {
+			continue
+		}
+		targetID, ok := edge["target_id"].(string)
+		if !ok {
+			continue
+		}
+		node, err := r.graph.GetNode(ctx, "capability", targetID)
+		if err != nil || node == nil {
+			continue // Skip if capability node is not found
+		}
+		name, _ := node["name"].(string)
+		existing[name] = targetID
+		previous = append(previous, domain.AgentCapability{Name: name})
+	}
+
+	current := make(map[string]bool, len(agent.Capabilities))
+	for _, capability := range agent.Capabilities {
+		current[capability.Name] = true
+		nodeID, kept := existing[capability.Name]
+		if !kept {
+			if err := r.addCapability(ctx, agent.ID, capability); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := r.graph.UpdateNode(ctx, "capability", nodeID, capabilityProperties(capability)); err != nil {
+			return nil, fmt.Errorf("failed to update capability node: %w", err)
+		}
+	}
+
+	for name, nodeID := range existing {
+		if current[name] {
+			continue
+		}
+		if err := r.graph.DeleteNode(ctx, "capability", nodeID); err != nil {
+			return nil, fmt.Errorf("failed to delete capability node: %w", err)
+		}
+	}
+
+	return previous, nil
+}
+
+// notifyCapabilityChanges tells the listener, if any, which capabilities the agent added or dropped
+// An agent without capability nodes is being indexed for the first time, which is not a change
+func (r *GraphAgentRepository) notifyCapabilityChanges(ctx context.Context, agent *domain.Agent, previous []domain.AgentCapability) {
+	if r.capabilityListener == nil || len(previous) == 0 {
+		return
+	}
+	if event, changed := domain.NewCapabilitiesChangedEvent(agent.ID, previous, agent.Capabilities); changed {
+		r.capabilityListener.CapabilitiesChanged(ctx, event)
+	}
+}
+
 // getAgentCapabilities retrieves capabilities for an agent
 func (r *GraphAgentRepository) getAgentCapabilities(ctx context.Context, agentNodeID string) ([]interface{}, error) {
 	// Get edges from the agent node
-	edges, err := r.graph.GetEdges(ctx, "agent", agentNodeID)
+	edges, err := r.graph.GetEdgesWithTargets(ctx, "agent", agentNodeID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get capability edges: %w", err)
 	}
@@ -412,6 +517,10 @@ func (r *GraphAgentRepository) getAgentCapabilities(ctx context.Context, agentNo
 			"name":        capabilityNode["name"],
 			"description": capabilityNode["description"],
 			"parameters":  capabilityNode["parameters"],
+			"inputs":      capabilityNode["inputs"],
+			"outputs":     capabilityNode["outputs"],
+
+			"timeout_seconds": capabilityNode["timeout_seconds"],
 		}
 		capabilities = append(capabilities, capabilityData)
 	}
@@ -422,11 +531,6 @@ func (r *GraphAgentRepository) getAgentCapabilities(ctx context.Context, agentNo
 // Helper methods for testing schema verification
 // These methods are used by tests to verify that schema elements exist in the database
 
-// dropIndex removes an index on the specified node label and property
-func (r *GraphAgentRepository) dropIndex(ctx context.Context, nodeLabel, property string) error {
-	return r.graph.DropIndex(ctx, nodeLabel, property)
-}
-
 // hasUniqueConstraint checks if a unique constraint exists on the specified node label and property
 func (r *GraphAgentRepository) hasUniqueConstraint(ctx context.Context, nodeLabel, property string) (bool, error) {
 	return r.graph.HasUniqueConstraint(ctx, nodeLabel, property)
@@ -4,8 +4,13 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuromesh/internal/agent/domain"
 	"neuromesh/internal/graph"
 	"neuromesh/internal/logging"
+	"neuromesh/testHelpers"
 )
 
 // TestGraphAgentRepository_EnsureSchema tests that the repository can define and write schema
@@ -60,13 +65,13 @@ func TestGraphAgentRepository_EnsureSchema(t *testing.T) {
 		t.Error("Expected Agent node to have index on status")
 	}
 
-	// Test 3: Capability node should have unique constraint on name within agent scope
-	hasCapabilityConstraint, err := repo.hasUniqueConstraint(ctx, "capability", "name")
+	// Test 3: Capability node should have an index on name; agents sharing a capability repeat it
+	hasCapabilityIndex, err := repo.hasIndex(ctx, "capability", "name")
 	if err != nil {
-		t.Fatalf("Failed to check Capability name constraint: %v", err)
+		t.Fatalf("Failed to check Capability name index: %v", err)
 	}
-	if !hasCapabilityConstraint {
-		t.Error("Expected Capability node to have unique constraint on name")
+	if !hasCapabilityIndex {
+		t.Error("Expected Capability node to have index on name")
 	}
 
 	// Test 4: HAS_CAPABILITY relationship should exist as defined relationship type
@@ -108,3 +113,169 @@ func TestGraphAgentRepository_EnsureSchema_Idempotent(t *testing.T) {
 		}
 	}
 }
+
+func TestGraphAgentRepository_UsesRawAgentIDAsNodeID(t *testing.T) {
+	ctx := context.Background()
+	mockGraph := testHelpers.NewCleanMockGraph()
+	repo := NewGraphAgentRepository(mockGraph)
+
+	agent, err := domain.NewAgent("text-processor", "Text Processor", "Processes text",
+		[]domain.AgentCapability{{Name: "word-count", Description: "Counts words"}})
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, agent))
+
+	// The node must be addressable by the raw agent ID used elsewhere in the graph
+	node, err := mockGraph.GetNode(ctx, "agent", agent.ID)
+	require.NoError(t, err)
+	require.NotNil(t, node)
+
+	stored, err := repo.GetByID(ctx, agent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, agent.ID, stored.ID)
+
+	require.NoError(t, repo.UpdateStatus(ctx, agent.ID, domain.AgentStatusOffline))
+	stored, err = repo.GetByID(ctx, agent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AgentStatusOffline, stored.Status)
+}
+
+func TestGraphAgentRepository_MetadataStoredAsJSON(t *testing.T) {
+	ctx := context.Background()
+	mockGraph := testHelpers.NewCleanMockGraph()
+	repo := NewGraphAgentRepository(mockGraph)
+
+	agent, err := domain.NewAgent("deploy-agent", "Deploy Agent", "Deploys services",
+		[]domain.AgentCapability{{Name: "deploy", Description: "Deploys a service"}})
+	require.NoError(t, err)
+	agent.Metadata["region"] = "eu-west-1"
+	require.NoError(t, repo.Create(ctx, agent))
+
+	node, err := mockGraph.GetNode(ctx, "agent", agent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, `{"region":"eu-west-1"}`, node["metadata"])
+
+	stored, err := repo.GetByID(ctx, agent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"region": "eu-west-1"}, stored.Metadata)
+}
+
+// recordingCapabilityListener keeps the capability change events it is told about
+type recordingCapabilityListener struct {
+	events []*domain.CapabilitiesChangedEvent
+}
+
+func (l *recordingCapabilityListener) CapabilitiesChanged(ctx context.Context, event *domain.CapabilitiesChangedEvent) {
+	l.events = append(l.events, event)
+}
+
+func TestGraphAgentRepository_Update_ReconcilesChangedCapabilities(t *testing.T) {
+	ctx := context.Background()
+	mockGraph := testHelpers.NewCleanMockGraph()
+	repo := NewGraphAgentRepository(mockGraph)
+	listener := &recordingCapabilityListener{}
+	repo.SetCapabilityChangeListener(listener)
+
+	agent, err := domain.NewAgent("text-processor", "Text Processor", "Processes text", []domain.AgentCapability{
+		{Name: "word-count", Description: "Counts words"},
+		{Name: "summarize", Description: "Summarizes text"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, agent))
+	assert.Empty(t, listener.events, "a first registration is not a capability change")
+
+	// The upgraded agent drops word-count, keeps summarize and adds translate
+	agent.Capabilities = []domain.AgentCapability{
+		{Name: "summarize", Description: "Summarizes text in one paragraph"},
+		{Name: "translate", Description: "Translates text"},
+	}
+	require.NoError(t, repo.Update(ctx, agent))
+
+	stored, err := repo.GetByID(ctx, agent.ID)
+	require.NoError(t, err)
+	assert.Nil(t, stored.GetCapability("word-count"))
+	require.NotNil(t, stored.GetCapability("summarize"))
+	assert.Equal(t, "Summarizes text in one paragraph", stored.GetCapability("summarize").Description)
+	assert.NotNil(t, stored.GetCapability("translate"))
+	assert.Len(t, stored.Capabilities, 2)
+
+	dropped, err := mockGraph.GetNode(ctx, "capability", "capability:text-processor:word-count")
+	require.NoError(t, err)
+	assert.Nil(t, dropped, "the dropped capability node is deleted")
+
+	require.Len(t, listener.events, 1)
+	assert.Equal(t, "text-processor", listener.events[0].AgentID)
+	assert.Equal(t, []string{"translate"}, listener.events[0].Added)
+	assert.Equal(t, []string{"word-count"}, listener.events[0].Removed)
+
+	// Re-registering the same capabilities is not a change
+	require.NoError(t, repo.Update(ctx, agent))
+	assert.Len(t, listener.events, 1)
+}
+
+// countingGraph counts the agents whose capabilities are loaded, i.e. the agents materialized by a query
+type countingGraph struct {
+	graph.Graph
+	materialized []string
+	fullScans    int
+}
+
+func (g *countingGraph) GetEdgesWithTargets(ctx context.Context, nodeType, nodeID string) ([]map[string]interface{}, error) {
+	if nodeType == "agent" {
+		g.materialized = append(g.materialized, nodeID)
+	}
+	return g.Graph.GetEdgesWithTargets(ctx, nodeType, nodeID)
+}
+
+func (g *countingGraph) QueryNodes(ctx context.Context, nodeType string, filters map[string]interface{}) ([]map[string]interface{}, error) {
+	if nodeType == "agent" && len(filters) == 0 {
+		g.fullScans++
+	}
+	return g.Graph.QueryNodes(ctx, nodeType, filters)
+}
+
+func TestGraphAgentRepository_GetByCapabilityAndStatus_LoadOnlyMatchingAgents(t *testing.T) {
+	ctx := context.Background()
+	counting := &countingGraph{Graph: testHelpers.NewCleanMockGraph()}
+	repo := NewGraphAgentRepository(counting)
+
+	for _, spec := range []struct {
+		id         string
+		status     domain.AgentStatus
+		capability string
+	}{
+		{"text-processor", domain.AgentStatusOnline, "word-count"},
+		{"text-processor-2", domain.AgentStatusOffline, "word-count"},
+		{"deployer", domain.AgentStatusOnline, "deploy"},
+		{"monitor", domain.AgentStatusBusy, "monitor"},
+	} {
+		agent, err := domain.NewAgent(spec.id, spec.id, "", []domain.AgentCapability{{Name: spec.capability}})
+		require.NoError(t, err)
+		agent.Status = spec.status
+		require.NoError(t, repo.Create(ctx, agent))
+	}
+
+	counting.materialized = nil
+	agents, err := repo.GetByCapability(ctx, "word-count")
+	require.NoError(t, err)
+	var ids []string
+	for _, agent := range agents {
+		ids = append(ids, agent.ID)
+	}
+	assert.ElementsMatch(t, []string{"text-processor", "text-processor-2"}, ids)
+	assert.ElementsMatch(t, ids, counting.materialized, "only agents with the capability are loaded")
+
+	counting.materialized = nil
+	agents, err = repo.GetByStatus(ctx, domain.AgentStatusOnline)
+	require.NoError(t, err)
+	ids = nil
+	for _, agent := range agents {
+		ids = append(ids, agent.ID)
+	}
+	assert.ElementsMatch(t, []string{"text-processor", "deployer"}, ids)
+	assert.ElementsMatch(t, ids, counting.materialized, "only agents with the status are loaded")
+
+	agents, err = repo.GetByCapability(ctx, "translate")
+	require.NoError(t, err)
+	assert.Empty(t, agents)
+	assert.Zero(t, counting.fullScans, "no lookup loads every agent")
+}
@@ -35,7 +35,7 @@ func TestAgentLifecyclePersistence(t *testing.T) {
 	defer graphInstance.Close(ctx)
 
 	// Clean up any existing test data
-	_ = graphInstance.ClearTestData(ctx)
+	_ = graphInstance.ClearNodesByType(ctx, "agent")
 
 	// Create agent registry service
 	registryService := NewService(graphInstance, logger)
@@ -145,5 +145,5 @@ func TestAgentLifecyclePersistence(t *testing.T) {
 	t.Log("✅ Agent lifecycle test complete: Registration, unregistration, and re-registration all work correctly")
 
 	// Clean up
-	_ = graphInstance.ClearTestData(ctx)
+	_ = graphInstance.ClearNodesByType(ctx, "agent")
 }
@@ -35,8 +35,8 @@ func TestProductionFlowAgentPersistence(t *testing.T) {
 	defer graphDB.Close(ctx)
 
 	// Clear any existing test data
-	_ = graphDB.ClearTestData(ctx)
-	defer graphDB.ClearTestData(ctx)
+	_ = graphDB.ClearNodesByType(ctx, "agent")
+	defer graphDB.ClearNodesByType(ctx, "agent")
 
 	// Create registry service (same as production orchestrator)
 	registryService := NewService(graphDB, logger)
@@ -37,9 +37,9 @@ func TestRealNeo4jPersistence(t *testing.T) {
 	defer graphInstance.Close(ctx)
 
 	// Clean up any existing test data
-	_ = graphInstance.ClearTestData(ctx)
+	_ = graphInstance.ClearNodesByType(ctx, "agent")
 	// NOTE: Defer cleanup commented out for manual inspection of data
-	// defer graphInstance.ClearTestData(ctx)
+	// defer graphInstance.ClearNodesByType(ctx, "agent")
 
 	// Create agent registry service
 	registryService := NewService(graphInstance, logger)
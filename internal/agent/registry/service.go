@@ -2,32 +2,76 @@ package registry
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"neuromesh/internal/agent/domain"
+	"neuromesh/internal/agent/infrastructure"
 	"neuromesh/internal/graph"
+	"neuromesh/internal/ids"
 	"neuromesh/internal/logging"
 )
 
 // Ensure Service implements AgentRegistry interface
 var _ domain.AgentRegistry = (*Service)(nil)
 
+// ErrAgentNotFound is returned when no agent with the requested ID is registered
+var ErrAgentNotFound = errors.New("agent not found")
+
+// DefaultStaleThreshold is how long an online agent may go without a heartbeat before it is considered stale
+const DefaultStaleThreshold = 31 * time.Second
+
+// CapabilityGCGracePeriod is how long a new capability node may stay unlinked before GC removes it
+const CapabilityGCGracePeriod = time.Minute
+
 // Service handles agent registry operations using graph storage
 type Service struct {
-	graph  graph.Graph
-	logger logging.Logger
+	graph          graph.Graph
+	logger         logging.Logger
+	staleThreshold time.Duration
+	workload       domain.WorkloadCounter
+	capabilities   domain.CapabilityIndex
 }
 
 // NewService creates a new registry service
 func NewService(g graph.Graph, logger logging.Logger) *Service {
+	return NewServiceWithStaleThreshold(g, logger, DefaultStaleThreshold)
+}
+
+// NewServiceWithStaleThreshold creates a registry service that marks agents stale after staleThreshold without a heartbeat
+func NewServiceWithStaleThreshold(g graph.Graph, logger logging.Logger, staleThreshold time.Duration) *Service {
+	if staleThreshold <= 0 {
+		staleThreshold = DefaultStaleThreshold
+	}
+
 	return &Service{
-		graph:  g,
-		logger: logger,
+		graph:          g,
+		logger:         logger,
+		staleThreshold: staleThreshold,
+		capabilities:   infrastructure.NewGraphAgentRepository(g),
 	}
 }
 
+// SetWorkloadCounter enables load-aware agent selection using in-flight step counts
+func (s *Service) SetWorkloadCounter(counter domain.WorkloadCounter) {
+	s.workload = counter
+}
+
+// SetCapabilityIndex replaces the index registrations maintain the agent's capability nodes through
+func (s *Service) SetCapabilityIndex(index domain.CapabilityIndex) {
+	s.capabilities = index
+}
+
+// StaleThreshold returns how long an online agent may go without a heartbeat
+func (s *Service) StaleThreshold() time.Duration {
+	return s.staleThreshold
+}
+
 // RegisterAgent registers a new agent or updates an existing offline agent
 func (s *Service) RegisterAgent(ctx context.Context, agent *domain.Agent) error {
 	if agent == nil {
@@ -50,8 +94,8 @@ func (s *Service) RegisterAgent(ctx context.Context, agent *domain.Agent) error
 	// Serialize metadata to JSON string for Neo4j storage
 	var metadataJSON string
 	if len(agent.Metadata) > 0 {
-		if metadataBytes, err := json.Marshal(agent.Metadata); err == nil {
-			metadataJSON = string(metadataBytes)
+		if encoded, err := graph.EncodeMapProperty(agent.Metadata); err == nil {
+			metadataJSON = encoded
 		}
 	}
 
@@ -77,7 +121,7 @@ func (s *Service) RegisterAgent(ctx context.Context, agent *domain.Agent) error
 	existingAgent, err := s.GetAgent(ctx, agent.ID)
 	if err == nil && existingAgent != nil {
 		// Agent exists, update it (preserving created_at)
-		err = s.graph.UpdateNode(ctx, "agent", agent.ID, properties)
+		err = s.graph.UpdateNode(ctx, "agent", ids.Agent(agent.ID), properties)
 		if err != nil {
 			if s.logger != nil {
 				s.logger.Error("Failed to update existing agent", err, "agent_id", agent.ID)
@@ -90,7 +134,7 @@ func (s *Service) RegisterAgent(ctx context.Context, agent *domain.Agent) error
 	} else {
 		// Agent doesn't exist, create new one
 		properties["created_at"] = time.Now().UTC()
-		err = s.graph.AddNode(ctx, "agent", agent.ID, properties)
+		err = s.graph.AddNode(ctx, "agent", ids.Agent(agent.ID), properties)
 		if err != nil {
 			if s.logger != nil {
 				s.logger.Error("Failed to register agent", err, "agent_id", agent.ID)
@@ -102,6 +146,11 @@ func (s *Service) RegisterAgent(ctx context.Context, agent *domain.Agent) error
 		}
 	}
 
+	// The index adds, updates and drops the capability nodes capability lookups traverse
+	if err := s.capabilities.SyncCapabilities(ctx, agent); err != nil {
+		return fmt.Errorf("failed to index agent capabilities: %w", err)
+	}
+
 	return nil
 }
 
@@ -112,7 +161,7 @@ func (s *Service) UnregisterAgent(ctx context.Context, agentID string) error {
 	}
 
 	// Mark agent as offline instead of deleting (for persistence)
-	err := s.graph.UpdateNode(ctx, "agent", agentID, map[string]interface{}{
+	err := s.graph.UpdateNode(ctx, "agent", ids.Agent(agentID), map[string]interface{}{
 		"status": domain.AgentStatusOffline,
 	})
 	if err != nil {
@@ -132,13 +181,13 @@ func (s *Service) GetAgent(ctx context.Context, agentID string) (*domain.Agent,
 		return nil, fmt.Errorf("agent ID cannot be empty")
 	}
 
-	nodeData, err := s.graph.GetNode(ctx, "agent", agentID)
-	if err != nil {
+	nodeData, err := s.graph.GetNode(ctx, "agent", ids.Agent(agentID))
+	if err != nil && !errors.Is(err, graph.ErrNodeNotFound) {
 		return nil, fmt.Errorf("failed to get agent: %w", err)
 	}
 
 	if nodeData == nil {
-		return nil, fmt.Errorf("agent not found")
+		return nil, fmt.Errorf("%w: %s: %w", ErrAgentNotFound, agentID, graph.ErrNodeNotFound)
 	}
 
 	return s.nodeToAgent(agentID, nodeData)
@@ -176,6 +225,87 @@ func (s *Service) GetAllAgents(ctx context.Context) ([]*domain.Agent, error) {
 	return agents, nil
 }
 
+// GetAgentsPaginated retrieves one page of agents ordered by ID
+// The cursor is the last ID listed, so agents registering or being deleted between requests never shift later pages
+func (s *Service) GetAgentsPaginated(ctx context.Context, opts domain.ListOptions) (domain.AgentPage, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = domain.DefaultAgentPageLimit
+	}
+	lastID, err := decodeCursor(opts.Cursor)
+	if err != nil {
+		return domain.AgentPage{}, err
+	}
+
+	total, err := s.graph.CountNodes(ctx, "agent", nil)
+	if err != nil {
+		return domain.AgentPage{}, fmt.Errorf("failed to count agents: %w", err)
+	}
+
+	// One agent past the page tells whether another page follows
+	queryOpts := graph.QueryOptions{OrderBy: "id", Limit: limit + 1}
+	if lastID != "" {
+		queryOpts.After = lastID
+	}
+	nodes, err := s.graph.QueryNodesWithOptions(ctx, "agent", nil, queryOpts)
+	if err != nil {
+		return domain.AgentPage{}, fmt.Errorf("failed to query agent page: %w", err)
+	}
+	hasMore := len(nodes) > limit
+	if hasMore {
+		nodes = nodes[:limit]
+	}
+
+	page := domain.AgentPage{Agents: make([]*domain.Agent, 0, len(nodes)), Total: total}
+	for _, nodeData := range nodes {
+		agentID, ok := nodeData["id"].(string)
+		if !ok {
+			continue
+		}
+		lastID = agentID
+
+		agent, err := s.nodeToAgent(agentID, nodeData)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Error("Failed to convert node to agent", err, "agent_id", agentID)
+			}
+			continue
+		}
+
+		page.Agents = append(page.Agents, agent)
+	}
+
+	if hasMore {
+		page.NextCursor = encodeCursor(lastID)
+	}
+
+	return page, nil
+}
+
+// cursorPrefix marks cursors issued by the registry, so arbitrary strings are rejected instead of read as agent IDs
+const cursorPrefix = "agent:"
+
+// encodeCursor turns the last agent ID of a page into an opaque cursor
+func encodeCursor(lastID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + lastID))
+}
+
+// decodeCursor returns the last agent ID a cursor points after; an empty cursor is the first page
+func decodeCursor(cursor string) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", domain.ErrInvalidCursor
+	}
+	lastID, ok := strings.CutPrefix(string(raw), cursorPrefix)
+	if !ok || lastID == "" {
+		return "", domain.ErrInvalidCursor
+	}
+	return lastID, nil
+}
+
 // GetOnlineAgents retrieves all online agents
 func (s *Service) GetOnlineAgents(ctx context.Context) ([]*domain.Agent, error) {
 	return s.GetAgentsByStatus(ctx, domain.AgentStatusOnline)
@@ -223,13 +353,51 @@ func (s *Service) GetAgentsByCapability(ctx context.Context, capability string)
 		return nil, fmt.Errorf("capability cannot be empty")
 	}
 
-	// Get all agents and filter by capability
-	nodes, err := s.graph.QueryNodes(ctx, "agent", nil)
+	agents, err := s.agentsWithCapability(ctx, capability)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query agents: %w", err)
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.Debug("Found agents by capability", "capability", capability, "count", len(agents))
+	}
+
+	return agents, nil
+}
+
+// FindAgentsByCapability finds online agents with a specific capability, ordered by agent ID
+func (s *Service) FindAgentsByCapability(ctx context.Context, capability string) ([]*domain.Agent, error) {
+	if capability == "" {
+		return nil, fmt.Errorf("capability cannot be empty")
+	}
+
+	capableAgents, err := s.agentsWithCapability(ctx, capability)
+	if err != nil {
+		return nil, err
 	}
 
 	var agents []*domain.Agent
+	for _, agent := range capableAgents {
+		if agent.Status == domain.AgentStatusOnline {
+			agents = append(agents, agent)
+		}
+	}
+
+	sort.Slice(agents, func(i, j int) bool {
+		return agents[i].ID < agents[j].ID
+	})
+
+	return agents, nil
+}
+
+// agentsWithCapability traverses from the capability nodes with the given name, so only matching agents are loaded
+func (s *Service) agentsWithCapability(ctx context.Context, capability string) ([]*domain.Agent, error) {
+	nodes, err := s.graph.QueryNodesByRelated(ctx, "agent", "HAS_CAPABILITY", "capability", map[string]interface{}{"name": capability})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query agents by capability: %w", err)
+	}
+
+	agents := make([]*domain.Agent, 0, len(nodes))
 	for _, nodeData := range nodes {
 		agentID, ok := nodeData["id"].(string)
 		if !ok {
@@ -244,17 +412,120 @@ func (s *Service) GetAgentsByCapability(ctx context.Context, capability string)
 			continue
 		}
 
-		// Check if agent has the required capability
-		if s.hasCapability(agent, capability) {
-			agents = append(agents, agent)
+		agents = append(agents, agent)
+	}
+
+	return agents, nil
+}
+
+// FindBestAgentForCapability selects the least-loaded, then least-recently-busy, online agent with a specific capability
+func (s *Service) FindBestAgentForCapability(ctx context.Context, capability string) (*domain.Agent, error) {
+	agents, err := s.FindAgentsByCapability(ctx, capability)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(agents) == 0 {
+		return nil, fmt.Errorf("no online agent found with capability: %s", capability)
+	}
+
+	activeSteps, err := s.activeStepCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Agents are already ordered by ID, so a stable sort keeps ties deterministic
+	sort.SliceStable(agents, func(i, j int) bool {
+		if activeSteps[agents[i].ID] != activeSteps[agents[j].ID] {
+			return activeSteps[agents[i].ID] < activeSteps[agents[j].ID]
 		}
+		return agents[i].LastBusyAt.Before(agents[j].LastBusyAt)
+	})
+
+	return agents[0], nil
+}
+
+// GetAgentWorkload returns every agent's status with its in-flight step count, least loaded first
+func (s *Service) GetAgentWorkload(ctx context.Context) ([]domain.AgentWorkload, error) {
+	agents, err := s.GetAllAgents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agents: %w", err)
 	}
 
-	if s.logger != nil {
-		s.logger.Debug("Found agents by capability", "capability", capability, "count", len(agents))
+	activeSteps, err := s.activeStepCounts(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return agents, nil
+	workloads := make([]domain.AgentWorkload, 0, len(agents))
+	for _, agent := range agents {
+		workloads = append(workloads, domain.AgentWorkload{
+			AgentID:     agent.ID,
+			Status:      agent.Status,
+			ActiveSteps: activeSteps[agent.ID],
+		})
+	}
+
+	sort.Slice(workloads, func(i, j int) bool {
+		if workloads[i].ActiveSteps != workloads[j].ActiveSteps {
+			return workloads[i].ActiveSteps < workloads[j].ActiveSteps
+		}
+		return workloads[i].AgentID < workloads[j].AgentID
+	})
+
+	return workloads, nil
+}
+
+// activeStepCounts returns in-flight step counts per agent, or none when no workload counter is set
+func (s *Service) activeStepCounts(ctx context.Context) (map[string]int, error) {
+	if s.workload == nil {
+		return map[string]int{}, nil
+	}
+
+	counts, err := s.workload.GetActiveStepCountByAgent(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent workload: %w", err)
+	}
+
+	return counts, nil
+}
+
+// BuildAgentContext renders all online agents in the format the AI planning prompts expect
+func (s *Service) BuildAgentContext(ctx context.Context) (string, error) {
+	agents, err := s.GetOnlineAgents(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get online agents: %w", err)
+	}
+
+	if len(agents) == 0 {
+		return "No agents currently registered", nil
+	}
+
+	sort.Slice(agents, func(i, j int) bool {
+		return agents[i].ID < agents[j].ID
+	})
+
+	var agentContext strings.Builder
+	agentContext.WriteString("Available Agents:\n")
+
+	for _, agent := range agents {
+		capabilities := "none"
+		if len(agent.Capabilities) > 0 {
+			capabilityNames := make([]string, len(agent.Capabilities))
+			for i, capability := range agent.Capabilities {
+				capabilityNames[i] = capability.Name
+			}
+			capabilities = strings.Join(capabilityNames, ", ")
+		}
+
+		agentContext.WriteString(fmt.Sprintf("- %s | Status: %s | Capabilities: %s", agent.ID, agent.Status, capabilities))
+		if agent.Description != "" {
+			agentContext.WriteString(fmt.Sprintf(" | Description: %s", agent.Description))
+		}
+		agentContext.WriteString("\n")
+	}
+
+	return agentContext.String(), nil
 }
 
 // UpdateAgentStatus updates an agent's status
@@ -268,7 +539,12 @@ func (s *Service) UpdateAgentStatus(ctx context.Context, agentID string, status
 		"updated_at": time.Now().UTC(),
 	}
 
-	err := s.graph.UpdateNode(ctx, "agent", agentID, properties)
+	// Track when the agent was last busy so work can be spread across agents
+	if status == domain.AgentStatusBusy {
+		properties["last_busy_at"] = time.Now().UTC()
+	}
+
+	err := s.graph.UpdateNode(ctx, "agent", ids.Agent(agentID), properties)
 	if err != nil {
 		return fmt.Errorf("failed to update agent status: %w", err)
 	}
@@ -291,7 +567,7 @@ func (s *Service) UpdateAgentLastSeen(ctx context.Context, agentID string) error
 		"updated_at": time.Now().UTC(),
 	}
 
-	err := s.graph.UpdateNode(ctx, "agent", agentID, properties)
+	err := s.graph.UpdateNode(ctx, "agent", ids.Agent(agentID), properties)
 	if err != nil {
 		return fmt.Errorf("failed to update agent last seen: %w", err)
 	}
@@ -322,7 +598,8 @@ func (s *Service) IsAgentHealthy(ctx context.Context, agentID string) (bool, err
 	return true, nil
 }
 
-// MonitorAgentHealth checks all agents and marks disconnected ones as such
+// MonitorAgentHealth marks online agents whose heartbeat is older than the stale threshold as offline
+// Error is reserved for agent-reported failures
 func (s *Service) MonitorAgentHealth(ctx context.Context) error {
 	// Get all online agents
 	onlineAgents, err := s.GetAgentsByStatus(ctx, domain.AgentStatusOnline)
@@ -332,22 +609,22 @@ func (s *Service) MonitorAgentHealth(ctx context.Context) error {
 
 	// Check each agent's health
 	for _, agent := range onlineAgents {
-		if time.Since(agent.LastSeen) >= 31*time.Second {
-			// Mark agent as disconnected
-			err := s.UpdateAgentStatus(ctx, agent.ID, domain.AgentStatusDisconnected)
+		if time.Since(agent.LastSeen) >= s.staleThreshold {
+			// Mark agent as offline
+			err := s.UpdateAgentStatus(ctx, agent.ID, domain.AgentStatusOffline)
 			if err != nil {
 				if s.logger != nil {
-					s.logger.Error("Failed to mark agent as disconnected", err, "agent_id", agent.ID)
+					s.logger.Error("Failed to mark agent as offline", err, "agent_id", agent.ID)
 				}
 				// Continue with other agents even if one fails
 				continue
 			}
 
 			if s.logger != nil {
-				s.logger.Info("Agent marked as disconnected due to missed heartbeat",
+				s.logger.Info("Agent marked as offline due to missed heartbeat",
 					"agent_id", agent.ID,
 					"last_seen", agent.LastSeen,
-					"timeout_seconds", 31)
+					"stale_threshold", s.staleThreshold.String())
 			}
 		}
 	}
@@ -355,6 +632,21 @@ func (s *Service) MonitorAgentHealth(ctx context.Context) error {
 	return nil
 }
 
+// GC deletes capability nodes that no agent links to with HAS_CAPABILITY, such as those left by failed registrations
+// Capabilities younger than CapabilityGCGracePeriod are kept, since a registration links its capability nodes just after creating them
+// It returns the number of capability nodes removed
+func (s *Service) GC(ctx context.Context) (int, error) {
+	removed, err := s.graph.DeleteOrphanedNodes(ctx, "capability", "HAS_CAPABILITY", time.Now().UTC().Add(-CapabilityGCGracePeriod))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete orphaned capability nodes: %w", err)
+	}
+
+	if removed > 0 && s.logger != nil {
+		s.logger.Info("Removed orphaned capability nodes", "count", removed)
+	}
+	return removed, nil
+}
+
 // Helper methods
 
 // nodeToAgent converts a graph node to an Agent domain object
@@ -392,6 +684,10 @@ func (s *Service) nodeToAgent(agentID string, nodeData map[string]interface{}) (
 		agent.UpdatedAt = updatedAtTime
 	}
 
+	if lastBusyAtTime, ok := nodeData["last_busy_at"].(time.Time); ok {
+		agent.LastBusyAt = lastBusyAtTime
+	}
+
 	// Parse capabilities JSON
 	if capabilitiesJSON, ok := nodeData["capabilities"].(string); ok && capabilitiesJSON != "" {
 		var capabilities []domain.AgentCapability
@@ -416,13 +712,3 @@ func (s *Service) nodeToAgent(agentID string, nodeData map[string]interface{}) (
 
 	return agent, nil
 }
-
-// hasCapability checks if an agent has a specific capability
-func (s *Service) hasCapability(agent *domain.Agent, capability string) bool {
-	for _, cap := range agent.Capabilities {
-		if cap.Name == capability {
-			return true
-		}
-	}
-	return false
-}
@@ -2,6 +2,8 @@ package registry_test
 
 import (
 	"context"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"neuromesh/internal/agent/domain"
+	"neuromesh/internal/agent/infrastructure"
 	"neuromesh/internal/agent/registry"
 	"neuromesh/internal/logging"
 	"neuromesh/testHelpers"
@@ -168,6 +171,206 @@ func TestAgentRegistry_GetAgentsByCapability(t *testing.T) {
 	assert.Contains(t, agentIDs, "agent-3")
 }
 
+func TestAgentRegistry_FindAgentsByCapability(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	logger := logging.NewStructuredLogger(logging.LevelError)
+
+	testGraph := testHelpers.NewCleanMockGraph()
+
+	registryService := registry.NewService(testGraph, logger)
+
+	wordCount := domain.AgentCapability{Name: "word-count", Description: "Count words"}
+	agents := []*domain.Agent{
+		{ID: "agent-c", Name: "Online Counter C", Status: domain.AgentStatusOnline, Capabilities: []domain.AgentCapability{wordCount}},
+		{ID: "agent-a", Name: "Online Counter A", Status: domain.AgentStatusOnline, Capabilities: []domain.AgentCapability{wordCount}},
+		{ID: "agent-busy", Name: "Busy Counter", Status: domain.AgentStatusBusy, Capabilities: []domain.AgentCapability{wordCount}},
+		{ID: "agent-offline", Name: "Offline Counter", Status: domain.AgentStatusOffline, Capabilities: []domain.AgentCapability{wordCount}},
+		{ID: "agent-image", Name: "Image Processor", Status: domain.AgentStatusOnline, Capabilities: []domain.AgentCapability{
+			{Name: "image-processing", Description: "Process images"},
+		}},
+	}
+
+	for _, agent := range agents {
+		require.NoError(t, registryService.RegisterAgent(ctx, agent))
+	}
+
+	t.Run("returns only online agents with capability in ID order", func(t *testing.T) {
+		found, err := registryService.FindAgentsByCapability(ctx, "word-count")
+
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, "agent-a", found[0].ID)
+		assert.Equal(t, "agent-c", found[1].ID)
+	})
+
+	t.Run("returns empty result for unknown capability", func(t *testing.T) {
+		found, err := registryService.FindAgentsByCapability(ctx, "translation")
+
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("rejects empty capability", func(t *testing.T) {
+		_, err := registryService.FindAgentsByCapability(ctx, "")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("traverses from the capability instead of loading every agent", func(t *testing.T) {
+		traversingGraph := testHelpers.NewTestifyMockGraph().(*testHelpers.TestifyMockGraph)
+		traversingGraph.On("QueryNodesByRelated", ctx, "agent", "HAS_CAPABILITY", "capability", map[string]interface{}{"name": "word-count"}).
+			Return([]map[string]interface{}{{"id": "agent-a", "name": "Online Counter A", "status": "online"}}, nil)
+
+		found, err := registry.NewService(traversingGraph, logger).FindAgentsByCapability(ctx, "word-count")
+
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, "agent-a", found[0].ID)
+		traversingGraph.AssertNotCalled(t, "QueryNodes")
+	})
+}
+
+func TestAgentRegistry_FindBestAgentForCapability(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	logger := logging.NewStructuredLogger(logging.LevelError)
+
+	testGraph := testHelpers.NewCleanMockGraph()
+
+	registryService := registry.NewService(testGraph, logger)
+
+	wordCount := domain.AgentCapability{Name: "word-count", Description: "Count words"}
+	for _, id := range []string{"agent-a", "agent-b", "agent-c"} {
+		require.NoError(t, registryService.RegisterAgent(ctx, &domain.Agent{
+			ID:           id,
+			Name:         "Counter " + id,
+			Status:       domain.AgentStatusOnline,
+			Capabilities: []domain.AgentCapability{wordCount},
+		}))
+	}
+	require.NoError(t, registryService.RegisterAgent(ctx, &domain.Agent{
+		ID:           "agent-offline",
+		Name:         "Offline Counter",
+		Status:       domain.AgentStatusOffline,
+		Capabilities: []domain.AgentCapability{wordCount},
+	}))
+
+	markBusyThenOnline := func(agentID string) {
+		require.NoError(t, registryService.UpdateAgentStatus(ctx, agentID, domain.AgentStatusBusy))
+		require.NoError(t, registryService.UpdateAgentStatus(ctx, agentID, domain.AgentStatusOnline))
+	}
+
+	t.Run("prefers agents that have never been busy, ordered by ID", func(t *testing.T) {
+		best, err := registryService.FindBestAgentForCapability(ctx, "word-count")
+
+		require.NoError(t, err)
+		assert.Equal(t, "agent-a", best.ID)
+	})
+
+	t.Run("prefers the least recently busy agent", func(t *testing.T) {
+		markBusyThenOnline("agent-a")
+		time.Sleep(time.Millisecond)
+		markBusyThenOnline("agent-b")
+		time.Sleep(time.Millisecond)
+		markBusyThenOnline("agent-c")
+
+		best, err := registryService.FindBestAgentForCapability(ctx, "word-count")
+		require.NoError(t, err)
+		assert.Equal(t, "agent-a", best.ID)
+
+		time.Sleep(time.Millisecond)
+		markBusyThenOnline("agent-a")
+
+		best, err = registryService.FindBestAgentForCapability(ctx, "word-count")
+		require.NoError(t, err)
+		assert.Equal(t, "agent-b", best.ID)
+	})
+
+	t.Run("skips agents that are currently busy", func(t *testing.T) {
+		require.NoError(t, registryService.UpdateAgentStatus(ctx, "agent-b", domain.AgentStatusBusy))
+
+		best, err := registryService.FindBestAgentForCapability(ctx, "word-count")
+		require.NoError(t, err)
+		assert.Equal(t, "agent-c", best.ID)
+	})
+
+	t.Run("returns error when no online agent has capability", func(t *testing.T) {
+		best, err := registryService.FindBestAgentForCapability(ctx, "translation")
+
+		assert.Error(t, err)
+		assert.Nil(t, best)
+		assert.Contains(t, err.Error(), "no online agent found with capability")
+	})
+}
+
+func TestAgentRegistry_BuildAgentContext(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	logger := logging.NewStructuredLogger(logging.LevelError)
+
+	testGraph := testHelpers.NewCleanMockGraph()
+
+	registryService := registry.NewService(testGraph, logger)
+
+	agents := []*domain.Agent{
+		{
+			ID:          "text-processor",
+			Name:        "Text Processor",
+			Description: "Processes and analyzes text",
+			Status:      domain.AgentStatusOnline,
+			Capabilities: []domain.AgentCapability{
+				{Name: "word-count", Description: "Count words"},
+				{Name: "text-analysis", Description: "Analyze text"},
+			},
+		},
+		{
+			ID:     "idle-agent",
+			Name:   "Idle Agent",
+			Status: domain.AgentStatusOnline,
+		},
+		{
+			ID:     "offline-agent",
+			Name:   "Offline Agent",
+			Status: domain.AgentStatusOffline,
+			Capabilities: []domain.AgentCapability{
+				{Name: "translation", Description: "Translate text"},
+			},
+		},
+	}
+
+	for _, agent := range agents {
+		require.NoError(t, registryService.RegisterAgent(ctx, agent))
+	}
+
+	// Act
+	agentContext, err := registryService.BuildAgentContext(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Available Agents:\n"+
+		"- idle-agent | Status: online | Capabilities: none\n"+
+		"- text-processor | Status: online | Capabilities: word-count, text-analysis | Description: Processes and analyzes text\n",
+		agentContext)
+
+	// Every agent line must follow the format the planning prompts parse
+	agentLine := regexp.MustCompile(`^- [\w-]+ \| Status: \w+ \| Capabilities: [^|]+( \| Description: .+)?$`)
+	lines := strings.Split(strings.TrimSpace(agentContext), "\n")
+	for _, line := range lines[1:] {
+		assert.Regexp(t, agentLine, line)
+	}
+	assert.NotContains(t, agentContext, "offline-agent")
+}
+
+func TestAgentRegistry_BuildAgentContext_NoAgents(t *testing.T) {
+	registryService := registry.NewService(testHelpers.NewCleanMockGraph(), logging.NewStructuredLogger(logging.LevelError))
+
+	agentContext, err := registryService.BuildAgentContext(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "No agents currently registered", agentContext)
+}
+
 func TestAgentRegistry_UpdateAgentStatus(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
@@ -317,11 +520,11 @@ func TestAgentRegistry_MonitorAgentHealth_AutoDisconnect(t *testing.T) {
 	// Assert
 	assert.NoError(t, err, "MonitorAgentHealth should execute without error")
 
-	// Verify agent status was updated to Disconnected
+	// Verify agent status was updated to Offline
 	updatedAgent, err := registryService.GetAgent(ctx, agentID)
 	require.NoError(t, err)
-	assert.Equal(t, domain.AgentStatusDisconnected, updatedAgent.Status,
-		"Agent should be marked as Disconnected after health monitoring")
+	assert.Equal(t, domain.AgentStatusOffline, updatedAgent.Status,
+		"Agent should be marked as Offline after health monitoring")
 }
 
 // Interface compliance test
@@ -333,3 +536,344 @@ func TestAgentRegistry_ImplementsInterface(t *testing.T) {
 	// Act & Assert - This will fail to compile if Service doesn't implement AgentRegistry
 	var _ domain.AgentRegistry = registry.NewService(testGraph, logger)
 }
+
+func TestAgentRegistry_MonitorAgentHealth_OnlyStaleAgentsTransitioned(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	logger := logging.NewStructuredLogger(logging.LevelError)
+	testGraph := testHelpers.NewCleanMockGraph()
+	registryService := registry.NewServiceWithStaleThreshold(testGraph, logger, 90*time.Second)
+	assert.Equal(t, 90*time.Second, registryService.StaleThreshold())
+
+	newAgent := func(id string, lastSeen time.Time) *domain.Agent {
+		return &domain.Agent{
+			ID:     id,
+			Name:   id,
+			Status: domain.AgentStatusOnline,
+			Capabilities: []domain.AgentCapability{
+				{Name: "test", Description: "Test capability"},
+			},
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			LastSeen:  lastSeen,
+		}
+	}
+	require.NoError(t, registryService.RegisterAgent(ctx, newAgent("fresh-agent", time.Now().Add(-60*time.Second))))
+	require.NoError(t, registryService.RegisterAgent(ctx, newAgent("stale-agent", time.Now().Add(-120*time.Second))))
+
+	// Act
+	err := registryService.MonitorAgentHealth(ctx)
+
+	// Assert
+	require.NoError(t, err)
+
+	fresh, err := registryService.GetAgent(ctx, "fresh-agent")
+	require.NoError(t, err)
+	assert.Equal(t, domain.AgentStatusOnline, fresh.Status, "Agent within the stale threshold should stay online")
+
+	stale, err := registryService.GetAgent(ctx, "stale-agent")
+	require.NoError(t, err)
+	assert.Equal(t, domain.AgentStatusOffline, stale.Status, "Agent past the stale threshold should be transitioned")
+}
+
+func TestAgentRegistry_UpdateAgentStatus_OfflineRoundTrip(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	logger := logging.NewStructuredLogger(logging.LevelError)
+	registryService := registry.NewService(testHelpers.NewCleanMockGraph(), logger)
+
+	agent := &domain.Agent{
+		ID:     "offline-agent",
+		Name:   "Offline Agent",
+		Status: domain.AgentStatusOnline,
+		Capabilities: []domain.AgentCapability{
+			{Name: "test", Description: "Test capability"},
+		},
+		LastSeen: time.Now(),
+	}
+	require.NoError(t, registryService.RegisterAgent(ctx, agent))
+
+	// Act
+	err := registryService.UpdateAgentStatus(ctx, agent.ID, domain.AgentStatusOffline)
+
+	// Assert
+	require.NoError(t, err)
+
+	stored, err := registryService.GetAgent(ctx, agent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AgentStatusOffline, stored.Status)
+	assert.True(t, stored.Status.IsValid())
+
+	offlineAgents, err := registryService.GetAgentsByStatus(ctx, domain.AgentStatusOffline)
+	require.NoError(t, err)
+	require.Len(t, offlineAgents, 1)
+	assert.Equal(t, agent.ID, offlineAgents[0].ID)
+}
+
+func TestAgentRegistry_RegisterAgent_DuplicateUpdatesExisting(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	logger := logging.NewStructuredLogger(logging.LevelError)
+	registryService := registry.NewService(testHelpers.NewCleanMockGraph(), logger)
+
+	first := &domain.Agent{
+		ID:     "restarting-agent",
+		Name:   "Restarting Agent",
+		Status: domain.AgentStatusOnline,
+		Capabilities: []domain.AgentCapability{
+			{Name: "word-count", Description: "Counts words"},
+		},
+		LastSeen: time.Now().Add(-time.Hour),
+	}
+	require.NoError(t, registryService.RegisterAgent(ctx, first))
+
+	original, err := registryService.GetAgent(ctx, first.ID)
+	require.NoError(t, err)
+	require.NoError(t, registryService.UpdateAgentStatus(ctx, first.ID, domain.AgentStatusOffline))
+
+	// Act - the agent restarts with a changed capability set
+	restarted := &domain.Agent{
+		ID:     first.ID,
+		Name:   first.Name,
+		Status: domain.AgentStatusOnline,
+		Capabilities: []domain.AgentCapability{
+			{Name: "word-count", Description: "Counts words"},
+			{Name: "sentiment", Description: "Detects sentiment"},
+		},
+		LastSeen: time.Now(),
+	}
+	err = registryService.RegisterAgent(ctx, restarted)
+
+	// Assert
+	require.NoError(t, err, "Re-registering an existing agent should update it")
+
+	updated, err := registryService.GetAgent(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AgentStatusOnline, updated.Status)
+	assert.True(t, updated.HasCapability("sentiment"), "New capabilities should be reflected")
+	assert.Len(t, updated.Capabilities, 2)
+	assert.True(t, updated.LastSeen.After(original.LastSeen), "last_seen should be refreshed")
+	assert.Equal(t, original.CreatedAt, updated.CreatedAt, "created_at should be preserved")
+}
+
+// stubWorkloadCounter returns fixed in-flight step counts per agent
+type stubWorkloadCounter map[string]int
+
+func (s stubWorkloadCounter) GetActiveStepCountByAgent(ctx context.Context) (map[string]int, error) {
+	return s, nil
+}
+
+func TestAgentRegistry_GetAgentWorkload(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	logger := logging.NewStructuredLogger(logging.LevelError)
+	registryService := registry.NewService(testHelpers.NewCleanMockGraph(), logger)
+	registryService.SetWorkloadCounter(stubWorkloadCounter{"busy-agent": 3, "light-agent": 1})
+
+	for _, id := range []string{"busy-agent", "light-agent", "idle-agent"} {
+		require.NoError(t, registryService.RegisterAgent(ctx, &domain.Agent{
+			ID:     id,
+			Name:   id,
+			Status: domain.AgentStatusOnline,
+			Capabilities: []domain.AgentCapability{
+				{Name: "word-count", Description: "Counts words"},
+			},
+			LastSeen: time.Now(),
+		}))
+	}
+
+	// Act
+	workloads, err := registryService.GetAgentWorkload(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []domain.AgentWorkload{
+		{AgentID: "idle-agent", Status: domain.AgentStatusOnline, ActiveSteps: 0},
+		{AgentID: "light-agent", Status: domain.AgentStatusOnline, ActiveSteps: 1},
+		{AgentID: "busy-agent", Status: domain.AgentStatusOnline, ActiveSteps: 3},
+	}, workloads)
+
+	best, err := registryService.FindBestAgentForCapability(ctx, "word-count")
+	require.NoError(t, err)
+	assert.Equal(t, "idle-agent", best.ID, "The least-loaded agent should be preferred")
+}
+
+// capabilityEvents keeps the capability change events the registry reports
+type capabilityEvents []*domain.CapabilitiesChangedEvent
+
+func (e *capabilityEvents) CapabilitiesChanged(ctx context.Context, event *domain.CapabilitiesChangedEvent) {
+	*e = append(*e, event)
+}
+
+func TestAgentRegistry_RegisterAgent_ReportsChangedCapabilities(t *testing.T) {
+	ctx := context.Background()
+	testGraph := testHelpers.NewCleanMockGraph()
+	registryService := registry.NewService(testGraph, logging.NewNoOpLogger())
+	events := &capabilityEvents{}
+	capabilityIndex := infrastructure.NewGraphAgentRepository(testGraph)
+	capabilityIndex.SetCapabilityChangeListener(events)
+	registryService.SetCapabilityIndex(capabilityIndex)
+
+	register := func(capabilities ...string) {
+		agent := &domain.Agent{ID: "text-processor", Name: "Text Processor", Status: domain.AgentStatusOnline}
+		for _, name := range capabilities {
+			agent.Capabilities = append(agent.Capabilities, domain.AgentCapability{Name: name})
+		}
+		require.NoError(t, registryService.RegisterAgent(ctx, agent))
+	}
+
+	register("word-count", "summarize")
+	register("summarize", "translate")
+	register("summarize", "translate")
+
+	require.Len(t, *events, 1, "only the upgrade changed the capabilities")
+	assert.Equal(t, []string{"translate"}, (*events)[0].Added)
+	assert.Equal(t, []string{"word-count"}, (*events)[0].Removed)
+
+	stored, err := registryService.GetAgent(ctx, "text-processor")
+	require.NoError(t, err)
+	assert.False(t, stored.HasCapability("word-count"))
+	assert.True(t, stored.HasCapability("summarize"))
+	assert.True(t, stored.HasCapability("translate"))
+
+	// Registration keeps the capability nodes capability lookups traverse
+	for capability, linked := range map[string]bool{"word-count": false, "summarize": true, "translate": true} {
+		agents, err := capabilityIndex.GetByCapability(ctx, capability)
+		require.NoError(t, err)
+		assert.Equal(t, linked, len(agents) == 1, capability)
+	}
+}
+
+func TestAgentRegistry_GetAgentsPaginated_WalksStablePages(t *testing.T) {
+	ctx := context.Background()
+	registryService := registry.NewService(testHelpers.NewCleanMockGraph(), logging.NewNoOpLogger())
+
+	register := func(id string) {
+		agent := &domain.Agent{ID: id, Name: id, Status: domain.AgentStatusOnline,
+			Capabilities: []domain.AgentCapability{{Name: "word-count"}}}
+		require.NoError(t, registryService.RegisterAgent(ctx, agent))
+	}
+	for _, id := range []string{"agent-c", "agent-a", "agent-e", "agent-b", "agent-d"} {
+		register(id)
+	}
+
+	first, err := registryService.GetAgentsPaginated(ctx, domain.ListOptions{Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 5, first.Total)
+	require.Len(t, first.Agents, 2)
+	require.NotEmpty(t, first.NextCursor)
+
+	// An agent registering between page requests joins the end without shifting earlier pages
+	register("agent-f")
+
+	var seen []string
+	for _, agent := range first.Agents {
+		seen = append(seen, agent.ID)
+	}
+	page := first
+	for page.NextCursor != "" {
+		page, err = registryService.GetAgentsPaginated(ctx, domain.ListOptions{Limit: 2, Cursor: page.NextCursor})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(page.Agents), 2)
+		for _, agent := range page.Agents {
+			seen = append(seen, agent.ID)
+		}
+	}
+
+	assert.Equal(t, 6, page.Total)
+	assert.Len(t, seen, 6, "every agent is listed exactly once")
+	assert.ElementsMatch(t, []string{"agent-a", "agent-b", "agent-c", "agent-d", "agent-e", "agent-f"}, seen)
+	assert.Equal(t, []string{"agent-a", "agent-b", "agent-c", "agent-d", "agent-e", "agent-f"}, seen, "pages follow agent ID order")
+
+	// Listing everything in one page gives the same order
+	all, err := registryService.GetAgentsPaginated(ctx, domain.ListOptions{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, all.NextCursor)
+	require.Len(t, all.Agents, 6)
+	for i, agent := range all.Agents {
+		assert.Equal(t, seen[i], agent.ID)
+	}
+}
+
+func TestAgentRegistry_GetAgentsPaginated_SurvivesDeletedAgents(t *testing.T) {
+	ctx := context.Background()
+	g := testHelpers.NewCleanMockGraph()
+	registryService := registry.NewService(g, logging.NewNoOpLogger())
+
+	for _, id := range []string{"agent-a", "agent-b", "agent-c", "agent-d", "agent-e"} {
+		require.NoError(t, registryService.RegisterAgent(ctx, &domain.Agent{ID: id, Name: id, Status: domain.AgentStatusOnline,
+			Capabilities: []domain.AgentCapability{{Name: "word-count"}}}))
+	}
+
+	first, err := registryService.GetAgentsPaginated(ctx, domain.ListOptions{Limit: 2})
+	require.NoError(t, err)
+	require.NotEmpty(t, first.NextCursor)
+
+	// Deleting agents already listed or not yet reached must not make the next page skip anyone
+	require.NoError(t, g.DeleteNode(ctx, "agent", "agent-a"))
+	require.NoError(t, g.DeleteNode(ctx, "agent", "agent-c"))
+
+	second, err := registryService.GetAgentsPaginated(ctx, domain.ListOptions{Limit: 2, Cursor: first.NextCursor})
+	require.NoError(t, err)
+
+	var ids []string
+	for _, agent := range second.Agents {
+		ids = append(ids, agent.ID)
+	}
+	assert.Equal(t, []string{"agent-d", "agent-e"}, ids)
+	assert.Empty(t, second.NextCursor, "no page follows the last agent")
+}
+
+func TestAgentRegistry_GetAgentsPaginated_RejectsForeignCursor(t *testing.T) {
+	registryService := registry.NewService(testHelpers.NewCleanMockGraph(), logging.NewNoOpLogger())
+
+	_, err := registryService.GetAgentsPaginated(context.Background(), domain.ListOptions{Cursor: "not-a-cursor"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func TestAgentRegistry_GC_RemovesOrphanedCapabilities(t *testing.T) {
+	ctx := context.Background()
+	testGraph := testHelpers.NewCleanMockGraph()
+	registryService := registry.NewService(testGraph, logging.NewStructuredLogger(logging.LevelError))
+
+	// The repository links each capability node to its agent with HAS_CAPABILITY
+	require.NoError(t, infrastructure.NewGraphAgentRepository(testGraph).Create(ctx, &domain.Agent{
+		ID:           "text-agent",
+		Name:         "Text Agent",
+		Status:       domain.AgentStatusOnline,
+		Capabilities: []domain.AgentCapability{{Name: "word-count"}, {Name: "uppercase"}},
+	}))
+	// A registration that failed after creating the capability node leaves it unlinked
+	require.NoError(t, testGraph.AddNode(ctx, "capability", "capability:crashed-agent:deploy", map[string]interface{}{"name": "deploy"}))
+
+	removed, err := registryService.GC(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	orphan, _ := testGraph.GetNode(ctx, "capability", "capability:crashed-agent:deploy")
+	assert.Nil(t, orphan, "orphaned capability should be deleted")
+	for _, linkedID := range []string{"capability:text-agent:word-count", "capability:text-agent:uppercase"} {
+		node, err := testGraph.GetNode(ctx, "capability", linkedID)
+		require.NoError(t, err)
+		assert.NotNil(t, node, "linked capability %s should be kept", linkedID)
+	}
+
+	t.Run("does nothing once no orphans are left", func(t *testing.T) {
+		removed, err := registryService.GC(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, removed)
+	})
+
+	t.Run("spares a capability whose registration is still linking it", func(t *testing.T) {
+		require.NoError(t, testGraph.AddNode(ctx, "capability", "capability:new-agent:deploy", map[string]interface{}{"name": "deploy", "created_at": time.Now().UTC()}))
+
+		removed, err := registryService.GC(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, removed)
+
+		node, err := testGraph.GetNode(ctx, "capability", "capability:new-agent:deploy")
+		require.NoError(t, err)
+		assert.NotNil(t, node)
+	})
+}
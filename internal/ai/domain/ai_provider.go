@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"sync"
 )
 
 // AIProvider defines the core domain interface for AI inference
@@ -10,6 +11,16 @@ type AIProvider interface {
 	// CallAI performs AI inference with system and user prompts
 	CallAI(ctx context.Context, systemPrompt, userPrompt string) (string, error)
 
+	// CallAIWithOptions performs AI inference, overriding provider defaults with opts
+	CallAIWithOptions(ctx context.Context, systemPrompt, userPrompt string, opts CallOptions) (string, error)
+
+	// CallAIWithUsage performs AI inference with opts and reports the tokens consumed by the call
+	CallAIWithUsage(ctx context.Context, systemPrompt, userPrompt string, opts CallOptions) (string, Usage, error)
+
+	// CallAIStream performs AI inference and emits content chunks as they arrive
+	// The channel is closed when the completion finishes or fails
+	CallAIStream(ctx context.Context, systemPrompt, userPrompt string) (<-chan string, error)
+
 	// GetProviderInfo returns metadata about the provider
 	GetProviderInfo() *ProviderInfo
 
@@ -17,6 +28,81 @@ type AIProvider interface {
 	Close() error
 }
 
+// CallOptions overrides provider defaults for a single call; zero values keep the defaults
+type CallOptions struct {
+	Model       string   `json:"model,omitempty"`
+	Temperature *float32 `json:"temperature,omitempty"`
+	MaxTokens   int      `json:"max_tokens,omitempty"`
+	TopP        *float32 `json:"top_p,omitempty"`
+}
+
+// WithTemperature returns a copy of the options with the given temperature
+func (o CallOptions) WithTemperature(temperature float32) CallOptions {
+	o.Temperature = &temperature
+	return o
+}
+
+// WithTopP returns a copy of the options with the given nucleus sampling value
+func (o CallOptions) WithTopP(topP float32) CallOptions {
+	o.TopP = &topP
+	return o
+}
+
+// Usage reports the tokens consumed by one or more AI calls
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// Add returns the sum of two usages
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		PromptTokens:     u.PromptTokens + other.PromptTokens,
+		CompletionTokens: u.CompletionTokens + other.CompletionTokens,
+		TotalTokens:      u.TotalTokens + other.TotalTokens,
+	}
+}
+
+// UsageRecorder accumulates token usage across the AI calls of one request
+type UsageRecorder struct {
+	mu    sync.Mutex
+	usage Usage
+}
+
+// NewUsageRecorder creates an empty usage recorder
+func NewUsageRecorder() *UsageRecorder {
+	return &UsageRecorder{}
+}
+
+// Record adds usage to the running total
+func (r *UsageRecorder) Record(usage Usage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usage = r.usage.Add(usage)
+}
+
+// Total returns the accumulated usage
+func (r *UsageRecorder) Total() Usage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.usage
+}
+
+type usageRecorderKey struct{}
+
+// WithUsageRecorder returns a context that accumulates token usage into recorder
+func WithUsageRecorder(ctx context.Context, recorder *UsageRecorder) context.Context {
+	return context.WithValue(ctx, usageRecorderKey{}, recorder)
+}
+
+// RecordUsage adds usage to the recorder carried by ctx, if any
+func RecordUsage(ctx context.Context, usage Usage) {
+	if recorder, ok := ctx.Value(usageRecorderKey{}).(*UsageRecorder); ok && recorder != nil {
+		recorder.Record(usage)
+	}
+}
+
 // ProviderInfo contains metadata about an AI provider
 type ProviderInfo struct {
 	Name    string `json:"name"`    // Provider name (e.g., "openai", "ollama")
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsage_Add(t *testing.T) {
+	total := Usage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}.Add(Usage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5})
+
+	assert.Equal(t, Usage{PromptTokens: 13, CompletionTokens: 7, TotalTokens: 20}, total)
+}
+
+func TestRecordUsage(t *testing.T) {
+	t.Run("accumulates into the recorder carried by the context", func(t *testing.T) {
+		recorder := NewUsageRecorder()
+		ctx := WithUsageRecorder(context.Background(), recorder)
+
+		RecordUsage(ctx, Usage{PromptTokens: 1, CompletionTokens: 1, TotalTokens: 2})
+		RecordUsage(ctx, Usage{PromptTokens: 2, CompletionTokens: 2, TotalTokens: 4})
+
+		assert.Equal(t, Usage{PromptTokens: 3, CompletionTokens: 3, TotalTokens: 6}, recorder.Total())
+	})
+
+	t.Run("is a no-op without a recorder", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			RecordUsage(context.Background(), Usage{TotalTokens: 1})
+		})
+	})
+}
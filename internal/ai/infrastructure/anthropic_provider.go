@@ -0,0 +1,291 @@
+package infrastructure
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"neuromesh/internal/ai/domain"
+	"neuromesh/internal/logging"
+)
+
+// AnthropicConfig contains configuration for Anthropic provider
+type AnthropicConfig struct {
+	APIKey      string        `json:"api_key"`
+	Model       string        `json:"model"`
+	BaseURL     string        `json:"base_url"`
+	APIVersion  string        `json:"api_version"`
+	Timeout     time.Duration `json:"timeout"`
+	MaxTokens   int           `json:"max_tokens"`
+	Temperature float32       `json:"temperature"`
+}
+
+// DefaultAnthropicConfig returns a default configuration for Anthropic
+func DefaultAnthropicConfig() *AnthropicConfig {
+	return &AnthropicConfig{
+		Model:       "claude-3-5-sonnet-latest",
+		BaseURL:     "https://api.anthropic.com/v1",
+		APIVersion:  "2023-06-01",
+		Timeout:     30 * time.Second,
+		MaxTokens:   4000,
+		Temperature: 0.7,
+	}
+}
+
+// AnthropicProvider implements domain.AIProvider using the Anthropic messages API
+// This is PURE INFRASTRUCTURE - only handles HTTP communication with Anthropic API
+type AnthropicProvider struct {
+	config *AnthropicConfig
+	client *http.Client
+	logger logging.Logger
+}
+
+// NewAnthropicProvider creates a new Anthropic provider instance
+func NewAnthropicProvider(config *AnthropicConfig, logger logging.Logger) *AnthropicProvider {
+	if config == nil {
+		config = DefaultAnthropicConfig()
+	}
+
+	return &AnthropicProvider{
+		config: config,
+		client: &http.Client{
+			Timeout: config.Timeout,
+		},
+		logger: logger,
+	}
+}
+
+// CallAI makes a raw AI inference call with system and user prompts
+func (p *AnthropicProvider) CallAI(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	return p.CallAIWithOptions(ctx, systemPrompt, userPrompt, domain.CallOptions{})
+}
+
+// CallAIWithOptions makes an AI inference call, overriding the configured model and sampling per request
+func (p *AnthropicProvider) CallAIWithOptions(ctx context.Context, systemPrompt, userPrompt string, opts domain.CallOptions) (string, error) {
+	content, _, err := p.complete(ctx, systemPrompt, userPrompt, opts)
+	return content, err
+}
+
+// CallAIWithUsage makes an AI inference call with per-request overrides and returns the token usage reported by Anthropic
+func (p *AnthropicProvider) CallAIWithUsage(ctx context.Context, systemPrompt, userPrompt string, opts domain.CallOptions) (string, domain.Usage, error) {
+	return p.complete(ctx, systemPrompt, userPrompt, opts)
+}
+
+// complete drains a streaming message into a single response and its usage
+func (p *AnthropicProvider) complete(ctx context.Context, systemPrompt, userPrompt string, opts domain.CallOptions) (string, domain.Usage, error) {
+	if p.logger != nil {
+		model := p.config.Model
+		if opts.Model != "" {
+			model = opts.Model
+		}
+		p.logger.Info("Making Anthropic API call", "model", model)
+	}
+
+	resp, err := p.openStream(ctx, systemPrompt, userPrompt, opts)
+	if err != nil {
+		return "", domain.Usage{}, err
+	}
+	defer resp.Body.Close()
+
+	var content strings.Builder
+	usage, err := p.readStream(resp.Body, func(chunk string) {
+		content.WriteString(chunk)
+	})
+	if err != nil {
+		return "", domain.Usage{}, err
+	}
+
+	if p.logger != nil {
+		p.logger.Info("Anthropic API call completed successfully", "response_length", content.Len(), "total_tokens", usage.TotalTokens)
+	}
+
+	return content.String(), usage, nil
+}
+
+// CallAIStream makes a streaming AI inference call and emits text chunks on the returned channel
+// The channel is closed when the message finishes; errors after the stream opened are logged
+func (p *AnthropicProvider) CallAIStream(ctx context.Context, systemPrompt, userPrompt string) (<-chan string, error) {
+	if p.logger != nil {
+		p.logger.Info("Making streaming Anthropic API call", "model", p.config.Model)
+	}
+
+	resp, err := p.openStream(ctx, systemPrompt, userPrompt, domain.CallOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make(chan string)
+	go func() {
+		defer close(chunks)
+		defer resp.Body.Close()
+
+		_, err := p.readStream(resp.Body, func(chunk string) {
+			select {
+			case chunks <- chunk:
+			case <-ctx.Done():
+			}
+		})
+		if err != nil && p.logger != nil {
+			p.logger.Error("Anthropic stream failed", err)
+		}
+	}()
+
+	return chunks, nil
+}
+
+// requestPayload builds the messages payload; the system prompt is a top-level field in this API
+func (p *AnthropicProvider) requestPayload(systemPrompt, userPrompt string, opts domain.CallOptions) map[string]interface{} {
+	payload := map[string]interface{}{
+		"model":  p.config.Model,
+		"system": systemPrompt,
+		"messages": []map[string]string{
+			{"role": "user", "content": userPrompt},
+		},
+		"max_tokens":  p.config.MaxTokens,
+		"temperature": p.config.Temperature,
+		"stream":      true,
+	}
+
+	if opts.Model != "" {
+		payload["model"] = opts.Model
+	}
+	if opts.Temperature != nil {
+		payload["temperature"] = *opts.Temperature
+	}
+	if opts.MaxTokens > 0 {
+		payload["max_tokens"] = opts.MaxTokens
+	}
+	if opts.TopP != nil {
+		payload["top_p"] = *opts.TopP
+	}
+
+	return payload
+}
+
+// openStream sends a streaming messages request and returns the open response
+func (p *AnthropicProvider) openStream(ctx context.Context, systemPrompt, userPrompt string, opts domain.CallOptions) (*http.Response, error) {
+	jsonData, err := json.Marshal(p.requestPayload(systemPrompt, userPrompt, opts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.config.BaseURL+"/messages", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("x-api-key", p.config.APIKey)
+	req.Header.Set("anthropic-version", p.config.APIVersion)
+
+	if p.logger != nil {
+		p.logger.Debug("Sending request to Anthropic", "url", req.URL.String())
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		if p.logger != nil {
+			p.logger.Error("Anthropic API request failed", err)
+		}
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Anthropic API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return resp, nil
+}
+
+// readStream parses Anthropic server-sent events, passes each text delta to onChunk and returns the reported usage
+func (p *AnthropicProvider) readStream(body io.Reader, onChunk func(string)) (domain.Usage, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var usage domain.Usage
+	started := false
+	for scanner.Scan() {
+		data, found := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "data:")
+		if !found {
+			continue
+		}
+
+		// Parse Anthropic stream event; the event type is repeated in the data payload
+		var event struct {
+			Type    string `json:"type"`
+			Message struct {
+				Usage struct {
+					InputTokens int `json:"input_tokens"`
+				} `json:"usage"`
+			} `json:"message"`
+			Delta struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"delta"`
+			Usage struct {
+				OutputTokens int `json:"output_tokens"`
+			} `json:"usage"`
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+
+		if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &event); err != nil {
+			return domain.Usage{}, fmt.Errorf("failed to parse Anthropic stream event: %w", err)
+		}
+
+		switch event.Type {
+		case "message_start":
+			started = true
+			usage.PromptTokens = event.Message.Usage.InputTokens
+		case "content_block_delta":
+			if event.Delta.Type == "text_delta" && event.Delta.Text != "" {
+				onChunk(event.Delta.Text)
+			}
+		case "message_delta":
+			usage.CompletionTokens = event.Usage.OutputTokens
+		case "error":
+			return domain.Usage{}, fmt.Errorf("Anthropic API error: %s", event.Error.Message)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		if p.logger != nil {
+			p.logger.Error("Failed to read response body", err)
+		}
+		return domain.Usage{}, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if !started {
+		return domain.Usage{}, fmt.Errorf("no message returned from Anthropic")
+	}
+
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	return usage, nil
+}
+
+// GetProviderInfo returns information about the Anthropic provider
+func (p *AnthropicProvider) GetProviderInfo() *domain.ProviderInfo {
+	return &domain.ProviderInfo{
+		Name:    "anthropic",
+		Model:   p.config.Model,
+		Version: "1.0.0",
+	}
+}
+
+// Close cleans up Anthropic provider resources
+func (p *AnthropicProvider) Close() error {
+	if p.logger != nil {
+		p.logger.Info("Closing Anthropic provider")
+	}
+	return nil
+}
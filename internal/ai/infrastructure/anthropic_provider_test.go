@@ -0,0 +1,136 @@
+package infrastructure
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"neuromesh/internal/ai/domain"
+	"neuromesh/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordedAnthropicStream is a trimmed capture of a messages API streaming response
+const recordedAnthropicStream = `event: message_start
+data: {"type":"message_start","message":{"id":"msg_01","type":"message","role":"assistant","content":[],"model":"claude-3-5-sonnet-latest","usage":{"input_tokens":25,"output_tokens":1}}}
+
+event: content_block_start
+data: {"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}
+
+event: ping
+data: {"type":"ping"}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hello"}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":" world"}}
+
+event: content_block_stop
+data: {"type":"content_block_stop","index":0}
+
+event: message_delta
+data: {"type":"message_delta","delta":{"stop_reason":"end_turn","stop_sequence":null},"usage":{"output_tokens":15}}
+
+event: message_stop
+data: {"type":"message_stop"}
+
+`
+
+func newTestAnthropicProvider(transport http.RoundTripper) *AnthropicProvider {
+	config := DefaultAnthropicConfig()
+	config.APIKey = "test-key"
+	provider := NewAnthropicProvider(config, logging.NewNoOpLogger())
+	provider.client.Transport = transport
+	return provider
+}
+
+func TestAnthropicProvider_CallAI(t *testing.T) {
+	t.Run("maps prompts into the messages request", func(t *testing.T) {
+		transport := &fakeStreamingTransport{status: http.StatusOK, body: recordedAnthropicStream}
+		provider := newTestAnthropicProvider(transport)
+
+		response, err := provider.CallAI(context.Background(), "You are helpful", "Say hello")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Hello world", response)
+
+		require.Len(t, transport.requests, 1)
+		body := transport.requests[0]
+		assert.Equal(t, "You are helpful", body["system"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"role": "user", "content": "Say hello"}}, body["messages"])
+		assert.Equal(t, true, body["stream"])
+	})
+
+	t.Run("reports usage from message_start and message_delta", func(t *testing.T) {
+		provider := newTestAnthropicProvider(&fakeStreamingTransport{status: http.StatusOK, body: recordedAnthropicStream})
+
+		_, usage, err := provider.CallAIWithUsage(context.Background(), "system", "user", domain.CallOptions{})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.Usage{PromptTokens: 25, CompletionTokens: 15, TotalTokens: 40}, usage)
+	})
+
+	t.Run("forwards per-call options", func(t *testing.T) {
+		transport := &fakeStreamingTransport{status: http.StatusOK, body: recordedAnthropicStream}
+		provider := newTestAnthropicProvider(transport)
+
+		_, err := provider.CallAIWithOptions(context.Background(), "system", "user", domain.CallOptions{Model: "claude-3-5-haiku-latest", MaxTokens: 100}.WithTemperature(0))
+
+		require.NoError(t, err)
+		body := transport.requests[0]
+		assert.Equal(t, "claude-3-5-haiku-latest", body["model"])
+		assert.Equal(t, float64(100), body["max_tokens"])
+		assert.Equal(t, float64(0), body["temperature"])
+	})
+
+	t.Run("surfaces stream error events", func(t *testing.T) {
+		body := "event: error\ndata: {\"type\":\"error\",\"error\":{\"type\":\"overloaded_error\",\"message\":\"Overloaded\"}}\n\n"
+		provider := newTestAnthropicProvider(&fakeStreamingTransport{status: http.StatusOK, body: body})
+
+		_, err := provider.CallAI(context.Background(), "system", "user")
+
+		assert.ErrorContains(t, err, "Overloaded")
+	})
+
+	t.Run("returns error for non-200 status", func(t *testing.T) {
+		provider := newTestAnthropicProvider(&fakeStreamingTransport{status: http.StatusUnauthorized, body: `{"type":"error"}`})
+
+		_, err := provider.CallAI(context.Background(), "system", "user")
+
+		assert.ErrorContains(t, err, "status 401")
+	})
+}
+
+func TestAnthropicProvider_CallAIStream(t *testing.T) {
+	provider := newTestAnthropicProvider(&fakeStreamingTransport{status: http.StatusOK, body: recordedAnthropicStream})
+
+	stream, err := provider.CallAIStream(context.Background(), "system", "user")
+	require.NoError(t, err)
+
+	var chunks []string
+	for chunk := range stream {
+		chunks = append(chunks, chunk)
+	}
+	assert.Equal(t, []string{"Hello", " world"}, chunks)
+}
+
+func TestNewAIProvider(t *testing.T) {
+	provider, err := NewAIProvider(ProviderConfig{ProviderType: ProviderTypeAnthropic, APIKey: "key"}, logging.NewNoOpLogger())
+	require.NoError(t, err)
+	assert.Equal(t, "anthropic", provider.GetProviderInfo().Name)
+
+	provider, err = NewAIProvider(ProviderConfig{APIKey: "key", Model: "gpt-4.1"}, logging.NewNoOpLogger())
+	require.NoError(t, err)
+	assert.Equal(t, "openai", provider.GetProviderInfo().Name)
+	assert.Equal(t, "gpt-4.1", provider.GetProviderInfo().Model)
+
+	_, err = NewAIProvider(ProviderConfig{ProviderType: "ollama"}, logging.NewNoOpLogger())
+	assert.Error(t, err)
+
+	providerType, err := ParseProviderType("Anthropic")
+	require.NoError(t, err)
+	assert.Equal(t, ProviderTypeAnthropic, providerType)
+}
@@ -1,36 +1,48 @@
 package infrastructure
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"neuromesh/internal/ai/domain"
 	"neuromesh/internal/logging"
+	"neuromesh/internal/metrics"
+	"neuromesh/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // OpenAIConfig contains configuration for OpenAI provider
 type OpenAIConfig struct {
-	APIKey      string        `json:"api_key"`
-	Model       string        `json:"model"`
-	BaseURL     string        `json:"base_url"`
-	Timeout     time.Duration `json:"timeout"`
-	MaxTokens   int           `json:"max_tokens"`
-	Temperature float32       `json:"temperature"`
+	APIKey         string        `json:"api_key"`
+	Model          string        `json:"model"`
+	BaseURL        string        `json:"base_url"`
+	Timeout        time.Duration `json:"timeout"`
+	MaxTokens      int           `json:"max_tokens"`
+	Temperature    float32       `json:"temperature"`
+	MaxAttempts    int           `json:"max_attempts"`     // Requests per call when OpenAI returns 429 or 5xx
+	RetryBaseDelay time.Duration `json:"retry_base_delay"` // First backoff delay, doubled per retry
 }
 
 // DefaultOpenAIConfig returns a default configuration for OpenAI
 func DefaultOpenAIConfig() *OpenAIConfig {
 	return &OpenAIConfig{
-		Model:       "gpt-4.1-mini",
-		BaseURL:     "https://api.openai.com/v1",
-		Timeout:     30 * time.Second,
-		MaxTokens:   4000,
-		Temperature: 0.7,
+		Model:          "gpt-4.1-mini",
+		BaseURL:        "https://api.openai.com/v1",
+		Timeout:        30 * time.Second,
+		MaxTokens:      4000,
+		Temperature:    0.7,
+		MaxAttempts:    3,
+		RetryBaseDelay: 1 * time.Second,
 	}
 }
 
@@ -60,11 +72,107 @@ func NewOpenAIProvider(config *OpenAIConfig, logger logging.Logger) *OpenAIProvi
 // CallAI makes a raw AI inference call with system and user prompts
 // This is pure infrastructure - only handles OpenAI API communication
 func (p *OpenAIProvider) CallAI(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	return p.CallAIWithOptions(ctx, systemPrompt, userPrompt, domain.CallOptions{})
+}
+
+// CallAIWithOptions makes an AI inference call, overriding the configured model and sampling per request
+func (p *OpenAIProvider) CallAIWithOptions(ctx context.Context, systemPrompt, userPrompt string, opts domain.CallOptions) (string, error) {
+	content, _, err := p.complete(ctx, systemPrompt, userPrompt, opts)
+	return content, err
+}
+
+// CallAIWithUsage makes an AI inference call with per-request overrides and returns the token usage reported by OpenAI
+func (p *OpenAIProvider) CallAIWithUsage(ctx context.Context, systemPrompt, userPrompt string, opts domain.CallOptions) (string, domain.Usage, error) {
+	return p.complete(ctx, systemPrompt, userPrompt, opts)
+}
+
+// complete drains a streaming completion into a single response and its usage
+func (p *OpenAIProvider) complete(ctx context.Context, systemPrompt, userPrompt string, opts domain.CallOptions) (string, domain.Usage, error) {
+	model := p.config.Model
+	if opts.Model != "" {
+		model = opts.Model
+	}
+	if p.logger != nil {
+		p.logger.Info("Making OpenAI API call", "model", model)
+	}
+
+	ctx, span := tracing.Start(ctx, "openai.chat_completion",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("ai.model", model)))
+	start := time.Now()
+
+	resp, err := p.openStream(ctx, systemPrompt, userPrompt, opts)
+	if err != nil {
+		metrics.ObserveAICall(model, start, err)
+		tracing.End(span, err)
+		return "", domain.Usage{}, err
+	}
+	defer resp.Body.Close()
+
+	// Drain the stream into a single completion
+	var content strings.Builder
+	usage, err := p.readStream(resp.Body, func(chunk string) {
+		content.WriteString(chunk)
+	})
+	metrics.ObserveAICall(model, start, err)
+	if err != nil {
+		tracing.End(span, err)
+		return "", domain.Usage{}, err
+	}
+
+	span.SetAttributes(attribute.Int("ai.usage.total_tokens", usage.TotalTokens))
+	span.End()
+
+	if p.logger != nil {
+		p.logger.Info("OpenAI API call completed successfully", "response_length", content.Len(), "total_tokens", usage.TotalTokens)
+	}
+
+	return content.String(), usage, nil
+}
+
+// CallAIStream makes a streaming AI inference call and emits content chunks on the returned channel
+// The channel is closed when the completion finishes; errors after the stream opened are logged
+func (p *OpenAIProvider) CallAIStream(ctx context.Context, systemPrompt, userPrompt string) (<-chan string, error) {
 	if p.logger != nil {
-		p.logger.Info("Making OpenAI API call", "model", p.config.Model)
+		p.logger.Info("Making streaming OpenAI API call", "model", p.config.Model)
 	}
 
-	// Build the request payload
+	ctx, span := tracing.Start(ctx, "openai.chat_completion_stream",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("ai.model", p.config.Model)))
+	start := time.Now()
+
+	resp, err := p.openStream(ctx, systemPrompt, userPrompt, domain.CallOptions{})
+	if err != nil {
+		metrics.ObserveAICall(p.config.Model, start, err)
+		tracing.End(span, err)
+		return nil, err
+	}
+
+	chunks := make(chan string)
+	go func() {
+		defer close(chunks)
+		defer resp.Body.Close()
+
+		// The span covers the whole stream, ending once the last chunk was read
+		_, err := p.readStream(resp.Body, func(chunk string) {
+			select {
+			case chunks <- chunk:
+			case <-ctx.Done():
+			}
+		})
+		metrics.ObserveAICall(p.config.Model, start, err)
+		tracing.End(span, err)
+		if err != nil && p.logger != nil {
+			p.logger.Error("OpenAI stream failed", err)
+		}
+	}()
+
+	return chunks, nil
+}
+
+// requestPayload builds the chat completion payload from config defaults and per-call overrides
+func (p *OpenAIProvider) requestPayload(systemPrompt, userPrompt string, opts domain.CallOptions) map[string]interface{} {
 	payload := map[string]interface{}{
 		"model": p.config.Model,
 		"messages": []map[string]string{
@@ -73,22 +181,84 @@ func (p *OpenAIProvider) CallAI(ctx context.Context, systemPrompt, userPrompt st
 		},
 		"max_tokens":  p.config.MaxTokens,
 		"temperature": p.config.Temperature,
+		"stream":      true,
+		// Ask OpenAI to append a final chunk carrying token usage
+		"stream_options": map[string]bool{"include_usage": true},
+	}
+
+	if opts.Model != "" {
+		payload["model"] = opts.Model
+	}
+	if opts.Temperature != nil {
+		payload["temperature"] = *opts.Temperature
+	}
+	if opts.MaxTokens > 0 {
+		payload["max_tokens"] = opts.MaxTokens
+	}
+	if opts.TopP != nil {
+		payload["top_p"] = *opts.TopP
 	}
 
+	return payload
+}
+
+// openStream sends a streaming chat completion request and returns the open response
+// Rate-limited and transient server errors are retried with exponential backoff
+func (p *OpenAIProvider) openStream(ctx context.Context, systemPrompt, userPrompt string, opts domain.CallOptions) (*http.Response, error) {
 	// Marshal the payload
-	jsonData, err := json.Marshal(payload)
+	jsonData, err := json.Marshal(p.requestPayload(systemPrompt, userPrompt, opts))
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	maxAttempts := p.config.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
 	}
 
+	for attempt := 1; ; attempt++ {
+		resp, err := p.sendRequest(ctx, jsonData)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			return resp, nil
+		}
+
+		// Check for HTTP errors
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		apiErr := fmt.Errorf("OpenAI API error (status %d): %s", resp.StatusCode, string(body))
+
+		if !isRetryableStatus(resp.StatusCode) || attempt >= maxAttempts {
+			return nil, apiErr
+		}
+
+		delay := p.retryDelay(attempt, resp.Header.Get("Retry-After"))
+		if p.logger != nil {
+			p.logger.Warn("Retrying OpenAI API call", "status", resp.StatusCode, "attempt", attempt, "delay", delay.String())
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("OpenAI API retry cancelled: %w", ctx.Err())
+		}
+	}
+}
+
+// sendRequest performs a single chat completion HTTP request
+func (p *OpenAIProvider) sendRequest(ctx context.Context, jsonData []byte) (*http.Response, error) {
 	// Create the request
-	req, err := http.NewRequestWithContext(ctx, "POST", p.config.BaseURL+"/chat/completions", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", p.config.BaseURL+"/chat/completions", bytes.NewReader(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
 	req.Header.Set("Authorization", "Bearer "+p.config.APIKey)
 
 	if p.logger != nil {
@@ -101,60 +271,106 @@ func (p *OpenAIProvider) CallAI(ctx context.Context, systemPrompt, userPrompt st
 		if p.logger != nil {
 			p.logger.Error("OpenAI API request failed", err)
 		}
-		return "", fmt.Errorf("failed to make request: %w", err)
+		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
-	defer resp.Body.Close()
 
 	if p.logger != nil {
 		p.logger.Debug("Received response from OpenAI", "status", resp.StatusCode)
 	}
 
-	// Read the response
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		if p.logger != nil {
-			p.logger.Error("Failed to read response body", err)
+	return resp, nil
+}
+
+// retryDelay honors Retry-After when present and otherwise backs off exponentially from RetryBaseDelay
+func (p *OpenAIProvider) retryDelay(attempt int, retryAfter string) time.Duration {
+	if retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if at, err := http.ParseTime(retryAfter); err == nil {
+			if delay := time.Until(at); delay > 0 {
+				return delay
+			}
+			return 0
 		}
-		return "", fmt.Errorf("failed to read response: %w", err)
 	}
+	return p.config.RetryBaseDelay * time.Duration(1<<(attempt-1))
+}
 
-	// Check for HTTP errors
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("OpenAI API error (status %d): %s", resp.StatusCode, string(body))
+// isRetryableStatus reports whether an OpenAI HTTP status is worth retrying
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable:
+		return true
 	}
+	return false
+}
 
-	// Parse OpenAI response
-	var openAIResponse struct {
-		Choices []struct {
-			Message struct {
-				Content string `json:"content"`
-			} `json:"message"`
-		} `json:"choices"`
-		Error *struct {
-			Message string `json:"message"`
-		} `json:"error"`
-	}
+// readStream parses OpenAI server-sent events, passes each content delta to onChunk and returns the reported usage
+func (p *OpenAIProvider) readStream(body io.Reader, onChunk func(string)) (domain.Usage, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
 
-	if err := json.Unmarshal(body, &openAIResponse); err != nil {
-		return "", fmt.Errorf("failed to parse OpenAI response: %w", err)
-	}
+	var usage domain.Usage
+	receivedChoice := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		data, found := strings.CutPrefix(line, "data:")
+		if !found {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			break
+		}
+
+		// Parse OpenAI stream chunk
+		var streamChunk struct {
+			Choices []struct {
+				Delta struct {
+					Content string `json:"content"`
+				} `json:"delta"`
+			} `json:"choices"`
+			Usage *domain.Usage `json:"usage"`
+			Error *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+
+		if err := json.Unmarshal([]byte(data), &streamChunk); err != nil {
+			return domain.Usage{}, fmt.Errorf("failed to parse OpenAI stream chunk: %w", err)
+		}
 
-	// Check for API errors
-	if openAIResponse.Error != nil {
-		return "", fmt.Errorf("OpenAI API error: %s", openAIResponse.Error.Message)
+		// Check for API errors
+		if streamChunk.Error != nil {
+			return domain.Usage{}, fmt.Errorf("OpenAI API error: %s", streamChunk.Error.Message)
+		}
+
+		if streamChunk.Usage != nil {
+			usage = *streamChunk.Usage
+		}
+
+		if len(streamChunk.Choices) == 0 {
+			continue
+		}
+		receivedChoice = true
+		if content := streamChunk.Choices[0].Delta.Content; content != "" {
+			onChunk(content)
+		}
 	}
 
-	// Extract the response content
-	if len(openAIResponse.Choices) == 0 {
-		return "", fmt.Errorf("no response choices from OpenAI")
+	if err := scanner.Err(); err != nil {
+		if p.logger != nil {
+			p.logger.Error("Failed to read response body", err)
+		}
+		return domain.Usage{}, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	content := openAIResponse.Choices[0].Message.Content
-	if p.logger != nil {
-		p.logger.Info("OpenAI API call completed successfully", "response_length", len(content))
+	if !receivedChoice {
+		return domain.Usage{}, fmt.Errorf("no response choices from OpenAI")
 	}
 
-	return content, nil
+	return usage, nil
 }
 
 // GetProviderInfo returns information about the OpenAI provider
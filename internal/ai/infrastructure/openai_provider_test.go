@@ -0,0 +1,281 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"neuromesh/internal/ai/domain"
+	"neuromesh/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStreamingTransport replays a canned OpenAI response for every request
+type fakeStreamingTransport struct {
+	status   int
+	body     string
+	requests []map[string]interface{}
+}
+
+func (f *fakeStreamingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var payload map[string]interface{}
+	if req.Body != nil {
+		_ = json.NewDecoder(req.Body).Decode(&payload)
+	}
+	f.requests = append(f.requests, payload)
+
+	return &http.Response{
+		StatusCode: f.status,
+		Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+		Body:       io.NopCloser(strings.NewReader(f.body)),
+		Request:    req,
+	}, nil
+}
+
+func sseBody(chunks ...string) string {
+	var b strings.Builder
+	for _, chunk := range chunks {
+		data, _ := json.Marshal(map[string]interface{}{
+			"choices": []map[string]interface{}{{"delta": map[string]string{"content": chunk}}},
+		})
+		b.WriteString("data: " + string(data) + "\n\n")
+	}
+	b.WriteString("data: [DONE]\n\n")
+	return b.String()
+}
+
+func newTestProvider(transport http.RoundTripper) *OpenAIProvider {
+	config := DefaultOpenAIConfig()
+	config.APIKey = "test-key"
+	provider := NewOpenAIProvider(config, logging.NewNoOpLogger())
+	provider.client.Transport = transport
+	return provider
+}
+
+func TestOpenAIProvider_CallAIStream(t *testing.T) {
+	t.Run("emits chunks in order and closes the channel", func(t *testing.T) {
+		transport := &fakeStreamingTransport{status: http.StatusOK, body: sseBody("Hel", "lo", " world")}
+		provider := newTestProvider(transport)
+
+		stream, err := provider.CallAIStream(context.Background(), "system", "user")
+		require.NoError(t, err)
+
+		var chunks []string
+		for chunk := range stream {
+			chunks = append(chunks, chunk)
+		}
+
+		assert.Equal(t, []string{"Hel", "lo", " world"}, chunks)
+		require.Len(t, transport.requests, 1)
+		assert.Equal(t, true, transport.requests[0]["stream"])
+	})
+
+	t.Run("returns error for non-200 status", func(t *testing.T) {
+		transport := &fakeStreamingTransport{status: http.StatusUnauthorized, body: `{"error":{"message":"bad key"}}`}
+		provider := newTestProvider(transport)
+
+		stream, err := provider.CallAIStream(context.Background(), "system", "user")
+
+		assert.Nil(t, stream)
+		assert.ErrorContains(t, err, "status 401")
+	})
+
+	t.Run("closes the channel when the stream reports an error", func(t *testing.T) {
+		body := strings.TrimSuffix(sseBody("partial"), "data: [DONE]\n\n") + `data: {"error":{"message":"overloaded"}}` + "\n\n"
+		provider := newTestProvider(&fakeStreamingTransport{status: http.StatusOK, body: body})
+
+		stream, err := provider.CallAIStream(context.Background(), "system", "user")
+		require.NoError(t, err)
+
+		var chunks []string
+		for chunk := range stream {
+			chunks = append(chunks, chunk)
+		}
+		assert.Equal(t, []string{"partial"}, chunks)
+	})
+}
+
+func TestOpenAIProvider_CallAI(t *testing.T) {
+	t.Run("drains the stream into a single completion", func(t *testing.T) {
+		provider := newTestProvider(&fakeStreamingTransport{status: http.StatusOK, body: sseBody("Hello", ", ", "world")})
+
+		response, err := provider.CallAI(context.Background(), "system", "user")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Hello, world", response)
+	})
+
+	t.Run("surfaces stream errors", func(t *testing.T) {
+		provider := newTestProvider(&fakeStreamingTransport{status: http.StatusOK, body: `data: {"error":{"message":"overloaded"}}` + "\n\n"})
+
+		_, err := provider.CallAI(context.Background(), "system", "user")
+
+		assert.ErrorContains(t, err, "overloaded")
+	})
+
+	t.Run("fails when no choices are returned", func(t *testing.T) {
+		provider := newTestProvider(&fakeStreamingTransport{status: http.StatusOK, body: "data: [DONE]\n\n"})
+
+		_, err := provider.CallAI(context.Background(), "system", "user")
+
+		assert.ErrorContains(t, err, "no response choices")
+	})
+}
+
+func TestOpenAIProvider_CallAIWithOptions(t *testing.T) {
+	t.Run("forwards per-call options into the request body", func(t *testing.T) {
+		transport := &fakeStreamingTransport{status: http.StatusOK, body: sseBody("ok")}
+		provider := newTestProvider(transport)
+
+		opts := domain.CallOptions{Model: "gpt-4.1", MaxTokens: 256}.WithTemperature(0).WithTopP(0.5)
+		_, err := provider.CallAIWithOptions(context.Background(), "system", "user", opts)
+		require.NoError(t, err)
+
+		require.Len(t, transport.requests, 1)
+		body := transport.requests[0]
+		assert.Equal(t, "gpt-4.1", body["model"])
+		assert.Equal(t, float64(0), body["temperature"])
+		assert.Equal(t, float64(256), body["max_tokens"])
+		assert.Equal(t, 0.5, body["top_p"])
+	})
+
+	t.Run("keeps config defaults for unset options", func(t *testing.T) {
+		transport := &fakeStreamingTransport{status: http.StatusOK, body: sseBody("ok")}
+		provider := newTestProvider(transport)
+
+		_, err := provider.CallAIWithOptions(context.Background(), "system", "user", domain.CallOptions{})
+		require.NoError(t, err)
+
+		body := transport.requests[0]
+		config := DefaultOpenAIConfig()
+		assert.Equal(t, config.Model, body["model"])
+		assert.InDelta(t, float64(config.Temperature), body["temperature"], 0.0001)
+		assert.Equal(t, float64(config.MaxTokens), body["max_tokens"])
+		assert.NotContains(t, body, "top_p")
+	})
+}
+
+func TestOpenAIProvider_CallAIWithUsage(t *testing.T) {
+	body := strings.TrimSuffix(sseBody("Hello", " world"), "data: [DONE]\n\n") +
+		`data: {"choices":[],"usage":{"prompt_tokens":12,"completion_tokens":3,"total_tokens":15}}` + "\n\n" +
+		"data: [DONE]\n\n"
+	transport := &fakeStreamingTransport{status: http.StatusOK, body: body}
+	provider := newTestProvider(transport)
+
+	response, usage, err := provider.CallAIWithUsage(context.Background(), "system", "user", domain.CallOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Hello world", response)
+	assert.Equal(t, domain.Usage{PromptTokens: 12, CompletionTokens: 3, TotalTokens: 15}, usage)
+	assert.Equal(t, map[string]interface{}{"include_usage": true}, transport.requests[0]["stream_options"])
+}
+
+// sequencedTransport returns the queued responses in order, repeating the last one
+type sequencedTransport struct {
+	responses []*http.Response
+	calls     int
+}
+
+func (s *sequencedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	index := s.calls
+	if index >= len(s.responses) {
+		index = len(s.responses) - 1
+	}
+	s.calls++
+
+	resp := *s.responses[index]
+	resp.Request = req
+	return &resp, nil
+}
+
+func cannedResponse(status int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestOpenAIProvider_Retries(t *testing.T) {
+	newRetryingProvider := func(transport http.RoundTripper) *OpenAIProvider {
+		provider := newTestProvider(transport)
+		provider.config.MaxAttempts = 3
+		provider.config.RetryBaseDelay = time.Millisecond
+		return provider
+	}
+
+	t.Run("retries a 429 honoring Retry-After then succeeds", func(t *testing.T) {
+		transport := &sequencedTransport{responses: []*http.Response{
+			cannedResponse(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"0"}}, `{"error":{"message":"rate limited"}}`),
+			cannedResponse(http.StatusOK, nil, sseBody("recovered")),
+		}}
+		provider := newRetryingProvider(transport)
+
+		response, err := provider.CallAI(context.Background(), "system", "user")
+
+		require.NoError(t, err)
+		assert.Equal(t, "recovered", response)
+		assert.Equal(t, 2, transport.calls)
+	})
+
+	t.Run("retries 5xx with backoff until max attempts", func(t *testing.T) {
+		transport := &sequencedTransport{responses: []*http.Response{
+			cannedResponse(http.StatusServiceUnavailable, nil, "unavailable"),
+		}}
+		provider := newRetryingProvider(transport)
+
+		_, err := provider.CallAI(context.Background(), "system", "user")
+
+		assert.ErrorContains(t, err, "status 503")
+		assert.Equal(t, 3, transport.calls)
+	})
+
+	t.Run("fails fast on non-retryable status", func(t *testing.T) {
+		for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized} {
+			transport := &sequencedTransport{responses: []*http.Response{
+				cannedResponse(status, nil, "rejected"),
+				cannedResponse(http.StatusOK, nil, sseBody("unreachable")),
+			}}
+			provider := newRetryingProvider(transport)
+
+			_, err := provider.CallAI(context.Background(), "system", "user")
+
+			assert.Error(t, err)
+			assert.Equal(t, 1, transport.calls, "status %d should not be retried", status)
+		}
+	})
+
+	t.Run("stops retrying when the context is cancelled", func(t *testing.T) {
+		transport := &sequencedTransport{responses: []*http.Response{
+			cannedResponse(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"60"}}, "slow down"),
+		}}
+		provider := newRetryingProvider(transport)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := provider.CallAI(ctx, "system", "user")
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1, transport.calls)
+	})
+}
+
+func TestOpenAIProvider_RetryDelay(t *testing.T) {
+	provider := newTestProvider(nil)
+	provider.config.RetryBaseDelay = 100 * time.Millisecond
+
+	assert.Equal(t, 100*time.Millisecond, provider.retryDelay(1, ""))
+	assert.Equal(t, 400*time.Millisecond, provider.retryDelay(3, ""))
+	assert.Equal(t, 2*time.Second, provider.retryDelay(1, "2"))
+	assert.Equal(t, 100*time.Millisecond, provider.retryDelay(1, "not-a-date"))
+}
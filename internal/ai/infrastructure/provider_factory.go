@@ -0,0 +1,58 @@
+package infrastructure
+
+import (
+	"fmt"
+	"strings"
+
+	"neuromesh/internal/ai/domain"
+	"neuromesh/internal/logging"
+)
+
+// ProviderType identifies an AI vendor implementation
+type ProviderType string
+
+const (
+	ProviderTypeOpenAI    ProviderType = "openai"
+	ProviderTypeAnthropic ProviderType = "anthropic"
+)
+
+// ParseProviderType converts a provider name to a ProviderType, defaulting to OpenAI
+func ParseProviderType(name string) (ProviderType, error) {
+	switch ProviderType(strings.ToLower(strings.TrimSpace(name))) {
+	case "", ProviderTypeOpenAI:
+		return ProviderTypeOpenAI, nil
+	case ProviderTypeAnthropic:
+		return ProviderTypeAnthropic, nil
+	default:
+		return "", fmt.Errorf("unknown AI provider type: %s", name)
+	}
+}
+
+// ProviderConfig selects and configures an AI provider
+type ProviderConfig struct {
+	ProviderType ProviderType
+	APIKey       string
+	Model        string // Optional; the vendor default is used when empty
+}
+
+// NewAIProvider creates the AI provider selected by config.ProviderType
+func NewAIProvider(config ProviderConfig, logger logging.Logger) (domain.AIProvider, error) {
+	switch config.ProviderType {
+	case "", ProviderTypeOpenAI:
+		openAIConfig := DefaultOpenAIConfig()
+		openAIConfig.APIKey = config.APIKey
+		if config.Model != "" {
+			openAIConfig.Model = config.Model
+		}
+		return NewOpenAIProvider(openAIConfig, logger), nil
+	case ProviderTypeAnthropic:
+		anthropicConfig := DefaultAnthropicConfig()
+		anthropicConfig.APIKey = config.APIKey
+		if config.Model != "" {
+			anthropicConfig.Model = config.Model
+		}
+		return NewAnthropicProvider(anthropicConfig, logger), nil
+	default:
+		return nil, fmt.Errorf("unknown AI provider type: %s", config.ProviderType)
+	}
+}
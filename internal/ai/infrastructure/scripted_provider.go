@@ -0,0 +1,188 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"neuromesh/internal/ai/domain"
+)
+
+// DefaultScriptedResponse is returned when no scripted prompt matches
+const DefaultScriptedResponse = "USER_RESPONSE:\nScripted provider has no response for this prompt"
+
+// scriptRule holds the queued responses for prompts containing match
+type scriptRule struct {
+	match     string
+	responses []string
+	next      int
+}
+
+// ScriptedCall records one prompt pair received by a ScriptedProvider
+type ScriptedCall struct {
+	SystemPrompt string
+	UserPrompt   string
+	Response     string
+}
+
+// ScriptedProvider implements domain.AIProvider with canned responses for offline, deterministic runs
+// Responses are selected by substring match against the user prompt; rules are checked in order
+type ScriptedProvider struct {
+	mu       sync.Mutex
+	rules    []*scriptRule
+	fallback string
+	calls    []ScriptedCall
+}
+
+// NewScriptedProvider creates a provider answering prompts that contain a key with its response
+// Longer keys are matched first so more specific prompts win over general ones
+func NewScriptedProvider(responses map[string]string) *ScriptedProvider {
+	keys := make([]string, 0, len(responses))
+	for key := range responses {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	builder := NewScriptBuilder()
+	for _, key := range keys {
+		builder.On(key, responses[key])
+	}
+	return builder.Build()
+}
+
+// CallAI returns the scripted response for the user prompt
+func (p *ScriptedProvider) CallAI(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	response := p.fallback
+	for _, rule := range p.rules {
+		if strings.Contains(userPrompt, rule.match) {
+			response = rule.responses[rule.next]
+			// Advance through the queue; the last response repeats
+			if rule.next < len(rule.responses)-1 {
+				rule.next++
+			}
+			break
+		}
+	}
+
+	p.calls = append(p.calls, ScriptedCall{SystemPrompt: systemPrompt, UserPrompt: userPrompt, Response: response})
+	return response, nil
+}
+
+// CallAIWithOptions ignores the options and returns the scripted response
+func (p *ScriptedProvider) CallAIWithOptions(ctx context.Context, systemPrompt, userPrompt string, opts domain.CallOptions) (string, error) {
+	return p.CallAI(ctx, systemPrompt, userPrompt)
+}
+
+// CallAIWithUsage returns the scripted response with zero usage
+func (p *ScriptedProvider) CallAIWithUsage(ctx context.Context, systemPrompt, userPrompt string, opts domain.CallOptions) (string, domain.Usage, error) {
+	response, err := p.CallAI(ctx, systemPrompt, userPrompt)
+	return response, domain.Usage{}, err
+}
+
+// CallAIStream emits the scripted response as a single chunk
+func (p *ScriptedProvider) CallAIStream(ctx context.Context, systemPrompt, userPrompt string) (<-chan string, error) {
+	response, err := p.CallAI(ctx, systemPrompt, userPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make(chan string, 1)
+	chunks <- response
+	close(chunks)
+	return chunks, nil
+}
+
+// Calls returns the prompts received so far
+func (p *ScriptedProvider) Calls() []ScriptedCall {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	calls := make([]ScriptedCall, len(p.calls))
+	copy(calls, p.calls)
+	return calls
+}
+
+// GetProviderInfo returns information about the scripted provider
+func (p *ScriptedProvider) GetProviderInfo() *domain.ProviderInfo {
+	return &domain.ProviderInfo{
+		Name:    "scripted",
+		Model:   "scripted",
+		Version: "1.0.0",
+	}
+}
+
+// Close releases scripted provider resources
+func (p *ScriptedProvider) Close() error {
+	return nil
+}
+
+// ScriptBuilder scripts multi-turn conversations for a ScriptedProvider
+type ScriptBuilder struct {
+	rules    []*scriptRule
+	fallback string
+}
+
+// NewScriptBuilder creates an empty script with the default fallback response
+func NewScriptBuilder() *ScriptBuilder {
+	return &ScriptBuilder{fallback: DefaultScriptedResponse}
+}
+
+// On queues responses for user prompts containing promptSubstring
+func (b *ScriptBuilder) On(promptSubstring string, responses ...string) *ScriptBuilder {
+	if len(responses) == 0 {
+		return b
+	}
+
+	for _, rule := range b.rules {
+		if rule.match == promptSubstring {
+			rule.responses = append(rule.responses, responses...)
+			return b
+		}
+	}
+
+	b.rules = append(b.rules, &scriptRule{match: promptSubstring, responses: responses})
+	return b
+}
+
+// OnSendEvent queues a SEND_EVENT: directive addressed to agentID
+func (b *ScriptBuilder) OnSendEvent(promptSubstring, agentID, action, content, intent string) *ScriptBuilder {
+	return b.On(promptSubstring, fmt.Sprintf("SEND_EVENT:\nAgent: %s\nAction: %s\nContent: %s\nIntent: %s", agentID, action, content, intent))
+}
+
+// OnUserResponse queues a USER_RESPONSE: directive carrying message
+func (b *ScriptBuilder) OnUserResponse(promptSubstring, message string) *ScriptBuilder {
+	return b.On(promptSubstring, "USER_RESPONSE:\n"+message)
+}
+
+// WithFallback sets the response returned when no rule matches
+func (b *ScriptBuilder) WithFallback(response string) *ScriptBuilder {
+	b.fallback = response
+	return b
+}
+
+// Build creates the scripted provider
+func (b *ScriptBuilder) Build() *ScriptedProvider {
+	rules := make([]*scriptRule, len(b.rules))
+	for i, rule := range b.rules {
+		rules[i] = &scriptRule{match: rule.match, responses: append([]string(nil), rule.responses...)}
+	}
+
+	return &ScriptedProvider{
+		rules:    rules,
+		fallback: b.fallback,
+	}
+}
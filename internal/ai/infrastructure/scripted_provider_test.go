@@ -0,0 +1,78 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+
+	"neuromesh/internal/ai/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptedProvider(t *testing.T) {
+	var _ domain.AIProvider = (*ScriptedProvider)(nil)
+
+	t.Run("matches the most specific key", func(t *testing.T) {
+		provider := NewScriptedProvider(map[string]string{
+			"deploy":            "general",
+			"deploy to staging": "specific",
+		})
+
+		response, err := provider.CallAI(context.Background(), "system", "please deploy to staging now")
+
+		require.NoError(t, err)
+		assert.Equal(t, "specific", response)
+	})
+
+	t.Run("falls back to a user response", func(t *testing.T) {
+		provider := NewScriptedProvider(nil)
+
+		response, err := provider.CallAI(context.Background(), "system", "anything")
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultScriptedResponse, response)
+	})
+
+	t.Run("replays queued responses in order and repeats the last", func(t *testing.T) {
+		provider := NewScriptBuilder().On("next", "first", "second").Build()
+
+		var responses []string
+		for i := 0; i < 3; i++ {
+			response, err := provider.CallAI(context.Background(), "", "next step")
+			require.NoError(t, err)
+			responses = append(responses, response)
+		}
+
+		assert.Equal(t, []string{"first", "second", "second"}, responses)
+		assert.Len(t, provider.Calls(), 3)
+	})
+
+	t.Run("scripts an execute, agent, user response flow", func(t *testing.T) {
+		provider := NewScriptBuilder().
+			OnSendEvent("Execute plan", "text-processor", "word-count", "Count words", "analysis").
+			OnUserResponse("Process the agent response", "2 words").
+			Build()
+
+		first, err := provider.CallAI(context.Background(), "", "Execute plan for user request: count")
+		require.NoError(t, err)
+		second, err := provider.CallAI(context.Background(), "", "Process the agent response and determine next execution step.")
+		require.NoError(t, err)
+
+		assert.Equal(t, "SEND_EVENT:\nAgent: text-processor\nAction: word-count\nContent: Count words\nIntent: analysis", first)
+		assert.Equal(t, "USER_RESPONSE:\n2 words", second)
+	})
+
+	t.Run("streams the scripted response", func(t *testing.T) {
+		provider := NewScriptedProvider(map[string]string{"hi": "hello"})
+
+		stream, err := provider.CallAIStream(context.Background(), "", "hi")
+		require.NoError(t, err)
+
+		var chunks []string
+		for chunk := range stream {
+			chunks = append(chunks, chunk)
+		}
+		assert.Equal(t, []string{"hello"}, chunks)
+	})
+}
@@ -0,0 +1,135 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"neuromesh/internal/graph"
+	learningDomain "neuromesh/internal/learning/domain"
+)
+
+// Node types read by the decision analytics, persisted by the conversation and learning repositories
+const (
+	NodeTypeAIDecision      = "AIDecision"
+	NodeTypeDecisionOutcome = "DecisionOutcome"
+	RelationshipOutcomeOf   = "OUTCOME_OF" // Links an outcome to its decision
+)
+
+// UncategorizedCategory groups decisions that have no recorded outcome to take a category from
+const UncategorizedCategory = "uncategorized"
+
+// DecisionStats aggregates the AI decisions made within a time window
+type DecisionStats struct {
+	Since             time.Time                     `json:"since"` // Zero when the window is unbounded
+	TotalDecisions    int                           `json:"total_decisions"`
+	AverageConfidence float64                       `json:"average_confidence"`
+	ByType            map[string]*DecisionTypeStats `json:"by_type"`
+	ByCategory        map[string]*CategoryStats     `json:"by_category"`
+}
+
+// DecisionTypeStats aggregates the decisions of one type (EXECUTE, CLARIFY or REJECT)
+type DecisionTypeStats struct {
+	Count       int     `json:"count"`
+	Rate        float64 `json:"rate"`         // Share of all decisions in the window
+	Outcomes    int     `json:"outcomes"`     // Decisions with a recorded outcome
+	Successes   int     `json:"successes"`    // Outcomes recorded as successful
+	SuccessRate float64 `json:"success_rate"` // Successes over outcomes
+}
+
+// CategoryStats aggregates the decisions made for requests in one category
+type CategoryStats struct {
+	Count             int     `json:"count"`
+	AverageConfidence float64 `json:"average_confidence"`
+}
+
+// Service computes aggregate insight over persisted AI decisions and their outcomes
+type Service struct {
+	graph graph.Graph
+	now   func() time.Time
+}
+
+// NewService creates a new analytics service reading from the graph
+func NewService(g graph.Graph) *Service {
+	return &Service{graph: g, now: time.Now}
+}
+
+// DecisionStats aggregates the decisions created within window before now, or all decisions when window is not positive
+// Counts and confidences are aggregated in the graph per decision type and outcome, so decisions are never loaded one by one
+func (s *Service) DecisionStats(ctx context.Context, window time.Duration) (DecisionStats, error) {
+	stats := DecisionStats{
+		ByType:     make(map[string]*DecisionTypeStats),
+		ByCategory: make(map[string]*CategoryStats),
+	}
+
+	conditions := []graph.Condition{}
+	if window > 0 {
+		stats.Since = s.now().UTC().Add(-window)
+		// created_at is stored in graph.TimestampFormat, so comparing in the same format orders by time
+		conditions = append(conditions, graph.Condition{Field: "created_at", Operator: graph.OperatorGreaterThan, Value: stats.Since.Format(graph.TimestampFormat)})
+	}
+
+	groups, err := s.graph.Aggregate(ctx, graph.Aggregation{
+		NodeType:        NodeTypeAIDecision,
+		Conditions:      conditions,
+		RelatedType:     NodeTypeDecisionOutcome,
+		RelatedEdgeType: RelationshipOutcomeOf,
+		GroupBy:         []string{"decision_type"},
+		RelatedGroupBy:  []string{"status", "category"},
+		AverageProperty: "confidence",
+	})
+	if err != nil {
+		return DecisionStats{}, fmt.Errorf("failed to aggregate decisions: %w", err)
+	}
+
+	totalConfidence := 0.0
+	categoryConfidence := make(map[string]float64)
+	for _, group := range groups {
+		decisionType, _ := group.Values["decision_type"].(string)
+		status, hasOutcome := group.Values[graph.RelatedPrefix+"status"].(string)
+		confidence := group.Average * float64(group.Count)
+		totalConfidence += confidence
+		stats.TotalDecisions += group.Count
+
+		typeStats, ok := stats.ByType[decisionType]
+		if !ok {
+			typeStats = &DecisionTypeStats{}
+			stats.ByType[decisionType] = typeStats
+		}
+		typeStats.Count += group.Count
+		if hasOutcome {
+			typeStats.Outcomes += group.Count
+			if status == string(learningDomain.OutcomeStatusSuccess) {
+				typeStats.Successes += group.Count
+			}
+		}
+
+		category := UncategorizedCategory
+		if outcomeCategory, _ := group.Values[graph.RelatedPrefix+"category"].(string); hasOutcome && outcomeCategory != "" {
+			category = outcomeCategory
+		}
+		categoryStats, ok := stats.ByCategory[category]
+		if !ok {
+			categoryStats = &CategoryStats{}
+			stats.ByCategory[category] = categoryStats
+		}
+		categoryStats.Count += group.Count
+		categoryConfidence[category] += confidence
+	}
+	if stats.TotalDecisions == 0 {
+		return stats, nil
+	}
+
+	stats.AverageConfidence = totalConfidence / float64(stats.TotalDecisions)
+	for _, typeStats := range stats.ByType {
+		typeStats.Rate = float64(typeStats.Count) / float64(stats.TotalDecisions)
+		if typeStats.Outcomes > 0 {
+			typeStats.SuccessRate = float64(typeStats.Successes) / float64(typeStats.Outcomes)
+		}
+	}
+	for category, categoryStats := range stats.ByCategory {
+		categoryStats.AverageConfidence = categoryConfidence[category] / float64(categoryStats.Count)
+	}
+
+	return stats, nil
+}
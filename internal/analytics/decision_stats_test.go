@@ -0,0 +1,171 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conversationDomain "neuromesh/internal/conversation/domain"
+	conversationInfra "neuromesh/internal/conversation/infrastructure"
+	"neuromesh/internal/graph"
+	learningDomain "neuromesh/internal/learning/domain"
+	learningInfra "neuromesh/internal/learning/infrastructure"
+	"neuromesh/testHelpers"
+)
+
+// seededDecision is an AI decision together with the outcome recorded for it, if any
+type seededDecision struct {
+	id         string
+	typ        string
+	confidence int
+	age        time.Duration
+	category   string
+	status     learningDomain.OutcomeStatus
+}
+
+func newTestAnalyticsService(t *testing.T, now time.Time, decisions []seededDecision) *Service {
+	ctx := context.Background()
+	g := testHelpers.NewCleanMockGraph()
+	conversationRepo := conversationInfra.NewGraphConversationRepository(g)
+	outcomeRepo := learningInfra.NewGraphOutcomeRepository(g)
+
+	conversation, err := conversationDomain.NewConversation("conv-1", "session-1", "user-1")
+	require.NoError(t, err)
+	require.NoError(t, conversationRepo.CreateConversation(ctx, conversation))
+	for _, seeded := range decisions {
+		require.NoError(t, conversationRepo.CreateAIDecision(ctx, &conversationDomain.AIDecision{
+			ID:             seeded.id,
+			ConversationID: "conv-1",
+			Type:           seeded.typ,
+			Confidence:     seeded.confidence,
+			CreatedAt:      now.Add(-seeded.age),
+		}))
+		if seeded.status != "" {
+			require.NoError(t, outcomeRepo.Save(ctx, &learningDomain.Outcome{
+				DecisionID: seeded.id,
+				Category:   seeded.category,
+				Status:     seeded.status,
+				RecordedAt: now.Add(-seeded.age),
+			}))
+		}
+	}
+
+	service := NewService(g)
+	service.now = func() time.Time { return now }
+	return service
+}
+
+func TestService_DecisionStats(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := newTestAnalyticsService(t, now, []seededDecision{
+		{id: "execute-text-1", typ: "EXECUTE", confidence: 90, age: time.Hour, category: "text_processing", status: learningDomain.OutcomeStatusSuccess},
+		{id: "execute-text-2", typ: "EXECUTE", confidence: 80, age: 2 * time.Hour, category: "text_processing", status: learningDomain.OutcomeStatusFailure},
+		{id: "execute-deploy", typ: "EXECUTE", confidence: 70, age: 3 * time.Hour, category: "deployment", status: learningDomain.OutcomeStatusSuccess},
+		{id: "clarify-deploy", typ: "CLARIFY", confidence: 40, age: 4 * time.Hour, category: "deployment", status: learningDomain.OutcomeStatusSuccess},
+		{id: "reject-unknown", typ: "REJECT", confidence: 20, age: 5 * time.Hour},
+		{id: "execute-old", typ: "EXECUTE", confidence: 10, age: 48 * time.Hour, category: "text_processing", status: learningDomain.OutcomeStatusFailure},
+	})
+
+	t.Run("aggregates the decisions inside the window", func(t *testing.T) {
+		stats, err := service.DecisionStats(context.Background(), 24*time.Hour)
+		require.NoError(t, err)
+
+		assert.Equal(t, now.Add(-24*time.Hour), stats.Since)
+		assert.Equal(t, 5, stats.TotalDecisions)
+		assert.InDelta(t, 60.0, stats.AverageConfidence, 0.001)
+
+		require.Contains(t, stats.ByType, "EXECUTE")
+		execute := stats.ByType["EXECUTE"]
+		assert.Equal(t, 3, execute.Count)
+		assert.InDelta(t, 0.6, execute.Rate, 0.001)
+		assert.Equal(t, 3, execute.Outcomes)
+		assert.Equal(t, 2, execute.Successes)
+		assert.InDelta(t, 2.0/3.0, execute.SuccessRate, 0.001)
+
+		assert.InDelta(t, 0.2, stats.ByType["CLARIFY"].Rate, 0.001)
+		assert.InDelta(t, 1.0, stats.ByType["CLARIFY"].SuccessRate, 0.001)
+		assert.InDelta(t, 0.2, stats.ByType["REJECT"].Rate, 0.001)
+		assert.Equal(t, 0, stats.ByType["REJECT"].Outcomes)
+		assert.Zero(t, stats.ByType["REJECT"].SuccessRate)
+
+		require.Len(t, stats.ByCategory, 3)
+		assert.Equal(t, 2, stats.ByCategory["text_processing"].Count)
+		assert.InDelta(t, 85.0, stats.ByCategory["text_processing"].AverageConfidence, 0.001)
+		assert.InDelta(t, 55.0, stats.ByCategory["deployment"].AverageConfidence, 0.001)
+		assert.InDelta(t, 20.0, stats.ByCategory[UncategorizedCategory].AverageConfidence, 0.001)
+	})
+
+	t.Run("covers every decision without a window", func(t *testing.T) {
+		stats, err := service.DecisionStats(context.Background(), 0)
+		require.NoError(t, err)
+
+		assert.True(t, stats.Since.IsZero())
+		assert.Equal(t, 6, stats.TotalDecisions)
+		assert.Equal(t, 4, stats.ByType["EXECUTE"].Count)
+		assert.InDelta(t, 0.5, stats.ByType["EXECUTE"].SuccessRate, 0.001)
+		assert.InDelta(t, 60.0, stats.ByCategory["text_processing"].AverageConfidence, 0.001)
+	})
+}
+
+func TestService_DecisionStats_NoDecisions(t *testing.T) {
+	service := newTestAnalyticsService(t, time.Now(), nil)
+
+	stats, err := service.DecisionStats(context.Background(), time.Hour)
+	require.NoError(t, err)
+	assert.Zero(t, stats.TotalDecisions)
+	assert.Zero(t, stats.AverageConfidence)
+	assert.Empty(t, stats.ByType)
+	assert.Empty(t, stats.ByCategory)
+}
+
+// aggregateCountingGraph counts the graph reads the analytics service makes
+type aggregateCountingGraph struct {
+	graph.Graph
+	aggregates int
+	nodeReads  int
+}
+
+func (g *aggregateCountingGraph) Aggregate(ctx context.Context, aggregation graph.Aggregation) ([]graph.AggregateGroup, error) {
+	g.aggregates++
+	return g.Graph.Aggregate(ctx, aggregation)
+}
+
+func (g *aggregateCountingGraph) QueryNodesAdvanced(ctx context.Context, nodeType string, conditions []graph.Condition) ([]map[string]interface{}, error) {
+	g.nodeReads++
+	return g.Graph.QueryNodesAdvanced(ctx, nodeType, conditions)
+}
+
+func TestService_DecisionStats_AggregatesInOneQuery(t *testing.T) {
+	now := time.Now()
+	seeded := newTestAnalyticsService(t, now, []seededDecision{
+		{id: "execute-1", typ: "EXECUTE", confidence: 90, age: time.Minute, category: "text_processing", status: learningDomain.OutcomeStatusSuccess},
+		{id: "execute-2", typ: "EXECUTE", confidence: 70, age: time.Minute},
+	})
+	counting := &aggregateCountingGraph{Graph: seeded.graph}
+	service := NewService(counting)
+
+	stats, err := service.DecisionStats(context.Background(), time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, stats.TotalDecisions)
+	assert.InDelta(t, 80.0, stats.AverageConfidence, 0.001)
+	assert.Equal(t, 1, counting.aggregates)
+	assert.Zero(t, counting.nodeReads, "decisions must not be loaded into Go")
+}
+
+func TestService_DecisionStats_CountsDecisionsWithinTheWindowStartSecond(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := newTestAnalyticsService(t, now, []seededDecision{
+		{id: "just-inside", typ: "EXECUTE", confidence: 90, age: time.Hour - 500*time.Millisecond},
+		{id: "just-outside", typ: "EXECUTE", confidence: 10, age: time.Hour + 500*time.Millisecond},
+	})
+
+	stats, err := service.DecisionStats(context.Background(), time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, stats.TotalDecisions)
+	assert.InDelta(t, 90.0, stats.AverageConfidence, 0.001)
+}
@@ -32,6 +32,7 @@ const (
 	AgentStatus_AGENT_STATUS_BUSY          AgentStatus = 2
 	AgentStatus_AGENT_STATUS_ERROR         AgentStatus = 3
 	AgentStatus_AGENT_STATUS_SHUTTING_DOWN AgentStatus = 4
+	AgentStatus_AGENT_STATUS_OFFLINE       AgentStatus = 5
 )
 
 // Enum value maps for AgentStatus.
@@ -42,6 +43,7 @@ var (
 		2: "AGENT_STATUS_BUSY",
 		3: "AGENT_STATUS_ERROR",
 		4: "AGENT_STATUS_SHUTTING_DOWN",
+		5: "AGENT_STATUS_OFFLINE",
 	}
 	AgentStatus_value = map[string]int32{
 		"AGENT_STATUS_UNKNOWN":       0,
@@ -49,6 +51,7 @@ var (
 		"AGENT_STATUS_BUSY":          2,
 		"AGENT_STATUS_ERROR":         3,
 		"AGENT_STATUS_SHUTTING_DOWN": 4,
+		"AGENT_STATUS_OFFLINE":       5,
 	}
 )
 
@@ -82,12 +85,13 @@ func (AgentStatus) EnumDescriptor() ([]byte, []int) {
 type MessageType int32
 
 const (
-	MessageType_MESSAGE_TYPE_UNKNOWN       MessageType = 0
-	MessageType_MESSAGE_TYPE_INSTRUCTION   MessageType = 1 // AI → Agent: Natural language instruction
-	MessageType_MESSAGE_TYPE_COMPLETION    MessageType = 2 // Agent → AI: Task completion response
-	MessageType_MESSAGE_TYPE_STATUS_UPDATE MessageType = 3 // Agent status updates (DEPRECATED - use UpdateAgentStatus RPC instead)
-	MessageType_MESSAGE_TYPE_ERROR         MessageType = 4 // Error notifications
-	MessageType_MESSAGE_TYPE_HEARTBEAT     MessageType = 5 // Keep-alive messages (DEPRECATED - use Heartbeat RPC instead)
+	MessageType_MESSAGE_TYPE_UNKNOWN        MessageType = 0
+	MessageType_MESSAGE_TYPE_INSTRUCTION    MessageType = 1 // AI → Agent: Natural language instruction
+	MessageType_MESSAGE_TYPE_COMPLETION     MessageType = 2 // Agent → AI: Task completion response
+	MessageType_MESSAGE_TYPE_STATUS_UPDATE  MessageType = 3 // Agent status updates (DEPRECATED - use UpdateAgentStatus RPC instead)
+	MessageType_MESSAGE_TYPE_ERROR          MessageType = 4 // Error notifications
+	MessageType_MESSAGE_TYPE_HEARTBEAT      MessageType = 5 // Keep-alive messages (DEPRECATED - use Heartbeat RPC instead)
+	MessageType_MESSAGE_TYPE_AGENT_TO_AGENT MessageType = 6 // Agent → Agent: Hand-off routed through the orchestrator
 )
 
 // Enum value maps for MessageType.
@@ -99,14 +103,16 @@ var (
 		3: "MESSAGE_TYPE_STATUS_UPDATE",
 		4: "MESSAGE_TYPE_ERROR",
 		5: "MESSAGE_TYPE_HEARTBEAT",
+		6: "MESSAGE_TYPE_AGENT_TO_AGENT",
 	}
 	MessageType_value = map[string]int32{
-		"MESSAGE_TYPE_UNKNOWN":       0,
-		"MESSAGE_TYPE_INSTRUCTION":   1,
-		"MESSAGE_TYPE_COMPLETION":    2,
-		"MESSAGE_TYPE_STATUS_UPDATE": 3,
-		"MESSAGE_TYPE_ERROR":         4,
-		"MESSAGE_TYPE_HEARTBEAT":     5,
+		"MESSAGE_TYPE_UNKNOWN":        0,
+		"MESSAGE_TYPE_INSTRUCTION":    1,
+		"MESSAGE_TYPE_COMPLETION":     2,
+		"MESSAGE_TYPE_STATUS_UPDATE":  3,
+		"MESSAGE_TYPE_ERROR":          4,
+		"MESSAGE_TYPE_HEARTBEAT":      5,
+		"MESSAGE_TYPE_AGENT_TO_AGENT": 6,
 	}
 )
 
@@ -228,6 +234,7 @@ type RegisterAgentResponse struct {
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	SessionId     string                 `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 	RegisteredAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=registered_at,json=registeredAt,proto3" json:"registered_at,omitempty"`
+	Status        AgentStatus            `protobuf:"varint,5,opt,name=status,proto3,enum=orchestration.AgentStatus" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -290,15 +297,23 @@ func (x *RegisterAgentResponse) GetRegisteredAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *RegisterAgentResponse) GetStatus() AgentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return AgentStatus_AGENT_STATUS_UNKNOWN
+}
+
 // Agent capabilities - what the agent can do
 type AgentCapability struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`               // e.g., "word-count", "text-analysis"
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"` // Human-readable description for AI
-	Inputs        []string               `protobuf:"bytes,3,rep,name=inputs,proto3" json:"inputs,omitempty"`           // Expected input types
-	Outputs       []string               `protobuf:"bytes,4,rep,name=outputs,proto3" json:"outputs,omitempty"`         // Expected output types
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                            // e.g., "word-count", "text-analysis"
+	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`                              // Human-readable description for AI
+	Inputs         []string               `protobuf:"bytes,3,rep,name=inputs,proto3" json:"inputs,omitempty"`                                        // Expected input types
+	Outputs        []string               `protobuf:"bytes,4,rep,name=outputs,proto3" json:"outputs,omitempty"`                                      // Expected output types
+	TimeoutSeconds int32                  `protobuf:"varint,5,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"` // How long the capability may take; 0 uses the orchestrator default
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *AgentCapability) Reset() {
@@ -359,6 +374,13 @@ func (x *AgentCapability) GetOutputs() []string {
 	return nil
 }
 
+func (x *AgentCapability) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
 // Heartbeat - simple health check
 type HeartbeatRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1113,6 +1135,7 @@ type UpdateAgentStatusResponse struct {
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	ServerTime    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=server_time,json=serverTime,proto3" json:"server_time,omitempty"`
+	Status        AgentStatus            `protobuf:"varint,4,opt,name=status,proto3,enum=orchestration.AgentStatus" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1168,6 +1191,13 @@ func (x *UpdateAgentStatusResponse) GetServerTime() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *UpdateAgentStatusResponse) GetStatus() AgentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return AgentStatus_AGENT_STATUS_UNKNOWN
+}
+
 var File_api_orchestration_proto protoreflect.FileDescriptor
 
 const file_api_orchestration_proto_rawDesc = "" +
@@ -1179,18 +1209,20 @@ const file_api_orchestration_proto_rawDesc = "" +
 	"\x04type\x18\x03 \x01(\tR\x04type\x12B\n" +
 	"\fcapabilities\x18\x04 \x03(\v2\x1e.orchestration.AgentCapabilityR\fcapabilities\x12\x18\n" +
 	"\aversion\x18\x05 \x01(\tR\aversion\x123\n" +
-	"\bmetadata\x18\x06 \x01(\v2\x17.google.protobuf.StructR\bmetadata\"\xab\x01\n" +
+	"\bmetadata\x18\x06 \x01(\v2\x17.google.protobuf.StructR\bmetadata\"\xdf\x01\n" +
 	"\x15RegisterAgentResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x03 \x01(\tR\tsessionId\x12?\n" +
-	"\rregistered_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fregisteredAt\"y\n" +
+	"\rregistered_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fregisteredAt\x122\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1a.orchestration.AgentStatusR\x06status\"\xa2\x01\n" +
 	"\x0fAgentCapability\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
 	"\x06inputs\x18\x03 \x03(\tR\x06inputs\x12\x18\n" +
-	"\aoutputs\x18\x04 \x03(\tR\aoutputs\"\xc0\x01\n" +
+	"\aoutputs\x18\x04 \x03(\tR\aoutputs\x12'\n" +
+	"\x0ftimeout_seconds\x18\x05 \x01(\x05R\x0etimeoutSeconds\"\xc0\x01\n" +
 	"\x10HeartbeatRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1d\n" +
 	"\n" +
@@ -1258,25 +1290,28 @@ const file_api_orchestration_proto_rawDesc = "" +
 	"session_id\x18\x02 \x01(\tR\tsessionId\x122\n" +
 	"\x06status\x18\x03 \x01(\x0e2\x1a.orchestration.AgentStatusR\x06status\x123\n" +
 	"\bmetadata\x18\x04 \x01(\v2\x17.google.protobuf.StructR\bmetadata\x128\n" +
-	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x8c\x01\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xc0\x01\n" +
 	"\x19UpdateAgentStatusResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12;\n" +
 	"\vserver_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"serverTime*\x90\x01\n" +
+	"serverTime\x122\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1a.orchestration.AgentStatusR\x06status*\xaa\x01\n" +
 	"\vAgentStatus\x12\x18\n" +
 	"\x14AGENT_STATUS_UNKNOWN\x10\x00\x12\x18\n" +
 	"\x14AGENT_STATUS_HEALTHY\x10\x01\x12\x15\n" +
 	"\x11AGENT_STATUS_BUSY\x10\x02\x12\x16\n" +
 	"\x12AGENT_STATUS_ERROR\x10\x03\x12\x1e\n" +
-	"\x1aAGENT_STATUS_SHUTTING_DOWN\x10\x04*\xb6\x01\n" +
+	"\x1aAGENT_STATUS_SHUTTING_DOWN\x10\x04\x12\x18\n" +
+	"\x14AGENT_STATUS_OFFLINE\x10\x05*\xd7\x01\n" +
 	"\vMessageType\x12\x18\n" +
 	"\x14MESSAGE_TYPE_UNKNOWN\x10\x00\x12\x1c\n" +
 	"\x18MESSAGE_TYPE_INSTRUCTION\x10\x01\x12\x1b\n" +
 	"\x17MESSAGE_TYPE_COMPLETION\x10\x02\x12\x1e\n" +
 	"\x1aMESSAGE_TYPE_STATUS_UPDATE\x10\x03\x12\x16\n" +
 	"\x12MESSAGE_TYPE_ERROR\x10\x04\x12\x1a\n" +
-	"\x16MESSAGE_TYPE_HEARTBEAT\x10\x052\x9f\x05\n" +
+	"\x16MESSAGE_TYPE_HEARTBEAT\x10\x05\x12\x1f\n" +
+	"\x1bMESSAGE_TYPE_AGENT_TO_AGENT\x10\x062\x9f\x05\n" +
 	"\x14OrchestrationService\x12Z\n" +
 	"\rRegisterAgent\x12#.orchestration.RegisterAgentRequest\x1a$.orchestration.RegisterAgentResponse\x12`\n" +
 	"\x0fUnregisterAgent\x12%.orchestration.UnregisterAgentRequest\x1a&.orchestration.UnregisterAgentResponse\x12N\n" +
@@ -1324,39 +1359,41 @@ var file_api_orchestration_proto_depIdxs = []int32{
 	4,  // 0: orchestration.RegisterAgentRequest.capabilities:type_name -> orchestration.AgentCapability
 	16, // 1: orchestration.RegisterAgentRequest.metadata:type_name -> google.protobuf.Struct
 	17, // 2: orchestration.RegisterAgentResponse.registered_at:type_name -> google.protobuf.Timestamp
-	0,  // 3: orchestration.HeartbeatRequest.status:type_name -> orchestration.AgentStatus
-	16, // 4: orchestration.HeartbeatRequest.health_metrics:type_name -> google.protobuf.Struct
-	17, // 5: orchestration.HeartbeatResponse.server_time:type_name -> google.protobuf.Timestamp
-	1,  // 6: orchestration.ConversationMessage.type:type_name -> orchestration.MessageType
-	16, // 7: orchestration.ConversationMessage.context:type_name -> google.protobuf.Struct
-	17, // 8: orchestration.ConversationMessage.timestamp:type_name -> google.protobuf.Timestamp
-	16, // 9: orchestration.InstructionMessage.parameters:type_name -> google.protobuf.Struct
-	17, // 10: orchestration.InstructionMessage.timestamp:type_name -> google.protobuf.Timestamp
-	16, // 11: orchestration.CompletionMessage.result_data:type_name -> google.protobuf.Struct
-	17, // 12: orchestration.CompletionMessage.timestamp:type_name -> google.protobuf.Timestamp
-	0,  // 13: orchestration.UpdateAgentStatusRequest.status:type_name -> orchestration.AgentStatus
-	16, // 14: orchestration.UpdateAgentStatusRequest.metadata:type_name -> google.protobuf.Struct
-	17, // 15: orchestration.UpdateAgentStatusRequest.timestamp:type_name -> google.protobuf.Timestamp
-	17, // 16: orchestration.UpdateAgentStatusResponse.server_time:type_name -> google.protobuf.Timestamp
-	2,  // 17: orchestration.OrchestrationService.RegisterAgent:input_type -> orchestration.RegisterAgentRequest
-	7,  // 18: orchestration.OrchestrationService.UnregisterAgent:input_type -> orchestration.UnregisterAgentRequest
-	5,  // 19: orchestration.OrchestrationService.Heartbeat:input_type -> orchestration.HeartbeatRequest
-	14, // 20: orchestration.OrchestrationService.UpdateAgentStatus:input_type -> orchestration.UpdateAgentStatusRequest
-	9,  // 21: orchestration.OrchestrationService.OpenConversation:input_type -> orchestration.ConversationMessage
-	10, // 22: orchestration.OrchestrationService.SendInstruction:input_type -> orchestration.InstructionMessage
-	12, // 23: orchestration.OrchestrationService.ReportCompletion:input_type -> orchestration.CompletionMessage
-	3,  // 24: orchestration.OrchestrationService.RegisterAgent:output_type -> orchestration.RegisterAgentResponse
-	8,  // 25: orchestration.OrchestrationService.UnregisterAgent:output_type -> orchestration.UnregisterAgentResponse
-	6,  // 26: orchestration.OrchestrationService.Heartbeat:output_type -> orchestration.HeartbeatResponse
-	15, // 27: orchestration.OrchestrationService.UpdateAgentStatus:output_type -> orchestration.UpdateAgentStatusResponse
-	9,  // 28: orchestration.OrchestrationService.OpenConversation:output_type -> orchestration.ConversationMessage
-	11, // 29: orchestration.OrchestrationService.SendInstruction:output_type -> orchestration.InstructionResponse
-	13, // 30: orchestration.OrchestrationService.ReportCompletion:output_type -> orchestration.CompletionResponse
-	24, // [24:31] is the sub-list for method output_type
-	17, // [17:24] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	0,  // 3: orchestration.RegisterAgentResponse.status:type_name -> orchestration.AgentStatus
+	0,  // 4: orchestration.HeartbeatRequest.status:type_name -> orchestration.AgentStatus
+	16, // 5: orchestration.HeartbeatRequest.health_metrics:type_name -> google.protobuf.Struct
+	17, // 6: orchestration.HeartbeatResponse.server_time:type_name -> google.protobuf.Timestamp
+	1,  // 7: orchestration.ConversationMessage.type:type_name -> orchestration.MessageType
+	16, // 8: orchestration.ConversationMessage.context:type_name -> google.protobuf.Struct
+	17, // 9: orchestration.ConversationMessage.timestamp:type_name -> google.protobuf.Timestamp
+	16, // 10: orchestration.InstructionMessage.parameters:type_name -> google.protobuf.Struct
+	17, // 11: orchestration.InstructionMessage.timestamp:type_name -> google.protobuf.Timestamp
+	16, // 12: orchestration.CompletionMessage.result_data:type_name -> google.protobuf.Struct
+	17, // 13: orchestration.CompletionMessage.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 14: orchestration.UpdateAgentStatusRequest.status:type_name -> orchestration.AgentStatus
+	16, // 15: orchestration.UpdateAgentStatusRequest.metadata:type_name -> google.protobuf.Struct
+	17, // 16: orchestration.UpdateAgentStatusRequest.timestamp:type_name -> google.protobuf.Timestamp
+	17, // 17: orchestration.UpdateAgentStatusResponse.server_time:type_name -> google.protobuf.Timestamp
+	0,  // 18: orchestration.UpdateAgentStatusResponse.status:type_name -> orchestration.AgentStatus
+	2,  // 19: orchestration.OrchestrationService.RegisterAgent:input_type -> orchestration.RegisterAgentRequest
+	7,  // 20: orchestration.OrchestrationService.UnregisterAgent:input_type -> orchestration.UnregisterAgentRequest
+	5,  // 21: orchestration.OrchestrationService.Heartbeat:input_type -> orchestration.HeartbeatRequest
+	14, // 22: orchestration.OrchestrationService.UpdateAgentStatus:input_type -> orchestration.UpdateAgentStatusRequest
+	9,  // 23: orchestration.OrchestrationService.OpenConversation:input_type -> orchestration.ConversationMessage
+	10, // 24: orchestration.OrchestrationService.SendInstruction:input_type -> orchestration.InstructionMessage
+	12, // 25: orchestration.OrchestrationService.ReportCompletion:input_type -> orchestration.CompletionMessage
+	3,  // 26: orchestration.OrchestrationService.RegisterAgent:output_type -> orchestration.RegisterAgentResponse
+	8,  // 27: orchestration.OrchestrationService.UnregisterAgent:output_type -> orchestration.UnregisterAgentResponse
+	6,  // 28: orchestration.OrchestrationService.Heartbeat:output_type -> orchestration.HeartbeatResponse
+	15, // 29: orchestration.OrchestrationService.UpdateAgentStatus:output_type -> orchestration.UpdateAgentStatusResponse
+	9,  // 30: orchestration.OrchestrationService.OpenConversation:output_type -> orchestration.ConversationMessage
+	11, // 31: orchestration.OrchestrationService.SendInstruction:output_type -> orchestration.InstructionResponse
+	13, // 32: orchestration.OrchestrationService.ReportCompletion:output_type -> orchestration.CompletionResponse
+	26, // [26:33] is the sub-list for method output_type
+	19, // [19:26] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_api_orchestration_proto_init() }
@@ -3,7 +3,9 @@ package application
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	aiDomain "neuromesh/internal/ai/domain"
 	"neuromesh/internal/conversation/domain"
 )
 
@@ -14,16 +16,28 @@ type ConversationService interface {
 	GetConversation(ctx context.Context, conversationID string) (*domain.Conversation, error)
 	GetConversationWithMessages(ctx context.Context, conversationID string) (*domain.Conversation, error)
 	UpdateConversationStatus(ctx context.Context, conversationID string, status domain.ConversationStatus) error
-	DeleteConversation(ctx context.Context, conversationID string) error
+	ArchiveConversation(ctx context.Context, conversationID string) error
+	PurgeConversation(ctx context.Context, conversationID string) error
 
 	// Message management
 	AddMessage(ctx context.Context, conversationID, messageID string, role domain.MessageRole, content string, metadata map[string]interface{}) error
 	GetConversationMessages(ctx context.Context, conversationID string) ([]domain.ConversationMessage, error)
 	GetMessagesByRole(ctx context.Context, conversationID string, role domain.MessageRole) ([]domain.ConversationMessage, error)
 
+	// Summarization
+	SummarizeConversation(ctx context.Context, conversationID string) (string, error)
+	GetPromptHistory(ctx context.Context, conversationID string) (string, error)
+	GetPromptContext(ctx context.Context, conversationID string, maxMessages int) (domain.PromptContext, error)
+
 	// Execution plan linking
 	LinkExecutionPlan(ctx context.Context, conversationID, planID string) error
 
+	// Decision history
+	RecordDecision(ctx context.Context, decision *domain.AIDecision) error
+	GetDecisionsByConversation(ctx context.Context, conversationID string) ([]*domain.AIDecision, error)
+	GetUserRequestWithDecisions(ctx context.Context, messageID string) (*domain.UserRequest, error)
+	GetDecisionChain(ctx context.Context, decisionID string) ([]*domain.AIDecision, error)
+
 	// Relationship management
 	LinkConversationToSession(ctx context.Context, conversationID, sessionID string) error
 	LinkConversationToUser(ctx context.Context, conversationID, userID string) error
@@ -32,20 +46,47 @@ type ConversationService interface {
 	FindConversationsByUser(ctx context.Context, userID string) ([]*domain.Conversation, error)
 	FindConversationsBySession(ctx context.Context, sessionID string) ([]*domain.Conversation, error)
 	FindActiveConversations(ctx context.Context) ([]*domain.Conversation, error)
+	SearchMessages(ctx context.Context, userID, query string, opts domain.SearchOptions) ([]domain.MessageHit, error)
 
 	// Schema management
 	EnsureSchema(ctx context.Context) error
 }
 
+// SummarizationConfig controls when conversation history is condensed into a summary
+type SummarizationConfig struct {
+	MessageThreshold int // Summarize once more unsummarized messages than this accumulate
+	RecentMessages   int // Most recent messages kept verbatim in prompts
+}
+
+// DefaultSummarizationConfig returns the default summarization settings
+func DefaultSummarizationConfig() SummarizationConfig {
+	return SummarizationConfig{
+		MessageThreshold: 20,
+		RecentMessages:   10,
+	}
+}
+
 // ConversationServiceImpl implements the ConversationService interface
 type ConversationServiceImpl struct {
-	repo domain.ConversationRepository
+	repo                domain.ConversationRepository
+	aiProvider          aiDomain.AIProvider
+	summarizationConfig SummarizationConfig
 }
 
 // NewConversationService creates a new conversation service implementation
 func NewConversationService(repo domain.ConversationRepository) ConversationService {
 	return &ConversationServiceImpl{
-		repo: repo,
+		repo:                repo,
+		summarizationConfig: DefaultSummarizationConfig(),
+	}
+}
+
+// NewConversationServiceWithSummarizer creates a conversation service that summarizes long histories with AI
+func NewConversationServiceWithSummarizer(repo domain.ConversationRepository, aiProvider aiDomain.AIProvider, config SummarizationConfig) ConversationService {
+	return &ConversationServiceImpl{
+		repo:                repo,
+		aiProvider:          aiProvider,
+		summarizationConfig: config,
 	}
 }
 
@@ -82,14 +123,39 @@ func (s *ConversationServiceImpl) GetConversation(ctx context.Context, conversat
 }
 
 // GetConversationWithMessages retrieves a conversation with all its messages
+// When ctx carries a requesting user, the conversation must belong to that user
 func (s *ConversationServiceImpl) GetConversationWithMessages(ctx context.Context, conversationID string) (*domain.Conversation, error) {
 	conversation, err := s.repo.GetConversationWithMessages(ctx, conversationID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get conversation with messages: %w", err)
 	}
+	if err := authorize(ctx, conversation); err != nil {
+		return nil, err
+	}
 	return conversation, nil
 }
 
+// authorize verifies that the requesting user in ctx, if any, owns the conversation
+func authorize(ctx context.Context, conversation *domain.Conversation) error {
+	userID, ok := domain.RequestingUser(ctx)
+	if !ok {
+		return nil
+	}
+	return conversation.AuthorizeUser(userID)
+}
+
+// authorizeByID loads a conversation and verifies the requesting user in ctx, if any, owns it
+func (s *ConversationServiceImpl) authorizeByID(ctx context.Context, conversationID string) error {
+	if _, ok := domain.RequestingUser(ctx); !ok {
+		return nil
+	}
+	conversation, err := s.repo.GetConversation(ctx, conversationID)
+	if err != nil {
+		return fmt.Errorf("failed to get conversation: %w", err)
+	}
+	return authorize(ctx, conversation)
+}
+
 // UpdateConversationStatus updates a conversation's status
 func (s *ConversationServiceImpl) UpdateConversationStatus(ctx context.Context, conversationID string, status domain.ConversationStatus) error {
 	conversation, err := s.repo.GetConversation(ctx, conversationID)
@@ -97,7 +163,9 @@ func (s *ConversationServiceImpl) UpdateConversationStatus(ctx context.Context,
 		return fmt.Errorf("failed to get conversation: %w", err)
 	}
 
-	conversation.SetStatus(status)
+	if err := conversation.TransitionTo(status); err != nil {
+		return err
+	}
 
 	if err := s.repo.UpdateConversation(ctx, conversation); err != nil {
 		return fmt.Errorf("failed to update conversation: %w", err)
@@ -106,10 +174,19 @@ func (s *ConversationServiceImpl) UpdateConversationStatus(ctx context.Context,
 	return nil
 }
 
-// DeleteConversation deletes a conversation
-func (s *ConversationServiceImpl) DeleteConversation(ctx context.Context, conversationID string) error {
-	if err := s.repo.DeleteConversation(ctx, conversationID); err != nil {
-		return fmt.Errorf("failed to delete conversation: %w", err)
+// ArchiveConversation soft-deletes a conversation so it is hidden from active queries but kept for audit
+func (s *ConversationServiceImpl) ArchiveConversation(ctx context.Context, conversationID string) error {
+	if err := s.repo.ArchiveConversation(ctx, conversationID); err != nil {
+		return fmt.Errorf("failed to archive conversation: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeConversation permanently deletes a conversation; prefer ArchiveConversation
+func (s *ConversationServiceImpl) PurgeConversation(ctx context.Context, conversationID string) error {
+	if err := s.repo.PurgeConversation(ctx, conversationID); err != nil {
+		return fmt.Errorf("failed to purge conversation: %w", err)
 	}
 	return nil
 }
@@ -121,6 +198,9 @@ func (s *ConversationServiceImpl) AddMessage(ctx context.Context, conversationID
 	if err != nil {
 		return fmt.Errorf("failed to get conversation: %w", err)
 	}
+	if err := authorize(ctx, conversation); err != nil {
+		return err
+	}
 
 	// Add message to conversation domain object
 	if err := conversation.AddMessage(messageID, role, content, metadata); err != nil {
@@ -156,6 +236,9 @@ func (s *ConversationServiceImpl) AddMessage(ctx context.Context, conversationID
 
 // GetConversationMessages retrieves all messages for a conversation
 func (s *ConversationServiceImpl) GetConversationMessages(ctx context.Context, conversationID string) ([]domain.ConversationMessage, error) {
+	if err := s.authorizeByID(ctx, conversationID); err != nil {
+		return nil, err
+	}
 	messages, err := s.repo.GetConversationMessages(ctx, conversationID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get conversation messages: %w", err)
@@ -165,6 +248,9 @@ func (s *ConversationServiceImpl) GetConversationMessages(ctx context.Context, c
 
 // GetMessagesByRole retrieves messages by role for a conversation
 func (s *ConversationServiceImpl) GetMessagesByRole(ctx context.Context, conversationID string, role domain.MessageRole) ([]domain.ConversationMessage, error) {
+	if err := s.authorizeByID(ctx, conversationID); err != nil {
+		return nil, err
+	}
 	messages, err := s.repo.GetMessagesByRole(ctx, conversationID, role)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get messages by role: %w", err)
@@ -172,6 +258,93 @@ func (s *ConversationServiceImpl) GetMessagesByRole(ctx context.Context, convers
 	return messages, nil
 }
 
+// SummarizeConversation folds older messages into the conversation summary once the threshold is exceeded
+// The most recent messages stay verbatim; the resulting summary is returned
+func (s *ConversationServiceImpl) SummarizeConversation(ctx context.Context, conversationID string) (string, error) {
+	conversation, err := s.repo.GetConversationWithMessages(ctx, conversationID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get conversation with messages: %w", err)
+	}
+
+	pending := conversation.UnsummarizedMessages()
+	if len(pending) <= s.summarizationConfig.MessageThreshold {
+		return conversation.Summary, nil
+	}
+	if s.aiProvider == nil {
+		return "", fmt.Errorf("conversation summarization requires an AI provider")
+	}
+
+	keep := s.summarizationConfig.RecentMessages
+	if keep < 0 {
+		keep = 0
+	}
+	toSummarize := pending[:len(pending)-min(keep, len(pending))]
+	if len(toSummarize) == 0 {
+		return conversation.Summary, nil
+	}
+
+	var transcript strings.Builder
+	messageIDs := make([]string, len(toSummarize))
+	for i, message := range toSummarize {
+		transcript.WriteString(fmt.Sprintf("%s: %s\n", message.Role, message.Content))
+		messageIDs[i] = message.ID
+	}
+
+	systemPrompt := `You summarize conversations between a user and an AI orchestrator.
+Produce a concise summary that preserves user goals, decisions made, agent results and open questions.
+If a previous summary is given, merge it with the new messages into a single summary.`
+
+	userPrompt := fmt.Sprintf("Previous summary:\n%s\n\nNew messages:\n%s", conversation.Summary, transcript.String())
+
+	summary, err := s.aiProvider.CallAI(ctx, systemPrompt, userPrompt)
+	if err != nil {
+		return "", fmt.Errorf("failed to summarize conversation: %w", err)
+	}
+	summary = strings.TrimSpace(summary)
+
+	conversation.SetSummary(summary)
+	if err := s.repo.UpdateConversation(ctx, conversation); err != nil {
+		return "", fmt.Errorf("failed to update conversation: %w", err)
+	}
+
+	if err := s.repo.MarkMessagesSummarized(ctx, conversationID, messageIDs); err != nil {
+		return "", fmt.Errorf("failed to mark summarized messages: %w", err)
+	}
+
+	return summary, nil
+}
+
+// GetPromptHistory returns the conversation history to feed into prompts: the summary plus the recent messages
+func (s *ConversationServiceImpl) GetPromptHistory(ctx context.Context, conversationID string) (string, error) {
+	promptContext, err := s.GetPromptContext(ctx, conversationID, s.summarizationConfig.RecentMessages)
+	if err != nil {
+		return "", err
+	}
+	return promptContext.Format(), nil
+}
+
+// GetPromptContext returns the conversation summary plus at most maxMessages recent messages in chronological order
+func (s *ConversationServiceImpl) GetPromptContext(ctx context.Context, conversationID string, maxMessages int) (domain.PromptContext, error) {
+	if maxMessages < 0 {
+		return domain.PromptContext{}, fmt.Errorf("max messages cannot be negative: %d", maxMessages)
+	}
+
+	conversation, err := s.repo.GetConversation(ctx, conversationID)
+	if err != nil {
+		return domain.PromptContext{}, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	var messages []domain.ConversationMessage
+	if maxMessages > 0 {
+		messages, err = s.repo.GetRecentMessages(ctx, conversationID, maxMessages)
+		if err != nil {
+			return domain.PromptContext{}, fmt.Errorf("failed to get recent messages: %w", err)
+		}
+	}
+
+	return domain.NewPromptContext(conversationID, conversation.Summary, messages), nil
+}
+
 // LinkExecutionPlan links an execution plan to a conversation
 func (s *ConversationServiceImpl) LinkExecutionPlan(ctx context.Context, conversationID, planID string) error {
 	// Get the conversation and update it
@@ -198,6 +371,64 @@ func (s *ConversationServiceImpl) LinkExecutionPlan(ctx context.Context, convers
 	return nil
 }
 
+// RecordDecision persists an AI decision in its conversation and links it to the user message that triggered it
+func (s *ConversationServiceImpl) RecordDecision(ctx context.Context, decision *domain.AIDecision) error {
+	if err := s.authorizeByID(ctx, decision.ConversationID); err != nil {
+		return err
+	}
+
+	if err := s.repo.CreateAIDecision(ctx, decision); err != nil {
+		return fmt.Errorf("failed to create decision: %w", err)
+	}
+
+	if decision.RequestMessageID != "" {
+		if err := s.repo.LinkUserRequestToAIDecision(ctx, decision.RequestMessageID, decision.ID); err != nil {
+			return fmt.Errorf("failed to link user request to decision: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetDecisionsByConversation retrieves the AI decisions made in a conversation, oldest first
+func (s *ConversationServiceImpl) GetDecisionsByConversation(ctx context.Context, conversationID string) ([]*domain.AIDecision, error) {
+	if err := s.authorizeByID(ctx, conversationID); err != nil {
+		return nil, err
+	}
+
+	decisions, err := s.repo.GetDecisionsByConversation(ctx, conversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation decisions: %w", err)
+	}
+	return decisions, nil
+}
+
+// GetUserRequestWithDecisions retrieves a user message together with the AI decisions it triggered
+func (s *ConversationServiceImpl) GetUserRequestWithDecisions(ctx context.Context, messageID string) (*domain.UserRequest, error) {
+	request, err := s.repo.GetUserRequestWithDecisions(ctx, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user request: %w", err)
+	}
+
+	if err := s.authorizeByID(ctx, request.ConversationID); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// GetDecisionChain retrieves the chain of decisions a decision follows, root first
+func (s *ConversationServiceImpl) GetDecisionChain(ctx context.Context, decisionID string) ([]*domain.AIDecision, error) {
+	chain, err := s.repo.GetDecisionChain(ctx, decisionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get decision chain: %w", err)
+	}
+
+	if err := s.authorizeByID(ctx, chain[len(chain)-1].ConversationID); err != nil {
+		return nil, err
+	}
+	return chain, nil
+}
+
 // LinkConversationToSession links a conversation to a session
 func (s *ConversationServiceImpl) LinkConversationToSession(ctx context.Context, conversationID, sessionID string) error {
 	if err := s.repo.LinkConversationToSession(ctx, conversationID, sessionID); err != nil {
@@ -223,6 +454,31 @@ func (s *ConversationServiceImpl) FindConversationsByUser(ctx context.Context, u
 	return conversations, nil
 }
 
+// SearchMessages finds a page of the user's messages whose content matches query, best matches first
+func (s *ConversationServiceImpl) SearchMessages(ctx context.Context, userID, query string, opts domain.SearchOptions) ([]domain.MessageHit, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("search query cannot be empty")
+	}
+	if requester, ok := domain.RequestingUser(ctx); ok && requester != userID {
+		return nil, fmt.Errorf("user %s may not search messages of user %s: %w", requester, userID, domain.ErrConversationAccessDenied)
+	}
+
+	if opts.Limit <= 0 {
+		opts.Limit = domain.DefaultSearchLimit
+	}
+	opts.Limit = min(opts.Limit, domain.MaxSearchLimit)
+	opts.Offset = max(opts.Offset, 0)
+
+	hits, err := s.repo.SearchMessages(ctx, userID, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search messages: %w", err)
+	}
+	return hits, nil
+}
+
 // FindConversationsBySession finds conversations by session ID
 func (s *ConversationServiceImpl) FindConversationsBySession(ctx context.Context, sessionID string) ([]*domain.Conversation, error) {
 	conversations, err := s.repo.FindConversationsBySession(ctx, sessionID)
@@ -0,0 +1,451 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	aiInfrastructure "neuromesh/internal/ai/infrastructure"
+	"neuromesh/internal/conversation/domain"
+	"neuromesh/internal/conversation/infrastructure"
+	"neuromesh/internal/graph"
+	"neuromesh/testHelpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedConversation stores a conversation with count alternating user/assistant messages one second apart
+func seedConversation(t *testing.T, repo domain.ConversationRepository, conversationID string, count int) {
+	t.Helper()
+	ctx := context.Background()
+
+	conversation, err := domain.NewConversation(conversationID, "session-1", "user-1")
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateConversation(ctx, conversation))
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < count; i++ {
+		role := domain.MessageRoleUser
+		if i%2 == 1 {
+			role = domain.MessageRoleAssistant
+		}
+		require.NoError(t, repo.AddMessage(ctx, conversationID, &domain.ConversationMessage{
+			ID:        fmt.Sprintf("msg-%02d", i),
+			Role:      role,
+			Content:   fmt.Sprintf("message %d", i),
+			Timestamp: start.Add(time.Duration(i) * time.Second),
+		}))
+	}
+}
+
+func TestConversationService_SummarizeConversation(t *testing.T) {
+	config := SummarizationConfig{MessageThreshold: 6, RecentMessages: 3}
+
+	t.Run("summarizes older messages once the threshold is exceeded", func(t *testing.T) {
+		repo := infrastructure.NewGraphConversationRepository(testHelpers.NewMockGraph())
+		seedConversation(t, repo, "conv-1", 8)
+
+		provider := aiInfrastructure.NewScriptedProvider(map[string]string{"New messages": "User asked about deployments."})
+		service := NewConversationServiceWithSummarizer(repo, provider, config)
+
+		summary, err := service.SummarizeConversation(context.Background(), "conv-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "User asked about deployments.", summary)
+
+		// Only the five oldest messages were sent for summarization
+		calls := provider.Calls()
+		require.Len(t, calls, 1)
+		assert.Contains(t, calls[0].UserPrompt, "message 0")
+		assert.Contains(t, calls[0].UserPrompt, "message 4")
+		assert.NotContains(t, calls[0].UserPrompt, "message 5")
+
+		conversation, err := service.GetConversationWithMessages(context.Background(), "conv-1")
+		require.NoError(t, err)
+		assert.Equal(t, "User asked about deployments.", conversation.Summary)
+		assert.Len(t, conversation.UnsummarizedMessages(), 3)
+	})
+
+	t.Run("prompt history prefers summary plus recent messages", func(t *testing.T) {
+		repo := infrastructure.NewGraphConversationRepository(testHelpers.NewMockGraph())
+		seedConversation(t, repo, "conv-2", 8)
+
+		provider := aiInfrastructure.NewScriptedProvider(map[string]string{"New messages": "Earlier: deployment discussion."})
+		service := NewConversationServiceWithSummarizer(repo, provider, config)
+
+		_, err := service.SummarizeConversation(context.Background(), "conv-2")
+		require.NoError(t, err)
+
+		history, err := service.GetPromptHistory(context.Background(), "conv-2")
+
+		require.NoError(t, err)
+		assert.Contains(t, history, "Conversation summary:\nEarlier: deployment discussion.")
+		assert.Contains(t, history, "user: message 6")
+		assert.Contains(t, history, "assistant: message 7")
+		assert.NotContains(t, history, "message 4")
+	})
+
+	t.Run("leaves short conversations untouched", func(t *testing.T) {
+		repo := infrastructure.NewGraphConversationRepository(testHelpers.NewMockGraph())
+		seedConversation(t, repo, "conv-3", 4)
+
+		provider := aiInfrastructure.NewScriptedProvider(nil)
+		service := NewConversationServiceWithSummarizer(repo, provider, config)
+
+		summary, err := service.SummarizeConversation(context.Background(), "conv-3")
+
+		require.NoError(t, err)
+		assert.Empty(t, summary)
+		assert.Empty(t, provider.Calls())
+	})
+
+	t.Run("requires an AI provider", func(t *testing.T) {
+		service := NewConversationService(infrastructure.NewGraphConversationRepository(testHelpers.NewMockGraph()))
+
+		_, err := service.SummarizeConversation(context.Background(), "conv-4")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestConversationService_GetPromptContext(t *testing.T) {
+	t.Run("returns the most recent messages in chronological order", func(t *testing.T) {
+		repo := infrastructure.NewGraphConversationRepository(testHelpers.NewMockGraph())
+		seedConversation(t, repo, "conv-1", 10)
+		service := NewConversationService(repo)
+
+		promptContext, err := service.GetPromptContext(context.Background(), "conv-1", 4)
+
+		require.NoError(t, err)
+		require.Len(t, promptContext.Messages, 4)
+		for i, message := range promptContext.Messages {
+			assert.Equal(t, fmt.Sprintf("msg-%02d", 6+i), message.ID)
+		}
+	})
+
+	t.Run("returns every message when the conversation is shorter than the window", func(t *testing.T) {
+		repo := infrastructure.NewGraphConversationRepository(testHelpers.NewMockGraph())
+		seedConversation(t, repo, "conv-2", 3)
+		service := NewConversationService(repo)
+
+		promptContext, err := service.GetPromptContext(context.Background(), "conv-2", 10)
+
+		require.NoError(t, err)
+		require.Len(t, promptContext.Messages, 3)
+		assert.Equal(t, "msg-00", promptContext.Messages[0].ID)
+		assert.Equal(t, "msg-02", promptContext.Messages[2].ID)
+		assert.Empty(t, promptContext.Summary)
+	})
+
+	t.Run("includes the conversation summary", func(t *testing.T) {
+		repo := infrastructure.NewGraphConversationRepository(testHelpers.NewMockGraph())
+		seedConversation(t, repo, "conv-3", 2)
+		conversation, err := repo.GetConversation(context.Background(), "conv-3")
+		require.NoError(t, err)
+		conversation.SetSummary("User wants a deployment")
+		require.NoError(t, repo.UpdateConversation(context.Background(), conversation))
+		service := NewConversationService(repo)
+
+		promptContext, err := service.GetPromptContext(context.Background(), "conv-3", 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, "User wants a deployment", promptContext.Summary)
+		assert.Equal(t, "Conversation summary:\nUser wants a deployment\n\nRecent messages:\nassistant: message 1", promptContext.Format())
+	})
+
+	t.Run("rejects a negative window", func(t *testing.T) {
+		service := NewConversationService(infrastructure.NewGraphConversationRepository(testHelpers.NewMockGraph()))
+
+		_, err := service.GetPromptContext(context.Background(), "conv-4", -1)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestConversationService_UpdateConversationStatus(t *testing.T) {
+	ctx := context.Background()
+	repo := infrastructure.NewGraphConversationRepository(testHelpers.NewCleanMockGraph())
+	service := NewConversationService(repo)
+	seedConversation(t, repo, "conv-1", 0)
+
+	require.NoError(t, service.UpdateConversationStatus(ctx, "conv-1", domain.ConversationStatusClosed))
+
+	err := service.UpdateConversationStatus(ctx, "conv-1", domain.ConversationStatusActive)
+	assert.ErrorIs(t, err, domain.ErrInvalidStatusTransition)
+
+	conversation, err := repo.GetConversation(ctx, "conv-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ConversationStatusClosed, conversation.Status)
+}
+
+func TestConversationService_ArchiveConversation(t *testing.T) {
+	ctx := context.Background()
+	repo := infrastructure.NewGraphConversationRepository(testHelpers.NewCleanMockGraph())
+	service := NewConversationService(repo)
+	seedConversation(t, repo, "conv-kept", 0)
+	seedConversation(t, repo, "conv-archived", 2)
+
+	require.NoError(t, service.ArchiveConversation(ctx, "conv-archived"))
+
+	t.Run("hidden from active queries", func(t *testing.T) {
+		active, err := service.FindActiveConversations(ctx)
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.Equal(t, "conv-kept", active[0].ID)
+	})
+
+	t.Run("still retrievable by ID with its messages", func(t *testing.T) {
+		conversation, err := service.GetConversationWithMessages(ctx, "conv-archived")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ConversationStatusArchived, conversation.Status)
+		assert.False(t, conversation.ArchivedAt.IsZero())
+		assert.Len(t, conversation.Messages, 2)
+	})
+
+	t.Run("purge removes the conversation", func(t *testing.T) {
+		require.NoError(t, service.PurgeConversation(ctx, "conv-archived"))
+
+		_, err := service.GetConversation(ctx, "conv-archived")
+		assert.Error(t, err)
+	})
+}
+
+func TestConversationService_SearchMessages(t *testing.T) {
+	ctx := context.Background()
+	repo := infrastructure.NewGraphConversationRepository(testHelpers.NewCleanMockGraph())
+	service := NewConversationService(repo)
+
+	for _, id := range []string{"conv-1", "conv-2"} {
+		_, err := service.CreateConversation(ctx, id, "session-1", "user-1")
+		require.NoError(t, err)
+	}
+	_, err := service.CreateConversation(ctx, "conv-other", "session-2", "user-2")
+	require.NoError(t, err)
+
+	require.NoError(t, service.AddMessage(ctx, "conv-1", "msg-1", domain.MessageRoleUser, "Deploy failed with ECONNREFUSED when reaching the database", nil))
+	require.NoError(t, service.AddMessage(ctx, "conv-1", "msg-2", domain.MessageRoleAssistant, "The database looks healthy now", nil))
+	require.NoError(t, service.AddMessage(ctx, "conv-2", "msg-3", domain.MessageRoleUser, "econnrefused again, econnrefused everywhere", nil))
+	require.NoError(t, service.AddMessage(ctx, "conv-other", "msg-4", domain.MessageRoleUser, "ECONNREFUSED from another user", nil))
+
+	hits, err := service.SearchMessages(ctx, "user-1", "ECONNREFUSED", domain.SearchOptions{})
+
+	require.NoError(t, err)
+	require.Len(t, hits, 2, "only the user's matching messages are returned")
+	assert.Equal(t, "msg-3", hits[0].MessageID, "more occurrences score higher")
+	assert.Equal(t, "conv-2", hits[0].ConversationID)
+	assert.Equal(t, "msg-1", hits[1].MessageID)
+	assert.Equal(t, "conv-1", hits[1].ConversationID)
+	assert.Contains(t, hits[1].Snippet, "ECONNREFUSED")
+	assert.Greater(t, hits[0].Score, hits[1].Score)
+
+	page, err := service.SearchMessages(ctx, "user-1", "ECONNREFUSED", domain.SearchOptions{Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "msg-1", page[0].MessageID)
+
+	_, err = service.SearchMessages(ctx, "user-1", "  ", domain.SearchOptions{})
+	assert.Error(t, err)
+
+	_, err = service.SearchMessages(domain.WithRequestingUser(ctx, "user-2"), "user-1", "ECONNREFUSED", domain.SearchOptions{})
+	assert.ErrorIs(t, err, domain.ErrConversationAccessDenied)
+}
+
+// searchRecordingGraph records the full-text searches issued against the wrapped graph
+type searchRecordingGraph struct {
+	graph.Graph
+	conditions []graph.Condition
+	limit      int
+	offset     int
+}
+
+func (g *searchRecordingGraph) FullTextSearch(ctx context.Context, nodeType, property, query string, conditions []graph.Condition, limit, offset int) ([]map[string]interface{}, error) {
+	g.conditions, g.limit, g.offset = conditions, limit, offset
+	return g.Graph.FullTextSearch(ctx, nodeType, property, query, conditions, limit, offset)
+}
+
+func TestConversationService_SearchMessages_PagesInQuery(t *testing.T) {
+	ctx := context.Background()
+	recording := &searchRecordingGraph{Graph: testHelpers.NewCleanMockGraph()}
+	service := NewConversationService(infrastructure.NewGraphConversationRepository(recording))
+	_, err := service.CreateConversation(ctx, "conv-1", "session-1", "user-1")
+	require.NoError(t, err)
+
+	_, err = service.SearchMessages(ctx, "user-1", "deploy", domain.SearchOptions{Limit: 1000, Offset: 40})
+	require.NoError(t, err)
+	assert.Equal(t, domain.MaxSearchLimit, recording.limit)
+	assert.Equal(t, 40, recording.offset)
+	assert.Equal(t, []graph.Condition{{Field: "conversation_id", Operator: graph.OperatorIn, Value: []string{"conv-1"}}}, recording.conditions)
+
+	_, err = service.SearchMessages(ctx, "user-1", "deploy", domain.SearchOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultSearchLimit, recording.limit)
+}
+
+func TestConversationService_AuthorizesRequestingUser(t *testing.T) {
+	repo := infrastructure.NewGraphConversationRepository(testHelpers.NewCleanMockGraph())
+	service := NewConversationService(repo)
+	seedConversation(t, repo, "conv-owned", 2) // owned by user-1
+
+	owner := domain.WithRequestingUser(context.Background(), "user-1")
+	intruder := domain.WithRequestingUser(context.Background(), "user-2")
+
+	t.Run("owner reads and appends", func(t *testing.T) {
+		require.NoError(t, service.AddMessage(owner, "conv-owned", "msg-owner", domain.MessageRoleUser, "still me", nil))
+
+		conversation, err := service.GetConversationWithMessages(owner, "conv-owned")
+		require.NoError(t, err)
+		assert.Len(t, conversation.Messages, 3)
+	})
+
+	t.Run("another user is denied", func(t *testing.T) {
+		_, err := service.GetConversationWithMessages(intruder, "conv-owned")
+		assert.ErrorIs(t, err, domain.ErrConversationAccessDenied)
+
+		_, err = service.GetConversationMessages(intruder, "conv-owned")
+		assert.ErrorIs(t, err, domain.ErrConversationAccessDenied)
+
+		_, err = service.GetMessagesByRole(intruder, "conv-owned", domain.MessageRoleUser)
+		assert.ErrorIs(t, err, domain.ErrConversationAccessDenied)
+
+		err = service.AddMessage(intruder, "conv-owned", "msg-intruder", domain.MessageRoleUser, "not mine", nil)
+		assert.ErrorIs(t, err, domain.ErrConversationAccessDenied)
+
+		messages, err := service.GetConversationMessages(owner, "conv-owned")
+		require.NoError(t, err)
+		assert.Len(t, messages, 3, "denied append must not be stored")
+	})
+
+	t.Run("internal callers without a requesting user are not restricted", func(t *testing.T) {
+		_, err := service.GetConversationWithMessages(context.Background(), "conv-owned")
+		assert.NoError(t, err)
+	})
+}
+
+func TestConversationService_GetUserRequestWithDecisions(t *testing.T) {
+	ctx := context.Background()
+	repo := infrastructure.NewGraphConversationRepository(testHelpers.NewCleanMockGraph())
+	service := NewConversationService(repo)
+	seedConversation(t, repo, "conv-decisions", 2) // msg-00 is the user request
+
+	start := time.Date(2025, 1, 1, 12, 0, 5, 0, time.UTC)
+	for i, decisionType := range []string{"CLARIFY", "EXECUTE"} {
+		require.NoError(t, service.RecordDecision(ctx, &domain.AIDecision{
+			ID:               fmt.Sprintf("decision-%d", i),
+			ConversationID:   "conv-decisions",
+			RequestMessageID: "msg-00",
+			Type:             decisionType,
+			Confidence:       60 + i*30,
+			CreatedAt:        start.Add(time.Duration(i) * time.Second),
+		}))
+	}
+
+	request, err := service.GetUserRequestWithDecisions(ctx, "msg-00")
+	require.NoError(t, err)
+
+	assert.Equal(t, "msg-00", request.ID)
+	assert.Equal(t, domain.MessageRoleUser, request.Role)
+	assert.Equal(t, "conv-decisions", request.ConversationID)
+	require.Len(t, request.Decisions, 2)
+	assert.Equal(t, "decision-0", request.Decisions[0].ID)
+	assert.Equal(t, "CLARIFY", request.Decisions[0].Type)
+	assert.Equal(t, "decision-1", request.Decisions[1].ID)
+	assert.Equal(t, "EXECUTE", request.Decisions[1].Type)
+	assert.Equal(t, 90, request.Decisions[1].Confidence)
+
+	_, err = service.GetUserRequestWithDecisions(domain.WithRequestingUser(ctx, "user-2"), "msg-00")
+	assert.ErrorIs(t, err, domain.ErrConversationAccessDenied)
+}
+
+// decisionReadCountingGraph counts the decision reads issued against the wrapped graph
+type decisionReadCountingGraph struct {
+	graph.Graph
+	decisionReads int
+}
+
+func (g *decisionReadCountingGraph) GetNode(ctx context.Context, nodeType, nodeID string) (map[string]interface{}, error) {
+	if nodeType == infrastructure.NodeTypeAIDecision {
+		g.decisionReads++
+	}
+	return g.Graph.GetNode(ctx, nodeType, nodeID)
+}
+
+func (g *decisionReadCountingGraph) FollowChain(ctx context.Context, nodeType, nodeID, edgeType string) ([]map[string]interface{}, error) {
+	g.decisionReads++
+	return g.Graph.FollowChain(ctx, nodeType, nodeID, edgeType)
+}
+
+func TestConversationService_GetDecisionChain_SingleQuery(t *testing.T) {
+	ctx := context.Background()
+	counting := &decisionReadCountingGraph{Graph: testHelpers.NewCleanMockGraph()}
+	repo := infrastructure.NewGraphConversationRepository(counting)
+	service := NewConversationService(repo)
+	seedConversation(t, repo, "conv-chain", 1)
+
+	previousID := ""
+	for i := 0; i < 5; i++ {
+		decision := &domain.AIDecision{
+			ID:             fmt.Sprintf("chain-%d", i),
+			ConversationID: "conv-chain",
+			Type:           "CLARIFY",
+			CreatedAt:      time.Date(2025, 1, 1, 12, 0, i, 0, time.UTC),
+		}
+		if previousID != "" {
+			decision.PreviousDecisions = []string{previousID}
+		}
+		require.NoError(t, service.RecordDecision(ctx, decision))
+		previousID = decision.ID
+	}
+
+	counting.decisionReads = 0
+	chain, err := service.GetDecisionChain(ctx, "chain-4")
+	require.NoError(t, err)
+	require.Len(t, chain, 5)
+	assert.Equal(t, "chain-0", chain[0].ID)
+	assert.Equal(t, "chain-4", chain[4].ID)
+	assert.Equal(t, 1, counting.decisionReads)
+}
+
+func TestConversationService_GetDecisionChain(t *testing.T) {
+	ctx := context.Background()
+	repo := infrastructure.NewGraphConversationRepository(testHelpers.NewCleanMockGraph())
+	service := NewConversationService(repo)
+	seedConversation(t, repo, "conv-chain", 1)
+
+	start := time.Date(2025, 1, 1, 12, 0, 5, 0, time.UTC)
+	previousID := ""
+	for i, decisionType := range []string{"CLARIFY", "CLARIFY", "EXECUTE"} {
+		decision := &domain.AIDecision{
+			ID:             fmt.Sprintf("chain-%d", i),
+			ConversationID: "conv-chain",
+			Type:           decisionType,
+			CreatedAt:      start.Add(time.Duration(i) * time.Second),
+		}
+		if previousID != "" {
+			decision.PreviousDecisions = []string{previousID}
+		}
+		require.NoError(t, service.RecordDecision(ctx, decision))
+		previousID = decision.ID
+	}
+
+	chain, err := service.GetDecisionChain(ctx, "chain-2")
+	require.NoError(t, err)
+	require.Len(t, chain, 3)
+	assert.Equal(t, "chain-0", chain[0].ID)
+	assert.Empty(t, chain[0].PreviousDecisions)
+	assert.Equal(t, "chain-1", chain[1].ID)
+	assert.Equal(t, []string{"chain-0"}, chain[1].PreviousDecisions)
+	assert.Equal(t, "chain-2", chain[2].ID)
+	assert.Equal(t, "EXECUTE", chain[2].Type)
+
+	chain, err = service.GetDecisionChain(ctx, "chain-1")
+	require.NoError(t, err)
+	assert.Len(t, chain, 2)
+
+	_, err = service.GetDecisionChain(ctx, "missing")
+	assert.Error(t, err)
+
+	_, err = service.GetDecisionChain(domain.WithRequestingUser(ctx, "user-2"), "chain-2")
+	assert.ErrorIs(t, err, domain.ErrConversationAccessDenied)
+}
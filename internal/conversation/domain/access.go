@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrConversationAccessDenied is returned when the requesting user does not own the conversation
+var ErrConversationAccessDenied = errors.New("conversation access denied")
+
+// requestingUserKey is the context key carrying the user on whose behalf a conversation is accessed
+type requestingUserKey struct{}
+
+// WithRequestingUser returns ctx marking conversation access as performed on behalf of userID
+func WithRequestingUser(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, requestingUserKey{}, userID)
+}
+
+// RequestingUser returns the user on whose behalf conversations are accessed; internal callers have none
+func RequestingUser(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(requestingUserKey{}).(string)
+	return userID, ok && userID != ""
+}
+
+// AuthorizeUser checks that userID owns the conversation
+func (c *Conversation) AuthorizeUser(userID string) error {
+	if c.UserID != userID {
+		return fmt.Errorf("user %s may not access conversation %s: %w", userID, c.ID, ErrConversationAccessDenied)
+	}
+	return nil
+}
@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -25,6 +28,33 @@ const (
 	ConversationStatusArchived ConversationStatus = "archived"
 )
 
+// ErrInvalidStatusTransition is returned when a conversation lifecycle transition is not allowed
+var ErrInvalidStatusTransition = errors.New("invalid conversation status transition")
+
+// conversationTransitions lists the statuses each status may move to
+var conversationTransitions = map[ConversationStatus][]ConversationStatus{
+	ConversationStatusActive:   {ConversationStatusPaused, ConversationStatusClosed, ConversationStatusArchived},
+	ConversationStatusPaused:   {ConversationStatusActive, ConversationStatusClosed, ConversationStatusArchived},
+	ConversationStatusClosed:   {ConversationStatusArchived},
+	ConversationStatusArchived: {},
+}
+
+// IsValid checks if the conversation status is known
+func (s ConversationStatus) IsValid() bool {
+	_, ok := conversationTransitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether the lifecycle allows moving from s to next
+func (s ConversationStatus) CanTransitionTo(next ConversationStatus) bool {
+	for _, allowed := range conversationTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 // MessageRole represents the role of a message sender
 type MessageRole string
 
@@ -37,11 +67,12 @@ const (
 
 // ConversationMessage represents a message within a conversation
 type ConversationMessage struct {
-	ID        string                 `json:"id"`
-	Role      MessageRole            `json:"role"`
-	Content   string                 `json:"content"`
-	Timestamp time.Time              `json:"timestamp"`
-	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	ID         string                 `json:"id"`
+	Role       MessageRole            `json:"role"`
+	Content    string                 `json:"content"`
+	Timestamp  time.Time              `json:"timestamp"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	Summarized bool                   `json:"summarized,omitempty"` // Already folded into the conversation summary
 }
 
 // Conversation represents a multi-turn conversation between users and AI
@@ -52,8 +83,10 @@ type Conversation struct {
 	Status           ConversationStatus    `json:"status"`
 	Messages         []ConversationMessage `json:"messages"`
 	ExecutionPlanIDs []string              `json:"execution_plan_ids"`
+	Summary          string                `json:"summary,omitempty"`
 	CreatedAt        time.Time             `json:"created_at"`
 	UpdatedAt        time.Time             `json:"updated_at"`
+	ArchivedAt       time.Time             `json:"archived_at,omitempty"` // Zero unless the conversation is archived
 }
 
 // NewConversation creates a new conversation with validation
@@ -152,7 +185,40 @@ func (c *Conversation) Validate() error {
 	return nil
 }
 
-// SetStatus updates the conversation status
+// TransitionTo moves the conversation to status, enforcing the lifecycle state machine
+// Transitioning to the current status is a no-op
+func (c *Conversation) TransitionTo(status ConversationStatus) error {
+	if !status.IsValid() {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidStatusTransition, status)
+	}
+
+	if c.Status == status {
+		return nil
+	}
+
+	if !c.Status.CanTransitionTo(status) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, c.Status, status)
+	}
+
+	c.SetStatus(status)
+	return nil
+}
+
+// Archive soft-deletes the conversation, keeping it and its messages for audit
+func (c *Conversation) Archive() error {
+	if c.Status == ConversationStatusArchived {
+		return nil
+	}
+
+	if err := c.TransitionTo(ConversationStatusArchived); err != nil {
+		return err
+	}
+
+	c.ArchivedAt = c.UpdatedAt
+	return nil
+}
+
+// SetStatus updates the conversation status without lifecycle checks
 func (c *Conversation) SetStatus(status ConversationStatus) {
 	c.Status = status
 	c.UpdatedAt = time.Now().UTC()
@@ -178,3 +244,80 @@ func (c *Conversation) GetLatestMessage() *ConversationMessage {
 func (c *Conversation) GetMessageCount() int {
 	return len(c.Messages)
 }
+
+// SortedMessages returns the messages ordered from oldest to newest
+func (c *Conversation) SortedMessages() []ConversationMessage {
+	messages := make([]ConversationMessage, len(c.Messages))
+	copy(messages, c.Messages)
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Timestamp.Before(messages[j].Timestamp)
+	})
+	return messages
+}
+
+// UnsummarizedMessages returns the messages not yet folded into the summary, oldest first
+func (c *Conversation) UnsummarizedMessages() []ConversationMessage {
+	var messages []ConversationMessage
+	for _, message := range c.SortedMessages() {
+		if !message.Summarized {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
+
+// SetSummary replaces the conversation summary
+func (c *Conversation) SetSummary(summary string) {
+	c.Summary = summary
+	c.UpdatedAt = time.Now().UTC()
+}
+
+// BuildPromptHistory renders the summary followed by at most recentN unsummarized messages
+// This keeps long conversations within the AI context window
+func (c *Conversation) BuildPromptHistory(recentN int) string {
+	recent := c.UnsummarizedMessages()
+	if recentN >= 0 && len(recent) > recentN {
+		recent = recent[len(recent)-recentN:]
+	}
+
+	return NewPromptContext(c.ID, c.Summary, recent).Format()
+}
+
+// PromptContext is the bounded slice of a conversation fed into AI prompts
+type PromptContext struct {
+	ConversationID string                `json:"conversation_id"`
+	Summary        string                `json:"summary,omitempty"`
+	Messages       []ConversationMessage `json:"messages"` // Oldest first
+}
+
+// NewPromptContext creates a prompt context from a summary and chronologically ordered messages
+func NewPromptContext(conversationID, summary string, messages []ConversationMessage) PromptContext {
+	if messages == nil {
+		messages = make([]ConversationMessage, 0)
+	}
+	return PromptContext{
+		ConversationID: conversationID,
+		Summary:        summary,
+		Messages:       messages,
+	}
+}
+
+// Format renders the summary followed by the messages as prompt text
+func (p PromptContext) Format() string {
+	var history strings.Builder
+
+	if p.Summary != "" {
+		history.WriteString("Conversation summary:\n")
+		history.WriteString(p.Summary)
+		history.WriteString("\n\n")
+	}
+
+	if len(p.Messages) > 0 {
+		history.WriteString("Recent messages:\n")
+		for _, message := range p.Messages {
+			history.WriteString(fmt.Sprintf("%s: %s\n", message.Role, message.Content))
+		}
+	}
+
+	return strings.TrimRight(history.String(), "\n")
+}
@@ -1,9 +1,12 @@
 package domain
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // RED - Write failing tests first
@@ -174,3 +177,74 @@ func TestConversation_GetMessagesByRole(t *testing.T) {
 		assert.Equal(t, "Assistant response", assistantMessages[0].Content)
 	})
 }
+
+func TestConversation_BuildPromptHistory(t *testing.T) {
+	conversation, err := NewConversation("conv-1", "session-1", "user-1")
+	require.NoError(t, err)
+
+	start := time.Now().UTC()
+	for i, content := range []string{"first", "second", "third"} {
+		conversation.Messages = append(conversation.Messages, ConversationMessage{
+			ID:         fmt.Sprintf("msg-%d", i),
+			Role:       MessageRoleUser,
+			Content:    content,
+			Timestamp:  start.Add(time.Duration(i) * time.Second),
+			Summarized: i == 0,
+		})
+	}
+	conversation.SetSummary("User introduced themselves")
+
+	history := conversation.BuildPromptHistory(1)
+
+	assert.Equal(t, "Conversation summary:\nUser introduced themselves\n\nRecent messages:\nuser: third", history)
+}
+
+func TestConversation_TransitionTo(t *testing.T) {
+	allowed := []struct{ from, to ConversationStatus }{
+		{ConversationStatusActive, ConversationStatusPaused},
+		{ConversationStatusActive, ConversationStatusClosed},
+		{ConversationStatusActive, ConversationStatusArchived},
+		{ConversationStatusPaused, ConversationStatusActive},
+		{ConversationStatusPaused, ConversationStatusClosed},
+		{ConversationStatusPaused, ConversationStatusArchived},
+		{ConversationStatusClosed, ConversationStatusArchived},
+	}
+	forbidden := []struct{ from, to ConversationStatus }{
+		{ConversationStatusClosed, ConversationStatusActive},
+		{ConversationStatusClosed, ConversationStatusPaused},
+		{ConversationStatusArchived, ConversationStatusActive},
+		{ConversationStatusArchived, ConversationStatusPaused},
+		{ConversationStatusArchived, ConversationStatusClosed},
+		{ConversationStatusActive, ConversationStatus("deleted")},
+	}
+
+	for _, tt := range allowed {
+		t.Run(fmt.Sprintf("allows %s to %s", tt.from, tt.to), func(t *testing.T) {
+			conversation := &Conversation{Status: tt.from}
+
+			err := conversation.TransitionTo(tt.to)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.to, conversation.Status)
+			assert.False(t, conversation.UpdatedAt.IsZero())
+		})
+	}
+
+	for _, tt := range forbidden {
+		t.Run(fmt.Sprintf("forbids %s to %s", tt.from, tt.to), func(t *testing.T) {
+			conversation := &Conversation{Status: tt.from}
+
+			err := conversation.TransitionTo(tt.to)
+
+			assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+			assert.Equal(t, tt.from, conversation.Status, "status should be unchanged")
+		})
+	}
+
+	t.Run("same status is a no-op", func(t *testing.T) {
+		conversation := &Conversation{Status: ConversationStatusClosed}
+
+		require.NoError(t, conversation.TransitionTo(ConversationStatusClosed))
+		assert.True(t, conversation.UpdatedAt.IsZero())
+	})
+}
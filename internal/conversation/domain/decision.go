@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// AIDecision is the persisted record of how the AI decided to handle one user request in a conversation
+type AIDecision struct {
+	ID                    string    `json:"id"`
+	ConversationID        string    `json:"conversation_id"`
+	RequestMessageID      string    `json:"request_message_id,omitempty"` // User message that triggered the decision
+	Type                  string    `json:"type"`                         // CLARIFY, EXECUTE or REJECT
+	Confidence            int       `json:"confidence"`                   // 0-100
+	Reasoning             string    `json:"reasoning"`
+	ClarificationQuestion string    `json:"clarification_question,omitempty"`
+	RejectionReason       string    `json:"rejection_reason,omitempty"`
+	ExecutionPlanID       string    `json:"execution_plan_id,omitempty"`
+	PreviousDecisions     []string  `json:"previous_decisions,omitempty"` // IDs of the decisions this one follows, most recent first
+	PromptTokens          int       `json:"prompt_tokens"`                // Tokens of every AI call made for the request
+	CompletionTokens      int       `json:"completion_tokens"`
+	TotalTokens           int       `json:"total_tokens"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+// Validate ensures the decision can be persisted
+func (d *AIDecision) Validate() error {
+	if d.ID == "" {
+		return ConversationValidationError{Field: "id", Message: "decision ID cannot be empty"}
+	}
+	if d.ConversationID == "" {
+		return ConversationValidationError{Field: "conversation_id", Message: "decision conversation ID cannot be empty"}
+	}
+	if d.Type == "" {
+		return ConversationValidationError{Field: "type", Message: fmt.Sprintf("decision %s has no type", d.ID)}
+	}
+	return nil
+}
+
+// PreviousDecisionID returns the decision this one directly follows, if any
+func (d *AIDecision) PreviousDecisionID() string {
+	if len(d.PreviousDecisions) == 0 {
+		return ""
+	}
+	return d.PreviousDecisions[0]
+}
+
+// UserRequest is a user message together with the AI decisions it triggered
+type UserRequest struct {
+	ConversationMessage
+	ConversationID string        `json:"conversation_id"`
+	Decisions      []*AIDecision `json:"decisions"`
+}
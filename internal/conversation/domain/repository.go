@@ -2,6 +2,33 @@ package domain
 
 import "context"
 
+// ListOptions pages and filters conversation listings
+type ListOptions struct {
+	Limit  int                // Maximum conversations returned; zero means no limit
+	Offset int                // Conversations skipped before the limit applies
+	Status ConversationStatus // Optional status filter
+}
+
+// Message search page sizes
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// SearchOptions pages a message search
+type SearchOptions struct {
+	Limit  int // Maximum hits returned; zero uses DefaultSearchLimit and larger values are capped at MaxSearchLimit
+	Offset int // Hits skipped before the limit applies
+}
+
+// MessageHit is a message matched by a content search
+type MessageHit struct {
+	ConversationID string  `json:"conversation_id"`
+	MessageID      string  `json:"message_id"`
+	Snippet        string  `json:"snippet"`
+	Score          float64 `json:"score"`
+}
+
 // ConversationRepository defines the interface for conversation persistence operations
 type ConversationRepository interface {
 	// Schema management
@@ -13,21 +40,33 @@ type ConversationRepository interface {
 	GetConversation(ctx context.Context, conversationID string) (*Conversation, error)
 	GetConversationWithMessages(ctx context.Context, conversationID string) (*Conversation, error)
 	UpdateConversation(ctx context.Context, conversation *Conversation) error
-	DeleteConversation(ctx context.Context, conversationID string) error
+	ArchiveConversation(ctx context.Context, conversationID string) error
+	PurgeConversation(ctx context.Context, conversationID string) error // Irreversible hard delete
 
 	// Message operations
 	AddMessage(ctx context.Context, conversationID string, message *ConversationMessage) error
 	GetConversationMessages(ctx context.Context, conversationID string) ([]ConversationMessage, error)
 	GetMessagesByRole(ctx context.Context, conversationID string, role MessageRole) ([]ConversationMessage, error)
+	GetRecentMessages(ctx context.Context, conversationID string, limit int) ([]ConversationMessage, error)
+	MarkMessagesSummarized(ctx context.Context, conversationID string, messageIDs []string) error
 
 	// Relationship operations
 	LinkConversationToSession(ctx context.Context, conversationID, sessionID string) error
 	LinkConversationToUser(ctx context.Context, conversationID, userID string) error
 	LinkExecutionPlan(ctx context.Context, conversationID, planID string) error
 
+	// Decision operations
+	CreateAIDecision(ctx context.Context, decision *AIDecision) error
+	LinkUserRequestToAIDecision(ctx context.Context, messageID, decisionID string) error
+	GetDecisionsByConversation(ctx context.Context, conversationID string) ([]*AIDecision, error)
+	GetUserRequestWithDecisions(ctx context.Context, messageID string) (*UserRequest, error)
+	GetDecisionChain(ctx context.Context, decisionID string) ([]*AIDecision, error) // Root first, ending with decisionID
+
 	// Query operations
 	FindConversationsByUser(ctx context.Context, userID string) ([]*Conversation, error)
+	FindConversationsByUserPaginated(ctx context.Context, userID string, opts ListOptions) ([]*Conversation, error)
 	FindConversationsBySession(ctx context.Context, sessionID string) ([]*Conversation, error)
 	FindActiveConversations(ctx context.Context) ([]*Conversation, error)
 	FindConversationsByStatus(ctx context.Context, status ConversationStatus) ([]*Conversation, error)
+	SearchMessages(ctx context.Context, userID, query string, opts SearchOptions) ([]MessageHit, error)
 }
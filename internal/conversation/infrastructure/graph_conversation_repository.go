@@ -2,8 +2,12 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
+	"unicode"
 
 	"neuromesh/internal/conversation/domain"
 	"neuromesh/internal/graph"
@@ -13,14 +17,22 @@ import (
 const (
 	NodeTypeConversation = "Conversation"
 	NodeTypeMessage      = "ConversationMessage"
+	NodeTypeAIDecision   = "AIDecision"
 
 	RelationshipBelongsToConversation = "BELONGS_TO_CONVERSATION"
 	RelationshipContainsMessage       = "CONTAINS_MESSAGE"
 	RelationshipInSession             = "IN_SESSION"
 	RelationshipParticipantIn         = "PARTICIPANT_IN"
 	RelationshipLinkedToPlan          = "LINKED_TO_PLAN"
+	RelationshipHasDecision           = "HAS_DECISION"
+	RelationshipDecidedIn             = "DECIDED_IN"
+	RelationshipFollows               = "FOLLOWS"
 
-	TimeFormat = "2006-01-02T15:04:05Z"
+	// TimeFormat keeps fixed-width nanoseconds so messages sent within the same second still sort in order
+	TimeFormat = graph.TimestampFormat
+
+	// snippetRadius is the number of characters kept on each side of a search match
+	snippetRadius = 40
 )
 
 // GraphConversationRepository implements conversation repository using the graph backend
@@ -40,9 +52,20 @@ func formatTime(t time.Time) string {
 	return t.Format(TimeFormat)
 }
 
-// parseTime parses time from graph storage
+// parseTime parses time from graph storage, including values stored with whole seconds
 func parseTime(timeStr string) (time.Time, error) {
-	return time.Parse(TimeFormat, timeStr)
+	return time.Parse("2006-01-02T15:04:05Z", timeStr)
+}
+
+// intProperty reads an integer property, which Neo4j returns as int64
+func intProperty(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	}
+	return 0
 }
 
 // EnsureConversationSchema ensures that the required schema for Conversation domain is in place
@@ -53,13 +76,21 @@ func (r *GraphConversationRepository) EnsureConversationSchema(ctx context.Conte
 	}
 
 	// Create indexes for Conversation nodes
-	conversationIndexes := []string{"user_id", "session_id", "status", "created_at", "updated_at"}
+	conversationIndexes := []string{"user_id", "session_id", "status", "created_at", "updated_at", "last_activity_at"}
 	for _, property := range conversationIndexes {
 		if err := r.graph.CreateIndex(ctx, NodeTypeConversation, property); err != nil {
 			return fmt.Errorf("failed to create conversation %s index: %w", property, err)
 		}
 	}
 
+	// Decisions are listed per conversation
+	if err := r.graph.CreateUniqueConstraint(ctx, NodeTypeAIDecision, "id"); err != nil {
+		return fmt.Errorf("failed to create decision id constraint: %w", err)
+	}
+	if err := r.graph.CreateIndex(ctx, NodeTypeAIDecision, "conversation_id"); err != nil {
+		return fmt.Errorf("failed to create decision conversation_id index: %w", err)
+	}
+
 	return nil
 }
 
@@ -78,6 +109,11 @@ func (r *GraphConversationRepository) EnsureMessageSchema(ctx context.Context) e
 		}
 	}
 
+	// Full-text index backing message content search
+	if err := r.graph.CreateFullTextIndex(ctx, NodeTypeMessage, "content"); err != nil {
+		return fmt.Errorf("failed to create message content full-text index: %w", err)
+	}
+
 	return nil
 }
 
@@ -89,8 +125,10 @@ func (r *GraphConversationRepository) CreateConversation(ctx context.Context, co
 		"user_id":            conversation.UserID,
 		"status":             string(conversation.Status),
 		"execution_plan_ids": conversation.ExecutionPlanIDs,
+		"summary":            conversation.Summary,
 		"created_at":         formatTime(conversation.CreatedAt),
 		"updated_at":         formatTime(conversation.UpdatedAt),
+		"last_activity_at":   formatTime(conversation.UpdatedAt),
 	}
 
 	return r.graph.AddNode(ctx, NodeTypeConversation, conversation.ID, properties)
@@ -99,12 +137,12 @@ func (r *GraphConversationRepository) CreateConversation(ctx context.Context, co
 // GetConversation retrieves a conversation by ID
 func (r *GraphConversationRepository) GetConversation(ctx context.Context, conversationID string) (*domain.Conversation, error) {
 	conversationProps, err := r.graph.GetNode(ctx, NodeTypeConversation, conversationID)
-	if err != nil {
+	if err != nil && !errors.Is(err, graph.ErrNodeNotFound) {
 		return nil, fmt.Errorf("failed to get conversation: %w", err)
 	}
 
 	if conversationProps == nil {
-		return nil, fmt.Errorf("conversation not found: %s", conversationID)
+		return nil, fmt.Errorf("conversation not found: %s: %w", conversationID, graph.ErrNodeNotFound)
 	}
 
 	return r.mapToConversation(conversationProps)
@@ -130,19 +168,42 @@ func (r *GraphConversationRepository) GetConversationWithMessages(ctx context.Co
 
 // UpdateConversation updates a conversation node in the graph
 func (r *GraphConversationRepository) UpdateConversation(ctx context.Context, conversation *domain.Conversation) error {
+	if !conversation.Status.IsValid() {
+		return fmt.Errorf("invalid conversation status: %s", conversation.Status)
+	}
+
 	properties := map[string]interface{}{
 		"session_id":         conversation.SessionID,
 		"user_id":            conversation.UserID,
 		"status":             string(conversation.Status),
 		"execution_plan_ids": conversation.ExecutionPlanIDs,
+		"summary":            conversation.Summary,
 		"updated_at":         formatTime(conversation.UpdatedAt),
+		"last_activity_at":   formatTime(conversation.UpdatedAt),
+	}
+	if !conversation.ArchivedAt.IsZero() {
+		properties["archived_at"] = formatTime(conversation.ArchivedAt)
 	}
 
 	return r.graph.UpdateNode(ctx, NodeTypeConversation, conversation.ID, properties)
 }
 
-// DeleteConversation deletes a conversation node from the graph
-func (r *GraphConversationRepository) DeleteConversation(ctx context.Context, conversationID string) error {
+// ArchiveConversation soft-deletes a conversation by moving it to the archived status
+func (r *GraphConversationRepository) ArchiveConversation(ctx context.Context, conversationID string) error {
+	conversation, err := r.GetConversation(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+
+	if err := conversation.Archive(); err != nil {
+		return fmt.Errorf("failed to archive conversation: %w", err)
+	}
+
+	return r.UpdateConversation(ctx, conversation)
+}
+
+// PurgeConversation permanently deletes a conversation node from the graph
+func (r *GraphConversationRepository) PurgeConversation(ctx context.Context, conversationID string) error {
 	return r.graph.DeleteNode(ctx, NodeTypeConversation, conversationID)
 }
 
@@ -157,15 +218,25 @@ func (r *GraphConversationRepository) AddMessage(ctx context.Context, conversati
 		"timestamp":       formatTime(message.Timestamp),
 	}
 
-	// Only add metadata if it's not nil and not empty
-	if message.Metadata != nil && len(message.Metadata) > 0 {
-		properties["metadata"] = message.Metadata
+	// Only add metadata if it's not empty
+	if len(message.Metadata) > 0 {
+		metadata, err := graph.EncodeMapProperty(message.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encode message metadata: %w", err)
+		}
+		properties["metadata"] = metadata
 	}
 
 	if err := r.graph.AddNode(ctx, NodeTypeMessage, message.ID, properties); err != nil {
 		return fmt.Errorf("failed to create message node: %w", err)
 	}
 
+	// Keep the conversation's activity time current for recency ordering
+	activity := map[string]interface{}{"last_activity_at": formatTime(message.Timestamp)}
+	if err := r.graph.UpdateNode(ctx, NodeTypeConversation, conversationID, activity); err != nil {
+		return fmt.Errorf("failed to update conversation activity: %w", err)
+	}
+
 	// Create relationship between conversation and message
 	relationshipProps := map[string]interface{}{
 		"created_at": formatTime(time.Now().UTC()),
@@ -223,6 +294,45 @@ func (r *GraphConversationRepository) GetMessagesByRole(ctx context.Context, con
 	return messages, nil
 }
 
+// GetRecentMessages retrieves the latest limit messages of a conversation, oldest first
+func (r *GraphConversationRepository) GetRecentMessages(ctx context.Context, conversationID string, limit int) ([]domain.ConversationMessage, error) {
+	filters := map[string]interface{}{
+		"conversation_id": conversationID,
+	}
+
+	// Newest first from the graph so the limit keeps the most recent messages
+	messageProps, err := r.graph.QueryNodesWithOptions(ctx, NodeTypeMessage, filters, graph.QueryOptions{
+		OrderBy:    "timestamp",
+		Descending: true,
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent messages: %w", err)
+	}
+
+	messages := make([]domain.ConversationMessage, len(messageProps))
+	for i, props := range messageProps {
+		message, err := r.mapToMessage(props)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map message properties: %w", err)
+		}
+		// Reverse into chronological order
+		messages[len(messageProps)-1-i] = *message
+	}
+
+	return messages, nil
+}
+
+// MarkMessagesSummarized flags messages as folded into the conversation summary
+func (r *GraphConversationRepository) MarkMessagesSummarized(ctx context.Context, conversationID string, messageIDs []string) error {
+	for _, messageID := range messageIDs {
+		if err := r.graph.UpdateNode(ctx, NodeTypeMessage, messageID, map[string]interface{}{"summarized": true}); err != nil {
+			return fmt.Errorf("failed to mark message %s as summarized: %w", messageID, err)
+		}
+	}
+	return nil
+}
+
 // LinkConversationToSession creates a relationship between conversation and session
 func (r *GraphConversationRepository) LinkConversationToSession(ctx context.Context, conversationID, sessionID string) error {
 	properties := map[string]interface{}{
@@ -250,13 +360,157 @@ func (r *GraphConversationRepository) LinkExecutionPlan(ctx context.Context, con
 	return r.graph.AddEdge(ctx, NodeTypeConversation, conversationID, "ExecutionPlan", planID, RelationshipLinkedToPlan, properties)
 }
 
-// FindConversationsByUser finds conversations by user ID
+// CreateAIDecision creates a decision node and links it to the conversation it was made in
+func (r *GraphConversationRepository) CreateAIDecision(ctx context.Context, decision *domain.AIDecision) error {
+	if err := decision.Validate(); err != nil {
+		return err
+	}
+
+	properties := map[string]interface{}{
+		"id":                     decision.ID,
+		"conversation_id":        decision.ConversationID,
+		"request_message_id":     decision.RequestMessageID,
+		"decision_type":          decision.Type,
+		"confidence":             decision.Confidence,
+		"reasoning":              decision.Reasoning,
+		"clarification_question": decision.ClarificationQuestion,
+		"rejection_reason":       decision.RejectionReason,
+		"execution_plan_id":      decision.ExecutionPlanID,
+		"previous_decisions":     decision.PreviousDecisions,
+		"prompt_tokens":          decision.PromptTokens,
+		"completion_tokens":      decision.CompletionTokens,
+		"total_tokens":           decision.TotalTokens,
+		"created_at":             formatTime(decision.CreatedAt),
+	}
+	if err := r.graph.AddNode(ctx, NodeTypeAIDecision, decision.ID, properties); err != nil {
+		return fmt.Errorf("failed to create decision node: %w", err)
+	}
+
+	if err := r.graph.AddEdge(ctx, NodeTypeAIDecision, decision.ID, NodeTypeConversation, decision.ConversationID, RelationshipDecidedIn, nil); err != nil {
+		return fmt.Errorf("failed to link decision to conversation: %w", err)
+	}
+
+	if previousID := decision.PreviousDecisionID(); previousID != "" {
+		if err := r.graph.AddEdge(ctx, NodeTypeAIDecision, decision.ID, NodeTypeAIDecision, previousID, RelationshipFollows, nil); err != nil {
+			return fmt.Errorf("failed to link decision to previous decision: %w", err)
+		}
+	}
+	return nil
+}
+
+// LinkUserRequestToAIDecision links the user message that triggered a decision to it
+func (r *GraphConversationRepository) LinkUserRequestToAIDecision(ctx context.Context, messageID, decisionID string) error {
+	return r.graph.AddEdge(ctx, NodeTypeMessage, messageID, NodeTypeAIDecision, decisionID, RelationshipHasDecision, nil)
+}
+
+// GetDecisionsByConversation retrieves the decisions made in a conversation, oldest first
+func (r *GraphConversationRepository) GetDecisionsByConversation(ctx context.Context, conversationID string) ([]*domain.AIDecision, error) {
+	decisionProps, err := r.graph.QueryNodesWithOptions(ctx, NodeTypeAIDecision, map[string]interface{}{
+		"conversation_id": conversationID,
+	}, graph.QueryOptions{OrderBy: "created_at"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query conversation decisions: %w", err)
+	}
+
+	decisions := make([]*domain.AIDecision, 0, len(decisionProps))
+	for _, props := range decisionProps {
+		decision, err := r.mapToAIDecision(props)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map decision properties: %w", err)
+		}
+		decisions = append(decisions, decision)
+	}
+
+	return decisions, nil
+}
+
+// GetUserRequestWithDecisions retrieves a user message with the decisions linked to it via HAS_DECISION, oldest first
+func (r *GraphConversationRepository) GetUserRequestWithDecisions(ctx context.Context, messageID string) (*domain.UserRequest, error) {
+	props, err := r.graph.GetNode(ctx, NodeTypeMessage, messageID)
+	if err != nil && !errors.Is(err, graph.ErrNodeNotFound) {
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+	if props == nil {
+		return nil, fmt.Errorf("message %s not found: %w", messageID, graph.ErrNodeNotFound)
+	}
+
+	message, err := r.mapToMessage(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map message properties: %w", err)
+	}
+	request := &domain.UserRequest{ConversationMessage: *message, Decisions: []*domain.AIDecision{}}
+	request.ConversationID, _ = props["conversation_id"].(string)
+
+	edges, err := r.graph.GetEdgesWithTargets(ctx, NodeTypeMessage, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message relationships: %w", err)
+	}
+
+	for _, edge := range edges {
+		if edge["type"] != RelationshipHasDecision || edge["target_type"] != NodeTypeAIDecision {
+			continue
+		}
+		decisionID, _ := edge["target_id"].(string)
+		decisionProps, err := r.graph.GetNode(ctx, NodeTypeAIDecision, decisionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get decision %s: %w", decisionID, err)
+		}
+		decision, err := r.mapToAIDecision(decisionProps)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map decision properties: %w", err)
+		}
+		request.Decisions = append(request.Decisions, decision)
+	}
+
+	sort.SliceStable(request.Decisions, func(i, j int) bool {
+		return request.Decisions[i].CreatedAt.Before(request.Decisions[j].CreatedAt)
+	})
+
+	return request, nil
+}
+
+// GetDecisionChain follows FOLLOWS edges from a decision back to the root of its chain in a single query
+// The chain is returned root first and ends with the requested decision; a pruned ancestor ends the chain
+func (r *GraphConversationRepository) GetDecisionChain(ctx context.Context, decisionID string) ([]*domain.AIDecision, error) {
+	nodes, err := r.graph.FollowChain(ctx, NodeTypeAIDecision, decisionID, RelationshipFollows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get decision chain for %s: %w", decisionID, err)
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("decision %s not found: %w", decisionID, graph.ErrNodeNotFound)
+	}
+
+	chain := make([]*domain.AIDecision, len(nodes))
+	for i, props := range nodes {
+		decision, err := r.mapToAIDecision(props)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map decision properties: %w", err)
+		}
+		chain[len(nodes)-1-i] = decision
+	}
+	return chain, nil
+}
+
+// FindConversationsByUser finds all conversations of a user, most recently active first
 func (r *GraphConversationRepository) FindConversationsByUser(ctx context.Context, userID string) ([]*domain.Conversation, error) {
+	return r.FindConversationsByUserPaginated(ctx, userID, domain.ListOptions{})
+}
+
+// FindConversationsByUserPaginated finds a page of a user's conversations, most recently active first
+func (r *GraphConversationRepository) FindConversationsByUserPaginated(ctx context.Context, userID string, opts domain.ListOptions) ([]*domain.Conversation, error) {
 	filters := map[string]interface{}{
 		"user_id": userID,
 	}
+	if opts.Status != "" {
+		filters["status"] = string(opts.Status)
+	}
 
-	conversationProps, err := r.graph.QueryNodes(ctx, NodeTypeConversation, filters)
+	conversationProps, err := r.graph.QueryNodesWithOptions(ctx, NodeTypeConversation, filters, graph.QueryOptions{
+		OrderBy:    "last_activity_at",
+		Descending: true,
+		Limit:      opts.Limit,
+		Offset:     opts.Offset,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query conversations by user: %w", err)
 	}
@@ -324,6 +578,81 @@ func (r *GraphConversationRepository) FindConversationsByStatus(ctx context.Cont
 	return conversations, nil
 }
 
+// SearchMessages runs a page of a full-text search over the content of the messages in the user's conversations
+func (r *GraphConversationRepository) SearchMessages(ctx context.Context, userID, query string, opts domain.SearchOptions) ([]domain.MessageHit, error) {
+	conversationProps, err := r.graph.QueryNodes(ctx, NodeTypeConversation, map[string]interface{}{"user_id": userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query conversations by user: %w", err)
+	}
+
+	hits := make([]domain.MessageHit, 0)
+	owned := make([]string, 0, len(conversationProps))
+	for _, props := range conversationProps {
+		if id, ok := props["id"].(string); ok {
+			owned = append(owned, id)
+		}
+	}
+	if len(owned) == 0 {
+		return hits, nil
+	}
+
+	// Ownership and paging are applied by the search query itself so other users' matches are never loaded
+	conditions := []graph.Condition{{Field: "conversation_id", Operator: graph.OperatorIn, Value: owned}}
+	results, err := r.graph.FullTextSearch(ctx, NodeTypeMessage, "content", query, conditions, opts.Limit, opts.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search message content: %w", err)
+	}
+
+	for _, props := range results {
+		conversationID, _ := props["conversation_id"].(string)
+		messageID, _ := props["id"].(string)
+		content, _ := props["content"].(string)
+		score, _ := props["score"].(float64)
+
+		hits = append(hits, domain.MessageHit{
+			ConversationID: conversationID,
+			MessageID:      messageID,
+			Snippet:        buildSnippet(content, query),
+			Score:          score,
+		})
+	}
+
+	return hits, nil
+}
+
+// buildSnippet returns the part of content surrounding the first query term it contains
+func buildSnippet(content, query string) string {
+	text := []rune(content)
+	lower := []rune(strings.Map(unicode.ToLower, content))
+
+	start, end := 0, 0
+	for _, term := range strings.Fields(strings.Map(unicode.ToLower, query)) {
+		if idx := strings.Index(string(lower), term); idx >= 0 {
+			start = len([]rune(string(lower)[:idx]))
+			end = start + len([]rune(term))
+			break
+		}
+	}
+
+	from := start - snippetRadius
+	if from < 0 {
+		from = 0
+	}
+	to := end + snippetRadius
+	if to > len(text) {
+		to = len(text)
+	}
+
+	snippet := string(text[from:to])
+	if from > 0 {
+		snippet = "..." + snippet
+	}
+	if to < len(text) {
+		snippet += "..."
+	}
+	return snippet
+}
+
 // mapToConversation converts map properties to Conversation domain object
 func (r *GraphConversationRepository) mapToConversation(props map[string]interface{}) (*domain.Conversation, error) {
 	id, ok := props["id"].(string)
@@ -368,20 +697,15 @@ func (r *GraphConversationRepository) mapToConversation(props map[string]interfa
 	}
 
 	// Handle execution plan IDs (may be nil or array)
-	var executionPlanIDs []string
-	if planIDs, exists := props["execution_plan_ids"]; exists && planIDs != nil {
-		if planIDsSlice, ok := planIDs.([]interface{}); ok {
-			executionPlanIDs = make([]string, len(planIDsSlice))
-			for i, planID := range planIDsSlice {
-				if planIDStr, ok := planID.(string); ok {
-					executionPlanIDs[i] = planIDStr
-				}
-			}
-		}
-	}
+	executionPlanIDs := graph.DecodeStringSliceProperty(props["execution_plan_ids"])
+
+	summary, _ := props["summary"].(string)
 
-	if executionPlanIDs == nil {
-		executionPlanIDs = make([]string, 0)
+	var archivedAt time.Time
+	if archivedAtStr, ok := props["archived_at"].(string); ok && archivedAtStr != "" {
+		if archivedAt, err = parseTime(archivedAtStr); err != nil {
+			return nil, fmt.Errorf("failed to parse archived_at: %w", err)
+		}
 	}
 
 	// Create conversation object
@@ -392,8 +716,10 @@ func (r *GraphConversationRepository) mapToConversation(props map[string]interfa
 		Status:           domain.ConversationStatus(statusStr),
 		Messages:         make([]domain.ConversationMessage, 0), // Messages loaded separately
 		ExecutionPlanIDs: executionPlanIDs,
+		Summary:          summary,
 		CreatedAt:        createdAt,
 		UpdatedAt:        updatedAt,
+		ArchivedAt:       archivedAt,
 	}
 
 	return conversation, nil
@@ -427,22 +753,68 @@ func (r *GraphConversationRepository) mapToMessage(props map[string]interface{})
 		return nil, fmt.Errorf("failed to parse timestamp: %w", err)
 	}
 
-	// Handle metadata (may be nil)
-	metadata := make(map[string]interface{})
-	if metadataRaw, exists := props["metadata"]; exists && metadataRaw != nil {
-		if metadataMap, ok := metadataRaw.(map[string]interface{}); ok {
-			metadata = metadataMap
-		}
+	// Handle metadata (may be absent)
+	metadata, err := graph.DecodeMapProperty(props["metadata"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode message metadata: %w", err)
 	}
 
+	summarized, _ := props["summarized"].(bool)
+
 	// Create message object
 	message := &domain.ConversationMessage{
-		ID:        id,
-		Role:      domain.MessageRole(roleStr),
-		Content:   content,
-		Timestamp: timestamp,
-		Metadata:  metadata,
+		ID:         id,
+		Role:       domain.MessageRole(roleStr),
+		Content:    content,
+		Timestamp:  timestamp,
+		Metadata:   metadata,
+		Summarized: summarized,
 	}
 
 	return message, nil
 }
+
+// mapToAIDecision maps graph properties to an AIDecision
+func (r *GraphConversationRepository) mapToAIDecision(props map[string]interface{}) (*domain.AIDecision, error) {
+	decision := &domain.AIDecision{}
+
+	id, ok := props["id"].(string)
+	if !ok {
+		return nil, fmt.Errorf("missing or invalid id")
+	}
+	decision.ID = id
+
+	decision.ConversationID, _ = props["conversation_id"].(string)
+	decision.RequestMessageID, _ = props["request_message_id"].(string)
+	decision.Type, _ = props["decision_type"].(string)
+	decision.Reasoning, _ = props["reasoning"].(string)
+	decision.ClarificationQuestion, _ = props["clarification_question"].(string)
+	decision.RejectionReason, _ = props["rejection_reason"].(string)
+	decision.ExecutionPlanID, _ = props["execution_plan_id"].(string)
+
+	switch previous := props["previous_decisions"].(type) {
+	case []string:
+		decision.PreviousDecisions = previous
+	case []interface{}:
+		for _, id := range previous {
+			if idStr, ok := id.(string); ok {
+				decision.PreviousDecisions = append(decision.PreviousDecisions, idStr)
+			}
+		}
+	}
+
+	decision.Confidence = intProperty(props["confidence"])
+	decision.PromptTokens = intProperty(props["prompt_tokens"])
+	decision.CompletionTokens = intProperty(props["completion_tokens"])
+	decision.TotalTokens = intProperty(props["total_tokens"])
+
+	if createdAtStr, ok := props["created_at"].(string); ok {
+		createdAt, err := parseTime(createdAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid created_at: %w", err)
+		}
+		decision.CreatedAt = createdAt
+	}
+
+	return decision, nil
+}
@@ -2,7 +2,9 @@ package infrastructure
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -10,6 +12,7 @@ import (
 	"neuromesh/internal/conversation/domain"
 	"neuromesh/internal/graph"
 	"neuromesh/internal/logging"
+	"neuromesh/testHelpers"
 )
 
 // TestGraphConversationRepository_ConversationSchema tests Conversation and Message schema creation
@@ -37,7 +40,7 @@ func TestGraphConversationRepository_ConversationSchema(t *testing.T) {
 
 	t.Run("GREEN: should create and store Conversation nodes", func(t *testing.T) {
 		// Clean up any existing test data
-		err := g.ClearTestData(ctx)
+		err := clearConversationNodes(ctx, g)
 		require.NoError(t, err, "Failed to clean up test data")
 
 		// Ensure schema exists first
@@ -64,7 +67,7 @@ func TestGraphConversationRepository_ConversationSchema(t *testing.T) {
 
 	t.Run("GREEN: should create and store Message nodes", func(t *testing.T) {
 		// Clean up any existing test data
-		err := g.ClearTestData(ctx)
+		err := clearConversationNodes(ctx, g)
 		require.NoError(t, err, "Failed to clean up test data")
 
 		// Ensure schemas exist first
@@ -103,7 +106,7 @@ func TestGraphConversationRepository_ConversationSchema(t *testing.T) {
 
 	t.Run("GREEN: should establish Conversation-Session-User relationships", func(t *testing.T) {
 		// Clean up any existing test data
-		err := g.ClearTestData(ctx)
+		err := clearConversationNodes(ctx, g)
 		require.NoError(t, err, "Failed to clean up test data")
 
 		// Ensure schemas exist first
@@ -126,7 +129,7 @@ func TestGraphConversationRepository_ConversationSchema(t *testing.T) {
 
 	t.Run("GREEN: should query conversations by user and session", func(t *testing.T) {
 		// Clean up any existing test data
-		err := g.ClearTestData(ctx)
+		err := clearConversationNodes(ctx, g)
 		require.NoError(t, err, "Failed to clean up test data")
 
 		// Ensure schema exists first
@@ -162,7 +165,7 @@ func TestGraphConversationRepository_ConversationSchema(t *testing.T) {
 
 	t.Run("GREEN: should handle message filtering by role", func(t *testing.T) {
 		// Clean up any existing test data
-		err := g.ClearTestData(ctx)
+		err := clearConversationNodes(ctx, g)
 		require.NoError(t, err, "Failed to clean up test data")
 
 		// Ensure schemas exist first
@@ -202,3 +205,123 @@ func TestGraphConversationRepository_ConversationSchema(t *testing.T) {
 		assert.Len(t, assistantMessages, 1, "Should find 1 assistant message")
 	})
 }
+
+func TestGraphConversationRepository_FindConversationsByUserPaginated(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGraphConversationRepository(testHelpers.NewCleanMockGraph())
+	base := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	// Conversations become active in order conv-1 .. conv-4; conv-2 is closed
+	for i, id := range []string{"conv-1", "conv-2", "conv-3", "conv-4"} {
+		conversation, err := domain.NewConversation(id, "session-1", "user-1")
+		require.NoError(t, err)
+		require.NoError(t, repo.CreateConversation(ctx, conversation))
+
+		require.NoError(t, repo.AddMessage(ctx, id, &domain.ConversationMessage{
+			ID: "msg-" + id, Role: domain.MessageRoleUser, Content: "hello", Timestamp: base.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+	closed, err := repo.GetConversation(ctx, "conv-2")
+	require.NoError(t, err)
+	closed.Status = domain.ConversationStatusClosed
+	require.NoError(t, repo.UpdateConversation(ctx, closed))
+	require.NoError(t, repo.AddMessage(ctx, "conv-2", &domain.ConversationMessage{
+		ID: "msg-conv-2-late", Role: domain.MessageRoleUser, Content: "late", Timestamp: base.Add(time.Hour),
+	}))
+
+	ids := func(conversations []*domain.Conversation) []string {
+		result := make([]string, len(conversations))
+		for i, conversation := range conversations {
+			result[i] = conversation.ID
+		}
+		return result
+	}
+
+	t.Run("orders by most recent activity", func(t *testing.T) {
+		conversations, err := repo.FindConversationsByUserPaginated(ctx, "user-1", domain.ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"conv-2", "conv-4", "conv-3", "conv-1"}, ids(conversations))
+	})
+
+	t.Run("applies limit and offset", func(t *testing.T) {
+		conversations, err := repo.FindConversationsByUserPaginated(ctx, "user-1", domain.ListOptions{Limit: 2, Offset: 1})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"conv-4", "conv-3"}, ids(conversations))
+	})
+
+	t.Run("filters by status", func(t *testing.T) {
+		conversations, err := repo.FindConversationsByUserPaginated(ctx, "user-1", domain.ListOptions{Status: domain.ConversationStatusActive})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"conv-4", "conv-3", "conv-1"}, ids(conversations))
+	})
+
+	t.Run("unpaginated wrapper returns all conversations", func(t *testing.T) {
+		conversations, err := repo.FindConversationsByUser(ctx, "user-1")
+		require.NoError(t, err)
+		assert.Len(t, conversations, 4)
+	})
+}
+
+func TestGraphConversationRepository_ExecutionPlanIDsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGraphConversationRepository(testHelpers.NewCleanMockGraph())
+
+	conversation, err := domain.NewConversation("conv-plans", "session-1", "user-1")
+	require.NoError(t, err)
+	require.NoError(t, conversation.LinkExecutionPlan("plan-1"))
+	require.NoError(t, conversation.LinkExecutionPlan("plan-2"))
+	require.NoError(t, repo.CreateConversation(ctx, conversation))
+
+	stored, err := repo.GetConversation(ctx, "conv-plans")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"plan-1", "plan-2"}, stored.ExecutionPlanIDs)
+}
+
+func TestGraphConversationRepository_MessageMetadataStoredAsJSON(t *testing.T) {
+	ctx := context.Background()
+	mockGraph := testHelpers.NewCleanMockGraph()
+	repo := NewGraphConversationRepository(mockGraph)
+
+	conversation, err := domain.NewConversation("conv-metadata", "session-1", "user-1")
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateConversation(ctx, conversation))
+
+	message := &domain.ConversationMessage{
+		ID:        "msg-metadata",
+		Role:      domain.MessageRoleAssistant,
+		Content:   "Done",
+		Timestamp: time.Now().UTC(),
+		Metadata:  map[string]interface{}{"intent": "deploy", "agents": []interface{}{"deploy-agent"}},
+	}
+	require.NoError(t, repo.AddMessage(ctx, "conv-metadata", message))
+
+	node, err := mockGraph.GetNode(ctx, NodeTypeMessage, "msg-metadata")
+	require.NoError(t, err)
+	assert.IsType(t, "", node["metadata"])
+
+	messages, err := repo.GetConversationMessages(ctx, "conv-metadata")
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, message.Metadata, messages[0].Metadata)
+}
+
+func TestBuildSnippet(t *testing.T) {
+	content := strings.Repeat("a", 60) + " ECONNREFUSED " + strings.Repeat("b", 60)
+
+	snippet := buildSnippet(content, "econnrefused")
+
+	assert.True(t, strings.HasPrefix(snippet, "..."))
+	assert.True(t, strings.HasSuffix(snippet, "..."))
+	assert.Contains(t, snippet, "ECONNREFUSED")
+	assert.Equal(t, "short message", buildSnippet("short message", "missing"))
+}
+
+// clearConversationNodes removes the conversation, message, decision, session and user nodes left by earlier runs without touching other data
+func clearConversationNodes(ctx context.Context, g *graph.Neo4jGraph) error {
+	for _, nodeType := range []string{NodeTypeConversation, NodeTypeMessage, NodeTypeAIDecision, "Session", "User"} {
+		if err := g.ClearNodesByType(ctx, nodeType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
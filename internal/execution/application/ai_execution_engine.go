@@ -2,21 +2,36 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	aiDomain "neuromesh/internal/ai/domain"
+	executionDomain "neuromesh/internal/execution/domain"
 	"neuromesh/internal/messaging"
 	"neuromesh/internal/orchestrator/infrastructure"
+	planningDomain "neuromesh/internal/planning/domain"
+	"neuromesh/internal/tracing"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	EventPrefix         = "SEND_EVENT:"
+	BatchEventPrefix    = "SEND_EVENTS:"
 	UserResponsePrefix  = "USER_RESPONSE:"
 	DefaultEventTimeout = 30 * time.Second
+
+	// DefaultMaxStepRetries applies when a failed event is not linked to a persisted step
+	DefaultMaxStepRetries = 3
+	// DefaultRetryBaseDelay is the first backoff delay, doubled on every further retry
+	DefaultRetryBaseDelay = 1 * time.Second
+
+	// NoExecutionPlan stands in for the plan in the prompt of requests executed without one
+	NoExecutionPlan = "No execution plan available"
 )
 
 // AIExecutionEngine handles AI-native execution with agent coordination
@@ -24,6 +39,13 @@ type AIExecutionEngine struct {
 	aiProvider         aiDomain.AIProvider
 	aiMessageBus       messaging.AIMessageBus
 	correlationTracker *infrastructure.CorrelationTracker
+	executionPlanRepo  planningDomain.ExecutionPlanRepository
+	resultRepo         executionDomain.AgentResultRepository
+	agentDirectory     AgentDirectory
+	dispatchQueue      *DispatchQueue
+	agentQueues        *AgentDispatchQueues
+	synthesizer        *ResultSynthesisService
+	retryBaseDelay     time.Duration
 }
 
 // NewAIExecutionEngine creates a new AI execution engine
@@ -32,28 +54,83 @@ func NewAIExecutionEngine(aiProvider aiDomain.AIProvider, aiMessageBus messaging
 		aiProvider:         aiProvider,
 		aiMessageBus:       aiMessageBus,
 		correlationTracker: correlationTracker,
+		retryBaseDelay:     DefaultRetryBaseDelay,
 	}
 }
 
+// NewAIExecutionEngineWithRepository creates a new AI execution engine that persists step retries
+func NewAIExecutionEngineWithRepository(aiProvider aiDomain.AIProvider, aiMessageBus messaging.AIMessageBus, correlationTracker *infrastructure.CorrelationTracker, executionPlanRepo planningDomain.ExecutionPlanRepository) *AIExecutionEngine {
+	engine := NewAIExecutionEngine(aiProvider, aiMessageBus, correlationTracker)
+	engine.executionPlanRepo = executionPlanRepo
+	return engine
+}
+
+// SetAgentResultRepository enables persistence of the results agents report for plan steps
+func (e *AIExecutionEngine) SetAgentResultRepository(resultRepo executionDomain.AgentResultRepository) {
+	e.resultRepo = resultRepo
+}
+
 // ExecuteWithAgents handles AI-native execution with bidirectional agent communication via events
 // This is stateless and supports concurrent executions using correlation IDs
-func (e *AIExecutionEngine) ExecuteWithAgents(ctx context.Context, executionPlan, userInput, userID, agentContext string) (string, error) {
+// planID is the persisted plan supplied by the orchestrator, or empty for requests without a plan
+func (e *AIExecutionEngine) ExecuteWithAgents(ctx context.Context, planID, userInput, userID, agentContext string) (result string, err error) {
+	ctx, span := tracing.Start(ctx, "execution.execute_with_agents", trace.WithAttributes(
+		attribute.String("plan.id", planID),
+		attribute.String("user.id", userID),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	// Every agent request of the execution carries its ID, so cancelling the ID cancels the whole request
+	executionID := executionDomain.ExecutionIDFromContext(ctx)
+	if executionID == "" {
+		executionID = executionDomain.NewExecutionID(userID)
+		ctx = executionDomain.WithExecutionID(ctx, executionID)
+	}
+	e.correlationTracker.BeginExecution(infrastructure.TrackedExecution{ExecutionID: executionID, UserID: userID, PlanID: planID})
+	defer e.correlationTracker.EndExecution(executionID)
+
+	// The synthesized plan results are returned to the user once the execution completes the plan
+	ctx, completion := withPlanCompletion(ctx)
+	result, err = e.execute(ctx, planID, userInput, userID, agentContext)
+	if err != nil {
+		return "", err
+	}
+	return e.finalAnswer(ctx, completion, planID, result), nil
+}
+
+// execute runs a plan with the agents and returns the AI's response to the user
+func (e *AIExecutionEngine) execute(ctx context.Context, planID, userInput, userID, agentContext string) (string, error) {
 	// Generate unique correlation ID for this execution
 	correlationID := fmt.Sprintf("exec-%s-%s", userID, uuid.New().String())
 
+	if plan := e.loadPlan(ctx, planID); plan != nil {
+		// Every dispatch of the execution waits for its slot at the plan's priority
+		ctx = withPlanPriority(ctx, plan.Priority)
+
+		// Plans whose steps declare dependencies run as a DAG rather than in the order the AI picks
+		if plan.HasStepDependencies() {
+			return e.executeStepGraph(ctx, plan, userInput, userID, agentContext)
+		}
+	}
+
 	// Get AI execution decision using improved system prompt
-	systemPrompt := e.buildExecutionSystemPrompt(agentContext, executionPlan)
+	systemPrompt := e.buildExecutionSystemPrompt(agentContext, planID)
 	userPrompt := fmt.Sprintf("Execute plan for user request: %s", userInput)
 
 	// Get AI execution decision
-	response, err := e.aiProvider.CallAI(ctx, systemPrompt, userPrompt)
+	response, err := e.callAI(ctx, systemPrompt, userPrompt)
 	if err != nil {
 		return "", fmt.Errorf("AI execution call failed: %w", err)
 	}
 
+	// Check if AI wants to fan out events to several agents in parallel
+	if strings.Contains(response, BatchEventPrefix) {
+		return e.handleBatchAgentEvents(ctx, response, userInput, userID, agentContext, planID)
+	}
+
 	// Check if AI wants to send event to an agent
 	if strings.Contains(response, EventPrefix) {
-		return e.handleAgentEvent(ctx, response, userInput, userID, agentContext, correlationID)
+		return e.handleAgentEvent(ctx, response, userInput, userID, agentContext, planID, correlationID)
 	}
 
 	// Extract direct user response
@@ -65,8 +142,24 @@ func (e *AIExecutionEngine) ExecuteWithAgents(ctx context.Context, executionPlan
 	return response, nil
 }
 
+// callAI calls the AI provider and records the consumed tokens on the request's usage recorder
+func (e *AIExecutionEngine) callAI(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	ctx, span := tracing.Start(ctx, "execution.ai_call")
+	response, usage, err := e.aiProvider.CallAIWithUsage(ctx, systemPrompt, userPrompt, aiDomain.CallOptions{})
+	tracing.End(span, err)
+	if err != nil {
+		return "", err
+	}
+	aiDomain.RecordUsage(ctx, usage)
+	return response, nil
+}
+
 // buildExecutionSystemPrompt creates the system prompt for AI execution
-func (e *AIExecutionEngine) buildExecutionSystemPrompt(agentContext, executionPlan string) string {
+func (e *AIExecutionEngine) buildExecutionSystemPrompt(agentContext, planID string) string {
+	executionPlan := planID
+	if executionPlan == "" {
+		executionPlan = NoExecutionPlan
+	}
 	return fmt.Sprintf(`You are an AI execution engine that coordinates with multiple agents to execute plans.
 
 EXECUTION PLAN:
@@ -86,58 +179,368 @@ Agent: [agent-id from context]
 Action: [specific action like "deploy", "analyze", "monitor"]
 Content: [specific instructions for the agent]
 Intent: [high-level goal like "deployment", "analysis"]
+Step: [step-id from the execution plan, if applicable]
+
+When several agents can work independently at the same time, respond with one block per agent:
+%s
+Agent: [agent-id from context]
+Action: [specific action]
+Content: [specific instructions for the agent]
+Intent: [high-level goal]
+Agent: [next agent-id]
+...
 
 When providing final response to user, respond with:
 %s
 [Your response to the user]
 
-Always use the execution plan as your guide and coordinate agents efficiently.`, executionPlan, agentContext, EventPrefix, UserResponsePrefix)
+Always use the execution plan as your guide and coordinate agents efficiently.`, executionPlan, agentContext, EventPrefix, BatchEventPrefix, UserResponsePrefix)
 }
 
 // handleAgentEvent processes AI's decision to send event to an agent during execution
-func (e *AIExecutionEngine) handleAgentEvent(ctx context.Context, aiResponse, originalRequest, userID, agentContext, correlationID string) (string, error) {
+func (e *AIExecutionEngine) handleAgentEvent(ctx context.Context, aiResponse, originalRequest, userID, agentContext, planID, correlationID string) (string, error) {
 	// Parse AI's agent event instruction
 	agentID := e.extractSection(aiResponse, "Agent:")
 	action := e.extractSection(aiResponse, "Action:")
 	content := e.extractSection(aiResponse, "Content:")
 	intent := e.extractSection(aiResponse, "Intent:")
+	stepID := e.extractSection(aiResponse, "Step:")
+
+	// Check the target before dispatch so an unknown or offline agent fails fast instead of timing out
+	agentID, timeout, err := e.resolveAgent(ctx, agentID, action)
+	if errors.Is(err, ErrAgentUnavailable) {
+		return e.deadLetterResponse(err), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	event := agentEvent{AgentID: agentID, Action: action, Content: content, Intent: intent, StepID: stepID}
+	if err := e.validateStepInputs(ctx, event); err != nil {
+		return "", err
+	}
+
+	agentResult, retries, err := e.dispatchWithRetry(ctx, stepID, userID, correlationID, func(correlationID string, retries int) (*executionDomain.AgentResult, error) {
+		// Create AI-to-Agent event message with correlation ID
+		eventMsg := e.newAgentInstruction(ctx, event, correlationID, originalRequest, userID, planID, timeout)
+		eventMsg.Context["retry_count"] = retries
+
+		// Send the event and wait for its response within one agent round-trip span
+		release, err := e.acquireDispatchSlot(ctx, agentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire dispatch slot for agent %s: %w", agentID, err)
+		}
+		agentResponse, err := e.sendAndAwaitAgent(ctx, eventMsg, stepID, userID)
+		release()
+		if err != nil {
+			return nil, err
+		}
+		executionDomain.ReportProgress(ctx, executionDomain.NewProgressEvent(executionDomain.ProgressEventAgentResponded, agentID, agentResponse.Content))
+
+		// Bind the result to the plan carried in the correlation context rather than deriving it from the step ID
+		return e.buildAgentResult(planID, stepID, correlationID, agentResponse), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if agentResult.IsFailed() {
+		if err := e.storeAgentResult(ctx, agentResult); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("agent %s failed after %d retries: %s", agentID, retries, agentResult.ErrorMessage)
+	}
+	if err := e.recordAgentResult(ctx, agentResult, userID); err != nil {
+		return "", err
+	}
+
+	// Let AI process the agent response during execution
+	return e.processAgentExecutionResponse(ctx, agentResult, originalRequest, userID, agentContext)
+}
+
+// dispatchWithRetry runs attempt until the agent reports success or the step runs out of retries, backing off exponentially in between
+// It returns the last result, which is still failed when the retries ran out, and the number of retries made
+func (e *AIExecutionEngine) dispatchWithRetry(ctx context.Context, stepID, userID, correlationID string, attempt func(correlationID string, retries int) (*executionDomain.AgentResult, error)) (*executionDomain.AgentResult, int, error) {
+	retries := 0
+	for {
+		result, err := attempt(correlationID, retries)
+		if err != nil {
+			return nil, retries, err
+		}
+		if !result.IsFailed() {
+			return result, retries, nil
+		}
 
-	// Create AI-to-Agent event message with correlation ID
-	eventMsg := &messaging.AIToAgentMessage{
-		AgentID:       agentID,
-		Content:       content,
-		Intent:        intent,
+		canRetry, err := e.prepareStepRetry(ctx, stepID, retries, result)
+		if err != nil {
+			return nil, retries, err
+		}
+		if !canRetry {
+			return result, retries, nil
+		}
+
+		retries++
+		if err := e.waitForRetryBackoff(ctx, retries); err != nil {
+			return nil, retries, err
+		}
+
+		// Each attempt gets its own correlation ID so late responses cannot be mistaken for the retry
+		correlationID = fmt.Sprintf("exec-%s-%s", userID, uuid.New().String())
+	}
+}
+
+// newAgentInstruction builds the instruction every dispatch path sends for an agent event, carrying the step's inputs as parameters
+func (e *AIExecutionEngine) newAgentInstruction(ctx context.Context, event agentEvent, correlationID, originalRequest, userID, planID string, timeout time.Duration) *messaging.AIToAgentMessage {
+	return &messaging.AIToAgentMessage{
+		AgentID:       event.AgentID,
+		Content:       event.Content,
+		Intent:        event.Intent,
 		CorrelationID: correlationID,
 		Context: map[string]interface{}{
 			"original_request": originalRequest,
 			"user_id":          userID,
-			"action":           action,
+			"action":           event.Action,
 			"execution_mode":   true,
+			"execution_id":     executionDomain.ExecutionIDFromContext(ctx),
+			"plan_id":          planID,
+			"step_id":          event.StepID,
 		},
-		Timeout: DefaultEventTimeout,
+		Parameters: e.stepParameters(ctx, event.StepID),
+		Timeout:    timeout,
 	}
+}
 
-	// Send event to agent via message bus
-	err := e.aiMessageBus.SendToAgent(ctx, eventMsg)
+// validateStepInputs checks a persisted step's inputs against the capability its agent declares for the action
+// Steps that were never persisted and agents or capabilities the directory does not know are not rejected
+func (e *AIExecutionEngine) validateStepInputs(ctx context.Context, event agentEvent) error {
+	if e.agentDirectory == nil || e.executionPlanRepo == nil || event.StepID == "" {
+		return nil
+	}
+
+	step, err := e.executionPlanRepo.GetStepByID(ctx, event.StepID)
+	if err != nil {
+		return nil
+	}
+	agent, err := e.agentDirectory.GetAgent(ctx, event.AgentID)
+	if err != nil || agent == nil {
+		return nil
+	}
+	if err := step.ValidateInputs(agent.GetCapability(event.Action)); err != nil {
+		return fmt.Errorf("cannot dispatch step %s to agent %s: %w", event.StepID, event.AgentID, err)
+	}
+	return nil
+}
+
+// stepParameters returns the inputs of a persisted step as structured instruction parameters
+// Steps that were never persisted or carry no valid inputs send none, leaving the agent to read the instruction text
+func (e *AIExecutionEngine) stepParameters(ctx context.Context, stepID string) map[string]interface{} {
+	if e.executionPlanRepo == nil || stepID == "" {
+		return nil
+	}
+
+	step, err := e.executionPlanRepo.GetStepByID(ctx, stepID)
 	if err != nil {
-		return "", fmt.Errorf("failed to send execution event to agent %s: %w", agentID, err)
+		return nil
 	}
+	inputs, err := step.GetInputs()
+	if err != nil || len(inputs) == 0 {
+		return nil
+	}
+	return inputs
+}
+
+// sendAndAwaitAgent sends an event to its agent and waits for the correlated response, tracing the round-trip
+// The trace context travels in the event context so the agent can continue the trace
+func (e *AIExecutionEngine) sendAndAwaitAgent(ctx context.Context, eventMsg *messaging.AIToAgentMessage, stepID, userID string) (response *messaging.AgentToAIMessage, err error) {
+	ctx, span := tracing.Start(ctx, "execution.agent_event", trace.WithAttributes(
+		attribute.String("agent.id", eventMsg.AgentID),
+		attribute.String("step.id", stepID),
+		attribute.String("correlation.id", eventMsg.CorrelationID),
+	))
+	defer func() { tracing.End(span, err) }()
+	tracing.Inject(ctx, eventMsg.Context)
+
+	// Send event to agent via message bus
+	if err := e.aiMessageBus.SendToAgent(ctx, eventMsg); err != nil {
+		return nil, fmt.Errorf("failed to send execution event to agent %s: %w", eventMsg.AgentID, err)
+	}
+	executionDomain.ReportProgress(ctx, executionDomain.NewProgressEvent(executionDomain.ProgressEventAgentEventSent, eventMsg.AgentID, eventMsg.Content))
 
 	// Wait for agent response using correlation tracker (stateless)
-	agentResponse, err := e.waitForAgentResponseWithCorrelation(ctx, correlationID, userID)
+	response, err = e.waitForAgentResponseWithCorrelation(ctx, eventMsg, userID)
 	if err != nil {
-		return "", fmt.Errorf("failed to receive agent execution response: %w", err)
+		return nil, fmt.Errorf("failed to receive agent execution response: %w", err)
 	}
+	return response, nil
+}
 
-	// Let AI process the agent response during execution
-	return e.processAgentExecutionResponse(ctx, agentResponse, originalRequest, userID, agentContext)
+// buildAgentResult converts an agent response into an AgentResult, detecting reported failures
+func (e *AIExecutionEngine) buildAgentResult(planID, stepID, correlationID string, response *messaging.AgentToAIMessage) *executionDomain.AgentResult {
+	result := executionDomain.NewAgentResult(planID, stepID, response.AgentID, correlationID, response.Content)
+
+	failed := response.MessageType == messaging.MessageTypeError
+	if success, ok := response.Context["success"].(bool); ok && !success {
+		failed = true
+	}
+
+	if failed {
+		errorMessage := response.Content
+		if errMsg, ok := response.Context["error"].(string); ok && errMsg != "" {
+			errorMessage = errMsg
+		}
+		result.MarkFailed(errorMessage)
+	}
+
+	return result
+}
+
+// recordAgentResult stores a successful result, completes its step and announces the completion
+// Completing the last outstanding step publishes a PlanCompletedEvent and marks the execution for result synthesis
+func (e *AIExecutionEngine) recordAgentResult(ctx context.Context, result *executionDomain.AgentResult, userID string) error {
+	if err := e.storeAgentResult(ctx, result); err != nil {
+		return err
+	}
+	if e.executionPlanRepo == nil || !result.HasPlan() || !result.HasStep() {
+		return nil
+	}
+
+	step, err := e.executionPlanRepo.GetStepByID(ctx, result.StepID)
+	if err != nil {
+		// The AI may reference a step that was never persisted
+		return nil
+	}
+	if !step.IsComplete() {
+		if step.Status == planningDomain.ExecutionStepStatusPending {
+			step.Assign()
+		}
+		if step.Status == planningDomain.ExecutionStepStatusAssigned {
+			if err := step.Start(); err != nil {
+				return fmt.Errorf("failed to start step %s: %w", step.ID, err)
+			}
+		}
+		if err := step.Complete(map[string]interface{}{planningDomain.StepOutputContent: result.Content}); err != nil {
+			return fmt.Errorf("failed to complete step %s: %w", step.ID, err)
+		}
+		if err := e.executionPlanRepo.UpdateStep(ctx, step); err != nil {
+			return fmt.Errorf("failed to persist completed step %s: %w", step.ID, err)
+		}
+	}
+
+	// Without stored results there is nothing to synthesize, so completion is not announced
+	if e.resultRepo == nil {
+		return nil
+	}
+
+	// Retries and duplicate reports re-store results; only the first completion of a step is announced
+	published, err := e.publishAgentCompletedEvent(ctx, result)
+	if err != nil || !published {
+		return err
+	}
+
+	counts, err := e.executionPlanRepo.GetStepStatusCounts(ctx, result.PlanID)
+	if err != nil {
+		return fmt.Errorf("failed to get step status counts for plan %s: %w", result.PlanID, err)
+	}
+	if !planningDomain.NewPlanProgress(result.PlanID, counts, nil).IsComplete() {
+		return nil
+	}
+
+	event := &messaging.PlanCompletedEvent{
+		PlanID:        result.PlanID,
+		UserID:        userID,
+		CorrelationID: result.CorrelationID,
+	}
+	if err := e.aiMessageBus.PublishPlanCompletedEvent(ctx, event); err != nil {
+		return fmt.Errorf("failed to publish plan completed event: %w", err)
+	}
+	markPlanCompleted(ctx)
+	return nil
+}
+
+// publishAgentCompletedEvent publishes the step's completion event once per (plan, step)
+func (e *AIExecutionEngine) publishAgentCompletedEvent(ctx context.Context, result *executionDomain.AgentResult) (bool, error) {
+	first, err := e.executionPlanRepo.MarkStepEventPublished(ctx, result.StepID)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark completion event for step %s: %w", result.StepID, err)
+	}
+	if !first {
+		return false, nil
+	}
+
+	event := &messaging.AgentCompletedEvent{
+		PlanID:        result.PlanID,
+		StepID:        result.StepID,
+		AgentID:       result.AgentID,
+		CorrelationID: result.CorrelationID,
+	}
+	if err := e.aiMessageBus.PublishAgentCompletedEvent(ctx, event); err != nil {
+		return false, fmt.Errorf("failed to publish agent completed event: %w", err)
+	}
+	return true, nil
+}
+
+// storeAgentResult persists a plan-bound agent result when a result repository is configured
+func (e *AIExecutionEngine) storeAgentResult(ctx context.Context, result *executionDomain.AgentResult) error {
+	if e.resultRepo == nil || !result.HasPlan() {
+		return nil
+	}
+	if err := e.resultRepo.Store(ctx, result); err != nil {
+		return fmt.Errorf("failed to store agent result for plan %s: %w", result.PlanID, err)
+	}
+	return nil
+}
+
+// prepareStepRetry decides whether a failed agent result may be retried and persists the retry count
+func (e *AIExecutionEngine) prepareStepRetry(ctx context.Context, stepID string, retries int, result *executionDomain.AgentResult) (bool, error) {
+	if e.executionPlanRepo == nil || stepID == "" {
+		return retries < DefaultMaxStepRetries, nil
+	}
+
+	step, err := e.executionPlanRepo.GetStepByID(ctx, stepID)
+	if err != nil {
+		// The AI may reference a step that was never persisted; fall back to the default policy
+		return retries < DefaultMaxStepRetries, nil
+	}
+
+	step.Fail(result.ErrorMessage)
+	if !step.CanRetry() {
+		if err := e.executionPlanRepo.UpdateStep(ctx, step); err != nil {
+			return false, fmt.Errorf("failed to persist failed step %s: %w", stepID, err)
+		}
+		return false, nil
+	}
+
+	if err := step.Retry(); err != nil {
+		return false, fmt.Errorf("failed to retry step %s: %w", stepID, err)
+	}
+	if err := e.executionPlanRepo.UpdateStep(ctx, step); err != nil {
+		return false, fmt.Errorf("failed to persist retry count for step %s: %w", stepID, err)
+	}
+
+	return true, nil
+}
+
+// waitForRetryBackoff sleeps with exponential backoff before the given retry attempt
+func (e *AIExecutionEngine) waitForRetryBackoff(ctx context.Context, retry int) error {
+	delay := e.retryBaseDelay * time.Duration(1<<(retry-1))
+
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // waitForAgentResponseWithCorrelation waits for an agent response using correlation tracking
-func (e *AIExecutionEngine) waitForAgentResponseWithCorrelation(ctx context.Context, correlationID, userID string) (*messaging.AgentToAIMessage, error) {
+func (e *AIExecutionEngine) waitForAgentResponseWithCorrelation(ctx context.Context, eventMsg *messaging.AIToAgentMessage, userID string) (*messaging.AgentToAIMessage, error) {
+	correlationID := eventMsg.CorrelationID
+
 	// Register request with correlation tracker
-	timeout := 30 * time.Second
-	responseChan := e.correlationTracker.RegisterRequest(correlationID, userID, timeout)
+	timeout := eventMsg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultEventTimeout
+	}
+	responseChan := e.registerAgentRequest(ctx, eventMsg, userID, timeout)
 
 	// Subscribe to the execution response channel
 	responseChannel, err := e.aiMessageBus.Subscribe(ctx, "ai-execution")
@@ -159,16 +562,13 @@ func (e *AIExecutionEngine) waitForAgentResponseWithCorrelation(ctx context.Cont
 					return
 				}
 				if msg != nil {
-					if msg.MessageType == messaging.MessageTypeAgentToAI && msg.CorrelationID == correlationID {
-						agentMsg := &messaging.AgentToAIMessage{
-							AgentID:       msg.FromID,
-							Content:       msg.Content,
-							CorrelationID: msg.CorrelationID,
-							MessageType:   msg.MessageType,
+					if isAgentResponse(msg) && msg.CorrelationID == correlationID {
+						reply := agentReply(msg)
+						e.correlationTracker.RouteResponse(reply)
+						// In-progress updates do not answer the event; keep waiting for the terminal response
+						if !reply.IsInProgress() {
+							return
 						}
-
-						e.correlationTracker.RouteResponse(agentMsg)
-						return
 					}
 				}
 			case <-ctx.Done():
@@ -183,6 +583,9 @@ func (e *AIExecutionEngine) waitForAgentResponseWithCorrelation(ctx context.Cont
 		if response != nil {
 			return response, nil
 		}
+		if e.correlationTracker.IsCancelled(correlationID) {
+			return nil, fmt.Errorf("%w: correlation %s", ErrExecutionCancelled, correlationID)
+		}
 		return nil, fmt.Errorf("received nil execution response for correlation %s", correlationID)
 	case <-ctx.Done():
 		e.correlationTracker.CleanupRequest(correlationID)
@@ -194,7 +597,7 @@ func (e *AIExecutionEngine) waitForAgentResponseWithCorrelation(ctx context.Cont
 }
 
 // processAgentExecutionResponse lets AI decide what to do with agent response during execution
-func (e *AIExecutionEngine) processAgentExecutionResponse(ctx context.Context, agentResponse *messaging.AgentToAIMessage, originalRequest, userID, agentContext string) (string, error) {
+func (e *AIExecutionEngine) processAgentExecutionResponse(ctx context.Context, agentResult *executionDomain.AgentResult, originalRequest, userID, agentContext string) (string, error) {
 	systemPrompt := fmt.Sprintf(`You are an AI execution engine processing an agent response during plan execution.
 
 Original user request: %s
@@ -213,22 +616,28 @@ Agent: [agent-id]
 Action: [specific action]
 Content: [specific instructions for the agent]
 Intent: [high-level goal]
+Step: [step-id from the execution plan, if applicable]
 
 If providing final result to user, respond with:
 %s
-[Your execution result for the user]`, originalRequest, agentResponse.AgentID, agentResponse.Content, agentContext, EventPrefix, UserResponsePrefix)
+[Your execution result for the user]`, originalRequest, agentResult.AgentID, agentResult.Content, agentContext, EventPrefix, UserResponsePrefix)
 
 	userPrompt := "Process the agent response and determine next execution step."
 
-	response, err := e.aiProvider.CallAI(ctx, systemPrompt, userPrompt)
+	response, err := e.callAI(ctx, systemPrompt, userPrompt)
 	if err != nil {
 		return "", fmt.Errorf("AI execution processing failed: %w", err)
 	}
 
+	// Check if AI wants to coordinate with several agents in parallel
+	if strings.Contains(response, BatchEventPrefix) {
+		return e.handleBatchAgentEvents(ctx, response, originalRequest, userID, agentContext, agentResult.PlanID)
+	}
+
 	// Check if AI wants to coordinate with another agent
 	if strings.Contains(response, EventPrefix) {
 		correlationID := fmt.Sprintf("exec-%s-%s", userID, uuid.New().String())
-		return e.handleAgentEvent(ctx, response, originalRequest, userID, agentContext, correlationID)
+		return e.handleAgentEvent(ctx, response, originalRequest, userID, agentContext, agentResult.PlanID, correlationID)
 	}
 
 	// Extract user response
@@ -239,11 +648,62 @@ If providing final result to user, respond with:
 	return response, nil
 }
 
+// isAgentResponse reports whether a bus message is an agent reply, including error replies
+func isAgentResponse(msg *messaging.Message) bool {
+	return msg.MessageType == messaging.MessageTypeAgentToAI || msg.MessageType == messaging.MessageTypeError
+}
+
+// agentReply converts an agent reply received from the bus into the message the correlation tracker routes
+func agentReply(msg *messaging.Message) *messaging.AgentToAIMessage {
+	return &messaging.AgentToAIMessage{
+		AgentID:       msg.FromID,
+		Content:       msg.Content,
+		CorrelationID: msg.CorrelationID,
+		MessageType:   msg.MessageType,
+		Context:       msg.Metadata,
+	}
+}
+
+// registerAgentRequest registers an event with the correlation tracker and forwards the agent's in-progress updates
+func (e *AIExecutionEngine) registerAgentRequest(ctx context.Context, eventMsg *messaging.AIToAgentMessage, userID string, timeout time.Duration) chan *messaging.AgentToAIMessage {
+	responseChan := e.correlationTracker.RegisterAgentRequest(eventMsg, userID, timeout)
+	e.correlationTracker.SetProgressHandler(eventMsg.CorrelationID, func(update *messaging.AgentToAIMessage) {
+		e.forwardInProgressResponse(ctx, update)
+	})
+	return responseChan
+}
+
+// forwardInProgressResponse stores an in-progress agent update and reports it as a progress event
+// The step stays open; the agent's terminal reply completes it
+func (e *AIExecutionEngine) forwardInProgressResponse(ctx context.Context, update *messaging.AgentToAIMessage) {
+	request, ok := e.correlationTracker.GetRequest(update.CorrelationID)
+	if !ok {
+		return
+	}
+
+	// Sequence numbers arrive as float64 once they have crossed the gRPC boundary
+	sequence, _ := update.Context["sequence"].(int)
+	if value, ok := update.Context["sequence"].(float64); ok {
+		sequence = int(value)
+	}
+
+	result := executionDomain.NewAgentResult(request.PlanID, request.StepID, update.AgentID, update.CorrelationID, update.Content)
+	result.MarkInProgress(sequence)
+	// Updates are informational, so one that cannot be stored must not fail the step
+	_ = e.storeAgentResult(ctx, result)
+
+	executionDomain.ReportProgress(ctx, executionDomain.NewProgressEvent(executionDomain.ProgressEventAgentProgress, update.AgentID, update.Content))
+}
+
 // extractSection extracts a section from AI response
+// The value may follow the section label on the same line or on the next line
 func (e *AIExecutionEngine) extractSection(response, section string) string {
 	lines := strings.Split(response, "\n")
 	for i, line := range lines {
-		if strings.Contains(line, section) {
+		if idx := strings.Index(line, section); idx >= 0 {
+			if inline := strings.TrimSpace(line[idx+len(section):]); inline != "" {
+				return inline
+			}
 			if i+1 < len(lines) {
 				return strings.TrimSpace(lines[i+1])
 			}
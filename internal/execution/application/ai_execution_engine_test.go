@@ -0,0 +1,803 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	agentDomain "neuromesh/internal/agent/domain"
+	aiDomain "neuromesh/internal/ai/domain"
+	aiInfrastructure "neuromesh/internal/ai/infrastructure"
+	executionDomain "neuromesh/internal/execution/domain"
+	executionInfrastructure "neuromesh/internal/execution/infrastructure"
+	"neuromesh/internal/graph"
+	"neuromesh/internal/messaging"
+	"neuromesh/internal/orchestrator/infrastructure"
+	planningDomain "neuromesh/internal/planning/domain"
+	"neuromesh/testHelpers"
+)
+
+// MockAIProvider is a testify mock for the AI provider
+type MockAIProvider struct {
+	mock.Mock
+}
+
+func (m *MockAIProvider) CallAI(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	args := m.Called(ctx, systemPrompt, userPrompt)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockAIProvider) CallAIWithOptions(ctx context.Context, systemPrompt, userPrompt string, opts aiDomain.CallOptions) (string, error) {
+	return m.CallAI(ctx, systemPrompt, userPrompt)
+}
+
+func (m *MockAIProvider) CallAIWithUsage(ctx context.Context, systemPrompt, userPrompt string, opts aiDomain.CallOptions) (string, aiDomain.Usage, error) {
+	response, err := m.CallAI(ctx, systemPrompt, userPrompt)
+	return response, aiDomain.Usage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}, err
+}
+
+func (m *MockAIProvider) CallAIStream(ctx context.Context, systemPrompt, userPrompt string) (<-chan string, error) {
+	response, err := m.CallAI(ctx, systemPrompt, userPrompt)
+	if err != nil {
+		return nil, err
+	}
+	chunks := make(chan string, 1)
+	chunks <- response
+	close(chunks)
+	return chunks, nil
+}
+
+func (m *MockAIProvider) GetProviderInfo() *aiDomain.ProviderInfo {
+	return &aiDomain.ProviderInfo{Name: "mock", Model: "mock"}
+}
+
+func (m *MockAIProvider) Close() error {
+	return nil
+}
+
+func TestAIExecutionEngine_ParseBatchEvents(t *testing.T) {
+	engine := NewAIExecutionEngine(nil, nil, nil)
+
+	response := `Running both in parallel.
+SEND_EVENTS:
+Agent: text-processor
+Action: count-words
+Content: Count the words in "hello world"
+Intent: analysis
+Step: step-1
+Agent:
+image-processor
+Action: resize
+Content: Resize the banner
+Intent: transformation`
+
+	events := engine.parseBatchEvents(response)
+
+	require.Len(t, events, 2)
+	assert.Equal(t, agentEvent{
+		AgentID: "text-processor",
+		Action:  "count-words",
+		Content: `Count the words in "hello world"`,
+		Intent:  "analysis",
+		StepID:  "step-1",
+	}, events[0])
+	assert.Equal(t, "image-processor", events[1].AgentID)
+	assert.Equal(t, "resize", events[1].Action)
+	assert.Equal(t, "", events[1].StepID)
+}
+
+func TestAIExecutionEngine_BatchFanOut_OutOfOrderResponses(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	aiProvider := &MockAIProvider{}
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	tracker := infrastructure.NewCorrelationTracker()
+	engine := NewAIExecutionEngine(aiProvider, aiMessageBus, tracker)
+
+	batchDirective := `SEND_EVENTS:
+Agent: agent-a
+Action: analyze
+Content: analyze input
+Intent: analysis
+Agent: agent-b
+Action: summarize
+Content: summarize input
+Intent: summary`
+
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Execute plan for user request: process the document").
+		Return(batchDirective, nil).Once()
+
+	var synthesisPrompt string
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Synthesize the agent responses and determine next execution step.").
+		Run(func(args mock.Arguments) { synthesisPrompt = args.String(1) }).
+		Return("USER_RESPONSE:\nBoth agents finished", nil).Once()
+
+	responses := make(chan *messaging.Message, 10)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil).Once()
+
+	sent := make(chan *messaging.AIToAgentMessage, 2)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { sent <- args.Get(1).(*messaging.AIToAgentMessage) }).
+		Return(nil).Twice()
+
+	// Simulate agents replying in the reverse order of dispatch
+	go func() {
+		byAgent := make(map[string]*messaging.AIToAgentMessage)
+		for len(byAgent) < 2 {
+			msg := <-sent
+			byAgent[msg.AgentID] = msg
+		}
+		for _, agentID := range []string{"agent-b", "agent-a"} {
+			responses <- &messaging.Message{
+				CorrelationID: byAgent[agentID].CorrelationID,
+				FromID:        agentID,
+				Content:       "result from " + agentID,
+				MessageType:   messaging.MessageTypeAgentToAI,
+			}
+		}
+	}()
+
+	result, err := engine.ExecuteWithAgents(ctx, "plan-123", "process the document", "user-1", "Available agents: agent-a, agent-b")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Both agents finished", result)
+	assert.Contains(t, synthesisPrompt, "Agent agent-a (action: analyze) responded: result from agent-a")
+	assert.Contains(t, synthesisPrompt, "Agent agent-b (action: summarize) responded: result from agent-b")
+	aiProvider.AssertExpectations(t)
+	aiMessageBus.AssertExpectations(t)
+}
+
+func TestAIExecutionEngine_BatchFanOut_PartialTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	aiProvider := &MockAIProvider{}
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	engine := NewAIExecutionEngine(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker())
+
+	batchDirective := `SEND_EVENTS:
+Agent: agent-a
+Action: analyze
+Content: analyze input
+Agent: agent-b
+Action: summarize
+Content: summarize input`
+
+	var synthesisPrompt string
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Synthesize the agent responses and determine next execution step.").
+		Run(func(args mock.Arguments) { synthesisPrompt = args.String(1) }).
+		Return("USER_RESPONSE:\nPartial result", nil).Once()
+
+	responses := make(chan *messaging.Message, 10)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil).Once()
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := args.Get(1).(*messaging.AIToAgentMessage)
+			// Only agent-a answers; agent-b must time out on its own deadline
+			msg.Timeout = 100 * time.Millisecond
+			if msg.AgentID == "agent-a" {
+				responses <- &messaging.Message{
+					CorrelationID: msg.CorrelationID,
+					FromID:        msg.AgentID,
+					Content:       "done",
+					MessageType:   messaging.MessageTypeAgentToAI,
+				}
+			}
+		}).
+		Return(nil).Twice()
+
+	result, err := engine.handleBatchAgentEvents(ctx, batchDirective, "process", "user-1", "", "plan-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Partial result", result)
+	assert.Contains(t, synthesisPrompt, "Agent agent-a (action: analyze) responded: done")
+	assert.Contains(t, synthesisPrompt, "Agent agent-b (action: summarize) FAILED: timeout")
+}
+
+func TestAIExecutionEngine_BatchFanOut_SendsStepParameters(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	plan := planningDomain.NewExecutionPlan("Text analysis", "Analyze and summarize", planningDomain.ExecutionPlanPriorityMedium)
+	analyze := planningDomain.NewExecutionStep("Analyze", "Analyze the text", "agent-a")
+	require.NoError(t, analyze.SetInputs(map[string]interface{}{"text": "hello world"}))
+	plan.AddStep(analyze)
+
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	require.NoError(t, planRepo.Create(ctx, plan))
+
+	aiProvider := &MockAIProvider{}
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	engine := NewAIExecutionEngineWithRepository(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker(), planRepo)
+
+	batchDirective := `SEND_EVENTS:
+Agent: agent-a
+Action: analyze
+Content: analyze input
+Intent: analysis
+Step: ` + analyze.ID + `
+Agent: agent-b
+Action: summarize
+Content: summarize input
+Intent: summary`
+
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Execute plan for user request: process the document").
+		Return(batchDirective, nil).Once()
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Synthesize the agent responses and determine next execution step.").
+		Return("USER_RESPONSE:\nBoth agents finished", nil).Once()
+
+	responses := make(chan *messaging.Message, 10)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil).Once()
+
+	sent := make(chan *messaging.AIToAgentMessage, 2)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := args.Get(1).(*messaging.AIToAgentMessage)
+			sent <- msg
+			responses <- &messaging.Message{
+				CorrelationID: msg.CorrelationID,
+				FromID:        msg.AgentID,
+				Content:       "result from " + msg.AgentID,
+				MessageType:   messaging.MessageTypeAgentToAI,
+			}
+		}).
+		Return(nil).Twice()
+
+	_, err := engine.ExecuteWithAgents(ctx, plan.ID, "process the document", "user-1", "Available agents: agent-a, agent-b")
+	require.NoError(t, err)
+	close(sent)
+
+	byAgent := make(map[string]*messaging.AIToAgentMessage)
+	for msg := range sent {
+		byAgent[msg.AgentID] = msg
+	}
+	require.Len(t, byAgent, 2)
+	assert.Equal(t, map[string]interface{}{"text": "hello world"}, byAgent["agent-a"].Parameters)
+	assert.Equal(t, analyze.ID, byAgent["agent-a"].Context["step_id"])
+	assert.Equal(t, 2, byAgent["agent-a"].Context["batch_size"])
+	assert.Nil(t, byAgent["agent-b"].Parameters)
+}
+
+func TestAIExecutionEngine_RetriesFailedStepWithBackoff(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	plan := planningDomain.NewExecutionPlan("Word count", "Count words", planningDomain.ExecutionPlanPriorityMedium)
+	step := planningDomain.NewExecutionStep("Count words", "Count words in text", "text-processor")
+	plan.AddStep(step)
+
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	require.NoError(t, planRepo.Create(ctx, plan))
+
+	aiProvider := &MockAIProvider{}
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	engine := NewAIExecutionEngineWithRepository(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker(), planRepo)
+	engine.retryBaseDelay = time.Millisecond
+
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Execute plan for user request: count words").
+		Return("SEND_EVENT:\nAgent: text-processor\nAction: word-count\nContent: Count words in \"hello world\"\nIntent: analysis\nStep: "+step.ID, nil).Once()
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Process the agent response and determine next execution step.").
+		Return("USER_RESPONSE:\nThe text contains 2 words", nil).Once()
+
+	responses := make(chan *messaging.Message, 10)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil)
+
+	attempts := 0
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := args.Get(1).(*messaging.AIToAgentMessage)
+			attempts++
+
+			reply := &messaging.Message{
+				CorrelationID: msg.CorrelationID,
+				FromID:        msg.AgentID,
+				MessageType:   messaging.MessageTypeAgentToAI,
+			}
+			if attempts <= 2 {
+				reply.Content = "temporary failure"
+				reply.Metadata = map[string]interface{}{"success": false, "error": "model unavailable"}
+			} else {
+				reply.Content = "The text contains 2 words."
+			}
+			responses <- reply
+		}).
+		Return(nil)
+
+	result, err := engine.ExecuteWithAgents(ctx, plan.ID, "count words", "user-1", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "The text contains 2 words", result)
+	assert.Equal(t, 3, attempts)
+
+	persisted, err := planRepo.GetStepByID(ctx, step.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, persisted.RetryCount)
+	aiProvider.AssertExpectations(t)
+}
+
+func TestAIExecutionEngine_BatchFanOut_RetriesFailedEvent(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	aiProvider := &MockAIProvider{}
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	engine := NewAIExecutionEngine(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker())
+	engine.retryBaseDelay = time.Millisecond
+
+	batchDirective := `SEND_EVENTS:
+Agent: agent-a
+Action: analyze
+Content: analyze input
+Intent: analysis
+Agent: agent-b
+Action: summarize
+Content: summarize input
+Intent: summary`
+
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Execute plan for user request: process the document").
+		Return(batchDirective, nil).Once()
+	var synthesisPrompt string
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Synthesize the agent responses and determine next execution step.").
+		Run(func(args mock.Arguments) { synthesisPrompt = args.String(1) }).
+		Return("USER_RESPONSE:\nBoth agents finished", nil).Once()
+
+	responses := make(chan *messaging.Message, 10)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil).Once()
+
+	var mu sync.Mutex
+	attempts := make(map[string][]*messaging.AIToAgentMessage)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := args.Get(1).(*messaging.AIToAgentMessage)
+			mu.Lock()
+			attempts[msg.AgentID] = append(attempts[msg.AgentID], msg)
+			first := len(attempts[msg.AgentID]) == 1
+			mu.Unlock()
+
+			reply := &messaging.Message{
+				CorrelationID: msg.CorrelationID,
+				FromID:        msg.AgentID,
+				Content:       "result from " + msg.AgentID,
+				MessageType:   messaging.MessageTypeAgentToAI,
+			}
+			if msg.AgentID == "agent-a" && first {
+				reply.Metadata = map[string]interface{}{"success": false, "error": "model unavailable"}
+			}
+			responses <- reply
+		}).
+		Return(nil)
+
+	result, err := engine.ExecuteWithAgents(ctx, "plan-123", "process the document", "user-1", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Both agents finished", result)
+	assert.Contains(t, synthesisPrompt, "Agent agent-a (action: analyze) responded: result from agent-a")
+
+	require.Len(t, attempts["agent-a"], 2)
+	assert.Len(t, attempts["agent-b"], 1)
+	assert.NotEqual(t, attempts["agent-a"][0].CorrelationID, attempts["agent-a"][1].CorrelationID)
+	assert.Equal(t, 1, attempts["agent-a"][1].Context["retry_count"])
+}
+
+func TestAIExecutionEngine_FailurePropagatesAfterRetriesExhausted(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	plan := planningDomain.NewExecutionPlan("Word count", "Count words", planningDomain.ExecutionPlanPriorityMedium)
+	step := planningDomain.NewExecutionStep("Count words", "Count words in text", "text-processor")
+	step.MaxRetries = 1
+	plan.AddStep(step)
+
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	require.NoError(t, planRepo.Create(ctx, plan))
+
+	aiProvider := &MockAIProvider{}
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	engine := NewAIExecutionEngineWithRepository(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker(), planRepo)
+	engine.retryBaseDelay = time.Millisecond
+
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, mock.Anything).
+		Return("SEND_EVENT:\nAgent: text-processor\nAction: word-count\nContent: Count words\nIntent: analysis\nStep: "+step.ID, nil).Once()
+
+	responses := make(chan *messaging.Message, 10)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := args.Get(1).(*messaging.AIToAgentMessage)
+			responses <- &messaging.Message{
+				CorrelationID: msg.CorrelationID,
+				FromID:        msg.AgentID,
+				Content:       "model unavailable",
+				MessageType:   messaging.MessageTypeError,
+			}
+		}).
+		Return(nil).Twice()
+
+	_, err := engine.ExecuteWithAgents(ctx, plan.ID, "count words", "user-1", "")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed after 1 retries: model unavailable")
+
+	persisted, err := planRepo.GetStepByID(ctx, step.ID)
+	require.NoError(t, err)
+	assert.Equal(t, planningDomain.ExecutionStepStatusFailed, persisted.Status)
+	assert.Equal(t, 1, persisted.RetryCount)
+	aiMessageBus.AssertExpectations(t)
+}
+
+func TestAIExecutionEngine_ReportsProgressForTwoStepPlan(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	aiProvider := &MockAIProvider{}
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	engine := NewAIExecutionEngine(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker())
+
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Execute plan for user request: count and translate").
+		Return("SEND_EVENT:\nAgent: text-processor\nAction: word-count\nContent: Count words\nIntent: analysis", nil).Once()
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Process the agent response and determine next execution step.").
+		Return("SEND_EVENT:\nAgent: translator\nAction: translate\nContent: Translate to Danish\nIntent: translation", nil).Once()
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Process the agent response and determine next execution step.").
+		Return("USER_RESPONSE:\n2 words, Danish: Hej verden", nil).Once()
+
+	responses := make(chan *messaging.Message, 10)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := args.Get(1).(*messaging.AIToAgentMessage)
+			responses <- &messaging.Message{
+				CorrelationID: msg.CorrelationID,
+				FromID:        msg.AgentID,
+				Content:       "done by " + msg.AgentID,
+				MessageType:   messaging.MessageTypeAgentToAI,
+			}
+		}).
+		Return(nil).Twice()
+
+	var events []executionDomain.ProgressEvent
+	ctx = executionDomain.WithProgressReporter(ctx, func(event executionDomain.ProgressEvent) {
+		events = append(events, event)
+	})
+
+	result, err := engine.ExecuteWithAgents(ctx, "plan-123", "count and translate", "user-1", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "2 words, Danish: Hej verden", result)
+
+	require.Len(t, events, 4)
+	assert.Equal(t, executionDomain.ProgressEventAgentEventSent, events[0].Type)
+	assert.Equal(t, "text-processor", events[0].AgentID)
+	assert.Equal(t, executionDomain.ProgressEventAgentResponded, events[1].Type)
+	assert.Equal(t, "done by text-processor", events[1].Message)
+	assert.Equal(t, executionDomain.ProgressEventAgentEventSent, events[2].Type)
+	assert.Equal(t, "translator", events[2].AgentID)
+	assert.Equal(t, executionDomain.ProgressEventAgentResponded, events[3].Type)
+	assert.Equal(t, "done by translator", events[3].Message)
+}
+
+func TestAIExecutionEngine_ForwardsInProgressResultsUntilTerminal(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	plan := planningDomain.NewExecutionPlan("Deploy", "Deploy services", planningDomain.ExecutionPlanPriorityMedium)
+	step := planningDomain.NewExecutionStep("Deploy services", "Deploy all services", "deployer")
+	plan.AddStep(step)
+
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	require.NoError(t, planRepo.Create(ctx, plan))
+	resultRepo := executionInfrastructure.NewGraphAgentResultRepository(testHelpers.NewCleanMockGraph())
+
+	aiProvider := &MockAIProvider{}
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	engine := NewAIExecutionEngineWithRepository(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker(), planRepo)
+	engine.SetAgentResultRepository(resultRepo)
+
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Execute plan for user request: deploy").
+		Return("SEND_EVENT:\nAgent: deployer\nAction: deploy\nContent: Deploy all services\nIntent: deployment\nStep: "+step.ID, nil).Once()
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Process the agent response and determine next execution step.").
+		Return("USER_RESPONSE:\nAll services deployed", nil).Once()
+
+	responses := make(chan *messaging.Message, 10)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil)
+	aiMessageBus.On("PublishAgentCompletedEvent", mock.Anything, mock.Anything).Return(nil)
+	aiMessageBus.On("PublishPlanCompletedEvent", mock.Anything, mock.Anything).Return(nil)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := args.Get(1).(*messaging.AIToAgentMessage)
+			for deployed := 1; deployed <= 3; deployed++ {
+				responses <- &messaging.Message{
+					CorrelationID: msg.CorrelationID,
+					FromID:        msg.AgentID,
+					Content:       fmt.Sprintf("deployed %d/5 services", deployed),
+					MessageType:   messaging.MessageTypeAgentToAI,
+					Metadata:      map[string]interface{}{"status": "IN_PROGRESS", "sequence": float64(deployed)},
+				}
+			}
+			responses <- &messaging.Message{
+				CorrelationID: msg.CorrelationID,
+				FromID:        msg.AgentID,
+				Content:       "deployed 5/5 services",
+				MessageType:   messaging.MessageTypeAgentToAI,
+			}
+		}).
+		Return(nil).Once()
+
+	var events []executionDomain.ProgressEvent
+	ctx = executionDomain.WithProgressReporter(ctx, func(event executionDomain.ProgressEvent) {
+		events = append(events, event)
+	})
+
+	result, err := engine.ExecuteWithAgents(ctx, plan.ID, "deploy", "user-1", "")
+	require.NoError(t, err)
+	assert.Equal(t, "All services deployed", result)
+
+	// Updates are forwarded in order, and only the terminal result answers the event
+	require.Len(t, events, 5)
+	assert.Equal(t, executionDomain.ProgressEventAgentEventSent, events[0].Type)
+	for i, event := range events[1:4] {
+		assert.Equal(t, executionDomain.ProgressEventAgentProgress, event.Type)
+		assert.Equal(t, fmt.Sprintf("deployed %d/5 services", i+1), event.Message)
+	}
+	assert.Equal(t, executionDomain.ProgressEventAgentResponded, events[4].Type)
+	assert.Equal(t, "deployed 5/5 services", events[4].Message)
+
+	stored, err := resultRepo.GetAgentResultsByExecutionPlan(ctx, plan.ID)
+	require.NoError(t, err)
+	require.Len(t, stored, 4)
+	for i, update := range stored[:3] {
+		assert.Equal(t, executionDomain.AgentResultStatusInProgress, update.Status)
+		assert.Equal(t, i+1, update.Sequence)
+		assert.Equal(t, step.ID, update.StepID)
+	}
+	assert.Equal(t, executionDomain.AgentResultStatusSuccess, stored[3].Status)
+
+	persisted, err := planRepo.GetStepByID(ctx, step.ID)
+	require.NoError(t, err)
+	assert.Equal(t, planningDomain.ExecutionStepStatusCompleted, persisted.Status)
+	assert.Equal(t, "deployed 5/5 services", persisted.OutputContent())
+	aiMessageBus.AssertNumberOfCalls(t, "PublishAgentCompletedEvent", 1)
+}
+
+func TestAIExecutionEngine_AccumulatesTokenUsage(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	aiProvider := &MockAIProvider{}
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	engine := NewAIExecutionEngine(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker())
+
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Execute plan for user request: count words").
+		Return("SEND_EVENT:\nAgent: text-processor\nAction: word-count\nContent: Count words\nIntent: analysis", nil).Once()
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Process the agent response and determine next execution step.").
+		Return("USER_RESPONSE:\n2 words", nil).Once()
+
+	responses := make(chan *messaging.Message, 10)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := args.Get(1).(*messaging.AIToAgentMessage)
+			responses <- &messaging.Message{
+				CorrelationID: msg.CorrelationID,
+				FromID:        msg.AgentID,
+				Content:       "2",
+				MessageType:   messaging.MessageTypeAgentToAI,
+			}
+		}).
+		Return(nil).Once()
+
+	recorder := aiDomain.NewUsageRecorder()
+	result, err := engine.ExecuteWithAgents(aiDomain.WithUsageRecorder(ctx, recorder), "plan-123", "count words", "user-1", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "2 words", result)
+	// Two AI calls at 10 prompt + 5 completion tokens each
+	assert.Equal(t, aiDomain.Usage{PromptTokens: 20, CompletionTokens: 10, TotalTokens: 30}, recorder.Total())
+}
+
+func TestAIExecutionEngine_ScriptedProviderFlow(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	aiProvider := aiInfrastructure.NewScriptBuilder().
+		OnSendEvent("Execute plan for user request", "text-processor", "word-count", "Count words", "analysis").
+		OnUserResponse("Process the agent response", "The text has 2 words").
+		Build()
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	engine := NewAIExecutionEngine(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker())
+
+	responses := make(chan *messaging.Message, 10)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := args.Get(1).(*messaging.AIToAgentMessage)
+			responses <- &messaging.Message{
+				CorrelationID: msg.CorrelationID,
+				FromID:        msg.AgentID,
+				Content:       "2",
+				MessageType:   messaging.MessageTypeAgentToAI,
+			}
+		}).
+		Return(nil).Once()
+
+	result, err := engine.ExecuteWithAgents(ctx, "plan-123", "count words in hello world", "user-1", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "The text has 2 words", result)
+	assert.Len(t, aiProvider.Calls(), 2)
+	aiMessageBus.AssertExpectations(t)
+}
+
+func TestAIExecutionEngine_DeadLettersEventForUnknownAgent(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	aiProvider := &MockAIProvider{}
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	agentRegistry := testHelpers.NewMockRegistry()
+	engine := NewAIExecutionEngine(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker())
+	engine.SetAgentDirectory(agentRegistry)
+
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Execute plan for user request: count words").
+		Return("SEND_EVENT:\nAgent: ghost-agent\nAction: word-count\nContent: Count words\nIntent: analysis", nil).Once()
+	agentRegistry.On("GetAgent", mock.Anything, "ghost-agent").Return(nil, fmt.Errorf("agent not found: %w", graph.ErrNodeNotFound))
+	agentRegistry.On("GetAgentsByCapability", mock.Anything, "word-count").Return([]*agentDomain.Agent{}, nil)
+
+	start := time.Now()
+	result, err := engine.ExecuteWithAgents(ctx, "plan-1", "count words", "user-1", "")
+
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Contains(t, result, "agent ghost-agent is not registered")
+	aiMessageBus.AssertNotCalled(t, "SendToAgent", mock.Anything, mock.Anything)
+}
+
+func TestAIExecutionEngine_RejectsStepMissingRequiredInput(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	plan := planningDomain.NewExecutionPlan("Word count", "Count words", planningDomain.ExecutionPlanPriorityMedium)
+	step := planningDomain.NewExecutionStep("Count words", "Count words in text", "text-processor")
+	require.NoError(t, step.SetInputs(map[string]interface{}{"language": "en"}))
+	plan.AddStep(step)
+
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	require.NoError(t, planRepo.Create(ctx, plan))
+
+	aiProvider := &MockAIProvider{}
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	agentRegistry := testHelpers.NewMockRegistry()
+	engine := NewAIExecutionEngineWithRepository(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker(), planRepo)
+	engine.SetAgentDirectory(agentRegistry)
+
+	agent := &agentDomain.Agent{ID: "text-processor", Status: agentDomain.AgentStatusOnline, Capabilities: []agentDomain.AgentCapability{
+		{Name: "word-count", Inputs: []string{"text"}, Parameters: map[string]string{"language": "string"}},
+	}}
+	agentRegistry.On("GetAgent", mock.Anything, "text-processor").Return(agent, nil)
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Execute plan for user request: count words").
+		Return("SEND_EVENT:\nAgent: text-processor\nAction: word-count\nContent: Count words\nIntent: analysis\nStep: "+step.ID, nil).Once()
+
+	_, err := engine.ExecuteWithAgents(ctx, plan.ID, "count words", "user-1", "")
+
+	require.ErrorIs(t, err, agentDomain.ErrMissingInput)
+	assert.Contains(t, err.Error(), "word-count requires text")
+	aiMessageBus.AssertNotCalled(t, "SendToAgent", mock.Anything, mock.Anything)
+}
+
+func TestAIExecutionEngine_ReroutesEventFromOfflineAgent(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	aiProvider := &MockAIProvider{}
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	agentRegistry := testHelpers.NewMockRegistry()
+	engine := NewAIExecutionEngine(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker())
+	engine.SetAgentDirectory(agentRegistry)
+
+	wordCount := agentDomain.AgentCapability{Name: "word-count"}
+	offline := &agentDomain.Agent{ID: "text-processor", Status: agentDomain.AgentStatusOffline, Capabilities: []agentDomain.AgentCapability{wordCount}}
+	standby := &agentDomain.Agent{ID: "text-processor-2", Status: agentDomain.AgentStatusOnline, Capabilities: []agentDomain.AgentCapability{wordCount}}
+
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Execute plan for user request: count words").
+		Return("SEND_EVENT:\nAgent: text-processor\nAction: word-count\nContent: Count words\nIntent: analysis", nil).Once()
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Process the agent response and determine next execution step.").
+		Return("USER_RESPONSE:\nThe text contains 2 words", nil).Once()
+	agentRegistry.On("GetAgent", mock.Anything, "text-processor").Return(offline, nil)
+	agentRegistry.On("GetAgentsByCapability", mock.Anything, "word-count").Return([]*agentDomain.Agent{offline, standby}, nil)
+
+	responses := make(chan *messaging.Message, 1)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.MatchedBy(func(msg *messaging.AIToAgentMessage) bool {
+		return msg.AgentID == "text-processor-2"
+	})).Run(func(args mock.Arguments) {
+		msg := args.Get(1).(*messaging.AIToAgentMessage)
+		responses <- &messaging.Message{
+			CorrelationID: msg.CorrelationID,
+			FromID:        msg.AgentID,
+			Content:       "The text contains 2 words.",
+			MessageType:   messaging.MessageTypeAgentToAI,
+		}
+	}).Return(nil).Once()
+
+	result, err := engine.ExecuteWithAgents(ctx, "plan-1", "count words", "user-1", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "The text contains 2 words", result)
+	aiMessageBus.AssertExpectations(t)
+}
+
+// capabilityTimeoutEngine sets up an engine whose only agent declares the given timeout for word-count
+// The agent replies after the given delay
+func capabilityTimeoutEngine(timeoutSeconds int, replyAfter time.Duration) (*AIExecutionEngine, *testHelpers.MockAIMessageBus) {
+	aiProvider := &MockAIProvider{}
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	agentRegistry := testHelpers.NewMockRegistry()
+	engine := NewAIExecutionEngine(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker())
+	engine.SetAgentDirectory(agentRegistry)
+
+	agent := &agentDomain.Agent{ID: "text-processor", Status: agentDomain.AgentStatusOnline, Capabilities: []agentDomain.AgentCapability{
+		{Name: "word-count", TimeoutSeconds: timeoutSeconds},
+	}}
+	agentRegistry.On("GetAgent", mock.Anything, "text-processor").Return(agent, nil)
+
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Execute plan for user request: count words").
+		Return("SEND_EVENT:\nAgent: text-processor\nAction: word-count\nContent: Count words\nIntent: analysis", nil).Once()
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Process the agent response and determine next execution step.").
+		Return("USER_RESPONSE:\nThe text contains 2 words", nil).Maybe()
+
+	responses := make(chan *messaging.Message, 1)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		msg := args.Get(1).(*messaging.AIToAgentMessage)
+		time.AfterFunc(replyAfter, func() {
+			responses <- &messaging.Message{
+				CorrelationID: msg.CorrelationID,
+				FromID:        msg.AgentID,
+				Content:       "The text contains 2 words.",
+				MessageType:   messaging.MessageTypeAgentToAI,
+			}
+		})
+	}).Return(nil).Once()
+
+	return engine, aiMessageBus
+}
+
+func TestAIExecutionEngine_FastCapabilityTimesOutOnItsOwnDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The agent never answers within the capability's one second
+	engine, aiMessageBus := capabilityTimeoutEngine(1, 5*time.Second)
+
+	start := time.Now()
+	_, err := engine.ExecuteWithAgents(ctx, "plan-1", "count words", "user-1", "")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timeout waiting for agent execution response")
+	assert.Less(t, time.Since(start), 3*time.Second, "the default event timeout was used instead of the capability's")
+	for _, call := range aiMessageBus.Calls {
+		if call.Method == "SendToAgent" {
+			assert.Equal(t, time.Second, call.Arguments.Get(1).(*messaging.AIToAgentMessage).Timeout)
+		}
+	}
+}
+
+func TestAIExecutionEngine_SlowCapabilityIsGivenItsDeclaredTime(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The reply arrives after a fast capability would have given up
+	engine, aiMessageBus := capabilityTimeoutEngine(3, 1500*time.Millisecond)
+
+	result, err := engine.ExecuteWithAgents(ctx, "plan-1", "count words", "user-1", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "The text contains 2 words", result)
+	aiMessageBus.AssertExpectations(t)
+}
@@ -0,0 +1,362 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	executionDomain "neuromesh/internal/execution/domain"
+	"neuromesh/internal/messaging"
+	"neuromesh/internal/tracing"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// agentEvent represents a single agent instruction parsed from a SEND_EVENTS: directive
+type agentEvent struct {
+	AgentID string
+	Action  string
+	Content string
+	Intent  string
+	StepID  string
+}
+
+// batchEventOutcome holds the result of one agent event dispatched as part of a batch
+type batchEventOutcome struct {
+	event  agentEvent
+	result *executionDomain.AgentResult
+	err    error
+}
+
+// handleBatchAgentEvents dispatches a SEND_EVENTS: directive to all listed agents concurrently,
+// waits for every correlated response and hands the aggregated results back to the AI
+func (e *AIExecutionEngine) handleBatchAgentEvents(ctx context.Context, aiResponse, originalRequest, userID, agentContext, planID string) (string, error) {
+	events := e.parseBatchEvents(aiResponse)
+	if len(events) == 0 {
+		return "", fmt.Errorf("no agent events found in %s directive", BatchEventPrefix)
+	}
+
+	// A single subscription serves the whole batch, including retries
+	responseChannel, err := e.aiMessageBus.Subscribe(ctx, "ai-execution")
+	if err != nil {
+		return "", fmt.Errorf("failed to subscribe for execution agent responses: %w", err)
+	}
+	pending := newPendingCorrelations()
+	done := make(chan struct{})
+	go e.routeBatchResponses(ctx, responseChannel, pending, done)
+
+	outcomes := make([]batchEventOutcome, len(events))
+	var wg sync.WaitGroup
+	for i := range events {
+		// Unknown or offline agents are rerouted or fail fast instead of waiting for the timeout
+		agentID, timeout, err := e.resolveAgent(ctx, events[i].AgentID, events[i].Action)
+		if err != nil {
+			outcomes[i] = batchEventOutcome{event: events[i], err: err}
+			continue
+		}
+		events[i].AgentID = agentID
+		if err := e.validateStepInputs(ctx, events[i]); err != nil {
+			outcomes[i] = batchEventOutcome{event: events[i], err: err}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, timeout time.Duration) {
+			defer wg.Done()
+			outcomes[i] = e.dispatchParallelEvent(ctx, events[i], timeout, originalRequest, userID, planID, pending, map[string]interface{}{"batch_size": len(events)})
+		}(i, timeout)
+	}
+	wg.Wait()
+	close(done)
+
+	succeeded := 0
+	for _, outcome := range outcomes {
+		if outcome.err == nil {
+			succeeded++
+		}
+	}
+	if succeeded == 0 {
+		return "", fmt.Errorf("no agent responses received for batch execution: %w", outcomes[0].err)
+	}
+
+	// Persist results in dispatch order; completing the plan's last step publishes the plan-completed event
+	for _, outcome := range outcomes {
+		if outcome.result == nil {
+			continue
+		}
+		if outcome.err != nil {
+			if err := e.storeAgentResult(ctx, outcome.result); err != nil {
+				return "", err
+			}
+			continue
+		}
+		if err := e.recordAgentResult(ctx, outcome.result, userID); err != nil {
+			return "", err
+		}
+	}
+
+	return e.processBatchExecutionResponses(ctx, outcomes, originalRequest, userID, agentContext, planID)
+}
+
+// pendingCorrelations is the set of correlation IDs awaiting a response, shared by dispatchers and the response router
+type pendingCorrelations struct {
+	mu  sync.Mutex
+	ids map[string]struct{}
+}
+
+func newPendingCorrelations() *pendingCorrelations {
+	return &pendingCorrelations{ids: make(map[string]struct{})}
+}
+
+func (p *pendingCorrelations) add(correlationID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ids[correlationID] = struct{}{}
+}
+
+func (p *pendingCorrelations) contains(correlationID string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, exists := p.ids[correlationID]
+	return exists
+}
+
+func (p *pendingCorrelations) remove(correlationID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.ids, correlationID)
+}
+
+// routeBatchResponses routes responses for the pending correlation IDs through the correlation tracker until done is closed
+func (e *AIExecutionEngine) routeBatchResponses(ctx context.Context, responseChannel <-chan *messaging.Message, pending *pendingCorrelations, done <-chan struct{}) {
+	for {
+		select {
+		case msg, ok := <-responseChannel:
+			if !ok {
+				return
+			}
+			if msg == nil || !isAgentResponse(msg) {
+				continue
+			}
+			if !pending.contains(msg.CorrelationID) {
+				continue
+			}
+
+			reply := agentReply(msg)
+			e.correlationTracker.RouteResponse(reply)
+			// In-progress updates leave the event waiting for its terminal response
+			if !reply.IsInProgress() {
+				pending.remove(msg.CorrelationID)
+			}
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// dispatchParallelEvent sends one event of a batch or step graph through the retry wrapper and waits for its final result
+// Every attempt is registered with the shared response router before it is dispatched so no early response is lost
+func (e *AIExecutionEngine) dispatchParallelEvent(ctx context.Context, event agentEvent, timeout time.Duration, originalRequest, userID, planID string, pending *pendingCorrelations, extraContext map[string]interface{}) batchEventOutcome {
+	correlationID := fmt.Sprintf("exec-%s-%s", userID, uuid.New().String())
+	result, retries, err := e.dispatchWithRetry(ctx, event.StepID, userID, correlationID, func(correlationID string, retries int) (*executionDomain.AgentResult, error) {
+		msg := e.newAgentInstruction(ctx, event, correlationID, originalRequest, userID, planID, timeout)
+		for key, value := range extraContext {
+			msg.Context[key] = value
+		}
+		msg.Context["retry_count"] = retries
+
+		responseChan := e.registerAgentRequest(ctx, msg, userID, timeout)
+		pending.add(correlationID)
+
+		outcome := e.dispatchAndWait(ctx, event, msg, responseChan, planID)
+		return outcome.result, outcome.err
+	})
+
+	outcome := batchEventOutcome{event: event, result: result, err: err}
+	if err == nil && result.IsFailed() {
+		outcome.err = fmt.Errorf("agent %s failed after %d retries: %s", event.AgentID, retries, result.ErrorMessage)
+	}
+	return outcome
+}
+
+// dispatchAndWait sends one attempt of a parallel event and waits for its correlated response with a per-agent timeout
+func (e *AIExecutionEngine) dispatchAndWait(ctx context.Context, event agentEvent, msg *messaging.AIToAgentMessage, responseChan chan *messaging.AgentToAIMessage, planID string) (outcome batchEventOutcome) {
+	outcome.event = event
+
+	ctx, span := tracing.Start(ctx, "execution.agent_event", trace.WithAttributes(
+		attribute.String("agent.id", event.AgentID),
+		attribute.String("step.id", event.StepID),
+		attribute.String("correlation.id", msg.CorrelationID),
+	))
+	defer func() { tracing.End(span, outcome.err) }()
+	tracing.Inject(ctx, msg.Context)
+
+	release, err := e.acquireDispatchSlot(ctx, event.AgentID)
+	if err != nil {
+		e.correlationTracker.CleanupRequest(msg.CorrelationID)
+		outcome.err = fmt.Errorf("failed to acquire dispatch slot for agent %s: %w", event.AgentID, err)
+		return outcome
+	}
+	defer release()
+
+	if err := e.aiMessageBus.SendToAgent(ctx, msg); err != nil {
+		e.correlationTracker.CleanupRequest(msg.CorrelationID)
+		outcome.err = fmt.Errorf("failed to send execution event to agent %s: %w", event.AgentID, err)
+		return outcome
+	}
+	executionDomain.ReportProgress(ctx, executionDomain.NewProgressEvent(executionDomain.ProgressEventAgentEventSent, event.AgentID, event.Content))
+
+	timeout := msg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultEventTimeout
+	}
+
+	select {
+	case response := <-responseChan:
+		if response == nil && e.correlationTracker.IsCancelled(msg.CorrelationID) {
+			outcome.err = fmt.Errorf("%w: correlation %s", ErrExecutionCancelled, msg.CorrelationID)
+			return outcome
+		}
+		if response == nil {
+			outcome.err = fmt.Errorf("received nil execution response for correlation %s", msg.CorrelationID)
+			return outcome
+		}
+		outcome.result = e.buildAgentResult(planID, event.StepID, msg.CorrelationID, response)
+		executionDomain.ReportProgress(ctx, executionDomain.NewProgressEvent(executionDomain.ProgressEventAgentResponded, event.AgentID, response.Content))
+	case <-ctx.Done():
+		e.correlationTracker.CleanupRequest(msg.CorrelationID)
+		outcome.err = ctx.Err()
+	case <-time.After(timeout):
+		e.correlationTracker.CleanupRequest(msg.CorrelationID)
+		outcome.err = fmt.Errorf("timeout waiting for agent %s execution response (correlation: %s)", event.AgentID, msg.CorrelationID)
+	}
+
+	return outcome
+}
+
+// processBatchExecutionResponses lets AI synthesize the aggregated responses of a parallel batch
+func (e *AIExecutionEngine) processBatchExecutionResponses(ctx context.Context, outcomes []batchEventOutcome, originalRequest, userID, agentContext, planID string) (string, error) {
+	var agentResponses strings.Builder
+	for _, outcome := range outcomes {
+		if outcome.err != nil {
+			agentResponses.WriteString(fmt.Sprintf("- Agent %s (action: %s) FAILED: %v\n", outcome.event.AgentID, outcome.event.Action, outcome.err))
+			continue
+		}
+		agentResponses.WriteString(fmt.Sprintf("- Agent %s (action: %s) responded: %s\n", outcome.result.AgentID, outcome.event.Action, outcome.result.Content))
+	}
+
+	systemPrompt := fmt.Sprintf(`You are an AI execution engine processing responses from agents that worked in parallel during plan execution.
+
+Original user request: %s
+Agent responses:
+%s
+Agent context: %v
+
+Based on the combined agent responses, decide:
+1. Do you need to coordinate with further agents to continue execution?
+2. Can you provide final execution result to user?
+
+If coordinating with another agent, respond with:
+%s
+Agent: [agent-id]
+Action: [specific action]
+Content: [specific instructions for the agent]
+Intent: [high-level goal]
+
+If coordinating with several independent agents at once, respond with %s followed by one block per agent.
+
+If providing final result to user, respond with:
+%s
+[Your synthesized execution result for the user]`, originalRequest, agentResponses.String(), agentContext, EventPrefix, BatchEventPrefix, UserResponsePrefix)
+
+	userPrompt := "Synthesize the agent responses and determine next execution step."
+
+	response, err := e.callAI(ctx, systemPrompt, userPrompt)
+	if err != nil {
+		return "", fmt.Errorf("AI execution processing failed: %w", err)
+	}
+
+	if strings.Contains(response, BatchEventPrefix) {
+		return e.handleBatchAgentEvents(ctx, response, originalRequest, userID, agentContext, planID)
+	}
+
+	if strings.Contains(response, EventPrefix) {
+		correlationID := fmt.Sprintf("exec-%s-%s", userID, uuid.New().String())
+		return e.handleAgentEvent(ctx, response, originalRequest, userID, agentContext, planID, correlationID)
+	}
+
+	if strings.Contains(response, UserResponsePrefix) {
+		return e.extractUserResponse(response), nil
+	}
+
+	return response, nil
+}
+
+// parseBatchEvents splits a SEND_EVENTS: directive into one event per Agent: block
+// Field values may follow the label on the same line or on the next line
+func (e *AIExecutionEngine) parseBatchEvents(response string) []agentEvent {
+	idx := strings.Index(response, BatchEventPrefix)
+	if idx < 0 {
+		return nil
+	}
+
+	lines := strings.Split(response[idx+len(BatchEventPrefix):], "\n")
+	var events []agentEvent
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, UserResponsePrefix) {
+			break
+		}
+
+		label, value, found := strings.Cut(line, ":")
+		label = strings.TrimSpace(label)
+		if !found || !isEventLabel(label) {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if value == "" && i+1 < len(lines) {
+			if nextLabel, _, ok := strings.Cut(lines[i+1], ":"); !ok || !isEventLabel(strings.TrimSpace(nextLabel)) {
+				value = strings.TrimSpace(lines[i+1])
+				i++
+			}
+		}
+
+		if label == "Agent" {
+			events = append(events, agentEvent{AgentID: value})
+			continue
+		}
+		if len(events) == 0 {
+			continue
+		}
+
+		current := &events[len(events)-1]
+		switch label {
+		case "Action":
+			current.Action = value
+		case "Content":
+			current.Content = value
+		case "Intent":
+			current.Intent = value
+		case "Step":
+			current.StepID = value
+		}
+	}
+
+	return events
+}
+
+// isEventLabel reports whether label is one of the fields of an agent event block
+func isEventLabel(label string) bool {
+	switch label {
+	case "Agent", "Action", "Content", "Intent", "Step":
+		return true
+	}
+	return false
+}
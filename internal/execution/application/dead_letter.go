@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	agentDomain "neuromesh/internal/agent/domain"
+	"neuromesh/internal/graph"
+)
+
+// ErrAgentUnavailable is returned when an event targets an agent that is not registered or not online
+var ErrAgentUnavailable = errors.New("agent unavailable")
+
+// AgentDirectory looks up registered agents before events are dispatched to them
+type AgentDirectory interface {
+	GetAgent(ctx context.Context, agentID string) (*agentDomain.Agent, error)
+	GetAgentsByCapability(ctx context.Context, capability string) ([]*agentDomain.Agent, error)
+}
+
+// SetAgentDirectory enables registry checks before dispatch, so events for missing or offline agents
+// are rerouted to an agent with the same capability or dead-lettered instead of timing out
+func (e *AIExecutionEngine) SetAgentDirectory(directory AgentDirectory) {
+	e.agentDirectory = directory
+}
+
+// resolveAgent returns the agent that should receive an event addressed to agentID,
+// along with how long to wait for that agent to complete the action
+// A missing or offline agent is replaced by a reachable agent sharing one of its capabilities,
+// falling back to the event's action as the capability when the agent is not registered
+func (e *AIExecutionEngine) resolveAgent(ctx context.Context, agentID, action string) (string, time.Duration, error) {
+	if e.agentDirectory == nil {
+		return agentID, DefaultEventTimeout, nil
+	}
+
+	agent, err := e.agentDirectory.GetAgent(ctx, agentID)
+	if err != nil && !errors.Is(err, graph.ErrNodeNotFound) {
+		return "", 0, fmt.Errorf("failed to look up agent %s: %w", agentID, err)
+	}
+	if agent != nil && isReachable(agent) {
+		return agentID, capabilityTimeout(agent, action), nil
+	}
+
+	state := "not registered"
+	capabilities := []string{action}
+	if agent != nil {
+		state = string(agent.Status)
+		capabilities = nil
+		for _, capability := range agent.Capabilities {
+			capabilities = append(capabilities, capability.Name)
+		}
+	}
+
+	for _, capability := range capabilities {
+		if capability == "" {
+			continue
+		}
+		candidates, err := e.agentDirectory.GetAgentsByCapability(ctx, capability)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to find alternative agents for capability %s: %w", capability, err)
+		}
+		for _, candidate := range candidates {
+			if candidate.ID != agentID && isReachable(candidate) {
+				return candidate.ID, capabilityTimeout(candidate, action), nil
+			}
+		}
+	}
+
+	return "", 0, fmt.Errorf("%w: agent %s is %s", ErrAgentUnavailable, agentID, state)
+}
+
+// capabilityTimeout returns the timeout the agent declares for the action, or DefaultEventTimeout when it declares none
+func capabilityTimeout(agent *agentDomain.Agent, action string) time.Duration {
+	if capability := agent.GetCapability(action); capability != nil && capability.Timeout() > 0 {
+		return capability.Timeout()
+	}
+	return DefaultEventTimeout
+}
+
+// deadLetterResponse explains to the user why an event could not be delivered
+func (e *AIExecutionEngine) deadLetterResponse(err error) string {
+	return fmt.Sprintf("I couldn't complete this request because %s and no other agent with the same capability is available.", unavailableReason(err))
+}
+
+// unavailableReason strips the sentinel prefix from an ErrAgentUnavailable error
+func unavailableReason(err error) string {
+	return strings.TrimPrefix(err.Error(), ErrAgentUnavailable.Error()+": ")
+}
+
+// isReachable reports whether an agent can currently accept events
+func isReachable(agent *agentDomain.Agent) bool {
+	return agent.Status == agentDomain.AgentStatusOnline || agent.Status == agentDomain.AgentStatusBusy
+}
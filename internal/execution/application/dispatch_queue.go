@@ -0,0 +1,250 @@
+package application
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	executionDomain "neuromesh/internal/execution/domain"
+	planningDomain "neuromesh/internal/planning/domain"
+)
+
+const (
+	// DefaultMaxConcurrentDispatches is the number of agent round-trips that may be in flight at once
+	DefaultMaxConcurrentDispatches = 10
+
+	// DefaultMaxConcurrentDispatchesPerAgent is the number of round-trips that may be in flight to any one agent
+	DefaultMaxConcurrentDispatchesPerAgent = 4
+
+	// DefaultPriorityAgingInterval is how long a waiting step takes to gain one priority level
+	DefaultPriorityAgingInterval = 30 * time.Second
+)
+
+// DispatchQueue limits concurrent agent dispatches and grants free slots by plan priority
+// Waiting steps age, gaining one priority level per aging interval, so low-priority plans cannot starve
+type DispatchQueue struct {
+	mu            sync.Mutex
+	capacity      int
+	active        int
+	agingInterval time.Duration
+	waiters       dispatchWaiters
+	sequence      uint64
+	now           func() time.Time
+}
+
+// NewDispatchQueue creates a dispatch queue allowing capacity concurrent dispatches
+func NewDispatchQueue(capacity int, agingInterval time.Duration) *DispatchQueue {
+	if capacity <= 0 {
+		capacity = DefaultMaxConcurrentDispatches
+	}
+	if agingInterval <= 0 {
+		agingInterval = DefaultPriorityAgingInterval
+	}
+	return &DispatchQueue{
+		capacity:      capacity,
+		agingInterval: agingInterval,
+		now:           time.Now,
+	}
+}
+
+// Acquire waits for a dispatch slot for a step of a plan with the given priority
+// The returned release function frees the slot and must be called once the dispatch is over
+func (q *DispatchQueue) Acquire(ctx context.Context, priority planningDomain.ExecutionPlanPriority) (func(), error) {
+	q.mu.Lock()
+	if q.active < q.capacity && q.waiters.Len() == 0 {
+		q.active++
+		q.mu.Unlock()
+		return q.releaseOnce(), nil
+	}
+
+	// Aging by one level per interval is the same as arriving one interval earlier per level,
+	// so the effective arrival time orders waiters without re-sorting them as they age
+	q.sequence++
+	waiter := &dispatchWaiter{
+		readyAt:  q.now().Add(-time.Duration(priority.Rank()) * q.agingInterval),
+		sequence: q.sequence,
+		granted:  make(chan struct{}),
+	}
+	heap.Push(&q.waiters, waiter)
+	q.mu.Unlock()
+
+	select {
+	case <-waiter.granted:
+		return q.releaseOnce(), nil
+	case <-ctx.Done():
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		if waiter.index >= 0 {
+			heap.Remove(&q.waiters, waiter.index)
+			return nil, ctx.Err()
+		}
+		// The slot was granted while the context was being cancelled; hand it on
+		q.release()
+		return nil, ctx.Err()
+	}
+}
+
+// Waiting returns the number of steps waiting for a dispatch slot
+func (q *DispatchQueue) Waiting() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.waiters.Len()
+}
+
+// releaseOnce returns a release function that frees the slot at most once
+func (q *DispatchQueue) releaseOnce() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			q.release()
+		})
+	}
+}
+
+// release hands the slot to the first waiter or frees it; q.mu must be held
+func (q *DispatchQueue) release() {
+	if q.waiters.Len() == 0 {
+		q.active--
+		return
+	}
+	waiter := heap.Pop(&q.waiters).(*dispatchWaiter)
+	close(waiter.granted)
+}
+
+// dispatchWaiter is a step waiting for a dispatch slot
+type dispatchWaiter struct {
+	readyAt  time.Time // Arrival time moved earlier by the plan priority
+	sequence uint64    // Keeps arrival order between waiters with the same readyAt
+	granted  chan struct{}
+	index    int // Position in the heap, -1 once removed
+}
+
+// dispatchWaiters is a min-heap of waiters ordered by effective arrival time
+type dispatchWaiters []*dispatchWaiter
+
+func (w dispatchWaiters) Len() int { return len(w) }
+
+func (w dispatchWaiters) Less(i, j int) bool {
+	if w[i].readyAt.Equal(w[j].readyAt) {
+		return w[i].sequence < w[j].sequence
+	}
+	return w[i].readyAt.Before(w[j].readyAt)
+}
+
+func (w dispatchWaiters) Swap(i, j int) {
+	w[i], w[j] = w[j], w[i]
+	w[i].index = i
+	w[j].index = j
+}
+
+func (w *dispatchWaiters) Push(x any) {
+	waiter := x.(*dispatchWaiter)
+	waiter.index = len(*w)
+	*w = append(*w, waiter)
+}
+
+func (w *dispatchWaiters) Pop() any {
+	old := *w
+	waiter := old[len(old)-1]
+	old[len(old)-1] = nil
+	waiter.index = -1
+	*w = old[:len(old)-1]
+	return waiter
+}
+
+// AgentDispatchQueues gives every agent its own dispatch queue, so one busy agent cannot take every slot
+type AgentDispatchQueues struct {
+	mu            sync.Mutex
+	capacity      int
+	agingInterval time.Duration
+	queues        map[string]*DispatchQueue
+}
+
+// NewAgentDispatchQueues creates dispatch queues allowing capacity concurrent dispatches to each agent
+func NewAgentDispatchQueues(capacity int, agingInterval time.Duration) *AgentDispatchQueues {
+	if capacity <= 0 {
+		capacity = DefaultMaxConcurrentDispatchesPerAgent
+	}
+	return &AgentDispatchQueues{
+		capacity:      capacity,
+		agingInterval: agingInterval,
+		queues:        make(map[string]*DispatchQueue),
+	}
+}
+
+// Acquire waits for a dispatch slot of the agent for a step of a plan with the given priority
+func (a *AgentDispatchQueues) Acquire(ctx context.Context, agentID string, priority planningDomain.ExecutionPlanPriority) (func(), error) {
+	return a.queue(agentID).Acquire(ctx, priority)
+}
+
+// queue returns the agent's dispatch queue, creating it on first use
+func (a *AgentDispatchQueues) queue(agentID string) *DispatchQueue {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	queue, exists := a.queues[agentID]
+	if !exists {
+		queue = NewDispatchQueue(a.capacity, a.agingInterval)
+		a.queues[agentID] = queue
+	}
+	return queue
+}
+
+type planPriorityKey struct{}
+
+// withPlanPriority records the priority of the plan being executed for its dispatches
+func withPlanPriority(ctx context.Context, priority planningDomain.ExecutionPlanPriority) context.Context {
+	return context.WithValue(ctx, planPriorityKey{}, priority)
+}
+
+// planPriority returns the priority of the plan being executed, or medium for requests without a plan
+func planPriority(ctx context.Context) planningDomain.ExecutionPlanPriority {
+	if priority, ok := ctx.Value(planPriorityKey{}).(planningDomain.ExecutionPlanPriority); ok && priority.IsValid() {
+		return priority
+	}
+	return planningDomain.ExecutionPlanPriorityMedium
+}
+
+// SetDispatchQueue makes agent dispatches wait for a slot in the queue, ordered by plan priority
+func (e *AIExecutionEngine) SetDispatchQueue(queue *DispatchQueue) {
+	e.dispatchQueue = queue
+}
+
+// SetAgentDispatchQueues also makes agent dispatches wait for a slot of their agent
+func (e *AIExecutionEngine) SetAgentDispatchQueues(queues *AgentDispatchQueues) {
+	e.agentQueues = queues
+}
+
+// acquireDispatchSlot waits for a dispatch slot to the agent, then for one of the shared queue
+// Slots are granted at the priority of the plan being executed; executions that were cancelled get no further slots
+func (e *AIExecutionEngine) acquireDispatchSlot(ctx context.Context, agentID string) (func(), error) {
+	if executionID := executionDomain.ExecutionIDFromContext(ctx); executionID != "" && e.correlationTracker.IsCancelled(executionID) {
+		return nil, fmt.Errorf("%w: execution %s", ErrExecutionCancelled, executionID)
+	}
+
+	priority := planPriority(ctx)
+	releaseAgent := func() {}
+	if e.agentQueues != nil {
+		release, err := e.agentQueues.Acquire(ctx, agentID, priority)
+		if err != nil {
+			return nil, err
+		}
+		releaseAgent = release
+	}
+	if e.dispatchQueue == nil {
+		return releaseAgent, nil
+	}
+
+	release, err := e.dispatchQueue.Acquire(ctx, priority)
+	if err != nil {
+		releaseAgent()
+		return nil, err
+	}
+	return func() {
+		release()
+		releaseAgent()
+	}, nil
+}
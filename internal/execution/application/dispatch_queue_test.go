@@ -0,0 +1,193 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"neuromesh/internal/messaging"
+	"neuromesh/internal/orchestrator/infrastructure"
+	planningDomain "neuromesh/internal/planning/domain"
+	"neuromesh/testHelpers"
+)
+
+// acquireInBackground queues an Acquire call and records its label in order once the slot is granted
+func acquireInBackground(t *testing.T, q *DispatchQueue, priority planningDomain.ExecutionPlanPriority, label string, granted chan<- string) {
+	t.Helper()
+	waiting := q.Waiting()
+	go func() {
+		release, err := q.Acquire(context.Background(), priority)
+		if err != nil {
+			return
+		}
+		granted <- label
+		release()
+	}()
+	require.Eventually(t, func() bool { return q.Waiting() == waiting+1 }, time.Second, time.Millisecond)
+}
+
+func TestDispatchQueue_GrantsHigherPriorityFirst(t *testing.T) {
+	q := NewDispatchQueue(1, time.Hour)
+	release, err := q.Acquire(context.Background(), planningDomain.ExecutionPlanPriorityMedium)
+	require.NoError(t, err)
+
+	granted := make(chan string, 3)
+	acquireInBackground(t, q, planningDomain.ExecutionPlanPriorityLow, "low", granted)
+	acquireInBackground(t, q, planningDomain.ExecutionPlanPriorityHigh, "high", granted)
+	acquireInBackground(t, q, planningDomain.ExecutionPlanPriorityCritical, "critical", granted)
+
+	release()
+	assert.Equal(t, "critical", <-granted)
+	assert.Equal(t, "high", <-granted)
+	assert.Equal(t, "low", <-granted)
+}
+
+func TestDispatchQueue_AgedLowPriorityRuns(t *testing.T) {
+	q := NewDispatchQueue(1, time.Minute)
+	clock := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	q.now = func() time.Time { return clock }
+
+	release, err := q.Acquire(context.Background(), planningDomain.ExecutionPlanPriorityMedium)
+	require.NoError(t, err)
+
+	granted := make(chan string, 4)
+	acquireInBackground(t, q, planningDomain.ExecutionPlanPriorityLow, "low", granted)
+
+	// High-priority plans keep arriving; after three intervals the low-priority step outranks new arrivals
+	clock = clock.Add(time.Minute)
+	acquireInBackground(t, q, planningDomain.ExecutionPlanPriorityHigh, "high-early", granted)
+	clock = clock.Add(2 * time.Minute)
+	acquireInBackground(t, q, planningDomain.ExecutionPlanPriorityHigh, "high-late", granted)
+
+	release()
+	assert.Equal(t, "high-early", <-granted)
+	assert.Equal(t, "low", <-granted)
+	assert.Equal(t, "high-late", <-granted)
+}
+
+func TestDispatchQueue_CancelledWaiterLeavesQueue(t *testing.T) {
+	q := NewDispatchQueue(1, time.Hour)
+	release, err := q.Acquire(context.Background(), planningDomain.ExecutionPlanPriorityMedium)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := q.Acquire(ctx, planningDomain.ExecutionPlanPriorityHigh)
+		done <- err
+	}()
+	require.Eventually(t, func() bool { return q.Waiting() == 1 }, time.Second, time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Equal(t, 0, q.Waiting())
+
+	// The slot is free again once released, not held by the cancelled waiter
+	release()
+	next, err := q.Acquire(context.Background(), planningDomain.ExecutionPlanPriorityLow)
+	require.NoError(t, err)
+	next()
+}
+
+func TestAIExecutionEngine_DispatchesHigherPriorityPlanFirst(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	plans := map[planningDomain.ExecutionPlanPriority]*planningDomain.ExecutionPlan{}
+	for _, priority := range []planningDomain.ExecutionPlanPriority{planningDomain.ExecutionPlanPriorityLow, planningDomain.ExecutionPlanPriorityHigh} {
+		plan := planningDomain.NewExecutionPlan(string(priority)+" plan", "Count words", priority)
+		plan.AddStep(planningDomain.NewExecutionStep("Count words", "Count words in text", "text-processor"))
+		require.NoError(t, planRepo.Create(ctx, plan))
+		plans[priority] = plan
+	}
+
+	aiProvider := &MockAIProvider{}
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	engine := NewAIExecutionEngineWithRepository(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker(), planRepo)
+	queue := NewDispatchQueue(1, time.Hour)
+	engine.SetDispatchQueue(queue)
+
+	for priority, plan := range plans {
+		aiProvider.On("CallAI", mock.Anything, mock.Anything, fmt.Sprintf("Execute plan for user request: %s request", priority)).
+			Return("SEND_EVENT:\nAgent: text-processor\nAction: word-count\nContent: Count words\nIntent: analysis\nStep: "+plan.Steps[0].ID, nil).Once()
+	}
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Process the agent response and determine next execution step.").
+		Return("USER_RESPONSE:\ndone", nil)
+
+	responses := make(chan *messaging.Message, 10)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil)
+
+	var mu sync.Mutex
+	var dispatched []string
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := args.Get(1).(*messaging.AIToAgentMessage)
+			mu.Lock()
+			dispatched = append(dispatched, msg.Context["plan_id"].(string))
+			mu.Unlock()
+			responses <- &messaging.Message{
+				CorrelationID: msg.CorrelationID,
+				FromID:        msg.AgentID,
+				Content:       "2 words",
+				MessageType:   messaging.MessageTypeAgentToAI,
+			}
+		}).
+		Return(nil)
+
+	// Another step holds the only slot, so both plans queue up: the low-priority one first
+	release, err := queue.Acquire(ctx, planningDomain.ExecutionPlanPriorityMedium)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for _, priority := range []planningDomain.ExecutionPlanPriority{planningDomain.ExecutionPlanPriorityLow, planningDomain.ExecutionPlanPriorityHigh} {
+		waiting := queue.Waiting()
+		wg.Add(1)
+		go func(priority planningDomain.ExecutionPlanPriority) {
+			defer wg.Done()
+			_, err := engine.ExecuteWithAgents(ctx, plans[priority].ID, string(priority)+" request", "user-1", "")
+			assert.NoError(t, err)
+		}(priority)
+		require.Eventually(t, func() bool { return queue.Waiting() == waiting+1 }, time.Second, time.Millisecond)
+	}
+
+	release()
+	wg.Wait()
+
+	assert.Equal(t, []string{plans[planningDomain.ExecutionPlanPriorityHigh].ID, plans[planningDomain.ExecutionPlanPriorityLow].ID}, dispatched)
+}
+
+func TestAIExecutionEngine_LimitsDispatchesPerAgent(t *testing.T) {
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	engine := NewAIExecutionEngineWithRepository(&MockAIProvider{}, testHelpers.NewMockAIMessageBus(), infrastructure.NewCorrelationTracker(), planRepo)
+	engine.SetDispatchQueue(NewDispatchQueue(2, time.Hour))
+	engine.SetAgentDispatchQueues(NewAgentDispatchQueues(1, time.Hour))
+	ctx := withPlanPriority(context.Background(), planningDomain.ExecutionPlanPriorityHigh)
+
+	release, err := engine.acquireDispatchSlot(ctx, "text-processor")
+	require.NoError(t, err)
+
+	// Another agent still gets a slot while the busy agent's next dispatch waits
+	other, err := engine.acquireDispatchSlot(ctx, "fetcher")
+	require.NoError(t, err)
+	other()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = engine.acquireDispatchSlot(waitCtx, "text-processor")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	next, err := engine.acquireDispatchSlot(ctx, "text-processor")
+	require.NoError(t, err)
+	next()
+
+	// The plan priority travels in the context, so dispatching never reloads the plan
+	assert.Empty(t, planRepo.GetCalls())
+}
@@ -0,0 +1,298 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"neuromesh/internal/messaging"
+	planningDomain "neuromesh/internal/planning/domain"
+)
+
+// ResumeIntent is the intent of the event re-dispatching a step interrupted by a restart
+const ResumeIntent = "resume"
+
+const (
+	// DefaultRecoveryMaxAge is how long a step may have been stuck before recovery fails it instead of re-dispatching it
+	DefaultRecoveryMaxAge = 15 * time.Minute
+	// DefaultRecoveryResponseTimeout is how long a re-dispatched step waits for its agent
+	DefaultRecoveryResponseTimeout = 5 * time.Minute
+)
+
+// RecoveryPolicy decides what happens to steps left assigned or executing when the orchestrator stopped
+type RecoveryPolicy struct {
+	MaxAge          time.Duration // Plans with a step stuck longer than this, or out of retries, are failed
+	ResponseTimeout time.Duration // How long a re-dispatched step waits for its agent before it is failed
+}
+
+// DefaultRecoveryPolicy returns the recovery policy used unless SetRecoveryPolicy overrides it
+func DefaultRecoveryPolicy() RecoveryPolicy {
+	return RecoveryPolicy{
+		MaxAge:          DefaultRecoveryMaxAge,
+		ResponseTimeout: DefaultRecoveryResponseTimeout,
+	}
+}
+
+// SetRecoveryPolicy sets the policy applied by RecoverIncompletePlans
+func (s *ExecutionService) SetRecoveryPolicy(policy RecoveryPolicy) {
+	s.recoveryPolicy = policy
+}
+
+// RecoverIncompletePlans resumes plans interrupted by a restart
+// Plans that are not finished and have steps stuck in assigned or executing, or executing plans with pending steps,
+// are either failed, when such a step is older than the policy's MaxAge or has no retries left, or resumed:
+// stuck steps are re-dispatched to their agents and pending steps are dispatched once their prerequisites completed
+func (s *ExecutionService) RecoverIncompletePlans(ctx context.Context) error {
+	if s.executionPlanRepo == nil {
+		return nil
+	}
+
+	plans, err := s.executionPlanRepo.GetPlansByStatus(ctx, planningDomain.NonTerminalExecutionPlanStatuses())
+	if err != nil {
+		return fmt.Errorf("failed to load incomplete execution plans: %w", err)
+	}
+
+	var errs []error
+	for _, plan := range plans {
+		if err := s.recoverPlan(ctx, plan); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// recoverPlan fails or resumes the interrupted steps of a single plan
+func (s *ExecutionService) recoverPlan(ctx context.Context, plan *planningDomain.ExecutionPlan) error {
+	var stuck, pending []*planningDomain.ExecutionStep
+	for _, step := range plan.Steps {
+		switch {
+		case step.Status.IsActive():
+			stuck = append(stuck, step)
+		case step.Status == planningDomain.ExecutionStepStatusPending && plan.Status == planningDomain.ExecutionPlanStatusExecuting:
+			// Steps of a plan that was already executing were waiting to be dispatched
+			pending = append(pending, step)
+		}
+	}
+	if len(stuck) == 0 && len(pending) == 0 {
+		return nil
+	}
+
+	interrupted := append(stuck, pending...)
+	for _, step := range interrupted {
+		if step.RetryCount >= step.MaxRetries || time.Since(stuckSince(plan, step)) > s.recoveryPolicy.MaxAge {
+			return s.failInterruptedPlan(ctx, plan, interrupted)
+		}
+	}
+
+	for _, step := range stuck {
+		if err := s.redispatchStep(ctx, plan, step); err != nil {
+			return err
+		}
+	}
+	return s.dispatchReadySteps(ctx, plan)
+}
+
+// dispatchReadySteps dispatches the pending steps whose prerequisites all completed
+// Pending steps behind a failed prerequisite are failed so the plan can finish
+func (s *ExecutionService) dispatchReadySteps(ctx context.Context, plan *planningDomain.ExecutionPlan) error {
+	var ready []*planningDomain.ExecutionStep
+	for blocked := true; blocked; {
+		blocked = false
+		ready = ready[:0]
+		for _, step := range plan.Steps {
+			if step.Status != planningDomain.ExecutionStepStatusPending {
+				continue
+			}
+
+			switch prerequisite := unfinishedPrerequisite(plan, step); {
+			case prerequisite == nil:
+				ready = append(ready, step)
+			case prerequisite.IsComplete():
+				step.Fail(fmt.Sprintf("%s: waits for %s step %s", ErrStepDependencyFailed, prerequisite.Status, prerequisite.ID))
+				if err := s.executionPlanRepo.UpdateStep(ctx, step); err != nil {
+					return fmt.Errorf("failed to persist blocked step %s: %w", step.ID, err)
+				}
+				// Steps depending on this one are blocked too
+				blocked = true
+			}
+		}
+	}
+
+	for _, step := range ready {
+		// The step was never dispatched, so resuming it does not count as a retry
+		if err := s.dispatchResumedStep(ctx, plan, step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unfinishedPrerequisite returns a prerequisite of step that has not completed successfully, or nil when the step is ready
+func unfinishedPrerequisite(plan *planningDomain.ExecutionPlan, step *planningDomain.ExecutionStep) *planningDomain.ExecutionStep {
+	var waiting *planningDomain.ExecutionStep
+	for _, prerequisiteID := range step.DependsOn {
+		prerequisite := plan.GetStepByID(prerequisiteID)
+		if prerequisite == nil || prerequisite.Status == planningDomain.ExecutionStepStatusCompleted {
+			continue
+		}
+		// A failed prerequisite outweighs one that is still running
+		if prerequisite.IsComplete() {
+			return prerequisite
+		}
+		waiting = prerequisite
+	}
+	return waiting
+}
+
+// stuckSince returns when a stuck step was last known to make progress
+func stuckSince(plan *planningDomain.ExecutionPlan, step *planningDomain.ExecutionStep) time.Time {
+	if step.StartedAt != nil {
+		return *step.StartedAt
+	}
+	if plan.StartedAt != nil {
+		return *plan.StartedAt
+	}
+	return plan.CreatedAt
+}
+
+// failInterruptedPlan marks the stuck steps and their plan as failed
+func (s *ExecutionService) failInterruptedPlan(ctx context.Context, plan *planningDomain.ExecutionPlan, stuck []*planningDomain.ExecutionStep) error {
+	for _, step := range stuck {
+		step.Fail("Interrupted by orchestrator restart")
+		if err := s.executionPlanRepo.UpdateStep(ctx, step); err != nil {
+			return fmt.Errorf("failed to persist interrupted step %s: %w", step.ID, err)
+		}
+	}
+
+	plan.Fail()
+	if err := s.executionPlanRepo.Update(ctx, plan); err != nil {
+		return fmt.Errorf("failed to persist interrupted execution plan %s: %w", plan.ID, err)
+	}
+	return nil
+}
+
+// redispatchStep counts a retry for a stuck step and sends it to its agent again
+func (s *ExecutionService) redispatchStep(ctx context.Context, plan *planningDomain.ExecutionPlan, step *planningDomain.ExecutionStep) error {
+	if err := step.Retry(); err != nil {
+		return fmt.Errorf("failed to retry step %s: %w", step.ID, err)
+	}
+	return s.dispatchResumedStep(ctx, plan, step)
+}
+
+// dispatchResumedStep assigns a step, sends it to its agent and awaits the response in the background
+func (s *ExecutionService) dispatchResumedStep(ctx context.Context, plan *planningDomain.ExecutionPlan, step *planningDomain.ExecutionStep) error {
+	step.Assign()
+	if err := s.executionPlanRepo.UpdateStep(ctx, step); err != nil {
+		return fmt.Errorf("failed to persist resumed step %s: %w", step.ID, err)
+	}
+
+	// Steps with unreadable inputs are resumed from their description alone
+	parameters, _ := step.GetInputs()
+	msg := &messaging.AIToAgentMessage{
+		AgentID:       step.AssignedAgent,
+		Content:       step.Description,
+		Intent:        ResumeIntent,
+		CorrelationID: fmt.Sprintf("resume-%s-%d", step.ID, step.RetryCount),
+		Context: map[string]interface{}{
+			"action":  ResumeIntent,
+			"plan_id": plan.ID,
+			"step_id": step.ID,
+		},
+		Parameters: parameters,
+	}
+
+	// Responses reach the tracker through the global message consumer
+	responseChan := s.correlationTracker.RegisterAgentRequest(msg, "", s.recoveryPolicy.ResponseTimeout)
+	if err := s.aiMessageBus.SendToAgent(ctx, msg); err != nil {
+		s.correlationTracker.CleanupRequest(msg.CorrelationID)
+		return fmt.Errorf("failed to re-dispatch step %s to agent %s: %w", step.ID, step.AssignedAgent, err)
+	}
+
+	go s.awaitResumedStep(ctx, plan.ID, step.ID, msg.CorrelationID, responseChan)
+	return nil
+}
+
+// awaitResumedStep records the outcome of a re-dispatched step once its agent answers or the wait times out
+func (s *ExecutionService) awaitResumedStep(ctx context.Context, planID, stepID, correlationID string, responseChan chan *messaging.AgentToAIMessage) {
+	timer := time.NewTimer(s.recoveryPolicy.ResponseTimeout)
+	defer timer.Stop()
+
+	var response *messaging.AgentToAIMessage
+	select {
+	case response = <-responseChan:
+		// A cancelled execution already had its plan and steps cancelled
+		if response == nil && s.correlationTracker.IsCancelled(correlationID) {
+			return
+		}
+	case <-timer.C:
+		s.correlationTracker.CleanupRequest(correlationID)
+	case <-ctx.Done():
+		s.correlationTracker.CleanupRequest(correlationID)
+		return
+	}
+
+	if err := s.finishResumedStep(ctx, planID, stepID, response); err != nil {
+		s.logger.Error("Failed to record resumed step outcome", err, "plan_id", planID, "step_id", stepID)
+	}
+}
+
+// finishResumedStep completes or fails a resumed step, dispatches the steps it unblocked and finalizes its plan once every step has finished
+func (s *ExecutionService) finishResumedStep(ctx context.Context, planID, stepID string, response *messaging.AgentToAIMessage) error {
+	// Steps finishing together must not dispatch the same unblocked step twice
+	s.resumeMu.Lock()
+	defer s.resumeMu.Unlock()
+
+	step, err := s.executionPlanRepo.GetStepByID(ctx, stepID)
+	if err != nil {
+		return fmt.Errorf("failed to load resumed step %s: %w", stepID, err)
+	}
+	if step.IsComplete() {
+		return nil
+	}
+
+	switch {
+	case response == nil:
+		step.Fail("No agent response after resuming the step")
+	case response.MessageType == messaging.MessageTypeError:
+		step.Fail(response.Content)
+	default:
+		if err := step.Start(); err != nil {
+			return fmt.Errorf("failed to start resumed step %s: %w", stepID, err)
+		}
+		if err := step.Complete(map[string]interface{}{planningDomain.StepOutputContent: response.Content}); err != nil {
+			return fmt.Errorf("failed to complete resumed step %s: %w", stepID, err)
+		}
+	}
+	if err := s.executionPlanRepo.UpdateStep(ctx, step); err != nil {
+		return fmt.Errorf("failed to persist resumed step %s: %w", stepID, err)
+	}
+
+	plan, err := s.executionPlanRepo.GetByID(ctx, planID)
+	if err != nil {
+		return fmt.Errorf("failed to load execution plan %s: %w", planID, err)
+	}
+	if plan.Status == planningDomain.ExecutionPlanStatusExecuting {
+		if err := s.dispatchReadySteps(ctx, plan); err != nil {
+			return err
+		}
+	}
+	failed := false
+	for _, planStep := range plan.Steps {
+		if !planStep.IsComplete() {
+			return nil
+		}
+		failed = failed || planStep.Status == planningDomain.ExecutionStepStatusFailed
+	}
+
+	if failed {
+		plan.Fail()
+	} else if err := plan.Complete(); err != nil {
+		// Plans that never started executing keep their status
+		return nil
+	}
+	if err := s.executionPlanRepo.Update(ctx, plan); err != nil {
+		return fmt.Errorf("failed to persist execution plan %s: %w", planID, err)
+	}
+	return nil
+}
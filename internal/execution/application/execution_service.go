@@ -0,0 +1,152 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"neuromesh/internal/logging"
+	"neuromesh/internal/messaging"
+	"neuromesh/internal/orchestrator/infrastructure"
+	planningDomain "neuromesh/internal/planning/domain"
+)
+
+var (
+	// ErrExecutionNotFound is returned when no in-flight execution matches a correlation ID
+	ErrExecutionNotFound = errors.New("execution not found")
+	// ErrExecutionCancelled is returned to a caller waiting on an execution that was cancelled
+	ErrExecutionCancelled = errors.New("execution cancelled")
+	// ErrExecutionAccessDenied is returned when a user cancels an execution running for another user
+	ErrExecutionAccessDenied = errors.New("execution belongs to another user")
+)
+
+// CancelIntent is the intent of the event telling an agent to abandon its work
+const CancelIntent = "cancel"
+
+// ExecutionService manages in-flight executions tracked by correlation ID
+type ExecutionService struct {
+	correlationTracker *infrastructure.CorrelationTracker
+	aiMessageBus       messaging.AIMessageBus
+	executionPlanRepo  planningDomain.ExecutionPlanRepository
+	recoveryPolicy     RecoveryPolicy
+	resumeMu           sync.Mutex
+	logger             logging.Logger
+}
+
+// NewExecutionService creates a new execution service
+func NewExecutionService(correlationTracker *infrastructure.CorrelationTracker, aiMessageBus messaging.AIMessageBus, executionPlanRepo planningDomain.ExecutionPlanRepository) *ExecutionService {
+	return &ExecutionService{
+		correlationTracker: correlationTracker,
+		aiMessageBus:       aiMessageBus,
+		executionPlanRepo:  executionPlanRepo,
+		recoveryPolicy:     DefaultRecoveryPolicy(),
+		logger:             logging.NewNoOpLogger(), // Default logger, can be injected later
+	}
+}
+
+// SetLogger allows injecting a custom logger
+func (s *ExecutionService) SetLogger(logger logging.Logger) {
+	s.logger = logger
+}
+
+// CancelExecution cancels a request's execution, or a single agent request, by its ID
+// An execution ID cancels every pending agent request of the execution and stops it dispatching more.
+// Other pending requests of the same plan are cancelled too, the plan and its unfinished steps are marked
+// cancelled, and every affected agent is told to stop via the message bus. Executions without a plan
+// cascade to nothing else. A non-empty requesterID must be the user the execution runs for.
+func (s *ExecutionService) CancelExecution(ctx context.Context, id, requesterID string) error {
+	if id == "" {
+		return fmt.Errorf("correlation ID cannot be empty")
+	}
+
+	var requests []infrastructure.CorrelationRequest
+	var planID, ownerID string
+	execution, isExecution := s.correlationTracker.GetExecution(id)
+	if isExecution {
+		planID, ownerID = execution.PlanID, execution.UserID
+	} else {
+		request, exists := s.correlationTracker.GetRequest(id)
+		if !exists {
+			return fmt.Errorf("%w: %s", ErrExecutionNotFound, id)
+		}
+		requests = []infrastructure.CorrelationRequest{request}
+		planID, ownerID = request.PlanID, request.UserID
+	}
+	if requesterID != "" && requesterID != ownerID {
+		return fmt.Errorf("%w: %s", ErrExecutionAccessDenied, id)
+	}
+	if isExecution {
+		s.correlationTracker.MarkExecutionCancelled(id)
+		requests = s.correlationTracker.GetExecutionRequests(id)
+	}
+
+	seen := make(map[string]bool, len(requests))
+	for _, request := range requests {
+		seen[request.CorrelationID] = true
+	}
+	for _, sibling := range s.correlationTracker.GetPlanRequests(planID) {
+		if !seen[sibling.CorrelationID] {
+			requests = append(requests, sibling)
+		}
+	}
+
+	var cancelled []infrastructure.CorrelationRequest
+	for _, req := range requests {
+		// A response may have arrived in the meantime; only requests still pending are cancelled
+		if s.correlationTracker.CancelRequest(req.CorrelationID) {
+			cancelled = append(cancelled, req)
+		}
+	}
+	// A running execution is cancelled even while it waits for the AI rather than an agent
+	if len(cancelled) == 0 && !isExecution {
+		return fmt.Errorf("%w: %s", ErrExecutionNotFound, id)
+	}
+
+	if err := s.cancelPlan(ctx, planID); err != nil {
+		return err
+	}
+
+	for _, req := range cancelled {
+		if err := s.notifyAgent(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cancelPlan marks the plan and all of its unfinished steps as cancelled
+func (s *ExecutionService) cancelPlan(ctx context.Context, planID string) error {
+	if s.executionPlanRepo == nil || planID == "" {
+		return nil
+	}
+
+	if _, err := s.executionPlanRepo.CancelPlan(ctx, planID); err != nil {
+		return fmt.Errorf("failed to cancel execution plan %s: %w", planID, err)
+	}
+	return nil
+}
+
+// notifyAgent tells the agent serving a cancelled request to abandon its work
+func (s *ExecutionService) notifyAgent(ctx context.Context, request infrastructure.CorrelationRequest) error {
+	if s.aiMessageBus == nil || request.AgentID == "" {
+		return nil
+	}
+
+	msg := &messaging.AIToAgentMessage{
+		AgentID:       request.AgentID,
+		Content:       "Execution cancelled by user",
+		Intent:        CancelIntent,
+		CorrelationID: request.CorrelationID,
+		Context: map[string]interface{}{
+			"action":  CancelIntent,
+			"user_id": request.UserID,
+			"plan_id": request.PlanID,
+			"step_id": request.StepID,
+		},
+	}
+	if err := s.aiMessageBus.SendToAgent(ctx, msg); err != nil {
+		return fmt.Errorf("failed to notify agent %s of cancellation: %w", request.AgentID, err)
+	}
+	return nil
+}
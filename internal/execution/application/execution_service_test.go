@@ -0,0 +1,315 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	aiInfrastructure "neuromesh/internal/ai/infrastructure"
+	executionDomain "neuromesh/internal/execution/domain"
+	"neuromesh/internal/messaging"
+	"neuromesh/internal/orchestrator/infrastructure"
+	planningDomain "neuromesh/internal/planning/domain"
+	"neuromesh/testHelpers"
+)
+
+func TestExecutionService_CancelExecution(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	plan := planningDomain.NewExecutionPlan("Deploy", "Deploy the application", planningDomain.ExecutionPlanPriorityMedium)
+	done := planningDomain.NewExecutionStep("Build", "Build the image", "build-agent")
+	hanging := planningDomain.NewExecutionStep("Deploy", "Deploy the image", "deploy-agent")
+	plan.AddStep(done)
+	plan.AddStep(hanging)
+	done.Status = planningDomain.ExecutionStepStatusCompleted
+	hanging.Status = planningDomain.ExecutionStepStatusExecuting
+
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	require.NoError(t, planRepo.Create(ctx, plan))
+
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(make(chan *messaging.Message)), nil)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.MatchedBy(func(msg *messaging.AIToAgentMessage) bool {
+		return msg.Intent == CancelIntent && msg.AgentID == "deploy-agent" && msg.CorrelationID == "exec-user-1-hang"
+	})).Return(nil).Once()
+
+	tracker := infrastructure.NewCorrelationTracker()
+	engine := NewAIExecutionEngineWithRepository(aiInfrastructure.NewScriptedProvider(nil), aiMessageBus, tracker, planRepo)
+	service := NewExecutionService(tracker, aiMessageBus, planRepo)
+
+	// The agent never answers, so the waiter blocks until the execution is cancelled
+	eventMsg := &messaging.AIToAgentMessage{
+		AgentID:       "deploy-agent",
+		Content:       "Deploy the image",
+		CorrelationID: "exec-user-1-hang",
+		Context:       map[string]interface{}{"plan_id": plan.ID, "step_id": hanging.ID},
+	}
+	waitErr := make(chan error, 1)
+	go func() {
+		_, err := engine.waitForAgentResponseWithCorrelation(ctx, eventMsg, "user-1")
+		waitErr <- err
+	}()
+	require.Eventually(t, func() bool {
+		_, pending := tracker.GetRequest(eventMsg.CorrelationID)
+		return pending
+	}, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, service.CancelExecution(ctx, eventMsg.CorrelationID, ""))
+
+	select {
+	case err := <-waitErr:
+		assert.True(t, errors.Is(err, ErrExecutionCancelled))
+	case <-time.After(time.Second):
+		t.Fatal("waiting goroutine was not unblocked by the cancellation")
+	}
+
+	persistedPlan, err := planRepo.GetByID(ctx, plan.ID)
+	require.NoError(t, err)
+	assert.Equal(t, planningDomain.ExecutionPlanStatusCancelled, persistedPlan.Status)
+	persistedStep, err := planRepo.GetStepByID(ctx, hanging.ID)
+	require.NoError(t, err)
+	assert.Equal(t, planningDomain.ExecutionStepStatusCancelled, persistedStep.Status)
+	persistedDone, err := planRepo.GetStepByID(ctx, done.ID)
+	require.NoError(t, err)
+	assert.Equal(t, planningDomain.ExecutionStepStatusCompleted, persistedDone.Status)
+	aiMessageBus.AssertExpectations(t)
+}
+
+func TestExecutionService_CancelExecution_ByExecutionID(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(make(chan *messaging.Message)), nil)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.MatchedBy(func(msg *messaging.AIToAgentMessage) bool {
+		return msg.Intent == CancelIntent && msg.CorrelationID == "exec-user-1-step"
+	})).Return(nil).Once()
+
+	tracker := infrastructure.NewCorrelationTracker()
+	engine := NewAIExecutionEngine(aiInfrastructure.NewScriptedProvider(nil), aiMessageBus, tracker)
+	service := NewExecutionService(tracker, aiMessageBus, testHelpers.NewMockExecutionPlanRepository())
+
+	// Two plan-less executions of different users, each waiting on an agent
+	wait := func(executionID, userID, correlationID string) <-chan error {
+		tracker.BeginExecution(infrastructure.TrackedExecution{ExecutionID: executionID, UserID: userID})
+		eventMsg := &messaging.AIToAgentMessage{
+			AgentID:       "text-processor",
+			CorrelationID: correlationID,
+			Context:       map[string]interface{}{"execution_id": executionID, "plan_id": ""},
+		}
+		waitErr := make(chan error, 1)
+		go func() {
+			_, err := engine.waitForAgentResponseWithCorrelation(ctx, eventMsg, userID)
+			waitErr <- err
+		}()
+		require.Eventually(t, func() bool {
+			_, pending := tracker.GetRequest(correlationID)
+			return pending
+		}, time.Second, 5*time.Millisecond)
+		return waitErr
+	}
+	cancelledErr := wait("exec-user-1", "user-1", "exec-user-1-step")
+	wait("exec-user-2", "user-2", "exec-user-2-step")
+
+	// Only the user the execution runs for may cancel it
+	assert.ErrorIs(t, service.CancelExecution(ctx, "exec-user-1", "user-2"), ErrExecutionAccessDenied)
+	require.NoError(t, service.CancelExecution(ctx, "exec-user-1", "user-1"))
+
+	select {
+	case err := <-cancelledErr:
+		assert.True(t, errors.Is(err, ErrExecutionCancelled))
+	case <-time.After(time.Second):
+		t.Fatal("waiting goroutine was not unblocked by the cancellation")
+	}
+
+	// The other user's request shares no plan with the cancelled one, so it keeps waiting
+	_, pending := tracker.GetRequest("exec-user-2-step")
+	assert.True(t, pending)
+
+	// The cancelled execution dispatches nothing further
+	_, err := engine.acquireDispatchSlot(executionDomain.WithExecutionID(ctx, "exec-user-1"), "")
+	assert.True(t, errors.Is(err, ErrExecutionCancelled))
+	aiMessageBus.AssertExpectations(t)
+}
+
+func TestExecutionService_CancelExecution_NotFound(t *testing.T) {
+	service := NewExecutionService(infrastructure.NewCorrelationTracker(), testHelpers.NewMockAIMessageBus(), testHelpers.NewMockExecutionPlanRepository())
+
+	err := service.CancelExecution(context.Background(), "unknown-correlation", "")
+
+	assert.True(t, errors.Is(err, ErrExecutionNotFound))
+}
+
+func TestExecutionService_RecoverIncompletePlans(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	recent := time.Now().Add(-time.Minute)
+	resumable := planningDomain.NewExecutionPlan("Summarize", "Summarize the report", planningDomain.ExecutionPlanPriorityMedium)
+	resumableStep := planningDomain.NewExecutionStep("Summarize", "Summarize the report", "text-processor")
+	resumable.AddStep(resumableStep)
+	resumable.Status = planningDomain.ExecutionPlanStatusExecuting
+	resumable.StartedAt = &recent
+	resumableStep.Status = planningDomain.ExecutionStepStatusExecuting
+	resumableStep.StartedAt = &recent
+
+	stale := time.Now().Add(-2 * time.Hour)
+	abandoned := planningDomain.NewExecutionPlan("Deploy", "Deploy the application", planningDomain.ExecutionPlanPriorityMedium)
+	abandonedStep := planningDomain.NewExecutionStep("Deploy", "Deploy the image", "deploy-agent")
+	abandoned.AddStep(abandonedStep)
+	abandoned.Status = planningDomain.ExecutionPlanStatusExecuting
+	abandoned.StartedAt = &stale
+	abandonedStep.Status = planningDomain.ExecutionStepStatusAssigned
+
+	finished := planningDomain.NewExecutionPlan("Done", "Already finished", planningDomain.ExecutionPlanPriorityLow)
+	finishedStep := planningDomain.NewExecutionStep("Done", "Already finished", "text-processor")
+	finished.AddStep(finishedStep)
+	finished.Status = planningDomain.ExecutionPlanStatusCompleted
+	finishedStep.Status = planningDomain.ExecutionStepStatusExecuting
+
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	for _, plan := range []*planningDomain.ExecutionPlan{resumable, abandoned, finished} {
+		require.NoError(t, planRepo.Create(ctx, plan))
+	}
+
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.MatchedBy(func(msg *messaging.AIToAgentMessage) bool {
+		return msg.Intent == ResumeIntent && msg.AgentID == "text-processor" && msg.Context["step_id"] == resumableStep.ID
+	})).Return(nil).Once()
+
+	tracker := infrastructure.NewCorrelationTracker()
+	service := NewExecutionService(tracker, aiMessageBus, planRepo)
+	service.SetRecoveryPolicy(RecoveryPolicy{MaxAge: time.Hour, ResponseTimeout: time.Minute})
+
+	require.NoError(t, service.RecoverIncompletePlans(ctx))
+	aiMessageBus.AssertExpectations(t)
+
+	// The stale plan is failed outright
+	persistedAbandoned, err := planRepo.GetByID(ctx, abandoned.ID)
+	require.NoError(t, err)
+	assert.Equal(t, planningDomain.ExecutionPlanStatusFailed, persistedAbandoned.Status)
+	persistedAbandonedStep, err := planRepo.GetStepByID(ctx, abandonedStep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, planningDomain.ExecutionStepStatusFailed, persistedAbandonedStep.Status)
+
+	// The recent step is re-dispatched as a retry and completes when its agent answers
+	correlationID := "resume-" + resumableStep.ID + "-1"
+	request, pending := tracker.GetRequest(correlationID)
+	require.True(t, pending)
+	assert.Equal(t, resumable.ID, request.PlanID)
+	redispatched, err := planRepo.GetStepByID(ctx, resumableStep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, redispatched.RetryCount)
+
+	require.True(t, tracker.RouteResponse(&messaging.AgentToAIMessage{
+		AgentID:       "text-processor",
+		Content:       "Summary ready",
+		CorrelationID: correlationID,
+		MessageType:   messaging.MessageTypeResponse,
+	}))
+	require.Eventually(t, func() bool {
+		plan, err := planRepo.GetByID(ctx, resumable.ID)
+		return err == nil && plan.Status == planningDomain.ExecutionPlanStatusCompleted
+	}, time.Second, 5*time.Millisecond)
+	completedStep, err := planRepo.GetStepByID(ctx, resumableStep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, planningDomain.ExecutionStepStatusCompleted, completedStep.Status)
+	assert.Equal(t, "Summary ready", completedStep.OutputContent())
+
+	// Finished plans are left alone
+	persistedFinished, err := planRepo.GetByID(ctx, finished.ID)
+	require.NoError(t, err)
+	assert.Equal(t, planningDomain.ExecutionPlanStatusCompleted, persistedFinished.Status)
+}
+
+func TestExecutionService_RecoverIncompletePlans_ResumesPendingSteps(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	recent := time.Now().Add(-time.Minute)
+	plan := planningDomain.NewExecutionPlan("Report", "Fetch, parse and publish", planningDomain.ExecutionPlanPriorityMedium)
+	fetch := planningDomain.NewExecutionStep("Fetch", "Fetch the data", "fetch-agent")
+	parse := planningDomain.NewExecutionStep("Parse", "Parse the data", "parse-agent")
+	notify := planningDomain.NewExecutionStep("Notify", "Notify the team", "notify-agent")
+	scan := planningDomain.NewExecutionStep("Scan", "Scan the data", "scan-agent")
+	publish := planningDomain.NewExecutionStep("Publish", "Publish the scan", "publish-agent")
+	for _, step := range []*planningDomain.ExecutionStep{fetch, parse, notify, scan, publish} {
+		require.NoError(t, plan.AddStep(step))
+	}
+	parse.DependOn(fetch)
+	publish.DependOn(scan)
+	plan.Status = planningDomain.ExecutionPlanStatusExecuting
+	plan.StartedAt = &recent
+	fetch.Status = planningDomain.ExecutionStepStatusExecuting
+	fetch.StartedAt = &recent
+	scan.Fail("scanner crashed")
+
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	require.NoError(t, planRepo.Create(ctx, plan))
+
+	var mu sync.Mutex
+	var dispatched []string
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			mu.Lock()
+			defer mu.Unlock()
+			dispatched = append(dispatched, args.Get(1).(*messaging.AIToAgentMessage).AgentID)
+		}).
+		Return(nil)
+
+	tracker := infrastructure.NewCorrelationTracker()
+	service := NewExecutionService(tracker, aiMessageBus, planRepo)
+	service.SetRecoveryPolicy(RecoveryPolicy{MaxAge: time.Hour, ResponseTimeout: time.Minute})
+
+	require.NoError(t, service.RecoverIncompletePlans(ctx))
+
+	// The interrupted step is retried and the independent pending step dispatched; the others wait or are blocked
+	mu.Lock()
+	assert.ElementsMatch(t, []string{"fetch-agent", "notify-agent"}, dispatched)
+	mu.Unlock()
+	persistedNotify, err := planRepo.GetStepByID(ctx, notify.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, persistedNotify.RetryCount)
+	persistedPublish, err := planRepo.GetStepByID(ctx, publish.ID)
+	require.NoError(t, err)
+	assert.Equal(t, planningDomain.ExecutionStepStatusFailed, persistedPublish.Status)
+	assert.Contains(t, persistedPublish.ErrorMessage, "step dependency failed")
+
+	// Completing the prerequisite dispatches its dependent
+	reply := func(step *planningDomain.ExecutionStep, retry int) {
+		require.True(t, tracker.RouteResponse(&messaging.AgentToAIMessage{
+			AgentID:       step.AssignedAgent,
+			Content:       step.Name + " done",
+			CorrelationID: fmt.Sprintf("resume-%s-%d", step.ID, retry),
+			MessageType:   messaging.MessageTypeResponse,
+		}))
+	}
+	reply(fetch, 1)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(dispatched) == 3 && dispatched[2] == "parse-agent"
+	}, time.Second, 5*time.Millisecond)
+
+	reply(notify, 0)
+	require.Eventually(t, func() bool {
+		step, err := planRepo.GetStepByID(ctx, notify.ID)
+		return err == nil && step.Status == planningDomain.ExecutionStepStatusCompleted
+	}, time.Second, 5*time.Millisecond)
+	reply(parse, 0)
+
+	// The plan finishes once every step has, failed because of the scan
+	require.Eventually(t, func() bool {
+		persisted, err := planRepo.GetByID(ctx, plan.ID)
+		return err == nil && persisted.Status == planningDomain.ExecutionPlanStatusFailed
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,55 @@
+package application
+
+import (
+	"context"
+	"sync"
+)
+
+// planCompletion records whether the execution carried by a context completed its plan
+type planCompletion struct {
+	mu        sync.Mutex
+	completed bool
+}
+
+type planCompletionKey struct{}
+
+// withPlanCompletion returns a context that tracks whether the execution completes its plan
+func withPlanCompletion(ctx context.Context) (context.Context, *planCompletion) {
+	completion := &planCompletion{}
+	return context.WithValue(ctx, planCompletionKey{}, completion), completion
+}
+
+// markPlanCompleted notes on the execution carried by ctx that its plan completed
+func markPlanCompleted(ctx context.Context) {
+	if completion, ok := ctx.Value(planCompletionKey{}).(*planCompletion); ok {
+		completion.mu.Lock()
+		completion.completed = true
+		completion.mu.Unlock()
+	}
+}
+
+// isCompleted reports whether the execution completed its plan
+func (c *planCompletion) isCompleted() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.completed
+}
+
+// SetResultSynthesizer makes executions that complete their plan answer with a synthesis of every step's result
+func (e *AIExecutionEngine) SetResultSynthesizer(synthesizer *ResultSynthesisService) {
+	e.synthesizer = synthesizer
+}
+
+// finalAnswer returns the answer delivered to the user once an execution finishes
+// When the execution completed its plan, the synthesized results replace the AI's last response
+// Synthesis is best effort, so a failure falls back to the AI's response
+func (e *AIExecutionEngine) finalAnswer(ctx context.Context, completion *planCompletion, planID, response string) string {
+	if e.synthesizer == nil || planID == "" || !completion.isCompleted() {
+		return response
+	}
+	answer, err := e.synthesizer.SynthesizeResults(ctx, planID)
+	if err != nil || answer == "" {
+		return response
+	}
+	return answer
+}
@@ -0,0 +1,98 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	aiDomain "neuromesh/internal/ai/domain"
+	executionDomain "neuromesh/internal/execution/domain"
+	planningDomain "neuromesh/internal/planning/domain"
+)
+
+// ResultSynthesisService combines the stored agent results of a plan into a final user-facing answer
+type ResultSynthesisService struct {
+	aiProvider        aiDomain.AIProvider
+	resultRepo        executionDomain.AgentResultRepository
+	executionPlanRepo planningDomain.ExecutionPlanRepository
+}
+
+// NewResultSynthesisService creates a new result synthesis service
+func NewResultSynthesisService(aiProvider aiDomain.AIProvider, resultRepo executionDomain.AgentResultRepository, executionPlanRepo planningDomain.ExecutionPlanRepository) *ResultSynthesisService {
+	return &ResultSynthesisService{
+		aiProvider:        aiProvider,
+		resultRepo:        resultRepo,
+		executionPlanRepo: executionPlanRepo,
+	}
+}
+
+// SynthesizeResults asks the AI provider to turn every agent result of a plan into one coherent response
+func (s *ResultSynthesisService) SynthesizeResults(ctx context.Context, planID string) (string, error) {
+	results, err := s.resultRepo.GetAgentResultsByExecutionPlan(ctx, planID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load agent results for plan %s: %w", planID, err)
+	}
+	// In-progress updates are superseded by the terminal result of their step
+	results = slices.DeleteFunc(results, (*executionDomain.AgentResult).IsInProgress)
+	if len(results) == 0 {
+		return "", fmt.Errorf("no agent results stored for plan %s", planID)
+	}
+
+	steps, err := s.executionPlanRepo.GetStepsByPlanID(ctx, planID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load steps for plan %s: %w", planID, err)
+	}
+	stepsByID := make(map[string]*planningDomain.ExecutionStep, len(steps))
+	for _, step := range steps {
+		stepsByID[step.ID] = step
+	}
+
+	// Results without a known step keep their timestamp order after the numbered steps
+	stepNumber := func(result *executionDomain.AgentResult) int {
+		if step, ok := stepsByID[result.StepID]; ok {
+			return step.StepNumber
+		}
+		return len(steps) + 1
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return stepNumber(results[i]) < stepNumber(results[j])
+	})
+
+	response, usage, err := s.aiProvider.CallAIWithUsage(ctx, buildSynthesisSystemPrompt(), buildSynthesisUserPrompt(results, stepsByID), aiDomain.CallOptions{})
+	if err != nil {
+		return "", fmt.Errorf("AI result synthesis failed: %w", err)
+	}
+	aiDomain.RecordUsage(ctx, usage)
+
+	return strings.TrimSpace(response), nil
+}
+
+// buildSynthesisSystemPrompt creates the system prompt for result synthesis
+func buildSynthesisSystemPrompt() string {
+	return `You are an AI orchestrator summarizing the outcome of a completed execution plan.
+
+You receive the results every agent reported, in step order. Combine them into one coherent,
+user-facing answer. Mention failed steps and their impact honestly, do not invent results that
+are not in the input, and do not refer to internal identifiers unless they help the user.`
+}
+
+// buildSynthesisUserPrompt lists the ordered agent results for synthesis
+func buildSynthesisUserPrompt(results []*executionDomain.AgentResult, stepsByID map[string]*planningDomain.ExecutionStep) string {
+	var prompt strings.Builder
+	prompt.WriteString("Agent results:\n")
+	for _, result := range results {
+		label := "Unplanned step"
+		if step, ok := stepsByID[result.StepID]; ok {
+			label = fmt.Sprintf("Step %d (%s)", step.StepNumber, step.Name)
+		}
+
+		if result.IsFailed() {
+			prompt.WriteString(fmt.Sprintf("- %s, agent %s FAILED: %s\n", label, result.AgentID, result.ErrorMessage))
+			continue
+		}
+		prompt.WriteString(fmt.Sprintf("- %s, agent %s: %s\n", label, result.AgentID, result.Content))
+	}
+	return prompt.String()
+}
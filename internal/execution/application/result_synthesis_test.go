@@ -0,0 +1,206 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	aiInfrastructure "neuromesh/internal/ai/infrastructure"
+	executionDomain "neuromesh/internal/execution/domain"
+	executionInfrastructure "neuromesh/internal/execution/infrastructure"
+	"neuromesh/internal/messaging"
+	"neuromesh/internal/orchestrator/infrastructure"
+	planningDomain "neuromesh/internal/planning/domain"
+	"neuromesh/testHelpers"
+)
+
+func TestResultSynthesisService_SynthesizeResults(t *testing.T) {
+	ctx := context.Background()
+
+	plan := planningDomain.NewExecutionPlan("Diagnose", "Diagnose patient symptoms", planningDomain.ExecutionPlanPriorityMedium)
+	symptoms := planningDomain.NewExecutionStep("Analyze symptoms", "Analyze reported symptoms", "symptom-analyzer")
+	labs := planningDomain.NewExecutionStep("Review labs", "Review lab results", "lab-reviewer")
+	plan.AddStep(symptoms)
+	plan.AddStep(labs)
+
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	require.NoError(t, planRepo.Create(ctx, plan))
+
+	// Store results out of step order to prove the prompt follows step numbers
+	resultRepo := executionInfrastructure.NewGraphAgentResultRepository(testHelpers.NewCleanMockGraph())
+	require.NoError(t, resultRepo.Store(ctx, executionDomain.NewAgentResult(plan.ID, labs.ID, "lab-reviewer", "corr-2", "White cell count is elevated")))
+	require.NoError(t, resultRepo.Store(ctx, executionDomain.NewAgentResult(plan.ID, symptoms.ID, "symptom-analyzer", "corr-1", "Fever and cough for three days")))
+
+	provider := aiInfrastructure.NewScriptedProvider(map[string]string{"Agent results": "Likely a bacterial respiratory infection."})
+	service := NewResultSynthesisService(provider, resultRepo, planRepo)
+
+	answer, err := service.SynthesizeResults(ctx, plan.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Likely a bacterial respiratory infection.", answer)
+
+	calls := provider.Calls()
+	require.Len(t, calls, 1)
+	prompt := calls[0].UserPrompt
+	assert.Contains(t, prompt, "Step 1 (Analyze symptoms), agent symptom-analyzer: Fever and cough for three days")
+	assert.Contains(t, prompt, "Step 2 (Review labs), agent lab-reviewer: White cell count is elevated")
+	assert.Less(t, strings.Index(prompt, "Fever and cough"), strings.Index(prompt, "White cell count"))
+}
+
+func TestResultSynthesisService_NoResults(t *testing.T) {
+	resultRepo := executionInfrastructure.NewGraphAgentResultRepository(testHelpers.NewCleanMockGraph())
+	service := NewResultSynthesisService(aiInfrastructure.NewScriptedProvider(nil), resultRepo, testHelpers.NewMockExecutionPlanRepository())
+
+	_, err := service.SynthesizeResults(context.Background(), "plan-without-results")
+
+	assert.Error(t, err)
+}
+
+func TestAIExecutionEngine_PublishesPlanCompletedOnLastStep(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	plan := planningDomain.NewExecutionPlan("Diagnose", "Diagnose patient symptoms", planningDomain.ExecutionPlanPriorityMedium)
+	steps := []*planningDomain.ExecutionStep{
+		planningDomain.NewExecutionStep("Analyze symptoms", "Analyze reported symptoms", "symptom-analyzer"),
+		planningDomain.NewExecutionStep("Review labs", "Review lab results", "lab-reviewer"),
+		planningDomain.NewExecutionStep("Recommend treatment", "Recommend a treatment", "treatment-advisor"),
+	}
+	for _, step := range steps {
+		plan.AddStep(step)
+	}
+	// The first two steps already finished
+	steps[0].Status = planningDomain.ExecutionStepStatusCompleted
+	steps[1].Status = planningDomain.ExecutionStepStatusCompleted
+	lastStep := steps[2]
+
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	require.NoError(t, planRepo.Create(ctx, plan))
+	resultRepo := executionInfrastructure.NewGraphAgentResultRepository(testHelpers.NewCleanMockGraph())
+
+	sendEvent := "SEND_EVENT:\nAgent: treatment-advisor\nAction: recommend\nContent: Recommend treatment\nIntent: diagnosis\nStep: " + lastStep.ID
+	aiProvider := aiInfrastructure.NewScriptBuilder().
+		On("Execute plan for user request", sendEvent, sendEvent).
+		OnUserResponse("Process the agent response", "Treatment recommended").
+		OnUserResponse("Process the agent response", "Treatment recommended").
+		Build()
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	engine := NewAIExecutionEngineWithRepository(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker(), planRepo)
+	engine.SetAgentResultRepository(resultRepo)
+
+	responses := make(chan *messaging.Message, 10)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := args.Get(1).(*messaging.AIToAgentMessage)
+			responses <- &messaging.Message{
+				CorrelationID: msg.CorrelationID,
+				FromID:        msg.AgentID,
+				Content:       "Rest and antibiotics",
+				MessageType:   messaging.MessageTypeAgentToAI,
+			}
+		}).
+		Return(nil)
+	aiMessageBus.On("PublishPlanCompletedEvent", mock.Anything, mock.MatchedBy(func(event *messaging.PlanCompletedEvent) bool {
+		return event.PlanID == plan.ID && event.UserID == "user-1"
+	})).Return(nil)
+	aiMessageBus.On("PublishAgentCompletedEvent", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := engine.ExecuteWithAgents(ctx, plan.ID, "diagnose my symptoms", "user-1", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Treatment recommended", result)
+
+	// A duplicate report for the already completed step must not announce the plan again
+	_, err = engine.ExecuteWithAgents(ctx, plan.ID, "diagnose my symptoms", "user-1", "")
+	require.NoError(t, err)
+
+	aiMessageBus.AssertNumberOfCalls(t, "PublishPlanCompletedEvent", 1)
+	aiMessageBus.AssertNumberOfCalls(t, "PublishAgentCompletedEvent", 1)
+
+	persisted, err := planRepo.GetStepByID(ctx, lastStep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, planningDomain.ExecutionStepStatusCompleted, persisted.Status)
+}
+
+func TestAIExecutionEngine_PublishesAgentCompletedOncePerStep(t *testing.T) {
+	ctx := context.Background()
+
+	plan := planningDomain.NewExecutionPlan("Word count", "Count words", planningDomain.ExecutionPlanPriorityMedium)
+	first := planningDomain.NewExecutionStep("Count words", "Count words in text", "text-processor")
+	second := planningDomain.NewExecutionStep("Summarize", "Summarize the count", "summarizer")
+	plan.AddStep(first)
+	plan.AddStep(second)
+
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	require.NoError(t, planRepo.Create(ctx, plan))
+
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	aiMessageBus.On("PublishAgentCompletedEvent", mock.Anything, mock.MatchedBy(func(event *messaging.AgentCompletedEvent) bool {
+		return event.PlanID == plan.ID && event.StepID == first.ID && event.AgentID == "text-processor"
+	})).Return(nil)
+
+	engine := NewAIExecutionEngineWithRepository(aiInfrastructure.NewScriptedProvider(nil), aiMessageBus, infrastructure.NewCorrelationTracker(), planRepo)
+	engine.SetAgentResultRepository(executionInfrastructure.NewGraphAgentResultRepository(testHelpers.NewCleanMockGraph()))
+
+	// A retried agent reports the same step twice
+	for i := 0; i < 2; i++ {
+		result := executionDomain.NewAgentResult(plan.ID, first.ID, "text-processor", "corr-1", "2")
+		require.NoError(t, engine.recordAgentResult(ctx, result, "user-1"))
+	}
+
+	aiMessageBus.AssertNumberOfCalls(t, "PublishAgentCompletedEvent", 1)
+	aiMessageBus.AssertNotCalled(t, "PublishPlanCompletedEvent", mock.Anything, mock.Anything)
+
+	persisted, err := planRepo.GetStepByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.True(t, persisted.EventPublished)
+}
+
+func TestAIExecutionEngine_ReturnsSynthesizedAnswerWhenPlanCompletes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	plan := planningDomain.NewExecutionPlan("Word count", "Count words", planningDomain.ExecutionPlanPriorityMedium)
+	step := planningDomain.NewExecutionStep("Count words", "Count words in text", "text-processor")
+	plan.AddStep(step)
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	require.NoError(t, planRepo.Create(ctx, plan))
+	resultRepo := executionInfrastructure.NewGraphAgentResultRepository(testHelpers.NewCleanMockGraph())
+
+	aiProvider := aiInfrastructure.NewScriptBuilder().
+		On("Execute plan for user request", "SEND_EVENT:\nAgent: text-processor\nAction: count\nContent: Count words\nIntent: analysis\nStep: "+step.ID).
+		OnUserResponse("Process the agent response", "Done").
+		On("Agent results", "The text has 2 words.").
+		Build()
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	engine := NewAIExecutionEngineWithRepository(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker(), planRepo)
+	engine.SetAgentResultRepository(resultRepo)
+	engine.SetResultSynthesizer(NewResultSynthesisService(aiProvider, resultRepo, planRepo))
+
+	responses := make(chan *messaging.Message, 10)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := args.Get(1).(*messaging.AIToAgentMessage)
+			responses <- &messaging.Message{
+				CorrelationID: msg.CorrelationID,
+				FromID:        msg.AgentID,
+				Content:       "2",
+				MessageType:   messaging.MessageTypeAgentToAI,
+			}
+		}).
+		Return(nil)
+	aiMessageBus.On("PublishAgentCompletedEvent", mock.Anything, mock.Anything).Return(nil)
+	aiMessageBus.On("PublishPlanCompletedEvent", mock.Anything, mock.Anything).Return(nil)
+
+	// The synthesis reaches the caller; nothing is sent back over the bus for it
+	result, err := engine.ExecuteWithAgents(ctx, plan.ID, "count the words", "user-1", "")
+	require.NoError(t, err)
+	assert.Equal(t, "The text has 2 words.", result)
+	aiMessageBus.AssertNotCalled(t, "SendToAI", mock.Anything, mock.Anything)
+}
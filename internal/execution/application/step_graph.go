@@ -0,0 +1,134 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	executionDomain "neuromesh/internal/execution/domain"
+	planningDomain "neuromesh/internal/planning/domain"
+)
+
+// ErrStepDependencyFailed is reported for steps that were not dispatched because a step they depend on failed
+var ErrStepDependencyFailed = errors.New("step dependency failed")
+
+// loadPlan returns the persisted plan, or nil for requests without one
+func (e *AIExecutionEngine) loadPlan(ctx context.Context, planID string) *planningDomain.ExecutionPlan {
+	if e.executionPlanRepo == nil || planID == "" {
+		return nil
+	}
+	plan, err := e.executionPlanRepo.GetByID(ctx, planID)
+	if err != nil {
+		return nil
+	}
+	return plan
+}
+
+// executeStepGraph runs a plan's steps in dependency order, dispatching each step as soon as its prerequisites complete
+// Independent steps run in parallel; once every step has finished the AI synthesizes the outcomes for the user
+func (e *AIExecutionEngine) executeStepGraph(ctx context.Context, plan *planningDomain.ExecutionPlan, originalRequest, userID, agentContext string) (string, error) {
+	// A cycle or a dependency outside the plan would leave its steps waiting forever
+	if err := plan.ValidateDependencies(); err != nil {
+		return "", fmt.Errorf("cannot execute plan %s: %w", plan.ID, err)
+	}
+
+	indexes := make(map[string]int, len(plan.Steps))
+	finished := make([]chan struct{}, len(plan.Steps))
+	for i, step := range plan.Steps {
+		indexes[step.ID] = i
+		finished[i] = make(chan struct{})
+	}
+
+	// A single subscription serves every step of the plan
+	responseChannel, err := e.aiMessageBus.Subscribe(ctx, "ai-execution")
+	if err != nil {
+		return "", fmt.Errorf("failed to subscribe for execution agent responses: %w", err)
+	}
+	pending := newPendingCorrelations()
+	done := make(chan struct{})
+	go e.routeBatchResponses(ctx, responseChannel, pending, done)
+
+	outcomes := make([]batchEventOutcome, len(plan.Steps))
+	var wg sync.WaitGroup
+	for i, step := range plan.Steps {
+		wg.Add(1)
+		go func(i int, step *planningDomain.ExecutionStep) {
+			defer wg.Done()
+			// Closing after the outcome is written lets dependents read it once they are unblocked
+			defer close(finished[i])
+
+			for _, prerequisiteID := range step.DependsOn {
+				prerequisite := indexes[prerequisiteID]
+				select {
+				case <-finished[prerequisite]:
+				case <-ctx.Done():
+					outcomes[i] = graphStepFailure(plan, step, ctx.Err())
+					return
+				}
+				if outcomes[prerequisite].err != nil {
+					outcomes[i] = graphStepFailure(plan, step, fmt.Errorf("%w: step %s waits for failed step %s", ErrStepDependencyFailed, step.ID, prerequisiteID))
+					return
+				}
+			}
+
+			outcomes[i] = e.runGraphStep(ctx, plan, step, originalRequest, userID, pending)
+		}(i, step)
+	}
+	wg.Wait()
+	close(done)
+
+	succeeded := 0
+	for _, outcome := range outcomes {
+		if outcome.err == nil {
+			succeeded++
+		}
+	}
+	if succeeded == 0 {
+		return "", fmt.Errorf("no step of plan %s completed: %w", plan.ID, outcomes[0].err)
+	}
+
+	return e.processBatchExecutionResponses(ctx, outcomes, originalRequest, userID, agentContext, plan.ID)
+}
+
+// runGraphStep dispatches one step of a plan to its assigned agent and records the result
+// Steps that already completed, e.g. before a restart, are not dispatched again
+func (e *AIExecutionEngine) runGraphStep(ctx context.Context, plan *planningDomain.ExecutionPlan, step *planningDomain.ExecutionStep, originalRequest, userID string, pending *pendingCorrelations) batchEventOutcome {
+	event := agentEvent{AgentID: step.AssignedAgent, Action: step.Name, Content: step.Description, Intent: plan.Name, StepID: step.ID}
+	if step.Status == planningDomain.ExecutionStepStatusCompleted {
+		return batchEventOutcome{event: event, result: executionDomain.NewAgentResult(plan.ID, step.ID, step.AssignedAgent, "", step.OutputContent())}
+	}
+
+	agentID, timeout, err := e.resolveAgent(ctx, event.AgentID, event.Action)
+	if err != nil {
+		return batchEventOutcome{event: event, err: err}
+	}
+	event.AgentID = agentID
+	if err := e.validateStepInputs(ctx, event); err != nil {
+		return batchEventOutcome{event: event, err: err}
+	}
+
+	outcome := e.dispatchParallelEvent(ctx, event, timeout, originalRequest, userID, plan.ID, pending, map[string]interface{}{"depends_on": step.DependsOn})
+	if outcome.result == nil {
+		return outcome
+	}
+	if outcome.err != nil {
+		if err := e.storeAgentResult(ctx, outcome.result); err != nil {
+			outcome.err = err
+		}
+		return outcome
+	}
+	// Completing the step here, not after the whole graph, keeps plan progress current while dependents run
+	if err := e.recordAgentResult(ctx, outcome.result, userID); err != nil {
+		outcome.err = err
+	}
+	return outcome
+}
+
+// graphStepFailure is the outcome of a step that could not be dispatched
+func graphStepFailure(plan *planningDomain.ExecutionPlan, step *planningDomain.ExecutionStep, err error) batchEventOutcome {
+	return batchEventOutcome{
+		event: agentEvent{AgentID: step.AssignedAgent, Action: step.Name, Content: step.Description, Intent: plan.Name, StepID: step.ID},
+		err:   err,
+	}
+}
@@ -0,0 +1,175 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	aiInfrastructure "neuromesh/internal/ai/infrastructure"
+	"neuromesh/internal/messaging"
+	"neuromesh/internal/orchestrator/infrastructure"
+	planningDomain "neuromesh/internal/planning/domain"
+	"neuromesh/testHelpers"
+)
+
+func TestAIExecutionEngine_ExecutesDiamondDependencyGraph(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// fetch runs first, parse and scan both wait only for fetch, report waits for parse and scan
+	plan := planningDomain.NewExecutionPlan("Audit", "Audit the repository", planningDomain.ExecutionPlanPriorityMedium)
+	fetch := planningDomain.NewExecutionStep("Fetch", "Fetch the repository", "git-agent")
+	parse := planningDomain.NewExecutionStep("Parse", "Parse the sources", "parser-agent")
+	scan := planningDomain.NewExecutionStep("Scan", "Scan dependencies", "scanner-agent")
+	report := planningDomain.NewExecutionStep("Report", "Write the report", "report-agent")
+	for _, step := range []*planningDomain.ExecutionStep{fetch, parse, scan, report} {
+		require.NoError(t, plan.AddStep(step))
+	}
+	parse.DependOn(fetch)
+	scan.DependOn(fetch)
+	report.DependOn(parse, scan)
+
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	require.NoError(t, planRepo.Create(ctx, plan))
+
+	aiProvider := aiInfrastructure.NewScriptedProvider(map[string]string{
+		"Synthesize the agent responses": "USER_RESPONSE:\nAudit report written",
+	})
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	engine := NewAIExecutionEngineWithRepository(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker(), planRepo)
+
+	responses := make(chan *messaging.Message, 10)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil)
+	dispatched := make(chan *messaging.AIToAgentMessage, 10)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { dispatched <- args.Get(1).(*messaging.AIToAgentMessage) }).
+		Return(nil)
+
+	// nextDispatch waits for the engine's next dispatch, returning it by step ID
+	nextDispatch := func(t *testing.T) (string, *messaging.AIToAgentMessage) {
+		t.Helper()
+		select {
+		case msg := <-dispatched:
+			return msg.Context["step_id"].(string), msg
+		case <-time.After(time.Second):
+			t.Fatal("expected a step to be dispatched")
+			return "", nil
+		}
+	}
+	assertNoDispatch := func(t *testing.T) {
+		t.Helper()
+		select {
+		case msg := <-dispatched:
+			t.Fatalf("step %s was dispatched before its dependencies completed", msg.Context["step_id"])
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	reply := func(msg *messaging.AIToAgentMessage) {
+		responses <- &messaging.Message{
+			CorrelationID: msg.CorrelationID,
+			FromID:        msg.AgentID,
+			Content:       msg.Content + " done",
+			MessageType:   messaging.MessageTypeAgentToAI,
+		}
+	}
+
+	type execution struct {
+		result string
+		err    error
+	}
+	finished := make(chan execution, 1)
+	go func() {
+		result, err := engine.ExecuteWithAgents(ctx, plan.ID, "audit the repository", "user-1", "")
+		finished <- execution{result, err}
+	}()
+
+	stepID, fetchMsg := nextDispatch(t)
+	assert.Equal(t, fetch.ID, stepID)
+	assertNoDispatch(t)
+	reply(fetchMsg)
+
+	// parse and scan are independent, so both are dispatched before either responds
+	first, firstMsg := nextDispatch(t)
+	second, secondMsg := nextDispatch(t)
+	assert.ElementsMatch(t, []string{parse.ID, scan.ID}, []string{first, second})
+
+	reply(firstMsg)
+	assertNoDispatch(t)
+	reply(secondMsg)
+
+	stepID, reportMsg := nextDispatch(t)
+	assert.Equal(t, report.ID, stepID)
+	assert.ElementsMatch(t, []string{parse.ID, scan.ID}, reportMsg.Context["depends_on"])
+	reply(reportMsg)
+
+	outcome := <-finished
+	require.NoError(t, outcome.err)
+	assert.Equal(t, "Audit report written", outcome.result)
+
+	for _, step := range []*planningDomain.ExecutionStep{fetch, parse, scan, report} {
+		persisted, err := planRepo.GetStepByID(ctx, step.ID)
+		require.NoError(t, err)
+		assert.Equal(t, planningDomain.ExecutionStepStatusCompleted, persisted.Status, step.Name)
+	}
+	for _, call := range aiProvider.Calls() {
+		assert.NotContains(t, call.UserPrompt, "Execute plan", "the AI does not sequence a plan with dependencies")
+	}
+}
+
+func TestAIExecutionEngine_SkipsStepsDependingOnFailedStep(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	plan := planningDomain.NewExecutionPlan("Deploy", "Deploy the application", planningDomain.ExecutionPlanPriorityMedium)
+	build := planningDomain.NewExecutionStep("Build", "Build the image", "build-agent")
+	lint := planningDomain.NewExecutionStep("Lint", "Lint the sources", "lint-agent")
+	deploy := planningDomain.NewExecutionStep("Deploy", "Deploy the image", "deploy-agent")
+	for _, step := range []*planningDomain.ExecutionStep{build, lint, deploy} {
+		require.NoError(t, plan.AddStep(step))
+	}
+	deploy.DependOn(build)
+	build.MaxRetries = 1
+
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	require.NoError(t, planRepo.Create(ctx, plan))
+
+	aiProvider := aiInfrastructure.NewScriptedProvider(map[string]string{
+		"Synthesize the agent responses": "USER_RESPONSE:\nBuild failed, nothing deployed",
+	})
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	engine := NewAIExecutionEngineWithRepository(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker(), planRepo)
+	engine.retryBaseDelay = time.Millisecond
+
+	responses := make(chan *messaging.Message, 10)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := args.Get(1).(*messaging.AIToAgentMessage)
+			reply := &messaging.Message{CorrelationID: msg.CorrelationID, FromID: msg.AgentID, Content: "ok", MessageType: messaging.MessageTypeAgentToAI}
+			if msg.AgentID == "build-agent" {
+				reply.Metadata = map[string]interface{}{"success": false, "error": "compilation failed"}
+			}
+			responses <- reply
+		}).
+		Return(nil)
+
+	result, err := engine.ExecuteWithAgents(ctx, plan.ID, "deploy", "user-1", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Build failed, nothing deployed", result)
+	// The build is retried once before its dependents are skipped
+	aiMessageBus.AssertNumberOfCalls(t, "SendToAgent", 3)
+	for _, call := range aiMessageBus.Calls {
+		if call.Method == "SendToAgent" {
+			assert.NotEqual(t, "deploy-agent", call.Arguments.Get(1).(*messaging.AIToAgentMessage).AgentID)
+		}
+	}
+
+	calls := aiProvider.Calls()
+	require.NotEmpty(t, calls)
+	assert.Contains(t, calls[len(calls)-1].SystemPrompt, "step dependency failed")
+}
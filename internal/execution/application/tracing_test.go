@@ -0,0 +1,85 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"neuromesh/internal/messaging"
+	"neuromesh/internal/orchestrator/infrastructure"
+	"neuromesh/internal/tracing"
+	"neuromesh/testHelpers"
+)
+
+func TestAIExecutionEngine_TracesSingleAgentConversation(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	aiProvider := &MockAIProvider{}
+	aiMessageBus := testHelpers.NewMockAIMessageBus()
+	engine := NewAIExecutionEngine(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker())
+
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Execute plan for user request: count words").
+		Return("SEND_EVENT:\nAgent: text-processor\nAction: word-count\nContent: Count words\nIntent: analysis\nStep: step-1", nil).Once()
+	aiProvider.On("CallAI", mock.Anything, mock.Anything, "Process the agent response and determine next execution step.").
+		Return("USER_RESPONSE:\nThe text contains 2 words", nil).Once()
+
+	var eventContext map[string]interface{}
+	responses := make(chan *messaging.Message, 1)
+	aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil)
+	aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := args.Get(1).(*messaging.AIToAgentMessage)
+			eventContext = msg.Context
+			responses <- &messaging.Message{
+				CorrelationID: msg.CorrelationID,
+				FromID:        msg.AgentID,
+				Content:       "The text contains 2 words.",
+				MessageType:   messaging.MessageTypeAgentToAI,
+			}
+		}).
+		Return(nil).Once()
+
+	_, err := engine.ExecuteWithAgents(ctx, "plan-1", "count words", "user-1", "")
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	byName := map[string][]tracetest.SpanStub{}
+	for _, span := range spans {
+		byName[span.Name] = append(byName[span.Name], span)
+	}
+
+	require.Len(t, byName["execution.execute_with_agents"], 1)
+	root := byName["execution.execute_with_agents"][0]
+	assert.False(t, root.Parent.IsValid())
+
+	require.Len(t, byName["execution.ai_call"], 2)
+	for _, aiCall := range byName["execution.ai_call"] {
+		assert.Equal(t, root.SpanContext.SpanID(), aiCall.Parent.SpanID())
+	}
+
+	require.Len(t, byName["execution.agent_event"], 1)
+	agentEvent := byName["execution.agent_event"][0]
+	assert.Equal(t, root.SpanContext.SpanID(), agentEvent.Parent.SpanID())
+	assert.Equal(t, root.SpanContext.TraceID(), agentEvent.SpanContext.TraceID())
+
+	// The agent receives the trace context with the event, parented to the agent round-trip span
+	remote := tracing.Extract(context.Background(), eventContext)
+	remoteSpan := trace.SpanContextFromContext(remote)
+	assert.Equal(t, root.SpanContext.TraceID(), remoteSpan.TraceID())
+	assert.Equal(t, agentEvent.SpanContext.SpanID(), remoteSpan.SpanID())
+}
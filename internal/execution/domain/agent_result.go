@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AgentResultStatus represents whether an agent succeeded or failed at its task
+type AgentResultStatus string
+
+const (
+	AgentResultStatusSuccess    AgentResultStatus = "SUCCESS"
+	AgentResultStatusFailed     AgentResultStatus = "FAILED"
+	AgentResultStatusInProgress AgentResultStatus = "IN_PROGRESS" // Incremental update; a terminal result follows
+)
+
+// AgentResult represents the outcome an agent reported for an execution step
+type AgentResult struct {
+	ID            string            `json:"id"`
+	PlanID        string            `json:"plan_id"`
+	StepID        string            `json:"step_id,omitempty"`
+	AgentID       string            `json:"agent_id"`
+	CorrelationID string            `json:"correlation_id"`
+	Content       string            `json:"content"`
+	Status        AgentResultStatus `json:"status"`
+	ErrorMessage  string            `json:"error_message,omitempty"`
+	Sequence      int               `json:"sequence,omitempty"` // Orders the in-progress updates of one agent event
+	Timestamp     time.Time         `json:"timestamp"`
+}
+
+// NewAgentResult creates a new agent result bound to an explicit plan ID
+func NewAgentResult(planID, stepID, agentID, correlationID, content string) *AgentResult {
+	return &AgentResult{
+		ID:            uuid.New().String(),
+		PlanID:        planID,
+		StepID:        stepID,
+		AgentID:       agentID,
+		CorrelationID: correlationID,
+		Content:       content,
+		Status:        AgentResultStatusSuccess,
+		Timestamp:     time.Now(),
+	}
+}
+
+// HasPlan returns true if the result is linked to an execution plan
+func (r *AgentResult) HasPlan() bool {
+	return r.PlanID != ""
+}
+
+// HasStep returns true if the result is linked to a specific execution step
+func (r *AgentResult) HasStep() bool {
+	return r.StepID != ""
+}
+
+// MarkFailed records that the agent failed to complete its task
+func (r *AgentResult) MarkFailed(errorMessage string) {
+	r.Status = AgentResultStatusFailed
+	r.ErrorMessage = errorMessage
+}
+
+// IsFailed returns true if the agent reported a failure
+func (r *AgentResult) IsFailed() bool {
+	return r.Status == AgentResultStatusFailed
+}
+
+// MarkInProgress records that the result is the given incremental update rather than the agent's final outcome
+func (r *AgentResult) MarkInProgress(sequence int) {
+	r.Status = AgentResultStatusInProgress
+	r.Sequence = sequence
+}
+
+// IsInProgress returns true if the result is an incremental update
+func (r *AgentResult) IsInProgress() bool {
+	return r.Status == AgentResultStatusInProgress
+}
+
+// StepResults groups the results reported for one execution step
+type StepResults struct {
+	StepID     string         `json:"step_id"`
+	StepNumber int            `json:"step_number"`
+	Results    []*AgentResult `json:"results"` // In the order they were reported
+}
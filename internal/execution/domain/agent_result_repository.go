@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+// AgentResultRepository defines the interface for agent result persistence
+type AgentResultRepository interface {
+	Store(ctx context.Context, result *AgentResult) error
+	GetAgentResultsByExecutionPlan(ctx context.Context, planID string) ([]*AgentResult, error)
+	// GetOrderedAgentResults returns a plan's results grouped by step in step number order
+	GetOrderedAgentResults(ctx context.Context, planID string) ([]*StepResults, error)
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAgentResult(t *testing.T) {
+	t.Run("should carry explicit plan and step IDs", func(t *testing.T) {
+		result := NewAgentResult("3f2c9a1e-plan", "step-xyz", "text-processor", "exec-user-1", "done")
+
+		assert.NotEmpty(t, result.ID)
+		assert.Equal(t, "3f2c9a1e-plan", result.PlanID)
+		assert.Equal(t, "step-xyz", result.StepID)
+		assert.Equal(t, "text-processor", result.AgentID)
+		assert.Equal(t, "exec-user-1", result.CorrelationID)
+		assert.Equal(t, "done", result.Content)
+		assert.False(t, result.Timestamp.IsZero())
+		assert.True(t, result.HasPlan())
+		assert.True(t, result.HasStep())
+		assert.Equal(t, AgentResultStatusSuccess, result.Status)
+		assert.False(t, result.IsFailed())
+	})
+
+	t.Run("should report missing plan and step", func(t *testing.T) {
+		result := NewAgentResult("", "", "text-processor", "exec-user-1", "done")
+
+		assert.False(t, result.HasPlan())
+		assert.False(t, result.HasStep())
+	})
+}
+
+func TestAgentResult_MarkFailed(t *testing.T) {
+	result := NewAgentResult("plan-1", "step-1", "text-processor", "exec-user-1", "")
+
+	result.MarkFailed("agent crashed")
+
+	assert.Equal(t, AgentResultStatusFailed, result.Status)
+	assert.Equal(t, "agent crashed", result.ErrorMessage)
+	assert.True(t, result.IsFailed())
+}
+
+func TestAgentResult_MarkInProgress(t *testing.T) {
+	result := NewAgentResult("plan-1", "step-1", "deployer", "exec-user-1", "deployed 2/5 services")
+
+	result.MarkInProgress(2)
+
+	assert.Equal(t, AgentResultStatusInProgress, result.Status)
+	assert.Equal(t, 2, result.Sequence)
+	assert.True(t, result.IsInProgress())
+	assert.False(t, result.IsFailed())
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type executionIDKey struct{}
+
+// NewExecutionID creates the ID of one request's execution; cancelling it cancels every agent request the execution makes
+func NewExecutionID(userID string) string {
+	return fmt.Sprintf("exec-%s-%s", userID, uuid.New().String())
+}
+
+// WithExecutionID returns a context carrying the ID the execution of a request runs under
+func WithExecutionID(ctx context.Context, executionID string) context.Context {
+	return context.WithValue(ctx, executionIDKey{}, executionID)
+}
+
+// ExecutionIDFromContext returns the execution ID carried by ctx, or an empty string
+func ExecutionIDFromContext(ctx context.Context) string {
+	executionID, _ := ctx.Value(executionIDKey{}).(string)
+	return executionID
+}
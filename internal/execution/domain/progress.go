@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ProgressEventType identifies a stage of request orchestration reported to listeners
+type ProgressEventType string
+
+const (
+	ProgressEventExecutionStarted ProgressEventType = "execution_started" // Message carries the execution ID, which cancels the request
+	ProgressEventDecisionMade     ProgressEventType = "decision_made"
+	ProgressEventAgentEventSent   ProgressEventType = "agent_event_sent"
+	ProgressEventAgentResponded   ProgressEventType = "agent_responded"
+	ProgressEventAgentProgress    ProgressEventType = "agent_progress" // An in-progress update from an agent still working
+	ProgressEventFinalAnswer      ProgressEventType = "final_answer"
+)
+
+// ProgressEvent describes an incremental step of orchestration as it happens
+type ProgressEvent struct {
+	Type      ProgressEventType `json:"type"`
+	AgentID   string            `json:"agent_id,omitempty"`
+	Message   string            `json:"message"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// ProgressReporter receives progress events; it may be called from several goroutines
+type ProgressReporter func(event ProgressEvent)
+
+type progressReporterKey struct{}
+
+// NewProgressEvent creates a progress event stamped with the current time
+func NewProgressEvent(eventType ProgressEventType, agentID, message string) ProgressEvent {
+	return ProgressEvent{
+		Type:      eventType,
+		AgentID:   agentID,
+		Message:   message,
+		Timestamp: time.Now(),
+	}
+}
+
+// WithProgressReporter returns a context that carries the given progress reporter
+func WithProgressReporter(ctx context.Context, reporter ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressReporterKey{}, reporter)
+}
+
+// ReportProgress sends an event to the progress reporter carried by ctx, if any
+func ReportProgress(ctx context.Context, event ProgressEvent) {
+	if reporter, ok := ctx.Value(progressReporterKey{}).(ProgressReporter); ok && reporter != nil {
+		reporter(event)
+	}
+}
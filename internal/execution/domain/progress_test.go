@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportProgress(t *testing.T) {
+	t.Run("should deliver events to the reporter in context", func(t *testing.T) {
+		var events []ProgressEvent
+		ctx := WithProgressReporter(context.Background(), func(event ProgressEvent) {
+			events = append(events, event)
+		})
+
+		ReportProgress(ctx, NewProgressEvent(ProgressEventAgentEventSent, "text-processor", "count words"))
+
+		assert.Len(t, events, 1)
+		assert.Equal(t, ProgressEventAgentEventSent, events[0].Type)
+		assert.Equal(t, "text-processor", events[0].AgentID)
+		assert.Equal(t, "count words", events[0].Message)
+		assert.False(t, events[0].Timestamp.IsZero())
+	})
+
+	t.Run("should ignore events without reporter", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			ReportProgress(context.Background(), NewProgressEvent(ProgressEventFinalAnswer, "", "done"))
+		})
+	})
+}
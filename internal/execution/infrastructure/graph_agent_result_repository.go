@@ -0,0 +1,156 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"neuromesh/internal/execution/domain"
+	"neuromesh/internal/graph"
+)
+
+const (
+	// NodeTypeAgentResult is the graph node type for stored agent results
+	NodeTypeAgentResult = "agent_result"
+
+	// RelationshipHasResult links an execution plan and its steps to the results their agents reported
+	RelationshipHasResult = "HAS_RESULT"
+)
+
+// GraphAgentResultRepository implements AgentResultRepository using the graph backend
+type GraphAgentResultRepository struct {
+	graph graph.Graph
+}
+
+// NewGraphAgentResultRepository creates a new graph-based agent result repository
+func NewGraphAgentResultRepository(g graph.Graph) *GraphAgentResultRepository {
+	return &GraphAgentResultRepository{
+		graph: g,
+	}
+}
+
+// EnsureSchema ensures the constraints and indexes for agent results are in place
+func (r *GraphAgentResultRepository) EnsureSchema(ctx context.Context) error {
+	if err := r.graph.CreateUniqueConstraint(ctx, NodeTypeAgentResult, "id"); err != nil {
+		return fmt.Errorf("failed to create unique constraint for %s.id: %w", NodeTypeAgentResult, err)
+	}
+
+	if err := r.graph.CreateIndex(ctx, NodeTypeAgentResult, "plan_id"); err != nil {
+		return fmt.Errorf("failed to create index for %s.plan_id: %w", NodeTypeAgentResult, err)
+	}
+
+	return nil
+}
+
+// Store persists an agent result and links it to its execution plan and step
+func (r *GraphAgentResultRepository) Store(ctx context.Context, result *domain.AgentResult) error {
+	if !result.HasPlan() {
+		return fmt.Errorf("agent result %s is not linked to an execution plan", result.ID)
+	}
+
+	properties := map[string]interface{}{
+		"id":             result.ID,
+		"plan_id":        result.PlanID,
+		"step_id":        result.StepID,
+		"agent_id":       result.AgentID,
+		"correlation_id": result.CorrelationID,
+		"content":        result.Content,
+		"status":         string(result.Status),
+		"error_message":  result.ErrorMessage,
+		"sequence":       result.Sequence,
+		"timestamp":      result.Timestamp.UTC(),
+	}
+
+	if err := r.graph.AddNode(ctx, NodeTypeAgentResult, result.ID, properties); err != nil {
+		return fmt.Errorf("failed to store agent result: %w", err)
+	}
+
+	if err := r.graph.AddEdge(ctx, "execution_plan", result.PlanID, NodeTypeAgentResult, result.ID, RelationshipHasResult, nil); err != nil {
+		return fmt.Errorf("failed to link agent result to execution plan: %w", err)
+	}
+
+	// Results for steps that were never persisted stay linked to the plan only
+	if result.HasStep() {
+		if err := r.graph.AddEdge(ctx, "execution_step", result.StepID, NodeTypeAgentResult, result.ID, RelationshipHasResult, nil); err != nil {
+			return fmt.Errorf("failed to link agent result to execution step: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetAgentResultsByExecutionPlan retrieves all results reported for a plan in timestamp order, in-progress updates included
+func (r *GraphAgentResultRepository) GetAgentResultsByExecutionPlan(ctx context.Context, planID string) ([]*domain.AgentResult, error) {
+	nodes, err := r.graph.QueryNodes(ctx, NodeTypeAgentResult, map[string]interface{}{
+		"plan_id": planID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query agent results by plan ID: %w", err)
+	}
+
+	results := make([]*domain.AgentResult, 0, len(nodes))
+	for _, data := range nodes {
+		results = append(results, r.mapToAgentResult(data))
+	}
+
+	// In-progress updates reported within the same instant keep their sequence order
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Timestamp.Equal(results[j].Timestamp) {
+			return results[i].Sequence < results[j].Sequence
+		}
+		return results[i].Timestamp.Before(results[j].Timestamp)
+	})
+
+	return results, nil
+}
+
+// GetOrderedAgentResults retrieves a plan's results grouped by step in step number order
+// The plan, its steps and their results are read in a single traversal, whatever the number of steps
+func (r *GraphAgentResultRepository) GetOrderedAgentResults(ctx context.Context, planID string) ([]*domain.StepResults, error) {
+	paths, err := r.graph.TraversePath(ctx, "execution_plan", planID, []graph.Hop{
+		{EdgeType: "CONTAINS_STEP", TargetType: "execution_step", OrderBy: "step_number"},
+		{EdgeType: RelationshipHasResult, TargetType: NodeTypeAgentResult, OrderBy: "timestamp"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ordered agent results for plan %s: %w", planID, err)
+	}
+
+	groups := []*domain.StepResults{}
+	for _, path := range paths {
+		step, result := path[0], r.mapToAgentResult(path[1])
+		stepID, _ := step["id"].(string)
+
+		// Paths arrive ordered by step, so a step's results are contiguous
+		if len(groups) == 0 || groups[len(groups)-1].StepID != stepID {
+			stepNumber, _ := step["step_number"].(int)
+			groups = append(groups, &domain.StepResults{StepID: stepID, StepNumber: stepNumber})
+		}
+		group := groups[len(groups)-1]
+		group.Results = append(group.Results, result)
+	}
+
+	return groups, nil
+}
+
+// mapToAgentResult converts graph node data to an AgentResult
+func (r *GraphAgentResultRepository) mapToAgentResult(data map[string]interface{}) *domain.AgentResult {
+	result := &domain.AgentResult{}
+
+	result.ID, _ = data["id"].(string)
+	result.PlanID, _ = data["plan_id"].(string)
+	result.StepID, _ = data["step_id"].(string)
+	result.AgentID, _ = data["agent_id"].(string)
+	result.CorrelationID, _ = data["correlation_id"].(string)
+	result.Content, _ = data["content"].(string)
+	result.ErrorMessage, _ = data["error_message"].(string)
+	result.Sequence, _ = data["sequence"].(int)
+	if status, ok := data["status"].(string); ok {
+		result.Status = domain.AgentResultStatus(status)
+	}
+	if timestamp, ok := data["timestamp"].(time.Time); ok {
+		result.Timestamp = timestamp
+	}
+
+	return result
+}
@@ -0,0 +1,122 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuromesh/internal/execution/domain"
+	"neuromesh/internal/graph"
+	"neuromesh/internal/ids"
+	"neuromesh/testHelpers"
+)
+
+// readCountingGraph counts the read operations issued against the wrapped graph
+type readCountingGraph struct {
+	graph.Graph
+	reads int
+}
+
+func (g *readCountingGraph) GetNode(ctx context.Context, nodeType, nodeID string) (map[string]interface{}, error) {
+	g.reads++
+	return g.Graph.GetNode(ctx, nodeType, nodeID)
+}
+
+func (g *readCountingGraph) QueryNodes(ctx context.Context, nodeType string, filters map[string]interface{}) ([]map[string]interface{}, error) {
+	g.reads++
+	return g.Graph.QueryNodes(ctx, nodeType, filters)
+}
+
+func (g *readCountingGraph) QueryNodesWithOptions(ctx context.Context, nodeType string, filters map[string]interface{}, opts graph.QueryOptions) ([]map[string]interface{}, error) {
+	g.reads++
+	return g.Graph.QueryNodesWithOptions(ctx, nodeType, filters, opts)
+}
+
+func (g *readCountingGraph) GetEdges(ctx context.Context, nodeType, nodeID string) ([]map[string]interface{}, error) {
+	g.reads++
+	return g.Graph.GetEdges(ctx, nodeType, nodeID)
+}
+
+func (g *readCountingGraph) GetEdgesWithTargets(ctx context.Context, nodeType, nodeID string) ([]map[string]interface{}, error) {
+	g.reads++
+	return g.Graph.GetEdgesWithTargets(ctx, nodeType, nodeID)
+}
+
+func (g *readCountingGraph) TraversePath(ctx context.Context, nodeType, nodeID string, hops []graph.Hop) ([][]map[string]interface{}, error) {
+	g.reads++
+	return g.Graph.TraversePath(ctx, nodeType, nodeID, hops)
+}
+
+// seedPlanResults stores a plan whose steps are created in reverse order, each with resultsPerStep results
+func seedPlanResults(t *testing.T, g graph.Graph, repo *GraphAgentResultRepository, planID string, steps, resultsPerStep int) {
+	t.Helper()
+	ctx := context.Background()
+	require.NoError(t, g.AddNode(ctx, "execution_plan", planID, map[string]interface{}{}))
+
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for number := steps; number >= 1; number-- {
+		stepID := ids.Step(planID, number)
+		require.NoError(t, g.AddNode(ctx, "execution_step", stepID, map[string]interface{}{"plan_id": planID, "step_number": number}))
+		require.NoError(t, g.AddEdge(ctx, "execution_plan", planID, "execution_step", stepID, "CONTAINS_STEP", nil))
+
+		// Later attempts are stored first to check results are ordered by report time within a step
+		for attempt := resultsPerStep; attempt >= 1; attempt-- {
+			result := domain.NewAgentResult(planID, stepID, "text-processor", fmt.Sprintf("corr-%d-%d", number, attempt), fmt.Sprintf("step %d attempt %d", number, attempt))
+			result.Timestamp = base.Add(time.Duration(number*10+attempt) * time.Second)
+			require.NoError(t, repo.Store(ctx, result))
+		}
+	}
+}
+
+func TestGraphAgentResultRepository_GetOrderedAgentResults(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("groups results by step in step number order", func(t *testing.T) {
+		g := testHelpers.NewCleanMockGraph()
+		repo := NewGraphAgentResultRepository(g)
+		seedPlanResults(t, g, repo, "plan-ordered", 3, 2)
+
+		// A result for a step that was never persisted is not part of any step group
+		require.NoError(t, repo.Store(ctx, domain.NewAgentResult("plan-ordered", "unknown-step", "text-processor", "corr-x", "orphan")))
+
+		groups, err := repo.GetOrderedAgentResults(ctx, "plan-ordered")
+		require.NoError(t, err)
+		require.Len(t, groups, 3)
+
+		for i, group := range groups {
+			assert.Equal(t, i+1, group.StepNumber)
+			assert.Equal(t, ids.Step("plan-ordered", i+1), group.StepID)
+			require.Len(t, group.Results, 2)
+			assert.Equal(t, fmt.Sprintf("step %d attempt 1", i+1), group.Results[0].Content)
+			assert.Equal(t, fmt.Sprintf("step %d attempt 2", i+1), group.Results[1].Content)
+			assert.Equal(t, group.StepID, group.Results[0].StepID)
+		}
+	})
+
+	t.Run("reads the graph once whatever the number of steps", func(t *testing.T) {
+		for _, steps := range []int{1, 5, 20} {
+			counting := &readCountingGraph{Graph: testHelpers.NewCleanMockGraph()}
+			repo := NewGraphAgentResultRepository(counting)
+			planID := fmt.Sprintf("plan-%d-steps", steps)
+			seedPlanResults(t, counting, repo, planID, steps, 1)
+			counting.reads = 0
+
+			groups, err := repo.GetOrderedAgentResults(ctx, planID)
+			require.NoError(t, err)
+			assert.Len(t, groups, steps)
+			assert.Equal(t, 1, counting.reads, "plan with %d steps", steps)
+		}
+	})
+
+	t.Run("returns no groups for a plan without results", func(t *testing.T) {
+		repo := NewGraphAgentResultRepository(testHelpers.NewCleanMockGraph())
+
+		groups, err := repo.GetOrderedAgentResults(ctx, "plan-empty")
+		require.NoError(t, err)
+		assert.Empty(t, groups)
+	})
+}
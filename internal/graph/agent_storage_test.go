@@ -20,10 +20,11 @@ func TestNeo4jAgentStorageAndRetrieval(t *testing.T) {
 
 	// Try to connect to real Neo4j
 	config := GraphConfig{
-		Backend:       GraphBackendNeo4j,
-		Neo4jURL:      "bolt://localhost:7687",
-		Neo4jUser:     "neo4j",
-		Neo4jPassword: "orchestrator123",
+		Backend:            GraphBackendNeo4j,
+		Neo4jURL:           "bolt://localhost:7687",
+		Neo4jUser:          "neo4j",
+		Neo4jPassword:      "orchestrator123",
+		AllowClearTestData: true,
 	}
 
 	graph, err := NewNeo4jGraph(ctx, config, logger)
@@ -128,10 +129,11 @@ func TestGraphAgentServiceIntegration(t *testing.T) {
 
 	// Create graph
 	config := GraphConfig{
-		Backend:       GraphBackendNeo4j,
-		Neo4jURL:      "bolt://localhost:7687",
-		Neo4jUser:     "neo4j",
-		Neo4jPassword: "orchestrator123",
+		Backend:            GraphBackendNeo4j,
+		Neo4jURL:           "bolt://localhost:7687",
+		Neo4jUser:          "neo4j",
+		Neo4jPassword:      "orchestrator123",
+		AllowClearTestData: true,
 	}
 
 	graph, err := NewNeo4jGraph(ctx, config, logger)
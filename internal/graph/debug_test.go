@@ -19,10 +19,11 @@ func TestDebugNeo4jStorage(t *testing.T) {
 
 	// Connect to Neo4j
 	config := GraphConfig{
-		Backend:       GraphBackendNeo4j,
-		Neo4jURL:      "bolt://localhost:7687",
-		Neo4jUser:     "neo4j",
-		Neo4jPassword: "orchestrator123",
+		Backend:            GraphBackendNeo4j,
+		Neo4jURL:           "bolt://localhost:7687",
+		Neo4jUser:          "neo4j",
+		Neo4jPassword:      "orchestrator123",
+		AllowClearTestData: true,
 	}
 
 	graph, err := NewNeo4jGraph(ctx, config, logger)
@@ -2,18 +2,43 @@ package graph
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
 
 	"neuromesh/internal/logging"
 )
 
+// ErrNodeNotFound is returned when a node lookup matches nothing
+var ErrNodeNotFound = errors.New("node not found")
+
+// ErrClearTestDataDisabled is returned when ClearTestData is called without opting in
+var ErrClearTestDataDisabled = errors.New("clearing all graph data is disabled: set AllowClearTestData or " + AllowClearTestDataEnv + "=true")
+
+// AllowClearTestDataEnv is the environment variable that enables ClearTestData when set to "true"
+const AllowClearTestDataEnv = "NEUROMESH_ALLOW_CLEAR_TEST_DATA"
+
+// TimestampFormat stores UTC times with fixed-width nanoseconds, so stored timestamps compare chronologically as strings
+const TimestampFormat = "2006-01-02T15:04:05.000000000Z"
+
 // Graph defines a simple interface for basic graph operations
 type Graph interface {
 	// Node operations - basic CRUD
 	AddNode(ctx context.Context, nodeType, nodeID string, properties map[string]interface{}) error
 	GetNode(ctx context.Context, nodeType, nodeID string) (map[string]interface{}, error)
 	UpdateNode(ctx context.Context, nodeType, nodeID string, properties map[string]interface{}) error
+	MergeNodeProperty(ctx context.Context, nodeType, nodeID, key string, value map[string]interface{}) error
+	MergeNode(ctx context.Context, nodeType, nodeID string, properties map[string]interface{}) (bool, error)              // Atomically creates the node unless one with nodeID exists, reporting whether it did
+	UpdateNodeIf(ctx context.Context, nodeType, nodeID string, expected, properties map[string]interface{}) (bool, error) // Atomically updates the node only while its properties equal expected, reporting whether it did
 	DeleteNode(ctx context.Context, nodeType, nodeID string) error
+	DeleteNodesByFilter(ctx context.Context, nodeType string, filters map[string]interface{}) (int, error)    // Detaches and deletes matching nodes, returning how many were deleted
+	DeleteOrphanedNodes(ctx context.Context, nodeType, edgeType string, createdBefore time.Time) (int, error) // Detaches and deletes, in one statement, the nodes no edgeType edge points to that were created before createdBefore
 	QueryNodes(ctx context.Context, nodeType string, filters map[string]interface{}) ([]map[string]interface{}, error)
+	QueryNodesWithOptions(ctx context.Context, nodeType string, filters map[string]interface{}, opts QueryOptions) ([]map[string]interface{}, error)
+	QueryNodesAdvanced(ctx context.Context, nodeType string, conditions []Condition) ([]map[string]interface{}, error)
+	CountNodes(ctx context.Context, nodeType string, filters map[string]interface{}) (int, error)
 
 	// Edge operations - basic CRUD
 	AddEdge(ctx context.Context, sourceType, sourceID, targetType, targetID, edgeType string, properties map[string]interface{}) error
@@ -22,19 +47,192 @@ type Graph interface {
 	UpdateEdge(ctx context.Context, sourceType, sourceID, targetType, targetID, edgeType string, properties map[string]interface{}) error
 	DeleteEdge(ctx context.Context, sourceType, sourceID, targetType, targetID, edgeType string) error
 
+	// Traversal - follows hops from a node in one query; each path lists the node reached at every hop
+	TraversePath(ctx context.Context, nodeType, nodeID string, hops []Hop) ([][]map[string]interface{}, error)
+
+	// FollowChain returns a node and every node reachable from it over a chain of edgeType edges in one query, nearest first
+	FollowChain(ctx context.Context, nodeType, nodeID, edgeType string) ([]map[string]interface{}, error)
+
+	// QueryNodesByRelated returns the distinct nodes with an edge to a target node matching all targetFilters
+	QueryNodesByRelated(ctx context.Context, nodeType, edgeType, targetType string, targetFilters map[string]interface{}) ([]map[string]interface{}, error)
+
+	// Aggregation operations
+	CountRelatedNodesByProperty(ctx context.Context, nodeType, nodeID, edgeType, property string) (map[string]int, error)
+	Aggregate(ctx context.Context, aggregation Aggregation) ([]AggregateGroup, error) // Groups and aggregates nodes in one query
+
+	// Full-text search - query is plain text; conditions filter matches before they are paged by score; results carry a "score" property
+	FullTextSearch(ctx context.Context, nodeType, property, query string, conditions []Condition, limit, offset int) ([]map[string]interface{}, error)
+
 	// Schema operations - for database schema management
 	CreateUniqueConstraint(ctx context.Context, nodeType, property string) error
 	CreateIndex(ctx context.Context, nodeType, property string) error
+	CreateFullTextIndex(ctx context.Context, nodeType, property string) error
 	DropIndex(ctx context.Context, nodeType, property string) error
+	DropUniqueConstraint(ctx context.Context, nodeType, property string) error
 	HasUniqueConstraint(ctx context.Context, nodeType, property string) (bool, error)
 	HasIndex(ctx context.Context, nodeType, property string) (bool, error)
 	HasRelationshipType(ctx context.Context, relationshipType string) (bool, error)
 
 	// Utility
-	GetStats() map[string]interface{}
+	GetStats(ctx context.Context) (map[string]interface{}, error) // total_nodes, total_edges and nodes_by_label
+
 	Close(ctx context.Context) error
 }
 
+// MergeMaps deep-merges src into a copy of dst; nested maps are merged and other values in src win
+func MergeMaps(dst, src map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(dst)+len(src))
+	for k, v := range dst {
+		merged[k] = v
+	}
+
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]interface{})
+		dstMap, dstIsMap := merged[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			merged[k] = MergeMaps(dstMap, srcMap)
+			continue
+		}
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// EncodeMapProperty encodes a nested map as the JSON string every map-valued node property is stored as
+// Neo4j cannot store maps as property values, so all writers go through this encoding
+func EncodeMapProperty(value interface{}) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode map property: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// DecodeMapProperty reads a nested map property written by EncodeMapProperty; a map value is returned as is
+func DecodeMapProperty(value interface{}) (map[string]interface{}, error) {
+	switch v := value.(type) {
+	case nil:
+		return map[string]interface{}{}, nil
+	case map[string]interface{}:
+		return v, nil
+	case string:
+		if v == "" {
+			return map[string]interface{}{}, nil
+		}
+		var decoded map[string]interface{}
+		if err := json.Unmarshal([]byte(v), &decoded); err != nil {
+			return nil, fmt.Errorf("failed to decode map property: %w", err)
+		}
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("unsupported map property type %T", value)
+	}
+}
+
+// DecodeStringSliceProperty reads a list property as a string slice, returning an empty slice when absent
+func DecodeStringSliceProperty(value interface{}) []string {
+	if strs, ok := convertStringSlice(value); ok {
+		return strs
+	}
+	return []string{}
+}
+
+// QueryOptions controls ordering and size of a node query
+type QueryOptions struct {
+	OrderBy    string // Property to sort by; empty leaves the order unspecified
+	ThenBy     string // Optional property breaking ties in OrderBy, sorted in the same direction
+	Descending bool
+	Limit      int         // Maximum number of nodes returned; zero means no limit
+	Offset     int         // Number of ordered nodes skipped before the limit applies
+	After      interface{} // Optional OrderBy value of the last node already seen; only nodes ordered after it are returned
+}
+
+// Hop is one outgoing edge of a path followed by TraversePath
+type Hop struct {
+	EdgeType   string
+	TargetType string
+	OrderBy    string // Optional property of the hop's node that orders the paths; earlier hops take precedence
+}
+
+// RelatedPrefix marks the grouping values of an Aggregation's related node in AggregateGroup.Values
+const RelatedPrefix = "related."
+
+// Aggregation counts and averages the nodes matching Conditions, grouped by their own properties and,
+// when RelatedType is set, by the properties of the node optionally linked to each one by an incoming RelatedEdgeType edge
+type Aggregation struct {
+	NodeType        string
+	Conditions      []Condition
+	RelatedType     string
+	RelatedEdgeType string
+	GroupBy         []string // Properties of the aggregated nodes
+	RelatedGroupBy  []string // Properties of the related node; nil for nodes without one
+	AverageProperty string   // Property of the aggregated nodes averaged within each group
+}
+
+// AggregateGroup is one group of an Aggregation
+type AggregateGroup struct {
+	Values  map[string]interface{} // Grouping values by property; related node properties carry RelatedPrefix
+	Count   int
+	Average float64
+}
+
+// Validate checks that the aggregation can be safely turned into a query
+func (a Aggregation) Validate() error {
+	properties := append(append([]string{a.AverageProperty}, a.GroupBy...), a.RelatedGroupBy...)
+	for _, property := range properties {
+		if !isValidPropertyName(property) {
+			return fmt.Errorf("invalid aggregation property: %q", property)
+		}
+	}
+	if len(a.RelatedGroupBy) > 0 && (a.RelatedType == "" || a.RelatedEdgeType == "") {
+		return fmt.Errorf("grouping by related properties requires a related type and edge type")
+	}
+	return nil
+}
+
+// Operator is a comparison supported by QueryNodesAdvanced
+type Operator string
+
+const (
+	OperatorEquals      Operator = "="
+	OperatorLessThan    Operator = "<"
+	OperatorGreaterThan Operator = ">"
+	OperatorIn          Operator = "IN"       // Value must be a slice; matches nodes whose property is one of its elements
+	OperatorContains    Operator = "CONTAINS" // Value must be a string; matches string properties containing it
+)
+
+// Condition filters nodes on a single property; conditions passed together are combined with AND
+type Condition struct {
+	Field    string
+	Operator Operator
+	Value    interface{}
+}
+
+// Validate checks that the condition can be safely turned into a query
+func (c Condition) Validate() error {
+	if !isValidPropertyName(c.Field) {
+		return fmt.Errorf("invalid condition field: %q", c.Field)
+	}
+
+	switch c.Operator {
+	case OperatorEquals, OperatorLessThan, OperatorGreaterThan:
+		return nil
+	case OperatorIn:
+		if c.Value == nil || reflect.TypeOf(c.Value).Kind() != reflect.Slice {
+			return fmt.Errorf("IN condition on %s requires a slice value", c.Field)
+		}
+		return nil
+	case OperatorContains:
+		if _, ok := c.Value.(string); !ok {
+			return fmt.Errorf("CONTAINS condition on %s requires a string value", c.Field)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported condition operator: %q", c.Operator)
+	}
+}
+
 // GraphConfig defines configuration for graph backends
 type GraphConfig struct {
 	Backend string `json:"backend"`
@@ -42,8 +240,26 @@ type GraphConfig struct {
 	Neo4jURL      string `json:"neo4j_url,omitempty"`
 	Neo4jUser     string `json:"neo4j_user,omitempty"`
 	Neo4jPassword string `json:"neo4j_password,omitempty"`
+	Neo4jDatabase string `json:"neo4j_database,omitempty"` // Database sessions run against; empty uses the server default
+	// Neo4j connection pool tuning; zero values fall back to the defaults below
+	MaxConnectionPoolSize        int           `json:"max_connection_pool_size,omitempty"`
+	ConnectionAcquisitionTimeout time.Duration `json:"connection_acquisition_timeout,omitempty"`
+	MaxConnectionLifetime        time.Duration `json:"max_connection_lifetime,omitempty"`
+	FetchSize                    int           `json:"fetch_size,omitempty"`    // Records pulled per batch by read queries
+	QueryTimeout                 time.Duration `json:"query_timeout,omitempty"` // Server-side limit for each read or write transaction
+	// AllowClearTestData lets ClearTestData wipe the database; only test setups should enable it
+	AllowClearTestData bool `json:"-"`
 }
 
+// Neo4j connection pool defaults
+const (
+	DefaultMaxConnectionPoolSize        = 100
+	DefaultConnectionAcquisitionTimeout = 60 * time.Second
+	DefaultMaxConnectionLifetime        = time.Hour
+	DefaultFetchSize                    = 1000
+	DefaultQueryTimeout                 = 30 * time.Second
+)
+
 // Graph backend types
 const (
 	GraphBackendEmbedded = "embedded"
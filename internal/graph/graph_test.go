@@ -0,0 +1,115 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeMaps(t *testing.T) {
+	existing := map[string]interface{}{
+		"theme": "dark",
+		"preferences": map[string]interface{}{
+			"language": "en",
+			"timezone": "UTC",
+		},
+	}
+	update := map[string]interface{}{
+		"plan": "pro",
+		"preferences": map[string]interface{}{
+			"timezone": "CET",
+		},
+	}
+
+	merged := MergeMaps(existing, update)
+
+	assert.Equal(t, map[string]interface{}{
+		"theme": "dark",
+		"plan":  "pro",
+		"preferences": map[string]interface{}{
+			"language": "en",
+			"timezone": "CET",
+		},
+	}, merged)
+	assert.Equal(t, "UTC", existing["preferences"].(map[string]interface{})["timezone"], "inputs should not be modified")
+}
+
+func TestDecodeMapProperty(t *testing.T) {
+	decoded, err := DecodeMapProperty(`{"theme":"dark"}`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"theme": "dark"}, decoded)
+
+	decoded, err = DecodeMapProperty(nil)
+	require.NoError(t, err)
+	assert.Empty(t, decoded)
+
+	_, err = DecodeMapProperty(42)
+	assert.Error(t, err)
+}
+
+func TestConvertValue_StringLists(t *testing.T) {
+	assert.Equal(t, []string{"billing", "urgent"}, convertValue([]interface{}{"billing", "urgent"}))
+	assert.Equal(t, []interface{}{"a", 1}, convertValue([]interface{}{"a", int64(1)}))
+	assert.Equal(t, []interface{}{}, convertValue([]interface{}{}))
+}
+
+func TestDecodeStringSliceProperty(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, DecodeStringSliceProperty([]string{"a", "b"}))
+	assert.Equal(t, []string{"a", "b"}, DecodeStringSliceProperty([]interface{}{"a", "b"}))
+	assert.Equal(t, []string{}, DecodeStringSliceProperty(nil))
+	assert.Equal(t, []string{}, DecodeStringSliceProperty([]interface{}{"a", 1}))
+}
+
+func TestBuildWhereClause(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition Condition
+		clause    string
+	}{
+		{"equals", Condition{Field: "status", Operator: OperatorEquals, Value: "active"}, " WHERE n.status = $p0"},
+		{"less than", Condition{Field: "expires_at", Operator: OperatorLessThan, Value: "2025-01-01T00:00:00Z"}, " WHERE n.expires_at < $p0"},
+		{"greater than", Condition{Field: "priority", Operator: OperatorGreaterThan, Value: 3}, " WHERE n.priority > $p0"},
+		{"in", Condition{Field: "status", Operator: OperatorIn, Value: []string{"online", "busy"}}, " WHERE n.status IN $p0"},
+		{"contains", Condition{Field: "name", Operator: OperatorContains, Value: "proc"}, " WHERE n.name CONTAINS $p0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clause, params, err := buildWhereClause([]Condition{tt.condition})
+			require.NoError(t, err)
+			assert.Equal(t, tt.clause, clause)
+			assert.Equal(t, map[string]interface{}{"p0": tt.condition.Value}, params)
+		})
+	}
+
+	t.Run("combines conditions with AND", func(t *testing.T) {
+		clause, params, err := buildWhereClause([]Condition{
+			{Field: "status", Operator: OperatorEquals, Value: "active"},
+			{Field: "expires_at", Operator: OperatorLessThan, Value: "now"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, " WHERE n.status = $p0 AND n.expires_at < $p1", clause)
+		assert.Len(t, params, 2)
+	})
+
+	t.Run("returns no clause without conditions", func(t *testing.T) {
+		clause, params, err := buildWhereClause(nil)
+		require.NoError(t, err)
+		assert.Empty(t, clause)
+		assert.Empty(t, params)
+	})
+
+	t.Run("rejects unsafe conditions", func(t *testing.T) {
+		invalid := []Condition{
+			{Field: "status) DETACH DELETE n //", Operator: OperatorEquals, Value: "x"},
+			{Field: "status", Operator: "STARTS WITH", Value: "x"},
+			{Field: "status", Operator: OperatorIn, Value: "online"},
+			{Field: "name", Operator: OperatorContains, Value: 42},
+		}
+		for _, condition := range invalid {
+			_, _, err := buildWhereClause([]Condition{condition})
+			assert.Error(t, err, condition)
+		}
+	})
+}
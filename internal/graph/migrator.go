@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// NodeTypeSchemaMigration records each migration applied to the graph, one node per version
+const NodeTypeSchemaMigration = "SchemaMigration"
+
+// Migration is one ordered step of the graph schema
+// Up should be idempotent, since a failure before the version is recorded runs it again on the next start
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context) error
+}
+
+// Migrator applies pending migrations in version order and records the versions it applied in the graph
+type Migrator struct {
+	graph      Graph
+	migrations []Migration
+	now        func() time.Time
+}
+
+// NewMigrator creates a migrator for the given migrations, which may be listed in any order
+func NewMigrator(g Graph, migrations ...Migration) *Migrator {
+	sorted := append([]Migration(nil), migrations...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return &Migrator{graph: g, migrations: sorted, now: time.Now}
+}
+
+// Migrate applies every migration whose version has not been recorded yet and returns the versions it applied
+func (m *Migrator) Migrate(ctx context.Context) ([]int, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	applied, err := m.AppliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	done := make(map[int]bool, len(applied))
+	for _, version := range applied {
+		done[version] = true
+	}
+
+	ran := []int{}
+	for _, migration := range m.migrations {
+		if done[migration.Version] {
+			continue
+		}
+		if err := migration.Up(ctx); err != nil {
+			return ran, fmt.Errorf("failed to apply migration %d (%s): %w", migration.Version, migration.Name, err)
+		}
+		if err := m.graph.AddNode(ctx, NodeTypeSchemaMigration, migrationID(migration.Version), map[string]interface{}{
+			"version":    migration.Version,
+			"name":       migration.Name,
+			"applied_at": m.now().UTC().Format(time.RFC3339),
+		}); err != nil {
+			return ran, fmt.Errorf("failed to record migration %d (%s): %w", migration.Version, migration.Name, err)
+		}
+		ran = append(ran, migration.Version)
+	}
+	return ran, nil
+}
+
+// AppliedVersions returns the versions recorded as applied, in ascending order
+func (m *Migrator) AppliedVersions(ctx context.Context) ([]int, error) {
+	nodes, err := m.graph.QueryNodes(ctx, NodeTypeSchemaMigration, map[string]interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+
+	versions := make([]int, 0, len(nodes))
+	for _, props := range nodes {
+		switch version := props["version"].(type) {
+		case int:
+			versions = append(versions, version)
+		case int64:
+			versions = append(versions, int(version))
+		default:
+			return nil, fmt.Errorf("migration node %v has an invalid version", props["id"])
+		}
+	}
+	sort.Ints(versions)
+	return versions, nil
+}
+
+// validate rejects migrations without a positive, unique version or an Up function
+func (m *Migrator) validate() error {
+	seen := make(map[int]bool, len(m.migrations))
+	for _, migration := range m.migrations {
+		if migration.Version <= 0 {
+			return fmt.Errorf("migration %q must have a positive version", migration.Name)
+		}
+		if seen[migration.Version] {
+			return fmt.Errorf("duplicate migration version %d", migration.Version)
+		}
+		if migration.Up == nil {
+			return fmt.Errorf("migration %d (%s) has no Up function", migration.Version, migration.Name)
+		}
+		seen[migration.Version] = true
+	}
+	return nil
+}
+
+// migrationID is the node ID recording a migration version
+func migrationID(version int) string {
+	return "migration-" + strconv.Itoa(version)
+}
@@ -0,0 +1,106 @@
+package graph_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuromesh/internal/graph"
+	"neuromesh/testHelpers"
+)
+
+func TestMigrator_Migrate(t *testing.T) {
+	ctx := context.Background()
+	g := testHelpers.NewCleanMockGraph()
+
+	var order []int
+	step := func(version int) graph.Migration {
+		return graph.Migration{Version: version, Name: "step", Up: func(ctx context.Context) error {
+			order = append(order, version)
+			return nil
+		}}
+	}
+
+	// Migrations listed out of order still run by version
+	applied, err := graph.NewMigrator(g, step(2), step(1)).Migrate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, applied)
+	assert.Equal(t, []int{1, 2}, order)
+
+	t.Run("records the applied versions", func(t *testing.T) {
+		versions, err := graph.NewMigrator(g).AppliedVersions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, versions)
+
+		count, err := g.CountNodes(ctx, graph.NodeTypeSchemaMigration, map[string]interface{}{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("runs each migration once", func(t *testing.T) {
+		applied, err := graph.NewMigrator(g, step(1), step(2)).Migrate(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, applied)
+		assert.Equal(t, []int{1, 2}, order)
+	})
+
+	t.Run("applies only new migrations", func(t *testing.T) {
+		applied, err := graph.NewMigrator(g, step(1), step(2), step(3)).Migrate(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []int{3}, applied)
+		assert.Equal(t, []int{1, 2, 3}, order)
+	})
+}
+
+func TestMigrator_FailedMigrationIsRetried(t *testing.T) {
+	ctx := context.Background()
+	g := testHelpers.NewCleanMockGraph()
+
+	failing := true
+	attempts := 0
+	migrations := []graph.Migration{
+		{Version: 1, Name: "first", Up: func(ctx context.Context) error { return nil }},
+		{Version: 2, Name: "flaky", Up: func(ctx context.Context) error {
+			attempts++
+			if failing {
+				return errors.New("index creation failed")
+			}
+			return nil
+		}},
+	}
+
+	applied, err := graph.NewMigrator(g, migrations...).Migrate(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "migration 2 (flaky)")
+	assert.Equal(t, []int{1}, applied)
+
+	failing = false
+	applied, err = graph.NewMigrator(g, migrations...).Migrate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, applied)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestMigrator_RejectsInvalidMigrations(t *testing.T) {
+	ctx := context.Background()
+	noop := func(ctx context.Context) error { return nil }
+
+	tests := []struct {
+		name       string
+		migrations []graph.Migration
+	}{
+		{"non-positive version", []graph.Migration{{Version: 0, Name: "zero", Up: noop}}},
+		{"duplicate version", []graph.Migration{{Version: 1, Name: "a", Up: noop}, {Version: 1, Name: "b", Up: noop}}},
+		{"missing Up", []graph.Migration{{Version: 1, Name: "empty"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := graph.NewMigrator(testHelpers.NewCleanMockGraph(), tt.migrations...).Migrate(ctx)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -2,18 +2,30 @@ package graph
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"neuromesh/internal/logging"
+	"neuromesh/internal/tracing"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	neo4jConfig "github.com/neo4j/neo4j-go-driver/v5/neo4j/config"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Neo4jGraph implements simple graph operations using Neo4j
 type Neo4jGraph struct {
-	driver neo4j.DriverWithContext
-	logger logging.Logger
+	driver             neo4j.DriverWithContext
+	database           string
+	fetchSize          int
+	queryTimeout       time.Duration
+	allowClearTestData bool
+	logger             logging.Logger
 }
 
 // NewNeo4jGraph creates a new Neo4j graph instance
@@ -28,8 +40,28 @@ func NewNeo4jGraph(ctx context.Context, config GraphConfig, logger logging.Logge
 		config.Neo4jPassword = "orchestrator123"
 	}
 
+	if config.MaxConnectionPoolSize <= 0 {
+		config.MaxConnectionPoolSize = DefaultMaxConnectionPoolSize
+	}
+	if config.ConnectionAcquisitionTimeout <= 0 {
+		config.ConnectionAcquisitionTimeout = DefaultConnectionAcquisitionTimeout
+	}
+	if config.MaxConnectionLifetime <= 0 {
+		config.MaxConnectionLifetime = DefaultMaxConnectionLifetime
+	}
+	if config.FetchSize <= 0 {
+		config.FetchSize = DefaultFetchSize
+	}
+	if config.QueryTimeout <= 0 {
+		config.QueryTimeout = DefaultQueryTimeout
+	}
+
 	auth := neo4j.BasicAuth(config.Neo4jUser, config.Neo4jPassword, "")
-	driver, err := neo4j.NewDriverWithContext(config.Neo4jURL, auth)
+	driver, err := neo4j.NewDriverWithContext(config.Neo4jURL, auth, func(c *neo4jConfig.Config) {
+		c.MaxConnectionPoolSize = config.MaxConnectionPoolSize
+		c.ConnectionAcquisitionTimeout = config.ConnectionAcquisitionTimeout
+		c.MaxConnectionLifetime = config.MaxConnectionLifetime
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Neo4j driver: %w", err)
 	}
@@ -41,8 +73,12 @@ func NewNeo4jGraph(ctx context.Context, config GraphConfig, logger logging.Logge
 	}
 
 	return &Neo4jGraph{
-		driver: driver,
-		logger: logger,
+		driver:             driver,
+		database:           config.Neo4jDatabase,
+		fetchSize:          config.FetchSize,
+		queryTimeout:       config.QueryTimeout,
+		allowClearTestData: config.AllowClearTestData,
+		logger:             logger,
 	}, nil
 }
 
@@ -56,9 +92,54 @@ func (g *Neo4jGraph) Driver() neo4j.DriverWithContext {
 	return g.driver
 }
 
+// newSession opens a session for one operation; sessions are cheap and not safe for concurrent use,
+// so reuse happens at the driver's connection pool while read sessions can be routed to followers
+func (g *Neo4jGraph) newSession(ctx context.Context, mode neo4j.AccessMode) neo4j.SessionWithContext {
+	return g.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode:   mode,
+		DatabaseName: g.database,
+		FetchSize:    g.fetchSize,
+	})
+}
+
+// executeRead runs work in a read transaction the server aborts after the query timeout
+func (g *Neo4jGraph) executeRead(ctx context.Context, session neo4j.SessionWithContext, work neo4j.ManagedTransactionWork) (interface{}, error) {
+	result, err := session.ExecuteRead(ctx, work, neo4j.WithTxTimeout(g.queryTimeout))
+	return result, contextError(ctx, err)
+}
+
+// executeWrite runs work in a write transaction the server aborts after the query timeout
+func (g *Neo4jGraph) executeWrite(ctx context.Context, session neo4j.SessionWithContext, work neo4j.ManagedTransactionWork) (interface{}, error) {
+	result, err := session.ExecuteWrite(ctx, work, neo4j.WithTxTimeout(g.queryTimeout))
+	return result, contextError(ctx, err)
+}
+
+// contextError makes a failure caused by ctx being cancelled or expiring match ctx's error with errors.Is,
+// whichever driver error the aborted query produced
+func contextError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil || errors.Is(err, ctx.Err()) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", ctx.Err(), err)
+}
+
+// startSpan traces a single graph operation against Neo4j
+func startSpan(ctx context.Context, operation, label string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "neo4j."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "neo4j"),
+			attribute.String("db.operation", operation),
+			attribute.String("db.neo4j.label", label),
+		))
+}
+
 // AddNode adds a node to the graph
 func (g *Neo4jGraph) AddNode(ctx context.Context, nodeType, nodeID string, properties map[string]interface{}) error {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	ctx, span := startSpan(ctx, "AddNode", nodeType)
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
 	defer session.Close(ctx)
 
 	query := fmt.Sprintf("CREATE (n:%s {id: $id}) SET n += $properties", nodeType)
@@ -67,7 +148,7 @@ func (g *Neo4jGraph) AddNode(ctx context.Context, nodeType, nodeID string, prope
 		"properties": properties,
 	}
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		_, err := tx.Run(ctx, query, params)
 		return nil, err
 	})
@@ -77,13 +158,16 @@ func (g *Neo4jGraph) AddNode(ctx context.Context, nodeType, nodeID string, prope
 
 // GetNode retrieves a node from the graph
 func (g *Neo4jGraph) GetNode(ctx context.Context, nodeType, nodeID string) (map[string]interface{}, error) {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	ctx, span := startSpan(ctx, "GetNode", nodeType)
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeRead)
 	defer session.Close(ctx)
 
 	query := fmt.Sprintf("MATCH (n:%s {id: $id}) RETURN n", nodeType)
 	params := map[string]interface{}{"id": nodeID}
 
-	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	result, err := g.executeRead(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		result, err := tx.Run(ctx, query, params)
 		if err != nil {
 			return nil, err
@@ -107,7 +191,7 @@ func (g *Neo4jGraph) GetNode(ctx context.Context, nodeType, nodeID string) (map[
 			return nodeMap, nil
 		}
 
-		return nil, fmt.Errorf("node not found")
+		return nil, fmt.Errorf("%w: %s %s", ErrNodeNotFound, nodeType, nodeID)
 	})
 
 	if err != nil {
@@ -119,7 +203,10 @@ func (g *Neo4jGraph) GetNode(ctx context.Context, nodeType, nodeID string) (map[
 
 // UpdateNode updates a node in the graph
 func (g *Neo4jGraph) UpdateNode(ctx context.Context, nodeType, nodeID string, properties map[string]interface{}) error {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	ctx, span := startSpan(ctx, "UpdateNode", nodeType)
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
 	defer session.Close(ctx)
 
 	query := fmt.Sprintf("MATCH (n:%s {id: $id}) SET n += $properties", nodeType)
@@ -128,7 +215,7 @@ func (g *Neo4jGraph) UpdateNode(ctx context.Context, nodeType, nodeID string, pr
 		"properties": properties,
 	}
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		_, err := tx.Run(ctx, query, params)
 		return nil, err
 	})
@@ -136,15 +223,143 @@ func (g *Neo4jGraph) UpdateNode(ctx context.Context, nodeType, nodeID string, pr
 	return err
 }
 
+// UpdateNodeIf sets properties on a node only if its current properties equal expected, as one compare-and-set
+// The node is write-locked before the comparison so concurrent callers cannot both see the expected values
+func (g *Neo4jGraph) UpdateNodeIf(ctx context.Context, nodeType, nodeID string, expected, properties map[string]interface{}) (bool, error) {
+	ctx, span := startSpan(ctx, "UpdateNodeIf", nodeType)
+	defer span.End()
+
+	conditions := []string{}
+	params := map[string]interface{}{
+		"id":         nodeID,
+		"properties": properties,
+	}
+	for k, v := range expected {
+		if !isValidPropertyName(k) {
+			return false, fmt.Errorf("invalid expected property: %s", k)
+		}
+		conditions = append(conditions, fmt.Sprintf("n.%s = $expected_%s", k, k))
+		params["expected_"+k] = v
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	query := fmt.Sprintf("MATCH (n:%s {id: $id}) SET n._lock = true REMOVE n._lock WITH n %s SET n += $properties RETURN count(n)", nodeType, where)
+
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
+	defer session.Close(ctx)
+
+	result, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var count int64
+		if result.Next(ctx) {
+			count, _ = result.Record().Values[0].(int64)
+		}
+		return count, result.Err()
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return result.(int64) > 0, nil
+}
+
+// MergeNode creates a node with MERGE, so concurrent callers racing on the same ID create it once
+// Paired with a unique constraint on id, the node ID serves as an idempotency key across processes
+func (g *Neo4jGraph) MergeNode(ctx context.Context, nodeType, nodeID string, properties map[string]interface{}) (bool, error) {
+	ctx, span := startSpan(ctx, "MergeNode", nodeType)
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf("MERGE (n:%s {id: $id}) ON CREATE SET n += $properties, n._created = true WITH n, n._created IS NOT NULL AS created REMOVE n._created RETURN created", nodeType)
+	params := map[string]interface{}{
+		"id":         nodeID,
+		"properties": properties,
+	}
+
+	result, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, err
+		}
+		created, _ := record.Values[0].(bool)
+		return created, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return result.(bool), nil
+}
+
+// MergeNodeProperty deep-merges value into a nested map property inside a single write transaction
+// Neo4j cannot store maps as properties, so the merged map is persisted as a JSON string
+func (g *Neo4jGraph) MergeNodeProperty(ctx context.Context, nodeType, nodeID, key string, value map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "MergeNodeProperty", nodeType)
+	defer span.End()
+
+	if !isValidPropertyName(key) {
+		return fmt.Errorf("invalid property name: %s", key)
+	}
+
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
+	defer session.Close(ctx)
+
+	readQuery := fmt.Sprintf("MATCH (n:%s {id: $id}) RETURN n.%s", nodeType, key)
+	writeQuery := fmt.Sprintf("MATCH (n:%s {id: $id}) SET n.%s = $value", nodeType, key)
+
+	_, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, readQuery, map[string]interface{}{"id": nodeID})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %s: %v", ErrNodeNotFound, nodeType, nodeID, err)
+		}
+
+		existing, err := DecodeMapProperty(convertValue(record.Values[0]))
+		if err != nil {
+			return nil, err
+		}
+
+		encoded, err := json.Marshal(MergeMaps(existing, value))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode merged property: %w", err)
+		}
+
+		_, err = tx.Run(ctx, writeQuery, map[string]interface{}{"id": nodeID, "value": string(encoded)})
+		return nil, err
+	})
+
+	return err
+}
+
 // DeleteNode deletes a node from the graph
 func (g *Neo4jGraph) DeleteNode(ctx context.Context, nodeType, nodeID string) error {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	ctx, span := startSpan(ctx, "DeleteNode", nodeType)
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
 	defer session.Close(ctx)
 
 	query := fmt.Sprintf("MATCH (n:%s {id: $id}) DETACH DELETE n", nodeType)
 	params := map[string]interface{}{"id": nodeID}
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		_, err := tx.Run(ctx, query, params)
 		return nil, err
 	})
@@ -152,28 +367,263 @@ func (g *Neo4jGraph) DeleteNode(ctx context.Context, nodeType, nodeID string) er
 	return err
 }
 
+// DeleteNodesByFilter detaches and deletes every node of nodeType whose properties equal filters
+// Filters are required so a mistaken call cannot wipe a whole node type
+func (g *Neo4jGraph) DeleteNodesByFilter(ctx context.Context, nodeType string, filters map[string]interface{}) (int, error) {
+	ctx, span := startSpan(ctx, "DeleteNodesByFilter", nodeType)
+	defer span.End()
+
+	if len(filters) == 0 {
+		return 0, fmt.Errorf("refusing to delete all %s nodes: at least one filter is required", nodeType)
+	}
+
+	conditions := []string{}
+	params := make(map[string]interface{})
+	for k, v := range filters {
+		if !isValidPropertyName(k) {
+			return 0, fmt.Errorf("invalid filter property: %s", k)
+		}
+		conditions = append(conditions, fmt.Sprintf("n.%s = $%s", k, k))
+		params[k] = v
+	}
+	query := fmt.Sprintf("MATCH (n:%s) WHERE %s DETACH DELETE n RETURN count(n)", nodeType, strings.Join(conditions, " AND "))
+
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
+	defer session.Close(ctx)
+
+	result, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var count int64
+		if result.Next(ctx) {
+			count, _ = result.Record().Values[0].(int64)
+		}
+		return count, result.Err()
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.(int64)), nil
+}
+
+// DeleteOrphanedNodes detaches and deletes the nodeType nodes without an incoming edgeType edge in a single statement
+// Nodes created at or after createdBefore are kept, so a node whose edge is still being written is not collected
+// Nodes without a created_at predate the timestamp and count as old
+func (g *Neo4jGraph) DeleteOrphanedNodes(ctx context.Context, nodeType, edgeType string, createdBefore time.Time) (int, error) {
+	ctx, span := startSpan(ctx, "DeleteOrphanedNodes", nodeType)
+	defer span.End()
+
+	query := fmt.Sprintf("MATCH (n:%s) WHERE NOT ()-[:%s]->(n) AND (n.created_at IS NULL OR n.created_at < $createdBefore) DETACH DELETE n RETURN count(n)", nodeType, edgeType)
+	params := map[string]interface{}{"createdBefore": createdBefore.UTC()}
+
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
+	defer session.Close(ctx)
+
+	result, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var count int64
+		if result.Next(ctx) {
+			count, _ = result.Record().Values[0].(int64)
+		}
+		return count, result.Err()
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.(int64)), nil
+}
+
 // QueryNodes queries nodes from the graph
 func (g *Neo4jGraph) QueryNodes(ctx context.Context, nodeType string, filters map[string]interface{}) ([]map[string]interface{}, error) {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	return g.QueryNodesWithOptions(ctx, nodeType, filters, QueryOptions{})
+}
+
+// QueryNodesWithOptions queries nodes matching filters with optional ordering and limit
+func (g *Neo4jGraph) QueryNodesWithOptions(ctx context.Context, nodeType string, filters map[string]interface{}, opts QueryOptions) ([]map[string]interface{}, error) {
+	ctx, span := startSpan(ctx, "QueryNodesWithOptions", nodeType)
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeRead)
 	defer session.Close(ctx)
 
 	// Build query
 	query := fmt.Sprintf("MATCH (n:%s)", nodeType)
 	params := make(map[string]interface{})
 
-	if filters != nil && len(filters) > 0 {
-		query += " WHERE "
+	conditions := []string{}
+	for k, v := range filters {
+		conditions = append(conditions, fmt.Sprintf("n.%s = $%s", k, k))
+		params[k] = v
+	}
+	if opts.After != nil {
+		if opts.OrderBy == "" || !isValidPropertyName(opts.OrderBy) {
+			return nil, fmt.Errorf("after requires a valid order by property, got %q", opts.OrderBy)
+		}
+		operator := ">"
+		if opts.Descending {
+			operator = "<"
+		}
+		conditions = append(conditions, fmt.Sprintf("n.%s %s $after", opts.OrderBy, operator))
+		params["after"] = opts.After
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " RETURN n"
+
+	if opts.OrderBy != "" {
+		if !isValidPropertyName(opts.OrderBy) {
+			return nil, fmt.Errorf("invalid order by property: %s", opts.OrderBy)
+		}
+		query += fmt.Sprintf(" ORDER BY n.%s", opts.OrderBy)
+		if opts.Descending {
+			query += " DESC"
+		}
+		if opts.ThenBy != "" {
+			if !isValidPropertyName(opts.ThenBy) {
+				return nil, fmt.Errorf("invalid then by property: %s", opts.ThenBy)
+			}
+			query += fmt.Sprintf(", n.%s", opts.ThenBy)
+			if opts.Descending {
+				query += " DESC"
+			}
+		}
+	}
+
+	if opts.Offset > 0 {
+		query += " SKIP $skip"
+		params["skip"] = opts.Offset
+	}
+
+	if opts.Limit > 0 {
+		query += " LIMIT $limit"
+		params["limit"] = opts.Limit
+	}
+
+	return g.readNodes(ctx, session, nodeType, query, params)
+}
+
+// CountNodes counts the nodes matching filters without loading them
+func (g *Neo4jGraph) CountNodes(ctx context.Context, nodeType string, filters map[string]interface{}) (int, error) {
+	ctx, span := startSpan(ctx, "CountNodes", nodeType)
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeRead)
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf("MATCH (n:%s)", nodeType)
+	params := make(map[string]interface{})
+
+	if len(filters) > 0 {
 		conditions := []string{}
 		for k, v := range filters {
+			if !isValidPropertyName(k) {
+				return 0, fmt.Errorf("invalid filter property: %s", k)
+			}
 			conditions = append(conditions, fmt.Sprintf("n.%s = $%s", k, k))
 			params[k] = v
 		}
-		query += strings.Join(conditions, " AND ") // ✅ FIX: Join all conditions
+		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	query += " RETURN n"
+	query += " RETURN count(n)"
 
-	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	result, err := g.executeRead(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var count int64
+		if result.Next(ctx) {
+			count, _ = result.Record().Values[0].(int64)
+		}
+		return count, result.Err()
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.(int64)), nil
+}
+
+// QueryNodesByRelated returns the distinct nodes with an edge to a target node matching all targetFilters
+func (g *Neo4jGraph) QueryNodesByRelated(ctx context.Context, nodeType, edgeType, targetType string, targetFilters map[string]interface{}) ([]map[string]interface{}, error) {
+	ctx, span := startSpan(ctx, "QueryNodesByRelated", nodeType)
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeRead)
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf("MATCH (n:%s)-[:%s]->(m:%s)", nodeType, edgeType, targetType)
+	params := make(map[string]interface{})
+
+	if len(targetFilters) > 0 {
+		conditions := []string{}
+		for k, v := range targetFilters {
+			if !isValidPropertyName(k) {
+				return nil, fmt.Errorf("invalid filter property: %s", k)
+			}
+			conditions = append(conditions, fmt.Sprintf("m.%s = $%s", k, k))
+			params[k] = v
+		}
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " RETURN DISTINCT n"
+
+	return g.readNodes(ctx, session, nodeType, query, params)
+}
+
+// QueryNodesAdvanced queries nodes matching all conditions, pushing comparisons down to Cypher
+func (g *Neo4jGraph) QueryNodesAdvanced(ctx context.Context, nodeType string, conditions []Condition) ([]map[string]interface{}, error) {
+	ctx, span := startSpan(ctx, "QueryNodesAdvanced", nodeType)
+	defer span.End()
+
+	where, params, err := buildWhereClause(conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	session := g.newSession(ctx, neo4j.AccessModeRead)
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf("MATCH (n:%s)%s RETURN n", nodeType, where)
+	return g.readNodes(ctx, session, nodeType, query, params)
+}
+
+// buildWhereClause turns conditions into a parameterized WHERE clause; field names are validated, values are always parameters
+func buildWhereClause(conditions []Condition) (string, map[string]interface{}, error) {
+	params := make(map[string]interface{})
+	if len(conditions) == 0 {
+		return "", params, nil
+	}
+
+	clauses := make([]string, 0, len(conditions))
+	for i, condition := range conditions {
+		if err := condition.Validate(); err != nil {
+			return "", nil, err
+		}
+		param := fmt.Sprintf("p%d", i)
+		clauses = append(clauses, fmt.Sprintf("n.%s %s $%s", condition.Field, condition.Operator, param))
+		params[param] = condition.Value
+	}
+	return " WHERE " + strings.Join(clauses, " AND "), params, nil
+}
+
+// readNodes runs a read query returning nodes bound to n and converts them to property maps
+func (g *Neo4jGraph) readNodes(ctx context.Context, session neo4j.SessionWithContext, nodeType, query string, params map[string]interface{}) ([]map[string]interface{}, error) {
+	result, err := g.executeRead(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		result, err := tx.Run(ctx, query, params)
 		if err != nil {
 			return nil, err
@@ -207,9 +657,23 @@ func (g *Neo4jGraph) QueryNodes(ctx context.Context, nodeType string, filters ma
 	return result.([]map[string]interface{}), nil
 }
 
+// isValidPropertyName reports whether name is safe to interpolate as a Cypher property key
+func isValidPropertyName(name string) bool {
+	for i, r := range name {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return false
+	}
+	return name != ""
+}
+
 // AddEdge adds an edge between two nodes
 func (g *Neo4jGraph) AddEdge(ctx context.Context, sourceType, sourceID, targetType, targetID, edgeType string, properties map[string]interface{}) error {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	ctx, span := startSpan(ctx, "AddEdge", edgeType)
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
 	defer session.Close(ctx)
 
 	query := fmt.Sprintf(`
@@ -224,7 +688,7 @@ func (g *Neo4jGraph) AddEdge(ctx context.Context, sourceType, sourceID, targetTy
 		"properties": properties,
 	}
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		_, err := tx.Run(ctx, query, params)
 		return nil, err
 	})
@@ -234,13 +698,16 @@ func (g *Neo4jGraph) AddEdge(ctx context.Context, sourceType, sourceID, targetTy
 
 // GetEdges gets edges from a node
 func (g *Neo4jGraph) GetEdges(ctx context.Context, nodeType, nodeID string) ([]map[string]interface{}, error) {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	ctx, span := startSpan(ctx, "GetEdges", nodeType)
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeRead)
 	defer session.Close(ctx)
 
 	query := fmt.Sprintf("MATCH (n:%s {id: $id})-[r]->(m) RETURN r", nodeType)
 	params := map[string]interface{}{"id": nodeID}
 
-	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	result, err := g.executeRead(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		result, err := tx.Run(ctx, query, params)
 		if err != nil {
 			return nil, err
@@ -275,13 +742,16 @@ func (g *Neo4jGraph) GetEdges(ctx context.Context, nodeType, nodeID string) ([]m
 
 // GetEdgesWithTargets retrieves edges with target node information
 func (g *Neo4jGraph) GetEdgesWithTargets(ctx context.Context, nodeType, nodeID string) ([]map[string]interface{}, error) {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	ctx, span := startSpan(ctx, "GetEdgesWithTargets", nodeType)
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeRead)
 	defer session.Close(ctx)
 
 	query := fmt.Sprintf("MATCH (n:%s {id: $id})-[r]->(m) RETURN r, m.id as target_id, labels(m)[0] as target_type", nodeType)
 	params := map[string]interface{}{"id": nodeID}
 
-	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	result, err := g.executeRead(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		result, err := tx.Run(ctx, query, params)
 		if err != nil {
 			return nil, err
@@ -320,7 +790,10 @@ func (g *Neo4jGraph) GetEdgesWithTargets(ctx context.Context, nodeType, nodeID s
 
 // UpdateEdge updates an edge
 func (g *Neo4jGraph) UpdateEdge(ctx context.Context, sourceType, sourceID, targetType, targetID, edgeType string, properties map[string]interface{}) error {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	ctx, span := startSpan(ctx, "UpdateEdge", edgeType)
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
 	defer session.Close(ctx)
 
 	query := fmt.Sprintf(`
@@ -334,7 +807,7 @@ func (g *Neo4jGraph) UpdateEdge(ctx context.Context, sourceType, sourceID, targe
 		"properties": properties,
 	}
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		_, err := tx.Run(ctx, query, params)
 		return nil, err
 	})
@@ -344,7 +817,10 @@ func (g *Neo4jGraph) UpdateEdge(ctx context.Context, sourceType, sourceID, targe
 
 // DeleteEdge deletes an edge
 func (g *Neo4jGraph) DeleteEdge(ctx context.Context, sourceType, sourceID, targetType, targetID, edgeType string) error {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	ctx, span := startSpan(ctx, "DeleteEdge", edgeType)
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
 	defer session.Close(ctx)
 
 	query := fmt.Sprintf(`
@@ -357,7 +833,7 @@ func (g *Neo4jGraph) DeleteEdge(ctx context.Context, sourceType, sourceID, targe
 		"targetID": targetID,
 	}
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		_, err := tx.Run(ctx, query, params)
 		return nil, err
 	})
@@ -365,12 +841,300 @@ func (g *Neo4jGraph) DeleteEdge(ctx context.Context, sourceType, sourceID, targe
 	return err
 }
 
-// ClearTestData removes all test data from the graph (for testing only)
+// TraversePath returns every path following hops from a node, matched and ordered in a single query
+func (g *Neo4jGraph) TraversePath(ctx context.Context, nodeType, nodeID string, hops []Hop) ([][]map[string]interface{}, error) {
+	ctx, span := startSpan(ctx, "TraversePath", nodeType)
+	defer span.End()
+
+	if len(hops) == 0 {
+		return nil, fmt.Errorf("path traversal requires at least one hop")
+	}
+
+	query := fmt.Sprintf("MATCH (start:%s {id: $id})", nodeType)
+	returns := make([]string, 0, len(hops))
+	orderBy := []string{}
+	for i, hop := range hops {
+		query += fmt.Sprintf("-[:%s]->(n%d:%s)", hop.EdgeType, i, hop.TargetType)
+		returns = append(returns, fmt.Sprintf("n%d", i))
+		if hop.OrderBy != "" {
+			if !isValidPropertyName(hop.OrderBy) {
+				return nil, fmt.Errorf("invalid order by property: %s", hop.OrderBy)
+			}
+			orderBy = append(orderBy, fmt.Sprintf("n%d.%s", i, hop.OrderBy))
+		}
+	}
+	query += " RETURN " + strings.Join(returns, ", ")
+	if len(orderBy) > 0 {
+		query += " ORDER BY " + strings.Join(orderBy, ", ")
+	}
+
+	session := g.newSession(ctx, neo4j.AccessModeRead)
+	defer session.Close(ctx)
+
+	result, err := g.executeRead(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, query, map[string]interface{}{"id": nodeID})
+		if err != nil {
+			return nil, err
+		}
+
+		paths := [][]map[string]interface{}{}
+		for result.Next(ctx) {
+			record := result.Record()
+			path := make([]map[string]interface{}, len(hops))
+			for i, value := range record.Values {
+				node := value.(neo4j.Node)
+				nodeMap := map[string]interface{}{
+					"type": hops[i].TargetType,
+				}
+				for k, v := range node.Props {
+					nodeMap[k] = convertValue(v)
+				}
+				path[i] = nodeMap
+			}
+			paths = append(paths, path)
+		}
+
+		return paths, result.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([][]map[string]interface{}), nil
+}
+
+// FollowChain matches the variable-length path of edgeType edges from a node and returns its nodes nearest first
+func (g *Neo4jGraph) FollowChain(ctx context.Context, nodeType, nodeID, edgeType string) ([]map[string]interface{}, error) {
+	ctx, span := startSpan(ctx, "FollowChain", nodeType)
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeRead)
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf("MATCH p = (start:%s {id: $id})-[:%s*0..]->(n:%s) RETURN n ORDER BY length(p)", nodeType, edgeType, nodeType)
+
+	result, err := g.executeRead(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, query, map[string]interface{}{"id": nodeID})
+		if err != nil {
+			return nil, err
+		}
+
+		// A cycle reaches the same node over longer paths; keep only its nearest occurrence
+		seen := make(map[string]bool)
+		nodes := []map[string]interface{}{}
+		for result.Next(ctx) {
+			node := result.Record().Values[0].(neo4j.Node)
+			if seen[node.ElementId] {
+				continue
+			}
+			seen[node.ElementId] = true
+
+			nodeMap := map[string]interface{}{
+				"type": nodeType,
+			}
+			for k, v := range node.Props {
+				nodeMap[k] = convertValue(v)
+			}
+			nodes = append(nodes, nodeMap)
+		}
+
+		return nodes, result.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]map[string]interface{}), nil
+}
+
+// CountRelatedNodesByProperty counts the nodes reachable over edgeType grouped by the value of property
+func (g *Neo4jGraph) CountRelatedNodesByProperty(ctx context.Context, nodeType, nodeID, edgeType, property string) (map[string]int, error) {
+	ctx, span := startSpan(ctx, "CountRelatedNodesByProperty", nodeType)
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeRead)
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf("MATCH (n:%s {id: $id})-[:%s]->(m) RETURN m[$property] AS value, count(m) AS count", nodeType, edgeType)
+	params := map[string]interface{}{
+		"id":       nodeID,
+		"property": property,
+	}
+
+	result, err := g.executeRead(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+
+		counts := make(map[string]int)
+		for result.Next(ctx) {
+			record := result.Record()
+			value := ""
+			if record.Values[0] != nil {
+				value = fmt.Sprintf("%v", convertValue(record.Values[0]))
+			}
+			counts[value] += int(record.Values[1].(int64))
+		}
+
+		return counts, result.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(map[string]int), nil
+}
+
+// Aggregate groups the matching nodes, optionally joined to their related node, and counts and averages each group in Cypher
+func (g *Neo4jGraph) Aggregate(ctx context.Context, aggregation Aggregation) ([]AggregateGroup, error) {
+	ctx, span := startSpan(ctx, "Aggregate", aggregation.NodeType)
+	defer span.End()
+
+	if err := aggregation.Validate(); err != nil {
+		return nil, err
+	}
+	where, params, err := buildWhereClause(aggregation.Conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(aggregation.GroupBy)+len(aggregation.RelatedGroupBy))
+	columns := make([]string, 0, cap(keys))
+	for _, property := range aggregation.GroupBy {
+		keys = append(keys, property)
+		columns = append(columns, fmt.Sprintf("n.%s AS g%d", property, len(columns)))
+	}
+	related := ""
+	if aggregation.RelatedType != "" {
+		related = fmt.Sprintf(" OPTIONAL MATCH (m:%s)-[:%s]->(n)", aggregation.RelatedType, aggregation.RelatedEdgeType)
+		for _, property := range aggregation.RelatedGroupBy {
+			keys = append(keys, RelatedPrefix+property)
+			columns = append(columns, fmt.Sprintf("m.%s AS g%d", property, len(columns)))
+		}
+	}
+	columns = append(columns, "count(n) AS count", fmt.Sprintf("avg(n.%s) AS average", aggregation.AverageProperty))
+	query := fmt.Sprintf("MATCH (n:%s)%s%s RETURN %s", aggregation.NodeType, where, related, strings.Join(columns, ", "))
+
+	session := g.newSession(ctx, neo4j.AccessModeRead)
+	defer session.Close(ctx)
+
+	result, err := g.executeRead(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var groups []AggregateGroup
+		for result.Next(ctx) {
+			values := result.Record().Values
+			group := AggregateGroup{Values: make(map[string]interface{}, len(keys))}
+			for i, key := range keys {
+				group.Values[key] = convertValue(values[i])
+			}
+			count, _ := values[len(keys)].(int64)
+			group.Count = int(count)
+			group.Average, _ = values[len(keys)+1].(float64)
+			groups = append(groups, group)
+		}
+		return groups, result.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]AggregateGroup), nil
+}
+
+// FullTextSearch queries the full-text index on nodeType.property, filtering and paging the matches in the same query, highest scoring nodes first
+func (g *Neo4jGraph) FullTextSearch(ctx context.Context, nodeType, property, query string, conditions []Condition, limit, offset int) ([]map[string]interface{}, error) {
+	ctx, span := startSpan(ctx, "FullTextSearch", nodeType)
+	defer span.End()
+
+	where, params, err := buildWhereClause(conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	session := g.newSession(ctx, neo4j.AccessModeRead)
+	defer session.Close(ctx)
+
+	cypher := "CALL db.index.fulltext.queryNodes($index, $query) YIELD node AS n, score" + where + " RETURN n, score ORDER BY score DESC, n.id"
+	params["index"] = fullTextIndexName(nodeType, property)
+	params["query"] = escapeLuceneQuery(query)
+	if offset > 0 {
+		cypher += " SKIP $offset"
+		params["offset"] = offset
+	}
+	if limit > 0 {
+		cypher += " LIMIT $limit"
+		params["limit"] = limit
+	}
+
+	result, err := g.executeRead(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, cypher, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var nodes []map[string]interface{}
+		for result.Next(ctx) {
+			record := result.Record()
+			node := record.Values[0].(neo4j.Node)
+
+			nodeMap := map[string]interface{}{
+				"type":  nodeType,
+				"score": record.Values[1].(float64),
+			}
+			for k, v := range node.Props {
+				nodeMap[k] = convertValue(v)
+			}
+
+			nodes = append(nodes, nodeMap)
+		}
+
+		return nodes, result.Err()
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to run full-text search: %w", err)
+	}
+
+	return result.([]map[string]interface{}), nil
+}
+
+// escapeLuceneQuery escapes Lucene operators so user input is matched as plain terms
+func escapeLuceneQuery(query string) string {
+	var b strings.Builder
+	for _, r := range query {
+		if strings.ContainsRune(`+-&|!(){}[]^"~*?:\/`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// VerifyConnectivity checks that the Neo4j server is reachable
+func (g *Neo4jGraph) VerifyConnectivity(ctx context.Context) error {
+	return g.driver.VerifyConnectivity(ctx)
+}
+
+// ClearTestData removes every node from the graph (for testing only)
+// It refuses to run unless the graph was configured with AllowClearTestData or
+// AllowClearTestDataEnv is set to "true", so it cannot wipe a non-test database by accident
 func (g *Neo4jGraph) ClearTestData(ctx context.Context) error {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	if !g.allowClearTestData && os.Getenv(AllowClearTestDataEnv) != "true" {
+		return ErrClearTestDataDisabled
+	}
+
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
 	defer session.Close(ctx)
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		_, err := tx.Run(ctx, "MATCH (n) DETACH DELETE n", nil)
 		return nil, err
 	})
@@ -378,23 +1142,92 @@ func (g *Neo4jGraph) ClearTestData(ctx context.Context) error {
 	return err
 }
 
-// GetStats returns basic statistics
-func (g *Neo4jGraph) GetStats() map[string]interface{} {
-	return map[string]interface{}{
-		"implementation": "neo4j",
-		"total_nodes":    0, // Simplified for now
+// ClearNodesByType removes all nodes with the given type, and their edges, leaving other types intact
+func (g *Neo4jGraph) ClearNodesByType(ctx context.Context, nodeType string) error {
+	ctx, span := startSpan(ctx, "ClearNodesByType", nodeType)
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf("MATCH (n:%s) DETACH DELETE n", nodeType)
+	_, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		_, err := tx.Run(ctx, query, nil)
+		return nil, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clear %s nodes: %w", nodeType, err)
+	}
+
+	return nil
+}
+
+// GetStats counts the graph's nodes and edges, with node counts per label, in one read transaction
+func (g *Neo4jGraph) GetStats(ctx context.Context) (map[string]interface{}, error) {
+	ctx, span := startSpan(ctx, "GetStats", "")
+	defer span.End()
+
+	session := g.newSession(ctx, neo4j.AccessModeRead)
+	defer session.Close(ctx)
+
+	result, err := g.executeRead(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		count := func(query string) (int, error) {
+			result, err := tx.Run(ctx, query, nil)
+			if err != nil {
+				return 0, err
+			}
+			record, err := result.Single(ctx)
+			if err != nil {
+				return 0, err
+			}
+			return int(record.Values[0].(int64)), nil
+		}
+
+		totalNodes, err := count("MATCH (n) RETURN count(n)")
+		if err != nil {
+			return nil, fmt.Errorf("failed to count nodes: %w", err)
+		}
+		totalEdges, err := count("MATCH ()-[r]->() RETURN count(r)")
+		if err != nil {
+			return nil, fmt.Errorf("failed to count edges: %w", err)
+		}
+
+		result, err := tx.Run(ctx, "MATCH (n) UNWIND labels(n) AS label RETURN label, count(*)", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count nodes by label: %w", err)
+		}
+		nodesByLabel := make(map[string]int)
+		for result.Next(ctx) {
+			values := result.Record().Values
+			nodesByLabel[values[0].(string)] = int(values[1].(int64))
+		}
+		if err := result.Err(); err != nil {
+			return nil, fmt.Errorf("failed to count nodes by label: %w", err)
+		}
+
+		return map[string]interface{}{
+			"implementation": "neo4j",
+			"total_nodes":    totalNodes,
+			"total_edges":    totalEdges,
+			"nodes_by_label": nodesByLabel,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	return result.(map[string]interface{}), nil
 }
 
 // Schema operations
 func (g *Neo4jGraph) CreateUniqueConstraint(ctx context.Context, nodeType, property string) error {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
 	defer session.Close(ctx)
 
 	constraintName := fmt.Sprintf("unique_%s_%s", strings.ToLower(nodeType), strings.ToLower(property))
 	query := fmt.Sprintf("CREATE CONSTRAINT %s IF NOT EXISTS FOR (n:%s) REQUIRE n.%s IS UNIQUE", constraintName, nodeType, property)
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		_, err := tx.Run(ctx, query, map[string]interface{}{})
 		return nil, err
 	})
@@ -403,13 +1236,13 @@ func (g *Neo4jGraph) CreateUniqueConstraint(ctx context.Context, nodeType, prope
 }
 
 func (g *Neo4jGraph) CreateIndex(ctx context.Context, nodeType, property string) error {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
 	defer session.Close(ctx)
 
 	indexName := fmt.Sprintf("index_%s_%s", strings.ToLower(nodeType), strings.ToLower(property))
 	query := fmt.Sprintf("CREATE INDEX %s IF NOT EXISTS FOR (n:%s) ON (n.%s)", indexName, nodeType, property)
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		_, err := tx.Run(ctx, query, map[string]interface{}{})
 		return nil, err
 	})
@@ -417,14 +1250,50 @@ func (g *Neo4jGraph) CreateIndex(ctx context.Context, nodeType, property string)
 	return err
 }
 
+// CreateFullTextIndex creates a full-text index over nodeType.property
+func (g *Neo4jGraph) CreateFullTextIndex(ctx context.Context, nodeType, property string) error {
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf("CREATE FULLTEXT INDEX %s IF NOT EXISTS FOR (n:%s) ON EACH [n.%s]", fullTextIndexName(nodeType, property), nodeType, property)
+
+	_, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		_, err := tx.Run(ctx, query, map[string]interface{}{})
+		return nil, err
+	})
+
+	return err
+}
+
+// fullTextIndexName returns the name of the full-text index over nodeType.property
+func fullTextIndexName(nodeType, property string) string {
+	return fmt.Sprintf("fulltext_%s_%s", strings.ToLower(nodeType), strings.ToLower(property))
+}
+
 func (g *Neo4jGraph) DropIndex(ctx context.Context, nodeType, property string) error {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
 	defer session.Close(ctx)
 
 	indexName := fmt.Sprintf("index_%s_%s", strings.ToLower(nodeType), strings.ToLower(property))
 	query := fmt.Sprintf("DROP INDEX %s IF EXISTS", indexName)
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		_, err := tx.Run(ctx, query, map[string]interface{}{})
+		return nil, err
+	})
+
+	return err
+}
+
+// DropUniqueConstraint removes the unique constraint CreateUniqueConstraint created, if it exists
+func (g *Neo4jGraph) DropUniqueConstraint(ctx context.Context, nodeType, property string) error {
+	session := g.newSession(ctx, neo4j.AccessModeWrite)
+	defer session.Close(ctx)
+
+	constraintName := fmt.Sprintf("unique_%s_%s", strings.ToLower(nodeType), strings.ToLower(property))
+	query := fmt.Sprintf("DROP CONSTRAINT %s IF EXISTS", constraintName)
+
+	_, err := g.executeWrite(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		_, err := tx.Run(ctx, query, map[string]interface{}{})
 		return nil, err
 	})
@@ -433,7 +1302,7 @@ func (g *Neo4jGraph) DropIndex(ctx context.Context, nodeType, property string) e
 }
 
 func (g *Neo4jGraph) HasUniqueConstraint(ctx context.Context, nodeType, property string) (bool, error) {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	session := g.newSession(ctx, neo4j.AccessModeRead)
 	defer session.Close(ctx)
 
 	// Check for unique constraints on the specified node type and property
@@ -443,7 +1312,7 @@ func (g *Neo4jGraph) HasUniqueConstraint(ctx context.Context, nodeType, property
 		"property": property,
 	}
 
-	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	result, err := g.executeRead(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		result, err := tx.Run(ctx, query, params)
 		if err != nil {
 			return false, err
@@ -459,7 +1328,7 @@ func (g *Neo4jGraph) HasUniqueConstraint(ctx context.Context, nodeType, property
 }
 
 func (g *Neo4jGraph) HasIndex(ctx context.Context, nodeType, property string) (bool, error) {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	session := g.newSession(ctx, neo4j.AccessModeRead)
 	defer session.Close(ctx)
 
 	query := "SHOW INDEXES YIELD name, labelsOrTypes, properties WHERE $nodeType IN labelsOrTypes AND $property IN properties"
@@ -468,7 +1337,7 @@ func (g *Neo4jGraph) HasIndex(ctx context.Context, nodeType, property string) (b
 		"property": property,
 	}
 
-	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	result, err := g.executeRead(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		result, err := tx.Run(ctx, query, params)
 		if err != nil {
 			return false, err
@@ -484,7 +1353,7 @@ func (g *Neo4jGraph) HasIndex(ctx context.Context, nodeType, property string) (b
 }
 
 func (g *Neo4jGraph) HasRelationshipType(ctx context.Context, relationshipType string) (bool, error) {
-	session := g.driver.NewSession(ctx, neo4j.SessionConfig{})
+	session := g.newSession(ctx, neo4j.AccessModeRead)
 	defer session.Close(ctx)
 
 	query := "CALL db.relationshipTypes() YIELD relationshipType as relType WHERE relType = $relationshipType RETURN count(relType) > 0 as exists"
@@ -492,7 +1361,7 @@ func (g *Neo4jGraph) HasRelationshipType(ctx context.Context, relationshipType s
 		"relationshipType": relationshipType,
 	}
 
-	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	result, err := g.executeRead(ctx, session, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		result, err := tx.Run(ctx, query, params)
 		if err != nil {
 			return false, err
@@ -518,6 +1387,10 @@ func convertValue(value interface{}) interface{} {
 		// Convert Neo4j int64 to int for consistency
 		return int(v)
 	case []interface{}:
+		// Lists of strings come back from Neo4j as []interface{}; normalize them
+		if strs, ok := convertStringSlice(v); ok {
+			return strs
+		}
 		// Convert slice elements recursively
 		result := make([]interface{}, len(v))
 		for i, elem := range v {
@@ -529,6 +1402,29 @@ func convertValue(value interface{}) interface{} {
 	}
 }
 
+// convertStringSlice converts a list to []string when every element is a string
+func convertStringSlice(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		if len(v) == 0 {
+			return nil, false
+		}
+		result := make([]string, len(v))
+		for i, elem := range v {
+			str, ok := elem.(string)
+			if !ok {
+				return nil, false
+			}
+			result[i] = str
+		}
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
 // convertProperties converts a map of Neo4j properties to normalized Go types
 func convertProperties(props map[string]interface{}) map[string]interface{} {
 	result := make(map[string]interface{})
@@ -2,10 +2,17 @@ package graph
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
 	"testing"
+	"time"
 
 	"neuromesh/internal/logging"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	neo4jConfig "github.com/neo4j/neo4j-go-driver/v5/neo4j/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -19,10 +26,11 @@ func TestNeo4jGraph_Integration(t *testing.T) {
 
 	logger := logging.NewNoOpLogger()
 	config := GraphConfig{
-		Backend:       GraphBackendNeo4j,
-		Neo4jURL:      "bolt://localhost:7687",
-		Neo4jUser:     "neo4j",
-		Neo4jPassword: "orchestrator123",
+		Backend:            GraphBackendNeo4j,
+		Neo4jURL:           "bolt://localhost:7687",
+		Neo4jUser:          "neo4j",
+		Neo4jPassword:      "orchestrator123",
+		AllowClearTestData: true,
 	}
 
 	ctx := context.Background()
@@ -97,6 +105,22 @@ func TestNeo4jGraph_Integration(t *testing.T) {
 	})
 
 	// Test edge operations
+	t.Run("Array Property Round Trip", func(t *testing.T) {
+		err := graph.AddNode(ctx, "Conversation", "conv-tags", map[string]interface{}{
+			"tags": []string{"billing", "urgent"},
+		})
+		require.NoError(t, err)
+
+		result, err := graph.GetNode(ctx, "Conversation", "conv-tags")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"billing", "urgent"}, result["tags"])
+
+		results, err := graph.QueryNodes(ctx, "Conversation", map[string]interface{}{"id": "conv-tags"})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, []string{"billing", "urgent"}, results[0]["tags"])
+	})
+
 	t.Run("Edge Operations", func(t *testing.T) {
 		// Add two nodes to connect
 		err := graph.AddNode(ctx, "Agent", "agent-source", map[string]interface{}{
@@ -152,10 +176,158 @@ func TestNeo4jGraph_Integration(t *testing.T) {
 		graph.DeleteNode(ctx, "Agent", "agent-2")
 	})
 
+	t.Run("Missing Node", func(t *testing.T) {
+		result, err := graph.GetNode(ctx, "Agent", "does-not-exist")
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, ErrNodeNotFound))
+	})
+
 	t.Run("GetStats", func(t *testing.T) {
-		stats := graph.GetStats()
-		assert.Equal(t, "neo4j", stats["implementation"])
+		before, err := graph.GetStats(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "neo4j", before["implementation"])
+
+		require.NoError(t, graph.AddNode(ctx, "StatsAgent", "stats-agent-1", nil))
+		require.NoError(t, graph.AddNode(ctx, "StatsAgent", "stats-agent-2", nil))
+		require.NoError(t, graph.AddNode(ctx, "StatsCapability", "stats-capability", nil))
+		require.NoError(t, graph.AddEdge(ctx, "StatsAgent", "stats-agent-1", "StatsCapability", "stats-capability", "HAS_CAPABILITY", nil))
+		require.NoError(t, graph.AddEdge(ctx, "StatsAgent", "stats-agent-2", "StatsCapability", "stats-capability", "HAS_CAPABILITY", nil))
+
+		after, err := graph.GetStats(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, before["total_nodes"].(int)+3, after["total_nodes"])
+		assert.Equal(t, before["total_edges"].(int)+2, after["total_edges"])
+		nodesByLabel := after["nodes_by_label"].(map[string]int)
+		assert.Equal(t, 2, nodesByLabel["StatsAgent"])
+		assert.Equal(t, 1, nodesByLabel["StatsCapability"])
 	})
+
+	t.Run("ClearNodesByType", func(t *testing.T) {
+		require.NoError(t, graph.AddNode(ctx, "ScopedAgent", "scoped-agent", nil))
+		require.NoError(t, graph.AddNode(ctx, "ScopedCapability", "scoped-capability", nil))
+		require.NoError(t, graph.AddEdge(ctx, "ScopedAgent", "scoped-agent", "ScopedCapability", "scoped-capability", "HAS_CAPABILITY", nil))
+
+		require.NoError(t, graph.ClearNodesByType(ctx, "ScopedAgent"))
+
+		_, err := graph.GetNode(ctx, "ScopedAgent", "scoped-agent")
+		assert.True(t, errors.Is(err, ErrNodeNotFound))
+
+		// Nodes of other types survive, without the edges of the deleted nodes
+		capability, err := graph.GetNode(ctx, "ScopedCapability", "scoped-capability")
+		require.NoError(t, err)
+		assert.Equal(t, "scoped-capability", capability["id"])
+		agent, err := graph.GetNode(ctx, "StatsAgent", "stats-agent-1")
+		require.NoError(t, err)
+		assert.Equal(t, "stats-agent-1", agent["id"])
+
+		stats, err := graph.GetStats(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, stats["nodes_by_label"].(map[string]int)["ScopedAgent"])
+	})
+
+	t.Run("DeleteNodesByFilter", func(t *testing.T) {
+		require.NoError(t, graph.AddNode(ctx, "FilteredCapability", "orphan-1", map[string]interface{}{"orphaned": true}))
+		require.NoError(t, graph.AddNode(ctx, "FilteredCapability", "orphan-2", map[string]interface{}{"orphaned": true}))
+		require.NoError(t, graph.AddNode(ctx, "FilteredCapability", "linked", map[string]interface{}{"orphaned": false}))
+
+		deleted, err := graph.DeleteNodesByFilter(ctx, "FilteredCapability", map[string]interface{}{"orphaned": true})
+		require.NoError(t, err)
+		assert.Equal(t, 2, deleted)
+
+		count, err := graph.CountNodes(ctx, "FilteredCapability", nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		_, err = graph.DeleteNodesByFilter(ctx, "FilteredCapability", nil)
+		assert.Error(t, err, "deleting without filters must be refused")
+	})
+
+	t.Run("Aggregate", func(t *testing.T) {
+		for id, props := range map[string]map[string]interface{}{
+			"d1": {"kind": "EXECUTE", "confidence": 90},
+			"d2": {"kind": "EXECUTE", "confidence": 70},
+			"d3": {"kind": "CLARIFY", "confidence": 40},
+		} {
+			require.NoError(t, graph.AddNode(ctx, "AggDecision", id, props))
+		}
+		require.NoError(t, graph.AddNode(ctx, "AggOutcome", "d1", map[string]interface{}{"status": "success"}))
+		require.NoError(t, graph.AddEdge(ctx, "AggOutcome", "d1", "AggDecision", "d1", "AGG_OUTCOME_OF", nil))
+
+		groups, err := graph.Aggregate(ctx, Aggregation{
+			NodeType:        "AggDecision",
+			RelatedType:     "AggOutcome",
+			RelatedEdgeType: "AGG_OUTCOME_OF",
+			GroupBy:         []string{"kind"},
+			RelatedGroupBy:  []string{"status"},
+			AverageProperty: "confidence",
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []AggregateGroup{
+			{Values: map[string]interface{}{"kind": "EXECUTE", RelatedPrefix + "status": "success"}, Count: 1, Average: 90},
+			{Values: map[string]interface{}{"kind": "EXECUTE", RelatedPrefix + "status": nil}, Count: 1, Average: 70},
+			{Values: map[string]interface{}{"kind": "CLARIFY", RelatedPrefix + "status": nil}, Count: 1, Average: 40},
+		}, groups)
+	})
+
+	t.Run("DeleteOrphanedNodes", func(t *testing.T) {
+		require.NoError(t, graph.AddNode(ctx, "OwnerAgent", "owner", nil))
+		require.NoError(t, graph.AddNode(ctx, "OwnedCapability", "linked", nil))
+		require.NoError(t, graph.AddNode(ctx, "OwnedCapability", "orphan", map[string]interface{}{"created_at": time.Now().UTC().Add(-time.Hour)}))
+		require.NoError(t, graph.AddNode(ctx, "OwnedCapability", "registering", map[string]interface{}{"created_at": time.Now().UTC()}))
+		require.NoError(t, graph.AddEdge(ctx, "OwnerAgent", "owner", "OwnedCapability", "linked", "OWNS", nil))
+
+		deleted, err := graph.DeleteOrphanedNodes(ctx, "OwnedCapability", "OWNS", time.Now().UTC().Add(-time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		remaining, err := graph.QueryNodes(ctx, "OwnedCapability", nil)
+		require.NoError(t, err)
+		assert.Len(t, remaining, 2, "linked and recently created nodes are kept")
+	})
+
+	t.Run("UpdateNodeIf", func(t *testing.T) {
+		require.NoError(t, graph.AddNode(ctx, "GuardedPlan", "plan-1", map[string]interface{}{"status": "pending"}))
+
+		updated, err := graph.UpdateNodeIf(ctx, "GuardedPlan", "plan-1", map[string]interface{}{"status": "pending"}, map[string]interface{}{"status": "approved"})
+		require.NoError(t, err)
+		assert.True(t, updated)
+
+		// The second transition from the same status loses
+		updated, err = graph.UpdateNodeIf(ctx, "GuardedPlan", "plan-1", map[string]interface{}{"status": "pending"}, map[string]interface{}{"status": "rejected"})
+		require.NoError(t, err)
+		assert.False(t, updated)
+
+		node, err := graph.GetNode(ctx, "GuardedPlan", "plan-1")
+		require.NoError(t, err)
+		assert.Equal(t, "approved", node["status"])
+		assert.NotContains(t, node, "_lock")
+	})
+
+	t.Run("MergeNode", func(t *testing.T) {
+		require.NoError(t, graph.CreateUniqueConstraint(ctx, "IdempotencyKey", "id"))
+
+		created, err := graph.MergeNode(ctx, "IdempotencyKey", "event-1", map[string]interface{}{"owner": "first"})
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		// A repeated key neither creates a node nor overwrites the first
+		created, err = graph.MergeNode(ctx, "IdempotencyKey", "event-1", map[string]interface{}{"owner": "second"})
+		require.NoError(t, err)
+		assert.False(t, created)
+
+		node, err := graph.GetNode(ctx, "IdempotencyKey", "event-1")
+		require.NoError(t, err)
+		assert.Equal(t, "first", node["owner"])
+		assert.NotContains(t, node, "_created")
+	})
+}
+
+// TestNeo4jGraph_ClearTestDataRequiresOptIn checks that the full wipe refuses to run without an explicit opt-in
+func TestNeo4jGraph_ClearTestDataRequiresOptIn(t *testing.T) {
+	t.Setenv(AllowClearTestDataEnv, "")
+
+	err := (&Neo4jGraph{}).ClearTestData(context.Background())
+	assert.ErrorIs(t, err, ErrClearTestDataDisabled)
 }
 
 // TestNeo4jGraph_ErrorHandling tests error scenarios
@@ -210,3 +382,108 @@ func TestGraphFactory_Neo4j(t *testing.T) {
 		defer graph.DeleteNode(ctx, "TestNode", "test-1")
 	}
 }
+
+// benchDatabaseEnv names the dedicated Neo4j database the graph benchmark runs against
+const benchDatabaseEnv = "NEO4J_BENCH_DATABASE"
+
+// BenchmarkNeo4jGraph_ConcurrentQueryNodes compares a raw session per call with the graph's read path on the same production-sized pool
+// It runs against the database named by NEO4J_BENCH_DATABASE and removes only the nodes it seeded, so it never touches application data
+// This benchmark requires a running Neo4j instance (use docker-compose up neo4j)
+func BenchmarkNeo4jGraph_ConcurrentQueryNodes(b *testing.B) {
+	database := os.Getenv(benchDatabaseEnv)
+	if database == "" {
+		b.Skipf("set %s to a dedicated Neo4j database to run this benchmark", benchDatabaseEnv)
+	}
+
+	ctx := context.Background()
+	config := GraphConfig{
+		Backend:                      GraphBackendNeo4j,
+		Neo4jURL:                     "bolt://localhost:7687",
+		Neo4jUser:                    "neo4j",
+		Neo4jPassword:                "orchestrator123",
+		Neo4jDatabase:                database,
+		MaxConnectionPoolSize:        DefaultMaxConnectionPoolSize,
+		ConnectionAcquisitionTimeout: 5 * time.Second,
+	}
+
+	g, err := NewNeo4jGraph(ctx, config, logging.NewNoOpLogger())
+	if err != nil {
+		b.Skipf("Neo4j not available: %v", err)
+	}
+	defer g.Close(ctx)
+
+	// Seeded nodes carry this run's ID so cleanup deletes nothing else
+	runID := fmt.Sprintf("bench-%d", time.Now().UnixNano())
+	for i := 0; i < 50; i++ {
+		require.NoError(b, g.AddNode(ctx, "BenchAgent", fmt.Sprintf("%s-agent-%d", runID, i), map[string]interface{}{"status": "online", "bench_run": runID}))
+	}
+	defer g.DeleteNodesByFilter(ctx, "BenchAgent", map[string]interface{}{"bench_run": runID})
+	filters := map[string]interface{}{"status": "online", "bench_run": runID}
+
+	b.Run("per_call_session", func(b *testing.B) {
+		driver, err := neo4j.NewDriverWithContext(config.Neo4jURL, neo4j.BasicAuth(config.Neo4jUser, config.Neo4jPassword, ""), func(c *neo4jConfig.Config) {
+			c.MaxConnectionPoolSize = config.MaxConnectionPoolSize
+			c.ConnectionAcquisitionTimeout = config.ConnectionAcquisitionTimeout
+		})
+		require.NoError(b, err)
+		defer driver.Close(ctx)
+
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				session := driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: database})
+				result, err := session.Run(ctx, "MATCH (n:BenchAgent) WHERE n.status = $status AND n.bench_run = $bench_run RETURN n", filters)
+				if err == nil {
+					_, err = result.Collect(ctx)
+				}
+				session.Close(ctx)
+				if err != nil {
+					b.Error(err)
+				}
+			}
+		})
+	})
+
+	b.Run("graph_read_session", func(b *testing.B) {
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if _, err := g.QueryNodes(ctx, "BenchAgent", filters); err != nil {
+					b.Error(err)
+				}
+			}
+		})
+	})
+}
+
+// TestNeo4jGraph_ContextCancellation checks that cancelling ctx aborts an operation stuck waiting on the server
+// The "server" accepts connections and never answers, so this test needs no Neo4j instance
+func TestNeo4jGraph_ContextCancellation(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close() // Held open without answering until the test ends
+		}
+	}()
+
+	driver, err := neo4j.NewDriverWithContext("bolt://"+listener.Addr().String(), neo4j.NoAuth())
+	require.NoError(t, err)
+	g := &Neo4jGraph{driver: driver, fetchSize: DefaultFetchSize, queryTimeout: DefaultQueryTimeout, logger: logging.NewNoOpLogger()}
+	defer g.Close(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = g.QueryNodes(ctx, "Agent", map[string]interface{}{"status": "online"})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 2*time.Second, "cancellation must abort the query promptly")
+}
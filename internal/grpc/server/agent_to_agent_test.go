@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "neuromesh/internal/api/grpc/api"
+	"neuromesh/internal/logging"
+	"neuromesh/internal/messaging"
+	"neuromesh/testHelpers"
+)
+
+func TestOrchestrationServer_AgentToAgentMessage_ReachesTargetSubscription(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewNoOpLogger()
+	bus := messaging.NewAIMessageBus(messaging.NewMemoryMessageBus(logger), testHelpers.NewCleanMockGraph(), logger)
+	server := NewOrchestrationServer(bus, testHelpers.NewMockRegistry(), logger)
+
+	agentB, err := bus.Subscribe(ctx, "agent-b")
+	require.NoError(t, err)
+
+	msgContext, err := structpb.NewStruct(map[string]interface{}{"purpose": "summarize"})
+	require.NoError(t, err)
+
+	err = server.processIncomingMessage(ctx, &pb.ConversationMessage{
+		MessageId:     "msg-1",
+		CorrelationId: "corr-a-to-b",
+		Type:          pb.MessageType_MESSAGE_TYPE_AGENT_TO_AGENT,
+		FromId:        "agent-a",
+		ToId:          "agent-b",
+		Content:       "Please summarize this text",
+		Context:       msgContext,
+	})
+	require.NoError(t, err)
+
+	select {
+	case received := <-agentB:
+		assert.Equal(t, "corr-a-to-b", received.CorrelationID)
+		assert.Equal(t, "agent-a", received.FromID)
+		assert.Equal(t, "agent-b", received.ToID)
+		assert.Equal(t, messaging.MessageTypeAgentToAgent, received.MessageType)
+
+		// The receiving stream gets the hand-off as an instruction-like message with its context
+		pbMsg := server.convertToPbMessage(received)
+		assert.Equal(t, pb.MessageType_MESSAGE_TYPE_AGENT_TO_AGENT, pbMsg.Type)
+		assert.Equal(t, "corr-a-to-b", pbMsg.CorrelationId)
+		assert.Equal(t, "Please summarize this text", pbMsg.Content)
+		assert.Equal(t, "summarize", pbMsg.Context.AsMap()["purpose"])
+	case <-time.After(time.Second):
+		t.Fatal("agent-b did not receive the agent-to-agent message")
+	}
+}
+
+func TestOrchestrationServer_AgentToAgentMessage_RequiresTarget(t *testing.T) {
+	logger := logging.NewNoOpLogger()
+	bus := messaging.NewAIMessageBus(messaging.NewMemoryMessageBus(logger), testHelpers.NewCleanMockGraph(), logger)
+	server := NewOrchestrationServer(bus, testHelpers.NewMockRegistry(), logger)
+
+	err := server.processIncomingMessage(context.Background(), &pb.ConversationMessage{
+		CorrelationId: "corr-no-target",
+		Type:          pb.MessageType_MESSAGE_TYPE_AGENT_TO_AGENT,
+		FromId:        "agent-a",
+		Content:       "Nobody to hand this to",
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	pb "neuromesh/internal/api/grpc/api"
+	"neuromesh/internal/logging"
+	"neuromesh/internal/messaging"
+	"neuromesh/internal/metrics"
+	"neuromesh/testHelpers"
+)
+
+// fakeConversationStream hands the server the messages pushed by the test, one per Recv
+// Messages the server sends to the agent are passed on to sent when it is set
+type fakeConversationStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	incoming chan *pb.ConversationMessage
+	sent     chan *pb.ConversationMessage
+}
+
+func (s *fakeConversationStream) Context() context.Context { return s.ctx }
+
+func (s *fakeConversationStream) Recv() (*pb.ConversationMessage, error) {
+	select {
+	case msg := <-s.incoming:
+		return msg, nil
+	case <-s.ctx.Done():
+		return nil, io.EOF
+	}
+}
+
+func (s *fakeConversationStream) Send(msg *pb.ConversationMessage) error {
+	if s.sent != nil {
+		s.sent <- msg
+	}
+	return nil
+}
+
+// slowAIBus holds the first agent message until released, so later ones pile up in the stream buffer
+type slowAIBus struct {
+	messaging.AIMessageBus
+	started   chan struct{}
+	release   chan struct{}
+	mu        sync.Mutex
+	processed []string
+}
+
+func (b *slowAIBus) Subscribe(ctx context.Context, participantID string) (<-chan *messaging.Message, error) {
+	return make(chan *messaging.Message), nil
+}
+
+func (b *slowAIBus) SendToAI(ctx context.Context, msg *messaging.AgentToAIMessage) error {
+	b.mu.Lock()
+	first := len(b.processed) == 0
+	b.processed = append(b.processed, msg.Content)
+	b.mu.Unlock()
+	if first {
+		close(b.started)
+		<-b.release
+	}
+	return nil
+}
+
+func (b *slowAIBus) Processed() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.processed...)
+}
+
+func TestOrchestrationServer_OpenConversation_DropsOldestWhenBufferFull(t *testing.T) {
+	agentID := "backpressure-agent"
+	ctx, cancel := context.WithCancel(metadata.NewIncomingContext(context.Background(), metadata.Pairs("agent-id", agentID)))
+	defer cancel()
+
+	bus := &slowAIBus{started: make(chan struct{}), release: make(chan struct{})}
+	server := NewOrchestrationServer(bus, testHelpers.NewMockRegistry(), logging.NewNoOpLogger())
+	server.SetIncomingBufferSize(2)
+
+	stream := &fakeConversationStream{ctx: ctx, incoming: make(chan *pb.ConversationMessage)}
+	done := make(chan error, 1)
+	go func() { done <- server.OpenConversation(stream) }()
+
+	completion := func(n int) *pb.ConversationMessage {
+		return &pb.ConversationMessage{
+			MessageId:     "msg-" + strconv.Itoa(n),
+			CorrelationId: "corr-" + strconv.Itoa(n),
+			Type:          pb.MessageType_MESSAGE_TYPE_COMPLETION,
+			FromId:        agentID,
+			Content:       strconv.Itoa(n),
+		}
+	}
+	push := func(n int) {
+		select {
+		case stream.incoming <- completion(n):
+		case <-time.After(time.Second):
+			t.Fatalf("stream stalled before message %d was received", n)
+		}
+	}
+	dropsBefore := testutil.ToFloat64(metrics.StreamBackpressure.WithLabelValues(agentID))
+
+	// The first message occupies the event loop; the next two fill the buffer
+	push(1)
+	select {
+	case <-bus.started:
+	case <-time.After(time.Second):
+		t.Fatal("first message was not processed")
+	}
+	for n := 2; n <= 6; n++ {
+		push(n)
+	}
+
+	// Messages 4 to 6 each pushed out the oldest buffered message instead of blocking the stream
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(metrics.StreamBackpressure.WithLabelValues(agentID)) == dropsBefore+3
+	}, time.Second, time.Millisecond)
+
+	close(bus.release)
+	require.Eventually(t, func() bool { return len(bus.Processed()) == 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"1", "5", "6"}, bus.Processed())
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("conversation stream did not close")
+	}
+}
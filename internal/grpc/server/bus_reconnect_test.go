@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "neuromesh/internal/api/grpc/api"
+	"neuromesh/internal/logging"
+	"neuromesh/internal/messaging"
+	"neuromesh/testHelpers"
+)
+
+// reconnectingAIBus hands out a new subscription channel per Subscribe and lets the test announce reconnects
+type reconnectingAIBus struct {
+	messaging.AIMessageBus
+	mu            sync.Mutex
+	subscriptions chan chan *messaging.Message
+	reconnected   chan struct{}
+}
+
+func newReconnectingAIBus() *reconnectingAIBus {
+	return &reconnectingAIBus{
+		subscriptions: make(chan chan *messaging.Message, 4),
+		reconnected:   make(chan struct{}),
+	}
+}
+
+func (b *reconnectingAIBus) Subscribe(ctx context.Context, participantID string) (<-chan *messaging.Message, error) {
+	subscription := make(chan *messaging.Message, 1)
+	b.subscriptions <- subscription
+	return subscription, nil
+}
+
+func (b *reconnectingAIBus) Reconnected() <-chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.reconnected
+}
+
+func (b *reconnectingAIBus) reconnect() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	close(b.reconnected)
+	b.reconnected = make(chan struct{})
+}
+
+func (b *reconnectingAIBus) nextSubscription(t *testing.T) chan *messaging.Message {
+	t.Helper()
+	select {
+	case subscription := <-b.subscriptions:
+		return subscription
+	case <-time.After(time.Second):
+		t.Fatal("agent did not subscribe to the message bus")
+		return nil
+	}
+}
+
+// openConversationWithBus opens a conversation stream for agent-1 whose outgoing messages are captured
+func openConversationWithBus(server *OrchestrationServer) (*fakeConversationStream, context.CancelFunc, <-chan error) {
+	ctx, cancel := context.WithCancel(metadata.NewIncomingContext(context.Background(), metadata.Pairs("agent-id", "agent-1")))
+	stream := &fakeConversationStream{
+		ctx:      ctx,
+		incoming: make(chan *pb.ConversationMessage),
+		sent:     make(chan *pb.ConversationMessage, 4),
+	}
+	done := make(chan error, 1)
+	go func() { done <- server.OpenConversation(stream) }()
+	return stream, cancel, done
+}
+
+func expectSent(t *testing.T, stream *fakeConversationStream, content string) {
+	t.Helper()
+	select {
+	case msg := <-stream.sent:
+		assert.Equal(t, content, msg.Content)
+	case <-time.After(time.Second):
+		t.Fatalf("agent did not receive %q", content)
+	}
+}
+
+func TestOrchestrationServer_OpenConversation_SurvivesBusReconnect(t *testing.T) {
+	bus := newReconnectingAIBus()
+	server := NewOrchestrationServer(bus, testHelpers.NewMockRegistry(), logging.NewNoOpLogger())
+	stream, cancel, done := openConversationWithBus(server)
+	defer cancel()
+
+	first := bus.nextSubscription(t)
+	first <- &messaging.Message{ID: "m1", CorrelationID: "corr-1", Content: "before the blip", MessageType: messaging.MessageTypeInstruction}
+	expectSent(t, stream, "before the blip")
+
+	// The broker connection drops, closing the subscription, and comes back
+	close(first)
+	bus.reconnect()
+
+	second := bus.nextSubscription(t)
+	second <- &messaging.Message{ID: "m2", CorrelationID: "corr-2", Content: "after the blip", MessageType: messaging.MessageTypeInstruction}
+	expectSent(t, stream, "after the blip")
+
+	select {
+	case err := <-done:
+		t.Fatalf("stream ended on a transient bus reconnect: %v", err)
+	default:
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("conversation stream did not close")
+	}
+}
+
+func TestOrchestrationServer_OpenConversation_EndsWhenBusDoesNotReconnect(t *testing.T) {
+	bus := newReconnectingAIBus()
+	server := NewOrchestrationServer(bus, testHelpers.NewMockRegistry(), logging.NewNoOpLogger())
+	server.SetBusReconnectTimeout(20 * time.Millisecond)
+	_, cancel, done := openConversationWithBus(server)
+	defer cancel()
+
+	close(bus.nextSubscription(t))
+
+	select {
+	case err := <-done:
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	case <-time.After(time.Second):
+		t.Fatal("stream kept waiting for a bus that never came back")
+	}
+}
+
+func TestOrchestrationServer_OpenConversation_EndsWhenBusCannotReconnect(t *testing.T) {
+	// The bus has no reconnect notifications, so a closed subscription is final
+	server := NewOrchestrationServer(&closingAIBus{}, testHelpers.NewMockRegistry(), logging.NewNoOpLogger())
+	_, cancel, done := openConversationWithBus(server)
+	defer cancel()
+
+	select {
+	case err := <-done:
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Contains(t, err.Error(), "message bus closed")
+	case <-time.After(time.Second):
+		t.Fatal("stream did not end after the bus closed")
+	}
+}
+
+// closingAIBus hands out subscriptions that are already closed
+type closingAIBus struct {
+	messaging.AIMessageBus
+}
+
+func (b *closingAIBus) Subscribe(ctx context.Context, participantID string) (<-chan *messaging.Message, error) {
+	subscription := make(chan *messaging.Message)
+	close(subscription)
+	return subscription, nil
+}
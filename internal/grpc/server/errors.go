@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"neuromesh/internal/agent/registry"
+	"neuromesh/internal/messaging"
+)
+
+// ErrorDomain identifies the orchestration server in ErrorInfo details
+const ErrorDomain = "orchestration.neuromesh"
+
+// Machine-readable reasons attached to gRPC errors as ErrorInfo details
+const (
+	ReasonAgentNotFound    = "AGENT_NOT_FOUND"
+	ReasonBusUnavailable   = "MESSAGE_BUS_UNAVAILABLE"
+	ReasonDeadlineExceeded = "DEADLINE_EXCEEDED"
+	ReasonCancelled        = "CANCELLED"
+	ReasonInternal         = "INTERNAL"
+)
+
+// errorStatus maps a domain error to a gRPC code and reason
+func errorStatus(err error) (codes.Code, string) {
+	switch {
+	case errors.Is(err, registry.ErrAgentNotFound):
+		return codes.NotFound, ReasonAgentNotFound
+	case errors.Is(err, messaging.ErrBusUnavailable):
+		return codes.Unavailable, ReasonBusUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded, ReasonDeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled, ReasonCancelled
+	default:
+		return codes.Internal, ReasonInternal
+	}
+}
+
+// toStatusError converts a failed operation into a gRPC error carrying the mapped code and an ErrorInfo reason
+func toStatusError(err error, format string, args ...interface{}) error {
+	code, reason := errorStatus(err)
+	st := status.New(code, fmt.Sprintf("%s: %v", fmt.Sprintf(format, args...), err))
+
+	detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: ErrorDomain,
+	})
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// ErrorReason extracts the machine-readable reason from a gRPC error returned by the orchestration server
+func ErrorReason(err error) string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == ErrorDomain {
+			return info.Reason
+		}
+	}
+	return ""
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"neuromesh/internal/agent/registry"
+	pb "neuromesh/internal/api/grpc/api"
+	"neuromesh/internal/logging"
+	"neuromesh/internal/messaging"
+	"neuromesh/testHelpers"
+)
+
+func TestOrchestrationServer_RegisterAgent_ErrorCodes(t *testing.T) {
+	tests := []struct {
+		name        string
+		registryErr error
+		busErr      error
+		wantCode    codes.Code
+		wantReason  string
+	}{
+		{name: "registry failure", registryErr: assert.AnError, wantCode: codes.Internal, wantReason: ReasonInternal},
+		{name: "agent not found", registryErr: fmt.Errorf("lookup failed: %w", registry.ErrAgentNotFound), wantCode: codes.NotFound, wantReason: ReasonAgentNotFound},
+		{name: "message bus down", registryErr: fmt.Errorf("publish failed: %w", messaging.ErrBusUnavailable), wantCode: codes.Unavailable, wantReason: ReasonBusUnavailable},
+		{name: "registry timeout", registryErr: fmt.Errorf("write failed: %w", context.DeadlineExceeded), wantCode: codes.DeadlineExceeded, wantReason: ReasonDeadlineExceeded},
+		// Queue preparation is best-effort, so an unavailable bus does not fail the registration
+		{name: "queue not prepared", busErr: fmt.Errorf("declare failed: %w", messaging.ErrBusUnavailable), wantCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRegistry := testHelpers.NewMockRegistry()
+			mockBus := testHelpers.NewMockAIMessageBus()
+			server := NewOrchestrationServer(mockBus, mockRegistry, logging.NewNoOpLogger())
+
+			mockRegistry.On("RegisterAgent", mock.Anything, mock.AnythingOfType("*domain.Agent")).Return(tt.registryErr)
+			if tt.registryErr == nil {
+				mockBus.On("PrepareAgentQueue", mock.Anything, "test-agent").Return(tt.busErr)
+			}
+
+			_, err := server.RegisterAgent(context.Background(), &pb.RegisterAgentRequest{
+				AgentId:      "test-agent",
+				Name:         "Test Agent",
+				Capabilities: []*pb.AgentCapability{{Name: "deploy", Description: "Deploy applications"}},
+			})
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantReason, ErrorReason(err))
+			mockRegistry.AssertExpectations(t)
+			mockBus.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrchestrationServer_SendInstruction_ErrorCodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    *pb.InstructionMessage
+		busErr     error
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{
+			name:     "missing agent ID",
+			request:  &pb.InstructionMessage{Content: "Deploy"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "missing content",
+			request:  &pb.InstructionMessage{AgentId: "test-agent"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:       "message bus down",
+			request:    &pb.InstructionMessage{AgentId: "test-agent", Content: "Deploy", CorrelationId: "corr-1"},
+			busErr:     fmt.Errorf("failed to send agent message to AI: %w", messaging.ErrBusUnavailable),
+			wantCode:   codes.Unavailable,
+			wantReason: ReasonBusUnavailable,
+		},
+		{
+			name:       "message bus failure",
+			request:    &pb.InstructionMessage{AgentId: "test-agent", Content: "Deploy", CorrelationId: "corr-1"},
+			busErr:     assert.AnError,
+			wantCode:   codes.Internal,
+			wantReason: ReasonInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRegistry := testHelpers.NewMockRegistry()
+			mockBus := testHelpers.NewMockAIMessageBus()
+			server := NewOrchestrationServer(mockBus, mockRegistry, logging.NewNoOpLogger())
+
+			if tt.busErr != nil {
+				mockBus.On("SendToAI", mock.Anything, mock.Anything).Return(tt.busErr)
+			}
+
+			_, err := server.SendInstruction(context.Background(), tt.request)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantReason, ErrorReason(err))
+			mockBus.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrchestrationServer_UpdateAgentStatus_NotFound(t *testing.T) {
+	mockRegistry := testHelpers.NewMockRegistry()
+	server := NewOrchestrationServer(testHelpers.NewMockAIMessageBus(), mockRegistry, logging.NewNoOpLogger())
+
+	mockRegistry.On("UpdateAgentStatus", mock.Anything, "ghost-agent", mock.Anything).
+		Return(fmt.Errorf("%w: ghost-agent", registry.ErrAgentNotFound))
+
+	_, err := server.UpdateAgentStatus(context.Background(), &pb.UpdateAgentStatusRequest{
+		AgentId: "ghost-agent",
+		Status:  pb.AgentStatus_AGENT_STATUS_HEALTHY,
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, ReasonAgentNotFound, ErrorReason(err))
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "neuromesh/internal/api/grpc/api"
+	"neuromesh/internal/logging"
+	"neuromesh/testHelpers"
+)
+
+func registrationRequest(agentID string) *pb.RegisterAgentRequest {
+	return &pb.RegisterAgentRequest{
+		AgentId:      agentID,
+		Name:         "Test Agent",
+		Capabilities: []*pb.AgentCapability{{Name: "deploy", Description: "Deploy applications"}},
+	}
+}
+
+func withIdempotencyKey(key string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyHeader, key))
+}
+
+func TestOrchestrationServer_RegisterAgent_RetryWithSameKeyIsNoOp(t *testing.T) {
+	registry := testHelpers.NewMockRegistry()
+	bus := testHelpers.NewMockAIMessageBus()
+	registry.On("RegisterAgent", mock.Anything, mock.Anything).Return(nil).Once()
+	bus.On("PrepareAgentQueue", mock.Anything, "test-agent").Return(nil).Once()
+	server := NewOrchestrationServer(bus, registry, logging.NewNoOpLogger())
+
+	first, err := server.RegisterAgent(withIdempotencyKey("register-1"), registrationRequest("test-agent"))
+	require.NoError(t, err)
+
+	// The client timed out and retries with the same key
+	retry, err := server.RegisterAgent(withIdempotencyKey("register-1"), registrationRequest("test-agent"))
+	require.NoError(t, err)
+
+	assert.True(t, retry.Success)
+	assert.Equal(t, first.RegisteredAt.AsTime(), retry.RegisteredAt.AsTime())
+	registry.AssertNumberOfCalls(t, "RegisterAgent", 1)
+	bus.AssertNumberOfCalls(t, "PrepareAgentQueue", 1)
+}
+
+func TestOrchestrationServer_RegisterAgent_KeyExpiresAfterTTL(t *testing.T) {
+	registry := testHelpers.NewMockRegistry()
+	bus := testHelpers.NewMockAIMessageBus()
+	registry.On("RegisterAgent", mock.Anything, mock.Anything).Return(nil)
+	bus.On("PrepareAgentQueue", mock.Anything, "test-agent").Return(nil)
+	server := NewOrchestrationServer(bus, registry, logging.NewNoOpLogger())
+	server.SetRegistrationIdempotencyTTL(10 * time.Millisecond)
+
+	_, err := server.RegisterAgent(withIdempotencyKey("register-1"), registrationRequest("test-agent"))
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+	_, err = server.RegisterAgent(withIdempotencyKey("register-1"), registrationRequest("test-agent"))
+	require.NoError(t, err)
+
+	bus.AssertNumberOfCalls(t, "PrepareAgentQueue", 2)
+}
+
+func TestOrchestrationServer_RegisterAgent_FailedRegistrationCanBeRetried(t *testing.T) {
+	registry := testHelpers.NewMockRegistry()
+	bus := testHelpers.NewMockAIMessageBus()
+	registry.On("RegisterAgent", mock.Anything, mock.Anything).Return(fmt.Errorf("graph unavailable")).Once()
+	registry.On("RegisterAgent", mock.Anything, mock.Anything).Return(nil).Once()
+	bus.On("PrepareAgentQueue", mock.Anything, "test-agent").Return(nil).Once()
+	server := NewOrchestrationServer(bus, registry, logging.NewNoOpLogger())
+
+	_, err := server.RegisterAgent(withIdempotencyKey("register-1"), registrationRequest("test-agent"))
+	require.Error(t, err)
+	_, err = server.RegisterAgent(withIdempotencyKey("register-1"), registrationRequest("test-agent"))
+	require.NoError(t, err)
+
+	registry.AssertNumberOfCalls(t, "RegisterAgent", 2)
+	bus.AssertNumberOfCalls(t, "PrepareAgentQueue", 1)
+}
+
+func TestOrchestrationServer_RegisterAgent_RejectsKeyReusedForAnotherAgent(t *testing.T) {
+	registry := testHelpers.NewMockRegistry()
+	bus := testHelpers.NewMockAIMessageBus()
+	registry.On("RegisterAgent", mock.Anything, mock.Anything).Return(nil).Once()
+	bus.On("PrepareAgentQueue", mock.Anything, "test-agent").Return(nil).Once()
+	server := NewOrchestrationServer(bus, registry, logging.NewNoOpLogger())
+
+	_, err := server.RegisterAgent(withIdempotencyKey("register-1"), registrationRequest("test-agent"))
+	require.NoError(t, err)
+	_, err = server.RegisterAgent(withIdempotencyKey("register-1"), registrationRequest("other-agent"))
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	registry.AssertNumberOfCalls(t, "RegisterAgent", 1)
+}
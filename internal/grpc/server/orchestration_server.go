@@ -8,6 +8,8 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -18,6 +20,25 @@ import (
 	pb "neuromesh/internal/api/grpc/api"
 	"neuromesh/internal/logging"
 	"neuromesh/internal/messaging"
+	"neuromesh/internal/metrics"
+	"neuromesh/internal/tracing"
+)
+
+const (
+	// DefaultIncomingBufferSize is the number of agent messages a conversation stream buffers while earlier ones are processed
+	DefaultIncomingBufferSize = 10
+
+	// DefaultMaxStreamsPerAgent is the number of conversation streams one agent may hold open at once
+	DefaultMaxStreamsPerAgent = 1
+
+	// DefaultBusReconnectTimeout is how long a conversation stream waits for the message bus to reconnect
+	DefaultBusReconnectTimeout = 30 * time.Second
+
+	// DefaultRegistrationIdempotencyTTL is how long a registration's idempotency key is remembered
+	DefaultRegistrationIdempotencyTTL = 5 * time.Minute
+
+	// IdempotencyKeyHeader is the gRPC metadata key an agent sets so retried registrations are not repeated
+	IdempotencyKeyHeader = "idempotency-key"
 )
 
 // OrchestrationServer implements the gRPC OrchestrationService as a stateless proxy.
@@ -32,9 +53,28 @@ type OrchestrationServer struct {
 	registryService domain.AgentRegistry
 	logger          logging.Logger
 
-	// Track active streams for cleanup
-	activeStreams map[string]context.CancelFunc
-	streamsMutex  sync.RWMutex
+	// Track active streams for cleanup, by agent and then by stream
+	activeStreams      map[string]map[uint64]context.CancelFunc
+	streamsMutex       sync.RWMutex
+	nextStreamID       uint64
+	maxStreamsPerAgent int
+
+	incomingBufferSize  int
+	busReconnectTimeout time.Duration
+
+	// Registrations remembered by idempotency key so retries do not repeat their side effects
+	registrations      map[string]*registrationRecord
+	registrationsMutex sync.Mutex
+	registrationTTL    time.Duration
+}
+
+// registrationRecord is the outcome of a registration made with an idempotency key
+type registrationRecord struct {
+	agentID      string
+	registeredAt time.Time
+	err          error
+	expiresAt    time.Time     // Zero while the registration is in progress
+	done         chan struct{} // Closed once the registration has finished
 }
 
 // NewOrchestrationServer creates a new gRPC server that acts as a stateless proxy
@@ -43,7 +83,41 @@ func NewOrchestrationServer(messageBus messaging.AIMessageBus, registryService d
 		messageBus:      messageBus,
 		registryService: registryService,
 		logger:          logger,
-		activeStreams:   make(map[string]context.CancelFunc),
+		activeStreams:   make(map[string]map[uint64]context.CancelFunc),
+		registrations:   make(map[string]*registrationRecord),
+
+		maxStreamsPerAgent:  DefaultMaxStreamsPerAgent,
+		incomingBufferSize:  DefaultIncomingBufferSize,
+		busReconnectTimeout: DefaultBusReconnectTimeout,
+		registrationTTL:     DefaultRegistrationIdempotencyTTL,
+	}
+}
+
+// SetRegistrationIdempotencyTTL sets how long a registration's idempotency key is remembered
+func (s *OrchestrationServer) SetRegistrationIdempotencyTTL(ttl time.Duration) {
+	if ttl > 0 {
+		s.registrationTTL = ttl
+	}
+}
+
+// SetBusReconnectTimeout sets how long a conversation stream waits for the message bus to reconnect before closing
+func (s *OrchestrationServer) SetBusReconnectTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		s.busReconnectTimeout = timeout
+	}
+}
+
+// SetMaxStreamsPerAgent sets how many conversation streams one agent may hold open at once; zero removes the limit
+func (s *OrchestrationServer) SetMaxStreamsPerAgent(max int) {
+	if max >= 0 {
+		s.maxStreamsPerAgent = max
+	}
+}
+
+// SetIncomingBufferSize sets how many agent messages each conversation stream buffers before dropping the oldest
+func (s *OrchestrationServer) SetIncomingBufferSize(size int) {
+	if size > 0 {
+		s.incomingBufferSize = size
 	}
 }
 
@@ -66,6 +140,42 @@ func (s *OrchestrationServer) RegisterAgent(ctx context.Context, req *pb.Registe
 		return nil, status.Errorf(codes.InvalidArgument, "agent must have at least one capability")
 	}
 
+	// A retry carrying the key of an earlier registration gets that registration's outcome
+	key := idempotencyKey(ctx)
+	if key == "" {
+		registeredAt, err := s.registerAgent(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return registrationResponse(registeredAt), nil
+	}
+
+	record, first := s.claimRegistration(key, req.AgentId)
+	if record.agentID != req.AgentId {
+		return nil, status.Errorf(codes.InvalidArgument, "idempotency key already used to register agent %s", record.agentID)
+	}
+	if first {
+		registeredAt, err := s.registerAgent(ctx, req)
+		s.finishRegistration(key, record, registeredAt, err)
+	} else {
+		select {
+		case <-record.done:
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		s.logger.Info("Returning earlier registration for retried request",
+			"agent_id", req.AgentId,
+			"idempotency_key", key)
+	}
+
+	if record.err != nil {
+		return nil, record.err
+	}
+	return registrationResponse(record.registeredAt), nil
+}
+
+// registerAgent registers the agent with the registry and prepares its message queue
+func (s *OrchestrationServer) registerAgent(ctx context.Context, req *pb.RegisterAgentRequest) (time.Time, error) {
 	s.logger.Info("Registering agent via gRPC",
 		"agent_id", req.AgentId,
 		"capabilities", req.Capabilities)
@@ -88,7 +198,7 @@ func (s *OrchestrationServer) RegisterAgent(ctx context.Context, req *pb.Registe
 	if err != nil {
 		s.logger.Error("Failed to register agent", err,
 			"agent_id", req.AgentId)
-		return nil, status.Errorf(codes.Internal, "failed to register agent: %v", err)
+		return time.Time{}, toStatusError(err, "failed to register agent")
 	}
 
 	// Prepare agent's message queue and routing (without starting consumption)
@@ -109,11 +219,66 @@ func (s *OrchestrationServer) RegisterAgent(ctx context.Context, req *pb.Registe
 	s.logger.Info("Successfully registered agent",
 		"agent_id", req.AgentId)
 
+	return time.Now(), nil
+}
+
+// registrationResponse reports a successful registration made at registeredAt; registered agents start online
+func registrationResponse(registeredAt time.Time) *pb.RegisterAgentResponse {
 	return &pb.RegisterAgentResponse{
 		Success:      true,
 		Message:      "Agent registered successfully",
-		RegisteredAt: timestamppb.Now(),
-	}, nil
+		RegisteredAt: timestamppb.New(registeredAt),
+		Status:       agentStatusToProto(domain.AgentStatusOnline),
+	}
+}
+
+// idempotencyKey returns the idempotency key sent in the request's gRPC metadata, if any
+func idempotencyKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if keys := md.Get(IdempotencyKeyHeader); len(keys) > 0 {
+		return keys[0]
+	}
+	return ""
+}
+
+// claimRegistration returns the registration remembered for key, or records a new one for agentID
+// The caller that records it gets true and must finish it with finishRegistration
+func (s *OrchestrationServer) claimRegistration(key, agentID string) (*registrationRecord, bool) {
+	s.registrationsMutex.Lock()
+	defer s.registrationsMutex.Unlock()
+
+	now := time.Now()
+	for k, record := range s.registrations {
+		if !record.expiresAt.IsZero() && now.After(record.expiresAt) {
+			delete(s.registrations, k)
+		}
+	}
+
+	if record, ok := s.registrations[key]; ok {
+		return record, false
+	}
+	record := &registrationRecord{agentID: agentID, done: make(chan struct{})}
+	s.registrations[key] = record
+	return record, true
+}
+
+// finishRegistration stores a registration's outcome and releases the retries waiting on it
+// A failed registration is forgotten so a later retry can try again
+func (s *OrchestrationServer) finishRegistration(key string, record *registrationRecord, registeredAt time.Time, err error) {
+	s.registrationsMutex.Lock()
+	defer s.registrationsMutex.Unlock()
+
+	record.registeredAt = registeredAt
+	record.err = err
+	if err != nil {
+		delete(s.registrations, key)
+	} else {
+		record.expiresAt = time.Now().Add(s.registrationTTL)
+	}
+	close(record.done)
 }
 
 // UnregisterAgent delegates agent unregistration to the registry service (domain logic)
@@ -133,10 +298,10 @@ func (s *OrchestrationServer) UnregisterAgent(ctx context.Context, req *pb.Unreg
 
 	// Clean up any active streams for this agent
 	s.streamsMutex.Lock()
-	if cancel, exists := s.activeStreams[req.AgentId]; exists {
+	for _, cancel := range s.activeStreams[req.AgentId] {
 		cancel()
-		delete(s.activeStreams, req.AgentId)
 	}
+	delete(s.activeStreams, req.AgentId)
 	s.streamsMutex.Unlock()
 
 	// Delegate to registry service (domain logic)
@@ -144,7 +309,7 @@ func (s *OrchestrationServer) UnregisterAgent(ctx context.Context, req *pb.Unreg
 	if err != nil {
 		s.logger.Error("Failed to unregister agent", err,
 			"agent_id", req.AgentId)
-		return nil, status.Errorf(codes.Internal, "failed to unregister agent: %v", err)
+		return nil, toStatusError(err, "failed to unregister agent")
 	}
 
 	// TODO: Add message bus cleanup when AIMessageBus supports Unsubscribe
@@ -173,17 +338,8 @@ func (s *OrchestrationServer) UpdateAgentStatus(ctx context.Context, req *pb.Upd
 		"status", req.Status)
 
 	// Convert protobuf status to domain status
-	var domainStatus domain.AgentStatus
-	switch req.Status {
-	case pb.AgentStatus_AGENT_STATUS_HEALTHY:
-		domainStatus = domain.AgentStatusOnline
-	case pb.AgentStatus_AGENT_STATUS_BUSY:
-		domainStatus = domain.AgentStatusBusy
-	case pb.AgentStatus_AGENT_STATUS_ERROR:
-		domainStatus = domain.AgentStatusError
-	case pb.AgentStatus_AGENT_STATUS_SHUTTING_DOWN:
-		domainStatus = domain.AgentStatusShuttingDown
-	default:
+	domainStatus, ok := agentStatusFromProto(req.Status)
+	if !ok {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid agent status: %v", req.Status)
 	}
 
@@ -193,7 +349,7 @@ func (s *OrchestrationServer) UpdateAgentStatus(ctx context.Context, req *pb.Upd
 		s.logger.Error("Failed to update agent status", err,
 			"agent_id", req.AgentId,
 			"status", req.Status)
-		return nil, status.Errorf(codes.Internal, "failed to update agent status: %v", err)
+		return nil, toStatusError(err, "failed to update agent status")
 	}
 
 	// Update last seen timestamp
@@ -212,6 +368,7 @@ func (s *OrchestrationServer) UpdateAgentStatus(ctx context.Context, req *pb.Upd
 		Success:    true,
 		Message:    "Agent status updated successfully",
 		ServerTime: timestamppb.Now(),
+		Status:     agentStatusToProto(domainStatus),
 	}, nil
 }
 
@@ -239,33 +396,30 @@ func (s *OrchestrationServer) OpenConversation(stream pb.OrchestrationService_Op
 
 	s.logger.Info("Agent opened conversation", "agent_id", agentID)
 
-	// Subscribe to message bus for agent communication
-	s.logger.Debug("Subscribing to message bus", "agent_id", agentID)
-	messageChan, err := s.messageBus.Subscribe(ctx, agentID)
+	// Track this stream for cleanup
+	streamCtx, untrack, err := s.trackStream(ctx, agentID)
 	if err != nil {
-		s.logger.Error("Failed to subscribe to message bus", err, "agent_id", agentID)
-		return status.Errorf(codes.Internal, "failed to subscribe to message bus: %v", err)
+		s.logger.Warn("Rejecting conversation stream", "agent_id", agentID, "error", err)
+		return err
 	}
 
-	// Track this stream for cleanup
-	streamCtx, cancel := context.WithCancel(ctx)
-	s.streamsMutex.Lock()
-	s.activeStreams[agentID] = cancel
-	s.streamsMutex.Unlock()
-
 	// Cleanup on exit
 	defer func() {
-		s.streamsMutex.Lock()
-		if _, exists := s.activeStreams[agentID]; exists {
-			cancel()
-			delete(s.activeStreams, agentID)
-		}
-		s.streamsMutex.Unlock()
+		untrack()
 		s.logger.Info("Conversation stream closed", "agent_id", agentID)
 	}()
 
+	// Subscribe to message bus for agent communication; the reconnect notification is taken first so none is missed
+	s.logger.Debug("Subscribing to message bus", "agent_id", agentID)
+	reconnected := s.busReconnected()
+	messageChan, err := s.messageBus.Subscribe(streamCtx, agentID)
+	if err != nil {
+		s.logger.Error("Failed to subscribe to message bus", err, "agent_id", agentID)
+		return toStatusError(err, "failed to subscribe to message bus")
+	}
+
 	// Channel for incoming messages from the stream
-	incomingChan := make(chan *pb.ConversationMessage, 10)
+	incomingChan := make(chan *pb.ConversationMessage, s.incomingBufferSize)
 	errorChan := make(chan error, 1)
 
 	// Goroutine to receive messages from the stream
@@ -282,6 +436,26 @@ func (s *OrchestrationServer) OpenConversation(stream pb.OrchestrationService_Op
 				return
 			}
 
+			// When the agent sends faster than its messages are processed the oldest buffered message is dropped,
+			// so Recv keeps draining the stream instead of stalling it
+			select {
+			case incomingChan <- msg:
+				continue
+			case <-streamCtx.Done():
+				return
+			default:
+			}
+			select {
+			case dropped := <-incomingChan:
+				metrics.StreamBackpressure.WithLabelValues(agentID).Inc()
+				s.logger.Warn("Conversation stream buffer full, dropping oldest message",
+					"agent_id", agentID,
+					"dropped_message_id", dropped.MessageId,
+					"dropped_correlation_id", dropped.CorrelationId,
+					"buffer_size", cap(incomingChan))
+			default:
+				// The event loop took a message in the meantime, making room
+			}
 			select {
 			case incomingChan <- msg:
 			case <-streamCtx.Done():
@@ -314,8 +488,15 @@ func (s *OrchestrationServer) OpenConversation(stream pb.OrchestrationService_Op
 
 		case busMsg := <-messageChan:
 			if busMsg == nil {
-				// Message bus closed - this is an error
-				return status.Errorf(codes.Internal, "message bus closed")
+				// The subscription ended; keep the agent connected if the bus is only reconnecting
+				messageChan, reconnected, err = s.awaitBusReconnect(streamCtx, agentID, reconnected)
+				if err != nil {
+					return err
+				}
+				if messageChan == nil {
+					return nil
+				}
+				continue
 			}
 
 			// Convert message bus message to protobuf and send to agent
@@ -328,8 +509,100 @@ func (s *OrchestrationServer) OpenConversation(stream pb.OrchestrationService_Op
 	}
 }
 
+// busReconnected returns the message bus's next reconnect notification, or nil if the bus never reconnects
+func (s *OrchestrationServer) busReconnected() <-chan struct{} {
+	if notifier, ok := s.messageBus.(messaging.ReconnectNotifier); ok {
+		return notifier.Reconnected()
+	}
+	return nil
+}
+
+// awaitBusReconnect waits for the message bus to reconnect after an agent's subscription ended, then subscribes again
+// A bus that never reconnects, or stays down past the reconnect timeout, has closed for good and ends the stream
+// A nil channel without an error means the stream itself ended while waiting
+func (s *OrchestrationServer) awaitBusReconnect(ctx context.Context, agentID string, reconnected <-chan struct{}) (<-chan *messaging.Message, <-chan struct{}, error) {
+	if reconnected == nil {
+		return nil, nil, status.Errorf(codes.Unavailable, "message bus closed")
+	}
+
+	s.logger.Warn("Message bus subscription ended, waiting for the bus to reconnect",
+		"agent_id", agentID,
+		"timeout", s.busReconnectTimeout)
+
+	timer := time.NewTimer(s.busReconnectTimeout)
+	defer timer.Stop()
+	select {
+	case <-reconnected:
+	case <-ctx.Done():
+		return nil, nil, nil
+	case <-timer.C:
+		return nil, nil, status.Errorf(codes.Unavailable, "message bus closed: no reconnect within %s", s.busReconnectTimeout)
+	}
+
+	next := s.busReconnected()
+	messageChan, err := s.messageBus.Subscribe(ctx, agentID)
+	if err != nil {
+		s.logger.Error("Failed to re-subscribe to message bus", err, "agent_id", agentID)
+		return nil, nil, toStatusError(err, "failed to re-subscribe to message bus")
+	}
+
+	s.logger.Info("Re-subscribed agent after message bus reconnect", "agent_id", agentID)
+	return messageChan, next, nil
+}
+
+// trackStream registers a conversation stream of an agent, rejecting it once the agent holds the maximum
+// The returned function cancels and forgets exactly this stream, leaving the agent's other streams open
+func (s *OrchestrationServer) trackStream(ctx context.Context, agentID string) (context.Context, func(), error) {
+	s.streamsMutex.Lock()
+	defer s.streamsMutex.Unlock()
+
+	streams := s.activeStreams[agentID]
+	if s.maxStreamsPerAgent > 0 && len(streams) >= s.maxStreamsPerAgent {
+		return nil, nil, status.Errorf(codes.FailedPrecondition,
+			"agent %s already has %d open conversation stream(s)", agentID, len(streams))
+	}
+	if streams == nil {
+		streams = make(map[uint64]context.CancelFunc)
+		s.activeStreams[agentID] = streams
+	}
+
+	s.nextStreamID++
+	streamID := s.nextStreamID
+	streamCtx, cancel := context.WithCancel(ctx)
+	streams[streamID] = cancel
+
+	untrack := func() {
+		cancel()
+		s.streamsMutex.Lock()
+		defer s.streamsMutex.Unlock()
+		// UnregisterAgent may already have dropped the agent's streams
+		if streams, exists := s.activeStreams[agentID]; exists {
+			delete(streams, streamID)
+			if len(streams) == 0 {
+				delete(s.activeStreams, agentID)
+			}
+		}
+	}
+	return streamCtx, untrack, nil
+}
+
+// openStreams returns the number of conversation streams an agent holds open
+func (s *OrchestrationServer) openStreams(agentID string) int {
+	s.streamsMutex.RLock()
+	defer s.streamsMutex.RUnlock()
+	return len(s.activeStreams[agentID])
+}
+
 // processIncomingMessage handles messages received from the agent
 func (s *OrchestrationServer) processIncomingMessage(ctx context.Context, msg *pb.ConversationMessage) error {
+	// Continue the trace the orchestrator started when it sent the event this message answers
+	ctx, span := tracing.Start(traceContext(ctx, msg), "grpc.agent_message", trace.WithAttributes(
+		attribute.String("message.type", msg.Type.String()),
+		attribute.String("agent.id", msg.FromId),
+		attribute.String("correlation.id", msg.CorrelationId),
+	))
+	defer span.End()
+
 	s.logger.Debug("Processing incoming message",
 		"from_id", msg.FromId,
 		"to_id", msg.ToId,
@@ -379,6 +652,25 @@ func (s *OrchestrationServer) processIncomingMessage(ctx context.Context, msg *p
 
 		return s.messageBus.SendToAI(ctx, aiMsg)
 
+	case pb.MessageType_MESSAGE_TYPE_AGENT_TO_AGENT:
+		// Agent handing work off to another agent
+		if msg.ToId == "" {
+			return fmt.Errorf("agent-to-agent message from %s has no target agent", msg.FromId)
+		}
+
+		msgContext := convertStructToMap(msg.Context)
+		purpose, _ := msgContext["purpose"].(string)
+		agentMsg := &messaging.AgentToAgentMessage{
+			FromAgentID:   msg.FromId,
+			ToAgentID:     msg.ToId,
+			Content:       msg.Content,
+			CorrelationID: msg.CorrelationId,
+			Context:       msgContext,
+			Purpose:       purpose,
+		}
+
+		return s.messageBus.SendBetweenAgents(ctx, agentMsg)
+
 	case pb.MessageType_MESSAGE_TYPE_HEARTBEAT:
 		// Agent heartbeat - could be handled separately or ignored in stream
 		s.logger.Debug("Received heartbeat in conversation stream", "agent_id", msg.FromId)
@@ -399,7 +691,7 @@ func (s *OrchestrationServer) convertToPbMessage(msg *messaging.Message) *pb.Con
 		ToId:          msg.ToID,
 		Type:          convertMessageType(msg.MessageType),
 		Content:       msg.Content,
-		Context:       nil, // Simplified for now
+		Context:       convertMapToStruct(msg.Metadata),
 		Timestamp:     timestamppb.New(msg.Timestamp),
 	}
 }
@@ -421,6 +713,8 @@ func convertMessageType(msgType messaging.MessageType) pb.MessageType {
 		return pb.MessageType_MESSAGE_TYPE_INSTRUCTION
 	case messaging.MessageTypeAgentToAI:
 		return pb.MessageType_MESSAGE_TYPE_COMPLETION
+	case messaging.MessageTypeAgentToAgent:
+		return pb.MessageType_MESSAGE_TYPE_AGENT_TO_AGENT
 	default:
 		return pb.MessageType_MESSAGE_TYPE_UNKNOWN
 	}
@@ -441,6 +735,32 @@ func convertStructToMap(s interface{}) map[string]interface{} {
 	return make(map[string]interface{})
 }
 
+// traceContext extracts the trace context carried in a message's context, falling back to the stream's gRPC metadata
+func traceContext(ctx context.Context, msg *pb.ConversationMessage) context.Context {
+	carrier := convertStructToMap(msg.Context)
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, key := range []string{"traceparent", "tracestate"} {
+			if _, exists := carrier[key]; !exists && len(md.Get(key)) > 0 {
+				carrier[key] = md.Get(key)[0]
+			}
+		}
+	}
+	return tracing.Extract(ctx, carrier)
+}
+
+// convertMapToStruct converts message metadata to a protobuf Struct, dropping it if it holds unsupported values
+func convertMapToStruct(m map[string]interface{}) *structpb.Struct {
+	if len(m) == 0 {
+		return nil
+	}
+
+	pbStruct, err := structpb.NewStruct(m)
+	if err != nil {
+		return nil
+	}
+	return pbStruct
+}
+
 func convertStructToStringMap(s interface{}) map[string]string {
 	if s == nil {
 		return make(map[string]string)
@@ -505,13 +825,22 @@ func (s *OrchestrationServer) SendInstruction(ctx context.Context, req *pb.Instr
 		"capability", req.Capability,
 		"correlation_id", req.CorrelationId)
 
+	parameters := convertStructToMap(req.Parameters)
+
+	// Validate parameters against the target capability when one is named
+	if req.Capability != "" {
+		if err := s.validateInstructionParameters(ctx, req.AgentId, req.Capability, parameters); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid instruction parameters: %v", err)
+		}
+	}
+
 	// Convert instruction to AI message
 	aiMsg := &messaging.AgentToAIMessage{
 		AgentID:       req.AgentId,
 		Content:       req.Content,
 		MessageType:   messaging.MessageTypeInstruction,
 		CorrelationID: req.CorrelationId,
-		Context:       convertStructToMap(req.Parameters),
+		Context:       parameters,
 	}
 
 	err := s.messageBus.SendToAI(ctx, aiMsg)
@@ -519,7 +848,7 @@ func (s *OrchestrationServer) SendInstruction(ctx context.Context, req *pb.Instr
 		s.logger.Error("Failed to send AI instruction", err,
 			"agent_id", req.AgentId,
 			"instruction_id", req.InstructionId)
-		return nil, status.Errorf(codes.Internal, "failed to send instruction: %v", err)
+		return nil, toStatusError(err, "failed to send instruction")
 	}
 
 	s.logger.Debug("AI instruction sent successfully",
@@ -534,6 +863,26 @@ func (s *OrchestrationServer) SendInstruction(ctx context.Context, req *pb.Instr
 	}, nil
 }
 
+// validateInstructionParameters checks parameters against the agent's declared capability inputs
+// Unknown agents or capabilities are not rejected; the agent remains the final authority
+func (s *OrchestrationServer) validateInstructionParameters(ctx context.Context, agentID, capabilityName string, parameters map[string]interface{}) error {
+	agent, err := s.registryService.GetAgent(ctx, agentID)
+	if err != nil || agent == nil {
+		s.logger.Debug("Skipping parameter validation for unknown agent", "agent_id", agentID)
+		return nil
+	}
+
+	capability := agent.GetCapability(capabilityName)
+	if capability == nil {
+		s.logger.Debug("Skipping parameter validation for undeclared capability",
+			"agent_id", agentID,
+			"capability", capabilityName)
+		return nil
+	}
+
+	return capability.ValidateInputs(parameters)
+}
+
 // ReportCompletion handles agents reporting completion of tasks
 func (s *OrchestrationServer) ReportCompletion(ctx context.Context, req *pb.CompletionMessage) (*pb.CompletionResponse, error) {
 	// Input validation
@@ -579,7 +928,7 @@ func (s *OrchestrationServer) ReportCompletion(ctx context.Context, req *pb.Comp
 		s.logger.Error("Failed to send completion report", err,
 			"agent_id", req.AgentId,
 			"completion_id", req.CompletionId)
-		return nil, status.Errorf(codes.Internal, "failed to send completion: %v", err)
+		return nil, toStatusError(err, "failed to send completion")
 	}
 
 	s.logger.Debug("Completion report sent successfully",
@@ -614,6 +963,8 @@ func (s *OrchestrationServer) Heartbeat(ctx context.Context, req *pb.HeartbeatRe
 		statusStr = "error"
 	case pb.AgentStatus_AGENT_STATUS_SHUTTING_DOWN:
 		statusStr = "shutting_down"
+	case pb.AgentStatus_AGENT_STATUS_OFFLINE:
+		statusStr = "offline"
 	default:
 		statusStr = "unknown"
 	}
@@ -626,7 +977,7 @@ func (s *OrchestrationServer) Heartbeat(ctx context.Context, req *pb.HeartbeatRe
 		return &pb.HeartbeatResponse{
 			Success:    false,
 			ServerTime: timestamppb.Now(),
-		}, status.Errorf(codes.Internal, "failed to update heartbeat: %v", err)
+		}, toStatusError(err, "failed to update heartbeat")
 	}
 
 	if s.logger != nil {
@@ -646,9 +997,48 @@ func convertCapabilitiesFromPb(pbCapabilities []*pb.AgentCapability) []domain.Ag
 	capabilities := make([]domain.AgentCapability, len(pbCapabilities))
 	for i, cap := range pbCapabilities {
 		capabilities[i] = domain.AgentCapability{
-			Name:        cap.Name,
-			Description: cap.Description,
+			Name:           cap.Name,
+			Description:    cap.Description,
+			Inputs:         cap.Inputs,
+			Outputs:        cap.Outputs,
+			TimeoutSeconds: int(cap.TimeoutSeconds),
 		}
 	}
 	return capabilities
 }
+
+// agentStatusFromProto converts a protobuf agent status to its domain equivalent
+func agentStatusFromProto(s pb.AgentStatus) (domain.AgentStatus, bool) {
+	switch s {
+	case pb.AgentStatus_AGENT_STATUS_HEALTHY:
+		return domain.AgentStatusOnline, true
+	case pb.AgentStatus_AGENT_STATUS_BUSY:
+		return domain.AgentStatusBusy, true
+	case pb.AgentStatus_AGENT_STATUS_ERROR:
+		return domain.AgentStatusError, true
+	case pb.AgentStatus_AGENT_STATUS_SHUTTING_DOWN:
+		return domain.AgentStatusShuttingDown, true
+	case pb.AgentStatus_AGENT_STATUS_OFFLINE:
+		return domain.AgentStatusOffline, true
+	default:
+		return "", false
+	}
+}
+
+// agentStatusToProto converts a domain agent status to its protobuf equivalent
+func agentStatusToProto(s domain.AgentStatus) pb.AgentStatus {
+	switch s {
+	case domain.AgentStatusOnline:
+		return pb.AgentStatus_AGENT_STATUS_HEALTHY
+	case domain.AgentStatusBusy:
+		return pb.AgentStatus_AGENT_STATUS_BUSY
+	case domain.AgentStatusError:
+		return pb.AgentStatus_AGENT_STATUS_ERROR
+	case domain.AgentStatusShuttingDown:
+		return pb.AgentStatus_AGENT_STATUS_SHUTTING_DOWN
+	case domain.AgentStatusOffline, domain.AgentStatusDisconnected:
+		return pb.AgentStatus_AGENT_STATUS_OFFLINE
+	default:
+		return pb.AgentStatus_AGENT_STATUS_UNKNOWN
+	}
+}
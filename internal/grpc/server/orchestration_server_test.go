@@ -62,6 +62,7 @@ func TestOrchestrationServer_RegisterAgent_Success(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.True(t, resp.Success)
 	assert.Contains(t, resp.Message, "Agent registered successfully")
+	assert.Equal(t, pb.AgentStatus_AGENT_STATUS_HEALTHY, resp.Status)
 
 	// Verify mock was called
 	mockRegistry.AssertExpectations(t)
@@ -337,3 +338,105 @@ func CreateTestAgent() *domain.Agent {
 	}
 	return agent
 }
+
+func TestOrchestrationServer_UpdateAgentStatus_Offline(t *testing.T) {
+	// Setup
+	logger := logging.NewNoOpLogger()
+	mockRegistry := testHelpers.NewMockRegistry()
+	mockBus := testHelpers.NewMockAIMessageBus()
+
+	server := NewOrchestrationServer(mockBus, mockRegistry, logger)
+
+	req := &pb.UpdateAgentStatusRequest{
+		AgentId: "test-agent",
+		Status:  pb.AgentStatus_AGENT_STATUS_OFFLINE,
+	}
+
+	// Mock expectations
+	mockRegistry.On("UpdateAgentStatus", mock.Anything, "test-agent", domain.AgentStatusOffline).Return(nil)
+	mockRegistry.On("UpdateAgentLastSeen", mock.Anything, "test-agent").Return(nil)
+
+	// Execute
+	resp, err := server.UpdateAgentStatus(context.Background(), req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.Equal(t, pb.AgentStatus_AGENT_STATUS_OFFLINE, resp.Status)
+	mockRegistry.AssertExpectations(t)
+}
+
+func TestAgentStatus_ProtoRoundTrip(t *testing.T) {
+	for _, status := range []pb.AgentStatus{
+		pb.AgentStatus_AGENT_STATUS_HEALTHY,
+		pb.AgentStatus_AGENT_STATUS_BUSY,
+		pb.AgentStatus_AGENT_STATUS_ERROR,
+		pb.AgentStatus_AGENT_STATUS_SHUTTING_DOWN,
+		pb.AgentStatus_AGENT_STATUS_OFFLINE,
+	} {
+		domainStatus, ok := agentStatusFromProto(status)
+		require.True(t, ok, "status %v should map to a domain status", status)
+		assert.Equal(t, status, agentStatusToProto(domainStatus))
+	}
+
+	_, ok := agentStatusFromProto(pb.AgentStatus_AGENT_STATUS_UNKNOWN)
+	assert.False(t, ok)
+	assert.Equal(t, pb.AgentStatus_AGENT_STATUS_OFFLINE, agentStatusToProto(domain.AgentStatusDisconnected))
+}
+
+func TestConvertCapabilitiesFromPb_PreservesInputsAndOutputs(t *testing.T) {
+	capabilities := convertCapabilitiesFromPb([]*pb.AgentCapability{
+		{Name: "word-count", Description: "Counts words", Inputs: []string{"text"}, Outputs: []string{"count"}, TimeoutSeconds: 900},
+	})
+
+	require.Len(t, capabilities, 1)
+	assert.Equal(t, []string{"text"}, capabilities[0].Inputs)
+	assert.Equal(t, []string{"count"}, capabilities[0].Outputs)
+	assert.Equal(t, 900, capabilities[0].TimeoutSeconds)
+}
+
+func TestOrchestrationServer_SendInstruction_ValidatesCapabilityParameters(t *testing.T) {
+	agent := CreateTestAgent()
+	agent.Capabilities = []domain.AgentCapability{
+		{Name: "word-count", Description: "Counts words", Inputs: []string{"text"}},
+	}
+
+	tests := []struct {
+		name       string
+		parameters map[string]interface{}
+		wantCode   codes.Code
+	}{
+		{name: "missing parameter", parameters: map[string]interface{}{}, wantCode: codes.InvalidArgument},
+		{name: "extra parameter", parameters: map[string]interface{}{"text": "hi", "format": "json"}, wantCode: codes.InvalidArgument},
+		{name: "valid parameters", parameters: map[string]interface{}{"text": "hi"}, wantCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRegistry := testHelpers.NewMockRegistry()
+			mockBus := testHelpers.NewMockAIMessageBus()
+			server := NewOrchestrationServer(mockBus, mockRegistry, logging.NewNoOpLogger())
+
+			mockRegistry.On("GetAgent", mock.Anything, agent.ID).Return(agent, nil)
+			if tt.wantCode == codes.OK {
+				mockBus.On("SendToAI", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			parameters, err := structpb.NewStruct(tt.parameters)
+			require.NoError(t, err)
+
+			_, err = server.SendInstruction(context.Background(), &pb.InstructionMessage{
+				InstructionId: "instr-1",
+				CorrelationId: "corr-1",
+				AgentId:       agent.ID,
+				Content:       "Count the words",
+				Capability:    "word-count",
+				Parameters:    parameters,
+			})
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			mockRegistry.AssertExpectations(t)
+			mockBus.AssertExpectations(t)
+		})
+	}
+}
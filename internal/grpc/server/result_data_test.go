@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "neuromesh/internal/api/grpc/api"
+	"neuromesh/internal/logging"
+	"neuromesh/internal/messaging"
+	"neuromesh/testHelpers"
+)
+
+func TestOrchestrationServer_CompletionResultData_ReachesAIMessageContext(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewNoOpLogger()
+	bus := messaging.NewAIMessageBus(messaging.NewMemoryMessageBus(logger), testHelpers.NewCleanMockGraph(), logger)
+	server := NewOrchestrationServer(bus, testHelpers.NewMockRegistry(), logger)
+
+	aiMessages, err := bus.Subscribe(ctx, "ai-orchestrator")
+	require.NoError(t, err)
+
+	resultData, err := structpb.NewStruct(map[string]interface{}{"word_count": 3, "step_id": "step-1"})
+	require.NoError(t, err)
+
+	// receiveAIMessage waits for the message the server forwarded to the AI
+	receiveAIMessage := func(t *testing.T) *messaging.Message {
+		t.Helper()
+		select {
+		case msg := <-aiMessages:
+			return msg
+		case <-time.After(time.Second):
+			t.Fatal("the AI did not receive the completion")
+			return nil
+		}
+	}
+
+	t.Run("completion on the conversation stream", func(t *testing.T) {
+		err := server.processIncomingMessage(ctx, &pb.ConversationMessage{
+			MessageId:     "msg-1",
+			CorrelationId: "corr-stream",
+			Type:          pb.MessageType_MESSAGE_TYPE_COMPLETION,
+			FromId:        "text-processor",
+			ToId:          "orchestrator",
+			Content:       `The text "hello big world" contains 3 words.`,
+			Context:       resultData,
+		})
+		require.NoError(t, err)
+
+		msg := receiveAIMessage(t)
+		assert.Equal(t, "corr-stream", msg.CorrelationID)
+		assert.Equal(t, messaging.MessageTypeAgentToAI, msg.MessageType)
+		assert.Equal(t, float64(3), msg.Metadata["word_count"])
+		assert.Equal(t, "step-1", msg.Metadata["step_id"])
+	})
+
+	t.Run("completion reported through ReportCompletion", func(t *testing.T) {
+		_, err := server.ReportCompletion(ctx, &pb.CompletionMessage{
+			CompletionId:  "completion-1",
+			AgentId:       "text-processor",
+			CorrelationId: "corr-report",
+			Content:       `The text "hello big world" contains 3 words.`,
+			Success:       true,
+			ResultData:    resultData,
+		})
+		require.NoError(t, err)
+
+		msg := receiveAIMessage(t)
+		assert.Equal(t, "corr-report", msg.CorrelationID)
+		assert.Equal(t, map[string]interface{}{"word_count": float64(3), "step_id": "step-1"}, msg.Metadata)
+	})
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "neuromesh/internal/api/grpc/api"
+	"neuromesh/internal/logging"
+	"neuromesh/testHelpers"
+)
+
+// openTestStream opens a conversation stream for the agent; cancelling the returned context closes it
+func openTestStream(server *OrchestrationServer, agentID string) (context.CancelFunc, <-chan error) {
+	ctx, cancel := context.WithCancel(metadata.NewIncomingContext(context.Background(), metadata.Pairs("agent-id", agentID)))
+	stream := &fakeConversationStream{ctx: ctx, incoming: make(chan *pb.ConversationMessage)}
+	done := make(chan error, 1)
+	go func() { done <- server.OpenConversation(stream) }()
+	return cancel, done
+}
+
+func waitForStreamEnd(t *testing.T, done <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(time.Second):
+		t.Fatal("conversation stream did not end")
+		return nil
+	}
+}
+
+func TestOrchestrationServer_OpenConversation_RejectsSecondStreamForAgent(t *testing.T) {
+	server := NewOrchestrationServer(&slowAIBus{}, testHelpers.NewMockRegistry(), logging.NewNoOpLogger())
+
+	closeFirst, firstDone := openTestStream(server, "agent-1")
+	require.Eventually(t, func() bool { return server.openStreams("agent-1") == 1 }, time.Second, time.Millisecond)
+
+	closeSecond, secondDone := openTestStream(server, "agent-1")
+	defer closeSecond()
+	err := waitForStreamEnd(t, secondDone)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// The rejected stream did not disturb the first one's tracking
+	assert.Equal(t, 1, server.openStreams("agent-1"))
+
+	closeFirst()
+	require.NoError(t, waitForStreamEnd(t, firstDone))
+	assert.Equal(t, 0, server.openStreams("agent-1"))
+
+	// Once the first stream is gone the agent may reconnect
+	closeThird, thirdDone := openTestStream(server, "agent-1")
+	require.Eventually(t, func() bool { return server.openStreams("agent-1") == 1 }, time.Second, time.Millisecond)
+	closeThird()
+	require.NoError(t, waitForStreamEnd(t, thirdDone))
+}
+
+func TestOrchestrationServer_OpenConversation_TracksConcurrentStreamsSeparately(t *testing.T) {
+	server := NewOrchestrationServer(&slowAIBus{}, testHelpers.NewMockRegistry(), logging.NewNoOpLogger())
+	server.SetMaxStreamsPerAgent(2)
+
+	closeFirst, firstDone := openTestStream(server, "agent-1")
+	closeSecond, secondDone := openTestStream(server, "agent-1")
+	require.Eventually(t, func() bool { return server.openStreams("agent-1") == 2 }, time.Second, time.Millisecond)
+
+	// Closing the first stream cancels only its own context
+	closeFirst()
+	require.NoError(t, waitForStreamEnd(t, firstDone))
+	assert.Equal(t, 1, server.openStreams("agent-1"))
+	select {
+	case err := <-secondDone:
+		t.Fatalf("second stream ended with the first: %v", err)
+	default:
+	}
+
+	closeSecond()
+	require.NoError(t, waitForStreamEnd(t, secondDone))
+	server.streamsMutex.RLock()
+	_, tracked := server.activeStreams["agent-1"]
+	server.streamsMutex.RUnlock()
+	assert.False(t, tracked, "no cancel funcs are left behind for the agent")
+}
+
+func TestOrchestrationServer_UnregisterAgent_ClosesEveryStream(t *testing.T) {
+	registry := testHelpers.NewMockRegistry()
+	registry.On("UnregisterAgent", mock.Anything, "agent-1").Return(nil)
+	server := NewOrchestrationServer(&slowAIBus{}, registry, logging.NewNoOpLogger())
+	server.SetMaxStreamsPerAgent(0)
+
+	closeFirst, firstDone := openTestStream(server, "agent-1")
+	defer closeFirst()
+	closeSecond, secondDone := openTestStream(server, "agent-1")
+	defer closeSecond()
+	require.Eventually(t, func() bool { return server.openStreams("agent-1") == 2 }, time.Second, time.Millisecond)
+
+	_, err := server.UnregisterAgent(context.Background(), &pb.UnregisterAgentRequest{AgentId: "agent-1"})
+	require.NoError(t, err)
+
+	require.NoError(t, waitForStreamEnd(t, firstDone))
+	require.NoError(t, waitForStreamEnd(t, secondDone))
+	assert.Equal(t, 0, server.openStreams("agent-1"))
+}
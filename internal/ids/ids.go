@@ -0,0 +1,40 @@
+// Package ids builds the graph node IDs shared across modules, so every repository agrees on node identity
+package ids
+
+import (
+	"strconv"
+	"strings"
+)
+
+// stepSeparator joins a plan ID to the plan-scoped part of its step IDs
+const stepSeparator = "-step-"
+
+// Agent returns the node ID of an agent
+// Agent nodes are keyed by the raw agent ID so edges from other modules resolve without a prefix
+func Agent(agentID string) string {
+	return agentID
+}
+
+// Capability returns the node ID of one capability of an agent
+func Capability(agentID, name string) string {
+	return "capability:" + Agent(agentID) + ":" + name
+}
+
+// Step returns the node ID of the nth step of a plan, numbered from 1
+func Step(planID string, n int) string {
+	return PlanStep(planID, strconv.Itoa(n))
+}
+
+// PlanStep returns the node ID of a plan's step identified within the plan by key, for steps not numbered yet
+func PlanStep(planID, key string) string {
+	return planID + stepSeparator + key
+}
+
+// StepPlan returns the plan ID embedded in a step ID built by Step or PlanStep, or "" when it has none
+func StepPlan(stepID string) string {
+	i := strings.LastIndex(stepID, stepSeparator)
+	if i <= 0 || i+len(stepSeparator) == len(stepID) {
+		return ""
+	}
+	return stepID[:i]
+}
@@ -0,0 +1,35 @@
+package ids
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgent(t *testing.T) {
+	assert.Equal(t, "text-processor", Agent("text-processor"))
+}
+
+func TestCapability(t *testing.T) {
+	id := Capability("text-processor", "word-count")
+
+	assert.Equal(t, "capability:text-processor:word-count", id)
+	assert.NotEqual(t, id, Capability("text-processor-2", "word-count"))
+}
+
+func TestStep(t *testing.T) {
+	assert.Equal(t, "plan-1-step-1", Step("plan-1", 1))
+	assert.NotEqual(t, Step("plan-1", 1), Step("plan-1", 2))
+	assert.NotEqual(t, Step("plan-1", 1), Step("plan-2", 1))
+}
+
+func TestStepPlan(t *testing.T) {
+	planID := "3f1c2a9e-8d4b-4c7a-9b1e-2f6d5a4c3b21"
+
+	assert.Equal(t, planID, StepPlan(Step(planID, 12)))
+	assert.Equal(t, planID, StepPlan(PlanStep(planID, "7a0e9c1d-5b2f-4e8a-a1c3-9d8e7f6a5b4c")))
+
+	for _, stepID := range []string{"", "7a0e9c1d-5b2f-4e8a-a1c3-9d8e7f6a5b4c", "-step-1", "plan-1-step-"} {
+		assert.Empty(t, StepPlan(stepID), stepID)
+	}
+}
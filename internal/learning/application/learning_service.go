@@ -0,0 +1,178 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"neuromesh/internal/learning/domain"
+)
+
+const (
+	// DefaultSimilarityScanLimit is how many recent outcomes are compared word by word with a new request
+	DefaultSimilarityScanLimit = 200
+	// DefaultSimilarOutcomeLimit is the maximum number of outcomes GetSimilarOutcomes returns
+	DefaultSimilarOutcomeLimit = 10
+)
+
+// LearningService records how decisions turned out and recalls the outcomes of similar past requests
+type LearningService interface {
+	// RecordOutcome stores or updates the outcome of a decision
+	RecordOutcome(ctx context.Context, decisionID string, outcome domain.Outcome) error
+	// GetSimilarOutcomes returns outcomes of past requests resembling userInput, closest first
+	GetSimilarOutcomes(ctx context.Context, userInput string) ([]*domain.Outcome, error)
+	// RecordFeedback stores a user's rating of a decision and folds it into the decision's outcome
+	RecordFeedback(ctx context.Context, feedback *domain.Feedback) error
+}
+
+// LearningServiceImpl implements the LearningService interface
+type LearningServiceImpl struct {
+	repo domain.OutcomeRepository
+}
+
+// NewLearningService creates a new learning service implementation
+func NewLearningService(repo domain.OutcomeRepository) *LearningServiceImpl {
+	return &LearningServiceImpl{repo: repo}
+}
+
+// RecordOutcome stores the outcome of a decision, merging it into an outcome already recorded for it
+func (s *LearningServiceImpl) RecordOutcome(ctx context.Context, decisionID string, outcome domain.Outcome) error {
+	outcome.DecisionID = decisionID
+	if outcome.RecordedAt.IsZero() {
+		outcome.RecordedAt = time.Now().UTC()
+	}
+
+	existing, err := s.repo.GetByDecisionID(ctx, decisionID)
+	if err != nil {
+		return fmt.Errorf("failed to load outcome of decision %s: %w", decisionID, err)
+	}
+	if existing != nil {
+		existing.Merge(outcome)
+		outcome = *existing
+	}
+
+	if err := s.repo.Save(ctx, &outcome); err != nil {
+		return fmt.Errorf("failed to save outcome of decision %s: %w", decisionID, err)
+	}
+	return nil
+}
+
+// RecordFeedback stores a user's rating of a decision and updates the decision's outcome to match
+func (s *LearningServiceImpl) RecordFeedback(ctx context.Context, feedback *domain.Feedback) error {
+	if feedback.CreatedAt.IsZero() {
+		feedback.CreatedAt = time.Now().UTC()
+	}
+
+	if err := s.repo.SaveFeedback(ctx, feedback); err != nil {
+		return fmt.Errorf("failed to save feedback on decision %s: %w", feedback.DecisionID, err)
+	}
+	return s.RecordOutcome(ctx, feedback.DecisionID, feedback.Outcome())
+}
+
+// GetSimilarOutcomes finds recent outcomes whose requests share words with userInput, then widens the match
+// to every outcome stored with the same intent or category as those requests
+// Outcomes sharing more words come first; ties and intent or category matches are ordered newest first
+func (s *LearningServiceImpl) GetSimilarOutcomes(ctx context.Context, userInput string) ([]*domain.Outcome, error) {
+	words := keywords(userInput)
+	if len(words) == 0 {
+		return []*domain.Outcome{}, nil
+	}
+
+	recent, err := s.repo.FindRecent(ctx, DefaultSimilarityScanLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load recent outcomes: %w", err)
+	}
+
+	scores := make(map[string]int)
+	matches := make(map[string]*domain.Outcome)
+	intents := make(map[string]bool)
+	categories := make(map[string]bool)
+	for _, outcome := range recent {
+		score := sharedWords(words, keywords(outcome.UserInput))
+		if score == 0 {
+			continue
+		}
+		scores[outcome.DecisionID] = score
+		matches[outcome.DecisionID] = outcome
+		if outcome.Intent != "" {
+			intents[outcome.Intent] = true
+		}
+		if outcome.Category != "" {
+			categories[outcome.Category] = true
+		}
+	}
+
+	related := make([]*domain.Outcome, 0)
+	for intent := range intents {
+		outcomes, err := s.repo.FindByIntent(ctx, intent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load outcomes for intent %s: %w", intent, err)
+		}
+		related = append(related, outcomes...)
+	}
+	for category := range categories {
+		outcomes, err := s.repo.FindByCategory(ctx, category)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load outcomes for category %s: %w", category, err)
+		}
+		related = append(related, outcomes...)
+	}
+	for _, outcome := range related {
+		if _, seen := matches[outcome.DecisionID]; !seen {
+			matches[outcome.DecisionID] = outcome
+		}
+	}
+
+	similar := make([]*domain.Outcome, 0, len(matches))
+	for _, outcome := range matches {
+		similar = append(similar, outcome)
+	}
+	sort.Slice(similar, func(i, j int) bool {
+		if scores[similar[i].DecisionID] != scores[similar[j].DecisionID] {
+			return scores[similar[i].DecisionID] > scores[similar[j].DecisionID]
+		}
+		if !similar[i].RecordedAt.Equal(similar[j].RecordedAt) {
+			return similar[i].RecordedAt.After(similar[j].RecordedAt)
+		}
+		return similar[i].DecisionID < similar[j].DecisionID
+	})
+
+	if len(similar) > DefaultSimilarOutcomeLimit {
+		similar = similar[:DefaultSimilarOutcomeLimit]
+	}
+	return similar, nil
+}
+
+// stopWords are common words that say nothing about what a request is for
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "this": true, "that": true,
+	"please": true, "can": true, "you": true, "could": true, "would": true, "from": true,
+	"into": true, "our": true, "all": true, "some": true, "what": true,
+}
+
+// keywords returns the distinct lower-case words of text that are at least three letters and not stop words
+func keywords(text string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(word) >= 3 && !stopWords[word] {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+// sharedWords counts the words present in both sets
+func sharedWords(a, b map[string]bool) int {
+	count := 0
+	for word := range a {
+		if b[word] {
+			count++
+		}
+	}
+	return count
+}
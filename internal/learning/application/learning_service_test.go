@@ -0,0 +1,133 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuromesh/internal/learning/domain"
+	"neuromesh/internal/learning/infrastructure"
+	"neuromesh/testHelpers"
+)
+
+func newTestLearningService() (*LearningServiceImpl, domain.OutcomeRepository) {
+	repo := infrastructure.NewGraphOutcomeRepository(testHelpers.NewCleanMockGraph())
+	return NewLearningService(repo), repo
+}
+
+func TestLearningService_RecordOutcome(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestLearningService()
+
+	require.NoError(t, service.RecordOutcome(ctx, "decision-1", domain.Outcome{
+		UserInput:       "Count words in hello world",
+		Intent:          "word_count",
+		Category:        "text_processing",
+		DecisionType:    "EXECUTE",
+		ExecutionPlanID: "plan-1",
+		Status:          domain.OutcomeStatusSuccess,
+	}))
+
+	stored, err := repo.GetByDecisionID(ctx, "decision-1")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, "word_count", stored.Intent)
+	assert.Equal(t, domain.OutcomeStatusSuccess, stored.Status)
+	assert.False(t, stored.RecordedAt.IsZero())
+
+	t.Run("a later rating keeps what the orchestrator recorded", func(t *testing.T) {
+		require.NoError(t, service.RecordOutcome(ctx, "decision-1", domain.Outcome{
+			Status:     domain.OutcomeStatusFailure,
+			UserRating: 1,
+		}))
+
+		stored, err := repo.GetByDecisionID(ctx, "decision-1")
+		require.NoError(t, err)
+		assert.Equal(t, domain.OutcomeStatusFailure, stored.Status)
+		assert.Equal(t, 1, stored.UserRating)
+		assert.Equal(t, "word_count", stored.Intent)
+		assert.Equal(t, "text_processing", stored.Category)
+		assert.Equal(t, "plan-1", stored.ExecutionPlanID)
+	})
+
+	t.Run("rejects an out of range rating", func(t *testing.T) {
+		err := service.RecordOutcome(ctx, "decision-2", domain.Outcome{Status: domain.OutcomeStatusSuccess, UserRating: 6})
+		assert.Error(t, err)
+	})
+}
+
+func TestLearningService_GetSimilarOutcomes(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestLearningService()
+
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, outcome := range []domain.Outcome{
+		{DecisionID: "count-hello", UserInput: "Count words in hello world", Intent: "word_count", Category: "text_processing"},
+		{DecisionID: "tally-essay", UserInput: "How long is my essay?", Intent: "word_count", Category: "text_processing"},
+		{DecisionID: "uppercase", UserInput: "Make this text uppercase", Intent: "transform_text", Category: "text_processing"},
+		{DecisionID: "deploy-api", UserInput: "Deploy the billing api", Intent: "deploy", Category: "deployment"},
+	} {
+		outcome.Status = domain.OutcomeStatusSuccess
+		outcome.RecordedAt = base.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, service.RecordOutcome(ctx, outcome.DecisionID, outcome))
+	}
+
+	t.Run("finds requests sharing words and those stored with their intent or category", func(t *testing.T) {
+		similar, err := service.GetSimilarOutcomes(ctx, "Please count the words of this sentence")
+		require.NoError(t, err)
+
+		ids := make([]string, 0, len(similar))
+		for _, outcome := range similar {
+			ids = append(ids, outcome.DecisionID)
+		}
+		// The direct word match comes first, then the text_processing outcomes newest first
+		assert.Equal(t, []string{"count-hello", "uppercase", "tally-essay"}, ids)
+	})
+
+	t.Run("matches the deployment outcome by its own words only", func(t *testing.T) {
+		similar, err := service.GetSimilarOutcomes(ctx, "deploy payments")
+		require.NoError(t, err)
+		require.Len(t, similar, 1)
+		assert.Equal(t, "deploy-api", similar[0].DecisionID)
+	})
+
+	t.Run("returns nothing for unrelated requests", func(t *testing.T) {
+		similar, err := service.GetSimilarOutcomes(ctx, "What is the weather?")
+		require.NoError(t, err)
+		assert.Empty(t, similar)
+	})
+}
+
+func TestLearningService_RecordFeedback(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestLearningService()
+
+	require.NoError(t, service.RecordOutcome(ctx, "decision-1", domain.Outcome{
+		UserInput: "Count words in hello world",
+		Intent:    "word_count",
+		Status:    domain.OutcomeStatusSuccess,
+	}))
+
+	require.NoError(t, service.RecordFeedback(ctx, &domain.Feedback{
+		ID:         "feedback-1",
+		DecisionID: "decision-1",
+		Rating:     domain.FeedbackRatingDown,
+		Comment:    "It counted three words",
+	}))
+
+	feedback, err := repo.GetFeedbackByDecision(ctx, "decision-1")
+	require.NoError(t, err)
+	require.Len(t, feedback, 1)
+	assert.Equal(t, domain.FeedbackRatingDown, feedback[0].Rating)
+	assert.False(t, feedback[0].CreatedAt.IsZero())
+
+	outcome, err := repo.GetByDecisionID(ctx, "decision-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.OutcomeStatusFailure, outcome.Status)
+	assert.Equal(t, domain.MinUserRating, outcome.UserRating)
+	assert.Equal(t, "It counted three words", outcome.Error)
+	assert.Equal(t, "word_count", outcome.Intent)
+}
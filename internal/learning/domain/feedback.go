@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// FeedbackRating is a user's thumbs up or down on an AI response
+type FeedbackRating string
+
+const (
+	FeedbackRatingUp   FeedbackRating = "up"
+	FeedbackRatingDown FeedbackRating = "down"
+)
+
+// IsValid reports whether r is a known rating
+func (r FeedbackRating) IsValid() bool {
+	return r == FeedbackRatingUp || r == FeedbackRatingDown
+}
+
+// MaxFeedbackCommentLength bounds the optional comment users leave with their rating
+const MaxFeedbackCommentLength = 2000
+
+// Feedback is a user's rating of the response produced by one AI decision
+type Feedback struct {
+	ID             string         `json:"id"`
+	DecisionID     string         `json:"decision_id"`
+	ConversationID string         `json:"conversation_id"`
+	UserID         string         `json:"user_id,omitempty"`
+	Rating         FeedbackRating `json:"rating"`
+	Comment        string         `json:"comment,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+// Validate ensures the feedback can be persisted
+func (f *Feedback) Validate() error {
+	if f.ID == "" {
+		return OutcomeValidationError{Field: "id", Message: "feedback ID cannot be empty"}
+	}
+	if f.DecisionID == "" {
+		return OutcomeValidationError{Field: "decision_id", Message: "decision ID cannot be empty"}
+	}
+	if !f.Rating.IsValid() {
+		return OutcomeValidationError{Field: "rating", Message: fmt.Sprintf("rating must be %q or %q", FeedbackRatingUp, FeedbackRatingDown)}
+	}
+	if len(f.Comment) > MaxFeedbackCommentLength {
+		return OutcomeValidationError{Field: "comment", Message: fmt.Sprintf("comment exceeds %d bytes", MaxFeedbackCommentLength)}
+	}
+	return nil
+}
+
+// Outcome converts the feedback into the outcome update it implies for its decision
+// A thumbs up marks the decision successful with the top rating, a thumbs down as failed with the lowest
+func (f *Feedback) Outcome() Outcome {
+	if f.Rating == FeedbackRatingUp {
+		return Outcome{Status: OutcomeStatusSuccess, UserRating: MaxUserRating, RecordedAt: f.CreatedAt}
+	}
+	return Outcome{Status: OutcomeStatusFailure, UserRating: MinUserRating, Error: f.Comment, RecordedAt: f.CreatedAt}
+}
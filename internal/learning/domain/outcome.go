@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// OutcomeValidationError represents validation errors for decision outcomes
+type OutcomeValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e OutcomeValidationError) Error() string {
+	return fmt.Sprintf("outcome validation error - %s: %s", e.Field, e.Message)
+}
+
+// OutcomeStatus reports whether the handling of a request worked
+type OutcomeStatus string
+
+const (
+	OutcomeStatusSuccess OutcomeStatus = "success"
+	OutcomeStatusFailure OutcomeStatus = "failure"
+)
+
+// IsValid reports whether s is a known outcome status
+func (s OutcomeStatus) IsValid() bool {
+	return s == OutcomeStatusSuccess || s == OutcomeStatusFailure
+}
+
+// Ratings users can give a response; zero means the outcome is unrated
+const (
+	MinUserRating = 1
+	MaxUserRating = 5
+)
+
+// Outcome records how an AI decision about a user request turned out
+type Outcome struct {
+	DecisionID      string        `json:"decision_id"`
+	UserInput       string        `json:"user_input"`
+	Intent          string        `json:"intent,omitempty"`
+	Category        string        `json:"category,omitempty"`
+	DecisionType    string        `json:"decision_type,omitempty"` // CLARIFY, EXECUTE or REJECT
+	ExecutionPlanID string        `json:"execution_plan_id,omitempty"`
+	Status          OutcomeStatus `json:"status"`
+	UserRating      int           `json:"user_rating,omitempty"` // 1-5, zero when the user has not rated the response
+	Error           string        `json:"error,omitempty"`
+	RecordedAt      time.Time     `json:"recorded_at"`
+}
+
+// Validate ensures the outcome can be persisted
+func (o *Outcome) Validate() error {
+	if o.DecisionID == "" {
+		return OutcomeValidationError{Field: "decision_id", Message: "decision ID cannot be empty"}
+	}
+	if !o.Status.IsValid() {
+		return OutcomeValidationError{Field: "status", Message: fmt.Sprintf("invalid outcome status: %s", o.Status)}
+	}
+	if o.UserRating != 0 && (o.UserRating < MinUserRating || o.UserRating > MaxUserRating) {
+		return OutcomeValidationError{Field: "user_rating", Message: fmt.Sprintf("rating must be between %d and %d", MinUserRating, MaxUserRating)}
+	}
+	return nil
+}
+
+// Merge applies a later outcome for the same decision, keeping earlier details the update leaves empty
+// This lets user feedback rate an outcome the orchestrator recorded without erasing its intent or plan
+func (o *Outcome) Merge(update Outcome) {
+	o.Status = update.Status
+	o.Error = update.Error
+	if update.UserInput != "" {
+		o.UserInput = update.UserInput
+	}
+	if update.Intent != "" {
+		o.Intent = update.Intent
+	}
+	if update.Category != "" {
+		o.Category = update.Category
+	}
+	if update.DecisionType != "" {
+		o.DecisionType = update.DecisionType
+	}
+	if update.ExecutionPlanID != "" {
+		o.ExecutionPlanID = update.ExecutionPlanID
+	}
+	if update.UserRating != 0 {
+		o.UserRating = update.UserRating
+	}
+	if !update.RecordedAt.IsZero() {
+		o.RecordedAt = update.RecordedAt
+	}
+}
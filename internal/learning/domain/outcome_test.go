@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutcome_Validate(t *testing.T) {
+	assert.NoError(t, (&Outcome{DecisionID: "decision-1", Status: OutcomeStatusSuccess}).Validate())
+	assert.NoError(t, (&Outcome{DecisionID: "decision-1", Status: OutcomeStatusFailure, UserRating: MaxUserRating}).Validate())
+
+	assert.Error(t, (&Outcome{Status: OutcomeStatusSuccess}).Validate())
+	assert.Error(t, (&Outcome{DecisionID: "decision-1", Status: "unknown"}).Validate())
+	assert.Error(t, (&Outcome{DecisionID: "decision-1", Status: OutcomeStatusSuccess, UserRating: -1}).Validate())
+}
+
+func TestOutcome_Merge(t *testing.T) {
+	outcome := Outcome{
+		DecisionID: "decision-1",
+		UserInput:  "Count words",
+		Intent:     "word_count",
+		Status:     OutcomeStatusSuccess,
+		UserRating: 4,
+	}
+
+	outcome.Merge(Outcome{Status: OutcomeStatusFailure, Error: "wrong count"})
+
+	assert.Equal(t, OutcomeStatusFailure, outcome.Status)
+	assert.Equal(t, "wrong count", outcome.Error)
+	assert.Equal(t, "Count words", outcome.UserInput)
+	assert.Equal(t, "word_count", outcome.Intent)
+	assert.Equal(t, 4, outcome.UserRating)
+}
+
+func TestFeedback_Outcome(t *testing.T) {
+	up := (&Feedback{Rating: FeedbackRatingUp}).Outcome()
+	assert.Equal(t, OutcomeStatusSuccess, up.Status)
+	assert.Equal(t, MaxUserRating, up.UserRating)
+
+	down := (&Feedback{Rating: FeedbackRatingDown, Comment: "wrong answer"}).Outcome()
+	assert.Equal(t, OutcomeStatusFailure, down.Status)
+	assert.Equal(t, MinUserRating, down.UserRating)
+	assert.Equal(t, "wrong answer", down.Error)
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "context"
+
+// OutcomeRepository defines the interface for decision outcome and feedback persistence
+type OutcomeRepository interface {
+	EnsureSchema(ctx context.Context) error
+
+	// Save creates or replaces the outcome of its decision
+	Save(ctx context.Context, outcome *Outcome) error
+	// GetByDecisionID returns the outcome of a decision, or nil when none was recorded
+	GetByDecisionID(ctx context.Context, decisionID string) (*Outcome, error)
+	// FindRecent returns up to limit outcomes, most recently recorded first
+	FindRecent(ctx context.Context, limit int) ([]*Outcome, error)
+	// FindByIntent returns the outcomes recorded for requests with the given intent
+	FindByIntent(ctx context.Context, intent string) ([]*Outcome, error)
+	// FindByCategory returns the outcomes recorded for requests in the given category
+	FindByCategory(ctx context.Context, category string) ([]*Outcome, error)
+
+	// SaveFeedback stores a user's feedback linked to its decision
+	SaveFeedback(ctx context.Context, feedback *Feedback) error
+	// GetFeedbackByDecision returns the feedback given on a decision, oldest first
+	GetFeedbackByDecision(ctx context.Context, decisionID string) ([]*Feedback, error)
+}
@@ -0,0 +1,269 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"neuromesh/internal/graph"
+	"neuromesh/internal/learning/domain"
+)
+
+// Constants for graph node types and relationships
+const (
+	NodeTypeDecisionOutcome = "DecisionOutcome"
+	NodeTypeFeedback        = "Feedback"
+	NodeTypeAIDecision      = "AIDecision" // Persisted by the conversation repository
+
+	RelationshipOutcomeOf  = "OUTCOME_OF"
+	RelationshipFeedbackOn = "FEEDBACK_ON"
+
+	TimeFormat = "2006-01-02T15:04:05Z"
+)
+
+// GraphOutcomeRepository implements outcome repository using the graph backend
+type GraphOutcomeRepository struct {
+	graph graph.Graph
+}
+
+// NewGraphOutcomeRepository creates a new graph-based outcome repository
+func NewGraphOutcomeRepository(g graph.Graph) *GraphOutcomeRepository {
+	return &GraphOutcomeRepository{graph: g}
+}
+
+// EnsureSchema creates the outcome and feedback constraints and the indexes used by lookups
+func (r *GraphOutcomeRepository) EnsureSchema(ctx context.Context) error {
+	if err := r.graph.CreateUniqueConstraint(ctx, NodeTypeDecisionOutcome, "id"); err != nil {
+		return fmt.Errorf("failed to create outcome id constraint: %w", err)
+	}
+
+	for _, property := range []string{"intent", "category", "status", "recorded_at"} {
+		if err := r.graph.CreateIndex(ctx, NodeTypeDecisionOutcome, property); err != nil {
+			return fmt.Errorf("failed to create outcome %s index: %w", property, err)
+		}
+	}
+
+	if err := r.graph.CreateUniqueConstraint(ctx, NodeTypeFeedback, "id"); err != nil {
+		return fmt.Errorf("failed to create feedback id constraint: %w", err)
+	}
+
+	for _, property := range []string{"decision_id", "created_at"} {
+		if err := r.graph.CreateIndex(ctx, NodeTypeFeedback, property); err != nil {
+			return fmt.Errorf("failed to create feedback %s index: %w", property, err)
+		}
+	}
+
+	return nil
+}
+
+// Save creates the outcome node linked to its decision, or updates it when the decision already has one
+// The outcome node shares its decision's ID
+func (r *GraphOutcomeRepository) Save(ctx context.Context, outcome *domain.Outcome) error {
+	if err := outcome.Validate(); err != nil {
+		return err
+	}
+
+	existing, err := r.graph.GetNode(ctx, NodeTypeDecisionOutcome, outcome.DecisionID)
+	if err != nil && !errors.Is(err, graph.ErrNodeNotFound) {
+		return fmt.Errorf("failed to get outcome: %w", err)
+	}
+
+	properties := outcomeProperties(outcome)
+	if existing != nil {
+		if err := r.graph.UpdateNode(ctx, NodeTypeDecisionOutcome, outcome.DecisionID, properties); err != nil {
+			return fmt.Errorf("failed to update outcome node: %w", err)
+		}
+		return nil
+	}
+
+	if err := r.graph.AddNode(ctx, NodeTypeDecisionOutcome, outcome.DecisionID, properties); err != nil {
+		return fmt.Errorf("failed to create outcome node: %w", err)
+	}
+	if err := r.graph.AddEdge(ctx, NodeTypeDecisionOutcome, outcome.DecisionID, NodeTypeAIDecision, outcome.DecisionID, RelationshipOutcomeOf, nil); err != nil {
+		return fmt.Errorf("failed to link outcome to decision: %w", err)
+	}
+	return nil
+}
+
+// GetByDecisionID returns the outcome of a decision, or nil when none was recorded
+func (r *GraphOutcomeRepository) GetByDecisionID(ctx context.Context, decisionID string) (*domain.Outcome, error) {
+	props, err := r.graph.GetNode(ctx, NodeTypeDecisionOutcome, decisionID)
+	if err != nil && !errors.Is(err, graph.ErrNodeNotFound) {
+		return nil, fmt.Errorf("failed to get outcome: %w", err)
+	}
+	if props == nil {
+		return nil, nil
+	}
+	return mapToOutcome(props)
+}
+
+// FindRecent returns up to limit outcomes, most recently recorded first
+func (r *GraphOutcomeRepository) FindRecent(ctx context.Context, limit int) ([]*domain.Outcome, error) {
+	nodes, err := r.graph.QueryNodesWithOptions(ctx, NodeTypeDecisionOutcome, map[string]interface{}{}, graph.QueryOptions{
+		OrderBy:    "recorded_at",
+		ThenBy:     "id",
+		Descending: true,
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent outcomes: %w", err)
+	}
+	return mapToOutcomes(nodes)
+}
+
+// FindByIntent returns the outcomes recorded for requests with the given intent
+func (r *GraphOutcomeRepository) FindByIntent(ctx context.Context, intent string) ([]*domain.Outcome, error) {
+	return r.findBy(ctx, "intent", intent)
+}
+
+// FindByCategory returns the outcomes recorded for requests in the given category
+func (r *GraphOutcomeRepository) FindByCategory(ctx context.Context, category string) ([]*domain.Outcome, error) {
+	return r.findBy(ctx, "category", category)
+}
+
+// findBy returns the outcomes whose property equals value, most recently recorded first
+func (r *GraphOutcomeRepository) findBy(ctx context.Context, property, value string) ([]*domain.Outcome, error) {
+	nodes, err := r.graph.QueryNodesWithOptions(ctx, NodeTypeDecisionOutcome, map[string]interface{}{
+		property: value,
+	}, graph.QueryOptions{OrderBy: "recorded_at", ThenBy: "id", Descending: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outcomes by %s: %w", property, err)
+	}
+	return mapToOutcomes(nodes)
+}
+
+// SaveFeedback stores a user's feedback as a node linked to its decision
+func (r *GraphOutcomeRepository) SaveFeedback(ctx context.Context, feedback *domain.Feedback) error {
+	if err := feedback.Validate(); err != nil {
+		return err
+	}
+
+	properties := map[string]interface{}{
+		"decision_id":     feedback.DecisionID,
+		"conversation_id": feedback.ConversationID,
+		"user_id":         feedback.UserID,
+		"rating":          string(feedback.Rating),
+		"comment":         feedback.Comment,
+		"created_at":      feedback.CreatedAt.UTC().Format(TimeFormat),
+	}
+	if err := r.graph.AddNode(ctx, NodeTypeFeedback, feedback.ID, properties); err != nil {
+		return fmt.Errorf("failed to create feedback node: %w", err)
+	}
+	if err := r.graph.AddEdge(ctx, NodeTypeFeedback, feedback.ID, NodeTypeAIDecision, feedback.DecisionID, RelationshipFeedbackOn, nil); err != nil {
+		return fmt.Errorf("failed to link feedback to decision: %w", err)
+	}
+	return nil
+}
+
+// GetFeedbackByDecision returns the feedback given on a decision, oldest first
+func (r *GraphOutcomeRepository) GetFeedbackByDecision(ctx context.Context, decisionID string) ([]*domain.Feedback, error) {
+	nodes, err := r.graph.QueryNodesWithOptions(ctx, NodeTypeFeedback, map[string]interface{}{
+		"decision_id": decisionID,
+	}, graph.QueryOptions{OrderBy: "created_at", ThenBy: "id"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feedback: %w", err)
+	}
+
+	feedback := make([]*domain.Feedback, 0, len(nodes))
+	for _, props := range nodes {
+		item, err := mapToFeedback(props)
+		if err != nil {
+			return nil, err
+		}
+		feedback = append(feedback, item)
+	}
+	return feedback, nil
+}
+
+// outcomeProperties converts an outcome into graph node properties
+func outcomeProperties(outcome *domain.Outcome) map[string]interface{} {
+	return map[string]interface{}{
+		"decision_id":       outcome.DecisionID,
+		"user_input":        outcome.UserInput,
+		"intent":            outcome.Intent,
+		"category":          outcome.Category,
+		"decision_type":     outcome.DecisionType,
+		"execution_plan_id": outcome.ExecutionPlanID,
+		"status":            string(outcome.Status),
+		"user_rating":       outcome.UserRating,
+		"error":             outcome.Error,
+		"recorded_at":       outcome.RecordedAt.UTC().Format(TimeFormat),
+	}
+}
+
+// mapToOutcomes converts outcome nodes into domain outcomes
+func mapToOutcomes(nodes []map[string]interface{}) ([]*domain.Outcome, error) {
+	outcomes := make([]*domain.Outcome, 0, len(nodes))
+	for _, props := range nodes {
+		outcome, err := mapToOutcome(props)
+		if err != nil {
+			return nil, err
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes, nil
+}
+
+// mapToOutcome converts outcome node properties into a domain outcome
+func mapToOutcome(props map[string]interface{}) (*domain.Outcome, error) {
+	decisionID, ok := props["decision_id"].(string)
+	if !ok {
+		return nil, fmt.Errorf("missing or invalid decision_id")
+	}
+
+	outcome := &domain.Outcome{DecisionID: decisionID}
+	outcome.UserInput, _ = props["user_input"].(string)
+	outcome.Intent, _ = props["intent"].(string)
+	outcome.Category, _ = props["category"].(string)
+	outcome.DecisionType, _ = props["decision_type"].(string)
+	outcome.ExecutionPlanID, _ = props["execution_plan_id"].(string)
+	outcome.Error, _ = props["error"].(string)
+	if status, ok := props["status"].(string); ok {
+		outcome.Status = domain.OutcomeStatus(status)
+	}
+
+	switch rating := props["user_rating"].(type) {
+	case int:
+		outcome.UserRating = rating
+	case int64:
+		outcome.UserRating = int(rating)
+	}
+
+	if recordedAt, ok := props["recorded_at"].(string); ok {
+		parsed, err := time.Parse(TimeFormat, recordedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recorded_at: %w", err)
+		}
+		outcome.RecordedAt = parsed
+	}
+
+	return outcome, nil
+}
+
+// mapToFeedback converts feedback node properties into domain feedback
+func mapToFeedback(props map[string]interface{}) (*domain.Feedback, error) {
+	id, ok := props["id"].(string)
+	if !ok {
+		return nil, fmt.Errorf("missing or invalid feedback id")
+	}
+
+	feedback := &domain.Feedback{ID: id}
+	feedback.DecisionID, _ = props["decision_id"].(string)
+	feedback.ConversationID, _ = props["conversation_id"].(string)
+	feedback.UserID, _ = props["user_id"].(string)
+	feedback.Comment, _ = props["comment"].(string)
+	if rating, ok := props["rating"].(string); ok {
+		feedback.Rating = domain.FeedbackRating(rating)
+	}
+
+	if createdAt, ok := props["created_at"].(string); ok {
+		parsed, err := time.Parse(TimeFormat, createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid created_at: %w", err)
+		}
+		feedback.CreatedAt = parsed
+	}
+
+	return feedback, nil
+}
@@ -0,0 +1,126 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuromesh/internal/learning/domain"
+	"neuromesh/testHelpers"
+)
+
+func TestGraphOutcomeRepository_SaveAndGet(t *testing.T) {
+	ctx := context.Background()
+	g := testHelpers.NewCleanMockGraph()
+	repo := NewGraphOutcomeRepository(g)
+	require.NoError(t, repo.EnsureSchema(ctx))
+	require.NoError(t, g.AddNode(ctx, NodeTypeAIDecision, "decision-1", map[string]interface{}{"decision_type": "EXECUTE"}))
+
+	recordedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	outcome := &domain.Outcome{
+		DecisionID:      "decision-1",
+		UserInput:       "Count words in hello world",
+		Intent:          "word_count",
+		Category:        "text_processing",
+		DecisionType:    "EXECUTE",
+		ExecutionPlanID: "plan-1",
+		Status:          domain.OutcomeStatusSuccess,
+		RecordedAt:      recordedAt,
+	}
+	require.NoError(t, repo.Save(ctx, outcome))
+
+	stored, err := repo.GetByDecisionID(ctx, "decision-1")
+	require.NoError(t, err)
+	assert.Equal(t, outcome, stored)
+
+	edges, err := g.GetEdgesWithTargets(ctx, NodeTypeDecisionOutcome, "decision-1")
+	require.NoError(t, err)
+	require.Len(t, edges, 1)
+	assert.Equal(t, RelationshipOutcomeOf, edges[0]["type"])
+
+	t.Run("saving again updates the outcome without a second link", func(t *testing.T) {
+		outcome.UserRating = 5
+		require.NoError(t, repo.Save(ctx, outcome))
+
+		stored, err := repo.GetByDecisionID(ctx, "decision-1")
+		require.NoError(t, err)
+		assert.Equal(t, 5, stored.UserRating)
+
+		edges, err := g.GetEdgesWithTargets(ctx, NodeTypeDecisionOutcome, "decision-1")
+		require.NoError(t, err)
+		assert.Len(t, edges, 1)
+	})
+
+	t.Run("returns nil for a decision without outcome", func(t *testing.T) {
+		stored, err := repo.GetByDecisionID(ctx, "decision-unknown")
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+	})
+
+	t.Run("rejects invalid outcomes", func(t *testing.T) {
+		assert.Error(t, repo.Save(ctx, &domain.Outcome{DecisionID: "decision-2", Status: "maybe"}))
+	})
+}
+
+func TestGraphOutcomeRepository_Find(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGraphOutcomeRepository(testHelpers.NewCleanMockGraph())
+
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, outcome := range []domain.Outcome{
+		{DecisionID: "decision-1", Intent: "word_count", Category: "text_processing"},
+		{DecisionID: "decision-2", Intent: "deploy", Category: "deployment"},
+		{DecisionID: "decision-3", Intent: "word_count", Category: "text_processing"},
+	} {
+		outcome.Status = domain.OutcomeStatusSuccess
+		outcome.RecordedAt = base.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, repo.Save(ctx, &outcome))
+	}
+
+	recent, err := repo.FindRecent(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, recent, 2)
+	assert.Equal(t, "decision-3", recent[0].DecisionID)
+	assert.Equal(t, "decision-2", recent[1].DecisionID)
+
+	byIntent, err := repo.FindByIntent(ctx, "word_count")
+	require.NoError(t, err)
+	require.Len(t, byIntent, 2)
+	assert.Equal(t, "decision-3", byIntent[0].DecisionID)
+
+	byCategory, err := repo.FindByCategory(ctx, "deployment")
+	require.NoError(t, err)
+	require.Len(t, byCategory, 1)
+	assert.Equal(t, "decision-2", byCategory[0].DecisionID)
+}
+
+func TestGraphOutcomeRepository_Feedback(t *testing.T) {
+	ctx := context.Background()
+	g := testHelpers.NewCleanMockGraph()
+	repo := NewGraphOutcomeRepository(g)
+	require.NoError(t, g.AddNode(ctx, NodeTypeAIDecision, "decision-1", map[string]interface{}{"decision_type": "EXECUTE"}))
+
+	given := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	later := &domain.Feedback{ID: "feedback-2", DecisionID: "decision-1", ConversationID: "conv-1", UserID: "user-1", Rating: domain.FeedbackRatingDown, Comment: "Wrong count", CreatedAt: given.Add(time.Minute)}
+	first := &domain.Feedback{ID: "feedback-1", DecisionID: "decision-1", ConversationID: "conv-1", UserID: "user-1", Rating: domain.FeedbackRatingUp, CreatedAt: given}
+	require.NoError(t, repo.SaveFeedback(ctx, later))
+	require.NoError(t, repo.SaveFeedback(ctx, first))
+
+	stored, err := repo.GetFeedbackByDecision(ctx, "decision-1")
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.Feedback{first, later}, stored)
+
+	edges, err := g.GetEdgesWithTargets(ctx, NodeTypeFeedback, "feedback-2")
+	require.NoError(t, err)
+	require.Len(t, edges, 1)
+	assert.Equal(t, RelationshipFeedbackOn, edges[0]["type"])
+	assert.Equal(t, "decision-1", edges[0]["target_id"])
+
+	t.Run("rejects an unknown rating", func(t *testing.T) {
+		err := repo.SaveFeedback(ctx, &domain.Feedback{ID: "feedback-3", DecisionID: "decision-1", Rating: "meh"})
+		assert.Error(t, err)
+	})
+}
@@ -15,6 +15,9 @@ type Logger interface {
 
 	// Warn logs a warning message with optional fields
 	Warn(msg string, fields ...interface{})
+
+	// With returns a child logger that adds the given key/value pairs to every entry
+	With(fields ...interface{}) Logger
 }
 
 // NoOpLogger implements Logger interface with no-op operations (for testing)
@@ -24,6 +27,7 @@ func (n *NoOpLogger) Info(msg string, fields ...interface{})             {}
 func (n *NoOpLogger) Error(msg string, err error, fields ...interface{}) {}
 func (n *NoOpLogger) Debug(msg string, fields ...interface{})            {}
 func (n *NoOpLogger) Warn(msg string, fields ...interface{})             {}
+func (n *NoOpLogger) With(fields ...interface{}) Logger                  { return n }
 
 // NewNoOpLogger creates a new no-op logger (useful for testing)
 func NewNoOpLogger() Logger {
@@ -90,6 +94,14 @@ func (l *logger) Warn(msg string, fields ...interface{}) {
 	l.sugar.Warnw(msg, fields...)
 }
 
+func (l *logger) With(fields ...interface{}) Logger {
+	child := l.sugar.With(fields...)
+	return &logger{
+		logger: child.Desugar(),
+		sugar:  child,
+	}
+}
+
 // Close flushes any buffered log entries
 func (l *logger) Close() error {
 	return l.logger.Sync()
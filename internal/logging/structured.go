@@ -1,16 +1,34 @@
 package logging
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
+	"os"
+	"sync"
 	"time"
 )
 
 // StructuredLogger implements Logger with structured output
 type StructuredLogger struct {
-	level LogLevel
+	level  LogLevel
+	format Format
+	mu     *sync.Mutex
+	output io.Writer
+	fields []interface{}
 }
 
+// Format selects how StructuredLogger renders log entries
+type Format int
+
+const (
+	// FormatText renders human-readable lines
+	FormatText Format = iota
+	// FormatJSON renders one JSON object per line
+	FormatJSON
+)
+
 // LogLevel represents logging levels
 type LogLevel int
 
@@ -24,7 +42,36 @@ const (
 // NewStructuredLogger creates a new structured logger
 func NewStructuredLogger(level LogLevel) Logger {
 	return &StructuredLogger{
-		level: level,
+		level:  level,
+		format: FormatText,
+		mu:     &sync.Mutex{},
+	}
+}
+
+// NewJSONLogger creates a structured logger that writes JSON lines to stdout
+func NewJSONLogger(level LogLevel) Logger {
+	return NewStructuredLoggerWithWriter(level, FormatJSON, os.Stdout)
+}
+
+// NewStructuredLoggerWithWriter creates a structured logger with the given format and output
+func NewStructuredLoggerWithWriter(level LogLevel, format Format, output io.Writer) Logger {
+	return &StructuredLogger{
+		level:  level,
+		format: format,
+		mu:     &sync.Mutex{},
+		output: output,
+	}
+}
+
+// ParseFormat converts a format name ("text" or "json") to a Format
+func ParseFormat(name string) (Format, error) {
+	switch name {
+	case "", "text":
+		return FormatText, nil
+	case "json":
+		return FormatJSON, nil
+	default:
+		return FormatText, fmt.Errorf("unknown log format: %s", name)
 	}
 }
 
@@ -62,7 +109,31 @@ func (s *StructuredLogger) Warn(msg string, fields ...interface{}) {
 	}
 }
 
+// With returns a child logger that carries the parent's fields plus the given ones
+func (s *StructuredLogger) With(fields ...interface{}) Logger {
+	combined := make([]interface{}, 0, len(s.fields)+len(fields))
+	combined = append(combined, s.fields...)
+	combined = append(combined, fields...)
+
+	return &StructuredLogger{
+		level:  s.level,
+		format: s.format,
+		mu:     s.mu,
+		output: s.output,
+		fields: combined,
+	}
+}
+
 func (s *StructuredLogger) logWithFields(level, msg string, fields ...interface{}) {
+	if len(s.fields) > 0 {
+		fields = append(append([]interface{}{}, s.fields...), fields...)
+	}
+
+	if s.format == FormatJSON {
+		s.logJSON(level, msg, fields...)
+		return
+	}
+
 	timestamp := time.Now().Format(time.RFC3339)
 	logMsg := fmt.Sprintf("[%s] %s %s", timestamp, level, msg)
 
@@ -76,5 +147,55 @@ func (s *StructuredLogger) logWithFields(level, msg string, fields ...interface{
 		}
 	}
 
+	if s.output != nil {
+		s.write(logMsg + "\n")
+		return
+	}
 	log.Println(logMsg)
 }
+
+// logJSON writes the entry as a single JSON object; fields become top-level keys
+func (s *StructuredLogger) logJSON(level, msg string, fields ...interface{}) {
+	entry := map[string]interface{}{
+		"timestamp": time.Now().Format(time.RFC3339Nano),
+		"level":     level,
+		"msg":       msg,
+	}
+	for i := 0; i+1 < len(fields); i += 2 {
+		entry[fmt.Sprint(fields[i])] = jsonValue(fields[i+1])
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		// Fall back to string values when a field cannot be encoded
+		for key, value := range entry {
+			entry[key] = fmt.Sprint(value)
+		}
+		line, _ = json.Marshal(entry)
+	}
+
+	s.write(string(line) + "\n")
+}
+
+// jsonValue converts values that encode poorly as JSON into strings
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return value
+	}
+}
+
+func (s *StructuredLogger) write(line string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	output := s.output
+	if output == nil {
+		output = os.Stdout
+	}
+	io.WriteString(output, line)
+}
@@ -0,0 +1,132 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), "line is not valid JSON: %s", line)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestStructuredLogger_JSONFormat(t *testing.T) {
+	t.Run("emits one JSON object per line with fields", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := NewStructuredLoggerWithWriter(LevelInfo, FormatJSON, &buf)
+
+		logger.Info("Agent registered", "agent_id", "text-processor", "capabilities", 2)
+		logger.Warn("Agent heartbeat late", "agent_id", "text-processor")
+
+		entries := decodeLines(t, &buf)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "INFO", entries[0]["level"])
+		assert.Equal(t, "Agent registered", entries[0]["msg"])
+		assert.Equal(t, "text-processor", entries[0]["agent_id"])
+		assert.Equal(t, float64(2), entries[0]["capabilities"])
+		assert.NotEmpty(t, entries[0]["timestamp"])
+		assert.Equal(t, "WARN", entries[1]["level"])
+	})
+
+	t.Run("includes error under error key", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := NewStructuredLoggerWithWriter(LevelInfo, FormatJSON, &buf)
+
+		logger.Error("Failed to send message", errors.New("connection closed"), "agent_id", "a1")
+
+		entries := decodeLines(t, &buf)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "ERROR", entries[0]["level"])
+		assert.Equal(t, "connection closed", entries[0]["error"])
+		assert.Equal(t, "a1", entries[0]["agent_id"])
+	})
+
+	t.Run("filters entries below the configured level", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := NewStructuredLoggerWithWriter(LevelWarn, FormatJSON, &buf)
+
+		logger.Debug("debug")
+		logger.Info("info")
+		logger.Warn("warn")
+		logger.Error("error", nil)
+
+		entries := decodeLines(t, &buf)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "WARN", entries[0]["level"])
+		assert.Equal(t, "ERROR", entries[1]["level"])
+		assert.NotContains(t, entries[1], "error")
+	})
+
+	t.Run("encodes unsupported values as strings", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := NewStructuredLoggerWithWriter(LevelDebug, FormatJSON, &buf)
+
+		logger.Debug("odd value", "callback", func() {})
+
+		entries := decodeLines(t, &buf)
+		require.Len(t, entries, 1)
+		assert.IsType(t, "", entries[0]["callback"])
+	})
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("json")
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSON, format)
+
+	format, err = ParseFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, FormatText, format)
+
+	_, err = ParseFormat("xml")
+	assert.Error(t, err)
+}
+
+func TestStructuredLogger_With(t *testing.T) {
+	var buf bytes.Buffer
+	root := NewStructuredLoggerWithWriter(LevelDebug, FormatJSON, &buf)
+
+	conversation := root.With("correlation_id", "conv-1")
+	step := conversation.With("step_id", "step-2")
+
+	conversation.Info("first")
+	conversation.Debug("second", "extra", true)
+	step.Error("third", errors.New("boom"))
+	root.Info("unscoped")
+
+	entries := decodeLines(t, &buf)
+	require.Len(t, entries, 4)
+	for _, entry := range entries[:3] {
+		assert.Equal(t, "conv-1", entry["correlation_id"], "entry %v", entry["msg"])
+	}
+	assert.Equal(t, true, entries[1]["extra"])
+	assert.Equal(t, "step-2", entries[2]["step_id"])
+	assert.Equal(t, "boom", entries[2]["error"])
+	assert.NotContains(t, entries[0], "step_id")
+	assert.NotContains(t, entries[3], "correlation_id")
+}
+
+func TestStructuredLogger_WithTextFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStructuredLoggerWithWriter(LevelInfo, FormatText, &buf).With("correlation_id", "conv-1")
+
+	logger.Info("hello", "agent_id", "a1")
+
+	assert.Contains(t, buf.String(), "correlation_id=conv-1")
+	assert.Contains(t, buf.String(), "agent_id=a1")
+}
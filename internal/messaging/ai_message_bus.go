@@ -3,6 +3,7 @@ package messaging
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"neuromesh/internal/graph"
@@ -29,13 +30,56 @@ type AIMessageBus interface {
 	// Subscribe to conversations by participant
 	Subscribe(ctx context.Context, participantID string) (<-chan *Message, error)
 
-	// Get conversation history from graph
+	// Get conversation history from the message store
 	GetConversationHistory(ctx context.Context, correlationID string) ([]*Message, error)
 
 	// Prepare agent queue for message reception (without starting consumption)
 	PrepareAgentQueue(ctx context.Context, agentID string) error
+
+	// Plan lifecycle events, delivered to PlanEventsParticipant subscribers
+	PublishAgentCompletedEvent(ctx context.Context, event *AgentCompletedEvent) error
+	PublishPlanCompletedEvent(ctx context.Context, event *PlanCompletedEvent) error
+}
+
+// PlanEventsParticipant is the participant that receives plan lifecycle events
+const PlanEventsParticipant = "plan-events"
+
+// NodeTypeRoutedMessage is the graph node type holding every message routed by the AI message bus
+const NodeTypeRoutedMessage = "RoutedMessage"
+
+// historyTimeFormat is fixed-width so stored timestamps sort chronologically as strings
+const historyTimeFormat = "2006-01-02T15:04:05.000000000Z"
+
+// AgentCompletedEvent announces that an agent completed one step of an execution plan
+type AgentCompletedEvent struct {
+	PlanID        string `json:"plan_id"`
+	StepID        string `json:"step_id"`
+	AgentID       string `json:"agent_id"`
+	CorrelationID string `json:"correlation_id"`
+}
+
+// PlanCompletedEvent announces that every step of an execution plan has completed
+type PlanCompletedEvent struct {
+	PlanID        string `json:"plan_id"`
+	UserID        string `json:"user_id"`
+	CorrelationID string `json:"correlation_id"`
 }
 
+// PlanCompletedEventFromMessage extracts a plan-completed event from a bus message
+func PlanCompletedEventFromMessage(msg *Message) (*PlanCompletedEvent, bool) {
+	if msg == nil || msg.MessageType != MessageTypePlanCompleted {
+		return nil, false
+	}
+
+	event := &PlanCompletedEvent{CorrelationID: msg.CorrelationID}
+	event.PlanID, _ = msg.Metadata["plan_id"].(string)
+	event.UserID, _ = msg.Metadata["user_id"].(string)
+	return event, event.PlanID != ""
+}
+
+// ParametersContextKey is the message context key carrying an instruction's structured parameters to the agent
+const ParametersContextKey = "parameters"
+
 // AIToAgentMessage represents AI instructions to an agent
 type AIToAgentMessage struct {
 	AgentID       string                 `json:"agent_id"`
@@ -43,9 +87,24 @@ type AIToAgentMessage struct {
 	Intent        string                 `json:"intent"`
 	CorrelationID string                 `json:"correlation_id"`
 	Context       map[string]interface{} `json:"context"`
+	Parameters    map[string]interface{} `json:"parameters,omitempty"` // Structured inputs, e.g. the execution step's inputs
 	Timeout       time.Duration          `json:"timeout,omitempty"`
 }
 
+// metadata returns the message context with the structured parameters under ParametersContextKey
+func (m *AIToAgentMessage) metadata() map[string]interface{} {
+	if len(m.Parameters) == 0 {
+		return m.Context
+	}
+
+	metadata := make(map[string]interface{}, len(m.Context)+1)
+	for key, value := range m.Context {
+		metadata[key] = value
+	}
+	metadata[ParametersContextKey] = m.Parameters
+	return metadata
+}
+
 // AgentToAIMessage represents agent communication to AI
 type AgentToAIMessage struct {
 	AgentID       string                 `json:"agent_id"`
@@ -56,6 +115,18 @@ type AgentToAIMessage struct {
 	NeedsHelp     bool                   `json:"needs_help"`
 }
 
+// Agents mark incremental updates by setting the reply context's status key to ReplyStatusInProgress
+const (
+	ReplyStatusContextKey = "status"
+	ReplyStatusInProgress = "IN_PROGRESS"
+)
+
+// IsInProgress reports whether the reply is an incremental update that the agent's final reply will follow
+func (m *AgentToAIMessage) IsInProgress() bool {
+	status, _ := m.Context[ReplyStatusContextKey].(string)
+	return m.MessageType == MessageTypeAgentToAI && strings.EqualFold(status, ReplyStatusInProgress)
+}
+
 // AgentToAgentMessage represents agent-to-agent communication (AI mediated)
 type AgentToAgentMessage struct {
 	FromAgentID   string                 `json:"from_agent_id"`
@@ -79,19 +150,33 @@ type UserToAIMessage struct {
 // AIMessageBusImpl implements the AI message bus
 type AIMessageBusImpl struct {
 	messageBus MessageBus
-	graph      graph.Graph
+	store      MessageStore
 	logger     logging.Logger
 }
 
-// NewAIMessageBus creates a new AI message bus
-func NewAIMessageBus(messageBus MessageBus, graph graph.Graph, logger logging.Logger) AIMessageBus {
+// NewAIMessageBus creates a new AI message bus that stores routed messages in the graph
+func NewAIMessageBus(messageBus MessageBus, g graph.Graph, logger logging.Logger) AIMessageBus {
+	var store MessageStore
+	if g != nil {
+		store = NewGraphMessageStore(g)
+	}
+	return NewAIMessageBusWithStore(messageBus, store, logger)
+}
+
+// NewAIMessageBusWithStore creates a new AI message bus that stores routed messages in the given store
+func NewAIMessageBusWithStore(messageBus MessageBus, store MessageStore, logger logging.Logger) *AIMessageBusImpl {
 	return &AIMessageBusImpl{
 		messageBus: messageBus,
-		graph:      graph,
+		store:      store,
 		logger:     logger,
 	}
 }
 
+// SetMessageStore replaces the store routed messages are persisted in
+func (bus *AIMessageBusImpl) SetMessageStore(store MessageStore) {
+	bus.store = store
+}
+
 // SendToAgent sends AI instructions to an agent
 func (bus *AIMessageBusImpl) SendToAgent(ctx context.Context, msg *AIToAgentMessage) error {
 	// Validate CorrelationID is present
@@ -105,18 +190,22 @@ func (bus *AIMessageBusImpl) SendToAgent(ctx context.Context, msg *AIToAgentMess
 		"correlation_id", msg.CorrelationID,
 		"intent", msg.Intent,
 		"content_length", len(msg.Content),
-		"has_context", len(msg.Context) > 0)
+		"has_context", len(msg.Context) > 0,
+		"has_parameters", len(msg.Parameters) > 0)
 
 	// Convert to generic message
 	message := &Message{
-		ID:            uuid.New().String(),
-		CorrelationID: msg.CorrelationID,
-		FromID:        "ai-orchestrator",
-		ToID:          msg.AgentID,
-		Content:       msg.Content,
-		MessageType:   MessageTypeAIToAgent,
-		Metadata:      msg.Context,
-		Timestamp:     time.Now(),
+		ID:             uuid.New().String(),
+		CorrelationID:  msg.CorrelationID,
+		FromID:         "ai-orchestrator",
+		ConversationID: conversationIDFrom(msg.Context),
+		ToID:           msg.AgentID,
+		Content:        msg.Content,
+		MessageType:    MessageTypeAIToAgent,
+		Metadata:       msg.metadata(),
+		Timestamp:      time.Now(),
+		// The orchestrator stops waiting after the instruction's timeout, so the message need not outlive it
+		TTL: msg.Timeout,
 	}
 
 	bus.logger.Debug("📦 Message details",
@@ -125,9 +214,9 @@ func (bus *AIMessageBusImpl) SendToAgent(ctx context.Context, msg *AIToAgentMess
 		"to", message.ToID,
 		"content", message.Content)
 
-	// Store in graph for conversation history
-	if err := bus.storeMessageInGraph(ctx, message); err != nil {
-		bus.logger.Error("❌ Failed to store AI-to-agent message in graph", err)
+	// Store for conversation history
+	if err := bus.storeMessage(ctx, message); err != nil {
+		bus.logger.Error("❌ Failed to store AI-to-agent message", err)
 	}
 
 	// Send via message bus
@@ -156,19 +245,20 @@ func (bus *AIMessageBusImpl) SendToAI(ctx context.Context, msg *AgentToAIMessage
 
 	// Convert to generic message
 	message := &Message{
-		ID:            uuid.New().String(),
-		CorrelationID: msg.CorrelationID,
-		FromID:        msg.AgentID,
-		ToID:          "ai-orchestrator",
-		Content:       msg.Content,
-		MessageType:   msg.MessageType,
-		Metadata:      msg.Context,
-		Timestamp:     time.Now(),
+		ID:             uuid.New().String(),
+		CorrelationID:  msg.CorrelationID,
+		ConversationID: conversationIDFrom(msg.Context),
+		FromID:         msg.AgentID,
+		ToID:           "ai-orchestrator",
+		Content:        msg.Content,
+		MessageType:    msg.MessageType,
+		Metadata:       msg.Context,
+		Timestamp:      time.Now(),
 	}
 
-	// Store in graph for AI context and learning
-	if err := bus.storeMessageInGraph(ctx, message); err != nil {
-		bus.logger.Error("Failed to store agent-to-AI message in graph", err)
+	// Store for AI context and learning
+	if err := bus.storeMessage(ctx, message); err != nil {
+		bus.logger.Error("Failed to store agent-to-AI message", err)
 	}
 
 	// Send to AI orchestrator
@@ -190,22 +280,31 @@ func (bus *AIMessageBusImpl) SendBetweenAgents(ctx context.Context, msg *AgentTo
 	if msg.CorrelationID == "" {
 		return fmt.Errorf("correlation ID is required for all messages")
 	}
+	if msg.FromAgentID == "" || msg.ToAgentID == "" {
+		return fmt.Errorf("sender and target agent IDs are required for agent-to-agent messages")
+	}
+
+	// Make sure the target agent's queue exists so the hand-off is kept until it subscribes
+	if err := bus.messageBus.PrepareAgentQueue(ctx, msg.ToAgentID); err != nil {
+		return fmt.Errorf("failed to prepare queue for agent %s: %w", msg.ToAgentID, err)
+	}
 
 	// Convert to generic message
 	message := &Message{
-		ID:            uuid.New().String(),
-		CorrelationID: msg.CorrelationID,
-		FromID:        msg.FromAgentID,
-		ToID:          msg.ToAgentID,
-		Content:       msg.Content,
-		MessageType:   MessageTypeAgentToAgent,
-		Metadata:      msg.Context,
-		Timestamp:     time.Now(),
+		ID:             uuid.New().String(),
+		CorrelationID:  msg.CorrelationID,
+		ConversationID: conversationIDFrom(msg.Context),
+		FromID:         msg.FromAgentID,
+		ToID:           msg.ToAgentID,
+		Content:        msg.Content,
+		MessageType:    MessageTypeAgentToAgent,
+		Metadata:       msg.Context,
+		Timestamp:      time.Now(),
 	}
 
-	// Store in graph for conversation history
-	if err := bus.storeMessageInGraph(ctx, message); err != nil {
-		bus.logger.Error("Failed to store agent-to-agent message in graph", err)
+	// Store for conversation history
+	if err := bus.storeMessage(ctx, message); err != nil {
+		bus.logger.Error("Failed to store agent-to-agent message", err)
 	}
 
 	// Send via message bus (AI mediates routing)
@@ -230,21 +329,28 @@ func (bus *AIMessageBusImpl) SendUserToAI(ctx context.Context, msg *UserToAIMess
 		return fmt.Errorf("correlation ID is required for all messages")
 	}
 
+	// A user's session is their conversation unless the context names another one
+	conversationID := conversationIDFrom(msg.Context)
+	if conversationID == "" {
+		conversationID = msg.SessionID
+	}
+
 	// Convert to generic message
 	message := &Message{
-		ID:            uuid.New().String(),
-		CorrelationID: msg.CorrelationID,
-		FromID:        msg.UserID,
-		ToID:          "ai-orchestrator",
-		Content:       msg.Content,
-		MessageType:   MessageTypeRequest,
-		Metadata:      msg.Context,
-		Timestamp:     time.Now(),
+		ID:             uuid.New().String(),
+		CorrelationID:  msg.CorrelationID,
+		ConversationID: conversationID,
+		FromID:         msg.UserID,
+		ToID:           "ai-orchestrator",
+		Content:        msg.Content,
+		MessageType:    MessageTypeRequest,
+		Metadata:       msg.Context,
+		Timestamp:      time.Now(),
 	}
 
-	// Store in graph for conversation history
-	if err := bus.storeMessageInGraph(ctx, message); err != nil {
-		bus.logger.Error("Failed to store user-to-AI message in graph", err)
+	// Store for conversation history
+	if err := bus.storeMessage(ctx, message); err != nil {
+		bus.logger.Error("Failed to store user-to-AI message", err)
 	}
 
 	// Send to AI orchestrator
@@ -260,16 +366,89 @@ func (bus *AIMessageBusImpl) SendUserToAI(ctx context.Context, msg *UserToAIMess
 	return nil
 }
 
+// PublishAgentCompletedEvent announces a completed plan step to PlanEventsParticipant subscribers
+func (bus *AIMessageBusImpl) PublishAgentCompletedEvent(ctx context.Context, event *AgentCompletedEvent) error {
+	if event.PlanID == "" || event.StepID == "" {
+		return fmt.Errorf("plan ID and step ID are required for agent completed events")
+	}
+
+	message := &Message{
+		ID:            uuid.New().String(),
+		CorrelationID: event.CorrelationID,
+		FromID:        event.AgentID,
+		ToID:          PlanEventsParticipant,
+		Content:       event.StepID,
+		MessageType:   MessageTypeAgentCompleted,
+		Metadata: map[string]interface{}{
+			"plan_id":  event.PlanID,
+			"step_id":  event.StepID,
+			"agent_id": event.AgentID,
+		},
+		Timestamp: time.Now(),
+	}
+
+	if err := bus.messageBus.SendMessage(ctx, message); err != nil {
+		return fmt.Errorf("failed to publish agent completed event for step %s: %w", event.StepID, err)
+	}
+
+	return nil
+}
+
+// PublishPlanCompletedEvent announces a completed plan to PlanEventsParticipant subscribers
+func (bus *AIMessageBusImpl) PublishPlanCompletedEvent(ctx context.Context, event *PlanCompletedEvent) error {
+	if event.PlanID == "" {
+		return fmt.Errorf("plan ID is required for plan completed events")
+	}
+
+	message := &Message{
+		ID:            uuid.New().String(),
+		CorrelationID: event.CorrelationID,
+		FromID:        "ai-orchestrator",
+		ToID:          PlanEventsParticipant,
+		Content:       event.PlanID,
+		MessageType:   MessageTypePlanCompleted,
+		Metadata: map[string]interface{}{
+			"plan_id": event.PlanID,
+			"user_id": event.UserID,
+		},
+		Timestamp: time.Now(),
+	}
+
+	if err := bus.messageBus.SendMessage(ctx, message); err != nil {
+		return fmt.Errorf("failed to publish plan completed event for plan %s: %w", event.PlanID, err)
+	}
+
+	bus.logger.Info("Plan completed event published",
+		"plan_id", event.PlanID,
+		"correlation_id", event.CorrelationID)
+
+	return nil
+}
+
 // Subscribe to conversations by participant
 func (bus *AIMessageBusImpl) Subscribe(ctx context.Context, participantID string) (<-chan *Message, error) {
 	return bus.messageBus.Subscribe(ctx, participantID)
 }
 
-// GetConversationHistory retrieves conversation history from graph
+// GetConversationHistory retrieves the messages routed under a correlation ID from the message store, oldest first
 func (bus *AIMessageBusImpl) GetConversationHistory(ctx context.Context, correlationID string) ([]*Message, error) {
-	// Use graph to retrieve conversation history
-	// For now, return the message bus history
-	return bus.messageBus.GetConversationHistory(ctx, correlationID)
+	if bus.store == nil {
+		return bus.messageBus.GetConversationHistory(ctx, correlationID)
+	}
+
+	messages, err := bus.store.ListByCorrelation(ctx, correlationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query conversation history: %w", err)
+	}
+	return messages, nil
+}
+
+// Reconnected passes on the base bus's reconnect notifications; nil if the base bus never reconnects
+func (bus *AIMessageBusImpl) Reconnected() <-chan struct{} {
+	if notifier, ok := bus.messageBus.(ReconnectNotifier); ok {
+		return notifier.Reconnected()
+	}
+	return nil
 }
 
 // PrepareAgentQueue ensures queue and routing are set up for an agent without starting consumption
@@ -277,16 +456,10 @@ func (bus *AIMessageBusImpl) PrepareAgentQueue(ctx context.Context, agentID stri
 	return bus.messageBus.PrepareAgentQueue(ctx, agentID)
 }
 
-// storeMessageInGraph stores a message in the graph for persistence and AI learning
-func (bus *AIMessageBusImpl) storeMessageInGraph(ctx context.Context, message *Message) error {
-	// For now, we'll just log that we're storing in graph
-	// In full implementation, this would use graph operations to persist conversation context
-	bus.logger.Debug("Storing message in graph",
-		"message_id", message.ID,
-		"correlation_id", message.CorrelationID,
-		"from", message.FromID,
-		"to", message.ToID)
-
-	// TODO: Implement actual graph storage when graph operations are defined
-	return nil
+// storeMessage persists a routed message so its correlation and conversation can be replayed
+func (bus *AIMessageBusImpl) storeMessage(ctx context.Context, message *Message) error {
+	if bus.store == nil {
+		return nil
+	}
+	return bus.store.AppendMessage(ctx, message)
 }
@@ -2,6 +2,8 @@ package messaging
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"testing"
 	"time"
 
@@ -12,13 +14,48 @@ import (
 	"neuromesh/internal/logging"
 )
 
-// Simple mock graph for messaging tests
-type mockGraph struct{}
+// Simple mock graph for messaging tests that keeps added nodes in insertion order
+// Methods the bus does not use fall through to the embedded nil interface
+type mockGraph struct {
+	graph.Graph
+	nodes []map[string]interface{}
+}
 
 func (m *mockGraph) AddNode(ctx context.Context, nodeType, nodeID string, properties map[string]interface{}) error {
+	node := map[string]interface{}{"type": nodeType, "id": nodeID}
+	for k, v := range properties {
+		node[k] = v
+	}
+	m.nodes = append(m.nodes, node)
 	return nil
 }
 
+// QueryNodesWithOptions matches filters exactly and orders by the string form of opts.OrderBy
+func (m *mockGraph) QueryNodesWithOptions(ctx context.Context, nodeType string, filters map[string]interface{}, opts graph.QueryOptions) ([]map[string]interface{}, error) {
+	var results []map[string]interface{}
+	for _, node := range m.nodes {
+		if node["type"] != nodeType {
+			continue
+		}
+		matches := true
+		for k, v := range filters {
+			if node[k] != v {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			results = append(results, node)
+		}
+	}
+	if opts.OrderBy != "" {
+		sort.SliceStable(results, func(i, j int) bool {
+			return fmt.Sprint(results[i][opts.OrderBy]) < fmt.Sprint(results[j][opts.OrderBy])
+		})
+	}
+	return results, nil
+}
+
 func (m *mockGraph) GetNode(ctx context.Context, nodeType, nodeID string) (map[string]interface{}, error) {
 	return make(map[string]interface{}), nil
 }
@@ -51,8 +88,8 @@ func (m *mockGraph) DeleteEdge(ctx context.Context, sourceType, sourceID, target
 	return nil
 }
 
-func (m *mockGraph) GetStats() map[string]interface{} {
-	return make(map[string]interface{})
+func (m *mockGraph) GetStats(ctx context.Context) (map[string]interface{}, error) {
+	return make(map[string]interface{}), nil
 }
 
 func (m *mockGraph) Close(ctx context.Context) error {
@@ -115,6 +152,56 @@ func TestAIMessageBus_TDD(t *testing.T) {
 		}
 	})
 
+	t.Run("instruction_parameters_reach_the_agent", func(t *testing.T) {
+		messageBus := NewMemoryMessageBus(logging.NewNoOpLogger())
+		aiMessageBus := NewAIMessageBus(messageBus, newMockGraph(), &TestLogger{t: t})
+
+		ctx := context.Background()
+		agentChan, err := aiMessageBus.Subscribe(ctx, "text-processor")
+		require.NoError(t, err)
+
+		instruction := &AIToAgentMessage{
+			AgentID:       "text-processor",
+			Content:       "Count the words",
+			CorrelationID: "workflow-456",
+			Context:       map[string]interface{}{"step_id": "step-1"},
+			Parameters:    map[string]interface{}{"text": "hello world"},
+		}
+		require.NoError(t, aiMessageBus.SendToAgent(ctx, instruction))
+
+		select {
+		case message := <-agentChan:
+			assert.Equal(t, "step-1", message.Metadata["step_id"])
+			assert.Equal(t, map[string]interface{}{"text": "hello world"}, message.Metadata[ParametersContextKey])
+		case <-time.After(1 * time.Second):
+			t.Fatal("Agent should have received AI instruction")
+		}
+		assert.NotContains(t, instruction.Context, ParametersContextKey, "the caller's context must not be modified")
+	})
+
+	t.Run("instruction_timeout_becomes_the_message_ttl", func(t *testing.T) {
+		messageBus := NewMemoryMessageBus(logging.NewNoOpLogger())
+		aiMessageBus := NewAIMessageBus(messageBus, newMockGraph(), &TestLogger{t: t})
+
+		ctx := context.Background()
+		agentChan, err := aiMessageBus.Subscribe(ctx, "report-agent")
+		require.NoError(t, err)
+
+		require.NoError(t, aiMessageBus.SendToAgent(ctx, &AIToAgentMessage{
+			AgentID:       "report-agent",
+			Content:       "Build the quarterly report",
+			CorrelationID: "workflow-789",
+			Timeout:       20 * time.Minute,
+		}))
+
+		select {
+		case message := <-agentChan:
+			assert.Equal(t, 20*time.Minute, message.TTL)
+		case <-time.After(1 * time.Second):
+			t.Fatal("Agent should have received AI instruction")
+		}
+	})
+
 	t.Run("agent_can_request_clarification_from_ai", func(t *testing.T) {
 		// Setup
 		messageBus := NewMemoryMessageBus(logging.NewNoOpLogger())
@@ -266,6 +353,63 @@ func TestAIMessageBus_TDD(t *testing.T) {
 		assert.Equal(t, "test-conversation", storedMessage.CorrelationID)
 	})
 
+	t.Run("conversation_history_replays_messages_in_order", func(t *testing.T) {
+		// Setup
+		messageBus := NewMemoryMessageBus(logging.NewNoOpLogger())
+		mockGraph := newMockGraph()
+		aiMessageBus := NewAIMessageBus(messageBus, mockGraph, &TestLogger{t: t})
+
+		ctx := context.Background()
+		for _, participant := range []string{"ai-orchestrator", "build-agent", "deploy-agent"} {
+			_, err := aiMessageBus.Subscribe(ctx, participant)
+			require.NoError(t, err)
+		}
+
+		correlationID := "replay-conversation"
+		require.NoError(t, aiMessageBus.SendToAgent(ctx, &AIToAgentMessage{
+			AgentID:       "build-agent",
+			Content:       "Build the web app",
+			Intent:        "build",
+			CorrelationID: correlationID,
+			Context:       map[string]interface{}{"version": "1.2.3"},
+		}))
+		require.NoError(t, aiMessageBus.SendBetweenAgents(ctx, &AgentToAgentMessage{
+			FromAgentID:   "build-agent",
+			ToAgentID:     "deploy-agent",
+			Content:       "Artifact ready for deployment",
+			CorrelationID: correlationID,
+		}))
+		require.NoError(t, aiMessageBus.SendToAgent(ctx, &AIToAgentMessage{
+			AgentID:       "build-agent",
+			Content:       "Unrelated instruction",
+			CorrelationID: "other-conversation",
+		}))
+		require.NoError(t, aiMessageBus.SendToAI(ctx, &AgentToAIMessage{
+			AgentID:       "deploy-agent",
+			Content:       "Deployment finished",
+			MessageType:   MessageTypeCompletion,
+			CorrelationID: correlationID,
+		}))
+
+		history, err := aiMessageBus.GetConversationHistory(ctx, correlationID)
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+
+		assert.Equal(t, "Build the web app", history[0].Content)
+		assert.Equal(t, MessageTypeAIToAgent, history[0].MessageType)
+		assert.Equal(t, "1.2.3", history[0].Metadata["version"])
+		assert.Equal(t, "Artifact ready for deployment", history[1].Content)
+		assert.Equal(t, "build-agent", history[1].FromID)
+		assert.Equal(t, "deploy-agent", history[1].ToID)
+		assert.Equal(t, "Deployment finished", history[2].Content)
+		for i, message := range history {
+			assert.Equal(t, correlationID, message.CorrelationID)
+			if i > 0 {
+				assert.False(t, message.Timestamp.Before(history[i-1].Timestamp))
+			}
+		}
+	})
+
 	t.Run("ai_handles_user_requests", func(t *testing.T) {
 		// Setup
 		messageBus := NewMemoryMessageBus(logging.NewNoOpLogger())
@@ -329,3 +473,7 @@ func (l *TestLogger) Error(msg string, err error, keysAndValues ...interface{})
 func (l *TestLogger) Warn(msg string, keysAndValues ...interface{}) {
 	l.t.Logf("WARN: %s %v", msg, keysAndValues)
 }
+
+func (l *TestLogger) With(keysAndValues ...interface{}) logging.Logger {
+	return l
+}
@@ -0,0 +1,264 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"neuromesh/internal/graph"
+	"neuromesh/internal/logging"
+
+	"github.com/google/uuid"
+)
+
+// NodeTypeBusEvent is the graph node type of the AI message bus event log
+const NodeTypeBusEvent = "BusEvent"
+
+// BusEventType identifies the AI message bus call a logged event records
+type BusEventType string
+
+const (
+	BusEventSendToAgent       BusEventType = "send_to_agent"
+	BusEventSendToAI          BusEventType = "send_to_ai"
+	BusEventSendBetweenAgents BusEventType = "send_between_agents"
+	BusEventSendUserToAI      BusEventType = "send_user_to_ai"
+)
+
+// BusEvent is one message sent through the AI message bus, kept with its original payload
+type BusEvent struct {
+	ID            string          `json:"id"`
+	Type          BusEventType    `json:"type"`
+	CorrelationID string          `json:"correlation_id"`
+	Timestamp     time.Time       `json:"timestamp"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// EventLogStore persists bus events in an append-only log
+type EventLogStore interface {
+	// AppendEvent adds an event to the end of the log
+	AppendEvent(ctx context.Context, event *BusEvent) error
+
+	// ListEventsByCorrelation returns the events logged under a correlation ID, oldest first
+	ListEventsByCorrelation(ctx context.Context, correlationID string) ([]*BusEvent, error)
+}
+
+// EventLog decorates an AI message bus, recording every message it sends so a correlation can be replayed
+// Calls other than the sends pass straight through to the decorated bus
+type EventLog struct {
+	AIMessageBus
+	store  EventLogStore
+	logger logging.Logger
+	now    func() time.Time
+}
+
+// NewEventLog wraps an AI message bus with an event log kept in the given store
+func NewEventLog(bus AIMessageBus, store EventLogStore, logger logging.Logger) *EventLog {
+	return &EventLog{
+		AIMessageBus: bus,
+		store:        store,
+		logger:       logger,
+		now:          time.Now,
+	}
+}
+
+// SendToAgent sends AI instructions to an agent and logs them
+func (l *EventLog) SendToAgent(ctx context.Context, msg *AIToAgentMessage) error {
+	if err := l.AIMessageBus.SendToAgent(ctx, msg); err != nil {
+		return err
+	}
+	l.record(ctx, BusEventSendToAgent, msg.CorrelationID, msg)
+	return nil
+}
+
+// SendToAI sends an agent's message to the AI and logs it
+func (l *EventLog) SendToAI(ctx context.Context, msg *AgentToAIMessage) error {
+	if err := l.AIMessageBus.SendToAI(ctx, msg); err != nil {
+		return err
+	}
+	l.record(ctx, BusEventSendToAI, msg.CorrelationID, msg)
+	return nil
+}
+
+// SendBetweenAgents sends an agent-to-agent message and logs it
+func (l *EventLog) SendBetweenAgents(ctx context.Context, msg *AgentToAgentMessage) error {
+	if err := l.AIMessageBus.SendBetweenAgents(ctx, msg); err != nil {
+		return err
+	}
+	l.record(ctx, BusEventSendBetweenAgents, msg.CorrelationID, msg)
+	return nil
+}
+
+// SendUserToAI sends a user's request to the AI and logs it
+func (l *EventLog) SendUserToAI(ctx context.Context, msg *UserToAIMessage) error {
+	if err := l.AIMessageBus.SendUserToAI(ctx, msg); err != nil {
+		return err
+	}
+	l.record(ctx, BusEventSendUserToAI, msg.CorrelationID, msg)
+	return nil
+}
+
+// Reconnected passes on the decorated bus's reconnect notifications
+func (l *EventLog) Reconnected() <-chan struct{} {
+	if notifier, ok := l.AIMessageBus.(ReconnectNotifier); ok {
+		return notifier.Reconnected()
+	}
+	return nil
+}
+
+// ReplayCorrelation re-emits the messages logged under a correlation ID into a sandbox bus, oldest first
+// The sandbox is never the decorated bus: replaying to live agents would repeat their side effects
+// Replayed messages are not logged again, so replaying never grows the log
+func (l *EventLog) ReplayCorrelation(ctx context.Context, correlationID string, sandbox AIMessageBus) ([]*BusEvent, error) {
+	if sandbox == nil {
+		return nil, fmt.Errorf("replay requires a sandbox bus")
+	}
+	if sandbox == l.AIMessageBus || sandbox == AIMessageBus(l) {
+		return nil, fmt.Errorf("replay must not target the live message bus")
+	}
+
+	events, err := l.store.ListEventsByCorrelation(ctx, correlationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event log for correlation %s: %w", correlationID, err)
+	}
+
+	for i, event := range events {
+		if err := replayEvent(ctx, sandbox, event); err != nil {
+			return events[:i], fmt.Errorf("failed to replay event %s: %w", event.ID, err)
+		}
+	}
+	return events, nil
+}
+
+// replayEvent decodes a logged payload and sends it again through the given bus
+func replayEvent(ctx context.Context, bus AIMessageBus, event *BusEvent) error {
+	switch event.Type {
+	case BusEventSendToAgent:
+		var msg AIToAgentMessage
+		if err := json.Unmarshal(event.Payload, &msg); err != nil {
+			return fmt.Errorf("failed to decode payload: %w", err)
+		}
+		return bus.SendToAgent(ctx, &msg)
+	case BusEventSendToAI:
+		var msg AgentToAIMessage
+		if err := json.Unmarshal(event.Payload, &msg); err != nil {
+			return fmt.Errorf("failed to decode payload: %w", err)
+		}
+		return bus.SendToAI(ctx, &msg)
+	case BusEventSendBetweenAgents:
+		var msg AgentToAgentMessage
+		if err := json.Unmarshal(event.Payload, &msg); err != nil {
+			return fmt.Errorf("failed to decode payload: %w", err)
+		}
+		return bus.SendBetweenAgents(ctx, &msg)
+	case BusEventSendUserToAI:
+		var msg UserToAIMessage
+		if err := json.Unmarshal(event.Payload, &msg); err != nil {
+			return fmt.Errorf("failed to decode payload: %w", err)
+		}
+		return bus.SendUserToAI(ctx, &msg)
+	default:
+		return fmt.Errorf("unknown bus event type: %s", event.Type)
+	}
+}
+
+// record appends a sent message to the log; the message is already delivered, so failures are only logged
+func (l *EventLog) record(ctx context.Context, eventType BusEventType, correlationID string, msg interface{}) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		l.logger.Error("Failed to encode bus event", err, "type", eventType, "correlation_id", correlationID)
+		return
+	}
+
+	event := &BusEvent{
+		ID:            uuid.New().String(),
+		Type:          eventType,
+		CorrelationID: correlationID,
+		Timestamp:     l.now(),
+		Payload:       payload,
+	}
+	if err := l.store.AppendEvent(ctx, event); err != nil {
+		l.logger.Error("Failed to log bus event", err, "type", eventType, "correlation_id", correlationID)
+	}
+}
+
+// GraphEventLogStore keeps the bus event log as graph nodes
+type GraphEventLogStore struct {
+	graph graph.Graph
+}
+
+// NewGraphEventLogStore creates an event log store backed by the graph
+func NewGraphEventLogStore(g graph.Graph) *GraphEventLogStore {
+	return &GraphEventLogStore{graph: g}
+}
+
+// EnsureSchema creates the constraint and index used to look up logged events
+func (s *GraphEventLogStore) EnsureSchema(ctx context.Context) error {
+	if err := s.graph.CreateUniqueConstraint(ctx, NodeTypeBusEvent, "id"); err != nil {
+		return fmt.Errorf("failed to create bus event ID constraint: %w", err)
+	}
+	if err := s.graph.CreateIndex(ctx, NodeTypeBusEvent, "correlation_id"); err != nil {
+		return fmt.Errorf("failed to create bus event correlation_id index: %w", err)
+	}
+	return nil
+}
+
+// AppendEvent stores an event as a node; the payload is kept as JSON
+func (s *GraphEventLogStore) AppendEvent(ctx context.Context, event *BusEvent) error {
+	properties := map[string]interface{}{
+		"id":             event.ID,
+		"event_type":     string(event.Type),
+		"correlation_id": event.CorrelationID,
+		"timestamp":      event.Timestamp.UTC().Format(historyTimeFormat),
+		"payload":        string(event.Payload),
+	}
+	if err := s.graph.AddNode(ctx, NodeTypeBusEvent, event.ID, properties); err != nil {
+		return fmt.Errorf("failed to store bus event %s: %w", event.ID, err)
+	}
+	return nil
+}
+
+// ListEventsByCorrelation returns the events logged under a correlation ID, oldest first
+func (s *GraphEventLogStore) ListEventsByCorrelation(ctx context.Context, correlationID string) ([]*BusEvent, error) {
+	filters := map[string]interface{}{"correlation_id": correlationID}
+	nodes, err := s.graph.QueryNodesWithOptions(ctx, NodeTypeBusEvent, filters, graph.QueryOptions{OrderBy: "timestamp"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bus events: %w", err)
+	}
+
+	events := make([]*BusEvent, 0, len(nodes))
+	for _, props := range nodes {
+		event, err := busEventFromNode(props)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map bus event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// busEventFromNode rebuilds a logged event from its graph properties
+func busEventFromNode(props map[string]interface{}) (*BusEvent, error) {
+	id, ok := props["id"].(string)
+	if !ok || id == "" {
+		return nil, fmt.Errorf("invalid bus event id")
+	}
+
+	timestampStr, _ := props["timestamp"].(string)
+	timestamp, err := time.Parse(historyTimeFormat, timestampStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse timestamp for bus event %s: %w", id, err)
+	}
+
+	eventType, _ := props["event_type"].(string)
+	correlationID, _ := props["correlation_id"].(string)
+	payload, _ := props["payload"].(string)
+
+	return &BusEvent{
+		ID:            id,
+		Type:          BusEventType(eventType),
+		CorrelationID: correlationID,
+		Timestamp:     timestamp,
+		Payload:       json.RawMessage(payload),
+	}, nil
+}
@@ -0,0 +1,132 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingBus records the order of the sends that reach the decorated bus
+type recordingBus struct {
+	AIMessageBus
+	sent []string
+}
+
+func (b *recordingBus) SendToAgent(ctx context.Context, msg *AIToAgentMessage) error {
+	b.sent = append(b.sent, "ai->"+msg.AgentID+": "+msg.Content)
+	return b.AIMessageBus.SendToAgent(ctx, msg)
+}
+
+func (b *recordingBus) SendToAI(ctx context.Context, msg *AgentToAIMessage) error {
+	b.sent = append(b.sent, msg.AgentID+"->ai: "+msg.Content)
+	return b.AIMessageBus.SendToAI(ctx, msg)
+}
+
+func (b *recordingBus) SendBetweenAgents(ctx context.Context, msg *AgentToAgentMessage) error {
+	b.sent = append(b.sent, msg.FromAgentID+"->"+msg.ToAgentID+": "+msg.Content)
+	return b.AIMessageBus.SendBetweenAgents(ctx, msg)
+}
+
+func (b *recordingBus) SendUserToAI(ctx context.Context, msg *UserToAIMessage) error {
+	b.sent = append(b.sent, msg.UserID+"->ai: "+msg.Content)
+	return b.AIMessageBus.SendUserToAI(ctx, msg)
+}
+
+func TestEventLog_LogsAndReplaysConversation(t *testing.T) {
+	ctx := context.Background()
+	logger := &TestLogger{t: t}
+	inner := &recordingBus{AIMessageBus: NewAIMessageBusWithStore(NewMemoryMessageBus(logger), nil, logger)}
+	for _, participant := range []string{"ai-orchestrator", "build-agent", "deploy-agent"} {
+		_, err := inner.Subscribe(ctx, participant)
+		require.NoError(t, err)
+	}
+
+	store := NewGraphEventLogStore(newMockGraph())
+	eventLog := NewEventLog(inner, store, logger)
+	clock := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	eventLog.now = func() time.Time {
+		clock = clock.Add(time.Millisecond)
+		return clock
+	}
+
+	correlationID := "deploy-1"
+	require.NoError(t, eventLog.SendUserToAI(ctx, &UserToAIMessage{UserID: "user-1", Content: "Deploy the app", CorrelationID: correlationID}))
+	require.NoError(t, eventLog.SendToAgent(ctx, &AIToAgentMessage{
+		AgentID: "build-agent", Content: "Build the image", CorrelationID: correlationID,
+		Parameters: map[string]interface{}{"tag": "v1.2.0"},
+	}))
+	require.NoError(t, eventLog.SendBetweenAgents(ctx, &AgentToAgentMessage{
+		FromAgentID: "build-agent", ToAgentID: "deploy-agent", Content: "Image ready", CorrelationID: correlationID, Purpose: "handoff",
+	}))
+	require.NoError(t, eventLog.SendToAI(ctx, &AgentToAIMessage{
+		AgentID: "deploy-agent", Content: "Deployed", MessageType: MessageTypeAgentToAI, CorrelationID: correlationID,
+	}))
+	// Another conversation is logged but not part of the replay
+	require.NoError(t, eventLog.SendUserToAI(ctx, &UserToAIMessage{UserID: "user-2", Content: "Hello", CorrelationID: "other"}))
+
+	conversation := []string{
+		"user-1->ai: Deploy the app",
+		"ai->build-agent: Build the image",
+		"build-agent->deploy-agent: Image ready",
+		"deploy-agent->ai: Deployed",
+	}
+
+	logged, err := store.ListEventsByCorrelation(ctx, correlationID)
+	require.NoError(t, err)
+	require.Len(t, logged, 4)
+	assert.Equal(t, []BusEventType{BusEventSendUserToAI, BusEventSendToAgent, BusEventSendBetweenAgents, BusEventSendToAI},
+		[]BusEventType{logged[0].Type, logged[1].Type, logged[2].Type, logged[3].Type})
+	for i, event := range logged {
+		assert.Equal(t, correlationID, event.CorrelationID)
+		if i > 0 {
+			assert.True(t, event.Timestamp.After(logged[i-1].Timestamp))
+		}
+	}
+
+	// Replay goes to a sandbox; live agents never see the instructions again
+	sandbox := &recordingBus{AIMessageBus: NewAIMessageBusWithStore(NewMemoryMessageBus(logger), nil, logger)}
+	for _, participant := range []string{"ai-orchestrator", "build-agent", "deploy-agent"} {
+		_, err := sandbox.Subscribe(ctx, participant)
+		require.NoError(t, err)
+	}
+	inner.sent = nil
+	replayed, err := eventLog.ReplayCorrelation(ctx, correlationID, sandbox)
+	require.NoError(t, err)
+	assert.Len(t, replayed, 4)
+	assert.Equal(t, conversation, sandbox.sent)
+	assert.Empty(t, inner.sent)
+
+	_, err = eventLog.ReplayCorrelation(ctx, correlationID, inner)
+	assert.Error(t, err)
+	_, err = eventLog.ReplayCorrelation(ctx, correlationID, eventLog)
+	assert.Error(t, err)
+
+	// Payloads survive the round trip, including structured parameters
+	var instruction AIToAgentMessage
+	require.NoError(t, json.Unmarshal(replayed[1].Payload, &instruction))
+	assert.Equal(t, "v1.2.0", instruction.Parameters["tag"])
+
+	// Replaying does not append to the log
+	logged, err = store.ListEventsByCorrelation(ctx, correlationID)
+	require.NoError(t, err)
+	assert.Len(t, logged, 4)
+}
+
+func TestEventLog_FailedSendIsNotLogged(t *testing.T) {
+	ctx := context.Background()
+	logger := &TestLogger{t: t}
+	store := NewGraphEventLogStore(newMockGraph())
+	eventLog := NewEventLog(NewAIMessageBusWithStore(NewMemoryMessageBus(logger), nil, logger), store, logger)
+
+	// Nobody subscribed to the agent, so the memory bus rejects the send
+	err := eventLog.SendToAgent(ctx, &AIToAgentMessage{AgentID: "missing-agent", Content: "Hello", CorrelationID: "corr-1"})
+	require.Error(t, err)
+
+	logged, err := store.ListEventsByCorrelation(ctx, "corr-1")
+	require.NoError(t, err)
+	assert.Empty(t, logged)
+}
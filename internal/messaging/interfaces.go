@@ -2,8 +2,12 @@ package messaging
 
 import (
 	"context"
+	"errors"
 )
 
+// ErrBusUnavailable is returned when the underlying message broker cannot be reached
+var ErrBusUnavailable = errors.New("message bus unavailable")
+
 // MessageBus handles natural language communication between AI, agents, and users
 // This is the event-driven messaging system for conversational orchestration
 type MessageBus interface {
@@ -29,6 +33,13 @@ type MessageBus interface {
 	PrepareAgentQueue(ctx context.Context, agentID string) error
 }
 
+// ReconnectNotifier is implemented by message buses that re-establish a dropped broker connection
+// Subscriptions end when the connection drops and must be renewed once the bus has reconnected
+type ReconnectNotifier interface {
+	// Reconnected returns a channel closed the next time the bus reconnects, or nil if the bus never reconnects
+	Reconnected() <-chan struct{}
+}
+
 // MessageHandler handles incoming messages
 type MessageHandler interface {
 	// Handle incoming message
@@ -0,0 +1,170 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"neuromesh/internal/graph"
+)
+
+// MessageStore persists the messages routed by the AI message bus so conversations can be replayed
+type MessageStore interface {
+	// AppendMessage stores a routed message
+	AppendMessage(ctx context.Context, message *Message) error
+
+	// ListByConversation returns the messages of a conversation, oldest first
+	ListByConversation(ctx context.Context, conversationID string) ([]*Message, error)
+
+	// ListByCorrelation returns the messages routed under a correlation ID, oldest first
+	ListByCorrelation(ctx context.Context, correlationID string) ([]*Message, error)
+}
+
+// MessageStoreType selects the backend that persists routed messages
+type MessageStoreType string
+
+const (
+	MessageStoreTypeNeo4j    MessageStoreType = "neo4j"
+	MessageStoreTypePostgres MessageStoreType = "postgres"
+)
+
+// ParseMessageStoreType converts a backend name to a MessageStoreType, defaulting to Neo4j
+func ParseMessageStoreType(name string) (MessageStoreType, error) {
+	switch MessageStoreType(strings.ToLower(strings.TrimSpace(name))) {
+	case "", MessageStoreTypeNeo4j:
+		return MessageStoreTypeNeo4j, nil
+	case MessageStoreTypePostgres:
+		return MessageStoreTypePostgres, nil
+	default:
+		return "", fmt.Errorf("unknown message store type: %s", name)
+	}
+}
+
+// conversationIDFrom returns the conversation a message belongs to when its context names one
+func conversationIDFrom(context map[string]interface{}) string {
+	conversationID, _ := context["conversation_id"].(string)
+	return conversationID
+}
+
+// GraphMessageStore stores routed messages as graph nodes
+type GraphMessageStore struct {
+	graph graph.Graph
+}
+
+// NewGraphMessageStore creates a message store backed by the graph
+func NewGraphMessageStore(g graph.Graph) *GraphMessageStore {
+	return &GraphMessageStore{graph: g}
+}
+
+// EnsureSchema creates the constraint and indexes used to look up routed messages
+func (s *GraphMessageStore) EnsureSchema(ctx context.Context) error {
+	if err := s.graph.CreateUniqueConstraint(ctx, NodeTypeRoutedMessage, "id"); err != nil {
+		return fmt.Errorf("failed to create routed message ID constraint: %w", err)
+	}
+	for _, property := range []string{"correlation_id", "conversation_id", "timestamp"} {
+		if err := s.graph.CreateIndex(ctx, NodeTypeRoutedMessage, property); err != nil {
+			return fmt.Errorf("failed to create routed message %s index: %w", property, err)
+		}
+	}
+	return nil
+}
+
+// AppendMessage stores a routed message as a node
+func (s *GraphMessageStore) AppendMessage(ctx context.Context, message *Message) error {
+	properties := map[string]interface{}{
+		"id":             message.ID,
+		"correlation_id": message.CorrelationID,
+		"from_id":        message.FromID,
+		"to_id":          message.ToID,
+		"content":        message.Content,
+		"message_type":   string(message.MessageType),
+		"timestamp":      message.Timestamp.UTC().Format(historyTimeFormat),
+	}
+	if message.ConversationID != "" {
+		properties["conversation_id"] = message.ConversationID
+	}
+
+	if len(message.Metadata) > 0 {
+		metadata, err := graph.EncodeMapProperty(message.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encode message metadata: %w", err)
+		}
+		properties["metadata"] = metadata
+	}
+
+	if err := s.graph.AddNode(ctx, NodeTypeRoutedMessage, message.ID, properties); err != nil {
+		return fmt.Errorf("failed to store message %s: %w", message.ID, err)
+	}
+	return nil
+}
+
+// ListByConversation returns the messages of a conversation, oldest first
+func (s *GraphMessageStore) ListByConversation(ctx context.Context, conversationID string) ([]*Message, error) {
+	return s.list(ctx, "conversation_id", conversationID)
+}
+
+// ListByCorrelation returns the messages routed under a correlation ID, oldest first
+func (s *GraphMessageStore) ListByCorrelation(ctx context.Context, correlationID string) ([]*Message, error) {
+	return s.list(ctx, "correlation_id", correlationID)
+}
+
+// list returns the routed messages whose property has the given value, ordered by timestamp
+func (s *GraphMessageStore) list(ctx context.Context, property, value string) ([]*Message, error) {
+	filters := map[string]interface{}{property: value}
+	nodes, err := s.graph.QueryNodesWithOptions(ctx, NodeTypeRoutedMessage, filters, graph.QueryOptions{OrderBy: "timestamp"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query routed messages: %w", err)
+	}
+
+	messages := make([]*Message, 0, len(nodes))
+	for _, props := range nodes {
+		message, err := messageFromNode(props)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map routed message: %w", err)
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// messageFromNode rebuilds a routed message from its graph properties
+func messageFromNode(props map[string]interface{}) (*Message, error) {
+	id, ok := props["id"].(string)
+	if !ok || id == "" {
+		return nil, fmt.Errorf("invalid message id")
+	}
+
+	timestampStr, ok := props["timestamp"].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid timestamp for message %s", id)
+	}
+	timestamp, err := time.Parse(historyTimeFormat, timestampStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse timestamp for message %s: %w", id, err)
+	}
+
+	metadata, err := graph.DecodeMapProperty(props["metadata"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode metadata for message %s: %w", id, err)
+	}
+
+	correlationID, _ := props["correlation_id"].(string)
+	conversationID, _ := props["conversation_id"].(string)
+	fromID, _ := props["from_id"].(string)
+	toID, _ := props["to_id"].(string)
+	content, _ := props["content"].(string)
+	messageType, _ := props["message_type"].(string)
+
+	return &Message{
+		ID:             id,
+		CorrelationID:  correlationID,
+		ConversationID: conversationID,
+		FromID:         fromID,
+		ToID:           toID,
+		Content:        content,
+		MessageType:    MessageType(messageType),
+		Metadata:       metadata,
+		Timestamp:      timestamp,
+	}, nil
+}
@@ -0,0 +1,140 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runMessageStoreSuite checks the behaviour every MessageStore backend must share
+func runMessageStoreSuite(t *testing.T, store MessageStore) {
+	ctx := context.Background()
+	// Unique IDs keep runs against a shared database independent
+	conversationID := "conversation-" + uuid.New().String()
+	otherConversationID := "conversation-" + uuid.New().String()
+	correlationID := "correlation-" + uuid.New().String()
+	// Whole microseconds, the finest precision every backend keeps
+	start := time.Date(2024, 5, 1, 9, 30, 0, 123000, time.UTC)
+
+	request := &Message{
+		ID: uuid.New().String(), CorrelationID: correlationID, ConversationID: conversationID,
+		FromID: "user-1", ToID: "ai-orchestrator", Content: "Deploy the web app",
+		MessageType: MessageTypeRequest, Metadata: map[string]interface{}{"target_env": "production"},
+		Timestamp: start,
+	}
+	instruction := &Message{
+		ID: uuid.New().String(), CorrelationID: correlationID, ConversationID: conversationID,
+		FromID: "ai-orchestrator", ToID: "deploy-agent", Content: "Deploy to production",
+		MessageType: MessageTypeAIToAgent, Timestamp: start.Add(time.Second),
+	}
+	followUp := &Message{
+		ID: uuid.New().String(), CorrelationID: "correlation-" + uuid.New().String(), ConversationID: conversationID,
+		FromID: "user-1", ToID: "ai-orchestrator", Content: "Is it live?",
+		MessageType: MessageTypeRequest, Timestamp: start.Add(time.Minute),
+	}
+	unrelated := &Message{
+		ID: uuid.New().String(), CorrelationID: "correlation-" + uuid.New().String(), ConversationID: otherConversationID,
+		FromID: "user-2", ToID: "ai-orchestrator", Content: "Hello",
+		MessageType: MessageTypeRequest, Timestamp: start,
+	}
+
+	// Appended out of order; listings are ordered by timestamp
+	for _, message := range []*Message{followUp, instruction, unrelated, request} {
+		require.NoError(t, store.AppendMessage(ctx, message))
+	}
+
+	t.Run("lists_by_correlation_oldest_first", func(t *testing.T) {
+		messages, err := store.ListByCorrelation(ctx, correlationID)
+		require.NoError(t, err)
+		require.Len(t, messages, 2)
+		assert.Equal(t, request.ID, messages[0].ID)
+		assert.Equal(t, instruction.ID, messages[1].ID)
+	})
+
+	t.Run("lists_by_conversation_across_correlations", func(t *testing.T) {
+		messages, err := store.ListByConversation(ctx, conversationID)
+		require.NoError(t, err)
+		require.Len(t, messages, 3)
+		assert.Equal(t, []string{request.ID, instruction.ID, followUp.ID}, []string{messages[0].ID, messages[1].ID, messages[2].ID})
+	})
+
+	t.Run("round_trips_every_field", func(t *testing.T) {
+		messages, err := store.ListByCorrelation(ctx, correlationID)
+		require.NoError(t, err)
+		require.NotEmpty(t, messages)
+		stored := messages[0]
+		assert.Equal(t, request.CorrelationID, stored.CorrelationID)
+		assert.Equal(t, request.ConversationID, stored.ConversationID)
+		assert.Equal(t, request.FromID, stored.FromID)
+		assert.Equal(t, request.ToID, stored.ToID)
+		assert.Equal(t, request.Content, stored.Content)
+		assert.Equal(t, request.MessageType, stored.MessageType)
+		assert.Equal(t, "production", stored.Metadata["target_env"])
+		assert.True(t, request.Timestamp.Equal(stored.Timestamp), "timestamp %s, want %s", stored.Timestamp, request.Timestamp)
+	})
+
+	t.Run("unknown_keys_list_nothing", func(t *testing.T) {
+		messages, err := store.ListByCorrelation(ctx, "correlation-"+uuid.New().String())
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+
+		messages, err = store.ListByConversation(ctx, "conversation-"+uuid.New().String())
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+	})
+}
+
+func TestGraphMessageStore(t *testing.T) {
+	runMessageStoreSuite(t, NewGraphMessageStore(newMockGraph()))
+}
+
+// TestPostgresMessageStore runs against the database named by POSTGRES_TEST_DSN and is skipped without one
+func TestPostgresMessageStore(t *testing.T) {
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := NewPostgresMessageStore(db)
+	require.NoError(t, store.EnsureSchema(context.Background()))
+	runMessageStoreSuite(t, store)
+}
+
+func TestAIMessageBus_StoresConversationID(t *testing.T) {
+	ctx := context.Background()
+	store := NewGraphMessageStore(newMockGraph())
+	bus := NewAIMessageBusWithStore(NewMemoryMessageBus(&TestLogger{t: t}), store, &TestLogger{t: t})
+	for _, participant := range []string{"ai-orchestrator", "deploy-agent"} {
+		_, err := bus.Subscribe(ctx, participant)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, bus.SendUserToAI(ctx, &UserToAIMessage{
+		UserID: "user-1", Content: "Deploy", CorrelationID: "corr-1", SessionID: "session-1",
+	}))
+	require.NoError(t, bus.SendToAgent(ctx, &AIToAgentMessage{
+		AgentID: "deploy-agent", Content: "Deploy", CorrelationID: "corr-1",
+		Context: map[string]interface{}{"conversation_id": "session-1"},
+	}))
+
+	messages, err := store.ListByConversation(ctx, "session-1")
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, MessageTypeRequest, messages[0].MessageType)
+	assert.Equal(t, MessageTypeAIToAgent, messages[1].MessageType)
+
+	history, err := bus.GetConversationHistory(ctx, "corr-1")
+	require.NoError(t, err)
+	assert.Len(t, history, 2)
+}
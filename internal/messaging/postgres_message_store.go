@@ -0,0 +1,113 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// postgresMessageSchema creates the routed message table and the indexes its lookups use
+// The sequence column keeps insertion order between messages with the same timestamp
+const postgresMessageSchema = `
+CREATE TABLE IF NOT EXISTS routed_messages (
+	sequence        BIGSERIAL PRIMARY KEY,
+	id              TEXT NOT NULL UNIQUE,
+	correlation_id  TEXT NOT NULL,
+	conversation_id TEXT NOT NULL DEFAULT '',
+	from_id         TEXT NOT NULL,
+	to_id           TEXT NOT NULL,
+	content         TEXT NOT NULL,
+	message_type    TEXT NOT NULL,
+	metadata        JSONB,
+	timestamp       TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS routed_messages_correlation_idx ON routed_messages (correlation_id, timestamp);
+CREATE INDEX IF NOT EXISTS routed_messages_conversation_idx ON routed_messages (conversation_id, timestamp);
+`
+
+const postgresMessageColumns = "id, correlation_id, conversation_id, from_id, to_id, content, message_type, metadata, timestamp"
+
+// PostgresMessageStore stores routed messages in a Postgres table
+// Postgres keeps timestamps to the microsecond, so finer precision is not preserved
+type PostgresMessageStore struct {
+	db *sql.DB
+}
+
+// NewPostgresMessageStore creates a message store on an open Postgres connection pool
+func NewPostgresMessageStore(db *sql.DB) *PostgresMessageStore {
+	return &PostgresMessageStore{db: db}
+}
+
+// EnsureSchema creates the routed message table and its indexes
+func (s *PostgresMessageStore) EnsureSchema(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, postgresMessageSchema); err != nil {
+		return fmt.Errorf("failed to create routed message schema: %w", err)
+	}
+	return nil
+}
+
+// AppendMessage inserts a routed message
+func (s *PostgresMessageStore) AppendMessage(ctx context.Context, message *Message) error {
+	var metadata []byte
+	if len(message.Metadata) > 0 {
+		encoded, err := json.Marshal(message.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encode message metadata: %w", err)
+		}
+		metadata = encoded
+	}
+
+	_, err := s.db.ExecContext(ctx,
+		"INSERT INTO routed_messages ("+postgresMessageColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		message.ID, message.CorrelationID, message.ConversationID, message.FromID, message.ToID,
+		message.Content, string(message.MessageType), metadata, message.Timestamp.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to store message %s: %w", message.ID, err)
+	}
+	return nil
+}
+
+// ListByConversation returns the messages of a conversation, oldest first
+func (s *PostgresMessageStore) ListByConversation(ctx context.Context, conversationID string) ([]*Message, error) {
+	return s.list(ctx, "conversation_id", conversationID)
+}
+
+// ListByCorrelation returns the messages routed under a correlation ID, oldest first
+func (s *PostgresMessageStore) ListByCorrelation(ctx context.Context, correlationID string) ([]*Message, error) {
+	return s.list(ctx, "correlation_id", correlationID)
+}
+
+// list returns the routed messages whose column has the given value; column is never user input
+func (s *PostgresMessageStore) list(ctx context.Context, column, value string) ([]*Message, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT "+postgresMessageColumns+" FROM routed_messages WHERE "+column+" = $1 ORDER BY timestamp, sequence",
+		value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query routed messages: %w", err)
+	}
+	defer rows.Close()
+
+	messages := []*Message{}
+	for rows.Next() {
+		var message Message
+		var messageType string
+		var metadata []byte
+		if err := rows.Scan(&message.ID, &message.CorrelationID, &message.ConversationID, &message.FromID, &message.ToID,
+			&message.Content, &messageType, &metadata, &message.Timestamp); err != nil {
+			return nil, fmt.Errorf("failed to scan routed message: %w", err)
+		}
+		message.MessageType = MessageType(messageType)
+		if len(metadata) > 0 {
+			if err := json.Unmarshal(metadata, &message.Metadata); err != nil {
+				return nil, fmt.Errorf("failed to decode metadata for message %s: %w", message.ID, err)
+			}
+		}
+		message.Timestamp = message.Timestamp.UTC()
+		messages = append(messages, &message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read routed messages: %w", err)
+	}
+	return messages, nil
+}
@@ -3,20 +3,26 @@ package messaging
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
 	"neuromesh/internal/logging"
+	"neuromesh/internal/metrics"
 
 	"github.com/google/uuid"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+// DefaultMessageTTL is how long a queued message lives when it carries no TTL of its own
+const DefaultMessageTTL = 5 * time.Minute
+
 // RabbitMQMessageBus implements MessageBus using RabbitMQ
 // Solves all reconnection and resilience issues
 type RabbitMQMessageBus struct {
-	conn    *amqp.Connection
+	conn    *amqp.Connection // Replaced on reconnect; guarded by mu like channel
 	channel *amqp.Channel
 	url     string
 	logger  logging.Logger
@@ -24,6 +30,7 @@ type RabbitMQMessageBus struct {
 	// Connection recovery
 	reconnectDelay time.Duration
 	maxReconnects  int
+	reconnected    chan struct{} // Closed and replaced after each reconnect; guarded by mu
 
 	// Exchanges and queues
 	agentExchange string
@@ -38,7 +45,7 @@ type RabbitMQMessageBus struct {
 type RabbitMQConfig struct {
 	URL            string
 	ReconnectDelay time.Duration
-	MaxReconnects  int
+	MaxReconnects  int // Attempts after a dropped connection; zero or less retries forever
 	Heartbeat      time.Duration
 }
 
@@ -52,35 +59,111 @@ func NewRabbitMQMessageBus(config RabbitMQConfig, logger logging.Logger) *Rabbit
 		agentExchange:  "agent.messages",
 		dlxExchange:    "agent.messages.dlx",
 		consumerTags:   make(map[string]string),
+		reconnected:    make(chan struct{}),
 	}
 }
 
 // Connect establishes connection to RabbitMQ with auto-recovery
 func (rmq *RabbitMQMessageBus) Connect(ctx context.Context) error {
+	conn, channel, err := rmq.dial()
+	if err != nil {
+		return err
+	}
+	rmq.mu.Lock()
+	rmq.conn, rmq.channel = conn, channel
+	rmq.mu.Unlock()
+
+	// Set up exchanges and queues
+	if err := rmq.setupTopology(channel); err != nil {
+		return err
+	}
+
+	go rmq.watchConnection(conn)
+	return nil
+}
+
+// dial opens a connection to RabbitMQ and a channel on it
+func (rmq *RabbitMQMessageBus) dial() (*amqp.Connection, *amqp.Channel, error) {
 	config := amqp.Config{
 		Heartbeat: 10 * time.Second,
 		Locale:    "en_US",
 	}
 
-	var err error
-	rmq.conn, err = amqp.DialConfig(rmq.url, config)
+	conn, err := amqp.DialConfig(rmq.url, config)
 	if err != nil {
-		return fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+		return nil, nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
 	}
 
-	rmq.channel, err = rmq.conn.Channel()
+	channel, err := conn.Channel()
 	if err != nil {
-		return fmt.Errorf("failed to open channel: %w", err)
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to open channel: %w", err)
 	}
+	return conn, channel, nil
+}
 
-	// Set up exchanges and queues
-	return rmq.setupTopology()
+// watchConnection reconnects when the broker drops the connection, up to maxReconnects attempts
+// Subscriptions end with the old connection; subscribers renew them once Reconnected fires
+func (rmq *RabbitMQMessageBus) watchConnection(conn *amqp.Connection) {
+	closeErr, ok := <-conn.NotifyClose(make(chan *amqp.Error, 1))
+	if !ok || closeErr == nil {
+		// Closed through Close, nothing to recover
+		return
+	}
+	rmq.logger.Warn("RabbitMQ connection lost, reconnecting", "error", closeErr.Error())
+
+	for attempt := 1; rmq.maxReconnects <= 0 || attempt <= rmq.maxReconnects; attempt++ {
+		time.Sleep(rmq.reconnectDelay)
+
+		newConn, channel, err := rmq.dial()
+		if err == nil {
+			err = rmq.setupTopology(channel)
+			if err != nil {
+				newConn.Close()
+			}
+		}
+		if err != nil {
+			rmq.logger.Warn("RabbitMQ reconnect attempt failed", "attempt", attempt, "error", err.Error())
+			continue
+		}
+
+		rmq.mu.Lock()
+		rmq.conn, rmq.channel = newConn, channel
+		close(rmq.reconnected)
+		rmq.reconnected = make(chan struct{})
+		rmq.mu.Unlock()
+
+		rmq.logger.Info("✅ Reconnected to RabbitMQ", "attempt", attempt)
+		go rmq.watchConnection(newConn)
+		return
+	}
+
+	rmq.logger.Error("Giving up reconnecting to RabbitMQ", fmt.Errorf("%w: %s", ErrBusUnavailable, closeErr.Error()),
+		"attempts", rmq.maxReconnects)
+}
+
+// Reconnected returns a channel closed the next time the bus re-establishes a dropped connection
+func (rmq *RabbitMQMessageBus) Reconnected() <-chan struct{} {
+	rmq.mu.RLock()
+	defer rmq.mu.RUnlock()
+	return rmq.reconnected
 }
 
-// setupTopology creates exchanges, queues, and bindings
-func (rmq *RabbitMQMessageBus) setupTopology() error {
+// currentChannel returns the channel of the current connection, which a reconnect may replace at any time
+func (rmq *RabbitMQMessageBus) currentChannel() (*amqp.Channel, error) {
+	rmq.mu.RLock()
+	defer rmq.mu.RUnlock()
+
+	if rmq.channel == nil {
+		return nil, fmt.Errorf("not connected to RabbitMQ: %w", ErrBusUnavailable)
+	}
+	return rmq.channel, nil
+}
+
+// setupTopology creates exchanges, queues, and bindings on the channel
+func (rmq *RabbitMQMessageBus) setupTopology(channel *amqp.Channel) error {
 	// Declare main exchange for agent messages
-	err := rmq.channel.ExchangeDeclare(
+	err := channel.ExchangeDeclare(
 		rmq.agentExchange, // name
 		"direct",          // type
 		true,              // durable
@@ -94,7 +177,7 @@ func (rmq *RabbitMQMessageBus) setupTopology() error {
 	}
 
 	// Declare dead letter exchange
-	err = rmq.channel.ExchangeDeclare(
+	err = channel.ExchangeDeclare(
 		rmq.dlxExchange,
 		"direct",
 		true,
@@ -118,8 +201,9 @@ func (rmq *RabbitMQMessageBus) SendMessage(ctx context.Context, message *Message
 		return fmt.Errorf("correlation ID is required for all messages")
 	}
 
-	if rmq.channel == nil {
-		return fmt.Errorf("not connected to RabbitMQ")
+	channel, err := rmq.currentChannel()
+	if err != nil {
+		return err
 	}
 
 	// Serialize message
@@ -129,7 +213,7 @@ func (rmq *RabbitMQMessageBus) SendMessage(ctx context.Context, message *Message
 	}
 
 	// Publish to agent's queue
-	err = rmq.channel.PublishWithContext(
+	err = channel.PublishWithContext(
 		ctx,
 		rmq.agentExchange, // exchange
 		message.ToID,      // routing key (agent ID)
@@ -141,7 +225,7 @@ func (rmq *RabbitMQMessageBus) SendMessage(ctx context.Context, message *Message
 			MessageId:     message.ID,
 			CorrelationId: message.CorrelationID,
 			Timestamp:     time.Now(),
-			Expiration:    "300000", // 5 minutes TTL
+			Expiration:    messageExpiration(message.TTL),
 			Headers: amqp.Table{
 				"fromAgentId": message.FromID,
 				"messageType": string(message.MessageType),
@@ -149,9 +233,13 @@ func (rmq *RabbitMQMessageBus) SendMessage(ctx context.Context, message *Message
 		},
 	)
 
+	if errors.Is(err, amqp.ErrClosed) {
+		return fmt.Errorf("failed to publish message: %w: %w", ErrBusUnavailable, err)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to publish message: %w", err)
 	}
+	metrics.MessagesSent.WithLabelValues(string(message.MessageType)).Inc()
 
 	rmq.logger.Debug("📨 Message published to agent queue",
 		"message_id", message.ID,
@@ -164,13 +252,16 @@ func (rmq *RabbitMQMessageBus) SendMessage(ctx context.Context, message *Message
 // PrepareAgentQueue ensures queue and routing are set up for an agent without starting consumption
 // This follows Single Responsibility Principle - separates setup from consumption
 func (rmq *RabbitMQMessageBus) PrepareAgentQueue(ctx context.Context, agentID string) error {
-	if rmq.channel == nil {
-		return fmt.Errorf("not connected to RabbitMQ")
+	channel, err := rmq.currentChannel()
+	if err != nil {
+		return err
 	}
 
 	// Declare agent's queue (idempotent - won't fail if already exists)
+	// The arguments must match the durable queues existing deployments already declared, or RabbitMQ
+	// rejects the declaration and closes the channel; messages also carry their own, usually shorter, expiration
 	queueName := fmt.Sprintf("agent.%s", agentID)
-	_, err := rmq.channel.QueueDeclare(
+	_, err = channel.QueueDeclare(
 		queueName, // name
 		true,      // durable
 		false,     // delete when unused
@@ -187,7 +278,7 @@ func (rmq *RabbitMQMessageBus) PrepareAgentQueue(ctx context.Context, agentID st
 	}
 
 	// Bind queue to exchange
-	err = rmq.channel.QueueBind(
+	err = channel.QueueBind(
 		queueName,         // queue name
 		agentID,           // routing key
 		rmq.agentExchange, // exchange
@@ -207,12 +298,13 @@ func (rmq *RabbitMQMessageBus) PrepareAgentQueue(ctx context.Context, agentID st
 
 // Subscribe subscribes an agent to messages (SOLVES RECONNECTION ISSUE)
 func (rmq *RabbitMQMessageBus) Subscribe(ctx context.Context, participantID string) (<-chan *Message, error) {
-	if rmq.channel == nil {
-		return nil, fmt.Errorf("not connected to RabbitMQ")
+	channel, err := rmq.currentChannel()
+	if err != nil {
+		return nil, err
 	}
 
 	// Ensure queue and routing are prepared (idempotent)
-	err := rmq.PrepareAgentQueue(ctx, participantID)
+	err = rmq.PrepareAgentQueue(ctx, participantID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare agent queue: %w", err)
 	}
@@ -228,7 +320,7 @@ func (rmq *RabbitMQMessageBus) Subscribe(ctx context.Context, participantID stri
 	rmq.consumerTags[participantID] = consumerTag
 	rmq.mu.Unlock()
 
-	msgs, err := rmq.channel.Consume(
+	msgs, err := channel.Consume(
 		queueName,   // queue
 		consumerTag, // consumer tag (must be unique per connection)
 		false,       // auto-ack (we'll ack manually)
@@ -246,19 +338,21 @@ func (rmq *RabbitMQMessageBus) Subscribe(ctx context.Context, participantID stri
 	// Convert AMQP messages to our Message type
 	messageChan := make(chan *Message, 100)
 
+	metrics.ActiveSubscriptions.Inc()
 	go func() {
 		defer close(messageChan)
+		defer metrics.ActiveSubscriptions.Dec()
 
 		for {
 			select {
 			case <-ctx.Done():
-				// Graceful shutdown - cancel consumer
-				rmq.channel.Cancel(participantID, false)
+				// Graceful shutdown - cancel consumer on the channel it was started on
+				channel.Cancel(consumerTag, false)
 				return
 
 			case delivery, ok := <-msgs:
 				if !ok {
-					// Channel closed - attempt reconnection handled by RabbitMQ client
+					// Connection dropped; the subscriber renews the subscription once the bus reconnects
 					rmq.logger.Warn("Message channel closed for agent", "agent_id", participantID)
 					return
 				}
@@ -275,6 +369,7 @@ func (rmq *RabbitMQMessageBus) Subscribe(ctx context.Context, participantID stri
 				select {
 				case messageChan <- &message:
 					delivery.Ack(false) // Message successfully delivered
+					metrics.MessagesReceived.WithLabelValues(string(message.MessageType)).Inc()
 				case <-ctx.Done():
 					delivery.Nack(false, true) // Requeue message
 					return
@@ -292,8 +387,9 @@ func (rmq *RabbitMQMessageBus) Subscribe(ctx context.Context, participantID stri
 
 // Unsubscribe removes an agent subscription (PROPER CLEANUP)
 func (rmq *RabbitMQMessageBus) Unsubscribe(ctx context.Context, participantID string) error {
-	if rmq.channel == nil {
-		return fmt.Errorf("not connected to RabbitMQ")
+	channel, err := rmq.currentChannel()
+	if err != nil {
+		return err
 	}
 
 	// Get the consumer tag for this participant
@@ -307,8 +403,7 @@ func (rmq *RabbitMQMessageBus) Unsubscribe(ctx context.Context, participantID st
 	}
 
 	// Cancel the consumer
-	err := rmq.channel.Cancel(consumerTag, false)
-	if err != nil {
+	if err := channel.Cancel(consumerTag, false); err != nil {
 		return fmt.Errorf("failed to cancel consumer: %w", err)
 	}
 
@@ -364,6 +459,9 @@ func (rmq *RabbitMQMessageBus) CreateConversation(ctx context.Context, participa
 
 // Close closes RabbitMQ connection
 func (rmq *RabbitMQMessageBus) Close() error {
+	rmq.mu.RLock()
+	defer rmq.mu.RUnlock()
+
 	if rmq.channel != nil {
 		rmq.channel.Close()
 	}
@@ -375,11 +473,22 @@ func (rmq *RabbitMQMessageBus) Close() error {
 
 // HealthCheck checks RabbitMQ connection health
 func (rmq *RabbitMQMessageBus) HealthCheck() error {
+	rmq.mu.RLock()
+	defer rmq.mu.RUnlock()
+
 	if rmq.conn == nil || rmq.conn.IsClosed() {
-		return fmt.Errorf("RabbitMQ connection closed")
+		return fmt.Errorf("RabbitMQ connection closed: %w", ErrBusUnavailable)
 	}
 	if rmq.channel == nil {
-		return fmt.Errorf("RabbitMQ channel not available")
+		return fmt.Errorf("RabbitMQ channel not available: %w", ErrBusUnavailable)
 	}
 	return nil
 }
+
+// messageExpiration formats a message TTL as the AMQP per-message expiration in milliseconds
+func messageExpiration(ttl time.Duration) string {
+	if ttl <= 0 {
+		ttl = DefaultMessageTTL
+	}
+	return strconv.FormatInt(ttl.Milliseconds(), 10)
+}
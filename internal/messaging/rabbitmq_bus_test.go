@@ -309,3 +309,9 @@ func isRabbitMQAvailable() bool {
 	}
 	return false
 }
+
+// Test that messages expire after their own TTL, defaulting when they carry none
+func TestMessageExpiration(t *testing.T) {
+	assert.Equal(t, "300000", messageExpiration(0))
+	assert.Equal(t, "1800000", messageExpiration(30*time.Minute))
+}
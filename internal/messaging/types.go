@@ -6,30 +6,34 @@ import (
 
 // Message represents a conversational message in the system
 type Message struct {
-	ID            string                 `json:"id"`
-	CorrelationID string                 `json:"correlation_id"`
-	FromID        string                 `json:"from_id"`
-	ToID          string                 `json:"to_id"`
-	Content       string                 `json:"content"`
-	MessageType   MessageType            `json:"message_type"`
-	Metadata      map[string]interface{} `json:"metadata"`
-	Timestamp     time.Time              `json:"timestamp"`
+	ID             string                 `json:"id"`
+	CorrelationID  string                 `json:"correlation_id"`
+	ConversationID string                 `json:"conversation_id,omitempty"`
+	FromID         string                 `json:"from_id"`
+	ToID           string                 `json:"to_id"`
+	Content        string                 `json:"content"`
+	MessageType    MessageType            `json:"message_type"`
+	Metadata       map[string]interface{} `json:"metadata"`
+	Timestamp      time.Time              `json:"timestamp"`
+	TTL            time.Duration          `json:"ttl,omitempty"` // How long the message may wait for its recipient; zero uses the bus default
 }
 
 // MessageType defines the type of message
 type MessageType string
 
 const (
-	MessageTypeRequest       MessageType = "request"
-	MessageTypeResponse      MessageType = "response"
-	MessageTypeClarification MessageType = "clarification"
-	MessageTypeNotification  MessageType = "notification"
-	MessageTypeAgentToAgent  MessageType = "agent_to_agent"
-	MessageTypeAIToAgent     MessageType = "ai_to_agent"
-	MessageTypeAgentToAI     MessageType = "agent_to_ai"
-	MessageTypeCompletion    MessageType = "completion"
-	MessageTypeError         MessageType = "error"
-	MessageTypeInstruction   MessageType = "instruction"
+	MessageTypeRequest        MessageType = "request"
+	MessageTypeResponse       MessageType = "response"
+	MessageTypeClarification  MessageType = "clarification"
+	MessageTypeNotification   MessageType = "notification"
+	MessageTypeAgentToAgent   MessageType = "agent_to_agent"
+	MessageTypeAIToAgent      MessageType = "ai_to_agent"
+	MessageTypeAgentToAI      MessageType = "agent_to_ai"
+	MessageTypeCompletion     MessageType = "completion"
+	MessageTypeError          MessageType = "error"
+	MessageTypeInstruction    MessageType = "instruction"
+	MessageTypePlanCompleted  MessageType = "plan_completed"
+	MessageTypeAgentCompleted MessageType = "agent_completed"
 )
 
 // ConversationContext represents the context of a conversation
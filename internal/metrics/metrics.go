@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes every metric exported by the orchestrator
+const Namespace = "neuromesh"
+
+// Registry holds the orchestrator collectors, kept apart from the global registry so tests can scrape it in isolation
+var Registry = prometheus.NewRegistry()
+
+var (
+	// MessagesSent counts messages published on the message bus by message type
+	MessagesSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "messagebus",
+		Name:      "messages_sent_total",
+		Help:      "Messages published on the message bus, by message type.",
+	}, []string{"message_type"})
+
+	// MessagesReceived counts messages delivered to subscribers by message type
+	MessagesReceived = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "messagebus",
+		Name:      "messages_received_total",
+		Help:      "Messages delivered to message bus subscribers, by message type.",
+	}, []string{"message_type"})
+
+	// ActiveSubscriptions tracks the number of open message bus subscriptions
+	ActiveSubscriptions = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "messagebus",
+		Name:      "active_subscriptions",
+		Help:      "Open message bus subscriptions.",
+	})
+
+	// AICallDuration observes AI provider call latency by model and outcome
+	AICallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "ai",
+		Name:      "call_duration_seconds",
+		Help:      "Latency of AI provider calls, by model and status.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"model", "status"})
+
+	// AgentResponseDuration observes the time between sending an event to an agent and routing its response
+	AgentResponseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "agent",
+		Name:      "response_duration_seconds",
+		Help:      "Time from registering an agent request to routing its response, by agent.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"agent_id"})
+
+	// StreamBackpressure counts agent messages dropped because a conversation stream's incoming buffer was full
+	StreamBackpressure = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "grpc",
+		Name:      "stream_backpressure_total",
+		Help:      "Agent messages dropped from a full conversation stream buffer, by agent.",
+	}, []string{"agent_id"})
+)
+
+func init() {
+	Registry.MustRegister(
+		MessagesSent,
+		MessagesReceived,
+		ActiveSubscriptions,
+		AICallDuration,
+		AgentResponseDuration,
+		StreamBackpressure,
+	)
+}
+
+// Handler serves the orchestrator metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// ObserveAICall records the latency of an AI call started at start
+func ObserveAICall(model string, start time.Time, err error) {
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	AICallDuration.WithLabelValues(model, status).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,58 @@
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	aiInfra "neuromesh/internal/ai/infrastructure"
+	"neuromesh/internal/logging"
+	"neuromesh/internal/messaging"
+	"neuromesh/internal/metrics"
+	orchestratorInfra "neuromesh/internal/orchestrator/infrastructure"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_RecordsSimulatedCalls(t *testing.T) {
+	openAI := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(rw, "data: {\"choices\":[{\"delta\":{\"content\":\"Hello\"}}]}\n\ndata: [DONE]\n\n")
+	}))
+	defer openAI.Close()
+
+	config := aiInfra.DefaultOpenAIConfig()
+	config.APIKey = "test-key"
+	config.BaseURL = openAI.URL
+	config.Model = "metrics-test-model"
+	provider := aiInfra.NewOpenAIProvider(config, logging.NewNoOpLogger())
+
+	_, err := provider.CallAI(context.Background(), "system", "user")
+	require.NoError(t, err)
+
+	tracker := orchestratorInfra.NewCorrelationTracker()
+	tracker.RegisterAgentRequest(&messaging.AIToAgentMessage{
+		AgentID:       "metrics-test-agent",
+		CorrelationID: "corr-metrics-1",
+	}, "user-1", time.Minute)
+	require.True(t, tracker.RouteResponse(&messaging.AgentToAIMessage{CorrelationID: "corr-metrics-1"}))
+
+	sentBefore := testutil.ToFloat64(metrics.MessagesSent.WithLabelValues("instruction"))
+	metrics.MessagesSent.WithLabelValues("instruction").Inc()
+	assert.Equal(t, sentBefore+1, testutil.ToFloat64(metrics.MessagesSent.WithLabelValues("instruction")))
+
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `neuromesh_ai_call_duration_seconds_count{model="metrics-test-model",status="success"} 1`)
+	assert.Contains(t, body, `neuromesh_agent_response_duration_seconds_count{agent_id="metrics-test-agent"} 1`)
+	assert.Contains(t, body, `neuromesh_messagebus_messages_sent_total{message_type="instruction"}`)
+	assert.Contains(t, body, "neuromesh_messagebus_active_subscriptions")
+}
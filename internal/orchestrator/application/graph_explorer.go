@@ -16,9 +16,15 @@ type AgentService interface {
 	UpdateAgentStatus(ctx context.Context, agentID string, status domain.AgentStatus) error
 }
 
+// AgentContextBuilder renders the agent context string from the agent registry
+type AgentContextBuilder interface {
+	BuildAgentContext(ctx context.Context) (string, error)
+}
+
 // GraphExplorer handles agent discovery and context formatting for AI consumption
 type GraphExplorer struct {
-	agentService AgentService
+	agentService        AgentService
+	agentContextBuilder AgentContextBuilder
 }
 
 // NewGraphExplorer creates a new GraphExplorer instance
@@ -28,9 +34,21 @@ func NewGraphExplorer(agentService AgentService) *GraphExplorer {
 	}
 }
 
+// NewGraphExplorerWithContextBuilder creates a new GraphExplorer that renders agent context from the registry
+func NewGraphExplorerWithContextBuilder(agentService AgentService, agentContextBuilder AgentContextBuilder) *GraphExplorer {
+	return &GraphExplorer{
+		agentService:        agentService,
+		agentContextBuilder: agentContextBuilder,
+	}
+}
+
 // GetAgentContext retrieves all available agents and formats them for AI consumption
 // Replaces the getAllAgents() functionality from the old orchestrator
 func (g *GraphExplorer) GetAgentContext(ctx context.Context) (string, error) {
+	if g.agentContextBuilder != nil {
+		return g.agentContextBuilder.BuildAgentContext(ctx)
+	}
+
 	agents, err := g.agentService.GetAvailableAgents(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to get available agents: %w", err)
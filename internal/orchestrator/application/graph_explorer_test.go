@@ -87,6 +87,27 @@ func TestGraphExplorer_GetAgentContext(t *testing.T) {
 	})
 }
 
+// stubAgentContextBuilder returns a fixed agent context
+type stubAgentContextBuilder struct {
+	agentContext string
+}
+
+func (s *stubAgentContextBuilder) BuildAgentContext(ctx context.Context) (string, error) {
+	return s.agentContext, nil
+}
+
+func TestGraphExplorer_GetAgentContext_WithContextBuilder(t *testing.T) {
+	mockAgentService := &MockAgentService{}
+	builder := &stubAgentContextBuilder{agentContext: "Available Agents:\n- text-processor | Status: online | Capabilities: word-count\n"}
+	explorer := NewGraphExplorerWithContextBuilder(mockAgentService, builder)
+
+	agentContext, err := explorer.GetAgentContext(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, builder.agentContext, agentContext)
+	mockAgentService.AssertNotCalled(t, "GetAvailableAgents", mock.Anything)
+}
+
 func TestGraphExplorer_FindCapableAgents(t *testing.T) {
 	t.Run("should find agents with specific capabilities", func(t *testing.T) {
 		mockAgentService := &MockAgentService{}
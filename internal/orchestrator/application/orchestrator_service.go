@@ -2,12 +2,18 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
+	aiDomain "neuromesh/internal/ai/domain"
+	executionDomain "neuromesh/internal/execution/domain"
+	learningDomain "neuromesh/internal/learning/domain"
 	"neuromesh/internal/logging"
 	orchestratorDomain "neuromesh/internal/orchestrator/domain"
 	planningDomain "neuromesh/internal/planning/domain"
+
+	"github.com/google/uuid"
 )
 
 // AIDecisionEngineInterface defines the interface for AI decision making
@@ -23,7 +29,7 @@ type GraphExplorerInterface interface {
 
 // AIExecutionEngineInterface defines the interface for AI-native execution orchestration
 type AIExecutionEngineInterface interface {
-	ExecuteWithAgents(ctx context.Context, executionPlan, userInput, userID, agentContext string) (string, error)
+	ExecuteWithAgents(ctx context.Context, planID, userInput, userID, agentContext string) (string, error)
 }
 
 // AIConversationEngineInterface defines the interface for AI-native conversation orchestration
@@ -31,11 +37,14 @@ type AIConversationEngineInterface interface {
 	ProcessWithAgents(ctx context.Context, userInput, userID, agentContext string) (string, error)
 }
 
-// LearningServiceInterface defines the interface for learning service
-type LearningServiceInterface interface {
-	StoreInsights(ctx context.Context, userRequest string, analysis *planningDomain.Analysis, decision *orchestratorDomain.Decision) error
-	AnalyzePatterns(ctx context.Context, sessionID string) (*orchestratorDomain.ConversationPattern, error)
-}
+var (
+	// ErrNoExecutionPlan is returned by PreviewPlan when the AI decides not to execute the request
+	ErrNoExecutionPlan = errors.New("no execution plan generated")
+	// ErrPlanNotAwaitingApproval is returned when approving or rejecting a plan that is not pending approval
+	ErrPlanNotAwaitingApproval = errors.New("plan is not awaiting approval")
+	// ErrPlanAccessDenied is returned when a user reviews a plan generated for another user's request
+	ErrPlanAccessDenied = planningDomain.ErrPlanAccessDenied
+)
 
 // OrchestratorService represents the clean AI orchestrator service implementation
 // This replaces the old ProcessRequest() functionality with clean architecture
@@ -43,6 +52,7 @@ type OrchestratorService struct {
 	aiDecisionEngine  AIDecisionEngineInterface
 	graphExplorer     GraphExplorerInterface
 	aiExecutionEngine AIExecutionEngineInterface
+	executionPlanRepo planningDomain.ExecutionPlanRepository
 	logger            logging.Logger
 }
 
@@ -61,12 +71,30 @@ func NewOrchestratorService(
 	}
 }
 
+// SetExecutionPlanRepository enables PreviewPlan, which loads the plan persisted by the decision engine
+func (ors *OrchestratorService) SetExecutionPlanRepository(executionPlanRepo planningDomain.ExecutionPlanRepository) {
+	ors.executionPlanRepo = executionPlanRepo
+}
+
 // OrchestratorRequest represents a user request to the orchestrator
 type OrchestratorRequest struct {
 	UserInput string `json:"user_input"`
 	UserID    string `json:"user_id"`
 	SessionID string `json:"session_id,omitempty"`
 	MessageID string `json:"message_id,omitempty"` // ID of the user message that triggered this request
+	// CorrelationID ties together all log lines of one request; defaults to MessageID or a generated ID
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
+
+// correlationID returns the request's correlation ID, generating one if none was supplied
+func (r *OrchestratorRequest) correlationID() string {
+	if r.CorrelationID != "" {
+		return r.CorrelationID
+	}
+	if r.MessageID != "" {
+		return r.MessageID
+	}
+	return uuid.New().String()
 }
 
 // OrchestratorResult represents the orchestrator's response
@@ -77,11 +105,20 @@ type OrchestratorResult struct {
 	ExecutionPlanID string                       `json:"execution_plan_id,omitempty"`
 	Success         bool                         `json:"success"`
 	Error           string                       `json:"error,omitempty"`
+	// Outcome describes how the request turned out; callers record it once the decision is persisted
+	Outcome *learningDomain.Outcome `json:"-"`
 }
 
 // ProcessUserRequest is the main entry point that replaces the old ProcessRequest()
 // This follows the clean architecture pattern with proper domain boundaries
 func (ors *OrchestratorService) ProcessUserRequest(ctx context.Context, request *OrchestratorRequest) (*OrchestratorResult, error) {
+	// Every log line for this request carries the same correlation ID
+	logger := ors.logger.With("correlation_id", request.correlationID())
+
+	// Token usage of every AI call made while executing this request
+	usageRecorder := aiDomain.NewUsageRecorder()
+	ctx = aiDomain.WithUsageRecorder(ctx, usageRecorder)
+
 	// 1. Get agent context for AI decision making
 	agentContext, err := ors.graphExplorer.GetAgentContext(ctx)
 	if err != nil {
@@ -108,6 +145,8 @@ func (ors *OrchestratorService) ProcessUserRequest(ctx context.Context, request
 		}, nil
 	}
 
+	executionDomain.ReportProgress(ctx, executionDomain.NewProgressEvent(executionDomain.ProgressEventDecisionMade, "", fmt.Sprintf("%s: %s", decision.Type, decision.Reasoning)))
+
 	result := &OrchestratorResult{
 		Analysis: analysis,
 		Decision: decision,
@@ -116,56 +155,233 @@ func (ors *OrchestratorService) ProcessUserRequest(ctx context.Context, request
 
 	// 3. Handle decision based on type
 	if decision.Type == orchestratorDomain.DecisionTypeClarify {
-		ors.logger.Info("🤔 Decision type: Clarify")
+		logger.Info("🤔 Decision type: Clarify")
 		result.Message = decision.ClarificationQuestion
+	} else if decision.Type == orchestratorDomain.DecisionTypeReject {
+		logger.Info("🚫 Decision type: Reject", "reason", decision.RejectionReason)
+		result.Message = decision.RejectionMessage()
 	} else if decision.Type == orchestratorDomain.DecisionTypeExecute {
-		ors.logger.Info("🚀 Decision type: Execute", "requiredAgents", len(analysis.RequiredAgents))
+		logger.Info("🚀 Decision type: Execute", "requiredAgents", len(analysis.RequiredAgents))
 
 		// Check if this is a meta-query that should be handled with AI orchestrator knowledge
 		if ors.isOrchestratorMetaQuery(request.UserInput) {
-			ors.logger.Info("🏛️ Meta-query detected, using AI to provide intelligent system insights")
+			logger.Info("🏛️ Meta-query detected, using AI to provide intelligent system insights")
 			// Use AI conversation engine with orchestrator context for dynamic, intelligent responses
 			result.Message = ors.handleMetaQuery(ctx, request.UserInput, agentContext)
+		} else if plan := ors.planAwaitingApproval(ctx, decision.ExecutionPlanID); plan != nil {
+			// Nothing is dispatched until a user approves the plan through ApprovePlan
+			logger.Info("⏸️ Execution plan awaits approval", "plan_id", plan.ID, "steps", len(plan.Steps))
+			result.ExecutionPlanID = plan.ID
+			result.Message = fmt.Sprintf("I've prepared an execution plan with %d steps. It will run once it is approved (plan %s).", len(plan.Steps), plan.ID)
 		} else if len(analysis.RequiredAgents) > 0 {
 			// AI-native execution: Use dedicated execution engine for agent coordination
-			ors.logger.Info("🚀 Using AI execution engine with agents", "agents", analysis.RequiredAgents)
-
-			// For now, use ExecutionPlanID as the plan text (backward compatibility)
-			// TODO: In future iterations, retrieve structured plan and convert to execution steps
-			executionPlan := decision.ExecutionPlanID
-			if executionPlan == "" {
-				executionPlan = "No execution plan available"
-			}
+			logger.Info("🚀 Using AI execution engine with agents", "agents", analysis.RequiredAgents)
 
-			// Use injected AI execution engine for agent coordination
-			executionResult, err := ors.aiExecutionEngine.ExecuteWithAgents(ctx, executionPlan, request.UserInput, request.UserID, agentContext)
+			// Requests without a persisted plan execute with an empty plan ID, so they share no plan tracking
+			executionResult, err := ors.aiExecutionEngine.ExecuteWithAgents(ctx, decision.ExecutionPlanID, request.UserInput, request.UserID, agentContext)
 			if err != nil {
-				ors.logger.Error("❌ AI-native execution failed", err)
+				logger.Error("❌ AI-native execution failed", err)
 				result.Success = false
 				result.Error = fmt.Sprintf("AI-native execution failed: %v", err)
 			} else {
-				ors.logger.Info("✅ AI execution engine result", "executionResult", executionResult)
+				logger.Info("✅ AI execution engine result", "executionResult", executionResult)
 				result.Message = executionResult
 			}
 		} else {
-			ors.logger.Info("📝 No agents required, using execution plan")
+			logger.Info("📝 No agents required, using execution plan")
 			result.Message = decision.ExecutionPlanID
 		}
 	} else {
-		ors.logger.Warn("❓ Unknown decision type", "type", decision.Type)
+		logger.Warn("❓ Unknown decision type", "type", decision.Type)
 	}
 
-	ors.logger.Info("✅ Final result", "success", result.Success, "message", result.Message, "error", result.Error)
+	usage := usageRecorder.Total()
+	if decision.Parameters == nil {
+		decision.Parameters = make(map[string]interface{})
+	}
+	decision.Parameters[orchestratorDomain.ParameterTokenUsage] = usage
+
+	logger.Info("✅ Final result", "success", result.Success, "message", result.Message, "error", result.Error, "total_tokens", usage.TotalTokens)
+	if result.Success {
+		executionDomain.ReportProgress(ctx, executionDomain.NewProgressEvent(executionDomain.ProgressEventFinalAnswer, "", result.Message))
+	}
 
-	// 4. Learning service removed for now (following YAGNI principles)
-	// err = ors.learningService.StoreInsights(ctx, request.UserInput, analysis, decision)
-	// if err != nil {
-	//	ors.logger.Warn("Failed to store learning insights", "error", err)
-	// }
+	// 4. Describe how the request turned out; the outcome is linked to the decision, so callers record it after the decision
+	outcome := newOutcome(request, analysis, decision, result)
+	result.Outcome = &outcome
 
 	return result, nil
 }
 
+// newOutcome describes the result of a handled request for the learning service
+func newOutcome(request *OrchestratorRequest, analysis *planningDomain.Analysis, decision *orchestratorDomain.Decision, result *OrchestratorResult) learningDomain.Outcome {
+	outcome := learningDomain.Outcome{
+		UserInput:       request.UserInput,
+		Intent:          analysis.Intent,
+		Category:        analysis.Category,
+		DecisionType:    string(decision.Type),
+		ExecutionPlanID: decision.ExecutionPlanID,
+		Status:          learningDomain.OutcomeStatusSuccess,
+	}
+	if !result.Success {
+		outcome.Status = learningDomain.OutcomeStatusFailure
+		outcome.Error = result.Error
+	}
+	return outcome
+}
+
+// ProcessUserRequestWithProgress processes a user request and reports orchestration progress to onProgress as it happens
+func (ors *OrchestratorService) ProcessUserRequestWithProgress(ctx context.Context, request *OrchestratorRequest, onProgress executionDomain.ProgressReporter) (*OrchestratorResult, error) {
+	return ors.ProcessUserRequest(executionDomain.WithProgressReporter(ctx, onProgress), request)
+}
+
+// PreviewPlan runs analysis and decision-making for a request and returns the resulting draft plan
+// No agent events are dispatched; requests the AI would clarify or reject return ErrNoExecutionPlan
+func (ors *OrchestratorService) PreviewPlan(ctx context.Context, userInput, userID string) (*planningDomain.ExecutionPlan, error) {
+	if ors.executionPlanRepo == nil {
+		return nil, fmt.Errorf("plan preview requires an execution plan repository")
+	}
+
+	agentContext, err := ors.graphExplorer.GetAgentContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent context: %w", err)
+	}
+
+	requestID := uuid.New().String()
+	analysis, err := ors.aiDecisionEngine.ExploreAndAnalyze(ctx, userInput, userID, agentContext, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze request: %w", err)
+	}
+
+	decision, err := ors.aiDecisionEngine.MakeDecision(ctx, userInput, userID, analysis, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make decision: %w", err)
+	}
+
+	switch {
+	case decision.Type == orchestratorDomain.DecisionTypeClarify:
+		return nil, fmt.Errorf("%w: clarification needed: %s", ErrNoExecutionPlan, decision.ClarificationQuestion)
+	case decision.Type == orchestratorDomain.DecisionTypeReject:
+		return nil, fmt.Errorf("%w: %s", ErrNoExecutionPlan, decision.RejectionMessage())
+	case decision.ExecutionPlanID == "":
+		return nil, fmt.Errorf("%w: decision %s has no plan", ErrNoExecutionPlan, decision.ID)
+	}
+
+	plan, err := ors.executionPlanRepo.GetByID(ctx, decision.ExecutionPlanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load execution plan %s: %w", decision.ExecutionPlanID, err)
+	}
+
+	ors.logger.Info("📋 Execution plan previewed", "plan_id", plan.ID, "steps", len(plan.Steps), "status", plan.Status)
+	return plan, nil
+}
+
+// ApprovePlan approves a plan awaiting approval on behalf of approverUserID and starts executing it in the background
+// Only the user the plan was generated for may approve it, and concurrent approvals start the plan at most once
+// The returned result acknowledges the approval; the plan's progress reports how the execution goes
+func (ors *OrchestratorService) ApprovePlan(ctx context.Context, planID, approverUserID string) (*OrchestratorResult, error) {
+	plan, err := ors.getPlanForReview(ctx, planID, approverUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := plan.ApproveBy(approverUserID); err != nil {
+		return nil, fmt.Errorf("failed to approve plan %s: %w", planID, err)
+	}
+	if err := ors.transitionReviewedPlan(ctx, plan); err != nil {
+		return nil, fmt.Errorf("failed to persist approval of plan %s: %w", planID, err)
+	}
+	ors.logger.Info("✅ Execution plan approved", "plan_id", planID, "approver", approverUserID)
+
+	// The reply is built before the execution starts working on the plan
+	result := &OrchestratorResult{
+		ExecutionPlanID: plan.ID,
+		Success:         true,
+		Message:         fmt.Sprintf("Plan %s approved; its %d steps are executing.", plan.ID, len(plan.Steps)),
+	}
+
+	// The execution outlives the approval request
+	go ors.executeApprovedPlan(context.WithoutCancel(ctx), plan, approverUserID)
+
+	return result, nil
+}
+
+// executeApprovedPlan runs an approved plan for the request it was generated from
+func (ors *OrchestratorService) executeApprovedPlan(ctx context.Context, plan *planningDomain.ExecutionPlan, userID string) {
+	agentContext, err := ors.graphExplorer.GetAgentContext(ctx)
+	if err != nil {
+		ors.logger.Error("❌ Failed to get agent context for approved plan", err, "plan_id", plan.ID)
+		return
+	}
+
+	executionResult, err := ors.aiExecutionEngine.ExecuteWithAgents(ctx, plan.ID, plan.Request, userID, agentContext)
+	if err != nil {
+		ors.logger.Error("❌ Approved plan execution failed", err, "plan_id", plan.ID)
+		return
+	}
+	ors.logger.Info("✅ Approved plan executed", "plan_id", plan.ID, "executionResult", executionResult)
+}
+
+// RejectPlan rejects a plan awaiting approval on behalf of approverUserID, so it never executes
+func (ors *OrchestratorService) RejectPlan(ctx context.Context, planID, approverUserID, reason string) error {
+	plan, err := ors.getPlanForReview(ctx, planID, approverUserID)
+	if err != nil {
+		return err
+	}
+	if err := plan.Reject(approverUserID, reason); err != nil {
+		return fmt.Errorf("failed to reject plan %s: %w", planID, err)
+	}
+	if err := ors.transitionReviewedPlan(ctx, plan); err != nil {
+		return fmt.Errorf("failed to persist rejection of plan %s: %w", planID, err)
+	}
+	ors.logger.Info("🚫 Execution plan rejected", "plan_id", planID, "approver", approverUserID, "reason", reason)
+	return nil
+}
+
+// getPlanForReview loads a plan the user may review
+// It returns ErrPlanAccessDenied for another user's plan and ErrPlanNotAwaitingApproval unless it is pending approval
+func (ors *OrchestratorService) getPlanForReview(ctx context.Context, planID, userID string) (*planningDomain.ExecutionPlan, error) {
+	if ors.executionPlanRepo == nil {
+		return nil, fmt.Errorf("plan approval requires an execution plan repository")
+	}
+
+	plan, err := ors.executionPlanRepo.GetByID(ctx, planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load execution plan %s: %w", planID, err)
+	}
+	if !plan.OwnedBy(userID) {
+		return nil, fmt.Errorf("%w: plan %s", ErrPlanAccessDenied, planID)
+	}
+	if !plan.AwaitsApproval() {
+		return nil, fmt.Errorf("%w: plan %s is %s", ErrPlanNotAwaitingApproval, planID, plan.Status)
+	}
+	return plan, nil
+}
+
+// transitionReviewedPlan persists a reviewed plan only if it is still pending approval
+// A concurrent review that got there first yields ErrPlanNotAwaitingApproval
+func (ors *OrchestratorService) transitionReviewedPlan(ctx context.Context, plan *planningDomain.ExecutionPlan) error {
+	updated, err := ors.executionPlanRepo.UpdateIfStatus(ctx, plan, planningDomain.ExecutionPlanStatusPendingApproval)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return fmt.Errorf("%w: plan %s was reviewed concurrently", ErrPlanNotAwaitingApproval, plan.ID)
+	}
+	return nil
+}
+
+// planAwaitingApproval returns the decision's plan if it must be approved before executing, or nil otherwise
+func (ors *OrchestratorService) planAwaitingApproval(ctx context.Context, planID string) *planningDomain.ExecutionPlan {
+	if ors.executionPlanRepo == nil || planID == "" {
+		return nil
+	}
+	plan, err := ors.executionPlanRepo.GetByID(ctx, planID)
+	if err != nil || !plan.AwaitsApproval() {
+		return nil
+	}
+	return plan
+}
+
 // NOTE: ProcessConversation and AnalyzeConversationPatterns methods removed
 // Following YAGNI principles - we're not implementing these features yet
 
@@ -1,17 +1,29 @@
 package application
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	aiInfrastructure "neuromesh/internal/ai/infrastructure"
+	executionApp "neuromesh/internal/execution/application"
+	learningDomain "neuromesh/internal/learning/domain"
 	"neuromesh/internal/logging"
+	"neuromesh/internal/messaging"
 	orchestratorDomain "neuromesh/internal/orchestrator/domain"
+	"neuromesh/internal/orchestrator/infrastructure"
 	planningApplication "neuromesh/internal/planning/application"
+	planningDomain "neuromesh/internal/planning/domain"
+	"neuromesh/testHelpers"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // Mock implementations for testing (but we'll use real AI provider)
@@ -33,6 +45,20 @@ func (m *MockAIExecutionEngine) ExecuteWithAgents(ctx context.Context, execution
 	return args.String(0), args.Error(1)
 }
 
+type MockAIDecisionEngine struct {
+	mock.Mock
+}
+
+func (m *MockAIDecisionEngine) ExploreAndAnalyze(ctx context.Context, userInput, userID, agentContext, requestID string) (*planningDomain.Analysis, error) {
+	args := m.Called(ctx, userInput, userID, agentContext, requestID)
+	return args.Get(0).(*planningDomain.Analysis), args.Error(1)
+}
+
+func (m *MockAIDecisionEngine) MakeDecision(ctx context.Context, userInput, userID string, analysis *planningDomain.Analysis, requestID string) (*orchestratorDomain.Decision, error) {
+	args := m.Called(ctx, userInput, userID, analysis, requestID)
+	return args.Get(0).(*orchestratorDomain.Decision), args.Error(1)
+}
+
 // setupRealAIProvider creates a real OpenAI provider for testing
 func setupRealAIProviderForOrchestrator(t *testing.T) *aiInfrastructure.OpenAIProvider {
 	apiKey := os.Getenv("OPENAI_API_KEY")
@@ -173,3 +199,308 @@ func TestOrchestratorService_ProcessUserRequest(t *testing.T) {
 		mockExplorer.AssertExpectations(t)
 	})
 }
+
+func TestOrchestratorService_ProcessUserRequest_LogsCorrelationID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewStructuredLoggerWithWriter(logging.LevelDebug, logging.FormatJSON, &buf)
+
+	mockDecisionEngine := &MockAIDecisionEngine{}
+	mockExplorer := &MockGraphExplorer{}
+	service := NewOrchestratorService(mockDecisionEngine, mockExplorer, &MockAIExecutionEngine{}, logger)
+
+	analysis := planningDomain.NewAnalysis("msg-42", "deploy", "deployment", 40, nil, "unclear target")
+	decision := orchestratorDomain.NewClarifyDecision("msg-42", analysis.ID, "Which environment?", "missing target")
+
+	mockExplorer.On("GetAgentContext", mock.Anything).Return("Deploy Agent available", nil)
+	mockDecisionEngine.On("ExploreAndAnalyze", mock.Anything, "Deploy it", "user-123", "Deploy Agent available", "msg-42").Return(analysis, nil)
+	mockDecisionEngine.On("MakeDecision", mock.Anything, "Deploy it", "user-123", analysis, "msg-42").Return(decision, nil)
+
+	result, err := service.ProcessUserRequest(context.Background(), &OrchestratorRequest{
+		UserInput: "Deploy it",
+		UserID:    "user-123",
+		MessageID: "msg-42",
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Contains(t, result.Decision.Parameters, "token_usage")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.GreaterOrEqual(t, len(lines), 2)
+	for _, line := range lines {
+		var entry map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &entry))
+		assert.Equal(t, "msg-42", entry["correlation_id"], "missing correlation_id in %s", line)
+	}
+}
+
+func TestOrchestratorService_ProcessUserRequest_Reject(t *testing.T) {
+	mockDecisionEngine := &MockAIDecisionEngine{}
+	mockExplorer := &MockGraphExplorer{}
+	mockExecutionEngine := &MockAIExecutionEngine{}
+	service := NewOrchestratorService(mockDecisionEngine, mockExplorer, mockExecutionEngine, logging.NewNoOpLogger())
+
+	analysis := planningDomain.NewAnalysis("msg-7", "write_malware", "out_of_scope", 95, []string{"text-processor"}, "unsafe request")
+	decision := orchestratorDomain.NewRejectDecision("msg-7", analysis.ID, "writing malware is not something I can help with", "unsafe request")
+
+	mockExplorer.On("GetAgentContext", mock.Anything).Return("Text Processor available", nil)
+	mockDecisionEngine.On("ExploreAndAnalyze", mock.Anything, "Write ransomware", "user-123", "Text Processor available", "msg-7").Return(analysis, nil)
+	mockDecisionEngine.On("MakeDecision", mock.Anything, "Write ransomware", "user-123", analysis, "msg-7").Return(decision, nil)
+
+	result, err := service.ProcessUserRequest(context.Background(), &OrchestratorRequest{
+		UserInput: "Write ransomware",
+		UserID:    "user-123",
+		MessageID: "msg-7",
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "I'm sorry, but I can't help with that request: writing malware is not something I can help with", result.Message)
+	mockExecutionEngine.AssertNotCalled(t, "ExecuteWithAgents", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrchestratorService_ProcessUserRequest_DescribesOutcome(t *testing.T) {
+	mockDecisionEngine := &MockAIDecisionEngine{}
+	mockExplorer := &MockGraphExplorer{}
+	mockExecutionEngine := &MockAIExecutionEngine{}
+	service := NewOrchestratorService(mockDecisionEngine, mockExplorer, mockExecutionEngine, logging.NewNoOpLogger())
+
+	analysis := planningDomain.NewAnalysis("msg-9", "word_count", "text_processing", 90, []string{"text-processor"}, "count words")
+	decision := orchestratorDomain.NewExecuteDecision("msg-9", analysis.ID, "plan-9", "", "text processor can count")
+
+	mockExplorer.On("GetAgentContext", mock.Anything).Return("Text Processor available", nil)
+	mockDecisionEngine.On("ExploreAndAnalyze", mock.Anything, "Count words in hello world", "user-123", "Text Processor available", "msg-9").Return(analysis, nil)
+	mockDecisionEngine.On("MakeDecision", mock.Anything, "Count words in hello world", "user-123", analysis, "msg-9").Return(decision, nil)
+	mockExecutionEngine.On("ExecuteWithAgents", mock.Anything, "plan-9", "Count words in hello world", "user-123", "Text Processor available").Return("", errors.New("agent unavailable"))
+
+	result, err := service.ProcessUserRequest(context.Background(), &OrchestratorRequest{
+		UserInput: "Count words in hello world",
+		UserID:    "user-123",
+		MessageID: "msg-9",
+	})
+
+	// The outcome is left for the caller to record once the decision is persisted
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, &learningDomain.Outcome{
+		UserInput:       "Count words in hello world",
+		Intent:          "word_count",
+		Category:        "text_processing",
+		DecisionType:    "EXECUTE",
+		ExecutionPlanID: "plan-9",
+		Status:          learningDomain.OutcomeStatusFailure,
+		Error:           "AI-native execution failed: agent unavailable",
+	}, result.Outcome)
+}
+
+func TestOrchestratorService_ProcessUserRequest_ExecutesWithoutPlanID(t *testing.T) {
+	mockDecisionEngine := &MockAIDecisionEngine{}
+	mockExplorer := &MockGraphExplorer{}
+	mockExecutionEngine := &MockAIExecutionEngine{}
+	service := NewOrchestratorService(mockDecisionEngine, mockExplorer, mockExecutionEngine, logging.NewNoOpLogger())
+
+	analysis := planningDomain.NewAnalysis("msg-10", "word_count", "text_processing", 90, []string{"text-processor"}, "count words")
+	decision := orchestratorDomain.NewExecuteDecision("msg-10", analysis.ID, "", "", "text processor can count")
+
+	mockExplorer.On("GetAgentContext", mock.Anything).Return("Text Processor available", nil)
+	mockDecisionEngine.On("ExploreAndAnalyze", mock.Anything, "Count words in hello world", "user-123", "Text Processor available", "msg-10").Return(analysis, nil)
+	mockDecisionEngine.On("MakeDecision", mock.Anything, "Count words in hello world", "user-123", analysis, "msg-10").Return(decision, nil)
+	mockExecutionEngine.On("ExecuteWithAgents", mock.Anything, "", "Count words in hello world", "user-123", "Text Processor available").Return("2 words", nil)
+
+	result, err := service.ProcessUserRequest(context.Background(), &OrchestratorRequest{
+		UserInput: "Count words in hello world",
+		UserID:    "user-123",
+		MessageID: "msg-10",
+	})
+
+	// Requests without a plan must not share a placeholder plan ID
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	mockExecutionEngine.AssertExpectations(t)
+}
+
+func TestOrchestratorService_PreviewPlan(t *testing.T) {
+	ctx := context.Background()
+
+	newService := func(decision *orchestratorDomain.Decision) (*OrchestratorService, *testHelpers.MockAIMessageBus, planningDomain.ExecutionPlanRepository) {
+		planRepo := testHelpers.NewMockExecutionPlanRepository()
+		aiMessageBus := testHelpers.NewMockAIMessageBus()
+		executionEngine := executionApp.NewAIExecutionEngineWithRepository(aiInfrastructure.NewScriptedProvider(nil), aiMessageBus, infrastructure.NewCorrelationTracker(), planRepo)
+
+		analysis := planningDomain.NewAnalysis("", "count_words", "text_processing", 90, []string{"text-processor"}, "word count requested")
+		mockDecisionEngine := &MockAIDecisionEngine{}
+		mockDecisionEngine.On("ExploreAndAnalyze", mock.Anything, "Count words in hello world", "user-123", "Text Processor available", mock.Anything).Return(analysis, nil)
+		mockDecisionEngine.On("MakeDecision", mock.Anything, "Count words in hello world", "user-123", analysis, mock.Anything).Return(decision, nil)
+		mockExplorer := &MockGraphExplorer{}
+		mockExplorer.On("GetAgentContext", mock.Anything).Return("Text Processor available", nil)
+
+		service := NewOrchestratorService(mockDecisionEngine, mockExplorer, executionEngine, logging.NewNoOpLogger())
+		service.SetExecutionPlanRepository(planRepo)
+		return service, aiMessageBus, planRepo
+	}
+
+	t.Run("returns the draft plan without dispatching agents", func(t *testing.T) {
+		plan := planningDomain.NewExecutionPlan("Count words", "Count the words of a text", planningDomain.ExecutionPlanPriorityMedium)
+		plan.AddStep(planningDomain.NewExecutionStep("Count words", "Count words in text", "text-processor"))
+		service, aiMessageBus, planRepo := newService(orchestratorDomain.NewExecuteDecision("", "analysis-1", plan.ID, "text-processor", "word count requested"))
+		require.NoError(t, planRepo.Create(ctx, plan))
+
+		preview, err := service.PreviewPlan(ctx, "Count words in hello world", "user-123")
+
+		require.NoError(t, err)
+		assert.Equal(t, plan.ID, preview.ID)
+		assert.Equal(t, planningDomain.ExecutionPlanStatusDraft, preview.Status)
+		assert.Len(t, preview.Steps, 1)
+		aiMessageBus.AssertNotCalled(t, "SendToAgent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns ErrNoExecutionPlan when clarification is needed", func(t *testing.T) {
+		service, aiMessageBus, _ := newService(orchestratorDomain.NewClarifyDecision("", "analysis-1", "Which text?", "missing text"))
+
+		preview, err := service.PreviewPlan(ctx, "Count words in hello world", "user-123")
+
+		assert.Nil(t, preview)
+		assert.True(t, errors.Is(err, ErrNoExecutionPlan))
+		assert.Contains(t, err.Error(), "Which text?")
+		aiMessageBus.AssertNotCalled(t, "SendToAgent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrchestratorService_PlanApproval(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// newService returns a service whose decision is to execute a plan that awaits approval
+	newService := func(t *testing.T) (*OrchestratorService, *testHelpers.MockAIMessageBus, planningDomain.ExecutionPlanRepository, *planningDomain.ExecutionPlan) {
+		plan := planningDomain.NewExecutionPlan("Count words", "Count the words of a text", planningDomain.ExecutionPlanPriorityMedium)
+		plan.AddStep(planningDomain.NewExecutionStep("Count words", "Count words in text", "text-processor"))
+		plan.Request = "Count words in hello world"
+		plan.UserID = "user-123"
+		require.NoError(t, plan.RequireApproval())
+		planRepo := testHelpers.NewMockExecutionPlanRepository()
+		require.NoError(t, planRepo.Create(ctx, plan))
+
+		aiProvider := aiInfrastructure.NewScriptedProvider(map[string]string{
+			"Execute plan for user request": "SEND_EVENT:\nAgent: text-processor\nAction: word-count\nContent: hello world\nIntent: analysis\nStep: " + plan.Steps[0].ID,
+			"Process the agent response":    "USER_RESPONSE:\nThe text contains 2 words.",
+		})
+		aiMessageBus := testHelpers.NewMockAIMessageBus()
+		executionEngine := executionApp.NewAIExecutionEngineWithRepository(aiProvider, aiMessageBus, infrastructure.NewCorrelationTracker(), planRepo)
+
+		analysis := planningDomain.NewAnalysis("", "count_words", "text_processing", 90, []string{"text-processor"}, "word count requested")
+		mockDecisionEngine := &MockAIDecisionEngine{}
+		mockDecisionEngine.On("ExploreAndAnalyze", mock.Anything, "Count words in hello world", "user-123", "Text Processor available", mock.Anything).Return(analysis, nil)
+		mockDecisionEngine.On("MakeDecision", mock.Anything, "Count words in hello world", "user-123", analysis, mock.Anything).
+			Return(orchestratorDomain.NewExecuteDecision("", "analysis-1", plan.ID, "text-processor", "word count requested"), nil)
+		mockExplorer := &MockGraphExplorer{}
+		mockExplorer.On("GetAgentContext", mock.Anything).Return("Text Processor available", nil)
+
+		service := NewOrchestratorService(mockDecisionEngine, mockExplorer, executionEngine, logging.NewNoOpLogger())
+		service.SetExecutionPlanRepository(planRepo)
+		return service, aiMessageBus, planRepo, plan
+	}
+
+	request := &OrchestratorRequest{UserInput: "Count words in hello world", UserID: "user-123", MessageID: "msg-1"}
+
+	t.Run("unapproved plan is not dispatched", func(t *testing.T) {
+		service, aiMessageBus, planRepo, plan := newService(t)
+
+		result, err := service.ProcessUserRequest(ctx, request)
+
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Equal(t, plan.ID, result.ExecutionPlanID)
+		assert.Contains(t, result.Message, "approved")
+		aiMessageBus.AssertNotCalled(t, "SendToAgent", mock.Anything, mock.Anything)
+
+		stored, err := planRepo.GetByID(ctx, plan.ID)
+		require.NoError(t, err)
+		assert.Equal(t, planningDomain.ExecutionPlanStatusPendingApproval, stored.Status)
+	})
+
+	t.Run("approved plan is dispatched", func(t *testing.T) {
+		service, aiMessageBus, planRepo, plan := newService(t)
+		_, err := service.ProcessUserRequest(ctx, request)
+		require.NoError(t, err)
+
+		responses := make(chan *messaging.Message, 1)
+		aiMessageBus.On("Subscribe", mock.Anything, "ai-execution").Return((<-chan *messaging.Message)(responses), nil)
+		aiMessageBus.On("SendToAgent", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				msg := args.Get(1).(*messaging.AIToAgentMessage)
+				responses <- &messaging.Message{
+					CorrelationID: msg.CorrelationID,
+					FromID:        msg.AgentID,
+					Content:       "2 words",
+					MessageType:   messaging.MessageTypeAgentToAI,
+				}
+			}).
+			Return(nil)
+
+		stepID := plan.Steps[0].ID
+		result, err := service.ApprovePlan(ctx, plan.ID, "user-123")
+
+		require.NoError(t, err)
+		assert.True(t, result.Success, result.Error)
+		assert.Equal(t, plan.ID, result.ExecutionPlanID)
+		assert.Contains(t, result.Message, "approved")
+
+		// The plan executes after the approval returns
+		assert.Eventually(t, func() bool {
+			step, err := planRepo.GetStepByID(ctx, stepID)
+			return err == nil && step.Status == planningDomain.ExecutionStepStatusCompleted
+		}, 2*time.Second, 10*time.Millisecond)
+		aiMessageBus.AssertNumberOfCalls(t, "SendToAgent", 1)
+
+		stored, err := planRepo.GetByID(ctx, plan.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "user-123", stored.ReviewedBy)
+	})
+
+	t.Run("another user's plan cannot be reviewed", func(t *testing.T) {
+		service, aiMessageBus, planRepo, plan := newService(t)
+
+		_, err := service.ApprovePlan(ctx, plan.ID, "user-456")
+		assert.ErrorIs(t, err, ErrPlanAccessDenied)
+		assert.ErrorIs(t, service.RejectPlan(ctx, plan.ID, "user-456", "not mine"), ErrPlanAccessDenied)
+
+		stored, err := planRepo.GetByID(ctx, plan.ID)
+		require.NoError(t, err)
+		assert.Equal(t, planningDomain.ExecutionPlanStatusPendingApproval, stored.Status)
+		aiMessageBus.AssertNotCalled(t, "SendToAgent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a plan reviewed concurrently is not approved again", func(t *testing.T) {
+		service, aiMessageBus, planRepo, plan := newService(t)
+
+		// Another replica rejected the plan after this one loaded it
+		rejected := *plan
+		require.NoError(t, rejected.Reject("user-123", "changed my mind"))
+		require.NoError(t, planRepo.Update(ctx, &rejected))
+		require.NoError(t, plan.ApproveBy("user-123"))
+
+		err := service.transitionReviewedPlan(ctx, plan)
+		assert.ErrorIs(t, err, ErrPlanNotAwaitingApproval)
+		aiMessageBus.AssertNotCalled(t, "SendToAgent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejected plan cannot be approved", func(t *testing.T) {
+		service, aiMessageBus, planRepo, plan := newService(t)
+
+		require.NoError(t, service.RejectPlan(ctx, plan.ID, "user-123", "not needed"))
+
+		stored, err := planRepo.GetByID(ctx, plan.ID)
+		require.NoError(t, err)
+		assert.Equal(t, planningDomain.ExecutionPlanStatusRejected, stored.Status)
+
+		_, err = service.ApprovePlan(ctx, plan.ID, "user-123")
+		assert.ErrorIs(t, err, ErrPlanNotAwaitingApproval)
+		aiMessageBus.AssertNotCalled(t, "SendToAgent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrchestratorRequest_CorrelationID(t *testing.T) {
+	assert.Equal(t, "corr-1", (&OrchestratorRequest{CorrelationID: "corr-1", MessageID: "msg-1"}).correlationID())
+	assert.Equal(t, "msg-1", (&OrchestratorRequest{MessageID: "msg-1"}).correlationID())
+	assert.NotEmpty(t, (&OrchestratorRequest{}).correlationID())
+}
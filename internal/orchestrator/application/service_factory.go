@@ -3,17 +3,24 @@ package application
 import (
 	"context"
 	"fmt"
+	"time"
 
+	agentInfra "neuromesh/internal/agent/infrastructure"
+	"neuromesh/internal/agent/registry"
 	aiDomain "neuromesh/internal/ai/domain"
 	aiInfrastructure "neuromesh/internal/ai/infrastructure"
 	conversationApp "neuromesh/internal/conversation/application"
 	conversationInfra "neuromesh/internal/conversation/infrastructure"
 	executionApp "neuromesh/internal/execution/application"
+	executionInfra "neuromesh/internal/execution/infrastructure"
 	"neuromesh/internal/graph"
+	learningApp "neuromesh/internal/learning/application"
+	learningInfra "neuromesh/internal/learning/infrastructure"
 	"neuromesh/internal/logging"
 	"neuromesh/internal/messaging"
 	"neuromesh/internal/orchestrator/infrastructure"
 	planningApp "neuromesh/internal/planning/application"
+	planningDomain "neuromesh/internal/planning/domain"
 	planningInfra "neuromesh/internal/planning/infrastructure"
 	userApp "neuromesh/internal/user/application"
 	userInfra "neuromesh/internal/user/infrastructure"
@@ -28,12 +35,20 @@ type ServiceFactory struct {
 	aiProvider            aiDomain.AIProvider
 	correlationTracker    *infrastructure.CorrelationTracker
 	globalMessageConsumer *infrastructure.GlobalMessageConsumer
+	executionService      *executionApp.ExecutionService
 	// Conversation services
 	conversationService conversationApp.ConversationService
 	userService         userApp.UserService
-	shutdownContext     context.Context
-	shutdownCancel      context.CancelFunc
-	started             bool // Track startup state to prevent double-start
+	// Planning services
+	planProgressService  *planningApp.PlanProgressService
+	learningService      *learningApp.LearningServiceImpl
+	minExecuteConfidence int  // Applied to the decision engine of orchestrator services created afterwards
+	requirePlanApproval  bool // Likewise; generated plans wait for a user's approval before they execute
+	maxDispatches        int  // Likewise; agent round-trips in flight at once, overall and to any one agent
+	maxAgentDispatches   int
+	shutdownContext      context.Context
+	shutdownCancel       context.CancelFunc
+	started              bool // Track startup state to prevent double-start
 }
 
 // NewServiceFactory creates a new service factory with proper dependency wiring
@@ -42,6 +57,23 @@ func NewServiceFactory(
 	graph graph.Graph,
 	messageBus messaging.MessageBus,
 	aiProvider aiDomain.AIProvider,
+) *ServiceFactory {
+	// Create AIMessageBus from the base MessageBus (only if dependencies are available)
+	var aiMessageBus messaging.AIMessageBus
+	if messageBus != nil && graph != nil {
+		aiMessageBus = messaging.NewAIMessageBus(messageBus, graph, logger)
+	}
+	return NewServiceFactoryWithAIMessageBus(logger, graph, messageBus, aiMessageBus, aiProvider)
+}
+
+// NewServiceFactoryWithAIMessageBus creates a service factory whose services all send through the given AI message bus
+// Decorators of the bus, such as the event log, then see the execution engine's instructions as well
+func NewServiceFactoryWithAIMessageBus(
+	logger logging.Logger,
+	graph graph.Graph,
+	messageBus messaging.MessageBus,
+	aiMessageBus messaging.AIMessageBus,
+	aiProvider aiDomain.AIProvider,
 ) *ServiceFactory {
 	// Create shutdown context for graceful cleanup
 	shutdownCtx, shutdownCancel := context.WithCancel(context.Background())
@@ -49,18 +81,24 @@ func NewServiceFactory(
 	// Create correlation tracker
 	correlationTracker := infrastructure.NewCorrelationTracker()
 
-	// Create AIMessageBus from the base MessageBus (only if dependencies are available)
-	var aiMessageBus messaging.AIMessageBus
 	var globalMessageConsumer *infrastructure.GlobalMessageConsumer
-
-	if messageBus != nil && graph != nil {
-		aiMessageBus = messaging.NewAIMessageBus(messageBus, graph, logger)
+	if aiMessageBus != nil && graph != nil {
 		globalMessageConsumer = infrastructure.NewGlobalMessageConsumer(aiMessageBus, correlationTracker)
 	}
 
+	// Cancels in-flight executions tracked by the correlation tracker
+	var executionPlanRepo planningDomain.ExecutionPlanRepository
+	if graph != nil {
+		executionPlanRepo = planningInfra.NewGraphExecutionPlanRepository(graph)
+	}
+	executionService := executionApp.NewExecutionService(correlationTracker, aiMessageBus, executionPlanRepo)
+	executionService.SetLogger(logger)
+
 	// Create conversation and user services
 	var conversationService conversationApp.ConversationService
 	var userService userApp.UserService
+	var planProgressService *planningApp.PlanProgressService
+	var learningService *learningApp.LearningServiceImpl
 
 	if graph != nil {
 		// Create repositories
@@ -69,7 +107,9 @@ func NewServiceFactory(
 
 		// Create services
 		userService = userApp.NewUserService(userRepo)
-		conversationService = conversationApp.NewConversationService(conversationRepo)
+		conversationService = conversationApp.NewConversationServiceWithSummarizer(conversationRepo, aiProvider, conversationApp.DefaultSummarizationConfig())
+		planProgressService = planningApp.NewPlanProgressService(planningInfra.NewGraphExecutionPlanRepository(graph))
+		learningService = learningApp.NewLearningService(learningInfra.NewGraphOutcomeRepository(graph))
 	}
 
 	return &ServiceFactory{
@@ -80,13 +120,35 @@ func NewServiceFactory(
 		aiProvider:            aiProvider,
 		correlationTracker:    correlationTracker,
 		globalMessageConsumer: globalMessageConsumer,
+		executionService:      executionService,
 		conversationService:   conversationService,
 		userService:           userService,
+		planProgressService:   planProgressService,
+		learningService:       learningService,
+		minExecuteConfidence:  planningApp.DefaultMinExecuteConfidence,
+		maxDispatches:         executionApp.DefaultMaxConcurrentDispatches,
+		maxAgentDispatches:    executionApp.DefaultMaxConcurrentDispatchesPerAgent,
 		shutdownContext:       shutdownCtx,
 		shutdownCancel:        shutdownCancel,
 	}
 }
 
+// SetMinExecuteConfidence sets the confidence EXECUTE decisions need before they run without asking the user
+func (sf *ServiceFactory) SetMinExecuteConfidence(confidence int) {
+	sf.minExecuteConfidence = confidence
+}
+
+// SetRequirePlanApproval makes generated plans wait for a user's approval before they execute
+func (sf *ServiceFactory) SetRequirePlanApproval(required bool) {
+	sf.requirePlanApproval = required
+}
+
+// SetDispatchLimits sets how many agent round-trips may be in flight at once, overall and to any one agent
+func (sf *ServiceFactory) SetDispatchLimits(maxDispatches, maxAgentDispatches int) {
+	sf.maxDispatches = maxDispatches
+	sf.maxAgentDispatches = maxAgentDispatches
+}
+
 // CreateOrchestratorService creates a fully wired orchestrator service
 func (sf *ServiceFactory) CreateOrchestratorService() *OrchestratorService {
 	// Create infrastructure services
@@ -97,16 +159,106 @@ func (sf *ServiceFactory) CreateOrchestratorService() *OrchestratorService {
 
 	// Create all application services with proper dependencies
 	aiDecisionEngine := planningApp.NewAIDecisionEngineWithRepository(sf.aiProvider, executionPlanRepo)
-	graphExplorer := NewGraphExplorer(agentService)
-	aiExecutionEngine := executionApp.NewAIExecutionEngine(sf.aiProvider, sf.aiMessageBus, sf.correlationTracker)
+	aiDecisionEngine.SetMinExecuteConfidence(sf.minExecuteConfidence)
+	aiDecisionEngine.SetRequirePlanApproval(sf.requirePlanApproval)
+	agentRegistry := sf.NewAgentRegistry(registry.DefaultStaleThreshold)
+	graphExplorer := NewGraphExplorerWithContextBuilder(agentService, agentRegistry)
+	aiExecutionEngine := executionApp.NewAIExecutionEngineWithRepository(sf.aiProvider, sf.aiMessageBus, sf.correlationTracker, executionPlanRepo)
 
-	// Wire everything together (without learning service for now - following YAGNI)
-	return NewOrchestratorService(
+	agentResultRepo := executionInfra.NewGraphAgentResultRepository(sf.graph)
+	aiExecutionEngine.SetAgentResultRepository(agentResultRepo)
+	// Executions that complete their plan answer with the synthesized results of every step
+	aiExecutionEngine.SetResultSynthesizer(executionApp.NewResultSynthesisService(sf.aiProvider, agentResultRepo, executionPlanRepo))
+	aiExecutionEngine.SetAgentDirectory(agentRegistry)
+	aiExecutionEngine.SetDispatchQueue(executionApp.NewDispatchQueue(sf.maxDispatches, executionApp.DefaultPriorityAgingInterval))
+	aiExecutionEngine.SetAgentDispatchQueues(executionApp.NewAgentDispatchQueues(sf.maxAgentDispatches, executionApp.DefaultPriorityAgingInterval))
+
+	// Wire everything together
+	orchestratorService := NewOrchestratorService(
 		aiDecisionEngine,
 		graphExplorer,
 		aiExecutionEngine,
 		sf.logger,
 	)
+	orchestratorService.SetExecutionPlanRepository(executionPlanRepo)
+	return orchestratorService
+}
+
+// NewAgentRegistry creates an agent registry whose registrations maintain capability nodes
+// Capability changes are reported to a listener that logs them
+func (sf *ServiceFactory) NewAgentRegistry(staleThreshold time.Duration) *registry.Service {
+	capabilityIndex := agentInfra.NewGraphAgentRepository(sf.graph)
+	capabilityIndex.SetCapabilityChangeListener(agentInfra.NewLoggingCapabilityListener(sf.logger))
+
+	agentRegistry := registry.NewServiceWithStaleThreshold(sf.graph, sf.logger, staleThreshold)
+	agentRegistry.SetCapabilityIndex(capabilityIndex)
+	return agentRegistry
+}
+
+// EnsureAllSchemas applies the pending graph schema migrations, then ensures the current schema of every graph store
+// Migrations are recorded in the graph and make the changes schema setup cannot, such as dropping a constraint;
+// schema setup is idempotent, so it runs on every startup once the graph is connected
+func (sf *ServiceFactory) EnsureAllSchemas(ctx context.Context) error {
+	if sf.graph == nil {
+		return fmt.Errorf("graph not initialized - schemas cannot be ensured")
+	}
+
+	applied, err := graph.NewMigrator(sf.graph, sf.schemaMigrations()...).Migrate(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to migrate graph schema: %w", err)
+	}
+
+	for _, schema := range sf.schemas() {
+		if err := schema.ensure(ctx); err != nil {
+			return fmt.Errorf("failed to ensure %s: %w", schema.name, err)
+		}
+	}
+
+	sf.logger.Info("ServiceFactory: All graph schemas ensured", "applied_migrations", applied)
+	return nil
+}
+
+// graphSchema is the current schema of one graph store
+type graphSchema struct {
+	name   string
+	ensure func(ctx context.Context) error
+}
+
+// schemas lists the current schema of every store kept in the graph
+func (sf *ServiceFactory) schemas() []graphSchema {
+	return []graphSchema{
+		{"agent schema", agentInfra.NewGraphAgentRepository(sf.graph).EnsureSchema},
+		{"orchestrator schema", infrastructure.NewGraphOrchestratorRepository(sf.graph).EnsureSchema},
+		{"execution plan schema", planningInfra.NewGraphExecutionPlanRepository(sf.graph).EnsureSchema},
+		{"agent result schema", executionInfra.NewGraphAgentResultRepository(sf.graph).EnsureSchema},
+		{"user schema", sf.userService.EnsureSchema},
+		{"conversation schema", sf.conversationService.EnsureSchema},
+		{"learning outcome schema", learningInfra.NewGraphOutcomeRepository(sf.graph).EnsureSchema},
+		{"message store schema", messaging.NewGraphMessageStore(sf.graph).EnsureSchema},
+		{"bus event log schema", messaging.NewGraphEventLogStore(sf.graph).EnsureSchema},
+	}
+}
+
+// schemaMigrations lists the graph schema changes in the order they were introduced
+// Append new steps with the next version; never renumber or edit a released one, and spell out each step's
+// schema calls rather than calling a store's EnsureSchema, which changes with the store
+// Versions 1 to 7 created the original store schemas, which schemas now ensures
+func (sf *ServiceFactory) schemaMigrations() []graph.Migration {
+	return []graph.Migration{
+		{Version: 8, Name: "per-agent capability nodes", Up: sf.indexCapabilityNames},
+	}
+}
+
+// indexCapabilityNames replaces the unique constraint on capability names with an index
+// Every agent links its own capability nodes, so agents sharing a capability repeat its name
+func (sf *ServiceFactory) indexCapabilityNames(ctx context.Context) error {
+	if err := sf.graph.DropUniqueConstraint(ctx, "capability", "name"); err != nil {
+		return fmt.Errorf("failed to drop capability.name constraint: %w", err)
+	}
+	if err := sf.graph.CreateIndex(ctx, "capability", "name"); err != nil {
+		return fmt.Errorf("failed to create capability.name index: %w", err)
+	}
+	return nil
 }
 
 // StartServices starts all background services in proper order
@@ -173,3 +325,23 @@ func (sf *ServiceFactory) GetUserService() userApp.UserService {
 func (sf *ServiceFactory) GetConversationService() conversationApp.ConversationService {
 	return sf.conversationService
 }
+
+// GetExecutionService returns the execution service instance
+func (sf *ServiceFactory) GetExecutionService() *executionApp.ExecutionService {
+	return sf.executionService
+}
+
+// GetPlanProgressService returns the plan progress service instance
+func (sf *ServiceFactory) GetPlanProgressService() *planningApp.PlanProgressService {
+	return sf.planProgressService
+}
+
+// GetLearningService returns the learning service instance
+func (sf *ServiceFactory) GetLearningService() *learningApp.LearningServiceImpl {
+	return sf.learningService
+}
+
+// GetCorrelationTracker returns the correlation tracker holding in-flight agent requests
+func (sf *ServiceFactory) GetCorrelationTracker() *infrastructure.CorrelationTracker {
+	return sf.correlationTracker
+}
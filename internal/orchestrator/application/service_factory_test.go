@@ -7,7 +7,9 @@ import (
 	"time"
 
 	aiInfrastructure "neuromesh/internal/ai/infrastructure"
+	"neuromesh/internal/graph"
 	"neuromesh/internal/logging"
+	"neuromesh/internal/messaging"
 	"neuromesh/testHelpers"
 
 	"github.com/stretchr/testify/assert"
@@ -227,3 +229,78 @@ func TestServiceFactory_ProductionScenario_TDD(t *testing.T) {
 		assert.NoError(t, shutdownErr, "Shutdown should succeed")
 	})
 }
+
+func TestServiceFactory_EnsureAllSchemas(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates the constraints and indexes of every repository", func(t *testing.T) {
+		g := testHelpers.NewCleanMockGraph()
+		factory := NewServiceFactory(logging.NewNoOpLogger(), g, nil, nil)
+
+		require.NoError(t, factory.EnsureAllSchemas(ctx))
+
+		for _, constraint := range [][2]string{
+			{"agent", "id"},
+			{"ExecutionPlan", "id"},
+			{"ExecutionStep", "id"},
+			{"execution_plan", "id"},
+			{"execution_step", "id"},
+			{"agent_result", "id"},
+			{"User", "id"},
+			{"Session", "id"},
+			{"Conversation", "id"},
+			{"ConversationMessage", "id"},
+			{"AIDecision", "id"},
+			{"DecisionOutcome", "id"},
+			{messaging.NodeTypeRoutedMessage, "id"},
+			{messaging.NodeTypeBusEvent, "id"},
+		} {
+			exists, err := g.HasUniqueConstraint(ctx, constraint[0], constraint[1])
+			require.NoError(t, err)
+			assert.True(t, exists, "missing unique constraint on %s.%s", constraint[0], constraint[1])
+		}
+
+		for _, index := range [][2]string{
+			{"agent", "status"},
+			{"capability", "name"},
+			{"execution_step", "step_number"},
+			{"agent_result", "plan_id"},
+		} {
+			exists, err := g.HasIndex(ctx, index[0], index[1])
+			require.NoError(t, err)
+			assert.True(t, exists, "missing index on %s.%s", index[0], index[1])
+		}
+
+		// Schema setup runs on every startup, so repeating it must succeed
+		require.NoError(t, factory.EnsureAllSchemas(ctx))
+
+		versions, err := graph.NewMigrator(g).AppliedVersions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []int{8}, versions)
+
+		// Agents sharing a capability each link their own capability node
+		exists, err := g.HasUniqueConstraint(ctx, "capability", "name")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("migrates graphs created with unique capability names", func(t *testing.T) {
+		g := testHelpers.NewCleanMockGraph()
+		require.NoError(t, g.CreateUniqueConstraint(ctx, "capability", "name"))
+		factory := NewServiceFactory(logging.NewNoOpLogger(), g, nil, nil)
+
+		require.NoError(t, factory.EnsureAllSchemas(ctx))
+
+		exists, err := g.HasUniqueConstraint(ctx, "capability", "name")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		exists, err = g.HasIndex(ctx, "capability", "name")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("requires a graph", func(t *testing.T) {
+		factory := NewServiceFactory(logging.NewNoOpLogger(), nil, nil, nil)
+		assert.Error(t, factory.EnsureAllSchemas(ctx))
+	})
+}
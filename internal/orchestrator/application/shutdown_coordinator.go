@@ -0,0 +1,98 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"neuromesh/internal/logging"
+)
+
+// DefaultDrainTimeout bounds how long shutdown waits for in-flight conversations to finish
+const DefaultDrainTimeout = 30 * time.Second
+
+// defaultDrainPollInterval is how often in-flight work is re-checked while draining
+const defaultDrainPollInterval = 100 * time.Millisecond
+
+// ErrDrainTimeout is returned when in-flight work did not finish within the drain timeout
+var ErrDrainTimeout = errors.New("drain timeout exceeded")
+
+// RequestGate stops admitting new requests once shutdown begins
+type RequestGate interface {
+	StopAccepting()
+}
+
+// InFlightSource reports how many requests are still being processed
+type InFlightSource interface {
+	InFlight() int
+}
+
+// ShutdownCoordinator stops new chat requests on shutdown and waits for in-flight conversations to drain
+type ShutdownCoordinator struct {
+	drainTimeout time.Duration
+	pollInterval time.Duration
+	gates        []RequestGate
+	sources      []InFlightSource
+	logger       logging.Logger
+}
+
+// NewShutdownCoordinator creates a shutdown coordinator waiting at most drainTimeout for in-flight work
+func NewShutdownCoordinator(drainTimeout time.Duration, logger logging.Logger) *ShutdownCoordinator {
+	if drainTimeout <= 0 {
+		drainTimeout = DefaultDrainTimeout
+	}
+	return &ShutdownCoordinator{
+		drainTimeout: drainTimeout,
+		pollInterval: defaultDrainPollInterval,
+		logger:       logger,
+	}
+}
+
+// AddGate registers a request entry point closed when draining starts
+func (sc *ShutdownCoordinator) AddGate(gate RequestGate) {
+	sc.gates = append(sc.gates, gate)
+}
+
+// AddInFlightSource registers in-flight work that must reach zero before shutdown proceeds
+func (sc *ShutdownCoordinator) AddInFlightSource(source InFlightSource) {
+	sc.sources = append(sc.sources, source)
+}
+
+// Drain stops all gates and blocks until every source reports zero in-flight requests
+// Returns ErrDrainTimeout if work is still in flight after the drain timeout or ctx is done
+func (sc *ShutdownCoordinator) Drain(ctx context.Context) error {
+	for _, gate := range sc.gates {
+		gate.StopAccepting()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sc.drainTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(sc.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		remaining := sc.inFlight()
+		if remaining == 0 {
+			sc.logger.Info("In-flight conversations drained")
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to drain %d in-flight requests: %w", remaining, ErrDrainTimeout)
+		case <-ticker.C:
+			sc.logger.Debug("Waiting for in-flight conversations", "remaining", remaining)
+		}
+	}
+}
+
+// inFlight sums the in-flight requests of all sources
+func (sc *ShutdownCoordinator) inFlight() int {
+	total := 0
+	for _, source := range sc.sources {
+		total += source.InFlight()
+	}
+	return total
+}
@@ -0,0 +1,79 @@
+package application
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"neuromesh/internal/logging"
+	"neuromesh/internal/messaging"
+	"neuromesh/internal/orchestrator/infrastructure"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRequestGate records whether shutdown closed it
+type fakeRequestGate struct {
+	stopped atomic.Bool
+}
+
+func (g *fakeRequestGate) StopAccepting() {
+	g.stopped.Store(true)
+}
+
+func TestShutdownCoordinator_Drain(t *testing.T) {
+	t.Run("waits for an in-flight request completing during the drain window", func(t *testing.T) {
+		tracker := infrastructure.NewCorrelationTracker()
+		responseChan := tracker.RegisterAgentRequest(&messaging.AIToAgentMessage{
+			AgentID:       "text-processor",
+			CorrelationID: "corr-in-flight",
+		}, "user-1", time.Minute)
+
+		gate := &fakeRequestGate{}
+		coordinator := NewShutdownCoordinator(2*time.Second, logging.NewNoOpLogger())
+		coordinator.pollInterval = 5 * time.Millisecond
+		coordinator.AddGate(gate)
+		coordinator.AddInFlightSource(tracker)
+
+		drained := make(chan error, 1)
+		go func() {
+			drained <- coordinator.Drain(context.Background())
+		}()
+
+		// The agent replies while shutdown is already draining
+		time.Sleep(50 * time.Millisecond)
+		assert.True(t, gate.stopped.Load())
+		select {
+		case err := <-drained:
+			t.Fatalf("drain finished before the in-flight request completed: %v", err)
+		default:
+		}
+		require.True(t, tracker.RouteResponse(&messaging.AgentToAIMessage{CorrelationID: "corr-in-flight", Content: "done"}))
+
+		select {
+		case err := <-drained:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("drain did not finish after the in-flight request completed")
+		}
+		response := <-responseChan
+		assert.Equal(t, "done", response.Content)
+		assert.Equal(t, 0, tracker.InFlight())
+	})
+
+	t.Run("gives up after the drain timeout", func(t *testing.T) {
+		tracker := infrastructure.NewCorrelationTracker()
+		tracker.RegisterRequest("corr-stuck", "user-1", time.Minute)
+
+		coordinator := NewShutdownCoordinator(30*time.Millisecond, logging.NewNoOpLogger())
+		coordinator.pollInterval = 5 * time.Millisecond
+		coordinator.AddInFlightSource(tracker)
+
+		err := coordinator.Drain(context.Background())
+
+		assert.ErrorIs(t, err, ErrDrainTimeout)
+		assert.Equal(t, 1, tracker.InFlight())
+	})
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,8 +13,12 @@ type DecisionType string
 const (
 	DecisionTypeClarify DecisionType = "CLARIFY"
 	DecisionTypeExecute DecisionType = "EXECUTE"
+	DecisionTypeReject  DecisionType = "REJECT"
 )
 
+// ParameterTokenUsage is the decision parameter holding the token usage of every AI call made for the request
+const ParameterTokenUsage = "token_usage"
+
 // Decision represents an AI decision about how to handle a user request
 type Decision struct {
 	ID                    string                 `json:"id"`
@@ -23,9 +28,11 @@ type Decision struct {
 	Action                string                 `json:"action,omitempty"`
 	Parameters            map[string]interface{} `json:"parameters,omitempty"`
 	ClarificationQuestion string                 `json:"clarification_question,omitempty"`
+	RejectionReason       string                 `json:"rejection_reason,omitempty"`   // Why an unsafe or out-of-scope request was refused
 	ExecutionPlanID       string                 `json:"execution_plan_id,omitempty"`  // Reference to graph-persisted ExecutionPlan
 	AgentCoordination     string                 `json:"agent_coordination,omitempty"` // May still be useful for coordination logic
 	Reasoning             string                 `json:"reasoning"`
+	Confidence            int                    `json:"confidence"` // 0-100, as reported by the AI for this decision
 	Timestamp             time.Time              `json:"timestamp"`
 }
 
@@ -56,6 +63,19 @@ func NewExecuteDecision(requestID, analysisID, executionPlanID, agentCoordinatio
 	}
 }
 
+// NewRejectDecision creates a decision to refuse an unsafe or out-of-scope request
+func NewRejectDecision(requestID, analysisID, rejectionReason, reasoning string) *Decision {
+	return &Decision{
+		ID:              uuid.New().String(),
+		RequestID:       requestID,
+		AnalysisID:      analysisID,
+		Type:            DecisionTypeReject,
+		RejectionReason: rejectionReason,
+		Reasoning:       reasoning,
+		Timestamp:       time.Now(),
+	}
+}
+
 // IsExecutable returns true if this decision can be executed
 func (d *Decision) IsExecutable() bool {
 	return d.Type == DecisionTypeExecute
@@ -70,3 +90,16 @@ func (d *Decision) HasAction() bool {
 func (d *Decision) NeedsClarification() bool {
 	return d.Type == DecisionTypeClarify
 }
+
+// IsRejected returns true if the request was refused
+func (d *Decision) IsRejected() bool {
+	return d.Type == DecisionTypeReject
+}
+
+// RejectionMessage returns the polite refusal shown to the user for a rejected request
+func (d *Decision) RejectionMessage() string {
+	if d.RejectionReason == "" {
+		return "I'm sorry, but I can't help with that request."
+	}
+	return fmt.Sprintf("I'm sorry, but I can't help with that request: %s", d.RejectionReason)
+}
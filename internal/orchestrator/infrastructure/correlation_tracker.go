@@ -6,26 +6,50 @@ import (
 	"time"
 
 	"neuromesh/internal/messaging"
+	"neuromesh/internal/metrics"
 )
 
 // CorrelationRequest represents a pending request waiting for a response
 type CorrelationRequest struct {
 	CorrelationID string
+	ExecutionID   string // Request execution the agent request belongs to, if any
 	UserID        string
+	AgentID       string
+	PlanID        string
+	StepID        string
 	ResponseChan  chan *messaging.AgentToAIMessage
+	RegisteredAt  time.Time
 	ExpiresAt     time.Time
+	onProgress    ProgressHandler
 }
 
+// TrackedExecution is a running request execution whose agent requests are cancelled together
+type TrackedExecution struct {
+	ExecutionID string
+	UserID      string
+	PlanID      string // Empty for executions without a plan
+}
+
+// cancelledExecutionRetention bounds how long a cancelled execution that never ends is remembered
+const cancelledExecutionRetention = time.Hour
+
+// ProgressHandler receives the in-progress updates an agent reports before its final reply
+type ProgressHandler func(update *messaging.AgentToAIMessage)
+
 // CorrelationTracker manages pending requests and routes responses by correlation ID
 type CorrelationTracker struct {
-	mu       sync.RWMutex
-	requests map[string]*CorrelationRequest
+	mu         sync.RWMutex
+	requests   map[string]*CorrelationRequest
+	executions map[string]TrackedExecution
+	cancelled  map[string]time.Time // correlation or execution ID -> when the cancellation record expires
 }
 
 // NewCorrelationTracker creates a new instance of CorrelationTracker
 func NewCorrelationTracker() *CorrelationTracker {
 	return &CorrelationTracker{
-		requests: make(map[string]*CorrelationRequest),
+		requests:   make(map[string]*CorrelationRequest),
+		executions: make(map[string]TrackedExecution),
+		cancelled:  make(map[string]time.Time),
 	}
 }
 
@@ -36,20 +60,171 @@ func (ct *CorrelationTracker) RegisterRequest(correlationID, userID string, time
 
 	responseChan := make(chan *messaging.AgentToAIMessage, 1)
 
+	now := time.Now()
 	request := &CorrelationRequest{
 		CorrelationID: correlationID,
 		UserID:        userID,
 		ResponseChan:  responseChan,
-		ExpiresAt:     time.Now().Add(timeout),
+		RegisteredAt:  now,
+		ExpiresAt:     now.Add(timeout),
 	}
 
 	ct.requests[correlationID] = request
 	return responseChan
 }
 
+// RegisterAgentRequest registers a request for an AI-to-agent event, recording the agent, plan and step it targets
+func (ct *CorrelationTracker) RegisterAgentRequest(msg *messaging.AIToAgentMessage, userID string, timeout time.Duration) chan *messaging.AgentToAIMessage {
+	responseChan := ct.RegisterRequest(msg.CorrelationID, userID, timeout)
+
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if request, exists := ct.requests[msg.CorrelationID]; exists {
+		request.AgentID = msg.AgentID
+		request.ExecutionID, _ = msg.Context["execution_id"].(string)
+		request.PlanID, _ = msg.Context["plan_id"].(string)
+		request.StepID, _ = msg.Context["step_id"].(string)
+	}
+	return responseChan
+}
+
+// SetProgressHandler sets the handler receiving in-progress updates for a pending request
+// Returns false if no matching request was found
+func (ct *CorrelationTracker) SetProgressHandler(correlationID string, handler ProgressHandler) bool {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	request, exists := ct.requests[correlationID]
+	if !exists {
+		return false
+	}
+	request.onProgress = handler
+	return true
+}
+
+// GetRequest returns a copy of the pending request for a correlation ID
+func (ct *CorrelationTracker) GetRequest(correlationID string) (CorrelationRequest, bool) {
+	ct.mu.RLock()
+	defer ct.mu.RUnlock()
+
+	request, exists := ct.requests[correlationID]
+	if !exists {
+		return CorrelationRequest{}, false
+	}
+	return *request, true
+}
+
+// GetPlanRequests returns copies of all pending requests belonging to an execution plan
+func (ct *CorrelationTracker) GetPlanRequests(planID string) []CorrelationRequest {
+	ct.mu.RLock()
+	defer ct.mu.RUnlock()
+
+	var requests []CorrelationRequest
+	for _, request := range ct.requests {
+		if planID != "" && request.PlanID == planID {
+			requests = append(requests, *request)
+		}
+	}
+	return requests
+}
+
+// BeginExecution tracks a running execution until EndExecution is called
+func (ct *CorrelationTracker) BeginExecution(execution TrackedExecution) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	ct.executions[execution.ExecutionID] = execution
+}
+
+// EndExecution stops tracking an execution and forgets whether it was cancelled
+func (ct *CorrelationTracker) EndExecution(executionID string) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	delete(ct.executions, executionID)
+	delete(ct.cancelled, executionID)
+}
+
+// GetExecution returns the running execution with the given ID
+func (ct *CorrelationTracker) GetExecution(executionID string) (TrackedExecution, bool) {
+	ct.mu.RLock()
+	defer ct.mu.RUnlock()
+
+	execution, exists := ct.executions[executionID]
+	return execution, exists
+}
+
+// GetExecutionRequests returns copies of all pending requests made by an execution
+func (ct *CorrelationTracker) GetExecutionRequests(executionID string) []CorrelationRequest {
+	ct.mu.RLock()
+	defer ct.mu.RUnlock()
+
+	var requests []CorrelationRequest
+	for _, request := range ct.requests {
+		if executionID != "" && request.ExecutionID == executionID {
+			requests = append(requests, *request)
+		}
+	}
+	return requests
+}
+
+// MarkExecutionCancelled records that a running execution was cancelled so it dispatches no further requests
+// Returns false if no matching execution is running
+func (ct *CorrelationTracker) MarkExecutionCancelled(executionID string) bool {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if _, exists := ct.executions[executionID]; !exists {
+		return false
+	}
+	ct.cancelled[executionID] = time.Now().Add(cancelledExecutionRetention)
+	return true
+}
+
+// InFlight returns the number of requests still waiting for an agent response
+func (ct *CorrelationTracker) InFlight() int {
+	ct.mu.RLock()
+	defer ct.mu.RUnlock()
+
+	return len(ct.requests)
+}
+
+// CancelRequest removes a pending request and unblocks its waiter by closing the response channel
+// Returns false if no matching request was found
+func (ct *CorrelationTracker) CancelRequest(correlationID string) bool {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	request, exists := ct.requests[correlationID]
+	if !exists {
+		return false
+	}
+
+	// Remember the cancellation so the waiter can tell it apart from a timeout or cleanup
+	ct.cancelled[correlationID] = request.ExpiresAt
+	close(request.ResponseChan)
+	delete(ct.requests, correlationID)
+	return true
+}
+
+// IsCancelled reports whether the request for a correlation ID, or the execution with that ID, was cancelled
+func (ct *CorrelationTracker) IsCancelled(correlationID string) bool {
+	ct.mu.RLock()
+	defer ct.mu.RUnlock()
+
+	_, cancelled := ct.cancelled[correlationID]
+	return cancelled
+}
+
 // RouteResponse routes an agent response to the appropriate waiting request
+// In-progress updates go to the request's progress handler and leave it waiting for the final reply
 // Returns true if the response was routed successfully, false if no matching request was found
 func (ct *CorrelationTracker) RouteResponse(response *messaging.AgentToAIMessage) bool {
+	if response.IsInProgress() {
+		return ct.routeProgress(response)
+	}
+
 	ct.mu.Lock()
 	defer ct.mu.Unlock()
 
@@ -63,6 +238,9 @@ func (ct *CorrelationTracker) RouteResponse(response *messaging.AgentToAIMessage
 	case request.ResponseChan <- response:
 		// Successfully sent, now clean up the request
 		delete(ct.requests, response.CorrelationID)
+		if request.AgentID != "" {
+			metrics.AgentResponseDuration.WithLabelValues(request.AgentID).Observe(time.Since(request.RegisteredAt).Seconds())
+		}
 		return true
 	default:
 		// Channel is full or closed, clean up anyway
@@ -71,6 +249,26 @@ func (ct *CorrelationTracker) RouteResponse(response *messaging.AgentToAIMessage
 	}
 }
 
+// routeProgress hands an in-progress update to the progress handler of its pending request
+// The handler runs outside the lock so it may use the tracker
+func (ct *CorrelationTracker) routeProgress(update *messaging.AgentToAIMessage) bool {
+	ct.mu.RLock()
+	request, exists := ct.requests[update.CorrelationID]
+	var handler ProgressHandler
+	if exists {
+		handler = request.onProgress
+	}
+	ct.mu.RUnlock()
+
+	if !exists {
+		return false
+	}
+	if handler != nil {
+		handler(update)
+	}
+	return true
+}
+
 // CleanupRequest removes a pending request from the tracker
 func (ct *CorrelationTracker) CleanupRequest(correlationID string) {
 	ct.mu.Lock()
@@ -122,4 +320,9 @@ func (ct *CorrelationTracker) cleanupExpiredRequests() {
 			delete(ct.requests, correlationID)
 		}
 	}
+	for correlationID, expiresAt := range ct.cancelled {
+		if now.After(expiresAt) {
+			delete(ct.cancelled, correlationID)
+		}
+	}
 }
@@ -209,3 +209,39 @@ func TestCorrelationTracker_AutoCleanup_ShouldRemoveExpiredRequests(t *testing.T
 		t.Fatal("Request should have been auto-cleaned up after timeout")
 	}
 }
+
+func TestCorrelationTracker_CancelRequest_ShouldUnblockWaiter(t *testing.T) {
+	tracker := NewCorrelationTracker()
+	responseChan := tracker.RegisterAgentRequest(&messaging.AIToAgentMessage{
+		AgentID:       "deploy-agent",
+		CorrelationID: "cancel-correlation",
+		Context:       map[string]interface{}{"plan_id": "plan-1", "step_id": "step-1"},
+	}, "user-1", 5*time.Second)
+
+	request, exists := tracker.GetRequest("cancel-correlation")
+	if !exists || request.AgentID != "deploy-agent" || request.PlanID != "plan-1" || request.StepID != "step-1" {
+		t.Fatalf("expected request to record agent, plan and step, got %+v", request)
+	}
+	if len(tracker.GetPlanRequests("plan-1")) != 1 {
+		t.Fatal("expected one pending request for plan-1")
+	}
+
+	if !tracker.CancelRequest("cancel-correlation") {
+		t.Fatal("CancelRequest should find the pending request")
+	}
+
+	select {
+	case response, ok := <-responseChan:
+		if ok || response != nil {
+			t.Fatal("cancelled request should close its channel without a response")
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("waiter was not unblocked by the cancellation")
+	}
+	if !tracker.IsCancelled("cancel-correlation") {
+		t.Fatal("IsCancelled should report the cancelled request")
+	}
+	if tracker.CancelRequest("cancel-correlation") {
+		t.Fatal("a request can only be cancelled once")
+	}
+}
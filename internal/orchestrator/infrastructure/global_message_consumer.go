@@ -25,20 +25,70 @@ func NewGlobalMessageConsumer(messageBus messaging.AIMessageBus, tracker *Correl
 }
 
 // StartConsumption starts consuming messages from the specified participant queue
+// The subscription is renewed whenever the message bus reconnects, until ctx is cancelled
 func (gmc *GlobalMessageConsumer) StartConsumption(ctx context.Context, participantID string) error {
-	// Subscribe to the message bus
+	// The reconnect notification is taken before subscribing so none is missed
+	reconnected := gmc.busReconnected()
 	messageChannel, err := gmc.messageBus.Subscribe(ctx, participantID)
 	if err != nil {
 		return fmt.Errorf("failed to subscribe to message bus: %w", err)
 	}
 
 	// Start message processing goroutine
-	go gmc.processMessages(ctx, messageChannel)
+	go gmc.consume(ctx, participantID, messageChannel, reconnected)
 
 	return nil
 }
 
-// processMessages processes incoming messages from the message channel
+// consume processes the subscription's messages and subscribes again each time the bus reconnects
+func (gmc *GlobalMessageConsumer) consume(ctx context.Context, participantID string, messageChannel <-chan *messaging.Message, reconnected <-chan struct{}) {
+	for messageChannel != nil {
+		gmc.processMessages(ctx, messageChannel)
+		if ctx.Err() != nil {
+			return
+		}
+		messageChannel, reconnected = gmc.resubscribe(ctx, participantID, reconnected)
+	}
+}
+
+// resubscribe waits for the message bus to reconnect and subscribes again, retrying on every further reconnect
+// A nil channel means consumption ends: ctx was cancelled or the bus never reconnects
+func (gmc *GlobalMessageConsumer) resubscribe(ctx context.Context, participantID string, reconnected <-chan struct{}) (<-chan *messaging.Message, <-chan struct{}) {
+	for {
+		if reconnected == nil {
+			gmc.logger.Warn("GlobalMessageConsumer: Message bus does not reconnect, agent replies are no longer consumed",
+				"participantID", participantID)
+			return nil, nil
+		}
+
+		gmc.logger.Warn("GlobalMessageConsumer: Subscription ended, waiting for the message bus to reconnect",
+			"participantID", participantID)
+		select {
+		case <-reconnected:
+		case <-ctx.Done():
+			return nil, nil
+		}
+
+		reconnected = gmc.busReconnected()
+		messageChannel, err := gmc.messageBus.Subscribe(ctx, participantID)
+		if err != nil {
+			gmc.logger.Error("GlobalMessageConsumer: Failed to re-subscribe after reconnect", err, "participantID", participantID)
+			continue
+		}
+		gmc.logger.Info("GlobalMessageConsumer: Re-subscribed after message bus reconnect", "participantID", participantID)
+		return messageChannel, reconnected
+	}
+}
+
+// busReconnected returns the message bus's next reconnect notification, or nil if the bus never reconnects
+func (gmc *GlobalMessageConsumer) busReconnected() <-chan struct{} {
+	if notifier, ok := gmc.messageBus.(messaging.ReconnectNotifier); ok {
+		return notifier.Reconnected()
+	}
+	return nil
+}
+
+// processMessages processes incoming messages until the message channel closes or ctx is cancelled
 func (gmc *GlobalMessageConsumer) processMessages(ctx context.Context, messageChannel <-chan *messaging.Message) {
 	for {
 		select {
@@ -47,7 +97,7 @@ func (gmc *GlobalMessageConsumer) processMessages(ctx context.Context, messageCh
 			return
 		case message, ok := <-messageChannel:
 			if !ok {
-				gmc.logger.Info("GlobalMessageConsumer: Message channel closed, stopping processing")
+				gmc.logger.Info("GlobalMessageConsumer: Message channel closed")
 				return
 			}
 
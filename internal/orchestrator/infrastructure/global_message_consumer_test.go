@@ -3,6 +3,7 @@ package infrastructure
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -80,6 +81,65 @@ func TestGlobalMessageConsumer_RouteMessage_ShouldRouteToCorrelationTracker(t *t
 	}
 }
 
+func TestGlobalMessageConsumer_RouteMessage_ShouldForwardInProgressUpdatesWithoutResolving(t *testing.T) {
+	// Arrange
+	tracker := NewCorrelationTracker()
+	consumer := NewGlobalMessageConsumer(&MockMessageBus{messages: make(chan *messaging.Message, 10)}, tracker)
+
+	correlationID := "test-correlation-progress"
+	responseChan := tracker.RegisterRequest(correlationID, "user-456", 5*time.Second)
+	var updates []string
+	tracker.SetProgressHandler(correlationID, func(update *messaging.AgentToAIMessage) {
+		updates = append(updates, update.Content)
+	})
+
+	progress := &messaging.Message{
+		MessageType:   messaging.MessageTypeAgentToAI,
+		Content:       "Half way there",
+		FromID:        "test-agent",
+		CorrelationID: correlationID,
+		Metadata:      map[string]interface{}{messaging.ReplyStatusContextKey: messaging.ReplyStatusInProgress, "sequence": 1},
+	}
+	final := &messaging.Message{
+		MessageType:   messaging.MessageTypeAgentToAI,
+		Content:       "Done",
+		FromID:        "test-agent",
+		CorrelationID: correlationID,
+	}
+
+	// Act
+	progressRouted := consumer.RouteMessage(progress)
+
+	// Assert: the update reaches the handler and the request keeps waiting
+	if !progressRouted {
+		t.Fatal("In-progress update should be routed to the pending request")
+	}
+	if len(updates) != 1 || updates[0] != "Half way there" {
+		t.Fatalf("Expected the update to reach the progress handler, got %v", updates)
+	}
+	select {
+	case response := <-responseChan:
+		t.Fatalf("In-progress update must not resolve the request, got %v", response)
+	default:
+	}
+	if _, pending := tracker.GetRequest(correlationID); !pending {
+		t.Fatal("Request should still be pending after an in-progress update")
+	}
+
+	// Act: the terminal reply resolves the request
+	if !consumer.RouteMessage(final) {
+		t.Fatal("Final reply should be routed to the pending request")
+	}
+	select {
+	case response := <-responseChan:
+		if response.Content != "Done" {
+			t.Fatalf("Expected the final reply, got %q", response.Content)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Final reply was not delivered")
+	}
+}
+
 func TestGlobalMessageConsumer_RouteMessage_ShouldHandleUnknownCorrelationID(t *testing.T) {
 	// Arrange
 	mockBus := &MockMessageBus{
@@ -218,6 +278,84 @@ func TestGlobalMessageConsumer_ConcurrentMessageProcessing_ShouldHandleMultipleM
 	}
 }
 
+func TestGlobalMessageConsumer_ShouldResubscribeAfterBusReconnect(t *testing.T) {
+	// Arrange: the first subscription ends when the connection drops
+	first := make(chan *messaging.Message)
+	second := make(chan *messaging.Message, 1)
+	bus := &reconnectingMessageBus{
+		subscriptions: []chan *messaging.Message{first, second},
+		reconnected:   make(chan struct{}),
+	}
+	tracker := NewCorrelationTracker()
+	consumer := NewGlobalMessageConsumer(bus, tracker)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := consumer.StartConsumption(ctx, "ai-orchestrator"); err != nil {
+		t.Fatalf("StartConsumption should not return error: %v", err)
+	}
+	responseChan := tracker.RegisterRequest("after-reconnect", "user-1", 5*time.Second)
+
+	// Act: drop the connection, reconnect, and deliver a reply on the renewed subscription
+	close(first)
+	bus.reconnect()
+	second <- &messaging.Message{
+		MessageType:   messaging.MessageTypeAgentToAI,
+		Content:       "Reply after reconnect",
+		FromID:        "test-agent",
+		CorrelationID: "after-reconnect",
+	}
+
+	// Assert
+	select {
+	case response := <-responseChan:
+		if response.Content != "Reply after reconnect" {
+			t.Errorf("Expected the reply from the renewed subscription, got %q", response.Content)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Reply after reconnect should have been routed")
+	}
+	if subscribed := bus.subscribeCount(); subscribed != 2 {
+		t.Errorf("Expected 2 subscriptions, got %d", subscribed)
+	}
+}
+
+// reconnectingMessageBus hands out one subscription per Subscribe call and notifies reconnects
+type reconnectingMessageBus struct {
+	MockMessageBus
+	mu            sync.Mutex
+	subscriptions []chan *messaging.Message
+	subscribed    int
+	reconnected   chan struct{}
+}
+
+func (m *reconnectingMessageBus) Subscribe(ctx context.Context, participantID string) (<-chan *messaging.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	subscription := m.subscriptions[m.subscribed]
+	m.subscribed++
+	return subscription, nil
+}
+
+func (m *reconnectingMessageBus) Reconnected() <-chan struct{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.reconnected
+}
+
+func (m *reconnectingMessageBus) reconnect() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	close(m.reconnected)
+	m.reconnected = make(chan struct{})
+}
+
+func (m *reconnectingMessageBus) subscribeCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.subscribed
+}
+
 // MockMessageBus for testing GlobalMessageConsumer
 type MockMessageBus struct {
 	messages        chan *messaging.Message
@@ -252,3 +390,11 @@ func (m *MockMessageBus) GetConversationHistory(ctx context.Context, correlation
 func (m *MockMessageBus) PrepareAgentQueue(ctx context.Context, agentID string) error {
 	return nil
 }
+
+func (m *MockMessageBus) PublishAgentCompletedEvent(ctx context.Context, event *messaging.AgentCompletedEvent) error {
+	return nil
+}
+
+func (m *MockMessageBus) PublishPlanCompletedEvent(ctx context.Context, event *messaging.PlanCompletedEvent) error {
+	return nil
+}
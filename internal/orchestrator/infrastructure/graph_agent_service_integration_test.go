@@ -34,8 +34,8 @@ func TestProductionBugReproduction(t *testing.T) {
 	defer graphInstance.Close(ctx)
 
 	// Clear any existing test data
-	_ = graphInstance.ClearTestData(ctx)
-	defer graphInstance.ClearTestData(ctx)
+	_ = graphInstance.ClearNodesByType(ctx, "agent")
+	defer graphInstance.ClearNodesByType(ctx, "agent")
 
 	// STEP 1: Simulate agent registration (exactly like registry service does)
 	t.Log("🔧 STEP 1: Simulating agent registration...")
@@ -11,30 +11,50 @@ import (
 	"neuromesh/internal/planning/domain"
 )
 
+// planningCallOptions pins temperature to zero so analyses and plans are reproducible
+var planningCallOptions = aiDomain.CallOptions{}.WithTemperature(0)
+
+// DefaultMinExecuteConfidence is the confidence below which EXECUTE decisions are downgraded to CLARIFY
+const DefaultMinExecuteConfidence = 60
+
 // AIDecisionEngine handles AI-powered decision making
 type AIDecisionEngine struct {
-	aiProvider        aiDomain.AIProvider
-	responseParser    *domain.ResponseParser
-	executionPlanRepo domain.ExecutionPlanRepository
+	aiProvider           aiDomain.AIProvider
+	responseParser       *domain.ResponseParser
+	executionPlanRepo    domain.ExecutionPlanRepository
+	minExecuteConfidence int
+	requirePlanApproval  bool
 }
 
 // NewAIDecisionEngine creates a new AI decision engine
 func NewAIDecisionEngine(aiProvider aiDomain.AIProvider) *AIDecisionEngine {
 	return &AIDecisionEngine{
-		aiProvider:     aiProvider,
-		responseParser: domain.NewResponseParser(),
+		aiProvider:           aiProvider,
+		responseParser:       domain.NewResponseParser(),
+		minExecuteConfidence: DefaultMinExecuteConfidence,
 	}
 }
 
 // NewAIDecisionEngineWithRepository creates a new AI decision engine with execution plan repository
 func NewAIDecisionEngineWithRepository(aiProvider aiDomain.AIProvider, executionPlanRepo domain.ExecutionPlanRepository) *AIDecisionEngine {
 	return &AIDecisionEngine{
-		aiProvider:        aiProvider,
-		responseParser:    domain.NewResponseParser(),
-		executionPlanRepo: executionPlanRepo,
+		aiProvider:           aiProvider,
+		responseParser:       domain.NewResponseParser(),
+		executionPlanRepo:    executionPlanRepo,
+		minExecuteConfidence: DefaultMinExecuteConfidence,
 	}
 }
 
+// SetMinExecuteConfidence sets the confidence (0-100) an EXECUTE decision needs; below it the user is asked to confirm
+func (e *AIDecisionEngine) SetMinExecuteConfidence(confidence int) {
+	e.minExecuteConfidence = confidence
+}
+
+// SetRequirePlanApproval makes generated plans wait in pending approval until a user approves or rejects them
+func (e *AIDecisionEngine) SetRequirePlanApproval(required bool) {
+	e.requirePlanApproval = required
+}
+
 // ExploreAndAnalyze analyzes user request with agent context and returns structured analysis
 func (e *AIDecisionEngine) ExploreAndAnalyze(ctx context.Context, userInput, userID, agentContext, requestID string) (*domain.Analysis, error) {
 	systemPrompt := `You are an AI orchestrator. You have access to the following agents and their capabilities:
@@ -61,7 +81,7 @@ Request: %s
 
 Analyze this request based on available agents.`, userID, userInput)
 
-	response, err := e.aiProvider.CallAI(ctx, systemPrompt, userPrompt)
+	response, err := e.callAI(ctx, systemPrompt, userPrompt)
 	if err != nil {
 		return nil, fmt.Errorf("AI call failed: %w", err)
 	}
@@ -78,16 +98,28 @@ Analyze this request based on available agents.`, userID, userInput)
 	return domain.NewAnalysis(requestID, intent, category, confidence, requiredAgents, reasoning), nil
 }
 
-// MakeDecision determines whether to clarify or execute based on analysis
+// callAI makes a planning call and records the consumed tokens on the request's usage recorder
+func (e *AIDecisionEngine) callAI(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	response, usage, err := e.aiProvider.CallAIWithUsage(ctx, systemPrompt, userPrompt, planningCallOptions)
+	if err != nil {
+		return "", err
+	}
+	aiDomain.RecordUsage(ctx, usage)
+	return response, nil
+}
+
+// MakeDecision determines whether to clarify, execute or reject based on analysis
 // Returns planning decisions only - orchestrator handles execution coordination
 func (e *AIDecisionEngine) MakeDecision(ctx context.Context, userInput, userID string, analysis *domain.Analysis, requestID string) (*orchestratorDomain.Decision, error) {
-	systemPrompt := `You are an AI orchestrator that decides whether to ask for clarification or execute a request.
+	systemPrompt := `You are an AI orchestrator that decides whether to ask for clarification, execute or reject a request.
 
 Based on the provided analysis, you must:
 
-1. ASSESS if you need clarification (confidence < 80 percent OR complex multi-step request)
-2. IF clarification needed: Generate a helpful clarification question
-3. IF ready to execute: Provide comprehensive execution plan with agent coordination
+1. ASSESS if the request is unsafe or outside the scope of the available agents
+2. IF unsafe or out of scope: Reject it with a short reason the user can understand
+3. ASSESS if you need clarification (confidence < 80 percent OR complex multi-step request)
+4. IF clarification needed: Generate a helpful clarification question
+5. IF ready to execute: Provide comprehensive execution plan with agent coordination
 
 Your analysis includes graph context with available agents and capabilities. When generating execution plans, you MUST:
 - Reference specific agents by name that were found in the graph exploration
@@ -96,14 +128,18 @@ Your analysis includes graph context with available agents and capabilities. Whe
 
 Respond in this EXACT format:
 
-DECISION: [CLARIFY|EXECUTE]
+DECISION: [CLARIFY|EXECUTE|REJECT]
 CONFIDENCE: [0-100]
 REASONING: [why this decision]
 
 [If CLARIFY]:
 CLARIFICATION: [specific question to ask]
 
+[If REJECT]:
+REJECT: [why the request cannot be handled]
+
 [If EXECUTE]:
+PRIORITY: [LOW|MEDIUM|HIGH|CRITICAL - how urgent the request is; MEDIUM unless the user says otherwise]
 EXECUTION_PLAN_JSON:
 {
   "steps": [
@@ -111,17 +147,24 @@ EXECUTION_PLAN_JSON:
       "step_number": 1,
       "agent_name": "exact-agent-name-from-analysis",
       "action_description": "specific action description",
-      "step_name": "brief step name"
+      "step_name": "brief step name",
+      "inputs": {"input_name": "value the agent's capability requires"},
+      "estimated_duration": 5
     },
     {
       "step_number": 2,
       "agent_name": "exact-agent-name-from-analysis", 
       "action_description": "specific action description",
-      "step_name": "brief step name"
+      "step_name": "brief step name",
+      "depends_on": [1]
     }
   ]
 }
 
+"estimated_duration" is the expected duration of the step in minutes.
+Steps run in step_number order unless any step lists "depends_on": the step numbers that must complete before it starts.
+Steps without dependencies on each other then run in parallel.
+
 AGENT_COORDINATION:
 - Primary Agent: [specific agent name from analysis and why]
 - Supporting Agents: [list specific agent names and roles]
@@ -139,18 +182,52 @@ Original Request: %s
 ANALYSIS:
 %s
 
-Based on this analysis, decide whether to clarify or execute.`, userID, userInput, analysisText)
+Based on this analysis, decide whether to clarify, execute or reject.`, userID, userInput, analysisText)
 
-	response, err := e.aiProvider.CallAI(ctx, systemPrompt, userPrompt)
+	// Follow-up turns build on the decisions already made in the conversation
+	if priorDecisions := domain.PriorDecisions(ctx); len(priorDecisions) > 0 {
+		userPrompt += "\n\nPREVIOUS DECISIONS IN THIS CONVERSATION (oldest first):\n" + domain.FormatPriorDecisions(priorDecisions)
+	}
+	if history := domain.ConversationHistory(ctx); history != "" {
+		userPrompt += "\n\nCONVERSATION HISTORY:\n" + history
+	}
+
+	response, err := e.callAI(ctx, systemPrompt, userPrompt)
 	if err != nil {
 		return nil, fmt.Errorf("AI call failed: %w", err)
 	}
 
+	// The decision's own confidence wins; the analysis confidence covers responses that omit it
+	confidence := analysis.Confidence
+	if confidenceStr := e.responseParser.ExtractSection(response, "CONFIDENCE:"); confidenceStr != "" {
+		confidence = e.responseParser.ParseConfidence(confidenceStr)
+	}
+
 	// Parse the decision
 	if strings.Contains(response, "DECISION: CLARIFY") {
 		clarificationQuestion := e.responseParser.ExtractSection(response, "CLARIFICATION:")
 		reasoning := e.responseParser.ExtractSection(response, "REASONING:")
-		return orchestratorDomain.NewClarifyDecision(requestID, analysis.ID, clarificationQuestion, reasoning), nil
+		decision := orchestratorDomain.NewClarifyDecision(requestID, analysis.ID, clarificationQuestion, reasoning)
+		decision.Confidence = confidence
+		return decision, nil
+	}
+
+	// Rejected requests never get an execution plan
+	if strings.Contains(response, "DECISION: REJECT") {
+		rejectionReason := e.responseParser.ExtractSection(response, "REJECT:")
+		reasoning := e.responseParser.ExtractSection(response, "REASONING:")
+		decision := orchestratorDomain.NewRejectDecision(requestID, analysis.ID, rejectionReason, reasoning)
+		decision.Confidence = confidence
+		return decision, nil
+	}
+
+	// Low-confidence executions are confirmed with the user before any plan is created
+	if confidence < e.minExecuteConfidence {
+		clarificationQuestion := fmt.Sprintf("I'm only %d%% confident I understood your request (%s). Could you confirm that this is what you want, or rephrase it?", confidence, analysis.Intent)
+		reasoning := fmt.Sprintf("EXECUTE downgraded to CLARIFY: confidence %d is below the minimum of %d. %s", confidence, e.minExecuteConfidence, e.responseParser.ExtractSection(response, "REASONING:"))
+		decision := orchestratorDomain.NewClarifyDecision(requestID, analysis.ID, clarificationQuestion, strings.TrimSpace(reasoning))
+		decision.Confidence = confidence
+		return decision, nil
 	}
 
 	// For execution decisions, create and persist structured ExecutionPlan
@@ -161,19 +238,28 @@ Based on this analysis, decide whether to clarify or execute.`, userID, userInpu
 	// If we have an ExecutionPlanRepository, create and persist structured plan
 	var executionPlanID string
 	if e.executionPlanRepo != nil {
+		// Create the ExecutionPlan first so its steps are created with plan-scoped IDs
+		// The plan's priority orders its steps in the dispatch queue
+		priority := domain.ParseExecutionPlanPriority(e.responseParser.ExtractSection(response, "PRIORITY:"))
+		plan := domain.NewExecutionPlan("AI Generated Plan", "Plan generated by AI decision engine", priority)
+		plan.Request = userInput
+		plan.UserID = userID
+
 		// Parse the JSON execution plan into structured steps
-		steps, err := e.parseExecutionPlanJSON(executionPlanJSON)
+		steps, err := e.parseExecutionPlanJSON(plan.ID, executionPlanJSON)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse execution plan JSON: %w", err)
 		}
-
-		// Create ExecutionPlan with steps
-		plan := domain.NewExecutionPlan("AI Generated Plan", "Plan generated by AI decision engine", domain.ExecutionPlanPriorityMedium)
 		for _, step := range steps {
 			if err := plan.AddStep(step); err != nil {
 				return nil, fmt.Errorf("failed to add step to plan: %w", err)
 			}
 		}
+		if e.requirePlanApproval {
+			if err := plan.RequireApproval(); err != nil {
+				return nil, fmt.Errorf("failed to hold plan for approval: %w", err)
+			}
+		}
 
 		// Persist the plan to the graph
 		if err := e.executionPlanRepo.Create(ctx, plan); err != nil {
@@ -193,11 +279,13 @@ Based on this analysis, decide whether to clarify or execute.`, userID, userInpu
 
 	// Return a planning recommendation that execution should happen
 	// Note: This creates a unified decision for now, but orchestrator coordinates domains
-	return orchestratorDomain.NewExecuteDecision(requestID, analysis.ID, executionPlanID, agentCoordination, reasoning), nil
+	decision := orchestratorDomain.NewExecuteDecision(requestID, analysis.ID, executionPlanID, agentCoordination, reasoning)
+	decision.Confidence = confidence
+	return decision, nil
 }
 
-// parseExecutionPlanJSON parses JSON execution plan into structured steps
-func (e *AIDecisionEngine) parseExecutionPlanJSON(jsonStr string) ([]*domain.ExecutionStep, error) {
+// parseExecutionPlanJSON parses JSON execution plan into structured steps of the given plan
+func (e *AIDecisionEngine) parseExecutionPlanJSON(planID, jsonStr string) ([]*domain.ExecutionStep, error) {
 	// Clean up the JSON string
 	jsonStr = strings.TrimSpace(jsonStr)
 	if jsonStr == "" {
@@ -206,10 +294,13 @@ func (e *AIDecisionEngine) parseExecutionPlanJSON(jsonStr string) ([]*domain.Exe
 
 	// Define the JSON structure we expect from the AI
 	type StepJSON struct {
-		StepNumber        int    `json:"step_number"`
-		AgentName         string `json:"agent_name"`
-		ActionDescription string `json:"action_description"`
-		StepName          string `json:"step_name"`
+		StepNumber        int                    `json:"step_number"`
+		AgentName         string                 `json:"agent_name"`
+		ActionDescription string                 `json:"action_description"`
+		StepName          string                 `json:"step_name"`
+		Inputs            map[string]interface{} `json:"inputs,omitempty"`
+		DependsOn         []int                  `json:"depends_on,omitempty"`
+		EstimatedDuration int                    `json:"estimated_duration,omitempty"` // Minutes
 	}
 
 	type ExecutionPlanJSON struct {
@@ -245,10 +336,29 @@ func (e *AIDecisionEngine) parseExecutionPlanJSON(jsonStr string) ([]*domain.Exe
 		}
 
 		// Create ExecutionStep
-		step := domain.NewExecutionStep(stepName, stepJSON.ActionDescription, stepJSON.AgentName)
+		step := domain.NewExecutionStepForPlan(planID, stepName, stepJSON.ActionDescription, stepJSON.AgentName)
 		step.StepNumber = stepJSON.StepNumber
+		step.EstimatedDuration = stepJSON.EstimatedDuration
+		if err := step.SetInputs(stepJSON.Inputs); err != nil {
+			return nil, fmt.Errorf("step %d: %w", stepJSON.StepNumber, err)
+		}
 		steps = append(steps, step)
 	}
 
+	// Dependencies refer to step numbers, which only identify steps once every step is parsed
+	byNumber := make(map[int]*domain.ExecutionStep, len(steps))
+	for _, step := range steps {
+		byNumber[step.StepNumber] = step
+	}
+	for i, stepJSON := range planJSON.Steps {
+		for _, number := range stepJSON.DependsOn {
+			prerequisite, ok := byNumber[number]
+			if !ok {
+				return nil, fmt.Errorf("step %d depends on unknown step %d", stepJSON.StepNumber, number)
+			}
+			steps[i].DependOn(prerequisite)
+		}
+	}
+
 	return steps, nil
 }
@@ -2,15 +2,56 @@ package application
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	aiDomain "neuromesh/internal/ai/domain"
 	orchestratorDomain "neuromesh/internal/orchestrator/domain"
 	"neuromesh/internal/planning/domain"
 	"neuromesh/testHelpers"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// recordingAIProvider returns a canned response and records the user prompt and options of each call
+type recordingAIProvider struct {
+	response string
+	prompts  []string
+	options  []aiDomain.CallOptions
+	usage    aiDomain.Usage
+}
+
+func (r *recordingAIProvider) CallAI(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	return r.CallAIWithOptions(ctx, systemPrompt, userPrompt, aiDomain.CallOptions{})
+}
+
+func (r *recordingAIProvider) CallAIWithOptions(ctx context.Context, systemPrompt, userPrompt string, opts aiDomain.CallOptions) (string, error) {
+	r.prompts = append(r.prompts, userPrompt)
+	r.options = append(r.options, opts)
+	return r.response, nil
+}
+
+func (r *recordingAIProvider) CallAIWithUsage(ctx context.Context, systemPrompt, userPrompt string, opts aiDomain.CallOptions) (string, aiDomain.Usage, error) {
+	response, err := r.CallAIWithOptions(ctx, systemPrompt, userPrompt, opts)
+	return response, r.usage, err
+}
+
+func (r *recordingAIProvider) CallAIStream(ctx context.Context, systemPrompt, userPrompt string) (<-chan string, error) {
+	chunks := make(chan string, 1)
+	chunks <- r.response
+	close(chunks)
+	return chunks, nil
+}
+
+func (r *recordingAIProvider) GetProviderInfo() *aiDomain.ProviderInfo {
+	return &aiDomain.ProviderInfo{Name: "recording", Model: "recording"}
+}
+
+func (r *recordingAIProvider) Close() error {
+	return nil
+}
+
 func TestAIDecisionEngine_ExploreAndAnalyze(t *testing.T) {
 	t.Run("should analyze user request with agent context using real AI", func(t *testing.T) {
 		aiProvider := testHelpers.SetupRealAIProvider(t)
@@ -140,3 +181,215 @@ func TestAIDecisionEngine_MakeDecision_WithExecutionPlanPersistence(t *testing.T
 		}
 	})
 }
+
+func TestAIDecisionEngine_UsesDeterministicTemperature(t *testing.T) {
+	provider := &recordingAIProvider{response: "ANALYSIS:\nIntent: deploy\nCategory: deployment\nConfidence: 40\nRequired_Agents: none\nReasoning: unclear\n\nDECISION: CLARIFY\nCLARIFICATION: Which environment?\nREASONING: missing target"}
+	engine := NewAIDecisionEngine(provider)
+
+	analysis, err := engine.ExploreAndAnalyze(context.Background(), "Deploy it", "user-123", "deploy-agent", "req-1")
+	require.NoError(t, err)
+	_, err = engine.MakeDecision(context.Background(), "Deploy it", "user-123", analysis, "req-1")
+	require.NoError(t, err)
+
+	require.Len(t, provider.options, 2)
+	for _, opts := range provider.options {
+		require.NotNil(t, opts.Temperature)
+		assert.Equal(t, float32(0), *opts.Temperature)
+	}
+}
+
+func TestAIDecisionEngine_MakeDecision_IncludesPriorDecisions(t *testing.T) {
+	provider := &recordingAIProvider{response: "DECISION: CLARIFY\nCONFIDENCE: 50\nREASONING: still missing the region\nCLARIFICATION: Which region?"}
+	engine := NewAIDecisionEngine(provider)
+	analysis := &domain.Analysis{ID: "analysis-1", Intent: "deploy", Category: "deployment", Confidence: 50}
+
+	ctx := domain.WithPriorDecisions(context.Background(), []domain.PriorDecision{
+		{Type: "CLARIFY", Confidence: 40, Summary: "Which environment?"},
+		{Type: "CLARIFY", Confidence: 55, Summary: "Which version?"},
+	})
+	_, err := engine.MakeDecision(ctx, "Production, version 2", "user-123", analysis, "req-2")
+	require.NoError(t, err)
+
+	require.Len(t, provider.prompts, 1)
+	assert.Contains(t, provider.prompts[0], "PREVIOUS DECISIONS IN THIS CONVERSATION (oldest first):\n"+
+		"1. CLARIFY (confidence 40%): Which environment?\n"+
+		"2. CLARIFY (confidence 55%): Which version?")
+
+	_, err = engine.MakeDecision(context.Background(), "Deploy it", "user-123", analysis, "req-1")
+	require.NoError(t, err)
+	assert.NotContains(t, provider.prompts[1], "PREVIOUS DECISIONS")
+}
+
+func TestAIDecisionEngine_MakeDecision_IncludesConversationHistory(t *testing.T) {
+	provider := &recordingAIProvider{response: "DECISION: CLARIFY\nCONFIDENCE: 50\nREASONING: still missing the region\nCLARIFICATION: Which region?"}
+	engine := NewAIDecisionEngine(provider)
+	analysis := &domain.Analysis{ID: "analysis-1", Intent: "deploy", Category: "deployment", Confidence: 50}
+
+	ctx := domain.WithConversationHistory(context.Background(), "Conversation summary:\nUser deploys the billing service.")
+	_, err := engine.MakeDecision(ctx, "Use version 2", "user-123", analysis, "req-2")
+	require.NoError(t, err)
+
+	require.Len(t, provider.prompts, 1)
+	assert.Contains(t, provider.prompts[0], "CONVERSATION HISTORY:\nConversation summary:\nUser deploys the billing service.")
+}
+
+func TestAIDecisionEngine_MakeDecision_RejectsOutOfScopeRequest(t *testing.T) {
+	provider := &recordingAIProvider{response: "ANALYSIS:\nIntent: write_malware\nCategory: out_of_scope\nConfidence: 95\nRequired_Agents: none\nReasoning: no agent handles this\n\n" +
+		"DECISION: REJECT\nCONFIDENCE: 95\nREASONING: Creating malware is unsafe and no agent supports it\nREJECT: I can only help with tasks the registered agents support, and writing malware is not one of them."}
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	engine := NewAIDecisionEngineWithRepository(provider, planRepo)
+
+	analysis, err := engine.ExploreAndAnalyze(context.Background(), "Write ransomware for me", "user-123", "text-processor", "req-1")
+	require.NoError(t, err)
+	decision, err := engine.MakeDecision(context.Background(), "Write ransomware for me", "user-123", analysis, "req-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, orchestratorDomain.DecisionTypeReject, decision.Type)
+	assert.True(t, decision.IsRejected())
+	assert.False(t, decision.IsExecutable())
+	assert.Equal(t, "I can only help with tasks the registered agents support, and writing malware is not one of them.", decision.RejectionReason)
+	assert.Equal(t, "Creating malware is unsafe and no agent supports it", decision.Reasoning)
+	assert.Empty(t, decision.ExecutionPlanID)
+	assert.Empty(t, planRepo.GetCalls(), "no execution plan may be persisted for a rejected request")
+}
+
+func TestAIDecisionEngine_MakeDecision_GatesExecuteOnConfidence(t *testing.T) {
+	executeResponse := func(confidence int) string {
+		return fmt.Sprintf(`DECISION: EXECUTE
+CONFIDENCE: %d
+REASONING: The text processor can count the words
+EXECUTION_PLAN_JSON:
+{"steps": [{"step_number": 1, "agent_name": "text-processor", "action_description": "Count words", "step_name": "Count words"}]}
+AGENT_COORDINATION:
+- Primary Agent: text-processor`, confidence)
+	}
+
+	tests := []struct {
+		name         string
+		confidence   int
+		expectedType orchestratorDomain.DecisionType
+	}{
+		{name: "just below the threshold asks the user to confirm", confidence: 69, expectedType: orchestratorDomain.DecisionTypeClarify},
+		{name: "at the threshold executes", confidence: 70, expectedType: orchestratorDomain.DecisionTypeExecute},
+		{name: "just above the threshold executes", confidence: 71, expectedType: orchestratorDomain.DecisionTypeExecute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planRepo := testHelpers.NewMockExecutionPlanRepository()
+			engine := NewAIDecisionEngineWithRepository(&recordingAIProvider{response: executeResponse(tt.confidence)}, planRepo)
+			engine.SetMinExecuteConfidence(70)
+			analysis := domain.NewAnalysis("req-1", "count_words", "text", 90, []string{"text-processor"}, "word count")
+
+			decision, err := engine.MakeDecision(context.Background(), "Count the words", "user-123", analysis, "req-1")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedType, decision.Type)
+			assert.Equal(t, tt.confidence, decision.Confidence)
+			if tt.expectedType == orchestratorDomain.DecisionTypeClarify {
+				assert.Contains(t, decision.ClarificationQuestion, "69% confident")
+				assert.Empty(t, decision.ExecutionPlanID)
+				assert.Empty(t, planRepo.GetCalls(), "no plan may be persisted before the user confirms")
+			} else {
+				assert.NotEmpty(t, decision.ExecutionPlanID)
+			}
+		})
+	}
+}
+
+func TestAIDecisionEngine_MakeDecision_PersistsStepDependencies(t *testing.T) {
+	response := `DECISION: EXECUTE
+CONFIDENCE: 90
+REASONING: Both analyses run on the fetched text
+EXECUTION_PLAN_JSON:
+{"steps": [
+  {"step_number": 1, "agent_name": "fetcher", "action_description": "Fetch the text", "step_name": "Fetch", "estimated_duration": 3},
+  {"step_number": 2, "agent_name": "text-processor", "action_description": "Count words", "step_name": "Count", "depends_on": [1]},
+  {"step_number": 3, "agent_name": "text-processor", "action_description": "Analyze sentiment", "step_name": "Sentiment", "depends_on": [1]},
+  {"step_number": 4, "agent_name": "reporter", "action_description": "Summarize", "step_name": "Report", "depends_on": [2, 3]}
+]}
+AGENT_COORDINATION:
+- Primary Agent: text-processor`
+
+	ctx := context.Background()
+	planRepo := testHelpers.NewMockExecutionPlanRepository()
+	engine := NewAIDecisionEngineWithRepository(&recordingAIProvider{response: response}, planRepo)
+	analysis := domain.NewAnalysis("req-1", "analyze_text", "text", 90, []string{"text-processor"}, "text analysis")
+
+	decision, err := engine.MakeDecision(ctx, "Analyze the text", "user-123", analysis, "req-1")
+	require.NoError(t, err)
+
+	plan, err := planRepo.GetByID(ctx, decision.ExecutionPlanID)
+	require.NoError(t, err)
+	require.Len(t, plan.Steps, 4)
+	assert.True(t, plan.HasStepDependencies())
+	assert.Empty(t, plan.Steps[0].DependsOn)
+	assert.Equal(t, 3, plan.Steps[0].EstimatedDuration)
+	assert.Equal(t, []string{plan.Steps[0].ID}, plan.Steps[1].DependsOn)
+	assert.Equal(t, []string{plan.Steps[0].ID}, plan.Steps[2].DependsOn)
+	assert.Equal(t, []string{plan.Steps[1].ID, plan.Steps[2].ID}, plan.Steps[3].DependsOn)
+	for _, step := range plan.Steps {
+		assert.Equal(t, plan.ID, domain.ParseStepID(step.ID), "step IDs embed their plan ID")
+	}
+
+	t.Run("unknown dependency is rejected", func(t *testing.T) {
+		invalid := `DECISION: EXECUTE
+CONFIDENCE: 90
+REASONING: Count the words
+EXECUTION_PLAN_JSON:
+{"steps": [{"step_number": 1, "agent_name": "text-processor", "action_description": "Count words", "depends_on": [7]}]}`
+		engine := NewAIDecisionEngineWithRepository(&recordingAIProvider{response: invalid}, testHelpers.NewMockExecutionPlanRepository())
+
+		_, err := engine.MakeDecision(ctx, "Count the words", "user-123", analysis, "req-2")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "step 1 depends on unknown step 7")
+	})
+}
+
+func TestAIDecisionEngine_MakeDecision_SetsPlanPriority(t *testing.T) {
+	ctx := context.Background()
+	analysis := domain.NewAnalysis("req-1", "word_count", "text", 90, []string{"text-processor"}, "word count")
+
+	for _, tc := range []struct {
+		name     string
+		priority string
+		expected domain.ExecutionPlanPriority
+	}{
+		{name: "from the decision", priority: "PRIORITY: high\n", expected: domain.ExecutionPlanPriorityHigh},
+		{name: "medium when omitted", expected: domain.ExecutionPlanPriorityMedium},
+		{name: "medium when unknown", priority: "PRIORITY: asap\n", expected: domain.ExecutionPlanPriorityMedium},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			response := "DECISION: EXECUTE\nCONFIDENCE: 90\nREASONING: The user needs it now\n" + tc.priority +
+				`EXECUTION_PLAN_JSON:
+{"steps": [{"step_number": 1, "agent_name": "text-processor", "action_description": "Count words", "step_name": "Count"}]}`
+			planRepo := testHelpers.NewMockExecutionPlanRepository()
+			engine := NewAIDecisionEngineWithRepository(&recordingAIProvider{response: response}, planRepo)
+
+			decision, err := engine.MakeDecision(ctx, "Count the words", "user-123", analysis, "req-1")
+			require.NoError(t, err)
+
+			plan, err := planRepo.GetByID(ctx, decision.ExecutionPlanID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, plan.Priority)
+			assert.Equal(t, "The user needs it now", decision.Reasoning)
+		})
+	}
+}
+
+func TestAIDecisionEngine_RecordsTokenUsage(t *testing.T) {
+	provider := &recordingAIProvider{
+		response: "ANALYSIS:\nIntent: deploy\nCategory: deployment\nConfidence: 40\nRequired_Agents: none\nReasoning: unclear\n\nDECISION: CLARIFY\nCLARIFICATION: Which environment?\nREASONING: missing target",
+		usage:    aiDomain.Usage{PromptTokens: 20, CompletionTokens: 5, TotalTokens: 25},
+	}
+	engine := NewAIDecisionEngine(provider)
+	recorder := aiDomain.NewUsageRecorder()
+	ctx := aiDomain.WithUsageRecorder(context.Background(), recorder)
+
+	analysis, err := engine.ExploreAndAnalyze(ctx, "Deploy it", "user-123", "deploy-agent", "req-1")
+	require.NoError(t, err)
+	_, err = engine.MakeDecision(ctx, "Deploy it", "user-123", analysis, "req-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, aiDomain.Usage{PromptTokens: 40, CompletionTokens: 10, TotalTokens: 50}, recorder.Total())
+}
@@ -0,0 +1,50 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"neuromesh/internal/planning/domain"
+)
+
+// PlanProgressService reports execution progress of plans
+type PlanProgressService struct {
+	executionPlanRepo domain.ExecutionPlanRepository
+	now               func() time.Time
+}
+
+// NewPlanProgressService creates a new plan progress service
+func NewPlanProgressService(executionPlanRepo domain.ExecutionPlanRepository) *PlanProgressService {
+	return &PlanProgressService{
+		executionPlanRepo: executionPlanRepo,
+		now:               time.Now,
+	}
+}
+
+// GetPlanProgress returns step counts by status, percent complete, the currently executing step and the ETA of a plan
+// A non-empty requesterID must own the plan, otherwise ErrPlanAccessDenied is returned; internal callers pass none
+// Progress is derived from the plan and its steps, loaded once per call; unknown plans return graph.ErrNodeNotFound
+func (s *PlanProgressService) GetPlanProgress(ctx context.Context, planID, requesterID string) (domain.PlanProgress, error) {
+	if planID == "" {
+		return domain.PlanProgress{}, fmt.Errorf("plan ID cannot be empty")
+	}
+
+	plan, err := s.executionPlanRepo.GetByID(ctx, planID)
+	if err != nil {
+		return domain.PlanProgress{}, fmt.Errorf("failed to get execution plan: %w", err)
+	}
+	if requesterID != "" && !plan.OwnedBy(requesterID) {
+		return domain.PlanProgress{}, fmt.Errorf("%w: plan %s", domain.ErrPlanAccessDenied, planID)
+	}
+
+	progress := domain.NewPlanProgress(planID, plan.StepStatusCounts(), plan.CurrentStep())
+	if progress.IsComplete() {
+		return progress, nil
+	}
+
+	eta := plan.EstimatedCompletion(s.now())
+	progress.EstimatedCompletion = &eta
+
+	return progress, nil
+}
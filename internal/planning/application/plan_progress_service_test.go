@@ -0,0 +1,131 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"neuromesh/internal/graph"
+	"neuromesh/internal/planning/domain"
+	"neuromesh/testHelpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanProgressService_GetPlanProgress(t *testing.T) {
+	ctx := context.Background()
+
+	newPlan := func(statuses ...domain.ExecutionStepStatus) *domain.ExecutionPlan {
+		plan := domain.NewExecutionPlan("Process text", "Process text", domain.ExecutionPlanPriorityMedium)
+		for _, status := range statuses {
+			step := domain.NewExecutionStep("Step", "Step", "text-processor")
+			step.Status = status
+			plan.AddStep(step)
+		}
+		return plan
+	}
+
+	t.Run("should report empty plan", func(t *testing.T) {
+		repo := testHelpers.NewMockExecutionPlanRepository()
+		plan := newPlan()
+		require.NoError(t, repo.Create(ctx, plan))
+
+		progress, err := NewPlanProgressService(repo).GetPlanProgress(ctx, plan.ID, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, plan.ID, progress.PlanID)
+		assert.Equal(t, 0, progress.TotalSteps)
+		assert.Equal(t, 0.0, progress.PercentComplete)
+		assert.Nil(t, progress.CurrentStep)
+	})
+
+	t.Run("should report partially executed plan", func(t *testing.T) {
+		repo := testHelpers.NewMockExecutionPlanRepository()
+		plan := newPlan(domain.ExecutionStepStatusCompleted, domain.ExecutionStepStatusExecuting, domain.ExecutionStepStatusPending, domain.ExecutionStepStatusPending)
+		require.NoError(t, repo.Create(ctx, plan))
+
+		progress, err := NewPlanProgressService(repo).GetPlanProgress(ctx, plan.ID, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, progress.TotalSteps)
+		assert.Equal(t, 1, progress.StatusCounts[domain.ExecutionStepStatusCompleted])
+		assert.Equal(t, 1, progress.StatusCounts[domain.ExecutionStepStatusExecuting])
+		assert.Equal(t, 2, progress.StatusCounts[domain.ExecutionStepStatusPending])
+		assert.Equal(t, 25.0, progress.PercentComplete)
+		require.NotNil(t, progress.CurrentStep)
+		assert.Equal(t, plan.Steps[1].ID, progress.CurrentStep.ID)
+	})
+
+	t.Run("should report fully completed plan", func(t *testing.T) {
+		repo := testHelpers.NewMockExecutionPlanRepository()
+		plan := newPlan(domain.ExecutionStepStatusCompleted, domain.ExecutionStepStatusCompleted)
+		require.NoError(t, repo.Create(ctx, plan))
+
+		progress, err := NewPlanProgressService(repo).GetPlanProgress(ctx, plan.ID, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, progress.TotalSteps)
+		assert.Equal(t, 100.0, progress.PercentComplete)
+		assert.True(t, progress.IsComplete())
+		assert.Nil(t, progress.CurrentStep)
+		assert.Nil(t, progress.EstimatedCompletion)
+	})
+
+	t.Run("should estimate completion of unfinished plan", func(t *testing.T) {
+		repo := testHelpers.NewMockExecutionPlanRepository()
+		plan := newPlan(domain.ExecutionStepStatusCompleted, domain.ExecutionStepStatusPending, domain.ExecutionStepStatusPending)
+		for _, step := range plan.Steps {
+			step.EstimatedDuration = 10
+		}
+		require.NoError(t, repo.Create(ctx, plan))
+		now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		service := NewPlanProgressService(repo)
+		service.now = func() time.Time { return now }
+
+		progress, err := service.GetPlanProgress(ctx, plan.ID, "")
+
+		require.NoError(t, err)
+		require.NotNil(t, progress.EstimatedCompletion)
+		assert.Equal(t, now.Add(20*time.Minute), *progress.EstimatedCompletion)
+	})
+
+	t.Run("should report progress to the plan's owner only", func(t *testing.T) {
+		repo := testHelpers.NewMockExecutionPlanRepository()
+		plan := newPlan(domain.ExecutionStepStatusCompleted)
+		plan.UserID = "user-1"
+		require.NoError(t, repo.Create(ctx, plan))
+		service := NewPlanProgressService(repo)
+
+		progress, err := service.GetPlanProgress(ctx, plan.ID, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, 1, progress.TotalSteps)
+
+		_, err = service.GetPlanProgress(ctx, plan.ID, "user-2")
+		assert.ErrorIs(t, err, domain.ErrPlanAccessDenied)
+	})
+
+	t.Run("should load the plan once per call", func(t *testing.T) {
+		repo := testHelpers.NewMockExecutionPlanRepository()
+		plan := newPlan(domain.ExecutionStepStatusCompleted, domain.ExecutionStepStatusPending)
+		plan.UserID = "user-1"
+		require.NoError(t, repo.Create(ctx, plan))
+
+		_, err := NewPlanProgressService(repo).GetPlanProgress(ctx, plan.ID, "user-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Create(" + plan.ID + ")", "GetByID(" + plan.ID + ")"}, repo.GetCalls())
+	})
+
+	t.Run("should report unknown plan as not found", func(t *testing.T) {
+		_, err := NewPlanProgressService(testHelpers.NewMockExecutionPlanRepository()).GetPlanProgress(ctx, "missing", "user-1")
+
+		assert.ErrorIs(t, err, graph.ErrNodeNotFound)
+	})
+
+	t.Run("should reject empty plan ID", func(t *testing.T) {
+		_, err := NewPlanProgressService(testHelpers.NewMockExecutionPlanRepository()).GetPlanProgress(ctx, "", "")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MaxPriorDecisions caps how many earlier decisions are included in a decision prompt
+const MaxPriorDecisions = 5
+
+// PriorDecision is the compact form of an earlier decision in the same conversation
+type PriorDecision struct {
+	Type       string
+	Confidence int
+	Summary    string // Clarification question, rejection reason or reasoning
+}
+
+// priorDecisionsKey is the context key carrying the conversation's earlier decisions
+type priorDecisionsKey struct{}
+
+// WithPriorDecisions returns ctx carrying the earlier decisions of the conversation, oldest first
+func WithPriorDecisions(ctx context.Context, decisions []PriorDecision) context.Context {
+	return context.WithValue(ctx, priorDecisionsKey{}, decisions)
+}
+
+// PriorDecisions returns the earlier decisions carried by ctx, oldest first
+func PriorDecisions(ctx context.Context) []PriorDecision {
+	decisions, _ := ctx.Value(priorDecisionsKey{}).([]PriorDecision)
+	return decisions
+}
+
+// conversationHistoryKey is the context key carrying the conversation's prompt history
+type conversationHistoryKey struct{}
+
+// WithConversationHistory returns ctx carrying the conversation summary and recent messages preceding the request
+func WithConversationHistory(ctx context.Context, history string) context.Context {
+	return context.WithValue(ctx, conversationHistoryKey{}, history)
+}
+
+// ConversationHistory returns the conversation history carried by ctx
+func ConversationHistory(ctx context.Context) string {
+	history, _ := ctx.Value(conversationHistoryKey{}).(string)
+	return history
+}
+
+// FormatPriorDecisions renders the most recent earlier decisions as a numbered list for prompts
+func FormatPriorDecisions(decisions []PriorDecision) string {
+	if len(decisions) > MaxPriorDecisions {
+		decisions = decisions[len(decisions)-MaxPriorDecisions:]
+	}
+
+	var b strings.Builder
+	for i, decision := range decisions {
+		fmt.Fprintf(&b, "%d. %s (confidence %d%%): %s\n", i+1, decision.Type, decision.Confidence, decision.Summary)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,11 +14,23 @@ import (
 type ExecutionPlanStatus string
 
 const (
-	ExecutionPlanStatusDraft     ExecutionPlanStatus = "DRAFT"
-	ExecutionPlanStatusApproved  ExecutionPlanStatus = "APPROVED"
-	ExecutionPlanStatusExecuting ExecutionPlanStatus = "EXECUTING"
-	ExecutionPlanStatusCompleted ExecutionPlanStatus = "COMPLETED"
-	ExecutionPlanStatusFailed    ExecutionPlanStatus = "FAILED"
+	ExecutionPlanStatusDraft           ExecutionPlanStatus = "DRAFT"
+	ExecutionPlanStatusPendingApproval ExecutionPlanStatus = "PENDING_APPROVAL" // Waiting for a user to approve or reject it
+	ExecutionPlanStatusApproved        ExecutionPlanStatus = "APPROVED"
+	ExecutionPlanStatusRejected        ExecutionPlanStatus = "REJECTED"
+	ExecutionPlanStatusExecuting       ExecutionPlanStatus = "EXECUTING"
+	ExecutionPlanStatusCompleted       ExecutionPlanStatus = "COMPLETED"
+	ExecutionPlanStatusFailed          ExecutionPlanStatus = "FAILED"
+	ExecutionPlanStatusCancelled       ExecutionPlanStatus = "CANCELLED"
+)
+
+var (
+	// ErrDependencyCycle is returned for plans whose steps depend on each other in a cycle
+	ErrDependencyCycle = errors.New("execution plan has a step dependency cycle")
+	// ErrUnknownStepDependency is returned for plans with a step depending on a step outside the plan
+	ErrUnknownStepDependency = errors.New("step depends on a step outside the plan")
+	// ErrPlanAccessDenied is returned when a user accesses a plan generated for another user's request
+	ErrPlanAccessDenied = errors.New("plan belongs to another user")
 )
 
 // ExecutionPlanPriority represents the priority level of an execution plan
@@ -42,6 +57,10 @@ type ExecutionPlan struct {
 	ActualDuration    int                   `json:"actual_duration"`    // Duration in minutes
 	CanModify         bool                  `json:"can_modify"`
 	Priority          ExecutionPlanPriority `json:"priority"`
+	Request           string                `json:"request,omitempty"`          // User request the plan was generated for
+	UserID            string                `json:"user_id,omitempty"`          // User who made the request; only they may review the plan
+	ReviewedBy        string                `json:"reviewed_by,omitempty"`      // User who approved or rejected the plan
+	RejectionReason   string                `json:"rejection_reason,omitempty"` // Why the reviewer rejected the plan
 	Steps             []*ExecutionStep      `json:"steps,omitempty"`
 }
 
@@ -73,9 +92,72 @@ func (p *ExecutionPlan) Validate() error {
 	if !p.Priority.IsValid() {
 		return fmt.Errorf("invalid execution plan priority: %s", p.Priority)
 	}
+	return p.ValidateDependencies()
+}
+
+// ValidateDependencies ensures every step dependency refers to a step of the plan and no steps wait for each other
+// A dependency cycle would block its steps forever, so the error names the steps forming it
+func (p *ExecutionPlan) ValidateDependencies() error {
+	steps := make(map[string]*ExecutionStep, len(p.Steps))
+	for _, step := range p.Steps {
+		steps[step.ID] = step
+	}
+	for _, step := range p.Steps {
+		for _, prerequisiteID := range step.DependsOn {
+			if _, ok := steps[prerequisiteID]; !ok {
+				return fmt.Errorf("%w: %s depends on %s", ErrUnknownStepDependency, step.label(), prerequisiteID)
+			}
+		}
+	}
+
+	// Depth-first search: reaching a step that is still on the path closes a cycle
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := make(map[string]int, len(p.Steps))
+	var path []*ExecutionStep
+	var visit func(step *ExecutionStep) error
+	visit = func(step *ExecutionStep) error {
+		state[step.ID] = onPath
+		path = append(path, step)
+		for _, prerequisiteID := range step.DependsOn {
+			prerequisite := steps[prerequisiteID]
+			switch state[prerequisiteID] {
+			case onPath:
+				return dependencyCycleError(path, prerequisite)
+			case unvisited:
+				if err := visit(prerequisite); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[step.ID] = done
+		return nil
+	}
+	for _, step := range p.Steps {
+		if state[step.ID] == unvisited {
+			if err := visit(step); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
+// dependencyCycleError names the steps of the cycle that closes when the last step of path depends on repeated
+func dependencyCycleError(path []*ExecutionStep, repeated *ExecutionStep) error {
+	start := slices.Index(path, repeated)
+	labels := make([]string, 0, len(path)-start+1)
+	for _, step := range path[start:] {
+		labels = append(labels, step.label())
+	}
+	labels = append(labels, repeated.label())
+	return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(labels, " -> "))
+}
+
 // AddStep adds a new step to the execution plan
 func (p *ExecutionPlan) AddStep(step *ExecutionStep) error {
 	if step == nil {
@@ -103,6 +185,26 @@ func (p *ExecutionPlan) GetStepByNumber(stepNumber int) *ExecutionStep {
 	return nil
 }
 
+// GetStepByID retrieves a step by its ID
+func (p *ExecutionPlan) GetStepByID(stepID string) *ExecutionStep {
+	for _, step := range p.Steps {
+		if step.ID == stepID {
+			return step
+		}
+	}
+	return nil
+}
+
+// HasStepDependencies returns true if any step must wait for other steps, making the plan a DAG rather than a sequence
+func (p *ExecutionPlan) HasStepDependencies() bool {
+	for _, step := range p.Steps {
+		if len(step.DependsOn) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // GetStepsByStatus retrieves all steps with a specific status
 func (p *ExecutionPlan) GetStepsByStatus(status ExecutionStepStatus) []*ExecutionStep {
 	var steps []*ExecutionStep
@@ -114,6 +216,26 @@ func (p *ExecutionPlan) GetStepsByStatus(status ExecutionStepStatus) []*Executio
 	return steps
 }
 
+// StepStatusCounts counts the plan's steps by status
+func (p *ExecutionPlan) StepStatusCounts() map[ExecutionStepStatus]int {
+	counts := make(map[ExecutionStepStatus]int)
+	for _, step := range p.Steps {
+		counts[step.Status]++
+	}
+	return counts
+}
+
+// CurrentStep returns the lowest-numbered executing step, or nil if no step is executing
+func (p *ExecutionPlan) CurrentStep() *ExecutionStep {
+	var current *ExecutionStep
+	for _, step := range p.Steps {
+		if step.Status == ExecutionStepStatusExecuting && (current == nil || step.StepNumber < current.StepNumber) {
+			current = step
+		}
+	}
+	return current
+}
+
 // GetNextStep returns the next step to be executed
 func (p *ExecutionPlan) GetNextStep() *ExecutionStep {
 	for _, step := range p.Steps {
@@ -131,6 +253,48 @@ func (p *ExecutionPlan) Approve() {
 	p.ApprovedAt = &now
 }
 
+// RequireApproval holds a draft plan until a user approves or rejects it
+func (p *ExecutionPlan) RequireApproval() error {
+	if p.Status != ExecutionPlanStatusDraft {
+		return fmt.Errorf("only draft plans can require approval, plan is %s", p.Status)
+	}
+	p.Status = ExecutionPlanStatusPendingApproval
+	return nil
+}
+
+// AwaitsApproval returns true if the plan must be approved before it can execute
+func (p *ExecutionPlan) AwaitsApproval() bool {
+	return p.Status == ExecutionPlanStatusPendingApproval
+}
+
+// OwnedBy reports whether the plan was generated for userID's request
+func (p *ExecutionPlan) OwnedBy(userID string) bool {
+	return p.UserID != "" && p.UserID == userID
+}
+
+// ApproveBy records a reviewer's approval of a plan awaiting approval
+func (p *ExecutionPlan) ApproveBy(reviewerID string) error {
+	if !p.AwaitsApproval() {
+		return fmt.Errorf("plan must be pending approval to approve, plan is %s", p.Status)
+	}
+	p.Approve()
+	p.ReviewedBy = reviewerID
+	return nil
+}
+
+// Reject records a reviewer's rejection of a plan awaiting approval; a rejected plan never executes
+func (p *ExecutionPlan) Reject(reviewerID, reason string) error {
+	if !p.AwaitsApproval() {
+		return fmt.Errorf("plan must be pending approval to reject, plan is %s", p.Status)
+	}
+	p.Status = ExecutionPlanStatusRejected
+	p.ReviewedBy = reviewerID
+	p.RejectionReason = reason
+	now := time.Now()
+	p.CompletedAt = &now
+	return nil
+}
+
 // Start marks the plan as executing and sets the start timestamp
 func (p *ExecutionPlan) Start() error {
 	if p.Status != ExecutionPlanStatusApproved {
@@ -170,9 +334,105 @@ func (p *ExecutionPlan) Fail() {
 	}
 }
 
-// IsComplete returns true if the plan is completed or failed
+// Cancel marks the plan and all of its unfinished steps as cancelled
+// Only plans that have not completed, failed or already been cancelled can be cancelled
+func (p *ExecutionPlan) Cancel() error {
+	if p.IsComplete() {
+		return fmt.Errorf("cannot cancel plan in %s status", p.Status)
+	}
+
+	for _, step := range p.Steps {
+		if step.IsComplete() {
+			continue
+		}
+		if err := step.Cancel(); err != nil {
+			return fmt.Errorf("failed to cancel step %s: %w", step.ID, err)
+		}
+	}
+
+	p.Status = ExecutionPlanStatusCancelled
+	now := time.Now()
+	p.CompletedAt = &now
+
+	// Calculate actual duration
+	if p.StartedAt != nil {
+		p.ActualDuration = int(now.Sub(*p.StartedAt).Minutes())
+	}
+	return nil
+}
+
+// IsComplete returns true if the plan is completed, failed, cancelled, or rejected
 func (p *ExecutionPlan) IsComplete() bool {
-	return p.Status == ExecutionPlanStatusCompleted || p.Status == ExecutionPlanStatusFailed
+	return p.Status == ExecutionPlanStatusCompleted || p.Status == ExecutionPlanStatusFailed || p.Status == ExecutionPlanStatusCancelled || p.Status == ExecutionPlanStatusRejected
+}
+
+// EstimatedCompletion estimates when the plan finishes from the estimated durations of its unfinished steps
+// Steps run one after another unless they declare dependencies, in which case the longest chain of dependent steps sets the pace
+func (p *ExecutionPlan) EstimatedCompletion(now time.Time) time.Time {
+	if p.IsComplete() && p.CompletedAt != nil {
+		return *p.CompletedAt
+	}
+	return now.Add(p.criticalPath(func(step *ExecutionStep) time.Duration {
+		return step.remainingEstimate(now)
+	}))
+}
+
+// ActualVsEstimated returns how much longer (positive) or shorter (negative) a finished plan ran than its steps were estimated to take
+// The second result is false until the plan has both started and completed
+func (p *ExecutionPlan) ActualVsEstimated() (time.Duration, bool) {
+	if p.StartedAt == nil || p.CompletedAt == nil {
+		return 0, false
+	}
+
+	estimated := p.criticalPath(func(step *ExecutionStep) time.Duration {
+		return time.Duration(step.EstimatedDuration) * time.Minute
+	})
+	if estimated == 0 {
+		estimated = time.Duration(p.EstimatedDuration) * time.Minute
+	}
+	return p.CompletedAt.Sub(*p.StartedAt) - estimated, true
+}
+
+// criticalPath returns the time the plan's steps take when each step lasts duration(step)
+// Without valid dependencies the steps are sequential, so their durations add up
+func (p *ExecutionPlan) criticalPath(duration func(step *ExecutionStep) time.Duration) time.Duration {
+	if !p.HasStepDependencies() || p.ValidateDependencies() != nil {
+		var total time.Duration
+		for _, step := range p.Steps {
+			total += duration(step)
+		}
+		return total
+	}
+
+	steps := make(map[string]*ExecutionStep, len(p.Steps))
+	for _, step := range p.Steps {
+		steps[step.ID] = step
+	}
+	// A step finishes its own duration after the last of its prerequisites
+	finish := make(map[string]time.Duration, len(p.Steps))
+	var finishOf func(step *ExecutionStep) time.Duration
+	finishOf = func(step *ExecutionStep) time.Duration {
+		if end, ok := finish[step.ID]; ok {
+			return end
+		}
+		var start time.Duration
+		for _, prerequisiteID := range step.DependsOn {
+			start = max(start, finishOf(steps[prerequisiteID]))
+		}
+		finish[step.ID] = start + duration(step)
+		return finish[step.ID]
+	}
+
+	var longest time.Duration
+	for _, step := range p.Steps {
+		longest = max(longest, finishOf(step))
+	}
+	return longest
+}
+
+// NonTerminalExecutionPlanStatuses returns the statuses of plans that have not finished
+func NonTerminalExecutionPlanStatuses() []ExecutionPlanStatus {
+	return []ExecutionPlanStatus{ExecutionPlanStatusDraft, ExecutionPlanStatusPendingApproval, ExecutionPlanStatusApproved, ExecutionPlanStatusExecuting}
 }
 
 // IsExecutable returns true if the plan can be executed
@@ -197,6 +457,10 @@ func (p *ExecutionPlan) ToMap() map[string]interface{} {
 		"actual_duration":    p.ActualDuration,
 		"can_modify":         p.CanModify,
 		"priority":           string(p.Priority),
+		"request":            p.Request,
+		"user_id":            p.UserID,
+		"reviewed_by":        p.ReviewedBy,
+		"rejection_reason":   p.RejectionReason,
 	}
 
 	if p.ApprovedAt != nil {
@@ -215,7 +479,8 @@ func (p *ExecutionPlan) ToMap() map[string]interface{} {
 // IsValid validates the ExecutionPlanStatus
 func (s ExecutionPlanStatus) IsValid() bool {
 	switch s {
-	case ExecutionPlanStatusDraft, ExecutionPlanStatusApproved, ExecutionPlanStatusExecuting, ExecutionPlanStatusCompleted, ExecutionPlanStatusFailed:
+	case ExecutionPlanStatusDraft, ExecutionPlanStatusPendingApproval, ExecutionPlanStatusApproved, ExecutionPlanStatusRejected,
+		ExecutionPlanStatusExecuting, ExecutionPlanStatusCompleted, ExecutionPlanStatusFailed, ExecutionPlanStatusCancelled:
 		return true
 	default:
 		return false
@@ -231,3 +496,26 @@ func (p ExecutionPlanPriority) IsValid() bool {
 		return false
 	}
 }
+
+// ParseExecutionPlanPriority reads a priority case-insensitively, defaulting to medium for anything unknown
+func ParseExecutionPlanPriority(value string) ExecutionPlanPriority {
+	priority := ExecutionPlanPriority(strings.ToUpper(strings.TrimSpace(value)))
+	if !priority.IsValid() {
+		return ExecutionPlanPriorityMedium
+	}
+	return priority
+}
+
+// Rank orders priorities for scheduling, from 0 for low to 3 for critical; unknown priorities rank as medium
+func (p ExecutionPlanPriority) Rank() int {
+	switch p {
+	case ExecutionPlanPriorityLow:
+		return 0
+	case ExecutionPlanPriorityHigh:
+		return 2
+	case ExecutionPlanPriorityCritical:
+		return 3
+	default:
+		return 1
+	}
+}
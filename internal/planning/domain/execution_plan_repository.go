@@ -9,13 +9,24 @@ type ExecutionPlanRepository interface {
 	GetByID(ctx context.Context, id string) (*ExecutionPlan, error)
 	GetByAnalysisID(ctx context.Context, analysisID string) (*ExecutionPlan, error)
 	Update(ctx context.Context, plan *ExecutionPlan) error
+	UpdateIfStatus(ctx context.Context, plan *ExecutionPlan, expected ExecutionPlanStatus) (bool, error) // Persists the plan only while its stored status is expected
+	CancelPlan(ctx context.Context, planID string) (*ExecutionPlan, error)                               // Cancels the plan and its unfinished steps
+	GetPlansByStatus(ctx context.Context, statuses []ExecutionPlanStatus) ([]*ExecutionPlan, error)
 
 	// Relationship operations
 	LinkToAnalysis(ctx context.Context, analysisID, planID string) error
 
 	// Step operations
 	GetStepsByPlanID(ctx context.Context, planID string) ([]*ExecutionStep, error)
+	GetStepByID(ctx context.Context, stepID string) (*ExecutionStep, error)
 	AddStep(ctx context.Context, step *ExecutionStep) error
 	UpdateStep(ctx context.Context, step *ExecutionStep) error
 	AssignStepToAgent(ctx context.Context, stepID, agentID string) error
+	MarkStepEventPublished(ctx context.Context, stepID string) (bool, error) // Reports whether this call set the flag
+
+	// Progress operations
+	GetStepStatusCounts(ctx context.Context, planID string) (map[ExecutionStepStatus]int, error)
+
+	// Workload operations
+	GetActiveStepCountByAgent(ctx context.Context) (map[string]int, error)
 }
@@ -39,6 +39,11 @@ func (m *MockExecutionPlanRepository) Update(ctx context.Context, plan *Executio
 	return args.Error(0)
 }
 
+func (m *MockExecutionPlanRepository) UpdateIfStatus(ctx context.Context, plan *ExecutionPlan, expected ExecutionPlanStatus) (bool, error) {
+	args := m.Called(ctx, plan, expected)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockExecutionPlanRepository) LinkToAnalysis(ctx context.Context, analysisID, planID string) error {
 	args := m.Called(ctx, analysisID, planID)
 	return args.Error(0)
@@ -52,6 +57,14 @@ func (m *MockExecutionPlanRepository) GetStepsByPlanID(ctx context.Context, plan
 	return args.Get(0).([]*ExecutionStep), args.Error(1)
 }
 
+func (m *MockExecutionPlanRepository) GetStepByID(ctx context.Context, stepID string) (*ExecutionStep, error) {
+	args := m.Called(ctx, stepID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ExecutionStep), args.Error(1)
+}
+
 func (m *MockExecutionPlanRepository) AddStep(ctx context.Context, step *ExecutionStep) error {
 	args := m.Called(ctx, step)
 	return args.Error(0)
@@ -67,6 +80,43 @@ func (m *MockExecutionPlanRepository) AssignStepToAgent(ctx context.Context, ste
 	return args.Error(0)
 }
 
+func (m *MockExecutionPlanRepository) GetStepStatusCounts(ctx context.Context, planID string) (map[ExecutionStepStatus]int, error) {
+	args := m.Called(ctx, planID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[ExecutionStepStatus]int), args.Error(1)
+}
+
+func (m *MockExecutionPlanRepository) CancelPlan(ctx context.Context, planID string) (*ExecutionPlan, error) {
+	args := m.Called(ctx, planID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ExecutionPlan), args.Error(1)
+}
+
+func (m *MockExecutionPlanRepository) GetPlansByStatus(ctx context.Context, statuses []ExecutionPlanStatus) ([]*ExecutionPlan, error) {
+	args := m.Called(ctx, statuses)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*ExecutionPlan), args.Error(1)
+}
+
+func (m *MockExecutionPlanRepository) MarkStepEventPublished(ctx context.Context, stepID string) (bool, error) {
+	args := m.Called(ctx, stepID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockExecutionPlanRepository) GetActiveStepCountByAgent(ctx context.Context) (map[string]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
 func TestExecutionPlanRepository_Interface(t *testing.T) {
 	// This test ensures our mock implements the interface correctly
 	var repo ExecutionPlanRepository = &MockExecutionPlanRepository{}
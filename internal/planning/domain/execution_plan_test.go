@@ -151,6 +151,28 @@ func TestExecutionPlan_GetStepsByStatus(t *testing.T) {
 	assert.Equal(t, step1, pendingSteps[0])
 }
 
+func TestExecutionPlan_StepStatusCountsAndCurrentStep(t *testing.T) {
+	plan := NewExecutionPlan("Test Plan", "Description", ExecutionPlanPriorityMedium)
+	assert.Empty(t, plan.StepStatusCounts())
+	assert.Nil(t, plan.CurrentStep())
+
+	step1 := NewExecutionStep("Step 1", "First step", "agent-1")
+	step2 := NewExecutionStep("Step 2", "Second step", "agent-2")
+	step3 := NewExecutionStep("Step 3", "Third step", "agent-3")
+	step1.Status = ExecutionStepStatusCompleted
+	step2.Status = ExecutionStepStatusExecuting
+	step3.Status = ExecutionStepStatusExecuting
+	plan.AddStep(step1)
+	plan.AddStep(step2)
+	plan.AddStep(step3)
+
+	assert.Equal(t, map[ExecutionStepStatus]int{
+		ExecutionStepStatusCompleted: 1,
+		ExecutionStepStatusExecuting: 2,
+	}, plan.StepStatusCounts())
+	assert.Equal(t, step2, plan.CurrentStep())
+}
+
 func TestExecutionPlan_GetNextStep(t *testing.T) {
 	plan := NewExecutionPlan("Test Plan", "Description", ExecutionPlanPriorityMedium)
 	step1 := NewExecutionStep("Step 1", "First step", "agent-1")
@@ -202,6 +224,230 @@ func TestExecutionPlan_StatusTransitions_Invalid(t *testing.T) {
 	assert.Contains(t, err.Error(), "must be executing")
 }
 
+func TestExecutionPlan_Cancel(t *testing.T) {
+	t.Run("cascades to unfinished steps", func(t *testing.T) {
+		plan := NewExecutionPlan("Test Plan", "Description", ExecutionPlanPriorityMedium)
+		done := NewExecutionStep("Build", "Build app", "agent-1")
+		running := NewExecutionStep("Deploy", "Deploy app", "agent-2")
+		waiting := NewExecutionStep("Verify", "Verify app", "agent-3")
+		plan.AddStep(done)
+		plan.AddStep(running)
+		plan.AddStep(waiting)
+		plan.Approve()
+		require.NoError(t, plan.Start())
+		done.Status = ExecutionStepStatusCompleted
+		running.Status = ExecutionStepStatusExecuting
+
+		require.NoError(t, plan.Cancel())
+
+		assert.Equal(t, ExecutionPlanStatusCancelled, plan.Status)
+		assert.NotNil(t, plan.CompletedAt)
+		assert.True(t, plan.IsComplete())
+		assert.Equal(t, ExecutionStepStatusCompleted, done.Status)
+		assert.Equal(t, ExecutionStepStatusCancelled, running.Status)
+		assert.Equal(t, ExecutionStepStatusCancelled, waiting.Status)
+	})
+
+	t.Run("completed plan is rejected", func(t *testing.T) {
+		plan := NewExecutionPlan("Test Plan", "Description", ExecutionPlanPriorityMedium)
+		plan.Approve()
+		require.NoError(t, plan.Start())
+		require.NoError(t, plan.Complete())
+
+		err := plan.Cancel()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot cancel")
+		assert.Equal(t, ExecutionPlanStatusCompleted, plan.Status)
+	})
+}
+
+func TestExecutionPlan_Approval(t *testing.T) {
+	t.Run("approved plan becomes executable", func(t *testing.T) {
+		plan := NewExecutionPlan("Test Plan", "Description", ExecutionPlanPriorityMedium)
+		plan.AddStep(NewExecutionStep("Step 1", "First step", "agent-1"))
+		require.NoError(t, plan.RequireApproval())
+		assert.True(t, plan.AwaitsApproval())
+		assert.False(t, plan.IsExecutable())
+
+		require.NoError(t, plan.ApproveBy("reviewer-1"))
+
+		assert.Equal(t, ExecutionPlanStatusApproved, plan.Status)
+		assert.Equal(t, "reviewer-1", plan.ReviewedBy)
+		assert.True(t, plan.IsExecutable())
+	})
+
+	t.Run("rejected plan is complete and cannot be approved", func(t *testing.T) {
+		plan := NewExecutionPlan("Test Plan", "Description", ExecutionPlanPriorityMedium)
+		require.NoError(t, plan.RequireApproval())
+
+		require.NoError(t, plan.Reject("reviewer-1", "too risky"))
+
+		assert.Equal(t, ExecutionPlanStatusRejected, plan.Status)
+		assert.Equal(t, "too risky", plan.RejectionReason)
+		assert.True(t, plan.IsComplete())
+		assert.NotNil(t, plan.CompletedAt)
+		assert.Error(t, plan.ApproveBy("reviewer-2"))
+	})
+
+	t.Run("only draft plans can require approval", func(t *testing.T) {
+		plan := NewExecutionPlan("Test Plan", "Description", ExecutionPlanPriorityMedium)
+		plan.Approve()
+
+		assert.Error(t, plan.RequireApproval())
+		assert.Error(t, plan.Reject("reviewer-1", "late"))
+	})
+}
+
+func TestExecutionPlan_StepDependencies(t *testing.T) {
+	plan := NewExecutionPlan("Test Plan", "Description", ExecutionPlanPriorityMedium)
+	build := NewExecutionStep("Build", "Build app", "agent-1")
+	deploy := NewExecutionStep("Deploy", "Deploy app", "agent-2")
+	plan.AddStep(build)
+	plan.AddStep(deploy)
+	assert.False(t, plan.HasStepDependencies())
+
+	deploy.DependOn(build)
+	deploy.DependOn(build)
+
+	assert.Equal(t, []string{build.ID}, deploy.DependsOn, "a prerequisite is recorded once")
+	assert.True(t, plan.HasStepDependencies())
+	assert.Same(t, deploy, plan.GetStepByID(deploy.ID))
+	assert.Nil(t, plan.GetStepByID("missing"))
+}
+
+func TestExecutionPlan_ValidateDependencies(t *testing.T) {
+	newPlan := func() (*ExecutionPlan, *ExecutionStep, *ExecutionStep, *ExecutionStep) {
+		plan := NewExecutionPlan("Test Plan", "Description", ExecutionPlanPriorityMedium)
+		fetch := NewExecutionStep("Fetch", "Fetch data", "agent-1")
+		parse := NewExecutionStep("Parse", "Parse data", "agent-2")
+		report := NewExecutionStep("Report", "Report results", "agent-3")
+		plan.AddStep(fetch)
+		plan.AddStep(parse)
+		plan.AddStep(report)
+		return plan, fetch, parse, report
+	}
+
+	t.Run("acyclic plan is valid", func(t *testing.T) {
+		plan, fetch, parse, report := newPlan()
+		parse.DependOn(fetch)
+		report.DependOn(fetch, parse)
+
+		assert.NoError(t, plan.ValidateDependencies())
+		assert.NoError(t, plan.Validate())
+	})
+
+	t.Run("three-step cycle is rejected", func(t *testing.T) {
+		plan, fetch, parse, report := newPlan()
+		parse.DependOn(fetch)
+		report.DependOn(parse)
+		fetch.DependOn(report)
+
+		err := plan.Validate()
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrDependencyCycle)
+		assert.Contains(t, err.Error(), "step 1 (Fetch) -> step 3 (Report) -> step 2 (Parse) -> step 1 (Fetch)")
+	})
+
+	t.Run("step depending on itself is rejected", func(t *testing.T) {
+		plan, fetch, _, _ := newPlan()
+		fetch.DependOn(fetch)
+
+		err := plan.ValidateDependencies()
+		assert.ErrorIs(t, err, ErrDependencyCycle)
+		assert.Contains(t, err.Error(), "step 1 (Fetch) -> step 1 (Fetch)")
+	})
+
+	t.Run("dependency outside the plan is rejected", func(t *testing.T) {
+		plan, _, parse, _ := newPlan()
+		parse.DependsOn = []string{"missing-step"}
+
+		err := plan.ValidateDependencies()
+		assert.ErrorIs(t, err, ErrUnknownStepDependency)
+		assert.Contains(t, err.Error(), "step 2 (Parse) depends on missing-step")
+	})
+}
+
+func TestExecutionPlan_EstimatedCompletion(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	newStep := func(name string, minutes int) *ExecutionStep {
+		step := NewExecutionStep(name, name, "agent-1")
+		step.EstimatedDuration = minutes
+		return step
+	}
+
+	t.Run("sequential steps add up", func(t *testing.T) {
+		plan := NewExecutionPlan("Test Plan", "Description", ExecutionPlanPriorityMedium)
+		build, test, deploy := newStep("Build", 10), newStep("Test", 20), newStep("Deploy", 30)
+		plan.AddStep(build)
+		plan.AddStep(test)
+		plan.AddStep(deploy)
+		assert.Equal(t, now.Add(60*time.Minute), plan.EstimatedCompletion(now))
+
+		// Finished steps no longer count and an executing step counts only its remaining time
+		build.Status = ExecutionStepStatusCompleted
+		startedAt := now.Add(-5 * time.Minute)
+		test.Status = ExecutionStepStatusExecuting
+		test.StartedAt = &startedAt
+		assert.Equal(t, now.Add(45*time.Minute), plan.EstimatedCompletion(now))
+	})
+
+	t.Run("parallel steps follow the critical path", func(t *testing.T) {
+		plan := NewExecutionPlan("Test Plan", "Description", ExecutionPlanPriorityMedium)
+		fetch, parse, scan, report := newStep("Fetch", 10), newStep("Parse", 20), newStep("Scan", 5), newStep("Report", 10)
+		plan.AddStep(fetch)
+		plan.AddStep(parse)
+		plan.AddStep(scan)
+		plan.AddStep(report)
+		parse.DependOn(fetch)
+		scan.DependOn(fetch)
+		report.DependOn(parse, scan)
+
+		// Fetch, then the longer of parse and scan, then report
+		assert.Equal(t, now.Add(40*time.Minute), plan.EstimatedCompletion(now))
+
+		fetch.Status = ExecutionStepStatusCompleted
+		parse.Status = ExecutionStepStatusCompleted
+		assert.Equal(t, now.Add(15*time.Minute), plan.EstimatedCompletion(now))
+	})
+
+	t.Run("completed plan reports its completion time", func(t *testing.T) {
+		plan := NewExecutionPlan("Test Plan", "Description", ExecutionPlanPriorityMedium)
+		plan.AddStep(newStep("Build", 10))
+		plan.Approve()
+		require.NoError(t, plan.Start())
+		require.NoError(t, plan.Complete())
+
+		assert.Equal(t, *plan.CompletedAt, plan.EstimatedCompletion(now))
+	})
+}
+
+func TestExecutionPlan_ActualVsEstimated(t *testing.T) {
+	plan := NewExecutionPlan("Test Plan", "Description", ExecutionPlanPriorityMedium)
+	fetch := NewExecutionStep("Fetch", "Fetch", "agent-1")
+	fetch.EstimatedDuration = 10
+	parse := NewExecutionStep("Parse", "Parse", "agent-1")
+	parse.EstimatedDuration = 20
+	scan := NewExecutionStep("Scan", "Scan", "agent-1")
+	scan.EstimatedDuration = 15
+	plan.AddStep(fetch)
+	plan.AddStep(parse)
+	plan.AddStep(scan)
+	parse.DependOn(fetch)
+	scan.DependOn(fetch)
+
+	_, finished := plan.ActualVsEstimated()
+	assert.False(t, finished, "no drift before the plan completes")
+
+	startedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	completedAt := startedAt.Add(45 * time.Minute)
+	plan.StartedAt = &startedAt
+	plan.CompletedAt = &completedAt
+
+	drift, finished := plan.ActualVsEstimated()
+	assert.True(t, finished)
+	assert.Equal(t, 15*time.Minute, drift, "estimated 30 minutes along the critical path")
+}
+
 func TestExecutionPlan_IsExecutable(t *testing.T) {
 	plan := NewExecutionPlan("Test Plan", "Description", ExecutionPlanPriorityMedium)
 	step := NewExecutionStep("Step 1", "First step", "agent-1")
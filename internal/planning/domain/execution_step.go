@@ -1,9 +1,14 @@
 package domain
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
+	agentDomain "neuromesh/internal/agent/domain"
+	"neuromesh/internal/ids"
+
 	"github.com/google/uuid"
 )
 
@@ -17,8 +22,12 @@ const (
 	ExecutionStepStatusCompleted ExecutionStepStatus = "COMPLETED"
 	ExecutionStepStatusFailed    ExecutionStepStatus = "FAILED"
 	ExecutionStepStatusSkipped   ExecutionStepStatus = "SKIPPED"
+	ExecutionStepStatusCancelled ExecutionStepStatus = "CANCELLED"
 )
 
+// StepOutputContent is the output key holding the reply of the agent that completed the step
+const StepOutputContent = "content"
+
 // ExecutionStep represents an individual step within an execution plan
 type ExecutionStep struct {
 	ID                string              `json:"id"`
@@ -39,6 +48,8 @@ type ExecutionStep struct {
 	MaxRetries        int                 `json:"max_retries"`        // Maximum allowed retries
 	StartedAt         *time.Time          `json:"started_at"`         // When step execution started
 	CompletedAt       *time.Time          `json:"completed_at"`       // When step execution completed
+	EventPublished    bool                `json:"event_published"`    // Whether the step's completion event was published
+	DependsOn         []string            `json:"depends_on"`         // IDs of the steps that must complete before this step starts
 }
 
 // NewExecutionStep creates a new execution step with validation
@@ -56,6 +67,19 @@ func NewExecutionStep(name, description, assignedAgent string) *ExecutionStep {
 	}
 }
 
+// NewExecutionStepForPlan creates a step of the given plan whose ID embeds the plan ID, see ParseStepID
+func NewExecutionStepForPlan(planID, name, description, assignedAgent string) *ExecutionStep {
+	step := NewExecutionStep(name, description, assignedAgent)
+	step.ID = ids.PlanStep(planID, step.ID)
+	step.PlanID = planID
+	return step
+}
+
+// ParseStepID returns the plan ID embedded in a step ID, or "" for steps not created for a plan
+func ParseStepID(stepID string) string {
+	return ids.StepPlan(stepID)
+}
+
 // Validate ensures the execution step is valid
 func (s *ExecutionStep) Validate() error {
 	if s.ID == "" {
@@ -89,13 +113,15 @@ func (s *ExecutionStep) Start() error {
 	return nil
 }
 
-// Complete marks the step as completed and calculates actual duration
-func (s *ExecutionStep) Complete(outputs string) error {
+// Complete marks the step as completed with its structured outputs and calculates actual duration
+func (s *ExecutionStep) Complete(outputs map[string]interface{}) error {
 	if s.Status != ExecutionStepStatusExecuting {
 		return fmt.Errorf("step must be executing to complete")
 	}
+	if err := s.SetOutputs(outputs); err != nil {
+		return err
+	}
 	s.Status = ExecutionStepStatusCompleted
-	s.Outputs = outputs
 	now := time.Now()
 	s.CompletedAt = &now
 
@@ -119,6 +145,22 @@ func (s *ExecutionStep) Fail(errorMessage string) {
 	}
 }
 
+// Cancel marks the step as cancelled; only steps that have not finished can be cancelled
+func (s *ExecutionStep) Cancel() error {
+	if s.IsComplete() {
+		return fmt.Errorf("cannot cancel step in %s status", s.Status)
+	}
+	s.Status = ExecutionStepStatusCancelled
+	now := time.Now()
+	s.CompletedAt = &now
+
+	// Calculate actual duration
+	if s.StartedAt != nil {
+		s.ActualDuration = int(now.Sub(*s.StartedAt).Minutes())
+	}
+	return nil
+}
+
 // Retry resets the step for retry if allowed
 func (s *ExecutionStep) Retry() error {
 	if s.RetryCount >= s.MaxRetries {
@@ -134,16 +176,133 @@ func (s *ExecutionStep) Retry() error {
 	return nil
 }
 
+// SetInputs stores the step's input parameters as JSON
+func (s *ExecutionStep) SetInputs(inputs map[string]interface{}) error {
+	encoded, err := encodeStepData(inputs)
+	if err != nil {
+		return fmt.Errorf("failed to encode inputs of step %s: %w", s.ID, err)
+	}
+	s.Inputs = encoded
+	return nil
+}
+
+// GetInputs decodes the step's input parameters; a step without inputs returns an empty map
+func (s *ExecutionStep) GetInputs() (map[string]interface{}, error) {
+	inputs, err := decodeStepData(s.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode inputs of step %s: %w", s.ID, err)
+	}
+	return inputs, nil
+}
+
+// SetOutputs stores the step's output results as JSON
+func (s *ExecutionStep) SetOutputs(outputs map[string]interface{}) error {
+	encoded, err := encodeStepData(outputs)
+	if err != nil {
+		return fmt.Errorf("failed to encode outputs of step %s: %w", s.ID, err)
+	}
+	s.Outputs = encoded
+	return nil
+}
+
+// GetOutputs decodes the step's output results; a step without outputs returns an empty map
+func (s *ExecutionStep) GetOutputs() (map[string]interface{}, error) {
+	outputs, err := decodeStepData(s.Outputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode outputs of step %s: %w", s.ID, err)
+	}
+	return outputs, nil
+}
+
+// OutputContent returns the agent reply stored in the step's outputs
+// Outputs stored as plain text before they were structured are returned unchanged
+func (s *ExecutionStep) OutputContent() string {
+	outputs, err := decodeStepData(s.Outputs)
+	if err != nil {
+		return s.Outputs
+	}
+	content, _ := outputs[StepOutputContent].(string)
+	return content
+}
+
+// ValidateInputs checks the step's inputs against its assigned agent's capability
+// A nil capability declares no requirements
+func (s *ExecutionStep) ValidateInputs(capability *agentDomain.AgentCapability) error {
+	inputs, err := s.GetInputs()
+	if err != nil {
+		return err
+	}
+	if capability == nil {
+		return nil
+	}
+	if err := capability.ValidateInputs(inputs); err != nil {
+		return fmt.Errorf("step %s: %w", s.Name, err)
+	}
+	return nil
+}
+
+// encodeStepData marshals step inputs or outputs; nil and empty maps are stored as an empty string
+func encodeStepData(data map[string]interface{}) (string, error) {
+	if len(data) == 0 {
+		return "", nil
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// decodeStepData unmarshals step inputs or outputs stored by encodeStepData or written as JSON objects by hand
+func decodeStepData(encoded string) (map[string]interface{}, error) {
+	data := make(map[string]interface{})
+	if encoded == "" {
+		return data, nil
+	}
+	if err := json.Unmarshal([]byte(encoded), &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DependOn makes the step wait for the given prerequisite steps to complete before it starts
+func (s *ExecutionStep) DependOn(prerequisites ...*ExecutionStep) {
+	for _, prerequisite := range prerequisites {
+		if !slices.Contains(s.DependsOn, prerequisite.ID) {
+			s.DependsOn = append(s.DependsOn, prerequisite.ID)
+		}
+	}
+}
+
+// remainingEstimate returns how much of the step's estimated duration is left at now
+// Finished steps have nothing left and executing steps have already used the time since they started
+func (s *ExecutionStep) remainingEstimate(now time.Time) time.Duration {
+	if s.IsComplete() {
+		return 0
+	}
+	remaining := time.Duration(s.EstimatedDuration) * time.Minute
+	if s.Status == ExecutionStepStatusExecuting && s.StartedAt != nil {
+		remaining -= now.Sub(*s.StartedAt)
+	}
+	return max(remaining, 0)
+}
+
+// label identifies the step by number and name in error messages
+func (s *ExecutionStep) label() string {
+	return fmt.Sprintf("step %d (%s)", s.StepNumber, s.Name)
+}
+
 // CanRetry returns true if the step can be retried
 func (s *ExecutionStep) CanRetry() bool {
 	return s.Status == ExecutionStepStatusFailed && s.RetryCount < s.MaxRetries
 }
 
-// IsComplete returns true if the step is completed, failed, or skipped
+// IsComplete returns true if the step is completed, failed, skipped, or cancelled
 func (s *ExecutionStep) IsComplete() bool {
 	return s.Status == ExecutionStepStatusCompleted ||
 		s.Status == ExecutionStepStatusFailed ||
-		s.Status == ExecutionStepStatusSkipped
+		s.Status == ExecutionStepStatusSkipped ||
+		s.Status == ExecutionStepStatusCancelled
 }
 
 // CanBeModified returns true if the step can be modified
@@ -155,13 +314,23 @@ func (s *ExecutionStep) CanBeModified() bool {
 func (s ExecutionStepStatus) IsValid() bool {
 	switch s {
 	case ExecutionStepStatusPending, ExecutionStepStatusAssigned, ExecutionStepStatusExecuting,
-		ExecutionStepStatusCompleted, ExecutionStepStatusFailed, ExecutionStepStatusSkipped:
+		ExecutionStepStatusCompleted, ExecutionStepStatusFailed, ExecutionStepStatusSkipped, ExecutionStepStatusCancelled:
 		return true
 	default:
 		return false
 	}
 }
 
+// IsActive reports whether a step in this status currently occupies its assigned agent
+func (s ExecutionStepStatus) IsActive() bool {
+	return s == ExecutionStepStatusAssigned || s == ExecutionStepStatusExecuting
+}
+
+// ActiveExecutionStepStatuses returns the statuses that count towards an agent's workload
+func ActiveExecutionStepStatuses() []ExecutionStepStatus {
+	return []ExecutionStepStatus{ExecutionStepStatusAssigned, ExecutionStepStatusExecuting}
+}
+
 // ToMap converts the execution step to a map for persistence
 func (s *ExecutionStep) ToMap() map[string]interface{} {
 	data := map[string]interface{}{
@@ -3,7 +3,10 @@ package domain
 import (
 	"testing"
 
+	agentDomain "neuromesh/internal/agent/domain"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewExecutionStep(t *testing.T) {
@@ -24,6 +27,25 @@ func TestNewExecutionStep(t *testing.T) {
 	assert.Equal(t, 3, step.MaxRetries) // Default max retries
 }
 
+func TestNewExecutionStepForPlan(t *testing.T) {
+	plan := NewExecutionPlan("Deploy", "Deploy the app", ExecutionPlanPriorityMedium)
+
+	step := NewExecutionStepForPlan(plan.ID, "Deploy Application", "Deploy app using kubectl", "kubernetes-agent")
+	other := NewExecutionStepForPlan(plan.ID, "Verify Deployment", "Check the rollout", "kubernetes-agent")
+
+	assert.Equal(t, plan.ID, step.PlanID)
+	assert.Equal(t, plan.ID, ParseStepID(step.ID))
+	assert.NotEqual(t, step.ID, other.ID)
+	assert.NoError(t, step.Validate())
+	require.NoError(t, plan.AddStep(step))
+	assert.Equal(t, plan.ID, ParseStepID(plan.Steps[0].ID))
+}
+
+func TestParseStepID_WithoutPlan(t *testing.T) {
+	assert.Empty(t, ParseStepID(NewExecutionStep("Deploy Application", "Deploy app", "kubernetes-agent").ID))
+	assert.Empty(t, ParseStepID(""))
+}
+
 func TestExecutionStep_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -110,11 +132,10 @@ func TestExecutionStep_StatusTransitions(t *testing.T) {
 	assert.NotNil(t, step.StartedAt)
 
 	// Test Complete
-	outputs := `{"result": "success"}`
-	err = step.Complete(outputs)
+	err = step.Complete(map[string]interface{}{"result": "success"})
 	assert.NoError(t, err)
 	assert.Equal(t, ExecutionStepStatusCompleted, step.Status)
-	assert.Equal(t, outputs, step.Outputs)
+	assert.JSONEq(t, `{"result": "success"}`, step.Outputs)
 	assert.NotNil(t, step.CompletedAt)
 	assert.GreaterOrEqual(t, step.ActualDuration, 0) // Duration can be 0 for very fast execution
 }
@@ -128,7 +149,7 @@ func TestExecutionStep_StatusTransitions_Invalid(t *testing.T) {
 	assert.Contains(t, err.Error(), "must be assigned")
 
 	// Cannot complete without executing
-	err = step.Complete("")
+	err = step.Complete(nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "must be executing")
 }
@@ -146,6 +167,45 @@ func TestExecutionStep_Fail(t *testing.T) {
 	assert.NotNil(t, step.CompletedAt)
 }
 
+func TestExecutionStep_Cancel(t *testing.T) {
+	t.Run("pending step", func(t *testing.T) {
+		step := NewExecutionStep("Deploy", "Deploy app", "agent-1")
+
+		assert.NoError(t, step.Cancel())
+		assert.Equal(t, ExecutionStepStatusCancelled, step.Status)
+		assert.NotNil(t, step.CompletedAt)
+		assert.True(t, step.IsComplete())
+	})
+
+	t.Run("executing step", func(t *testing.T) {
+		step := NewExecutionStep("Deploy", "Deploy app", "agent-1")
+		step.Assign()
+		step.Start()
+
+		assert.NoError(t, step.Cancel())
+		assert.Equal(t, ExecutionStepStatusCancelled, step.Status)
+	})
+
+	t.Run("completed step is rejected", func(t *testing.T) {
+		step := NewExecutionStep("Deploy", "Deploy app", "agent-1")
+		step.Assign()
+		step.Start()
+		step.Complete(map[string]interface{}{StepOutputContent: "done"})
+
+		err := step.Cancel()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot cancel")
+		assert.Equal(t, ExecutionStepStatusCompleted, step.Status)
+	})
+
+	t.Run("cancelled step is rejected", func(t *testing.T) {
+		step := NewExecutionStep("Deploy", "Deploy app", "agent-1")
+		step.Cancel()
+
+		assert.Error(t, step.Cancel())
+	})
+}
+
 func TestExecutionStep_Retry(t *testing.T) {
 	step := NewExecutionStep("Deploy", "Deploy app", "agent-1")
 	step.MaxRetries = 2
@@ -198,6 +258,9 @@ func TestExecutionStep_IsComplete(t *testing.T) {
 
 	step.Status = ExecutionStepStatusSkipped
 	assert.True(t, step.IsComplete())
+
+	step.Status = ExecutionStepStatusCancelled
+	assert.True(t, step.IsComplete())
 }
 
 func TestExecutionStep_CanBeModified(t *testing.T) {
@@ -215,3 +278,69 @@ func TestExecutionStep_CanBeModified(t *testing.T) {
 	step.CanModify = false
 	assert.False(t, step.CanBeModified())
 }
+
+func TestExecutionStep_InputsAndOutputsRoundTrip(t *testing.T) {
+	step := NewExecutionStep("Count words", "Count the words", "text-processor")
+
+	inputs, err := step.GetInputs()
+	require.NoError(t, err)
+	assert.Empty(t, inputs)
+
+	require.NoError(t, step.SetInputs(map[string]interface{}{
+		"text":    "hello world",
+		"options": map[string]interface{}{"case_sensitive": true},
+	}))
+	assert.JSONEq(t, `{"text":"hello world","options":{"case_sensitive":true}}`, step.Inputs)
+
+	inputs, err = step.GetInputs()
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", inputs["text"])
+	assert.Equal(t, map[string]interface{}{"case_sensitive": true}, inputs["options"])
+
+	require.NoError(t, step.SetOutputs(map[string]interface{}{"count": 2}))
+	outputs, err := step.GetOutputs()
+	require.NoError(t, err)
+	assert.Equal(t, float64(2), outputs["count"])
+
+	// Hand-written JSON decodes the same way
+	step.Outputs = `{"result": "success"}`
+	outputs, err = step.GetOutputs()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"result": "success"}, outputs)
+
+	require.NoError(t, step.SetInputs(nil))
+	assert.Empty(t, step.Inputs)
+
+	step.Inputs = "not json"
+	_, err = step.GetInputs()
+	assert.Error(t, err)
+}
+
+func TestExecutionStep_OutputContent(t *testing.T) {
+	step := NewExecutionStep("Count words", "Count the words", "text-processor")
+	step.Assign()
+	require.NoError(t, step.Start())
+	require.NoError(t, step.Complete(map[string]interface{}{StepOutputContent: "2 words"}))
+	assert.Equal(t, "2 words", step.OutputContent())
+
+	// Outputs stored as plain text are returned as they are
+	step.Outputs = "2 words"
+	assert.Equal(t, "2 words", step.OutputContent())
+}
+
+func TestExecutionStep_ValidateInputs(t *testing.T) {
+	capability := &agentDomain.AgentCapability{Name: "word-count", Inputs: []string{"text", "language"}}
+	step := NewExecutionStep("Count words", "Count the words", "text-processor")
+
+	require.NoError(t, step.SetInputs(map[string]interface{}{"text": "hello world"}))
+	err := step.ValidateInputs(capability)
+	assert.ErrorIs(t, err, agentDomain.ErrMissingInput)
+	assert.Contains(t, err.Error(), "language")
+
+	require.NoError(t, step.SetInputs(map[string]interface{}{"text": "hello world", "language": "en"}))
+	assert.NoError(t, step.ValidateInputs(capability))
+
+	// Capabilities without declared inputs, or no known capability, accept any inputs
+	assert.NoError(t, step.ValidateInputs(&agentDomain.AgentCapability{Name: "echo"}))
+	assert.NoError(t, step.ValidateInputs(nil))
+}
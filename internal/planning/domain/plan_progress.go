@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// PlanProgress summarizes how far an execution plan has progressed
+type PlanProgress struct {
+	PlanID          string                      `json:"plan_id"`
+	TotalSteps      int                         `json:"total_steps"`
+	StatusCounts    map[ExecutionStepStatus]int `json:"status_counts"`
+	PercentComplete float64                     `json:"percent_complete"`
+	CurrentStep     *ExecutionStep              `json:"current_step,omitempty"`
+	// EstimatedCompletion is when the unfinished steps are expected to finish; unset once the plan is complete
+	EstimatedCompletion *time.Time `json:"estimated_completion,omitempty"`
+}
+
+// NewPlanProgress builds plan progress from per-status step counts
+func NewPlanProgress(planID string, statusCounts map[ExecutionStepStatus]int, currentStep *ExecutionStep) PlanProgress {
+	if statusCounts == nil {
+		statusCounts = make(map[ExecutionStepStatus]int)
+	}
+
+	total := 0
+	for _, count := range statusCounts {
+		total += count
+	}
+
+	progress := PlanProgress{
+		PlanID:       planID,
+		TotalSteps:   total,
+		StatusCounts: statusCounts,
+		CurrentStep:  currentStep,
+	}
+
+	if total > 0 {
+		done := statusCounts[ExecutionStepStatusCompleted] + statusCounts[ExecutionStepStatusSkipped]
+		progress.PercentComplete = float64(done) / float64(total) * 100
+	}
+
+	return progress
+}
+
+// IsComplete returns true if every step of the plan has been completed or skipped
+func (p PlanProgress) IsComplete() bool {
+	return p.TotalSteps > 0 && p.PercentComplete == 100
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPlanProgress(t *testing.T) {
+	t.Run("should report zero progress for empty plan", func(t *testing.T) {
+		progress := NewPlanProgress("plan-1", nil, nil)
+
+		assert.Equal(t, "plan-1", progress.PlanID)
+		assert.Equal(t, 0, progress.TotalSteps)
+		assert.NotNil(t, progress.StatusCounts)
+		assert.Equal(t, 0.0, progress.PercentComplete)
+		assert.Nil(t, progress.CurrentStep)
+		assert.False(t, progress.IsComplete())
+	})
+
+	t.Run("should compute partial progress", func(t *testing.T) {
+		current := NewExecutionStep("Analyze", "Analyze text", "text-processor")
+		current.Status = ExecutionStepStatusExecuting
+
+		progress := NewPlanProgress("plan-1", map[ExecutionStepStatus]int{
+			ExecutionStepStatusCompleted: 1,
+			ExecutionStepStatusExecuting: 1,
+			ExecutionStepStatusPending:   2,
+		}, current)
+
+		assert.Equal(t, 4, progress.TotalSteps)
+		assert.Equal(t, 25.0, progress.PercentComplete)
+		assert.Equal(t, current, progress.CurrentStep)
+		assert.False(t, progress.IsComplete())
+	})
+
+	t.Run("should count skipped steps as done", func(t *testing.T) {
+		progress := NewPlanProgress("plan-1", map[ExecutionStepStatus]int{
+			ExecutionStepStatusCompleted: 3,
+			ExecutionStepStatusSkipped:   1,
+		}, nil)
+
+		assert.Equal(t, 4, progress.TotalSteps)
+		assert.Equal(t, 100.0, progress.PercentComplete)
+		assert.True(t, progress.IsComplete())
+	})
+}
@@ -22,7 +22,7 @@ func (r *ResponseParser) ExtractSection(text, marker string) string {
 
 	section := parts[1]
 	// Find the end of this section (next marker or end of text)
-	nextMarkers := []string{"DECISION:", "CONFIDENCE:", "REASONING:", "CLARIFICATION:", "EXECUTION_PLAN:", "AGENT_COORDINATION:", "Intent:", "Category:", "Required_Agents:"}
+	nextMarkers := []string{"DECISION:", "CONFIDENCE:", "REASONING:", "CLARIFICATION:", "REJECT:", "PRIORITY:", "EXECUTION_PLAN:", "EXECUTION_PLAN_JSON:", "AGENT_COORDINATION:", "Intent:", "Category:", "Required_Agents:"}
 	minIndex := len(section)
 
 	for _, nextMarker := range nextMarkers {
@@ -235,6 +235,9 @@ func (r *GraphAnalysisRepository) nodeToAnalysis(nodeData map[string]interface{}
 		if err := json.Unmarshal([]byte(requiredAgentsStr), &requiredAgents); err != nil {
 			return nil, fmt.Errorf("failed to parse required_agents JSON for analysis %s: %w", id, err)
 		}
+	} else if nodeData["required_agents"] != nil {
+		// Older nodes may store required agents as a native list
+		requiredAgents = graph.DecodeStringSliceProperty(nodeData["required_agents"])
 	}
 
 	// Parse timestamp with better error handling
@@ -2,19 +2,31 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"neuromesh/internal/graph"
+	"neuromesh/internal/ids"
 	"neuromesh/internal/planning/domain"
 )
 
+// RelationshipDependsOn links an execution step to a step that must complete before it starts
+const RelationshipDependsOn = "DEPENDS_ON"
+
 // GraphExecutionPlanRepository implements ExecutionPlanRepository using Neo4j graph
 type GraphExecutionPlanRepository struct {
 	graph graph.Graph
 }
 
+// nodeTypePublishedEvent marks an event as published; its ID is the event's idempotency key
+const nodeTypePublishedEvent = "published_event"
+
+// agentCompletedEventKey returns the idempotency key of a step's agent-completed event
+func agentCompletedEventKey(stepID string) string {
+	return "agent_completed:" + stepID
+}
+
 // NewGraphExecutionPlanRepository creates a new graph-based execution plan repository
 func NewGraphExecutionPlanRepository(g graph.Graph) *GraphExecutionPlanRepository {
 	return &GraphExecutionPlanRepository{
@@ -50,6 +62,11 @@ func (r *GraphExecutionPlanRepository) EnsureSchema(ctx context.Context) error {
 		return fmt.Errorf("failed to create index for execution_step.step_number: %w", err)
 	}
 
+	// Published events are keyed by idempotency key, so a duplicate publication cannot create a second node
+	if err := r.graph.CreateUniqueConstraint(ctx, nodeTypePublishedEvent, "id"); err != nil {
+		return fmt.Errorf("failed to create unique constraint for %s.id: %w", nodeTypePublishedEvent, err)
+	}
+
 	return nil
 }
 
@@ -82,24 +99,33 @@ func (r *GraphExecutionPlanRepository) Create(ctx context.Context, plan *domain.
 
 		// Create ASSIGNED_TO relationship to agent
 		if step.AssignedAgent != "" {
-			if err := r.graph.AddEdge(ctx, "execution_step", step.ID, "agent", step.AssignedAgent, "ASSIGNED_TO", nil); err != nil {
+			if err := r.graph.AddEdge(ctx, "execution_step", step.ID, "agent", ids.Agent(step.AssignedAgent), "ASSIGNED_TO", nil); err != nil {
 				return fmt.Errorf("failed to create ASSIGNED_TO relationship: %w", err)
 			}
 		}
 	}
 
+	// Dependencies are linked once every step node exists
+	for _, step := range plan.Steps {
+		for _, prerequisiteID := range step.DependsOn {
+			if err := r.graph.AddEdge(ctx, "execution_step", step.ID, "execution_step", prerequisiteID, RelationshipDependsOn, nil); err != nil {
+				return fmt.Errorf("failed to create %s relationship: %w", RelationshipDependsOn, err)
+			}
+		}
+	}
+
 	return nil
 }
 
 // GetByID retrieves an execution plan by its ID
 func (r *GraphExecutionPlanRepository) GetByID(ctx context.Context, id string) (*domain.ExecutionPlan, error) {
 	planData, err := r.graph.GetNode(ctx, "execution_plan", id)
-	if err != nil {
-		if strings.Contains(err.Error(), "node not found") {
-			return nil, fmt.Errorf("execution plan %s not found", id)
-		}
+	if err != nil && !errors.Is(err, graph.ErrNodeNotFound) {
 		return nil, fmt.Errorf("failed to get execution plan: %w", err)
 	}
+	if planData == nil {
+		return nil, fmt.Errorf("execution plan %s not found: %w", id, graph.ErrNodeNotFound)
+	}
 
 	plan, err := r.mapToExecutionPlan(planData)
 	if err != nil {
@@ -159,6 +185,50 @@ func (r *GraphExecutionPlanRepository) Update(ctx context.Context, plan *domain.
 	return nil
 }
 
+// UpdateIfStatus persists the plan only if its stored status is still expected, reporting whether it did
+// Concurrent transitions from the same status therefore succeed at most once
+func (r *GraphExecutionPlanRepository) UpdateIfStatus(ctx context.Context, plan *domain.ExecutionPlan, expected domain.ExecutionPlanStatus) (bool, error) {
+	if err := plan.Validate(); err != nil {
+		return false, fmt.Errorf("invalid execution plan: %w", err)
+	}
+
+	updated, err := r.graph.UpdateNodeIf(ctx, "execution_plan", plan.ID, map[string]interface{}{"status": string(expected)}, plan.ToMap())
+	if err != nil {
+		return false, fmt.Errorf("failed to update execution plan: %w", err)
+	}
+	return updated, nil
+}
+
+// CancelPlan cancels an execution plan and persists the plan together with every step it cancelled
+func (r *GraphExecutionPlanRepository) CancelPlan(ctx context.Context, planID string) (*domain.ExecutionPlan, error) {
+	plan, err := r.GetByID(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	unfinished := make([]*domain.ExecutionStep, 0, len(plan.Steps))
+	for _, step := range plan.Steps {
+		if !step.IsComplete() {
+			unfinished = append(unfinished, step)
+		}
+	}
+
+	if err := plan.Cancel(); err != nil {
+		return nil, err
+	}
+
+	for _, step := range unfinished {
+		if err := r.UpdateStep(ctx, step); err != nil {
+			return nil, fmt.Errorf("failed to persist cancelled step %s: %w", step.ID, err)
+		}
+	}
+	if err := r.Update(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
 // LinkToAnalysis creates a relationship between analysis and execution plan
 func (r *GraphExecutionPlanRepository) LinkToAnalysis(ctx context.Context, analysisID, planID string) error {
 	// Create the CREATES_PLAN relationship edge
@@ -198,9 +268,181 @@ func (r *GraphExecutionPlanRepository) GetStepsByPlanID(ctx context.Context, pla
 		}
 	}
 
+	if err := r.loadPlanStepDependencies(ctx, planID, steps); err != nil {
+		return nil, err
+	}
+
 	return steps, nil
 }
 
+// loadPlanStepDependencies fills in the dependencies of a plan's steps from their DEPENDS_ON relationships in one traversal
+func (r *GraphExecutionPlanRepository) loadPlanStepDependencies(ctx context.Context, planID string, steps []*domain.ExecutionStep) error {
+	if len(steps) == 0 {
+		return nil
+	}
+
+	paths, err := r.graph.TraversePath(ctx, "execution_plan", planID, []graph.Hop{
+		{EdgeType: "CONTAINS_STEP", TargetType: "execution_step", OrderBy: "step_number"},
+		{EdgeType: RelationshipDependsOn, TargetType: "execution_step", OrderBy: "step_number"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query step dependencies of plan %s: %w", planID, err)
+	}
+
+	byID := make(map[string]*domain.ExecutionStep, len(steps))
+	for _, step := range steps {
+		byID[step.ID] = step
+	}
+	for _, path := range paths {
+		stepID, _ := path[0]["id"].(string)
+		prerequisiteID, _ := path[1]["id"].(string)
+		if step, ok := byID[stepID]; ok && prerequisiteID != "" {
+			step.DependsOn = append(step.DependsOn, prerequisiteID)
+		}
+	}
+
+	return nil
+}
+
+// loadStepDependencies fills in the dependencies of a single step from its DEPENDS_ON relationships
+func (r *GraphExecutionPlanRepository) loadStepDependencies(ctx context.Context, step *domain.ExecutionStep) error {
+	edges, err := r.graph.GetEdgesWithTargets(ctx, "execution_step", step.ID)
+	if err != nil {
+		return fmt.Errorf("failed to query dependencies of step %s: %w", step.ID, err)
+	}
+
+	for _, edge := range edges {
+		if edgeType, _ := edge["type"].(string); edgeType != RelationshipDependsOn {
+			continue
+		}
+		if prerequisiteID, ok := edge["target_id"].(string); ok {
+			step.DependsOn = append(step.DependsOn, prerequisiteID)
+		}
+	}
+
+	return nil
+}
+
+// GetStepByID retrieves a single execution step by its ID
+func (r *GraphExecutionPlanRepository) GetStepByID(ctx context.Context, stepID string) (*domain.ExecutionStep, error) {
+	stepData, err := r.graph.GetNode(ctx, "execution_step", stepID)
+	if err != nil && !errors.Is(err, graph.ErrNodeNotFound) {
+		return nil, fmt.Errorf("failed to get execution step: %w", err)
+	}
+	if stepData == nil {
+		return nil, fmt.Errorf("step not found: %s: %w", stepID, graph.ErrNodeNotFound)
+	}
+
+	step, err := r.mapToExecutionStep(stepData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map execution step: %w", err)
+	}
+
+	if err := r.loadStepDependencies(ctx, step); err != nil {
+		return nil, err
+	}
+
+	return step, nil
+}
+
+// GetStepStatusCounts aggregates step counts by status over the plan's CONTAINS_STEP relationships
+func (r *GraphExecutionPlanRepository) GetStepStatusCounts(ctx context.Context, planID string) (map[domain.ExecutionStepStatus]int, error) {
+	counts, err := r.graph.CountRelatedNodesByProperty(ctx, "execution_plan", planID, "CONTAINS_STEP", "status")
+	if err != nil {
+		return nil, fmt.Errorf("failed to count steps by status: %w", err)
+	}
+
+	statusCounts := make(map[domain.ExecutionStepStatus]int, len(counts))
+	for status, count := range counts {
+		statusCounts[domain.ExecutionStepStatus(status)] += count
+	}
+
+	return statusCounts, nil
+}
+
+// MarkStepEventPublished sets the step's event_published flag, reporting whether it was previously unset
+// Only the caller whose MERGE creates the step's published_event node wins, across every orchestrator process
+// The flag is written only here, never by UpdateStep, so stale step copies cannot reset it
+func (r *GraphExecutionPlanRepository) MarkStepEventPublished(ctx context.Context, stepID string) (bool, error) {
+	stepData, err := r.graph.GetNode(ctx, "execution_step", stepID)
+	if err != nil && !errors.Is(err, graph.ErrNodeNotFound) {
+		return false, fmt.Errorf("failed to get execution step: %w", err)
+	}
+	if stepData == nil {
+		return false, fmt.Errorf("step not found: %s: %w", stepID, graph.ErrNodeNotFound)
+	}
+
+	created, err := r.graph.MergeNode(ctx, nodeTypePublishedEvent, agentCompletedEventKey(stepID), map[string]interface{}{
+		"step_id":      stepID,
+		"published_at": time.Now().UTC(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to record step event publication: %w", err)
+	}
+	if !created {
+		return false, nil
+	}
+
+	if err := r.graph.UpdateNode(ctx, "execution_step", stepID, map[string]interface{}{"event_published": true}); err != nil {
+		return false, fmt.Errorf("failed to mark step event published: %w", err)
+	}
+	return true, nil
+}
+
+// GetPlansByStatus retrieves the execution plans in any of the given statuses, with their steps
+func (r *GraphExecutionPlanRepository) GetPlansByStatus(ctx context.Context, statuses []domain.ExecutionPlanStatus) ([]*domain.ExecutionPlan, error) {
+	values := make([]string, len(statuses))
+	for i, status := range statuses {
+		values[i] = string(status)
+	}
+
+	planNodes, err := r.graph.QueryNodesAdvanced(ctx, "execution_plan", []graph.Condition{
+		{Field: "status", Operator: graph.OperatorIn, Value: values},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query execution plans by status: %w", err)
+	}
+
+	plans := make([]*domain.ExecutionPlan, 0, len(planNodes))
+	for _, planData := range planNodes {
+		plan, err := r.mapToExecutionPlan(planData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map execution plan: %w", err)
+		}
+
+		steps, err := r.GetStepsByPlanID(ctx, plan.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load steps for plan %s: %w", plan.ID, err)
+		}
+		plan.Steps = steps
+		plans = append(plans, plan)
+	}
+
+	return plans, nil
+}
+
+// GetActiveStepCountByAgent counts assigned and executing steps grouped by assigned agent
+func (r *GraphExecutionPlanRepository) GetActiveStepCountByAgent(ctx context.Context) (map[string]int, error) {
+	counts := make(map[string]int)
+
+	for _, status := range domain.ActiveExecutionStepStatuses() {
+		stepNodes, err := r.graph.QueryNodes(ctx, "execution_step", map[string]interface{}{
+			"status": string(status),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %s steps: %w", status, err)
+		}
+
+		for _, stepData := range stepNodes {
+			if agentID, ok := stepData["assigned_agent"].(string); ok && agentID != "" {
+				counts[agentID]++
+			}
+		}
+	}
+
+	return counts, nil
+}
+
 // AddStep adds a new step to the graph
 func (r *GraphExecutionPlanRepository) AddStep(ctx context.Context, step *domain.ExecutionStep) error {
 	if err := step.Validate(); err != nil {
@@ -242,14 +484,14 @@ func (r *GraphExecutionPlanRepository) AssignStepToAgent(ctx context.Context, st
 	// If there's already an assigned agent, remove the old relationship
 	if currentAgent, ok := stepData["assigned_agent"].(string); ok && currentAgent != "" {
 		// Delete the old relationship
-		if err := r.graph.DeleteEdge(ctx, "execution_step", stepID, "agent", currentAgent, "ASSIGNED_TO"); err != nil {
+		if err := r.graph.DeleteEdge(ctx, "execution_step", stepID, "agent", ids.Agent(currentAgent), "ASSIGNED_TO"); err != nil {
 			// Log the error but continue - the relationship might not exist
 			// This is acceptable for our use case
 		}
 	}
 
 	// Create new ASSIGNED_TO relationship
-	if err := r.graph.AddEdge(ctx, "execution_step", stepID, "agent", agentID, "ASSIGNED_TO", nil); err != nil {
+	if err := r.graph.AddEdge(ctx, "execution_step", stepID, "agent", ids.Agent(agentID), "ASSIGNED_TO", nil); err != nil {
 		return fmt.Errorf("failed to create new ASSIGNED_TO relationship: %w", err)
 	}
 
@@ -290,6 +532,11 @@ func (r *GraphExecutionPlanRepository) mapToExecutionPlan(data map[string]interf
 		plan.Priority = domain.ExecutionPlanPriority(priority)
 	}
 
+	plan.Request, _ = data["request"].(string)
+	plan.UserID, _ = data["user_id"].(string)
+	plan.ReviewedBy, _ = data["reviewed_by"].(string)
+	plan.RejectionReason, _ = data["rejection_reason"].(string)
+
 	if canModify, ok := data["can_modify"].(bool); ok {
 		plan.CanModify = canModify
 	}
@@ -386,6 +633,10 @@ func (r *GraphExecutionPlanRepository) mapToExecutionStep(data map[string]interf
 		step.MaxRetries = maxRetries
 	}
 
+	if eventPublished, ok := data["event_published"].(bool); ok {
+		step.EventPublished = eventPublished
+	}
+
 	// Handle time fields
 	if startedAt, ok := data["started_at"].(time.Time); ok {
 		step.StartedAt = &startedAt
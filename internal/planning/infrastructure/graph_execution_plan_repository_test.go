@@ -7,6 +7,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	agentDomain "neuromesh/internal/agent/domain"
+	agentInfrastructure "neuromesh/internal/agent/infrastructure"
+	"neuromesh/internal/ids"
 	"neuromesh/internal/planning/domain"
 )
 
@@ -168,6 +171,75 @@ func TestGraphExecutionPlanRepository_AddStep(t *testing.T) {
 	assert.Equal(t, step.Name, steps[0].Name)
 }
 
+func TestGraphExecutionPlanRepository_GetStepByID(t *testing.T) {
+	ctx := context.Background()
+	graph := setupTestGraph(t)
+	repo := NewGraphExecutionPlanRepository(graph)
+
+	// Create plan with steps
+	plan := domain.NewExecutionPlan("Test Plan", "Description", domain.ExecutionPlanPriorityMedium)
+	step1 := domain.NewExecutionStep("Step 1", "First step", "agent-1")
+	step2 := domain.NewExecutionStep("Step 2", "Second step", "agent-2")
+	plan.AddStep(step1)
+	plan.AddStep(step2)
+
+	err := repo.Create(ctx, plan)
+	require.NoError(t, err)
+
+	t.Run("found", func(t *testing.T) {
+		step, err := repo.GetStepByID(ctx, step2.ID)
+		require.NoError(t, err)
+		assert.Equal(t, step2.ID, step.ID)
+		assert.Equal(t, plan.ID, step.PlanID)
+		assert.Equal(t, 2, step.StepNumber)
+		assert.Equal(t, "Step 2", step.Name)
+		assert.Equal(t, "agent-2", step.AssignedAgent)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		step, err := repo.GetStepByID(ctx, "non-existent-step")
+		assert.Error(t, err)
+		assert.Nil(t, step)
+		assert.Contains(t, err.Error(), "step not found")
+	})
+}
+
+func TestGraphExecutionPlanRepository_GetStepStatusCounts(t *testing.T) {
+	ctx := context.Background()
+	graph := setupTestGraph(t)
+	repo := NewGraphExecutionPlanRepository(graph)
+
+	t.Run("empty plan", func(t *testing.T) {
+		plan := domain.NewExecutionPlan("Empty Plan", "Description", domain.ExecutionPlanPriorityMedium)
+		require.NoError(t, repo.Create(ctx, plan))
+
+		counts, err := repo.GetStepStatusCounts(ctx, plan.ID)
+		require.NoError(t, err)
+		assert.Empty(t, counts)
+	})
+
+	t.Run("partially executed plan", func(t *testing.T) {
+		plan := domain.NewExecutionPlan("Test Plan", "Description", domain.ExecutionPlanPriorityMedium)
+		step1 := domain.NewExecutionStep("Step 1", "First step", "agent-1")
+		step1.Status = domain.ExecutionStepStatusCompleted
+		step2 := domain.NewExecutionStep("Step 2", "Second step", "agent-2")
+		step2.Status = domain.ExecutionStepStatusExecuting
+		step3 := domain.NewExecutionStep("Step 3", "Third step", "agent-3")
+		plan.AddStep(step1)
+		plan.AddStep(step2)
+		plan.AddStep(step3)
+		require.NoError(t, repo.Create(ctx, plan))
+
+		counts, err := repo.GetStepStatusCounts(ctx, plan.ID)
+		require.NoError(t, err)
+		assert.Equal(t, map[domain.ExecutionStepStatus]int{
+			domain.ExecutionStepStatusCompleted: 1,
+			domain.ExecutionStepStatusExecuting: 1,
+			domain.ExecutionStepStatusPending:   1,
+		}, counts)
+	})
+}
+
 func TestGraphExecutionPlanRepository_UpdateStep(t *testing.T) {
 	ctx := context.Background()
 	graph := setupTestGraph(t)
@@ -236,3 +308,36 @@ func TestGraphExecutionPlanRepository_EnsureSchema(t *testing.T) {
 	err = repo.EnsureSchema(ctx)
 	assert.NoError(t, err)
 }
+
+func TestGraphExecutionPlanRepository_AssignedToResolvesAgentNode(t *testing.T) {
+	ctx := context.Background()
+	g := setupTestGraph(t)
+	agentRepo := agentInfrastructure.NewGraphAgentRepository(g)
+	repo := NewGraphExecutionPlanRepository(g)
+
+	agent, err := agentDomain.NewAgent("test-assigned-agent", "Assigned Agent", "Agent assigned to a plan step",
+		[]agentDomain.AgentCapability{{Name: "word-count", Description: "Counts words"}})
+	require.NoError(t, err)
+	require.NoError(t, agentRepo.Create(ctx, agent))
+
+	plan := domain.NewExecutionPlan("Assignment Plan", "Plan with an assigned step", domain.ExecutionPlanPriorityMedium)
+	step := domain.NewExecutionStep("Count words", "Count words in text", agent.ID)
+	plan.AddStep(step)
+	require.NoError(t, repo.Create(ctx, plan))
+
+	edges, err := g.GetEdgesWithTargets(ctx, "execution_step", step.ID)
+	require.NoError(t, err)
+
+	var assignedAgentID string
+	for _, edge := range edges {
+		if edge["type"] == "ASSIGNED_TO" {
+			assignedAgentID, _ = edge["target_id"].(string)
+		}
+	}
+	require.Equal(t, ids.Agent(agent.ID), assignedAgentID, "ASSIGNED_TO should target the agent node")
+
+	// The edge target must be the agent node created by the agent repository
+	assignedAgent, err := agentRepo.GetByID(ctx, assignedAgentID)
+	require.NoError(t, err)
+	assert.Equal(t, agent.Name, assignedAgent.Name)
+}
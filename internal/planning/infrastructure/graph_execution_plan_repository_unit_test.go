@@ -0,0 +1,188 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuromesh/internal/graph"
+	"neuromesh/internal/planning/domain"
+	"neuromesh/testHelpers"
+)
+
+func TestGraphExecutionPlanRepository_GetActiveStepCountByAgent_Unit(t *testing.T) {
+	ctx := context.Background()
+	mockGraph := testHelpers.NewCleanMockGraph()
+	repo := NewGraphExecutionPlanRepository(mockGraph)
+
+	seedStep := func(id, agentID string, status domain.ExecutionStepStatus) {
+		require.NoError(t, mockGraph.AddNode(ctx, "execution_step", id, map[string]interface{}{
+			"assigned_agent": agentID,
+			"status":         string(status),
+		}))
+	}
+	seedStep("step-1", "agent-a", domain.ExecutionStepStatusAssigned)
+	seedStep("step-2", "agent-a", domain.ExecutionStepStatusExecuting)
+	seedStep("step-3", "agent-a", domain.ExecutionStepStatusCompleted)
+	seedStep("step-4", "agent-b", domain.ExecutionStepStatusExecuting)
+	seedStep("step-5", "agent-b", domain.ExecutionStepStatusPending)
+	seedStep("step-6", "agent-b", domain.ExecutionStepStatusFailed)
+
+	counts, err := repo.GetActiveStepCountByAgent(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"agent-a": 2, "agent-b": 1}, counts)
+}
+
+func TestGraphExecutionPlanRepository_NotFoundIsTyped_Unit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("graph returning nil for a missing node", func(t *testing.T) {
+		repo := NewGraphExecutionPlanRepository(testHelpers.NewCleanMockGraph())
+
+		_, err := repo.GetByID(ctx, "missing-plan")
+		assert.True(t, errors.Is(err, graph.ErrNodeNotFound))
+
+		_, err = repo.GetStepByID(ctx, "missing-step")
+		assert.True(t, errors.Is(err, graph.ErrNodeNotFound))
+	})
+
+	t.Run("graph returning ErrNodeNotFound", func(t *testing.T) {
+		mockGraph := &testHelpers.TestifyMockGraph{}
+		notFound := fmt.Errorf("%w: execution_plan missing-plan", graph.ErrNodeNotFound)
+		mockGraph.On("GetNode", ctx, "execution_plan", "missing-plan").Return(map[string]interface{}(nil), notFound)
+		repo := NewGraphExecutionPlanRepository(mockGraph)
+
+		plan, err := repo.GetByID(ctx, "missing-plan")
+		assert.Nil(t, plan)
+		assert.True(t, errors.Is(err, graph.ErrNodeNotFound))
+		assert.Contains(t, err.Error(), "execution plan missing-plan not found")
+	})
+}
+
+func TestGraphExecutionPlanRepository_MarkStepEventPublished_Unit(t *testing.T) {
+	ctx := context.Background()
+	mockGraph := testHelpers.NewCleanMockGraph()
+	repo := NewGraphExecutionPlanRepository(mockGraph)
+	require.NoError(t, mockGraph.AddNode(ctx, "execution_step", "step-1", map[string]interface{}{"id": "step-1", "status": "completed"}))
+
+	first, err := repo.MarkStepEventPublished(ctx, "step-1")
+	require.NoError(t, err)
+	assert.True(t, first)
+
+	second, err := repo.MarkStepEventPublished(ctx, "step-1")
+	require.NoError(t, err)
+	assert.False(t, second)
+
+	// A second orchestrator process shares only the graph, not the repository
+	other, err := NewGraphExecutionPlanRepository(mockGraph).MarkStepEventPublished(ctx, "step-1")
+	require.NoError(t, err)
+	assert.False(t, other)
+
+	marker, err := mockGraph.GetNode(ctx, nodeTypePublishedEvent, agentCompletedEventKey("step-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "step-1", marker["step_id"])
+
+	_, err = repo.MarkStepEventPublished(ctx, "missing-step")
+	assert.True(t, errors.Is(err, graph.ErrNodeNotFound))
+}
+
+func TestGraphExecutionPlanRepository_CancelPlan_Unit(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGraphExecutionPlanRepository(testHelpers.NewCleanMockGraph())
+
+	plan := domain.NewExecutionPlan("Deploy", "Deploy the application", domain.ExecutionPlanPriorityMedium)
+	done := domain.NewExecutionStep("Build", "Build the image", "build-agent")
+	running := domain.NewExecutionStep("Deploy", "Deploy the image", "deploy-agent")
+	plan.AddStep(done)
+	plan.AddStep(running)
+	done.Status = domain.ExecutionStepStatusCompleted
+	running.Status = domain.ExecutionStepStatusExecuting
+	require.NoError(t, repo.Create(ctx, plan))
+
+	cancelled, err := repo.CancelPlan(ctx, plan.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ExecutionPlanStatusCancelled, cancelled.Status)
+
+	persisted, err := repo.GetByID(ctx, plan.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ExecutionPlanStatusCancelled, persisted.Status)
+	statuses := map[string]domain.ExecutionStepStatus{}
+	for _, step := range persisted.Steps {
+		statuses[step.ID] = step.Status
+	}
+	assert.Equal(t, domain.ExecutionStepStatusCompleted, statuses[done.ID])
+	assert.Equal(t, domain.ExecutionStepStatusCancelled, statuses[running.ID])
+
+	_, err = repo.CancelPlan(ctx, plan.ID)
+	assert.Error(t, err, "a cancelled plan cannot be cancelled again")
+}
+
+func TestGraphExecutionPlanRepository_StepDependencies_Unit(t *testing.T) {
+	ctx := context.Background()
+	mockGraph := testHelpers.NewCleanMockGraph()
+	repo := NewGraphExecutionPlanRepository(mockGraph)
+
+	// A diamond: fetch first, then parse and scan independently, then report once both are done
+	plan := domain.NewExecutionPlan("Audit", "Audit the repository", domain.ExecutionPlanPriorityMedium)
+	fetch := domain.NewExecutionStep("Fetch", "Fetch the repository", "git-agent")
+	parse := domain.NewExecutionStep("Parse", "Parse the sources", "parser-agent")
+	scan := domain.NewExecutionStep("Scan", "Scan dependencies", "scanner-agent")
+	report := domain.NewExecutionStep("Report", "Write the report", "report-agent")
+	for _, step := range []*domain.ExecutionStep{fetch, parse, scan, report} {
+		require.NoError(t, plan.AddStep(step))
+	}
+	parse.DependOn(fetch)
+	scan.DependOn(fetch)
+	report.DependOn(parse, scan)
+	require.NoError(t, repo.Create(ctx, plan))
+
+	edges, err := mockGraph.GetEdgesWithTargets(ctx, "execution_step", report.ID)
+	require.NoError(t, err)
+	dependsOn := 0
+	for _, edge := range edges {
+		if edge["type"] == RelationshipDependsOn {
+			dependsOn++
+		}
+	}
+	assert.Equal(t, 2, dependsOn)
+
+	persisted, err := repo.GetByID(ctx, plan.ID)
+	require.NoError(t, err)
+	require.Len(t, persisted.Steps, 4)
+	assert.Empty(t, persisted.Steps[0].DependsOn)
+	assert.Equal(t, []string{fetch.ID}, persisted.Steps[1].DependsOn)
+	assert.Equal(t, []string{fetch.ID}, persisted.Steps[2].DependsOn)
+	assert.Equal(t, []string{parse.ID, scan.ID}, persisted.Steps[3].DependsOn)
+
+	step, err := repo.GetStepByID(ctx, report.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{parse.ID, scan.ID}, step.DependsOn)
+}
+
+func TestGraphExecutionPlanRepository_CreateRejectsDependencyCycle_Unit(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGraphExecutionPlanRepository(testHelpers.NewCleanMockGraph())
+
+	plan := domain.NewExecutionPlan("Audit", "Audit the repository", domain.ExecutionPlanPriorityMedium)
+	fetch := domain.NewExecutionStep("Fetch", "Fetch the repository", "git-agent")
+	parse := domain.NewExecutionStep("Parse", "Parse the sources", "parser-agent")
+	report := domain.NewExecutionStep("Report", "Write the report", "report-agent")
+	for _, step := range []*domain.ExecutionStep{fetch, parse, report} {
+		require.NoError(t, plan.AddStep(step))
+	}
+	parse.DependOn(fetch)
+	report.DependOn(parse)
+	fetch.DependOn(report)
+
+	err := repo.Create(ctx, plan)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrDependencyCycle))
+
+	_, err = repo.GetByID(ctx, plan.ID)
+	assert.True(t, errors.Is(err, graph.ErrNodeNotFound), "a rejected plan is not persisted")
+}
@@ -10,6 +10,9 @@ import (
 	"neuromesh/internal/logging"
 )
 
+// testNodeTypes lists the node types the planning tests create, cleared before and after each test
+var testNodeTypes = []string{"Analysis", "analysis", "execution_plan", "execution_step", "User", "Session", "Conversation", "Message"}
+
 // setupTestNeo4j creates a Neo4j connection for testing
 func setupTestNeo4j(t *testing.T) (graph.Graph, func()) {
 	if testing.Short() {
@@ -30,11 +33,15 @@ func setupTestNeo4j(t *testing.T) (graph.Graph, func()) {
 	require.NoError(t, err, "Failed to connect to Neo4j")
 
 	// Clean up any existing test data
-	err = g.ClearTestData(ctx)
-	require.NoError(t, err, "Failed to clean up test data")
+	for _, nodeType := range testNodeTypes {
+		err = g.ClearNodesByType(ctx, nodeType)
+		require.NoError(t, err, "Failed to clean up test data")
+	}
 
 	cleanup := func() {
-		g.ClearTestData(ctx)
+		for _, nodeType := range testNodeTypes {
+			g.ClearNodesByType(ctx, nodeType)
+		}
 		g.Close(ctx)
 	}
 
@@ -0,0 +1,40 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Config selects how the spans of a service are exported
+type Config struct {
+	ServiceName  string
+	OTLPEndpoint string // URL of an OTLP gRPC collector, e.g. http://localhost:4317; spans are not exported when empty
+}
+
+// Setup registers the global tracer provider and W3C trace context propagator for the service
+// Without an OTLP endpoint spans are still created, so trace IDs propagate to agents, but they are not exported
+// The returned shutdown flushes pending spans and must be called before the process exits
+func Setup(ctx context.Context, config Config) (func(context.Context) error, error) {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", config.ServiceName))),
+	}
+
+	if config.OTLPEndpoint != "" {
+		exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(config.OTLPEndpoint))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+	return provider.Shutdown, nil
+}
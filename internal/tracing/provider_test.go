@@ -0,0 +1,29 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestSetup_RegistersRecordingProvider(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Config{ServiceName: "neuromesh-test"})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, shutdown(context.Background()))
+		otel.SetTracerProvider(noop.NewTracerProvider())
+	})
+
+	ctx, span := Start(context.Background(), "test")
+	defer span.End()
+	assert.True(t, span.IsRecording())
+
+	// Spans propagate even though nothing is exported
+	carrier := map[string]interface{}{}
+	Inject(ctx, carrier)
+	assert.Contains(t, carrier, "traceparent")
+}
@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation name of every span created by the orchestrator
+const TracerName = "neuromesh"
+
+// propagator carries W3C trace context (traceparent/tracestate) across the message bus and gRPC
+var propagator = propagation.TraceContext{}
+
+// Start creates a span named name as a child of any span in ctx, using the global tracer provider
+// Spans are dropped unless a tracer provider is registered with otel.SetTracerProvider
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, opts...)
+}
+
+// End records err on the span, if any, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Inject writes the trace context of ctx into a message context map so the receiver can continue the trace
+func Inject(ctx context.Context, carrier map[string]interface{}) {
+	if carrier == nil {
+		return
+	}
+	headers := propagation.MapCarrier{}
+	propagator.Inject(ctx, headers)
+	for key, value := range headers {
+		carrier[key] = value
+	}
+}
+
+// Extract returns ctx extended with the trace context found in a message context map
+func Extract(ctx context.Context, carrier map[string]interface{}) context.Context {
+	headers := propagation.MapCarrier{}
+	for _, key := range propagator.Fields() {
+		if value, ok := carrier[key].(string); ok {
+			headers[key] = value
+		}
+	}
+	return propagator.Extract(ctx, headers)
+}
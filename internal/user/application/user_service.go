@@ -25,7 +25,7 @@ type UserService interface {
 	GetUserSessions(ctx context.Context, userID string) ([]*domain.Session, error)
 	ExtendSession(ctx context.Context, sessionID string, duration time.Duration) error
 	CloseSession(ctx context.Context, sessionID string) error
-	CleanupExpiredSessions(ctx context.Context) error
+	CleanupExpiredSessions(ctx context.Context) (int, error)
 
 	// Query operations
 	FindUsersByType(ctx context.Context, userType domain.UserType) ([]*domain.User, error)
@@ -111,17 +111,14 @@ func (s *UserServiceImpl) UpdateUserLastSeen(ctx context.Context, userID string)
 	return nil
 }
 
-// SetUserMetadata sets metadata for a user
+// SetUserMetadata sets a single metadata key for a user without overwriting other keys
 func (s *UserServiceImpl) SetUserMetadata(ctx context.Context, userID, key string, value interface{}) error {
-	user, err := s.repo.GetUser(ctx, userID)
-	if err != nil {
+	if _, err := s.repo.GetUser(ctx, userID); err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 
-	user.SetMetadata(key, value)
-
-	if err := s.repo.UpdateUser(ctx, user); err != nil {
-		return fmt.Errorf("failed to update user: %w", err)
+	if err := s.repo.MergeUserMetadata(ctx, userID, map[string]interface{}{key: value}); err != nil {
+		return fmt.Errorf("failed to update user metadata: %w", err)
 	}
 
 	return nil
@@ -204,23 +201,25 @@ func (s *UserServiceImpl) CloseSession(ctx context.Context, sessionID string) er
 	return nil
 }
 
-// CleanupExpiredSessions marks expired sessions and optionally deletes them
-func (s *UserServiceImpl) CleanupExpiredSessions(ctx context.Context) error {
+// CleanupExpiredSessions unlinks expired sessions from their users, deletes them and returns how many were removed
+func (s *UserServiceImpl) CleanupExpiredSessions(ctx context.Context) (int, error) {
 	expiredSessions, err := s.repo.FindExpiredSessions(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to find expired sessions: %w", err)
+		return 0, fmt.Errorf("failed to find expired sessions: %w", err)
 	}
 
+	removed := 0
 	for _, session := range expiredSessions {
-		if session.Status != domain.SessionStatusExpired {
-			session.MarkExpired()
-			if err := s.repo.UpdateSession(ctx, session); err != nil {
-				return fmt.Errorf("failed to mark session as expired: %w", err)
-			}
+		if err := s.repo.UnlinkUserFromSession(ctx, session.UserID, session.ID); err != nil {
+			return removed, fmt.Errorf("failed to unlink expired session %s: %w", session.ID, err)
+		}
+		if err := s.repo.DeleteSession(ctx, session.ID); err != nil {
+			return removed, fmt.Errorf("failed to delete expired session %s: %w", session.ID, err)
 		}
+		removed++
 	}
 
-	return nil
+	return removed, nil
 }
 
 // FindUsersByType finds users by type
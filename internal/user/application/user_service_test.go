@@ -0,0 +1,54 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"neuromesh/internal/user/domain"
+	"neuromesh/internal/user/infrastructure"
+	"neuromesh/testHelpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserService_CleanupExpiredSessions(t *testing.T) {
+	ctx := context.Background()
+	repo := infrastructure.NewGraphUserRepository(testHelpers.NewCleanMockGraph())
+	service := NewUserService(repo)
+
+	_, err := service.CreateUser(ctx, "user-1", "session-valid", domain.UserTypeWebSession)
+	require.NoError(t, err)
+
+	for _, id := range []string{"session-expired-1", "session-expired-2", "session-valid"} {
+		session, err := service.CreateSession(ctx, id, "user-1", time.Hour)
+		require.NoError(t, err)
+		if id != "session-valid" {
+			session.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+			require.NoError(t, repo.UpdateSession(ctx, session))
+		}
+	}
+
+	removed, err := service.CleanupExpiredSessions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+
+	_, err = service.GetSession(ctx, "session-expired-1")
+	assert.Error(t, err, "expired session should be deleted")
+	_, err = service.GetSession(ctx, "session-expired-2")
+	assert.Error(t, err, "expired session should be deleted")
+
+	valid, err := service.GetSession(ctx, "session-valid")
+	require.NoError(t, err)
+	assert.Equal(t, "session-valid", valid.ID)
+
+	sessions, err := service.GetUserSessions(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "session-valid", sessions[0].ID)
+
+	removed, err = service.CleanupExpiredSessions(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+}
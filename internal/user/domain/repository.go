@@ -13,6 +13,7 @@ type UserRepository interface {
 	GetUser(ctx context.Context, userID string) (*User, error)
 	GetUserWithSessions(ctx context.Context, userID string) (*User, error)
 	UpdateUser(ctx context.Context, user *User) error
+	MergeUserMetadata(ctx context.Context, userID string, metadata map[string]interface{}) error
 	DeleteUser(ctx context.Context, userID string) error
 
 	// Session operations
@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -95,7 +96,11 @@ func (r *GraphUserRepository) CreateUser(ctx context.Context, user *domain.User)
 
 	// Add metadata if present
 	if len(user.Metadata) > 0 {
-		properties["metadata"] = user.Metadata
+		metadata, err := graph.EncodeMapProperty(user.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encode user metadata: %w", err)
+		}
+		properties["metadata"] = metadata
 	}
 
 	return r.graph.AddNode(ctx, NodeTypeUser, user.ID, properties)
@@ -104,12 +109,12 @@ func (r *GraphUserRepository) CreateUser(ctx context.Context, user *domain.User)
 // GetUser retrieves a user by ID
 func (r *GraphUserRepository) GetUser(ctx context.Context, userID string) (*domain.User, error) {
 	userProps, err := r.graph.GetNode(ctx, NodeTypeUser, userID)
-	if err != nil {
+	if err != nil && !errors.Is(err, graph.ErrNodeNotFound) {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
 	if userProps == nil {
-		return nil, fmt.Errorf("user not found: %s", userID)
+		return nil, fmt.Errorf("user not found: %s: %w", userID, graph.ErrNodeNotFound)
 	}
 
 	return r.mapToUser(userProps)
@@ -148,12 +153,27 @@ func (r *GraphUserRepository) UpdateUser(ctx context.Context, user *domain.User)
 
 	// Add metadata if present
 	if len(user.Metadata) > 0 {
-		properties["metadata"] = user.Metadata
+		metadata, err := graph.EncodeMapProperty(user.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encode user metadata: %w", err)
+		}
+		properties["metadata"] = metadata
 	}
 
 	return r.graph.UpdateNode(ctx, NodeTypeUser, user.ID, properties)
 }
 
+// MergeUserMetadata deep-merges metadata into the user's stored metadata, keeping keys not provided
+func (r *GraphUserRepository) MergeUserMetadata(ctx context.Context, userID string, metadata map[string]interface{}) error {
+	if err := r.graph.MergeNodeProperty(ctx, NodeTypeUser, userID, "metadata", metadata); err != nil {
+		return fmt.Errorf("failed to merge user metadata: %w", err)
+	}
+
+	return r.graph.UpdateNode(ctx, NodeTypeUser, userID, map[string]interface{}{
+		"updated_at": formatTime(time.Now().UTC()),
+	})
+}
+
 // DeleteUser deletes a user node from the graph
 func (r *GraphUserRepository) DeleteUser(ctx context.Context, userID string) error {
 	return r.graph.DeleteNode(ctx, NodeTypeUser, userID)
@@ -172,7 +192,11 @@ func (r *GraphUserRepository) CreateSession(ctx context.Context, session *domain
 
 	// Add metadata if present
 	if len(session.Metadata) > 0 {
-		properties["metadata"] = session.Metadata
+		metadata, err := graph.EncodeMapProperty(session.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encode session metadata: %w", err)
+		}
+		properties["metadata"] = metadata
 	}
 
 	return r.graph.AddNode(ctx, NodeTypeSession, session.ID, properties)
@@ -181,12 +205,12 @@ func (r *GraphUserRepository) CreateSession(ctx context.Context, session *domain
 // GetSession retrieves a session by ID
 func (r *GraphUserRepository) GetSession(ctx context.Context, sessionID string) (*domain.Session, error) {
 	sessionProps, err := r.graph.GetNode(ctx, NodeTypeSession, sessionID)
-	if err != nil {
+	if err != nil && !errors.Is(err, graph.ErrNodeNotFound) {
 		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 
 	if sessionProps == nil {
-		return nil, fmt.Errorf("session not found: %s", sessionID)
+		return nil, fmt.Errorf("session not found: %s: %w", sessionID, graph.ErrNodeNotFound)
 	}
 
 	return r.mapToSession(sessionProps)
@@ -227,7 +251,11 @@ func (r *GraphUserRepository) UpdateSession(ctx context.Context, session *domain
 
 	// Add metadata if present
 	if len(session.Metadata) > 0 {
-		properties["metadata"] = session.Metadata
+		metadata, err := graph.EncodeMapProperty(session.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encode session metadata: %w", err)
+		}
+		properties["metadata"] = metadata
 	}
 
 	return r.graph.UpdateNode(ctx, NodeTypeSession, session.ID, properties)
@@ -298,27 +326,25 @@ func (r *GraphUserRepository) FindActiveUsers(ctx context.Context) ([]*domain.Us
 	return users, nil
 }
 
-// FindExpiredSessions finds all expired sessions
+// FindExpiredSessions finds all sessions whose expires_at lies in the past
 func (r *GraphUserRepository) FindExpiredSessions(ctx context.Context) ([]*domain.Session, error) {
-	// This is a simplified implementation - in a real scenario, you'd use a more complex query
-	// to find sessions where expires_at < current time
-	filters := map[string]interface{}{} // Query all sessions, then filter
+	// expires_at is stored in a fixed-width UTC format, so the string comparison orders by time
+	conditions := []graph.Condition{
+		{Field: "expires_at", Operator: graph.OperatorLessThan, Value: formatTime(time.Now().UTC())},
+	}
 
-	sessionProps, err := r.graph.QueryNodes(ctx, NodeTypeSession, filters)
+	sessionProps, err := r.graph.QueryNodesAdvanced(ctx, NodeTypeSession, conditions)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query sessions: %w", err)
+		return nil, fmt.Errorf("failed to query expired sessions: %w", err)
 	}
 
-	var expiredSessions []*domain.Session
+	expiredSessions := make([]*domain.Session, 0, len(sessionProps))
 	for _, props := range sessionProps {
 		session, err := r.mapToSession(props)
 		if err != nil {
 			return nil, fmt.Errorf("failed to map session properties: %w", err)
 		}
-
-		if session.IsExpired() {
-			expiredSessions = append(expiredSessions, session)
-		}
+		expiredSessions = append(expiredSessions, session)
 	}
 
 	return expiredSessions, nil
@@ -391,7 +417,7 @@ func (r *GraphUserRepository) mapToUser(props map[string]interface{}) (*domain.U
 
 	// Add metadata if present
 	if metadata, exists := props["metadata"]; exists {
-		if metadataMap, ok := metadata.(map[string]interface{}); ok {
+		if metadataMap, err := graph.DecodeMapProperty(metadata); err == nil {
 			user.Metadata = metadataMap
 		}
 	}
@@ -460,7 +486,7 @@ func (r *GraphUserRepository) mapToSession(props map[string]interface{}) (*domai
 
 	// Add metadata if present
 	if metadata, exists := props["metadata"]; exists {
-		if metadataMap, ok := metadata.(map[string]interface{}); ok {
+		if metadataMap, err := graph.DecodeMapProperty(metadata); err == nil {
 			session.Metadata = metadataMap
 		}
 	}
@@ -8,6 +8,7 @@ import (
 	"neuromesh/internal/graph"
 	"neuromesh/internal/logging"
 	"neuromesh/internal/user/domain"
+	"neuromesh/testHelpers"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -38,7 +39,7 @@ func TestGraphUserRepository_UserSchema(t *testing.T) {
 
 	t.Run("GREEN: should create User schema constraints and indexes", func(t *testing.T) {
 		// Clean up any existing test data
-		err := g.ClearTestData(ctx)
+		err := clearUserNodes(ctx, g)
 		require.NoError(t, err, "Failed to clean up test data")
 
 		// Now this should succeed
@@ -48,7 +49,7 @@ func TestGraphUserRepository_UserSchema(t *testing.T) {
 
 	t.Run("GREEN: should create Session schema constraints and indexes", func(t *testing.T) {
 		// Clean up any existing test data
-		err := g.ClearTestData(ctx)
+		err := clearUserNodes(ctx, g)
 		require.NoError(t, err, "Failed to clean up test data")
 
 		// Now this should succeed
@@ -58,7 +59,7 @@ func TestGraphUserRepository_UserSchema(t *testing.T) {
 
 	t.Run("GREEN: should create and store User nodes", func(t *testing.T) {
 		// Clean up any existing test data
-		err := g.ClearTestData(ctx)
+		err := clearUserNodes(ctx, g)
 		require.NoError(t, err, "Failed to clean up test data")
 
 		// Ensure schema exists first
@@ -85,7 +86,7 @@ func TestGraphUserRepository_UserSchema(t *testing.T) {
 
 	t.Run("GREEN: should create and store Session nodes", func(t *testing.T) {
 		// Clean up any existing test data
-		err := g.ClearTestData(ctx)
+		err := clearUserNodes(ctx, g)
 		require.NoError(t, err, "Failed to clean up test data")
 
 		// Ensure schema exists first
@@ -111,7 +112,7 @@ func TestGraphUserRepository_UserSchema(t *testing.T) {
 
 	t.Run("GREEN: should establish User-Session relationships", func(t *testing.T) {
 		// Clean up any existing test data
-		err := g.ClearTestData(ctx)
+		err := clearUserNodes(ctx, g)
 		require.NoError(t, err, "Failed to clean up test data")
 
 		// Ensure schemas exist first
@@ -138,7 +139,7 @@ func TestGraphUserRepository_UserSchema(t *testing.T) {
 
 	t.Run("GREEN: should query User with relationships", func(t *testing.T) {
 		// Clean up any existing test data
-		err := g.ClearTestData(ctx)
+		err := clearUserNodes(ctx, g)
 		require.NoError(t, err, "Failed to clean up test data")
 
 		// Ensure schemas exist first
@@ -159,3 +160,86 @@ func TestGraphUserRepository_UserSchema(t *testing.T) {
 		assert.Equal(t, "session-456", retrievedUser.SessionID, "Session ID should match")
 	})
 }
+
+func TestGraphUserRepository_MergeUserMetadata_PreservesExistingKeys(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGraphUserRepository(testHelpers.NewCleanMockGraph())
+
+	user, err := domain.NewUser("user-merge", "session-merge", domain.UserTypeWebSession)
+	require.NoError(t, err)
+	user.SetMetadata("theme", "dark")
+	user.SetMetadata("preferences", map[string]interface{}{"language": "en"})
+	require.NoError(t, repo.CreateUser(ctx, user))
+
+	// Partial update touching one top-level key and one nested key
+	err = repo.MergeUserMetadata(ctx, user.ID, map[string]interface{}{
+		"plan":        "pro",
+		"preferences": map[string]interface{}{"timezone": "CET"},
+	})
+	require.NoError(t, err)
+
+	stored, err := repo.GetUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "dark", stored.Metadata["theme"], "existing key should survive a partial update")
+	assert.Equal(t, "pro", stored.Metadata["plan"])
+	assert.Equal(t, map[string]interface{}{"language": "en", "timezone": "CET"}, stored.Metadata["preferences"])
+}
+
+func TestGraphUserRepository_MetadataStoredAsJSON(t *testing.T) {
+	ctx := context.Background()
+	mockGraph := testHelpers.NewCleanMockGraph()
+	repo := NewGraphUserRepository(mockGraph)
+
+	user, err := domain.NewUser("user-json", "session-json", domain.UserTypeWebSession)
+	require.NoError(t, err)
+	user.SetMetadata("preferences", map[string]interface{}{"language": "en"})
+	require.NoError(t, repo.CreateUser(ctx, user))
+
+	session, err := domain.NewSession("session-json", "user-json", time.Hour)
+	require.NoError(t, err)
+	session.Metadata = map[string]interface{}{"client": "web"}
+	require.NoError(t, repo.CreateSession(ctx, session))
+
+	for _, node := range []struct{ nodeType, id string }{{NodeTypeUser, "user-json"}, {NodeTypeSession, "session-json"}} {
+		props, err := mockGraph.GetNode(ctx, node.nodeType, node.id)
+		require.NoError(t, err)
+		assert.IsType(t, "", props["metadata"], "%s metadata should be stored as JSON", node.nodeType)
+	}
+
+	storedUser, err := repo.GetUser(ctx, "user-json")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"language": "en"}, storedUser.Metadata["preferences"])
+
+	storedSession, err := repo.GetSession(ctx, "session-json")
+	require.NoError(t, err)
+	assert.Equal(t, "web", storedSession.Metadata["client"])
+}
+
+func TestGraphUserRepository_FindExpiredSessions(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGraphUserRepository(testHelpers.NewCleanMockGraph())
+
+	expired, err := domain.NewSession("session-expired", "user-1", time.Hour)
+	require.NoError(t, err)
+	expired.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+	require.NoError(t, repo.CreateSession(ctx, expired))
+
+	active, err := domain.NewSession("session-active", "user-1", time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateSession(ctx, active))
+
+	sessions, err := repo.FindExpiredSessions(ctx)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "session-expired", sessions[0].ID)
+}
+
+// clearUserNodes removes the User and Session nodes left by earlier runs without touching other data
+func clearUserNodes(ctx context.Context, g *graph.Neo4jGraph) error {
+	for _, nodeType := range []string{NodeTypeUser, NodeTypeSession} {
+		if err := g.ClearNodesByType(ctx, nodeType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
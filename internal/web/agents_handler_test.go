@@ -0,0 +1,139 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	agentDomain "neuromesh/internal/agent/domain"
+	"neuromesh/internal/graph"
+	"neuromesh/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAgentProvider serves agents from memory the way the registry service does
+type fakeAgentProvider struct {
+	agents []*agentDomain.Agent
+}
+
+func (f *fakeAgentProvider) GetAllAgents(ctx context.Context) ([]*agentDomain.Agent, error) {
+	return f.agents, nil
+}
+
+func (f *fakeAgentProvider) GetAgent(ctx context.Context, agentID string) (*agentDomain.Agent, error) {
+	for _, agent := range f.agents {
+		if agent.ID == agentID {
+			return agent, nil
+		}
+	}
+	return nil, fmt.Errorf("agent not found: %w", graph.ErrNodeNotFound)
+}
+
+func TestWebBFF_AgentsEndpoints(t *testing.T) {
+	lastSeen := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	provider := &fakeAgentProvider{agents: []*agentDomain.Agent{
+		{
+			ID:       "text-processor",
+			Name:     "Text Processor",
+			Status:   agentDomain.AgentStatusOnline,
+			LastSeen: lastSeen,
+			Capabilities: []agentDomain.AgentCapability{
+				{Name: "word-count", Description: "Counts words in text"},
+			},
+		},
+		{
+			ID:       "deploy-agent",
+			Name:     "Deploy Agent",
+			Status:   agentDomain.AgentStatusBusy,
+			LastSeen: lastSeen,
+			Capabilities: []agentDomain.AgentCapability{
+				{Name: "deploy", Description: "Deploys applications"},
+				{Name: "rollback", Description: "Rolls back deployments"},
+			},
+		},
+		{
+			ID:       "legacy-agent",
+			Name:     "Legacy Agent",
+			Status:   agentDomain.AgentStatusOffline,
+			LastSeen: lastSeen,
+		},
+	}}
+
+	newServer := func(provider AgentProvider) http.Handler {
+		bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+		if provider != nil {
+			bff.SetAgentProvider(provider)
+		}
+		return bff.CreateWebServer(":0").Handler
+	}
+
+	t.Run("lists all agents", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/agents", nil)
+		rec := httptest.NewRecorder()
+		newServer(provider).ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var body []map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body, 3)
+
+		first := body[0]
+		assert.Equal(t, "text-processor", first["id"])
+		assert.Equal(t, "Text Processor", first["name"])
+		assert.Equal(t, "online", first["status"])
+		assert.Equal(t, "2025-01-02T03:04:05Z", first["last_seen"])
+		capabilities := first["capabilities"].([]interface{})
+		require.Len(t, capabilities, 1)
+		assert.Equal(t, "word-count", capabilities[0].(map[string]interface{})["name"])
+
+		assert.Len(t, body[1]["capabilities"], 2)
+		assert.Equal(t, []interface{}{}, body[2]["capabilities"])
+	})
+
+	t.Run("inspects a single agent", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/agents/deploy-agent", nil)
+		rec := httptest.NewRecorder()
+		newServer(provider).ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var body AgentResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "deploy-agent", body.ID)
+		assert.Equal(t, agentDomain.AgentStatusBusy, body.Status)
+		assert.Equal(t, lastSeen, body.LastSeen)
+		assert.Len(t, body.Capabilities, 2)
+	})
+
+	t.Run("returns 404 for a missing agent", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/agents/unknown-agent", nil)
+		rec := httptest.NewRecorder()
+		newServer(provider).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("returns 503 without provider", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/agents", nil)
+		rec := httptest.NewRecorder()
+		newServer(nil).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+
+	t.Run("rejects non-GET requests", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/agents", nil)
+		rec := httptest.NewRecorder()
+		newServer(provider).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
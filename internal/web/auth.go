@@ -0,0 +1,187 @@
+package web
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	conversationDomain "neuromesh/internal/conversation/domain"
+	userDomain "neuromesh/internal/user/domain"
+)
+
+// ErrUnauthenticated is returned by authenticators for missing, malformed or unknown credentials
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// errSessionForbidden is returned when an authenticated user addresses a session owned by someone else
+var errSessionForbidden = errors.New("session belongs to another user")
+
+// Credential schemes accepted in the Authorization header
+const (
+	AuthSchemeBearer = "Bearer"
+	AuthSchemeAPIKey = "ApiKey"
+)
+
+// Authenticator resolves credentials from the Authorization header to a user
+type Authenticator interface {
+	Authenticate(ctx context.Context, scheme, credential string) (*userDomain.User, error)
+}
+
+// authenticatedUserKey is the context key carrying the authenticated user of a request
+type authenticatedUserKey struct{}
+
+// WithAuthenticatedUser returns ctx carrying the authenticated user
+func WithAuthenticatedUser(ctx context.Context, user *userDomain.User) context.Context {
+	return context.WithValue(ctx, authenticatedUserKey{}, user)
+}
+
+// AuthenticatedUser returns the user authenticated for the request, if any
+func AuthenticatedUser(ctx context.Context) (*userDomain.User, bool) {
+	user, ok := ctx.Value(authenticatedUserKey{}).(*userDomain.User)
+	return user, ok && user != nil
+}
+
+// requestUserID returns the authenticated user's ID, falling back to the session ID for anonymous web sessions
+func requestUserID(ctx context.Context, sessionID string) string {
+	if user, ok := AuthenticatedUser(ctx); ok {
+		return user.ID
+	}
+	return sessionID
+}
+
+// SetAuthenticator requires chat and conversation requests to carry credentials resolved by authenticator
+func (w *WebBFF) SetAuthenticator(authenticator Authenticator) {
+	w.authenticator = authenticator
+}
+
+// authenticate resolves the request's credentials, writing a 401 response when they are missing or invalid
+// Without an authenticator every request is admitted unchanged
+func (w *WebBFF) authenticate(rw http.ResponseWriter, r *http.Request) (*http.Request, bool) {
+	if w.authenticator == nil {
+		return r, true
+	}
+
+	scheme, credential, ok := parseAuthorization(r.Header.Get("Authorization"))
+	if !ok {
+		rw.Header().Set("WWW-Authenticate", AuthSchemeBearer)
+		http.Error(rw, "Missing or malformed credentials", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	user, err := w.authenticator.Authenticate(r.Context(), scheme, credential)
+	if err != nil || user == nil || user.Status == userDomain.UserStatusBlocked {
+		if err != nil && !errors.Is(err, ErrUnauthenticated) {
+			w.logger.Error("Failed to authenticate request", err)
+		}
+		rw.Header().Set("WWW-Authenticate", AuthSchemeBearer)
+		http.Error(rw, "Invalid credentials", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	// The conversation service enforces ownership for the authenticated user as well
+	ctx := conversationDomain.WithRequestingUser(WithAuthenticatedUser(r.Context(), user), user.ID)
+	return r.WithContext(ctx), true
+}
+
+// authorizeSession writes a 403 response when the authenticated user does not own the session
+func (w *WebBFF) authorizeSession(ctx context.Context, rw http.ResponseWriter, sessionID string) bool {
+	err := w.checkSessionOwner(ctx, sessionID)
+	if err == nil {
+		return true
+	}
+	if errors.Is(err, errSessionForbidden) {
+		http.Error(rw, "Forbidden", http.StatusForbidden)
+		return false
+	}
+	w.logger.Error("Failed to resolve session owner", err, "sessionID", sessionID)
+	http.Error(rw, "Internal server error", http.StatusInternalServerError)
+	return false
+}
+
+// checkSessionOwner verifies that a session and its durable conversation belong to the authenticated user
+func (w *WebBFF) checkSessionOwner(ctx context.Context, sessionID string) error {
+	user, ok := AuthenticatedUser(ctx)
+	if !ok {
+		return nil
+	}
+
+	w.sessionMutex.RLock()
+	session, exists := w.sessions[sessionID]
+	w.sessionMutex.RUnlock()
+	if exists && session.UserID != user.ID {
+		return errSessionForbidden
+	}
+
+	if w.recorder != nil {
+		conversation, err := w.recorder.GetSessionConversation(ctx, sessionID)
+		if errors.Is(err, conversationDomain.ErrConversationAccessDenied) {
+			return errSessionForbidden
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load session conversation: %w", err)
+		}
+		if conversation != nil && conversation.UserID != user.ID {
+			return errSessionForbidden
+		}
+	}
+	return nil
+}
+
+// parseAuthorization splits an Authorization header into a supported scheme and its credential
+func parseAuthorization(header string) (string, string, bool) {
+	scheme, credential, found := strings.Cut(strings.TrimSpace(header), " ")
+	credential = strings.TrimSpace(credential)
+	if !found || credential == "" {
+		return "", "", false
+	}
+	switch {
+	case strings.EqualFold(scheme, AuthSchemeBearer):
+		return AuthSchemeBearer, credential, true
+	case strings.EqualFold(scheme, AuthSchemeAPIKey):
+		return AuthSchemeAPIKey, credential, true
+	}
+	return "", "", false
+}
+
+// StaticTokenAuthenticator authenticates a fixed set of tokens, each bound to a user ID
+type StaticTokenAuthenticator struct {
+	tokens map[string]string // token -> user ID
+}
+
+// NewStaticTokenAuthenticator creates an authenticator accepting the given tokens as bearer tokens or API keys
+func NewStaticTokenAuthenticator(tokens map[string]string) *StaticTokenAuthenticator {
+	return &StaticTokenAuthenticator{tokens: tokens}
+}
+
+// ParseStaticTokens parses "token:user-id" pairs separated by commas
+func ParseStaticTokens(value string) (map[string]string, error) {
+	tokens := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		token, userID, found := strings.Cut(pair, ":")
+		if !found || token == "" || userID == "" {
+			return nil, fmt.Errorf("invalid token entry %q, expected token:user-id", pair)
+		}
+		tokens[token] = userID
+	}
+	return tokens, nil
+}
+
+// Authenticate resolves a known token to its API user; tokens are compared in constant time
+func (a *StaticTokenAuthenticator) Authenticate(ctx context.Context, scheme, credential string) (*userDomain.User, error) {
+	for token, userID := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(credential)) == 1 {
+			return &userDomain.User{
+				ID:       userID,
+				UserType: userDomain.UserTypeAPIUser,
+				Status:   userDomain.UserStatusActive,
+			}, nil
+		}
+	}
+	return nil, ErrUnauthenticated
+}
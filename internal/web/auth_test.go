@@ -0,0 +1,84 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	conversationApp "neuromesh/internal/conversation/application"
+	conversationInfra "neuromesh/internal/conversation/infrastructure"
+	"neuromesh/internal/logging"
+	userApp "neuromesh/internal/user/application"
+	userInfra "neuromesh/internal/user/infrastructure"
+	"neuromesh/testHelpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebBFF_Authentication(t *testing.T) {
+	testGraph := testHelpers.NewCleanMockGraph()
+	conversationService := conversationApp.NewConversationService(conversationInfra.NewGraphConversationRepository(testGraph))
+	userService := userApp.NewUserService(userInfra.NewGraphUserRepository(testGraph))
+
+	bff := NewConversationAwareWebBFF(&MockAIOrchestrator{}, conversationService, userService, logging.NewNoOpLogger())
+	bff.SetAuthenticator(NewStaticTokenAuthenticator(map[string]string{
+		"alice-token": "alice",
+		"bob-key":     "bob",
+	}))
+	handler := bff.CreateWebServer(":0").Handler
+
+	chat := func(authorization, sessionID string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(ChatRequest{SessionID: sessionID, Message: "Hello"})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/chat", bytes.NewBuffer(body))
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("binds the conversation to the authenticated user", func(t *testing.T) {
+		rec := chat("Bearer alice-token", "alice-session")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var response WebResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		conversation, err := conversationService.GetConversation(context.Background(), response.ConversationID)
+		require.NoError(t, err)
+		assert.Equal(t, "alice", conversation.UserID)
+		assert.Equal(t, "alice-session", conversation.SessionID)
+	})
+
+	t.Run("accepts API keys", func(t *testing.T) {
+		rec := chat("ApiKey bob-key", "bob-session")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("rejects missing credentials", func(t *testing.T) {
+		rec := chat("", "alice-session")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, AuthSchemeBearer, rec.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("rejects unknown tokens", func(t *testing.T) {
+		rec := chat("Bearer forged-token", "alice-session")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("rejects a token that does not own the conversation", func(t *testing.T) {
+		rec := chat("Bearer bob-key", "alice-session")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/alice-session/conversation", nil)
+		req.Header.Set("Authorization", "ApiKey bob-key")
+		historyRec := httptest.NewRecorder()
+		handler.ServeHTTP(historyRec, req)
+		assert.Equal(t, http.StatusForbidden, historyRec.Code)
+	})
+}
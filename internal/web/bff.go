@@ -7,10 +7,20 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	agentDomain "neuromesh/internal/agent/domain"
+	conversationDomain "neuromesh/internal/conversation/domain"
+	executionApp "neuromesh/internal/execution/application"
+	executionDomain "neuromesh/internal/execution/domain"
+	"neuromesh/internal/graph"
 	"neuromesh/internal/logging"
+	"neuromesh/internal/metrics"
 	"neuromesh/internal/orchestrator/application"
+	planningDomain "neuromesh/internal/planning/domain"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
 
@@ -18,15 +28,19 @@ import (
 type ChatRequest struct {
 	SessionID string `json:"session_id"`
 	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"` // Optional client correlation ID echoed on chat socket frames
 }
 
 // WebResponse represents a response from the WebBFF to the web client
 type WebResponse struct {
-	Content       string `json:"content"`
-	SessionID     string `json:"session_id"`
-	Intent        string `json:"intent,omitempty"`
-	Error         string `json:"error,omitempty"`
+	Content   string `json:"content"`
+	SessionID string `json:"session_id"`
+	Intent    string `json:"intent,omitempty"`
+	Error     string `json:"error,omitempty"`
+	// CorrelationID identifies the request's execution; POST /api/conversation/{correlationID}/cancel cancels it
 	CorrelationID string `json:"correlation_id,omitempty"`
+	// ConversationID identifies the durable conversation of the session so clients can reload its history
+	ConversationID string `json:"conversation_id,omitempty"`
 }
 
 // AIOrchestrator defines the interface for AI orchestration operations
@@ -34,6 +48,138 @@ type AIOrchestrator interface {
 	ProcessRequest(ctx context.Context, userInput, userID string) (*application.OrchestratorResult, error)
 }
 
+// StreamingAIOrchestrator is implemented by orchestrators that can report progress while processing a request
+type StreamingAIOrchestrator interface {
+	ProcessRequestWithProgress(ctx context.Context, userInput, userID string, onProgress executionDomain.ProgressReporter) (*application.OrchestratorResult, error)
+}
+
+// PlanPreviewer is implemented by orchestrators that can generate a plan without executing it
+type PlanPreviewer interface {
+	PreviewPlan(ctx context.Context, userInput, userID string) (*planningDomain.ExecutionPlan, error)
+}
+
+// PlanApprover is implemented by orchestrators whose plans can wait for a user's approval before executing
+type PlanApprover interface {
+	ApprovePlan(ctx context.Context, planID, approverUserID string) (*application.OrchestratorResult, error)
+	RejectPlan(ctx context.Context, planID, approverUserID, reason string) error
+}
+
+// PlanReviewRequest is the body of a plan approval or rejection from the web UI
+type PlanReviewRequest struct {
+	SessionID string `json:"session_id"`
+	Reason    string `json:"reason,omitempty"` // Only used when rejecting
+}
+
+// PlanReviewResponse reports the outcome of a plan approval or rejection
+type PlanReviewResponse struct {
+	PlanID  string `json:"plan_id"`
+	Status  string `json:"status"`
+	Content string `json:"content,omitempty"` // Execution result of an approved plan
+	Error   string `json:"error,omitempty"`
+	// ExecutionID identifies the background execution of an approved plan, which cancels it
+	ExecutionID string `json:"execution_id,omitempty"`
+}
+
+// PlanProgressProvider defines the interface for querying execution plan progress
+type PlanProgressProvider interface {
+	// GetPlanProgress reports a plan's progress to requesterID, who must own it unless empty
+	GetPlanProgress(ctx context.Context, planID, requesterID string) (planningDomain.PlanProgress, error)
+}
+
+// ExecutionCanceller defines the interface for cancelling an in-flight execution
+type ExecutionCanceller interface {
+	// CancelExecution cancels an execution on behalf of requesterID, who must own it unless empty
+	CancelExecution(ctx context.Context, correlationID, requesterID string) error
+}
+
+// AgentProvider defines the interface for listing and inspecting registered agents
+type AgentProvider interface {
+	GetAllAgents(ctx context.Context) ([]*agentDomain.Agent, error)
+	GetAgent(ctx context.Context, agentID string) (*agentDomain.Agent, error)
+}
+
+// ConversationRecorder persists web chat exchanges in one durable conversation per session
+type ConversationRecorder interface {
+	// StartExchange resolves or creates the session's conversation and records the user message
+	StartExchange(ctx context.Context, sessionID, message string) (Exchange, error)
+	// FinishExchange records the orchestrator's reply and decision in the conversation
+	FinishExchange(ctx context.Context, exchange Exchange, result *application.OrchestratorResult) error
+	// GetSessionConversation returns the session's conversation with its messages, or nil when it has none
+	GetSessionConversation(ctx context.Context, sessionID string) (*conversationDomain.Conversation, error)
+	// GetConversation returns a conversation with its messages by ID
+	GetConversation(ctx context.Context, conversationID string) (*conversationDomain.Conversation, error)
+	// GetConversationDecisions returns the AI decisions made in a conversation, oldest first
+	GetConversationDecisions(ctx context.Context, conversationID string) ([]*conversationDomain.AIDecision, error)
+}
+
+// AgentResultProvider defines the interface for loading the agent results of an execution plan
+type AgentResultProvider interface {
+	GetAgentResultsByExecutionPlan(ctx context.Context, planID string) ([]*executionDomain.AgentResult, error)
+}
+
+// Exchange identifies one user request recorded in a durable conversation
+type Exchange struct {
+	ConversationID   string
+	RequestMessageID string // ID of the recorded user message
+	LastDecisionID   string // Most recent decision in the conversation, which the new decision follows
+	PriorDecisions   []planningDomain.PriorDecision
+	History          string // Conversation summary and recent messages preceding the request
+}
+
+// decisionContext returns ctx carrying the conversation state the orchestrator decides on
+func (e Exchange) decisionContext(ctx context.Context) context.Context {
+	ctx = planningDomain.WithPriorDecisions(ctx, e.PriorDecisions)
+	return planningDomain.WithConversationHistory(ctx, e.History)
+}
+
+// AgentResponse represents a registered agent as returned by the agents API
+type AgentResponse struct {
+	ID           string                        `json:"id"`
+	Name         string                        `json:"name"`
+	Status       agentDomain.AgentStatus       `json:"status"`
+	LastSeen     time.Time                     `json:"last_seen"`
+	Capabilities []agentDomain.AgentCapability `json:"capabilities"`
+}
+
+// newAgentResponse converts a domain agent into its API representation
+func newAgentResponse(agent *agentDomain.Agent) AgentResponse {
+	capabilities := agent.Capabilities
+	if capabilities == nil {
+		capabilities = []agentDomain.AgentCapability{}
+	}
+	return AgentResponse{
+		ID:           agent.ID,
+		Name:         agent.Name,
+		Status:       agent.Status,
+		LastSeen:     agent.LastSeen,
+		Capabilities: capabilities,
+	}
+}
+
+// SessionConversationResponse is a session's durable conversation as returned to reconnecting clients
+type SessionConversationResponse struct {
+	ConversationID string                                   `json:"conversation_id"`
+	SessionID      string                                   `json:"session_id"`
+	Messages       []conversationDomain.ConversationMessage `json:"messages"`
+}
+
+// SessionResponse carries a newly issued web session ID
+type SessionResponse struct {
+	SessionID string `json:"session_id"`
+}
+
+// ReadinessCheck reports whether a dependency of the web server is reachable
+type ReadinessCheck func(ctx context.Context) error
+
+// namedReadinessCheck associates a readiness check with the dependency it verifies
+type namedReadinessCheck struct {
+	name  string
+	check ReadinessCheck
+}
+
+// DefaultReadinessTimeout bounds how long /readyz waits for all dependency checks
+const DefaultReadinessTimeout = 5 * time.Second
+
 // WebSocket upgrader
 var upgrader = websocket.Upgrader{
 	CheckOrigin: func(r *http.Request) bool {
@@ -46,10 +192,27 @@ var upgrader = websocket.Upgrader{
 // WebBFF (Backend for Frontend) handles web session communication
 // It provides a clean separation between web UI concerns and agent orchestration
 type WebBFF struct {
-	orchestrator AIOrchestrator
-	logger       logging.Logger
-	sessions     map[string]*WebSession
-	sessionMutex sync.RWMutex
+	orchestrator  AIOrchestrator
+	planProgress  PlanProgressProvider
+	agents        AgentProvider
+	canceller     ExecutionCanceller
+	agentResults  AgentResultProvider
+	feedback      FeedbackRecorder
+	analytics     DecisionAnalytics
+	rateLimiter   RateLimiter
+	authenticator Authenticator
+	recorder      ConversationRecorder
+	cors          *CORSConfig
+	readiness     []namedReadinessCheck
+	logger        logging.Logger
+	sessions      map[string]*WebSession
+	sessionMutex  sync.RWMutex
+
+	maxMessageLength int
+
+	// draining rejects new chat requests during shutdown while inFlight counts messages still being processed
+	draining atomic.Bool
+	inFlight atomic.Int64
 }
 
 // WebSession represents a web user session
@@ -66,12 +229,49 @@ func NewWebBFF(orchestrator AIOrchestrator, logger logging.Logger) *WebBFF {
 		logger:       logger,
 		sessions:     make(map[string]*WebSession),
 		sessionMutex: sync.RWMutex{},
+
+		maxMessageLength: DefaultMaxMessageLength,
 	}
 }
 
+// SetPlanProgressProvider enables the plan progress endpoint
+func (w *WebBFF) SetPlanProgressProvider(provider PlanProgressProvider) {
+	w.planProgress = provider
+}
+
+// SetConversationRecorder enables conversation persistence for chat requests and the session history endpoint
+func (w *WebBFF) SetConversationRecorder(recorder ConversationRecorder) {
+	w.recorder = recorder
+}
+
+// SetAgentProvider enables the agent listing endpoints
+func (w *WebBFF) SetAgentProvider(provider AgentProvider) {
+	w.agents = provider
+}
+
+// SetExecutionCanceller enables the execution cancellation endpoint
+func (w *WebBFF) SetExecutionCanceller(canceller ExecutionCanceller) {
+	w.canceller = canceller
+}
+
+// AddReadinessCheck registers a dependency check reported by the /readyz endpoint
+func (w *WebBFF) AddReadinessCheck(name string, check ReadinessCheck) {
+	w.readiness = append(w.readiness, namedReadinessCheck{name: name, check: check})
+}
+
 // ProcessWebMessage processes a message from a web session
 // This method handles web-specific concerns and delegates AI processing to the orchestrator
 func (w *WebBFF) ProcessWebMessage(ctx context.Context, sessionID, message string) (*WebResponse, error) {
+	return w.processWebMessage(ctx, sessionID, message, nil)
+}
+
+// ProcessWebMessageWithProgress processes a message from a web session and reports orchestration progress to onProgress
+func (w *WebBFF) ProcessWebMessageWithProgress(ctx context.Context, sessionID, message string, onProgress executionDomain.ProgressReporter) (*WebResponse, error) {
+	return w.processWebMessage(ctx, sessionID, message, onProgress)
+}
+
+// processWebMessage handles a web message, streaming progress when a reporter is given and the orchestrator supports it
+func (w *WebBFF) processWebMessage(ctx context.Context, sessionID, message string, onProgress executionDomain.ProgressReporter) (*WebResponse, error) {
 	// Validate input
 	if sessionID == "" {
 		return nil, errors.New("session ID cannot be empty")
@@ -85,14 +285,32 @@ func (w *WebBFF) ProcessWebMessage(ctx context.Context, sessionID, message strin
 		return nil, ctx.Err()
 	}
 
-	// Get or create session
-	session := w.getOrCreateSession(sessionID)
+	w.inFlight.Add(1)
+	defer w.inFlight.Add(-1)
+
+	// Get or create session, owned by the authenticated user when authentication is enabled
+	session := w.getOrCreateSession(sessionID, requestUserID(ctx, sessionID))
+
+	// The execution ID is handed out up front so the client can cancel the request while it runs
+	executionID := executionDomain.NewExecutionID(session.UserID)
+	ctx = executionDomain.WithExecutionID(ctx, executionID)
+
+	// Record the user message in the session's durable conversation
+	exchange := w.startExchange(ctx, sessionID, message)
+	ctx = exchange.decisionContext(ctx)
 
 	w.logger.Debug("Processing web message", "sessionID", sessionID, "message", message)
 
 	// Process request through AI orchestrator
 	// Note: For web sessions, we use the sessionID as userID to maintain session isolation
-	aiResponse, err := w.orchestrator.ProcessRequest(ctx, message, session.UserID)
+	var aiResponse *application.OrchestratorResult
+	var err error
+	if streamingOrchestrator, ok := w.orchestrator.(StreamingAIOrchestrator); ok && onProgress != nil {
+		onProgress(executionDomain.NewProgressEvent(executionDomain.ProgressEventExecutionStarted, "", executionID))
+		aiResponse, err = streamingOrchestrator.ProcessRequestWithProgress(ctx, message, session.UserID, onProgress)
+	} else {
+		aiResponse, err = w.orchestrator.ProcessRequest(ctx, message, session.UserID)
+	}
 	if err != nil {
 		w.logger.Error("Failed to process AI request", err, "sessionID", sessionID)
 		return &WebResponse{
@@ -133,9 +351,15 @@ func (w *WebBFF) ProcessWebMessage(ctx context.Context, sessionID, message strin
 	}
 
 	webResponse := &WebResponse{
-		Content:   aiResponse.Message,
-		SessionID: sessionID,
-		Intent:    intent,
+		Content:       aiResponse.Message,
+		SessionID:     sessionID,
+		Intent:        intent,
+		CorrelationID: executionID,
+	}
+
+	if exchange.ConversationID != "" {
+		w.finishExchange(ctx, exchange, aiResponse)
+		webResponse.ConversationID = exchange.ConversationID
 	}
 
 	w.logger.Info("Web message processed successfully", "sessionID", sessionID)
@@ -143,8 +367,29 @@ func (w *WebBFF) ProcessWebMessage(ctx context.Context, sessionID, message strin
 	return webResponse, nil
 }
 
+// startExchange records the user message when conversation persistence is enabled
+// Persistence failures are logged so the request is still answered
+func (w *WebBFF) startExchange(ctx context.Context, sessionID, message string) Exchange {
+	if w.recorder == nil {
+		return Exchange{}
+	}
+
+	exchange, err := w.recorder.StartExchange(ctx, sessionID, message)
+	if err != nil {
+		w.logger.Error("Failed to record user message", err, "sessionID", sessionID, "conversationID", exchange.ConversationID)
+	}
+	return exchange
+}
+
+// finishExchange records the orchestrator's reply in the session's conversation
+func (w *WebBFF) finishExchange(ctx context.Context, exchange Exchange, result *application.OrchestratorResult) {
+	if err := w.recorder.FinishExchange(ctx, exchange, result); err != nil {
+		w.logger.Error("Failed to record assistant response", err, "conversationID", exchange.ConversationID)
+	}
+}
+
 // getOrCreateSession retrieves an existing session or creates a new one
-func (w *WebBFF) getOrCreateSession(sessionID string) *WebSession {
+func (w *WebBFF) getOrCreateSession(sessionID, userID string) *WebSession {
 	w.sessionMutex.RLock()
 	session, exists := w.sessions[sessionID]
 	w.sessionMutex.RUnlock()
@@ -164,8 +409,8 @@ func (w *WebBFF) getOrCreateSession(sessionID string) *WebSession {
 
 	session = &WebSession{
 		SessionID: sessionID,
-		UserID:    sessionID, // Use sessionID as userID for web sessions
-		CreatedAt: 0,         // Could add timestamp here if needed
+		UserID:    userID, // The session ID doubles as user ID for unauthenticated web sessions
+		CreatedAt: 0,      // Could add timestamp here if needed
 	}
 	w.sessions[sessionID] = session
 
@@ -181,22 +426,35 @@ func (w *WebBFF) ChatHandler() http.Handler {
 			http.Error(rw, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
+		if !w.admitRequest(rw) {
+			return
+		}
+		r, authenticated := w.authenticate(rw, r)
+		if !authenticated {
+			return
+		}
 
 		// Parse request
 		var chatReq ChatRequest
-		if err := json.NewDecoder(r.Body).Decode(&chatReq); err != nil {
+		if err := decodeChatRequest(r.Body, &chatReq); err != nil {
+			if errors.Is(err, errInvalidUTF8) {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
 			w.logger.Error("Failed to decode chat request", err)
 			http.Error(rw, "Invalid JSON", http.StatusBadRequest)
 			return
 		}
 
 		// Validate request
-		if chatReq.SessionID == "" {
-			http.Error(rw, "session_id is required", http.StatusBadRequest)
+		if err := w.validateChatRequest(&chatReq); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !w.authorizeSession(r.Context(), rw, chatReq.SessionID) {
 			return
 		}
-		if chatReq.Message == "" {
-			http.Error(rw, "message is required", http.StatusBadRequest)
+		if !w.allowRequest(rw, r) {
 			return
 		}
 
@@ -218,9 +476,428 @@ func (w *WebBFF) ChatHandler() http.Handler {
 	})
 }
 
+// ChatStreamHandler returns an HTTP handler that streams orchestration progress as Server-Sent Events
+// Progress events are sent as they happen, followed by a final "response" event carrying the WebResponse
+func (w *WebBFF) ChatStreamHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(rw, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !w.admitRequest(rw) {
+			return
+		}
+		r, authenticated := w.authenticate(rw, r)
+		if !authenticated {
+			return
+		}
+
+		var chatReq ChatRequest
+		if err := decodeChatRequest(r.Body, &chatReq); err != nil {
+			if errors.Is(err, errInvalidUTF8) {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+			w.logger.Error("Failed to decode chat stream request", err)
+			http.Error(rw, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+		if err := w.validateChatRequest(&chatReq); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !w.authorizeSession(r.Context(), rw, chatReq.SessionID) {
+			return
+		}
+		if !w.allowRequest(rw, r) {
+			return
+		}
+
+		flusher, ok := rw.(http.Flusher)
+		if !ok {
+			http.Error(rw, "Streaming not supported", http.StatusInternalServerError)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "text/event-stream")
+		rw.Header().Set("Cache-Control", "no-cache")
+		rw.Header().Set("Connection", "keep-alive")
+		rw.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		// Agents may report concurrently during batch execution, so writes are serialized
+		var writeMutex sync.Mutex
+		writeEvent := func(event string, payload interface{}) {
+			data, err := json.Marshal(payload)
+			if err != nil {
+				w.logger.Error("Failed to encode stream event", err, "event", event)
+				return
+			}
+
+			writeMutex.Lock()
+			defer writeMutex.Unlock()
+			fmt.Fprintf(rw, "event: %s\ndata: %s\n\n", event, data)
+			flusher.Flush()
+		}
+
+		response, err := w.ProcessWebMessageWithProgress(r.Context(), chatReq.SessionID, chatReq.Message, func(progress executionDomain.ProgressEvent) {
+			writeEvent(string(progress.Type), progress)
+		})
+		if err != nil {
+			w.logger.Error("Failed to process streamed web message", err)
+			writeEvent("error", map[string]string{"error": err.Error()})
+			return
+		}
+
+		writeEvent("response", response)
+	})
+}
+
+// HealthzHandler returns an HTTP handler reporting that the process is alive
+func (w *WebBFF) HealthzHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusOK)
+		json.NewEncoder(rw).Encode(map[string]string{"status": "ok"})
+	})
+}
+
+// ReadyzHandler returns an HTTP handler that checks every registered dependency
+// It responds with 503 and lists the failed dependencies when any check fails
+func (w *WebBFF) ReadyzHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), DefaultReadinessTimeout)
+		defer cancel()
+
+		checks := make(map[string]string, len(w.readiness))
+		failed := []string{}
+		for _, readiness := range w.readiness {
+			if err := readiness.check(ctx); err != nil {
+				w.logger.Warn("Readiness check failed", "dependency", readiness.name, "error", err.Error())
+				checks[readiness.name] = err.Error()
+				failed = append(failed, readiness.name)
+				continue
+			}
+			checks[readiness.name] = "ok"
+		}
+
+		status := "ready"
+		statusCode := http.StatusOK
+		if len(failed) > 0 {
+			status = "not_ready"
+			statusCode = http.StatusServiceUnavailable
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(statusCode)
+		json.NewEncoder(rw).Encode(map[string]interface{}{
+			"status": status,
+			"checks": checks,
+			"failed": failed,
+		})
+	})
+}
+
+// PlanProgressHandler returns an HTTP handler reporting the progress of an execution plan
+func (w *WebBFF) PlanProgressHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(rw, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if w.planProgress == nil {
+			http.Error(rw, "Plan progress not available", http.StatusServiceUnavailable)
+			return
+		}
+		r, authenticated := w.authenticate(rw, r)
+		if !authenticated {
+			return
+		}
+
+		planID := r.PathValue("id")
+		if planID == "" {
+			http.Error(rw, "plan id is required", http.StatusBadRequest)
+			return
+		}
+
+		// Authenticated users only see the progress of their own plans
+		progress, err := w.planProgress.GetPlanProgress(r.Context(), planID, requestUserID(r.Context(), ""))
+		if errors.Is(err, planningDomain.ErrPlanAccessDenied) {
+			http.Error(rw, "Forbidden", http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, graph.ErrNodeNotFound) {
+			http.Error(rw, "Plan not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			w.logger.Error("Failed to get plan progress", err, "plan_id", planID)
+			http.Error(rw, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(rw).Encode(progress); err != nil {
+			w.logger.Error("Failed to encode plan progress", err)
+			http.Error(rw, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	})
+}
+
+// PlanPreviewHandler returns an HTTP handler generating the draft execution plan for a message without running it
+func (w *WebBFF) PlanPreviewHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		previewer, ok := w.orchestrator.(PlanPreviewer)
+		if !ok {
+			http.Error(rw, "Plan preview not available", http.StatusServiceUnavailable)
+			return
+		}
+		if !w.admitRequest(rw) {
+			return
+		}
+		r, authenticated := w.authenticate(rw, r)
+		if !authenticated {
+			return
+		}
+
+		var chatReq ChatRequest
+		if err := decodeChatRequest(r.Body, &chatReq); err != nil {
+			if errors.Is(err, errInvalidUTF8) {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+			http.Error(rw, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+		if err := w.validateChatRequest(&chatReq); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !w.authorizeSession(r.Context(), rw, chatReq.SessionID) {
+			return
+		}
+		if !w.allowRequest(rw, r) {
+			return
+		}
+
+		plan, err := previewer.PreviewPlan(r.Context(), chatReq.Message, requestUserID(r.Context(), chatReq.SessionID))
+		if errors.Is(err, application.ErrNoExecutionPlan) {
+			http.Error(rw, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		if err != nil {
+			w.logger.Error("Failed to preview plan", err, "sessionID", chatReq.SessionID)
+			http.Error(rw, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(rw).Encode(plan); err != nil {
+			w.logger.Error("Failed to encode plan preview", err)
+			http.Error(rw, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	})
+}
+
+// PlanApprovalHandler returns an HTTP handler approving a plan that awaits approval and starting its execution
+// The plan executes in the background, so approvals are answered with 202 Accepted
+func (w *WebBFF) PlanApprovalHandler() http.Handler {
+	return w.planReviewHandler(http.StatusAccepted, func(ctx context.Context, approver PlanApprover, planID, userID string, review PlanReviewRequest) (PlanReviewResponse, error) {
+		executionID := executionDomain.NewExecutionID(userID)
+		result, err := approver.ApprovePlan(executionDomain.WithExecutionID(ctx, executionID), planID, userID)
+		if err != nil {
+			return PlanReviewResponse{}, err
+		}
+		return PlanReviewResponse{PlanID: planID, Status: "approved", Content: result.Message, Error: result.Error, ExecutionID: executionID}, nil
+	})
+}
+
+// PlanRejectionHandler returns an HTTP handler rejecting a plan that awaits approval
+func (w *WebBFF) PlanRejectionHandler() http.Handler {
+	return w.planReviewHandler(http.StatusOK, func(ctx context.Context, approver PlanApprover, planID, userID string, review PlanReviewRequest) (PlanReviewResponse, error) {
+		if err := approver.RejectPlan(ctx, planID, userID, review.Reason); err != nil {
+			return PlanReviewResponse{}, err
+		}
+		return PlanReviewResponse{PlanID: planID, Status: "rejected"}, nil
+	})
+}
+
+// planReviewHandler authenticates a plan review on behalf of the session's user and applies it with review
+// A successful review is answered with successStatus
+func (w *WebBFF) planReviewHandler(successStatus int, review func(ctx context.Context, approver PlanApprover, planID, userID string, req PlanReviewRequest) (PlanReviewResponse, error)) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		approver, ok := w.orchestrator.(PlanApprover)
+		if !ok {
+			http.Error(rw, "Plan approval not available", http.StatusServiceUnavailable)
+			return
+		}
+		if !w.admitRequest(rw) {
+			return
+		}
+		r, authenticated := w.authenticate(rw, r)
+		if !authenticated {
+			return
+		}
+
+		planID := r.PathValue("id")
+		var req PlanReviewRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(rw, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+		if req.SessionID == "" {
+			http.Error(rw, "session_id is required", http.StatusBadRequest)
+			return
+		}
+		if !w.authorizeSession(r.Context(), rw, req.SessionID) {
+			return
+		}
+
+		response, err := review(r.Context(), approver, planID, requestUserID(r.Context(), req.SessionID), req)
+		if errors.Is(err, application.ErrPlanNotAwaitingApproval) {
+			http.Error(rw, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, application.ErrPlanAccessDenied) {
+			http.Error(rw, "Forbidden", http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, graph.ErrNodeNotFound) {
+			http.Error(rw, "Plan not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			w.logger.Error("Failed to review plan", err, "plan_id", planID)
+			http.Error(rw, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(successStatus)
+		if err := json.NewEncoder(rw).Encode(response); err != nil {
+			w.logger.Error("Failed to encode plan review response", err)
+			http.Error(rw, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+	})
+}
+
+// CancelExecutionHandler returns an HTTP handler cancelling the execution identified by the correlation ID of a chat response
+func (w *WebBFF) CancelExecutionHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if w.canceller == nil {
+			http.Error(rw, "Execution cancellation not available", http.StatusServiceUnavailable)
+			return
+		}
+		r, authenticated := w.authenticate(rw, r)
+		if !authenticated {
+			return
+		}
+
+		correlationID := r.PathValue("correlationID")
+		if correlationID == "" {
+			http.Error(rw, "correlation id is required", http.StatusBadRequest)
+			return
+		}
+
+		// Authenticated users can only cancel their own executions
+		err := w.canceller.CancelExecution(r.Context(), correlationID, requestUserID(r.Context(), ""))
+		if errors.Is(err, executionApp.ErrExecutionNotFound) {
+			http.Error(rw, "Execution not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, executionApp.ErrExecutionAccessDenied) {
+			http.Error(rw, "Forbidden", http.StatusForbidden)
+			return
+		}
+		if err != nil {
+			w.logger.Error("Failed to cancel execution", err, "correlation_id", correlationID)
+			http.Error(rw, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.logger.Info("Execution cancelled", "correlation_id", correlationID)
+
+		rw.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(rw).Encode(map[string]string{
+			"status":         "cancelled",
+			"correlation_id": correlationID,
+		})
+	})
+}
+
+// AgentsHandler returns an HTTP handler listing all registered agents
+func (w *WebBFF) AgentsHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if w.agents == nil {
+			http.Error(rw, "Agent registry not available", http.StatusServiceUnavailable)
+			return
+		}
+
+		agents, err := w.agents.GetAllAgents(r.Context())
+		if err != nil {
+			w.logger.Error("Failed to list agents", err)
+			http.Error(rw, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		response := make([]AgentResponse, 0, len(agents))
+		for _, agent := range agents {
+			response = append(response, newAgentResponse(agent))
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(rw).Encode(response); err != nil {
+			w.logger.Error("Failed to encode agents", err)
+		}
+	})
+}
+
+// AgentHandler returns an HTTP handler inspecting a single registered agent
+func (w *WebBFF) AgentHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if w.agents == nil {
+			http.Error(rw, "Agent registry not available", http.StatusServiceUnavailable)
+			return
+		}
+
+		agentID := r.PathValue("id")
+		if agentID == "" {
+			http.Error(rw, "agent id is required", http.StatusBadRequest)
+			return
+		}
+
+		agent, err := w.agents.GetAgent(r.Context(), agentID)
+		if errors.Is(err, graph.ErrNodeNotFound) || (err == nil && agent == nil) {
+			http.Error(rw, "Agent not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			w.logger.Error("Failed to get agent", err, "agent_id", agentID)
+			http.Error(rw, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(rw).Encode(newAgentResponse(agent)); err != nil {
+			w.logger.Error("Failed to encode agent", err)
+		}
+	})
+}
+
 // WebSocketHandler returns a WebSocket handler for real-time chat
 func (w *WebBFF) WebSocketHandler() http.Handler {
 	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if !w.admitRequest(rw) {
+			return
+		}
+		r, authenticated := w.authenticate(rw, r)
+		if !authenticated {
+			return
+		}
+
 		// Upgrade connection to WebSocket
 		conn, err := upgrader.Upgrade(rw, r, nil)
 		if err != nil {
@@ -243,20 +920,28 @@ func (w *WebBFF) WebSocketHandler() http.Handler {
 			}
 
 			// Use session ID from message, or fall back to default from URL
-			sessionID := message.SessionID
-			if sessionID == "" {
-				sessionID = defaultSessionID
+			if message.SessionID == "" {
+				message.SessionID = defaultSessionID
 			}
 
-			// Validate session ID
-			if sessionID == "" {
-				w.logger.Error("Missing session_id in WebSocket message", nil)
-				conn.WriteJSON(map[string]string{"error": "session_id is required"})
+			// Validate session ID and message
+			if err := w.validateChatRequest(&message); err != nil {
+				w.logger.Error("Invalid WebSocket message", err)
+				conn.WriteJSON(map[string]string{"error": err.Error()})
+				continue
+			}
+			if err := w.checkSessionOwner(r.Context(), message.SessionID); err != nil {
+				w.logger.Warn("Rejected WebSocket message for session", "sessionID", message.SessionID, "error", err)
+				conn.WriteJSON(map[string]string{"error": "Forbidden"})
 				continue
 			}
+			if w.draining.Load() {
+				conn.WriteJSON(map[string]string{"error": "Server is shutting down"})
+				break
+			}
 
 			// Process message
-			response, err := w.ProcessWebMessage(r.Context(), sessionID, message.Message)
+			response, err := w.ProcessWebMessage(r.Context(), message.SessionID, message.Message)
 			if err != nil {
 				w.logger.Error("Failed to process WebSocket message", err)
 				conn.WriteJSON(map[string]string{"error": "Failed to process message"})
@@ -274,13 +959,112 @@ func (w *WebBFF) WebSocketHandler() http.Handler {
 	})
 }
 
+// SessionHandler returns an HTTP handler issuing a new session ID drawn from crypto/rand
+// With authentication enabled the session is bound to the authenticated user
+func (w *WebBFF) SessionHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if !w.admitRequest(rw) {
+			return
+		}
+		r, authenticated := w.authenticate(rw, r)
+		if !authenticated {
+			return
+		}
+
+		sessionID := uuid.NewString()
+		w.getOrCreateSession(sessionID, requestUserID(r.Context(), sessionID))
+
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(rw).Encode(SessionResponse{SessionID: sessionID}); err != nil {
+			w.logger.Error("Failed to encode session", err)
+		}
+	})
+}
+
+// SessionConversationHandler returns an HTTP handler loading a session's conversation so a reconnecting client can restore it
+// History is only returned to the authenticated user owning the conversation
+func (w *WebBFF) SessionConversationHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if w.recorder == nil {
+			http.Error(rw, "Conversation persistence not available", http.StatusServiceUnavailable)
+			return
+		}
+		if w.authenticator == nil {
+			http.Error(rw, "Conversation history requires authentication", http.StatusUnauthorized)
+			return
+		}
+		r, authenticated := w.authenticate(rw, r)
+		if !authenticated {
+			return
+		}
+		user, _ := AuthenticatedUser(r.Context())
+
+		sessionID := r.PathValue("sessionID")
+		if !sessionIDPattern.MatchString(sessionID) {
+			http.Error(rw, "invalid session_id", http.StatusBadRequest)
+			return
+		}
+
+		conversation, err := w.recorder.GetSessionConversation(r.Context(), sessionID)
+		if errors.Is(err, conversationDomain.ErrConversationAccessDenied) {
+			http.Error(rw, "Forbidden", http.StatusForbidden)
+			return
+		}
+		if err != nil {
+			w.logger.Error("Failed to load session conversation", err, "sessionID", sessionID)
+			http.Error(rw, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		if conversation == nil {
+			http.Error(rw, "Conversation not found", http.StatusNotFound)
+			return
+		}
+		if conversation.UserID != user.ID {
+			http.Error(rw, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		messages := conversation.Messages
+		if messages == nil {
+			messages = []conversationDomain.ConversationMessage{}
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(rw).Encode(SessionConversationResponse{
+			ConversationID: conversation.ID,
+			SessionID:      sessionID,
+			Messages:       messages,
+		}); err != nil {
+			w.logger.Error("Failed to encode session conversation", err)
+		}
+	})
+}
+
 // CreateWebServer creates and configures an HTTP server with WebBFF routes
 func (w *WebBFF) CreateWebServer(addr string) *http.Server {
 	mux := http.NewServeMux()
 
 	// Add routes
 	mux.Handle("/api/chat", w.ChatHandler())
+	mux.Handle("/api/chat/stream", w.ChatStreamHandler())
 	mux.Handle("/ws", w.WebSocketHandler())
+	mux.Handle("GET /ws/chat", w.ChatWebSocketHandler())
+	mux.Handle("GET /api/plans/{id}/progress", w.PlanProgressHandler())
+	mux.Handle("POST /api/plan/preview", w.PlanPreviewHandler())
+	mux.Handle("POST /api/plans/{id}/approve", w.PlanApprovalHandler())
+	mux.Handle("POST /api/plans/{id}/reject", w.PlanRejectionHandler())
+	mux.Handle("POST /api/conversation/{correlationID}/cancel", w.CancelExecutionHandler())
+	mux.Handle("POST /api/sessions", w.SessionHandler())
+	mux.Handle("GET /api/sessions/{sessionID}/conversation", w.SessionConversationHandler())
+	mux.Handle("GET /api/conversations/{id}/export", w.ConversationExportHandler())
+	mux.Handle("POST /api/conversations/{id}/feedback", w.FeedbackHandler())
+	mux.Handle("GET /api/analytics/decisions", w.DecisionAnalyticsHandler())
+	mux.Handle("GET /api/agents", w.AgentsHandler())
+	mux.Handle("GET /api/agents/{id}", w.AgentHandler())
+	mux.Handle("GET /healthz", w.HealthzHandler())
+	mux.Handle("GET /readyz", w.ReadyzHandler())
+	mux.Handle("GET /metrics", metrics.Handler())
 
 	// Add health check
 	mux.HandleFunc("/health", func(rw http.ResponseWriter, r *http.Request) {
@@ -291,6 +1075,6 @@ func (w *WebBFF) CreateWebServer(addr string) *http.Server {
 
 	return &http.Server{
 		Addr:    addr,
-		Handler: mux,
+		Handler: w.withCORS(mux),
 	}
 }
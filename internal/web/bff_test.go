@@ -8,7 +8,7 @@ import (
 
 	"neuromesh/internal/logging"
 	"neuromesh/internal/orchestrator/application"
-	"neuromesh/internal/orchestrator/domain"
+	"neuromesh/internal/planning/domain"
 )
 
 // MockAIOrchestrator for testing
@@ -0,0 +1,108 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	executionApp "neuromesh/internal/execution/application"
+	"neuromesh/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubExecutionCanceller records cancellations and rejects unknown correlation IDs and other users' executions
+type stubExecutionCanceller struct {
+	pending   map[string]bool
+	owners    map[string]string
+	cancelled []string
+}
+
+func (s *stubExecutionCanceller) CancelExecution(ctx context.Context, correlationID, requesterID string) error {
+	if !s.pending[correlationID] {
+		return fmt.Errorf("%w: %s", executionApp.ErrExecutionNotFound, correlationID)
+	}
+	if requesterID != "" && s.owners[correlationID] != requesterID {
+		return fmt.Errorf("%w: %s", executionApp.ErrExecutionAccessDenied, correlationID)
+	}
+	s.cancelled = append(s.cancelled, correlationID)
+	return nil
+}
+
+func TestWebBFF_CancelExecutionEndpoint(t *testing.T) {
+	newServer := func(canceller ExecutionCanceller) http.Handler {
+		bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+		if canceller != nil {
+			bff.SetExecutionCanceller(canceller)
+		}
+		return bff.CreateWebServer(":0").Handler
+	}
+
+	t.Run("cancels a pending execution", func(t *testing.T) {
+		canceller := &stubExecutionCanceller{pending: map[string]bool{"exec-123": true}}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/conversation/exec-123/cancel", nil)
+		rec := httptest.NewRecorder()
+		newServer(canceller).ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, []string{"exec-123"}, canceller.cancelled)
+
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "cancelled", body["status"])
+		assert.Equal(t, "exec-123", body["correlation_id"])
+	})
+
+	t.Run("returns 404 for an unknown execution", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/conversation/exec-unknown/cancel", nil)
+		rec := httptest.NewRecorder()
+		newServer(&stubExecutionCanceller{}).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("requires the execution's owner when authentication is enabled", func(t *testing.T) {
+		canceller := &stubExecutionCanceller{pending: map[string]bool{"exec-123": true}, owners: map[string]string{"exec-123": "alice"}}
+		bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+		bff.SetExecutionCanceller(canceller)
+		bff.SetAuthenticator(NewStaticTokenAuthenticator(map[string]string{"alice-token": "alice", "bob-token": "bob"}))
+		server := bff.CreateWebServer(":0").Handler
+
+		cancel := func(authorization string) int {
+			req := httptest.NewRequest(http.MethodPost, "/api/conversation/exec-123/cancel", nil)
+			if authorization != "" {
+				req.Header.Set("Authorization", authorization)
+			}
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+			return rec.Code
+		}
+
+		assert.Equal(t, http.StatusUnauthorized, cancel(""))
+		assert.Equal(t, http.StatusForbidden, cancel("Bearer bob-token"))
+		assert.Empty(t, canceller.cancelled)
+		assert.Equal(t, http.StatusOK, cancel("Bearer alice-token"))
+		assert.Equal(t, []string{"exec-123"}, canceller.cancelled)
+	})
+
+	t.Run("returns 503 without canceller", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/conversation/exec-123/cancel", nil)
+		rec := httptest.NewRecorder()
+		newServer(nil).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+
+	t.Run("rejects non-POST requests", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/conversation/exec-123/cancel", nil)
+		rec := httptest.NewRecorder()
+		newServer(&stubExecutionCanceller{}).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
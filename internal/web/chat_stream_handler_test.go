@@ -0,0 +1,121 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	executionDomain "neuromesh/internal/execution/domain"
+	"neuromesh/internal/logging"
+	"neuromesh/internal/orchestrator/application"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamingMockAIOrchestrator replays a fixed two-step plan through the progress reporter
+type streamingMockAIOrchestrator struct {
+	MockAIOrchestrator
+}
+
+func (m *streamingMockAIOrchestrator) ProcessRequestWithProgress(ctx context.Context, userInput, userID string, onProgress executionDomain.ProgressReporter) (*application.OrchestratorResult, error) {
+	onProgress(executionDomain.NewProgressEvent(executionDomain.ProgressEventDecisionMade, "", "EXECUTE: count then translate"))
+	onProgress(executionDomain.NewProgressEvent(executionDomain.ProgressEventAgentEventSent, "text-processor", "Count words"))
+	onProgress(executionDomain.NewProgressEvent(executionDomain.ProgressEventAgentResponded, "text-processor", "2 words"))
+	onProgress(executionDomain.NewProgressEvent(executionDomain.ProgressEventAgentEventSent, "translator", "Translate to Danish"))
+	onProgress(executionDomain.NewProgressEvent(executionDomain.ProgressEventAgentResponded, "translator", "Hej verden"))
+	onProgress(executionDomain.NewProgressEvent(executionDomain.ProgressEventFinalAnswer, "", "2 words, Danish: Hej verden"))
+
+	return &application.OrchestratorResult{
+		Message: "2 words, Danish: Hej verden",
+		Success: true,
+	}, nil
+}
+
+// parseSSEEvents returns the event names and data payloads of a Server-Sent Events body in order
+func parseSSEEvents(t *testing.T, body string) ([]string, []string) {
+	var names, data []string
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if name, ok := strings.CutPrefix(line, "event: "); ok {
+			names = append(names, name)
+		}
+		if payload, ok := strings.CutPrefix(line, "data: "); ok {
+			data = append(data, payload)
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return names, data
+}
+
+func TestWebBFF_ChatStreamHandler(t *testing.T) {
+	t.Run("streams progress events for a two-step plan", func(t *testing.T) {
+		bff := NewWebBFF(&streamingMockAIOrchestrator{}, logging.NewNoOpLogger())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/chat/stream", strings.NewReader(`{"session_id":"session-1","message":"Count and translate hello world"}`))
+		rec := httptest.NewRecorder()
+		bff.CreateWebServer(":0").Handler.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+
+		names, data := parseSSEEvents(t, rec.Body.String())
+		assert.Equal(t, []string{
+			"execution_started",
+			"decision_made",
+			"agent_event_sent",
+			"agent_responded",
+			"agent_event_sent",
+			"agent_responded",
+			"final_answer",
+			"response",
+		}, names)
+		require.Len(t, data, len(names))
+		assert.Contains(t, data[2], `"agent_id":"text-processor"`)
+		assert.Contains(t, data[4], `"agent_id":"translator"`)
+		assert.Contains(t, data[7], `"content":"2 words, Danish: Hej verden"`)
+
+		// The execution is announced first with the ID that cancels it, which the response repeats
+		var started executionDomain.ProgressEvent
+		require.NoError(t, json.Unmarshal([]byte(data[0]), &started))
+		assert.True(t, strings.HasPrefix(started.Message, "exec-"))
+		assert.Contains(t, data[7], `"correlation_id":"`+started.Message+`"`)
+	})
+
+	t.Run("falls back to a single response for non-streaming orchestrators", func(t *testing.T) {
+		bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/chat/stream", strings.NewReader(`{"session_id":"session-1","message":"hello"}`))
+		rec := httptest.NewRecorder()
+		bff.ChatStreamHandler().ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		names, _ := parseSSEEvents(t, rec.Body.String())
+		assert.Equal(t, []string{"response"}, names)
+	})
+
+	t.Run("validates required fields", func(t *testing.T) {
+		bff := NewWebBFF(&streamingMockAIOrchestrator{}, logging.NewNoOpLogger())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/chat/stream", strings.NewReader(`{"message":"hello"}`))
+		rec := httptest.NewRecorder()
+		bff.ChatStreamHandler().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("rejects non-POST requests", func(t *testing.T) {
+		bff := NewWebBFF(&streamingMockAIOrchestrator{}, logging.NewNoOpLogger())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/chat/stream", nil)
+		rec := httptest.NewRecorder()
+		bff.ChatStreamHandler().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
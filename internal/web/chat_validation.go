@@ -0,0 +1,71 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultMaxMessageLength is the largest chat message, in bytes, forwarded to the orchestrator
+const DefaultMaxMessageLength = 8 * 1024
+
+// sessionIDPattern restricts session IDs to short identifiers such as "web-user-1700000000"
+var sessionIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// errInvalidUTF8 is returned for chat requests that are not valid UTF-8
+var errInvalidUTF8 = errors.New("message must be valid UTF-8")
+
+// SetMaxMessageLength overrides the maximum accepted chat message length in bytes
+func (w *WebBFF) SetMaxMessageLength(maxLength int) {
+	w.maxMessageLength = maxLength
+}
+
+// decodeChatRequest decodes a chat request body, rejecting invalid UTF-8 that JSON decoding would silently replace
+func decodeChatRequest(body io.Reader, req *ChatRequest) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("failed to read chat request: %w", err)
+	}
+	if !utf8.Valid(data) {
+		return errInvalidUTF8
+	}
+	return json.Unmarshal(data, req)
+}
+
+// validateChatRequest checks the session ID and message of a chat request, stripping control characters from the message
+func (w *WebBFF) validateChatRequest(req *ChatRequest) error {
+	if req.SessionID == "" {
+		return fmt.Errorf("session_id is required")
+	}
+	if !sessionIDPattern.MatchString(req.SessionID) {
+		return fmt.Errorf("session_id must be 1-128 letters, digits, '.', '_', ':' or '-'")
+	}
+
+	if !utf8.ValidString(req.Message) {
+		return errInvalidUTF8
+	}
+	if len(req.Message) > w.maxMessageLength {
+		return fmt.Errorf("message exceeds maximum length of %d bytes", w.maxMessageLength)
+	}
+
+	req.Message = strings.TrimSpace(stripControlCharacters(req.Message))
+	if req.Message == "" {
+		return fmt.Errorf("message is required")
+	}
+	return nil
+}
+
+// stripControlCharacters removes control characters other than line breaks and tabs
+func stripControlCharacters(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+			return -1
+		}
+		return r
+	}, s)
+}
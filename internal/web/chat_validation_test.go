@@ -0,0 +1,70 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"neuromesh/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebBFF_ChatInputValidation(t *testing.T) {
+	bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+	bff.SetMaxMessageLength(64)
+	handler := bff.ChatHandler()
+
+	chat := func(sessionID, message string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(ChatRequest{SessionID: sessionID, Message: message})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/chat", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("passes a valid message through", func(t *testing.T) {
+		rec := chat("web-user-1700000000", "  Count words in\x00 hello\x1b world\n")
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var response WebResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "Mock AI response for: Count words in hello world", response.Content)
+	})
+
+	t.Run("rejects oversized messages", func(t *testing.T) {
+		rec := chat("web-user-1", strings.Repeat("a", 65))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "message exceeds maximum length of 64 bytes")
+	})
+
+	t.Run("rejects invalid UTF-8", func(t *testing.T) {
+		// json.Marshal would replace invalid bytes, so the body is written by hand
+		body := []byte("{\"session_id\":\"web-user-1\",\"message\":\"bad \xff\xfe bytes\"}")
+		req := httptest.NewRequest(http.MethodPost, "/api/chat", bytes.NewBuffer(body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "message must be valid UTF-8")
+	})
+
+	t.Run("rejects messages with only control characters", func(t *testing.T) {
+		rec := chat("web-user-1", "\x00\x07 ")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "message is required")
+	})
+
+	t.Run("rejects malformed session IDs", func(t *testing.T) {
+		for _, sessionID := range []string{"has spaces", "../etc/passwd", strings.Repeat("s", 129)} {
+			rec := chat(sessionID, "Hello")
+			assert.Equal(t, http.StatusBadRequest, rec.Code, sessionID)
+			assert.Contains(t, rec.Body.String(), "session_id must be")
+		}
+	})
+}
@@ -0,0 +1,213 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	executionDomain "neuromesh/internal/execution/domain"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// chatSocketPongWait is how long the chat socket waits for any client frame, including pongs, before giving up on the client
+	chatSocketPongWait = 60 * time.Second
+	// chatSocketPingPeriod is how often the chat socket pings the client; it must be shorter than chatSocketPongWait
+	chatSocketPingPeriod = chatSocketPongWait * 9 / 10
+	// chatSocketWriteWait bounds a single frame write to a slow client
+	chatSocketWriteWait = 10 * time.Second
+	// chatSocketMaxInFlight bounds the requests one connection may have processing at once
+	chatSocketMaxInFlight = 4
+)
+
+// Chat socket frame types sent to the client
+const (
+	ChatFrameProgress = "progress"
+	ChatFrameResponse = "response"
+	ChatFrameError    = "error"
+)
+
+// ChatFrame is a server-to-client frame on the chat WebSocket
+type ChatFrame struct {
+	Type      string                         `json:"type"`
+	SessionID string                         `json:"session_id"`
+	RequestID string                         `json:"request_id,omitempty"`
+	Progress  *executionDomain.ProgressEvent `json:"progress,omitempty"`
+	Response  *WebResponse                   `json:"response,omitempty"`
+	Error     string                         `json:"error,omitempty"`
+}
+
+// ChatWebSocketHandler returns a WebSocket handler for bidirectional chat on a single session
+// Clients send ChatRequest messages at any time; each is processed concurrently, up to chatSocketMaxInFlight per
+// connection, and answered with progress frames followed by a response or error frame carrying its request ID
+func (w *WebBFF) ChatWebSocketHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if !w.admitRequest(rw) {
+			return
+		}
+		r, authenticated := w.authenticate(rw, r)
+		if !authenticated {
+			return
+		}
+
+		sessionID := r.URL.Query().Get("session_id")
+		if !sessionIDPattern.MatchString(sessionID) {
+			http.Error(rw, "invalid session_id", http.StatusBadRequest)
+			return
+		}
+		if !w.authorizeSession(r.Context(), rw, sessionID) {
+			return
+		}
+
+		conn, err := upgrader.Upgrade(rw, r, nil)
+		if err != nil {
+			w.logger.Error("Failed to upgrade chat socket", err, "sessionID", sessionID)
+			return
+		}
+
+		w.logger.Info("Chat socket connected", "sessionID", sessionID)
+		socket := &chatSocket{bff: w, conn: conn, sessionID: sessionID, rateLimitKey: rateLimitKey(r), slots: make(chan struct{}, chatSocketMaxInFlight)}
+		socket.serve(r.Context())
+		w.logger.Info("Chat socket disconnected", "sessionID", sessionID)
+	})
+}
+
+// chatSocket multiplexes the chat requests of one WebSocket connection over the orchestrator
+type chatSocket struct {
+	bff          *WebBFF
+	conn         *websocket.Conn
+	sessionID    string
+	rateLimitKey string // Client the connection's requests are throttled as
+
+	writeMutex sync.Mutex     // gorilla/websocket allows one concurrent writer
+	inFlight   sync.WaitGroup // Requests still being processed
+	slots      chan struct{}  // Semaphore bounding the requests in flight
+}
+
+// serve reads client messages until the client disconnects, then cancels and awaits the requests still in flight
+func (s *chatSocket) serve(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		s.inFlight.Wait()
+		s.conn.Close()
+	}()
+
+	s.conn.SetReadDeadline(time.Now().Add(chatSocketPongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(chatSocketPongWait))
+	})
+	go s.keepAlive(ctx)
+
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				s.bff.logger.Warn("Chat socket closed unexpectedly", "sessionID", s.sessionID, "error", err)
+			}
+			return
+		}
+		s.conn.SetReadDeadline(time.Now().Add(chatSocketPongWait))
+
+		if s.bff.draining.Load() {
+			s.writeFrame(ChatFrame{Type: ChatFrameError, Error: "Server is shutting down"})
+			s.close(websocket.CloseGoingAway, "server is shutting down")
+			return
+		}
+
+		var request ChatRequest
+		if err := json.Unmarshal(data, &request); err != nil {
+			s.writeFrame(ChatFrame{Type: ChatFrameError, Error: "Invalid JSON"})
+			continue
+		}
+		if request.RequestID == "" {
+			request.RequestID = uuid.New().String()
+		}
+		if request.SessionID == "" {
+			request.SessionID = s.sessionID
+		}
+		if request.SessionID != s.sessionID {
+			s.writeFrame(ChatFrame{Type: ChatFrameError, RequestID: request.RequestID, Error: "session_id does not match the connection"})
+			continue
+		}
+		if err := s.bff.validateChatRequest(&request); err != nil {
+			s.writeFrame(ChatFrame{Type: ChatFrameError, RequestID: request.RequestID, Error: err.Error()})
+			continue
+		}
+		if s.bff.rateLimiter != nil {
+			if allowed, _ := s.bff.rateLimiter.Allow(s.rateLimitKey); !allowed {
+				s.writeFrame(ChatFrame{Type: ChatFrameError, RequestID: request.RequestID, Error: "Too many requests"})
+				continue
+			}
+		}
+
+		// Reject rather than wait for a slot so the read loop keeps serving pongs and the close handshake
+		select {
+		case s.slots <- struct{}{}:
+		default:
+			s.writeFrame(ChatFrame{Type: ChatFrameError, RequestID: request.RequestID, Error: "Too many requests in flight"})
+			continue
+		}
+		s.inFlight.Add(1)
+		go s.handleMessage(ctx, request)
+	}
+}
+
+// handleMessage processes one chat request, streaming its progress to the client
+func (s *chatSocket) handleMessage(ctx context.Context, request ChatRequest) {
+	defer func() {
+		<-s.slots
+		s.inFlight.Done()
+	}()
+
+	response, err := s.bff.ProcessWebMessageWithProgress(ctx, s.sessionID, request.Message, func(progress executionDomain.ProgressEvent) {
+		s.writeFrame(ChatFrame{Type: ChatFrameProgress, RequestID: request.RequestID, Progress: &progress})
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			s.bff.logger.Error("Failed to process chat socket message", err, "sessionID", s.sessionID, "requestID", request.RequestID)
+		}
+		s.writeFrame(ChatFrame{Type: ChatFrameError, RequestID: request.RequestID, Error: "Failed to process message"})
+		return
+	}
+	s.writeFrame(ChatFrame{Type: ChatFrameResponse, RequestID: request.RequestID, Response: response})
+}
+
+// keepAlive pings the client until the connection is done; a client that stops answering hits the read deadline
+func (s *chatSocket) keepAlive(ctx context.Context) {
+	ticker := time.NewTicker(chatSocketPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(chatSocketWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeFrame sends a frame to the client, serializing concurrent writers
+func (s *chatSocket) writeFrame(frame ChatFrame) {
+	frame.SessionID = s.sessionID
+
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+	s.conn.SetWriteDeadline(time.Now().Add(chatSocketWriteWait))
+	if err := s.conn.WriteJSON(frame); err != nil {
+		s.bff.logger.Debug("Failed to write chat socket frame", "sessionID", s.sessionID, "type", frame.Type, "error", err)
+	}
+}
+
+// close tells the client why the server is ending the connection
+func (s *chatSocket) close(code int, reason string) {
+	message := websocket.FormatCloseMessage(code, reason)
+	s.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(chatSocketWriteWait))
+}
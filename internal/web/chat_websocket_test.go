@@ -0,0 +1,163 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	executionDomain "neuromesh/internal/execution/domain"
+	"neuromesh/internal/logging"
+	"neuromesh/internal/orchestrator/application"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dialChatSocket connects a WebSocket test client to the chat socket of a test server
+func dialChatSocket(t *testing.T, server *httptest.Server, sessionID string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/chat?session_id=" + sessionID
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// readChatFrame reads the next frame from the chat socket
+func readChatFrame(t *testing.T, conn *websocket.Conn) ChatFrame {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	var frame ChatFrame
+	require.NoError(t, conn.ReadJSON(&frame))
+	return frame
+}
+
+// blockingAIOrchestrator holds every request until released or cancelled
+type blockingAIOrchestrator struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (o *blockingAIOrchestrator) ProcessRequest(ctx context.Context, userInput, userID string) (*application.OrchestratorResult, error) {
+	o.started <- struct{}{}
+	select {
+	case <-o.release:
+		return &application.OrchestratorResult{Message: "done: " + userInput, Success: true}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestWebBFF_ChatWebSocketHandler(t *testing.T) {
+	bff := NewWebBFF(&streamingMockAIOrchestrator{}, logging.NewNoOpLogger())
+	server := httptest.NewServer(bff.CreateWebServer(":0").Handler)
+	defer server.Close()
+
+	t.Run("streams progress frames and the response for a message", func(t *testing.T) {
+		conn := dialChatSocket(t, server, "ws-session")
+		require.NoError(t, conn.WriteJSON(ChatRequest{Message: "Count and translate hello world"}))
+
+		var progressTypes []executionDomain.ProgressEventType
+		frame := readChatFrame(t, conn)
+		for frame.Type == ChatFrameProgress {
+			require.NotNil(t, frame.Progress)
+			progressTypes = append(progressTypes, frame.Progress.Type)
+			frame = readChatFrame(t, conn)
+		}
+
+		assert.Equal(t, []executionDomain.ProgressEventType{
+			executionDomain.ProgressEventExecutionStarted,
+			executionDomain.ProgressEventDecisionMade,
+			executionDomain.ProgressEventAgentEventSent,
+			executionDomain.ProgressEventAgentResponded,
+			executionDomain.ProgressEventAgentEventSent,
+			executionDomain.ProgressEventAgentResponded,
+			executionDomain.ProgressEventFinalAnswer,
+		}, progressTypes)
+		require.Equal(t, ChatFrameResponse, frame.Type)
+		assert.Equal(t, "ws-session", frame.SessionID)
+		assert.NotEmpty(t, frame.RequestID)
+		require.NotNil(t, frame.Response)
+		assert.Equal(t, "2 words, Danish: Hej verden", frame.Response.Content)
+	})
+
+	t.Run("keeps the connection open for follow-up messages", func(t *testing.T) {
+		conn := dialChatSocket(t, server, "ws-follow-up")
+
+		require.NoError(t, conn.WriteJSON(ChatRequest{SessionID: "other-session", Message: "Hello"}))
+		frame := readChatFrame(t, conn)
+		assert.Equal(t, ChatFrameError, frame.Type)
+		assert.Equal(t, "session_id does not match the connection", frame.Error)
+
+		require.NoError(t, conn.WriteJSON(ChatRequest{Message: "Follow up", RequestID: "req-2"}))
+		frame = readChatFrame(t, conn)
+		for frame.Type == ChatFrameProgress {
+			assert.Equal(t, "req-2", frame.RequestID)
+			frame = readChatFrame(t, conn)
+		}
+		assert.Equal(t, ChatFrameResponse, frame.Type)
+		assert.Equal(t, "req-2", frame.RequestID)
+	})
+
+	t.Run("answers pings and closes cleanly", func(t *testing.T) {
+		conn := dialChatSocket(t, server, "ws-close")
+
+		pong := make(chan string, 1)
+		conn.SetPongHandler(func(data string) error {
+			pong <- data
+			return nil
+		})
+		require.NoError(t, conn.WriteControl(websocket.PingMessage, []byte("ping"), time.Now().Add(time.Second)))
+
+		require.NoError(t, conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")))
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		_, _, err := conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "expected a normal close, got %v", err)
+		assert.Equal(t, "ping", <-pong)
+	})
+
+	t.Run("rejects connections without a valid session id", func(t *testing.T) {
+		url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/chat"
+		_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+		require.Error(t, err)
+		require.NotNil(t, resp)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestWebBFF_ChatWebSocketHandler_BoundsRequestsInFlight(t *testing.T) {
+	orchestrator := &blockingAIOrchestrator{started: make(chan struct{}, chatSocketMaxInFlight), release: make(chan struct{})}
+	bff := NewWebBFF(orchestrator, logging.NewNoOpLogger())
+	server := httptest.NewServer(bff.CreateWebServer(":0").Handler)
+	defer server.Close()
+
+	conn := dialChatSocket(t, server, "ws-bounded")
+	for i := 0; i < chatSocketMaxInFlight; i++ {
+		require.NoError(t, conn.WriteJSON(ChatRequest{Message: "Hold", RequestID: fmt.Sprintf("held-%d", i)}))
+		<-orchestrator.started
+	}
+
+	require.NoError(t, conn.WriteJSON(ChatRequest{Message: "One too many", RequestID: "overflow"}))
+	frame := readChatFrame(t, conn)
+	assert.Equal(t, ChatFrameError, frame.Type)
+	assert.Equal(t, "overflow", frame.RequestID)
+	assert.Equal(t, "Too many requests in flight", frame.Error)
+
+	close(orchestrator.release)
+	responded := make(map[string]bool)
+	for len(responded) < chatSocketMaxInFlight {
+		frame := readChatFrame(t, conn)
+		require.Equal(t, ChatFrameResponse, frame.Type)
+		responded[frame.RequestID] = true
+	}
+
+	require.NoError(t, conn.WriteJSON(ChatRequest{Message: "Room again", RequestID: "after"}))
+	frame = readChatFrame(t, conn)
+	assert.Equal(t, ChatFrameResponse, frame.Type)
+	assert.Equal(t, "after", frame.RequestID)
+}
@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"time"
 
+	aiDomain "neuromesh/internal/ai/domain"
 	conversationApp "neuromesh/internal/conversation/application"
 	conversationDomain "neuromesh/internal/conversation/domain"
+	learningDomain "neuromesh/internal/learning/domain"
 	"neuromesh/internal/logging"
 	orchestratorApp "neuromesh/internal/orchestrator/application"
+	orchestratorDomain "neuromesh/internal/orchestrator/domain"
+	planningDomain "neuromesh/internal/planning/domain"
 	userApp "neuromesh/internal/user/application"
 	userDomain "neuromesh/internal/user/domain"
 
@@ -20,9 +24,15 @@ type ConversationAwareWebBFF struct {
 	*WebBFF             // Embed existing WebBFF
 	conversationService conversationApp.ConversationService
 	userService         userApp.UserService
+	outcomes            OutcomeRecorder
 	logger              logging.Logger
 }
 
+// OutcomeRecorder defines the interface for recording how AI decisions turned out
+type OutcomeRecorder interface {
+	RecordOutcome(ctx context.Context, decisionID string, outcome learningDomain.Outcome) error
+}
+
 // NewConversationAwareWebBFF creates a new conversation-aware WebBFF
 func NewConversationAwareWebBFF(
 	orchestrator AIOrchestrator,
@@ -32,12 +42,22 @@ func NewConversationAwareWebBFF(
 ) *ConversationAwareWebBFF {
 	webBFF := NewWebBFF(orchestrator, logger)
 
-	return &ConversationAwareWebBFF{
+	conversationBFF := &ConversationAwareWebBFF{
 		WebBFF:              webBFF,
 		conversationService: conversationService,
 		userService:         userService,
 		logger:              logger,
 	}
+
+	// Chat endpoints served by the embedded WebBFF persist exchanges through this BFF
+	webBFF.SetConversationRecorder(conversationBFF)
+
+	return conversationBFF
+}
+
+// SetOutcomeRecorder records the outcome of every persisted decision so later requests can learn from it
+func (w *ConversationAwareWebBFF) SetOutcomeRecorder(recorder OutcomeRecorder) {
+	w.outcomes = recorder
 }
 
 // ProcessWebMessageWithConversation processes a web message with full conversation persistence
@@ -53,84 +73,229 @@ func (w *ConversationAwareWebBFF) ProcessWebMessageWithConversation(ctx context.
 	w.logger.Debug("Processing web message with conversation persistence",
 		"sessionID", sessionID, "message", message)
 
-	// 1. Ensure user and session exist
+	// 1. Resolve the session's conversation and record the user message
+	exchange, err := w.StartExchange(ctx, sessionID, message)
+	conversationID := exchange.ConversationID
+	if conversationID == "" {
+		w.logger.Error("Failed to initialize conversation", err, "sessionID", sessionID)
+		return w.handleError("Failed to initialize conversation", sessionID), nil
+	}
+	if err != nil {
+		// Continue processing even if message storage fails
+		w.logger.Error("Failed to record user message", err, "conversationID", conversationID)
+	}
+
+	// 2. Process through orchestrator, building on the conversation's earlier decisions
+	ctx = exchange.decisionContext(ctx)
+	orchestratorRequest := &orchestratorApp.OrchestratorRequest{
+		UserInput: message,
+		UserID:    requestUserID(ctx, sessionID),
+		SessionID: sessionID,
+	}
+
+	aiResponse, err := w.processOrchestratorRequest(ctx, orchestratorRequest)
+	if err != nil {
+		w.logger.Error("Failed to process orchestrator request", err, "sessionID", sessionID)
+		return w.handleError("Failed to process request", sessionID), nil
+	}
+
+	// 3. Record the AI response and link any execution plan
+	if err := w.FinishExchange(ctx, exchange, aiResponse); err != nil {
+		// Continue processing even if message storage fails
+		w.logger.Error("Failed to record assistant response", err, "conversationID", conversationID)
+	}
+
+	// 4. Build web response
+	webResponse := w.buildWebResponse(aiResponse, sessionID)
+	webResponse.ConversationID = conversationID
+
+	w.logger.Info("Web message processed with conversation persistence",
+		"sessionID", sessionID, "conversationID", conversationID)
+
+	return webResponse, nil
+}
+
+// StartExchange resolves the session's durable conversation, creating it on first use, and records the user message
+// The conversation ID is returned even when only recording the message failed
+func (w *ConversationAwareWebBFF) StartExchange(ctx context.Context, sessionID, message string) (Exchange, error) {
 	user, _, err := w.ensureUserAndSession(ctx, sessionID)
 	if err != nil {
-		w.logger.Error("Failed to ensure user and session", err, "sessionID", sessionID)
-		return w.handleError("Failed to initialize session", sessionID), nil
+		return Exchange{}, fmt.Errorf("failed to ensure user and session: %w", err)
 	}
 
-	// 2. Get or create conversation for this session
 	conversation, err := w.getOrCreateConversation(ctx, sessionID, user.ID)
 	if err != nil {
-		w.logger.Error("Failed to get or create conversation", err, "sessionID", sessionID)
-		return w.handleError("Failed to initialize conversation", sessionID), nil
+		return Exchange{}, fmt.Errorf("failed to get or create conversation: %w", err)
+	}
+
+	// History is read before the request is recorded so the request is not repeated in it
+	history, err := w.conversationService.GetPromptHistory(ctx, conversation.ID)
+	if err != nil {
+		w.logger.Warn("Failed to load conversation history", "conversationID", conversation.ID, "error", err)
 	}
 
-	// 3. Add user message to conversation
 	userMessageID := generateMessageID()
 	err = w.conversationService.AddMessage(ctx, conversation.ID, userMessageID,
 		conversationDomain.MessageRoleUser, message, nil)
 	if err != nil {
-		w.logger.Error("Failed to add user message to conversation", err,
-			"conversationID", conversation.ID, "messageID", userMessageID)
-		// Continue processing even if message storage fails
+		return Exchange{ConversationID: conversation.ID}, fmt.Errorf("failed to add user message %s: %w", userMessageID, err)
 	}
 
-	// 4. Process through orchestrator
-	orchestratorRequest := &orchestratorApp.OrchestratorRequest{
-		UserInput: message,
-		UserID:    user.ID,
-		SessionID: sessionID,
-		MessageID: userMessageID, // Link orchestrator processing to the user message
+	exchange := Exchange{ConversationID: conversation.ID, RequestMessageID: userMessageID, History: history}
+	if err := w.loadPriorDecisions(ctx, &exchange); err != nil {
+		// Decisions are still made without history
+		w.logger.Warn("Failed to load previous decisions", "conversationID", conversation.ID, "error", err)
 	}
+	return exchange, nil
+}
 
-	aiResponse, err := w.processOrchestratorRequest(ctx, orchestratorRequest)
+// loadPriorDecisions fills in the decision chain the exchange's new decision will follow
+func (w *ConversationAwareWebBFF) loadPriorDecisions(ctx context.Context, exchange *Exchange) error {
+	decisions, err := w.conversationService.GetDecisionsByConversation(ctx, exchange.ConversationID)
 	if err != nil {
-		w.logger.Error("Failed to process orchestrator request", err, "sessionID", sessionID)
-		return w.handleError("Failed to process request", sessionID), nil
+		return fmt.Errorf("failed to get conversation decisions: %w", err)
+	}
+	if len(decisions) == 0 {
+		return nil
 	}
 
-	// 5. Add AI response to conversation
-	assistantMessageID := generateMessageID()
-	assistantMetadata := w.buildAssistantMetadata(aiResponse)
+	exchange.LastDecisionID = decisions[len(decisions)-1].ID
+	chain, err := w.conversationService.GetDecisionChain(ctx, exchange.LastDecisionID)
+	if err != nil {
+		return fmt.Errorf("failed to get decision chain for %s: %w", exchange.LastDecisionID, err)
+	}
+	if len(chain) > planningDomain.MaxPriorDecisions {
+		chain = chain[len(chain)-planningDomain.MaxPriorDecisions:]
+	}
+
+	for _, decision := range chain {
+		summary := decision.Reasoning
+		switch {
+		case decision.ClarificationQuestion != "":
+			summary = decision.ClarificationQuestion
+		case decision.RejectionReason != "":
+			summary = decision.RejectionReason
+		}
+		exchange.PriorDecisions = append(exchange.PriorDecisions, planningDomain.PriorDecision{
+			Type:       decision.Type,
+			Confidence: decision.Confidence,
+			Summary:    summary,
+		})
+	}
+	return nil
+}
 
-	err = w.conversationService.AddMessage(ctx, conversation.ID, assistantMessageID,
-		conversationDomain.MessageRoleAssistant, aiResponse.Message, assistantMetadata)
+// FinishExchange records the orchestrator's reply and decision in the conversation and links any execution plan it created
+func (w *ConversationAwareWebBFF) FinishExchange(ctx context.Context, exchange Exchange, aiResponse *orchestratorApp.OrchestratorResult) error {
+	conversationID := exchange.ConversationID
+	assistantMessageID := generateMessageID()
+	err := w.conversationService.AddMessage(ctx, conversationID, assistantMessageID,
+		conversationDomain.MessageRoleAssistant, aiResponse.Message, w.buildAssistantMetadata(aiResponse))
 	if err != nil {
-		w.logger.Error("Failed to add assistant message to conversation", err,
-			"conversationID", conversation.ID, "messageID", assistantMessageID)
-		// Continue processing even if message storage fails
+		return fmt.Errorf("failed to add assistant message %s: %w", assistantMessageID, err)
 	}
 
-	// 6. Link execution plan if created
 	if aiResponse.ExecutionPlanID != "" {
-		err = w.conversationService.LinkExecutionPlan(ctx, conversation.ID, aiResponse.ExecutionPlanID)
-		if err != nil {
-			w.logger.Error("Failed to link execution plan to conversation", err,
-				"conversationID", conversation.ID, "executionPlanID", aiResponse.ExecutionPlanID)
-			// Continue processing even if linking fails
+		if err := w.conversationService.LinkExecutionPlan(ctx, conversationID, aiResponse.ExecutionPlanID); err != nil {
+			return fmt.Errorf("failed to link execution plan %s: %w", aiResponse.ExecutionPlanID, err)
 		}
 	}
 
-	// 7. Build web response
-	webResponse := w.buildWebResponse(aiResponse, sessionID)
+	if aiResponse.Decision != nil {
+		decision := newAIDecisionRecord(exchange, aiResponse.Decision)
+		if err := w.conversationService.RecordDecision(ctx, decision); err != nil {
+			return fmt.Errorf("failed to record decision %s: %w", decision.ID, err)
+		}
 
-	w.logger.Info("Web message processed with conversation persistence",
-		"sessionID", sessionID, "conversationID", conversation.ID)
+		// The outcome links to the decision node, so it can only be recorded once the decision exists
+		// Learning is best effort and never fails the exchange
+		if w.outcomes != nil && aiResponse.Outcome != nil {
+			if err := w.outcomes.RecordOutcome(ctx, decision.ID, *aiResponse.Outcome); err != nil {
+				w.logger.Warn("Failed to record decision outcome", "decision_id", decision.ID, "error", err)
+			}
+		}
+	}
 
-	return webResponse, nil
+	// Long conversations are condensed once they pass the summarization threshold; a failed attempt is retried on the next exchange
+	if _, err := w.conversationService.SummarizeConversation(ctx, conversationID); err != nil {
+		w.logger.Warn("Failed to summarize conversation", "conversationID", conversationID, "error", err)
+	}
+
+	return nil
+}
+
+// newAIDecisionRecord converts an orchestrator decision into its conversation record
+func newAIDecisionRecord(exchange Exchange, decision *orchestratorDomain.Decision) *conversationDomain.AIDecision {
+	id := decision.ID
+	if id == "" {
+		id = uuid.New().String()
+	}
+	var previousDecisions []string
+	if exchange.LastDecisionID != "" {
+		previousDecisions = []string{exchange.LastDecisionID}
+	}
+	createdAt := decision.Timestamp
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	usage, _ := decision.Parameters[orchestratorDomain.ParameterTokenUsage].(aiDomain.Usage)
+	return &conversationDomain.AIDecision{
+		ID:                    id,
+		ConversationID:        exchange.ConversationID,
+		RequestMessageID:      exchange.RequestMessageID,
+		Type:                  string(decision.Type),
+		Confidence:            decision.Confidence,
+		Reasoning:             decision.Reasoning,
+		ClarificationQuestion: decision.ClarificationQuestion,
+		RejectionReason:       decision.RejectionReason,
+		ExecutionPlanID:       decision.ExecutionPlanID,
+		PreviousDecisions:     previousDecisions,
+		PromptTokens:          usage.PromptTokens,
+		CompletionTokens:      usage.CompletionTokens,
+		TotalTokens:           usage.TotalTokens,
+		CreatedAt:             createdAt.UTC(),
+	}
+}
+
+// GetSessionConversation returns the session's active conversation with its messages, or nil when it has none
+func (w *ConversationAwareWebBFF) GetSessionConversation(ctx context.Context, sessionID string) (*conversationDomain.Conversation, error) {
+	conversations, err := w.conversationService.FindConversationsBySession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find conversations for session: %w", err)
+	}
+
+	for _, conv := range conversations {
+		if conv.Status == conversationDomain.ConversationStatusActive {
+			return w.conversationService.GetConversationWithMessages(ctx, conv.ID)
+		}
+	}
+
+	return nil, nil
+}
+
+// GetConversation returns a conversation with its messages by ID
+func (w *ConversationAwareWebBFF) GetConversation(ctx context.Context, conversationID string) (*conversationDomain.Conversation, error) {
+	return w.conversationService.GetConversationWithMessages(ctx, conversationID)
+}
+
+// GetConversationDecisions returns the AI decisions made in a conversation, oldest first
+func (w *ConversationAwareWebBFF) GetConversationDecisions(ctx context.Context, conversationID string) ([]*conversationDomain.AIDecision, error) {
+	return w.conversationService.GetDecisionsByConversation(ctx, conversationID)
 }
 
 // ensureUserAndSession ensures that the user and session exist in the graph
 func (w *ConversationAwareWebBFF) ensureUserAndSession(ctx context.Context, sessionID string) (*userDomain.User, *userDomain.Session, error) {
-	// Check if user exists for this session
-	userID := sessionID // Use sessionID as userID for web sessions
+	// Authenticated requests belong to the resolved user; anonymous web sessions use the session ID as user ID
+	userID := requestUserID(ctx, sessionID)
+	userType := userDomain.UserTypeWebSession
+	if authenticated, ok := AuthenticatedUser(ctx); ok {
+		userType = authenticated.UserType
+	}
 
 	user, err := w.userService.GetUser(ctx, userID)
 	if err != nil {
 		// User doesn't exist, create new user
-		user, err = w.userService.CreateUser(ctx, userID, sessionID, userDomain.UserTypeWebSession)
+		user, err = w.userService.CreateUser(ctx, userID, sessionID, userType)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create user: %w", err)
 		}
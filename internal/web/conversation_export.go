@@ -0,0 +1,194 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	conversationDomain "neuromesh/internal/conversation/domain"
+	executionDomain "neuromesh/internal/execution/domain"
+	"neuromesh/internal/graph"
+)
+
+// Formats accepted by the conversation export endpoint
+const (
+	ExportFormatJSON     = "json"
+	ExportFormatMarkdown = "markdown"
+)
+
+// ConversationExport is a conversation transcript together with its linked execution plans
+type ConversationExport struct {
+	ConversationID string                                   `json:"conversation_id"`
+	SessionID      string                                   `json:"session_id"`
+	UserID         string                                   `json:"user_id"`
+	Status         conversationDomain.ConversationStatus    `json:"status"`
+	Summary        string                                   `json:"summary,omitempty"`
+	CreatedAt      time.Time                                `json:"created_at"`
+	ExportedAt     time.Time                                `json:"exported_at"`
+	Messages       []conversationDomain.ConversationMessage `json:"messages"`
+	Plans          []ExportedPlan                           `json:"plans"`
+}
+
+// ExportedPlan is an execution plan linked to an exported conversation with the results its agents produced
+type ExportedPlan struct {
+	PlanID  string                         `json:"plan_id"`
+	Results []*executionDomain.AgentResult `json:"results"`
+}
+
+// SetAgentResultProvider includes the agent results of linked execution plans in conversation exports
+func (w *WebBFF) SetAgentResultProvider(provider AgentResultProvider) {
+	w.agentResults = provider
+}
+
+// ConversationExportHandler returns an HTTP handler downloading a conversation as JSON or a Markdown transcript
+func (w *WebBFF) ConversationExportHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if w.recorder == nil {
+			http.Error(rw, "Conversation persistence not available", http.StatusServiceUnavailable)
+			return
+		}
+		r, authenticated := w.authenticate(rw, r)
+		if !authenticated {
+			return
+		}
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = ExportFormatJSON
+		}
+		if format != ExportFormatJSON && format != ExportFormatMarkdown {
+			http.Error(rw, "format must be json or markdown", http.StatusBadRequest)
+			return
+		}
+
+		conversation, ok := w.loadRequestConversation(rw, r)
+		if !ok {
+			return
+		}
+
+		export, err := w.buildConversationExport(r.Context(), conversation)
+		if err != nil {
+			w.logger.Error("Failed to load agent results for export", err, "conversationID", conversation.ID)
+			http.Error(rw, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		if format == ExportFormatMarkdown {
+			rw.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+			rw.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.md"`, conversation.ID))
+			fmt.Fprint(rw, export.Markdown())
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.json"`, conversation.ID))
+		if err := json.NewEncoder(rw).Encode(export); err != nil {
+			w.logger.Error("Failed to encode conversation export", err)
+		}
+	})
+}
+
+// loadRequestConversation loads the conversation named by the request path, writing the error response
+// when it does not exist or belongs to another user than the authenticated one
+func (w *WebBFF) loadRequestConversation(rw http.ResponseWriter, r *http.Request) (*conversationDomain.Conversation, bool) {
+	conversationID := r.PathValue("id")
+	conversation, err := w.recorder.GetConversation(r.Context(), conversationID)
+	if errors.Is(err, conversationDomain.ErrConversationAccessDenied) {
+		http.Error(rw, "Forbidden", http.StatusForbidden)
+		return nil, false
+	}
+	if errors.Is(err, graph.ErrNodeNotFound) || (err == nil && conversation == nil) {
+		http.Error(rw, "Conversation not found", http.StatusNotFound)
+		return nil, false
+	}
+	if err != nil {
+		w.logger.Error("Failed to load conversation", err, "conversationID", conversationID)
+		http.Error(rw, "Internal server error", http.StatusInternalServerError)
+		return nil, false
+	}
+	if user, ok := AuthenticatedUser(r.Context()); ok && conversation.UserID != user.ID {
+		http.Error(rw, "Forbidden", http.StatusForbidden)
+		return nil, false
+	}
+	return conversation, true
+}
+
+// buildConversationExport collects a conversation's messages in order and, when available, its plans' agent results
+func (w *WebBFF) buildConversationExport(ctx context.Context, conversation *conversationDomain.Conversation) (*ConversationExport, error) {
+	export := &ConversationExport{
+		ConversationID: conversation.ID,
+		SessionID:      conversation.SessionID,
+		UserID:         conversation.UserID,
+		Status:         conversation.Status,
+		Summary:        conversation.Summary,
+		CreatedAt:      conversation.CreatedAt,
+		ExportedAt:     time.Now().UTC(),
+		Messages:       conversation.SortedMessages(),
+		Plans:          []ExportedPlan{},
+	}
+
+	for _, planID := range conversation.ExecutionPlanIDs {
+		plan := ExportedPlan{PlanID: planID, Results: []*executionDomain.AgentResult{}}
+		if w.agentResults != nil {
+			results, err := w.agentResults.GetAgentResultsByExecutionPlan(ctx, planID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get agent results for plan %s: %w", planID, err)
+			}
+			if results != nil {
+				plan.Results = results
+			}
+		}
+		export.Plans = append(export.Plans, plan)
+	}
+
+	return export, nil
+}
+
+// Markdown renders the export as a readable transcript with a header per message and per agent result
+func (e *ConversationExport) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Conversation %s\n\n", e.ConversationID)
+	fmt.Fprintf(&b, "- Session: %s\n", e.SessionID)
+	fmt.Fprintf(&b, "- Started: %s\n", e.CreatedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "- Exported: %s\n", e.ExportedAt.UTC().Format(time.RFC3339))
+	if e.Summary != "" {
+		fmt.Fprintf(&b, "\n> %s\n", strings.ReplaceAll(e.Summary, "\n", "\n> "))
+	}
+
+	for _, message := range e.Messages {
+		fmt.Fprintf(&b, "\n## %s · %s\n\n%s\n", roleTitle(string(message.Role)), message.Timestamp.UTC().Format(time.RFC3339), message.Content)
+	}
+
+	if len(e.Plans) == 0 {
+		return b.String()
+	}
+
+	b.WriteString("\n## Execution plans\n")
+	for _, plan := range e.Plans {
+		fmt.Fprintf(&b, "\n### Plan %s\n", plan.PlanID)
+		if len(plan.Results) == 0 {
+			b.WriteString("\nNo agent results recorded.\n")
+		}
+		for _, result := range plan.Results {
+			fmt.Fprintf(&b, "\n#### Agent %s (%s) · %s\n\n%s\n", result.AgentID, result.Status, result.Timestamp.UTC().Format(time.RFC3339), result.Content)
+			if result.ErrorMessage != "" {
+				fmt.Fprintf(&b, "\nError: %s\n", result.ErrorMessage)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// roleTitle capitalizes a message role for use as a transcript header
+func roleTitle(role string) string {
+	if role == "" {
+		return "Unknown"
+	}
+	return strings.ToUpper(role[:1]) + role[1:]
+}
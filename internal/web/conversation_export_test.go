@@ -0,0 +1,122 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conversationApp "neuromesh/internal/conversation/application"
+	conversationDomain "neuromesh/internal/conversation/domain"
+	conversationInfra "neuromesh/internal/conversation/infrastructure"
+	executionDomain "neuromesh/internal/execution/domain"
+	"neuromesh/internal/logging"
+	userApp "neuromesh/internal/user/application"
+	userInfra "neuromesh/internal/user/infrastructure"
+	"neuromesh/testHelpers"
+)
+
+// stubAgentResults returns canned agent results per plan
+type stubAgentResults map[string][]*executionDomain.AgentResult
+
+func (s stubAgentResults) GetAgentResultsByExecutionPlan(ctx context.Context, planID string) ([]*executionDomain.AgentResult, error) {
+	return s[planID], nil
+}
+
+// newExportTestHandler serves a conversation whose request was carried out by an agent
+func newExportTestHandler(t *testing.T) http.Handler {
+	ctx := context.Background()
+	testGraph := testHelpers.NewCleanMockGraph()
+	conversationRepo := conversationInfra.NewGraphConversationRepository(testGraph)
+	conversationService := conversationApp.NewConversationService(conversationRepo)
+	userService := userApp.NewUserService(userInfra.NewGraphUserRepository(testGraph))
+
+	_, err := conversationService.CreateConversation(ctx, "conv-1", "web-user-1", "user-1")
+	require.NoError(t, err)
+	asked := time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC)
+	// The reply is stored first so the export has to order messages by timestamp
+	require.NoError(t, conversationRepo.AddMessage(ctx, "conv-1", &conversationDomain.ConversationMessage{
+		ID: "msg-2", Role: conversationDomain.MessageRoleAssistant, Content: "The text has 2 words.", Timestamp: asked.Add(6 * time.Second),
+	}))
+	require.NoError(t, conversationRepo.AddMessage(ctx, "conv-1", &conversationDomain.ConversationMessage{
+		ID: "msg-1", Role: conversationDomain.MessageRoleUser, Content: "Count words in hello world", Timestamp: asked,
+	}))
+	require.NoError(t, conversationService.LinkExecutionPlan(ctx, "conv-1", "plan-1"))
+
+	bff := NewConversationAwareWebBFF(&MockAIOrchestrator{}, conversationService, userService, logging.NewNoOpLogger())
+	bff.SetAgentResultProvider(stubAgentResults{
+		"plan-1": {{
+			ID:        "result-1",
+			PlanID:    "plan-1",
+			AgentID:   "text-processor",
+			Content:   "word_count: 2",
+			Status:    executionDomain.AgentResultStatusSuccess,
+			Timestamp: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		}},
+	})
+	return bff.CreateWebServer(":0").Handler
+}
+
+func TestConversationExportHandler_JSON(t *testing.T) {
+	handler := newExportTestHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/conversations/conv-1/export?format=json", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), `filename="conv-1.json"`)
+
+	var export ConversationExport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &export))
+	assert.Equal(t, "conv-1", export.ConversationID)
+	assert.Equal(t, "web-user-1", export.SessionID)
+	require.Len(t, export.Messages, 2)
+	assert.Equal(t, conversationDomain.MessageRoleUser, export.Messages[0].Role)
+	assert.Equal(t, "The text has 2 words.", export.Messages[1].Content)
+	require.Len(t, export.Plans, 1)
+	assert.Equal(t, "plan-1", export.Plans[0].PlanID)
+	require.Len(t, export.Plans[0].Results, 1)
+	assert.Equal(t, "text-processor", export.Plans[0].Results[0].AgentID)
+	assert.Equal(t, "word_count: 2", export.Plans[0].Results[0].Content)
+}
+
+func TestConversationExportHandler_Markdown(t *testing.T) {
+	handler := newExportTestHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/conversations/conv-1/export?format=markdown", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/markdown; charset=utf-8", rec.Header().Get("Content-Type"))
+
+	transcript := rec.Body.String()
+	assert.True(t, strings.HasPrefix(transcript, "# Conversation conv-1\n"))
+	assert.Contains(t, transcript, "- Session: web-user-1")
+	assert.Contains(t, transcript, "## User · 2025-01-02T03:04:00Z\n\nCount words in hello world\n")
+	assert.Contains(t, transcript, "## Assistant · 2025-01-02T03:04:06Z\n\nThe text has 2 words.\n")
+	assert.Less(t, strings.Index(transcript, "## User"), strings.Index(transcript, "## Assistant"))
+	assert.Contains(t, transcript, "### Plan plan-1")
+	assert.Contains(t, transcript, "#### Agent text-processor (SUCCESS) · 2025-01-02T03:04:05Z\n\nword_count: 2\n")
+}
+
+func TestConversationExportHandler_RejectsUnknownConversationAndFormat(t *testing.T) {
+	handler := newExportTestHandler(t)
+
+	for path, status := range map[string]int{
+		"/api/conversations/conv-missing/export":      http.StatusNotFound,
+		"/api/conversations/conv-1/export?format=pdf": http.StatusBadRequest,
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, status, rec.Code, path)
+	}
+}
@@ -0,0 +1,62 @@
+package web
+
+import (
+	"context"
+	"testing"
+
+	aiInfrastructure "neuromesh/internal/ai/infrastructure"
+	conversationApp "neuromesh/internal/conversation/application"
+	conversationInfra "neuromesh/internal/conversation/infrastructure"
+	"neuromesh/internal/logging"
+	orchestratorApp "neuromesh/internal/orchestrator/application"
+	planningDomain "neuromesh/internal/planning/domain"
+	userApp "neuromesh/internal/user/application"
+	userInfra "neuromesh/internal/user/infrastructure"
+	"neuromesh/testHelpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// historyRecordingOrchestrator records the conversation history each request was decided on
+type historyRecordingOrchestrator struct {
+	histories []string
+}
+
+func (o *historyRecordingOrchestrator) ProcessRequest(ctx context.Context, userInput, userID string) (*orchestratorApp.OrchestratorResult, error) {
+	o.histories = append(o.histories, planningDomain.ConversationHistory(ctx))
+	return &orchestratorApp.OrchestratorResult{Message: "reply to " + userInput, Success: true}, nil
+}
+
+func TestConversationAwareWebBFF_SummarizesLongConversations(t *testing.T) {
+	ctx := context.Background()
+	testGraph := testHelpers.NewCleanMockGraph()
+	provider := aiInfrastructure.NewScriptedProvider(map[string]string{"New messages": "User asked three questions."})
+	config := conversationApp.SummarizationConfig{MessageThreshold: 4, RecentMessages: 2}
+	conversationService := conversationApp.NewConversationServiceWithSummarizer(conversationInfra.NewGraphConversationRepository(testGraph), provider, config)
+	userService := userApp.NewUserService(userInfra.NewGraphUserRepository(testGraph))
+	orchestrator := &historyRecordingOrchestrator{}
+	bff := NewConversationAwareWebBFF(orchestrator, conversationService, userService, logging.NewNoOpLogger())
+
+	var conversationID string
+	for _, message := range []string{"first", "second", "third", "fourth"} {
+		response, err := bff.ProcessWebMessageWithConversation(ctx, "session-1", message)
+		require.NoError(t, err)
+		conversationID = response.ConversationID
+	}
+
+	// The first request has no history; later ones see the earlier exchanges
+	require.Len(t, orchestrator.histories, 4)
+	assert.Empty(t, orchestrator.histories[0])
+	assert.Contains(t, orchestrator.histories[1], "reply to first")
+
+	// Six messages passed the threshold after the third exchange, so the fourth request is decided on the summary
+	require.Len(t, provider.Calls(), 1)
+	assert.Contains(t, orchestrator.histories[3], "Conversation summary:\nUser asked three questions.")
+	assert.Contains(t, orchestrator.histories[3], "reply to third")
+	assert.NotContains(t, orchestrator.histories[3], "reply to first")
+
+	conversation, err := conversationService.GetConversation(ctx, conversationID)
+	require.NoError(t, err)
+	assert.Equal(t, "User asked three questions.", conversation.Summary)
+}
@@ -0,0 +1,141 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Methods and headers allowed cross-origin when a CORS config leaves them empty
+var (
+	DefaultCORSMethods = []string{http.MethodGet, http.MethodPost, http.MethodOptions}
+	DefaultCORSHeaders = []string{"Content-Type", "Authorization"}
+)
+
+// DefaultCORSMaxAge is how long browsers may cache a preflight response
+const DefaultCORSMaxAge = 10 * time.Minute
+
+// CORSConfig lists the origins allowed to call the WebBFF from a browser and what they may send
+// An origin of "*" allows every origin
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// DevCORSConfig allows any origin with the default methods and headers, for local front-end development
+func DevCORSConfig() CORSConfig {
+	return CORSConfig{AllowedOrigins: []string{"*"}}
+}
+
+// Validate checks that the configuration allows at least one origin and does not pair a wildcard with credentials
+func (c CORSConfig) Validate() error {
+	if len(c.AllowedOrigins) == 0 {
+		return fmt.Errorf("at least one allowed origin is required")
+	}
+	if c.AllowCredentials && slices.Contains(c.AllowedOrigins, "*") {
+		return fmt.Errorf("credentials cannot be allowed for the wildcard origin")
+	}
+	if c.MaxAge < 0 {
+		return fmt.Errorf("max age cannot be negative")
+	}
+	return nil
+}
+
+// SetCORS allows cross-origin browser requests as described by config; without it only same-origin callers are served
+func (w *WebBFF) SetCORS(config CORSConfig) {
+	if len(config.AllowedMethods) == 0 {
+		config.AllowedMethods = DefaultCORSMethods
+	}
+	if len(config.AllowedHeaders) == 0 {
+		config.AllowedHeaders = DefaultCORSHeaders
+	}
+	if config.MaxAge == 0 {
+		config.MaxAge = DefaultCORSMaxAge
+	}
+	w.cors = &config
+}
+
+// withCORS adds Access-Control-Allow-* headers for allowed origins and answers their preflight requests
+// Preflights from other origins, or asking for a method or header that is not allowed, are rejected with 403
+func (w *WebBFF) withCORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		config := w.cors
+		origin := r.Header.Get("Origin")
+		if config == nil || origin == "" {
+			next.ServeHTTP(rw, r)
+			return
+		}
+
+		rw.Header().Add("Vary", "Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		allowedOrigin, ok := config.allowOrigin(origin)
+		if !ok {
+			if preflight {
+				http.Error(rw, "Origin not allowed", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(rw, r)
+			return
+		}
+
+		rw.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
+		if config.AllowCredentials {
+			rw.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+		if !preflight {
+			next.ServeHTTP(rw, r)
+			return
+		}
+
+		if !config.allowsMethod(r.Header.Get("Access-Control-Request-Method")) || !config.allowsHeaders(r.Header.Get("Access-Control-Request-Headers")) {
+			http.Error(rw, "Method or headers not allowed", http.StatusForbidden)
+			return
+		}
+		rw.Header().Set("Access-Control-Allow-Methods", strings.Join(config.AllowedMethods, ", "))
+		rw.Header().Set("Access-Control-Allow-Headers", strings.Join(config.AllowedHeaders, ", "))
+		rw.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(config.MaxAge.Seconds())))
+		rw.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// allowOrigin returns the Access-Control-Allow-Origin value for origin, or false when it is not allowed
+func (c *CORSConfig) allowOrigin(origin string) (string, bool) {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" {
+			return "*", true
+		}
+		if strings.EqualFold(allowed, origin) {
+			return origin, true
+		}
+	}
+	return "", false
+}
+
+// allowsMethod reports whether method is one of the allowed methods
+func (c *CORSConfig) allowsMethod(method string) bool {
+	return slices.ContainsFunc(c.AllowedMethods, func(allowed string) bool {
+		return strings.EqualFold(allowed, method)
+	})
+}
+
+// allowsHeaders reports whether every header in a comma-separated Access-Control-Request-Headers value is allowed
+func (c *CORSConfig) allowsHeaders(requested string) bool {
+	for _, header := range strings.Split(requested, ",") {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		if !slices.ContainsFunc(c.AllowedHeaders, func(allowed string) bool {
+			return strings.EqualFold(allowed, header)
+		}) {
+			return false
+		}
+	}
+	return true
+}
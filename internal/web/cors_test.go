@@ -0,0 +1,115 @@
+package web
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"neuromesh/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func corsHandler(config *CORSConfig) http.Handler {
+	bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+	if config != nil {
+		bff.SetCORS(*config)
+	}
+	return bff.CreateWebServer(":0").Handler
+}
+
+func preflightRequest(origin, method, headers string) *http.Request {
+	req := httptest.NewRequest(http.MethodOptions, "/api/chat", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", method)
+	if headers != "" {
+		req.Header.Set("Access-Control-Request-Headers", headers)
+	}
+	return req
+}
+
+func TestCORS_AnswersPreflightForAllowedOrigin(t *testing.T) {
+	handler := corsHandler(&CORSConfig{
+		AllowedOrigins:   []string{"https://ui.example.com"},
+		AllowCredentials: true,
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, preflightRequest("https://ui.example.com", http.MethodPost, "content-type, authorization"))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://ui.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "GET, POST, OPTIONS", rec.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Content-Type, Authorization", rec.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "600", rec.Header().Get("Access-Control-Max-Age"))
+	assert.Equal(t, "Origin", rec.Header().Get("Vary"))
+}
+
+func TestCORS_RejectsPreflightForUnknownOriginMethodOrHeader(t *testing.T) {
+	handler := corsHandler(&CORSConfig{AllowedOrigins: []string{"https://ui.example.com"}})
+
+	for name, req := range map[string]*http.Request{
+		"origin": preflightRequest("https://evil.example.com", http.MethodPost, ""),
+		"method": preflightRequest("https://ui.example.com", http.MethodDelete, ""),
+		"header": preflightRequest("https://ui.example.com", http.MethodPost, "X-Custom"),
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code, name)
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Methods"), name)
+	}
+}
+
+func TestCORS_AddsHeadersToAllowedCrossOriginRequests(t *testing.T) {
+	handler := corsHandler(&CORSConfig{AllowedOrigins: []string{"https://ui.example.com"}})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/chat", bytes.NewBufferString(`{"session_id":"web-user-1","message":"Hello"}`))
+	req.Header.Set("Origin", "https://ui.example.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "https://ui.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+
+	req = httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_DisabledByDefault(t *testing.T) {
+	handler := corsHandler(nil)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, preflightRequest("https://ui.example.com", http.MethodPost, ""))
+
+	assert.NotEqual(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_DevConfigAllowsAnyOrigin(t *testing.T) {
+	config := DevCORSConfig()
+	require.NoError(t, config.Validate())
+	handler := corsHandler(&config)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, preflightRequest("http://localhost:5173", http.MethodPost, "Content-Type"))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestCORSConfig_Validate(t *testing.T) {
+	assert.Error(t, CORSConfig{}.Validate())
+	assert.Error(t, CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}.Validate())
+	assert.NoError(t, CORSConfig{AllowedOrigins: []string{"https://ui.example.com"}, AllowCredentials: true}.Validate())
+}
@@ -0,0 +1,60 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"neuromesh/internal/analytics"
+)
+
+// DefaultDecisionAnalyticsWindow is the window aggregated when the request does not name one
+const DefaultDecisionAnalyticsWindow = 7 * 24 * time.Hour
+
+// DecisionAnalytics defines the interface for aggregating AI decision quality
+type DecisionAnalytics interface {
+	DecisionStats(ctx context.Context, window time.Duration) (analytics.DecisionStats, error)
+}
+
+// SetDecisionAnalytics enables the decision analytics endpoint
+func (w *WebBFF) SetDecisionAnalytics(provider DecisionAnalytics) {
+	w.analytics = provider
+}
+
+// DecisionAnalyticsHandler returns an HTTP handler reporting decision statistics over a window such as ?window=24h
+// A window of 0 covers every stored decision
+func (w *WebBFF) DecisionAnalyticsHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if w.analytics == nil {
+			http.Error(rw, "Decision analytics not available", http.StatusServiceUnavailable)
+			return
+		}
+		r, authenticated := w.authenticate(rw, r)
+		if !authenticated {
+			return
+		}
+
+		window := DefaultDecisionAnalyticsWindow
+		if raw := r.URL.Query().Get("window"); raw != "" {
+			parsed, err := time.ParseDuration(raw)
+			if err != nil || parsed < 0 {
+				http.Error(rw, "window must be a non-negative duration such as 24h", http.StatusBadRequest)
+				return
+			}
+			window = parsed
+		}
+
+		stats, err := w.analytics.DecisionStats(r.Context(), window)
+		if err != nil {
+			w.logger.Error("Failed to compute decision statistics", err)
+			http.Error(rw, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(rw).Encode(stats); err != nil {
+			w.logger.Error("Failed to encode decision statistics", err)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"neuromesh/internal/analytics"
+	"neuromesh/internal/logging"
+)
+
+// stubDecisionAnalytics records the requested window and returns canned statistics
+type stubDecisionAnalytics struct {
+	window time.Duration
+	stats  analytics.DecisionStats
+	err    error
+}
+
+func (s *stubDecisionAnalytics) DecisionStats(ctx context.Context, window time.Duration) (analytics.DecisionStats, error) {
+	s.window = window
+	return s.stats, s.err
+}
+
+func TestDecisionAnalyticsHandler(t *testing.T) {
+	provider := &stubDecisionAnalytics{stats: analytics.DecisionStats{
+		TotalDecisions:    2,
+		AverageConfidence: 75,
+		ByType: map[string]*analytics.DecisionTypeStats{
+			"EXECUTE": {Count: 2, Rate: 1, Outcomes: 2, Successes: 1, SuccessRate: 0.5},
+		},
+		ByCategory: map[string]*analytics.CategoryStats{
+			"text_processing": {Count: 2, AverageConfidence: 75},
+		},
+	}}
+	bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+	bff.SetDecisionAnalytics(provider)
+	handler := bff.CreateWebServer(":0").Handler
+
+	t.Run("reports the statistics of the requested window", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/analytics/decisions?window=24h", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, 24*time.Hour, provider.window)
+
+		var stats analytics.DecisionStats
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+		assert.Equal(t, 2, stats.TotalDecisions)
+		assert.InDelta(t, 0.5, stats.ByType["EXECUTE"].SuccessRate, 0.001)
+		assert.InDelta(t, 75.0, stats.ByCategory["text_processing"].AverageConfidence, 0.001)
+	})
+
+	t.Run("defaults the window", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/analytics/decisions", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, DefaultDecisionAnalyticsWindow, provider.window)
+	})
+
+	t.Run("rejects an invalid window", func(t *testing.T) {
+		for _, window := range []string{"soon", "-1h"} {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/analytics/decisions?window="+window, nil))
+			assert.Equal(t, http.StatusBadRequest, rec.Code, window)
+		}
+	})
+
+	t.Run("reports failures as server errors", func(t *testing.T) {
+		provider.err = errors.New("graph unavailable")
+		defer func() { provider.err = nil }()
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/analytics/decisions", nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("unavailable without an analytics service", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger()).CreateWebServer(":0").Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/analytics/decisions", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
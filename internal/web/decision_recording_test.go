@@ -0,0 +1,129 @@
+package web
+
+import (
+	"context"
+	"testing"
+
+	aiDomain "neuromesh/internal/ai/domain"
+	conversationApp "neuromesh/internal/conversation/application"
+	conversationDomain "neuromesh/internal/conversation/domain"
+	conversationInfra "neuromesh/internal/conversation/infrastructure"
+	learningApp "neuromesh/internal/learning/application"
+	learningDomain "neuromesh/internal/learning/domain"
+	learningInfra "neuromesh/internal/learning/infrastructure"
+	"neuromesh/internal/logging"
+	orchestratorApp "neuromesh/internal/orchestrator/application"
+	orchestratorDomain "neuromesh/internal/orchestrator/domain"
+	planningDomain "neuromesh/internal/planning/domain"
+	userApp "neuromesh/internal/user/application"
+	userInfra "neuromesh/internal/user/infrastructure"
+	"neuromesh/testHelpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversationAwareWebBFF_RecordsDecisionGraph(t *testing.T) {
+	ctx := context.Background()
+	testGraph := testHelpers.NewCleanMockGraph()
+	conversationService := conversationApp.NewConversationService(conversationInfra.NewGraphConversationRepository(testGraph))
+	userService := userApp.NewUserService(userInfra.NewGraphUserRepository(testGraph))
+
+	analysis := planningDomain.NewAnalysis("", "word_count", "text", 92, []string{"text-processor"}, "word count request")
+	decision := orchestratorDomain.NewExecuteDecision("", analysis.ID, "plan-1", "text-processor counts words", "Clear request")
+	decision.Confidence = 92
+	decision.Parameters = map[string]interface{}{
+		orchestratorDomain.ParameterTokenUsage: aiDomain.Usage{PromptTokens: 120, CompletionTokens: 30, TotalTokens: 150},
+	}
+	orchestrator := &MockAIOrchestrator{responses: map[string]*orchestratorApp.OrchestratorResult{
+		"Count words in hello world": {
+			Message:         "The text contains 2 words.",
+			Analysis:        analysis,
+			Decision:        decision,
+			ExecutionPlanID: "plan-1",
+			Success:         true,
+		},
+	}}
+	bff := NewConversationAwareWebBFF(orchestrator, conversationService, userService, logging.NewNoOpLogger())
+
+	response, err := bff.ProcessWebMessageWithConversation(ctx, "session-1", "Count words in hello world")
+	require.NoError(t, err)
+	require.NotEmpty(t, response.ConversationID)
+
+	decisions, err := conversationService.GetDecisionsByConversation(ctx, response.ConversationID)
+	require.NoError(t, err)
+	require.Len(t, decisions, 1)
+	recorded := decisions[0]
+	assert.Equal(t, decision.ID, recorded.ID)
+	assert.Equal(t, string(orchestratorDomain.DecisionTypeExecute), recorded.Type)
+	assert.Equal(t, 92, recorded.Confidence)
+	assert.Equal(t, "plan-1", recorded.ExecutionPlanID)
+	assert.Equal(t, 120, recorded.PromptTokens)
+	assert.Equal(t, 30, recorded.CompletionTokens)
+	assert.Equal(t, 150, recorded.TotalTokens)
+
+	userMessages, err := conversationService.GetMessagesByRole(ctx, response.ConversationID, conversationDomain.MessageRoleUser)
+	require.NoError(t, err)
+	require.Len(t, userMessages, 1)
+	assert.Equal(t, userMessages[0].ID, recorded.RequestMessageID)
+
+	// The user request has the decision, which was made in the conversation
+	requestEdges, err := testGraph.GetEdgesWithTargets(ctx, conversationInfra.NodeTypeMessage, userMessages[0].ID)
+	require.NoError(t, err)
+	assert.Contains(t, requestEdges, map[string]interface{}{
+		"type":        conversationInfra.RelationshipHasDecision,
+		"target_id":   decision.ID,
+		"target_type": conversationInfra.NodeTypeAIDecision,
+	})
+	decisionEdges, err := testGraph.GetEdgesWithTargets(ctx, conversationInfra.NodeTypeAIDecision, decision.ID)
+	require.NoError(t, err)
+	assert.Contains(t, decisionEdges, map[string]interface{}{
+		"type":        conversationInfra.RelationshipDecidedIn,
+		"target_id":   response.ConversationID,
+		"target_type": conversationInfra.NodeTypeConversation,
+	})
+}
+
+func TestConversationAwareWebBFF_LinksOutcomeToRecordedDecision(t *testing.T) {
+	ctx := context.Background()
+	testGraph := testHelpers.NewCleanMockGraph()
+	conversationService := conversationApp.NewConversationService(conversationInfra.NewGraphConversationRepository(testGraph))
+	userService := userApp.NewUserService(userInfra.NewGraphUserRepository(testGraph))
+	outcomeRepo := learningInfra.NewGraphOutcomeRepository(testGraph)
+
+	analysis := planningDomain.NewAnalysis("", "word_count", "text", 92, []string{"text-processor"}, "word count request")
+	decision := orchestratorDomain.NewExecuteDecision("", analysis.ID, "plan-1", "text-processor counts words", "Clear request")
+	orchestrator := &MockAIOrchestrator{responses: map[string]*orchestratorApp.OrchestratorResult{
+		"Count words in hello world": {
+			Message:  "The text contains 2 words.",
+			Analysis: analysis,
+			Decision: decision,
+			Success:  true,
+			Outcome: &learningDomain.Outcome{
+				UserInput:    "Count words in hello world",
+				Intent:       "word_count",
+				DecisionType: string(orchestratorDomain.DecisionTypeExecute),
+				Status:       learningDomain.OutcomeStatusSuccess,
+			},
+		},
+	}}
+	bff := NewConversationAwareWebBFF(orchestrator, conversationService, userService, logging.NewNoOpLogger())
+	bff.SetOutcomeRecorder(learningApp.NewLearningService(outcomeRepo))
+
+	_, err := bff.ProcessWebMessageWithConversation(ctx, "session-1", "Count words in hello world")
+	require.NoError(t, err)
+
+	outcome, err := outcomeRepo.GetByDecisionID(ctx, decision.ID)
+	require.NoError(t, err)
+	require.NotNil(t, outcome)
+	assert.Equal(t, learningDomain.OutcomeStatusSuccess, outcome.Status)
+
+	// The outcome is recorded after its decision, so the link to the decision node is created
+	outcomeEdges, err := testGraph.GetEdgesWithTargets(ctx, learningInfra.NodeTypeDecisionOutcome, decision.ID)
+	require.NoError(t, err)
+	assert.Contains(t, outcomeEdges, map[string]interface{}{
+		"type":        learningInfra.RelationshipOutcomeOf,
+		"target_id":   decision.ID,
+		"target_type": learningInfra.NodeTypeAIDecision,
+	})
+}
@@ -0,0 +1,29 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// drainRetryAfterSeconds is the Retry-After sent to chat clients while the server shuts down
+const drainRetryAfterSeconds = 5
+
+// StopAccepting makes the chat endpoints reject new requests so in-flight conversations can drain
+func (w *WebBFF) StopAccepting() {
+	w.draining.Store(true)
+}
+
+// InFlight returns the number of chat messages currently being processed
+func (w *WebBFF) InFlight() int {
+	return int(w.inFlight.Load())
+}
+
+// admitRequest rejects chat requests with 503 once the server is draining
+func (w *WebBFF) admitRequest(rw http.ResponseWriter) bool {
+	if !w.draining.Load() {
+		return true
+	}
+	rw.Header().Set("Retry-After", strconv.Itoa(drainRetryAfterSeconds))
+	http.Error(rw, "Server is shutting down", http.StatusServiceUnavailable)
+	return false
+}
@@ -0,0 +1,27 @@
+package web
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"neuromesh/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebBFF_RejectsChatWhileDraining(t *testing.T) {
+	bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+	bff.StopAccepting()
+
+	for _, path := range []string{"/api/chat", "/api/chat/stream"} {
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(`{"session_id":"web-user-1","message":"Hello"}`))
+		rec := httptest.NewRecorder()
+		bff.CreateWebServer(":0").Handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code, path)
+		assert.Equal(t, "5", rec.Header().Get("Retry-After"), path)
+	}
+	assert.Equal(t, 0, bff.InFlight())
+}
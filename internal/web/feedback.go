@@ -0,0 +1,128 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	conversationDomain "neuromesh/internal/conversation/domain"
+	learningDomain "neuromesh/internal/learning/domain"
+)
+
+// FeedbackRecorder defines the interface for recording user feedback on AI decisions
+type FeedbackRecorder interface {
+	RecordFeedback(ctx context.Context, feedback *learningDomain.Feedback) error
+}
+
+// FeedbackRequest is a thumbs up or down on a conversation's response
+// DecisionID defaults to the conversation's latest decision
+type FeedbackRequest struct {
+	DecisionID string                        `json:"decision_id,omitempty"`
+	Rating     learningDomain.FeedbackRating `json:"rating"`
+	Comment    string                        `json:"comment,omitempty"`
+}
+
+// FeedbackResponse identifies the stored feedback and the decision it was attached to
+type FeedbackResponse struct {
+	FeedbackID string                        `json:"feedback_id"`
+	DecisionID string                        `json:"decision_id"`
+	Rating     learningDomain.FeedbackRating `json:"rating"`
+}
+
+// SetFeedbackRecorder enables the conversation feedback endpoint
+func (w *WebBFF) SetFeedbackRecorder(recorder FeedbackRecorder) {
+	w.feedback = recorder
+}
+
+// FeedbackHandler returns an HTTP handler recording a user's rating of an AI decision in a conversation
+func (w *WebBFF) FeedbackHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if w.recorder == nil || w.feedback == nil {
+			http.Error(rw, "Feedback not available", http.StatusServiceUnavailable)
+			return
+		}
+		r, authenticated := w.authenticate(rw, r)
+		if !authenticated {
+			return
+		}
+
+		var req FeedbackRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(rw, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+		if !req.Rating.IsValid() {
+			http.Error(rw, "rating must be up or down", http.StatusBadRequest)
+			return
+		}
+
+		conversation, ok := w.loadRequestConversation(rw, r)
+		if !ok {
+			return
+		}
+
+		decisions, err := w.recorder.GetConversationDecisions(r.Context(), conversation.ID)
+		if err != nil {
+			w.logger.Error("Failed to load conversation decisions", err, "conversationID", conversation.ID)
+			http.Error(rw, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		decision := feedbackDecision(decisions, req.DecisionID)
+		if decision == nil {
+			http.Error(rw, "Decision not found", http.StatusNotFound)
+			return
+		}
+
+		userID := conversation.UserID
+		if user, ok := AuthenticatedUser(r.Context()); ok {
+			userID = user.ID
+		}
+		feedback := &learningDomain.Feedback{
+			ID:             uuid.New().String(),
+			DecisionID:     decision.ID,
+			ConversationID: conversation.ID,
+			UserID:         userID,
+			Rating:         req.Rating,
+			Comment:        req.Comment,
+			CreatedAt:      time.Now().UTC(),
+		}
+
+		err = w.feedback.RecordFeedback(r.Context(), feedback)
+		var validationErr learningDomain.OutcomeValidationError
+		if errors.As(err, &validationErr) {
+			http.Error(rw, validationErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			w.logger.Error("Failed to record feedback", err, "decisionID", decision.ID)
+			http.Error(rw, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(rw).Encode(FeedbackResponse{FeedbackID: feedback.ID, DecisionID: decision.ID, Rating: feedback.Rating}); err != nil {
+			w.logger.Error("Failed to encode feedback response", err)
+		}
+	})
+}
+
+// feedbackDecision returns the decision with the given ID, or the latest decision when no ID is given
+func feedbackDecision(decisions []*conversationDomain.AIDecision, decisionID string) *conversationDomain.AIDecision {
+	if decisionID == "" {
+		if len(decisions) == 0 {
+			return nil
+		}
+		return decisions[len(decisions)-1]
+	}
+	for _, decision := range decisions {
+		if decision.ID == decisionID {
+			return decision
+		}
+	}
+	return nil
+}
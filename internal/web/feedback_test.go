@@ -0,0 +1,138 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conversationApp "neuromesh/internal/conversation/application"
+	conversationDomain "neuromesh/internal/conversation/domain"
+	conversationInfra "neuromesh/internal/conversation/infrastructure"
+	learningApp "neuromesh/internal/learning/application"
+	learningDomain "neuromesh/internal/learning/domain"
+	learningInfra "neuromesh/internal/learning/infrastructure"
+	"neuromesh/internal/logging"
+	userApp "neuromesh/internal/user/application"
+	userInfra "neuromesh/internal/user/infrastructure"
+	"neuromesh/testHelpers"
+)
+
+// newFeedbackTestHandler serves a conversation with two decisions, returning the repository feedback is stored in
+func newFeedbackTestHandler(t *testing.T) (http.Handler, *learningInfra.GraphOutcomeRepository) {
+	ctx := context.Background()
+	testGraph := testHelpers.NewCleanMockGraph()
+	conversationService := conversationApp.NewConversationService(conversationInfra.NewGraphConversationRepository(testGraph))
+	userService := userApp.NewUserService(userInfra.NewGraphUserRepository(testGraph))
+	outcomeRepo := learningInfra.NewGraphOutcomeRepository(testGraph)
+
+	_, err := conversationService.CreateConversation(ctx, "conv-1", "web-user-1", "user-1")
+	require.NoError(t, err)
+	decided := time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC)
+	for i, decisionID := range []string{"decision-1", "decision-2"} {
+		require.NoError(t, conversationService.RecordDecision(ctx, &conversationDomain.AIDecision{
+			ID:             decisionID,
+			ConversationID: "conv-1",
+			Type:           "EXECUTE",
+			Confidence:     90,
+			CreatedAt:      decided.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+
+	bff := NewConversationAwareWebBFF(&MockAIOrchestrator{}, conversationService, userService, logging.NewNoOpLogger())
+	bff.SetFeedbackRecorder(learningApp.NewLearningService(outcomeRepo))
+	return bff.CreateWebServer(":0").Handler, outcomeRepo
+}
+
+func postFeedback(handler http.Handler, conversationID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/conversations/"+conversationID+"/feedback", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestFeedbackHandler_RatesLatestDecision(t *testing.T) {
+	handler, outcomeRepo := newFeedbackTestHandler(t)
+
+	rec := postFeedback(handler, "conv-1", `{"rating":"down","comment":"That count is wrong"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	var response FeedbackResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "decision-2", response.DecisionID)
+	assert.NotEmpty(t, response.FeedbackID)
+
+	feedback, err := outcomeRepo.GetFeedbackByDecision(context.Background(), "decision-2")
+	require.NoError(t, err)
+	require.Len(t, feedback, 1)
+	assert.Equal(t, response.FeedbackID, feedback[0].ID)
+	assert.Equal(t, "conv-1", feedback[0].ConversationID)
+	assert.Equal(t, "user-1", feedback[0].UserID)
+	assert.Equal(t, learningDomain.FeedbackRatingDown, feedback[0].Rating)
+	assert.Equal(t, "That count is wrong", feedback[0].Comment)
+
+	outcome, err := outcomeRepo.GetByDecisionID(context.Background(), "decision-2")
+	require.NoError(t, err)
+	require.NotNil(t, outcome)
+	assert.Equal(t, learningDomain.OutcomeStatusFailure, outcome.Status)
+	assert.Equal(t, learningDomain.MinUserRating, outcome.UserRating)
+
+	untouched, err := outcomeRepo.GetFeedbackByDecision(context.Background(), "decision-1")
+	require.NoError(t, err)
+	assert.Empty(t, untouched)
+}
+
+func TestFeedbackHandler_RatesGivenDecision(t *testing.T) {
+	handler, outcomeRepo := newFeedbackTestHandler(t)
+
+	rec := postFeedback(handler, "conv-1", `{"decision_id":"decision-1","rating":"up"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	feedback, err := outcomeRepo.GetFeedbackByDecision(context.Background(), "decision-1")
+	require.NoError(t, err)
+	require.Len(t, feedback, 1)
+	assert.Equal(t, learningDomain.FeedbackRatingUp, feedback[0].Rating)
+
+	outcome, err := outcomeRepo.GetByDecisionID(context.Background(), "decision-1")
+	require.NoError(t, err)
+	require.NotNil(t, outcome)
+	assert.Equal(t, learningDomain.OutcomeStatusSuccess, outcome.Status)
+	assert.Equal(t, learningDomain.MaxUserRating, outcome.UserRating)
+}
+
+func TestFeedbackHandler_Errors(t *testing.T) {
+	handler, _ := newFeedbackTestHandler(t)
+
+	tests := []struct {
+		name           string
+		conversationID string
+		body           string
+		expectedStatus int
+	}{
+		{"invalid JSON", "conv-1", `{`, http.StatusBadRequest},
+		{"unknown rating", "conv-1", `{"rating":"meh"}`, http.StatusBadRequest},
+		{"comment too long", "conv-1", `{"rating":"down","comment":"` + strings.Repeat("x", learningDomain.MaxFeedbackCommentLength+1) + `"}`, http.StatusBadRequest},
+		{"unknown conversation", "conv-unknown", `{"rating":"up"}`, http.StatusNotFound},
+		{"decision of another conversation", "conv-1", `{"decision_id":"decision-other","rating":"up"}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := postFeedback(handler, tt.conversationID, tt.body)
+			assert.Equal(t, tt.expectedStatus, rec.Code, rec.Body.String())
+		})
+	}
+
+	t.Run("unavailable without a feedback recorder", func(t *testing.T) {
+		bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+		rec := postFeedback(bff.CreateWebServer(":0").Handler, "conv-1", `{"rating":"up"}`)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
@@ -10,7 +10,7 @@ import (
 
 	"neuromesh/internal/logging"
 	"neuromesh/internal/orchestrator/application"
-	"neuromesh/internal/orchestrator/domain"
+	"neuromesh/internal/planning/domain"
 )
 
 // TestMockOrchestrator for focused testing
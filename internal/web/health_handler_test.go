@@ -0,0 +1,79 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"neuromesh/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDependency reports a fixed health state
+type fakeDependency struct {
+	err error
+}
+
+func (f *fakeDependency) Check(ctx context.Context) error {
+	return f.err
+}
+
+func TestWebBFF_HealthEndpoints(t *testing.T) {
+	serve := func(bff *WebBFF, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		bff.CreateWebServer(":0").Handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("healthz reports alive even when dependencies are down", func(t *testing.T) {
+		bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+		bff.AddReadinessCheck("neo4j", (&fakeDependency{err: errors.New("connection refused")}).Check)
+
+		rec := serve(bff, "/healthz")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+	})
+
+	t.Run("readyz returns 200 when all dependencies are healthy", func(t *testing.T) {
+		bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+		bff.AddReadinessCheck("neo4j", (&fakeDependency{}).Check)
+		bff.AddReadinessCheck("rabbitmq", (&fakeDependency{}).Check)
+
+		rec := serve(bff, "/readyz")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "ready", body["status"])
+		assert.Empty(t, body["failed"])
+		assert.Equal(t, map[string]interface{}{"neo4j": "ok", "rabbitmq": "ok"}, body["checks"])
+	})
+
+	t.Run("readyz returns 503 listing failed dependencies", func(t *testing.T) {
+		bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+		bff.AddReadinessCheck("neo4j", (&fakeDependency{}).Check)
+		bff.AddReadinessCheck("rabbitmq", (&fakeDependency{err: errors.New("RabbitMQ connection closed")}).Check)
+
+		rec := serve(bff, "/readyz")
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "not_ready", body["status"])
+		assert.Equal(t, []interface{}{"rabbitmq"}, body["failed"])
+		assert.Equal(t, "RabbitMQ connection closed", body["checks"].(map[string]interface{})["rabbitmq"])
+	})
+
+	t.Run("readyz is ready without registered checks", func(t *testing.T) {
+		rec := serve(NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger()), "/readyz")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
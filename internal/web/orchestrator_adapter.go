@@ -3,7 +3,9 @@ package web
 import (
 	"context"
 
+	executionDomain "neuromesh/internal/execution/domain"
 	"neuromesh/internal/orchestrator/application"
+	planningDomain "neuromesh/internal/planning/domain"
 )
 
 // OrchestratorAdapter adapts the new clean architecture orchestrator
@@ -34,3 +36,28 @@ func (w *OrchestratorAdapter) ProcessRequest(ctx context.Context, userInput, use
 	// Return the result directly - no more conversion needed!
 	return result, nil
 }
+
+// ProcessRequestWithProgress adapts ProcessUserRequestWithProgress to the web interface
+func (w *OrchestratorAdapter) ProcessRequestWithProgress(ctx context.Context, userInput, userID string, onProgress executionDomain.ProgressReporter) (*application.OrchestratorResult, error) {
+	request := &application.OrchestratorRequest{
+		UserInput: userInput,
+		UserID:    userID,
+	}
+
+	return w.orchestratorService.ProcessUserRequestWithProgress(ctx, request, onProgress)
+}
+
+// PreviewPlan adapts the orchestrator's plan preview to the web interface
+func (w *OrchestratorAdapter) PreviewPlan(ctx context.Context, userInput, userID string) (*planningDomain.ExecutionPlan, error) {
+	return w.orchestratorService.PreviewPlan(ctx, userInput, userID)
+}
+
+// ApprovePlan adapts the orchestrator's plan approval to the web interface
+func (w *OrchestratorAdapter) ApprovePlan(ctx context.Context, planID, approverUserID string) (*application.OrchestratorResult, error) {
+	return w.orchestratorService.ApprovePlan(ctx, planID, approverUserID)
+}
+
+// RejectPlan adapts the orchestrator's plan rejection to the web interface
+func (w *OrchestratorAdapter) RejectPlan(ctx context.Context, planID, approverUserID, reason string) error {
+	return w.orchestratorService.RejectPlan(ctx, planID, approverUserID, reason)
+}
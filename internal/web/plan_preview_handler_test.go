@@ -0,0 +1,89 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"neuromesh/internal/logging"
+	"neuromesh/internal/orchestrator/application"
+	planningDomain "neuromesh/internal/planning/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubPlanPreviewer is an orchestrator that returns a canned plan preview
+type stubPlanPreviewer struct {
+	MockAIOrchestrator
+	plan      *planningDomain.ExecutionPlan
+	err       error
+	userInput string
+	userID    string
+}
+
+func (s *stubPlanPreviewer) PreviewPlan(ctx context.Context, userInput, userID string) (*planningDomain.ExecutionPlan, error) {
+	s.userInput, s.userID = userInput, userID
+	return s.plan, s.err
+}
+
+func TestWebBFF_PlanPreviewEndpoint(t *testing.T) {
+	preview := func(orchestrator AIOrchestrator, body string) *httptest.ResponseRecorder {
+		server := NewWebBFF(orchestrator, logging.NewNoOpLogger()).CreateWebServer(":0").Handler
+		req := httptest.NewRequest(http.MethodPost, "/api/plan/preview", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("returns the draft plan", func(t *testing.T) {
+		plan := planningDomain.NewExecutionPlan("Count words", "Count the words of a text", planningDomain.ExecutionPlanPriorityMedium)
+		plan.AddStep(planningDomain.NewExecutionStep("Count words", "Count words in text", "text-processor"))
+		previewer := &stubPlanPreviewer{plan: plan}
+
+		rec := preview(previewer, `{"session_id":"session-1","message":"count words in hello world"}`)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "count words in hello world", previewer.userInput)
+		assert.Equal(t, "session-1", previewer.userID)
+
+		var body planningDomain.ExecutionPlan
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, plan.ID, body.ID)
+		assert.Equal(t, planningDomain.ExecutionPlanStatusDraft, body.Status)
+		assert.Len(t, body.Steps, 1)
+	})
+
+	t.Run("returns 422 when no plan is generated", func(t *testing.T) {
+		previewer := &stubPlanPreviewer{err: fmt.Errorf("%w: clarification needed: which text?", application.ErrNoExecutionPlan)}
+
+		rec := preview(previewer, `{"session_id":"session-1","message":"count words"}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "which text?")
+	})
+
+	t.Run("returns 400 for an invalid request", func(t *testing.T) {
+		rec := preview(&stubPlanPreviewer{}, `{"session_id":"session-1"`)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("returns 503 when the orchestrator cannot preview plans", func(t *testing.T) {
+		rec := preview(&MockAIOrchestrator{}, `{"session_id":"session-1","message":"count words"}`)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+
+	t.Run("rejects non-POST requests", func(t *testing.T) {
+		server := NewWebBFF(&stubPlanPreviewer{}, logging.NewNoOpLogger()).CreateWebServer(":0").Handler
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/plan/preview", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
@@ -0,0 +1,123 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"neuromesh/internal/graph"
+	"neuromesh/internal/logging"
+	planningDomain "neuromesh/internal/planning/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubPlanProgressProvider returns canned progress for tests, denying requesters other than owner when it is set
+type stubPlanProgressProvider struct {
+	progress planningDomain.PlanProgress
+	err      error
+	owner    string
+	planID   string
+}
+
+func (s *stubPlanProgressProvider) GetPlanProgress(ctx context.Context, planID, requesterID string) (planningDomain.PlanProgress, error) {
+	s.planID = planID
+	if requesterID != "" && requesterID != s.owner {
+		return planningDomain.PlanProgress{}, planningDomain.ErrPlanAccessDenied
+	}
+	return s.progress, s.err
+}
+
+func TestWebBFF_PlanProgressEndpoint(t *testing.T) {
+	newServer := func(provider PlanProgressProvider) http.Handler {
+		bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+		if provider != nil {
+			bff.SetPlanProgressProvider(provider)
+		}
+		return bff.CreateWebServer(":0").Handler
+	}
+
+	t.Run("returns progress for plan", func(t *testing.T) {
+		provider := &stubPlanProgressProvider{
+			progress: planningDomain.NewPlanProgress("plan-123", map[planningDomain.ExecutionStepStatus]int{
+				planningDomain.ExecutionStepStatusCompleted: 1,
+				planningDomain.ExecutionStepStatusPending:   1,
+			}, nil),
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/api/plans/plan-123/progress", nil)
+		rec := httptest.NewRecorder()
+		newServer(provider).ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "plan-123", provider.planID)
+
+		var body planningDomain.PlanProgress
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, 2, body.TotalSteps)
+		assert.Equal(t, 50.0, body.PercentComplete)
+		assert.Equal(t, 1, body.StatusCounts[planningDomain.ExecutionStepStatusCompleted])
+	})
+
+	t.Run("returns 404 for unknown plan", func(t *testing.T) {
+		provider := &stubPlanProgressProvider{err: fmt.Errorf("failed to get execution plan: %w", graph.ErrNodeNotFound)}
+
+		req := httptest.NewRequest(http.MethodGet, "/api/plans/missing/progress", nil)
+		rec := httptest.NewRecorder()
+		newServer(provider).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("returns 500 when provider fails", func(t *testing.T) {
+		provider := &stubPlanProgressProvider{err: errors.New("graph unavailable")}
+
+		req := httptest.NewRequest(http.MethodGet, "/api/plans/plan-123/progress", nil)
+		rec := httptest.NewRecorder()
+		newServer(provider).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("requires the plan's owner when authentication is enabled", func(t *testing.T) {
+		bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+		bff.SetPlanProgressProvider(&stubPlanProgressProvider{owner: "alice", progress: planningDomain.NewPlanProgress("plan-123", nil, nil)})
+		bff.SetAuthenticator(NewStaticTokenAuthenticator(map[string]string{"alice-token": "alice", "bob-token": "bob"}))
+		server := bff.CreateWebServer(":0").Handler
+
+		getProgress := func(authorization string) int {
+			req := httptest.NewRequest(http.MethodGet, "/api/plans/plan-123/progress", nil)
+			if authorization != "" {
+				req.Header.Set("Authorization", authorization)
+			}
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+			return rec.Code
+		}
+
+		assert.Equal(t, http.StatusUnauthorized, getProgress(""))
+		assert.Equal(t, http.StatusForbidden, getProgress("Bearer bob-token"))
+		assert.Equal(t, http.StatusOK, getProgress("Bearer alice-token"))
+	})
+
+	t.Run("returns 503 without provider", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/plans/plan-123/progress", nil)
+		rec := httptest.NewRecorder()
+		newServer(nil).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+
+	t.Run("rejects non-GET requests", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/plans/plan-123/progress", nil)
+		rec := httptest.NewRecorder()
+		newServer(&stubPlanProgressProvider{}).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
@@ -0,0 +1,113 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	executionDomain "neuromesh/internal/execution/domain"
+	"neuromesh/internal/graph"
+	"neuromesh/internal/logging"
+	"neuromesh/internal/orchestrator/application"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubPlanApprover is an orchestrator that records plan reviews
+type stubPlanApprover struct {
+	MockAIOrchestrator
+	result      *application.OrchestratorResult
+	err         error
+	planID      string
+	approver    string
+	reason      string
+	executionID string
+}
+
+func (s *stubPlanApprover) ApprovePlan(ctx context.Context, planID, approverUserID string) (*application.OrchestratorResult, error) {
+	s.planID, s.approver = planID, approverUserID
+	s.executionID = executionDomain.ExecutionIDFromContext(ctx)
+	return s.result, s.err
+}
+
+func (s *stubPlanApprover) RejectPlan(ctx context.Context, planID, approverUserID, reason string) error {
+	s.planID, s.approver, s.reason = planID, approverUserID, reason
+	return s.err
+}
+
+func TestWebBFF_PlanReviewEndpoints(t *testing.T) {
+	review := func(orchestrator AIOrchestrator, path, body string) *httptest.ResponseRecorder {
+		server := NewWebBFF(orchestrator, logging.NewNoOpLogger()).CreateWebServer(":0").Handler
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		return rec
+	}
+
+	t.Run("approves the plan and accepts its execution", func(t *testing.T) {
+		approver := &stubPlanApprover{result: &application.OrchestratorResult{Success: true, Message: "Plan plan-1 approved; its 1 steps are executing."}}
+
+		rec := review(approver, "/api/plans/plan-1/approve", `{"session_id":"session-1"}`)
+
+		require.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Equal(t, "plan-1", approver.planID)
+		assert.Equal(t, "session-1", approver.approver)
+
+		var body PlanReviewResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		// The approved plan executes under the returned ID, which cancels it
+		assert.NotEmpty(t, approver.executionID)
+		assert.Equal(t, PlanReviewResponse{PlanID: "plan-1", Status: "approved", Content: "Plan plan-1 approved; its 1 steps are executing.", ExecutionID: approver.executionID}, body)
+	})
+
+	t.Run("rejects the plan with a reason", func(t *testing.T) {
+		approver := &stubPlanApprover{}
+
+		rec := review(approver, "/api/plans/plan-1/reject", `{"session_id":"session-1","reason":"not needed"}`)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "plan-1", approver.planID)
+		assert.Equal(t, "not needed", approver.reason)
+		assert.Contains(t, rec.Body.String(), `"status":"rejected"`)
+	})
+
+	t.Run("returns 409 for a plan not awaiting approval", func(t *testing.T) {
+		approver := &stubPlanApprover{err: fmt.Errorf("%w: plan plan-1 is REJECTED", application.ErrPlanNotAwaitingApproval)}
+
+		rec := review(approver, "/api/plans/plan-1/approve", `{"session_id":"session-1"}`)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("returns 403 for another user's plan", func(t *testing.T) {
+		approver := &stubPlanApprover{err: fmt.Errorf("%w: plan plan-1", application.ErrPlanAccessDenied)}
+
+		rec := review(approver, "/api/plans/plan-1/approve", `{"session_id":"session-1"}`)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("returns 404 for an unknown plan", func(t *testing.T) {
+		approver := &stubPlanApprover{err: fmt.Errorf("failed to load execution plan plan-1: %w", graph.ErrNodeNotFound)}
+
+		rec := review(approver, "/api/plans/plan-1/reject", `{"session_id":"session-1"}`)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("returns 400 without a session", func(t *testing.T) {
+		rec := review(&stubPlanApprover{}, "/api/plans/plan-1/approve", `{}`)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("returns 503 when the orchestrator cannot approve plans", func(t *testing.T) {
+		rec := review(&MockAIOrchestrator{}, "/api/plans/plan-1/approve", `{"session_id":"session-1"}`)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
@@ -0,0 +1,157 @@
+package web
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Default chat rate limit applied per authenticated user or client address
+const (
+	DefaultRequestsPerMinute = 20
+	DefaultRateLimitBurst    = 5
+)
+
+// RateLimiter decides whether a client may issue another chat request
+type RateLimiter interface {
+	// Allow consumes one request for key; when denied it reports how long until a request is allowed again
+	Allow(key string) (bool, time.Duration)
+}
+
+// RateLimitConfig configures a token-bucket rate limiter
+type RateLimitConfig struct {
+	RequestsPerMinute int // Sustained rate at which tokens are refilled
+	Burst             int // Bucket capacity, the number of requests allowed back to back
+}
+
+// Validate checks that the configuration describes a usable limiter
+func (c RateLimitConfig) Validate() error {
+	if c.RequestsPerMinute <= 0 {
+		return fmt.Errorf("requests per minute must be positive")
+	}
+	if c.Burst <= 0 {
+		return fmt.Errorf("burst must be positive")
+	}
+	return nil
+}
+
+// tokenBucket holds the remaining tokens of one key as of the last refill
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// TokenBucketLimiter is an in-memory token-bucket RateLimiter
+// Buckets idle long enough to have refilled completely are evicted, since a fresh bucket is identical
+type TokenBucketLimiter struct {
+	ratePerSecond float64
+	burst         float64
+	idleAfter     time.Duration // Time for an empty bucket to refill completely
+	now           func() time.Time
+	buckets       map[string]*tokenBucket
+	lastEviction  time.Time
+	mutex         sync.Mutex
+}
+
+// NewTokenBucketLimiter creates a token-bucket limiter using the wall clock
+func NewTokenBucketLimiter(config RateLimitConfig) *TokenBucketLimiter {
+	return NewTokenBucketLimiterWithClock(config, time.Now)
+}
+
+// NewTokenBucketLimiterWithClock creates a token-bucket limiter reading time from now
+func NewTokenBucketLimiterWithClock(config RateLimitConfig, now func() time.Time) *TokenBucketLimiter {
+	ratePerSecond := float64(config.RequestsPerMinute) / 60
+	return &TokenBucketLimiter{
+		ratePerSecond: ratePerSecond,
+		burst:         float64(config.Burst),
+		idleAfter:     time.Duration(float64(config.Burst) / ratePerSecond * float64(time.Second)),
+		now:           now,
+		buckets:       make(map[string]*tokenBucket),
+		lastEviction:  now(),
+	}
+}
+
+// Allow consumes a token from the key's bucket, refilling it for the time elapsed since the last call
+func (l *TokenBucketLimiter) Allow(key string) (bool, time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastEviction) >= l.idleAfter {
+		l.evictIdle(now)
+	}
+
+	bucket, exists := l.buckets[key]
+	if !exists {
+		bucket = &tokenBucket{tokens: l.burst, lastRefill: now}
+		l.buckets[key] = bucket
+	}
+
+	if elapsed := now.Sub(bucket.lastRefill).Seconds(); elapsed > 0 {
+		bucket.tokens = math.Min(l.burst, bucket.tokens+elapsed*l.ratePerSecond)
+		bucket.lastRefill = now
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+
+	wait := (1 - bucket.tokens) / l.ratePerSecond
+	return false, time.Duration(wait * float64(time.Second))
+}
+
+// evictIdle drops the buckets that have refilled completely; the caller holds the mutex
+func (l *TokenBucketLimiter) evictIdle(now time.Time) {
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.lastRefill) >= l.idleAfter {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastEviction = now
+}
+
+// SetRateLimiter enables throttling of the chat endpoints per authenticated user or client address
+func (w *WebBFF) SetRateLimiter(limiter RateLimiter) {
+	w.rateLimiter = limiter
+}
+
+// rateLimitKey identifies the client a request is throttled as
+// Session IDs are chosen by the client, so the authenticated user or else the remote address is used
+func rateLimitKey(r *http.Request) string {
+	if user, ok := AuthenticatedUser(r.Context()); ok {
+		return "user:" + user.ID
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "addr:" + host
+}
+
+// allowRequest applies the rate limiter to the request's client, writing a 429 response when it is exceeded
+func (w *WebBFF) allowRequest(rw http.ResponseWriter, r *http.Request) bool {
+	if w.rateLimiter == nil {
+		return true
+	}
+
+	key := rateLimitKey(r)
+	allowed, retryAfter := w.rateLimiter.Allow(key)
+	if allowed {
+		return true
+	}
+
+	// Retry-After is expressed in whole seconds, rounded up so clients never retry too early
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.logger.Warn("Rate limit exceeded", "client", key, "retryAfter", seconds)
+	rw.Header().Set("Retry-After", strconv.Itoa(seconds))
+	http.Error(rw, "Too many requests", http.StatusTooManyRequests)
+	return false
+}
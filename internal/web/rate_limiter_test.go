@@ -0,0 +1,154 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"neuromesh/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a manually advanced clock for rate limiter tests
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestTokenBucketLimiter(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := NewTokenBucketLimiterWithClock(RateLimitConfig{RequestsPerMinute: 6, Burst: 2}, clock.Now)
+
+	t.Run("allows requests within the burst", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			allowed, _ := limiter.Allow("session-a")
+			assert.True(t, allowed)
+		}
+	})
+
+	t.Run("blocks requests over the limit with retry delay", func(t *testing.T) {
+		allowed, retryAfter := limiter.Allow("session-a")
+		assert.False(t, allowed)
+		assert.Equal(t, 10*time.Second, retryAfter)
+	})
+
+	t.Run("keeps sessions independent", func(t *testing.T) {
+		allowed, _ := limiter.Allow("session-b")
+		assert.True(t, allowed)
+	})
+
+	t.Run("refills tokens over time", func(t *testing.T) {
+		clock.Advance(10 * time.Second)
+		allowed, _ := limiter.Allow("session-a")
+		assert.True(t, allowed)
+
+		allowed, _ = limiter.Allow("session-a")
+		assert.False(t, allowed)
+	})
+}
+
+func TestWebBFF_ChatRateLimiting(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+	bff.SetRateLimiter(NewTokenBucketLimiterWithClock(RateLimitConfig{RequestsPerMinute: 2, Burst: 1}, clock.Now))
+	handler := bff.CreateWebServer(":0").Handler
+
+	chat := func(path, sessionID, remoteAddr string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(ChatRequest{SessionID: sessionID, Message: "Hello"})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer(body))
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("allows requests within the limit", func(t *testing.T) {
+		rec := chat("/api/chat", "session-1", "192.0.2.1:40000")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("rejects requests over the limit", func(t *testing.T) {
+		rec := chat("/api/chat", "session-1", "192.0.2.1:40000")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+
+		// The streaming endpoint shares the client's budget
+		rec = chat("/api/chat/stream", "session-1", "192.0.2.1:40000")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	})
+
+	t.Run("does not reset the budget for a new session ID", func(t *testing.T) {
+		rec := chat("/api/chat", "session-2", "192.0.2.1:40001")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	})
+
+	t.Run("does not throttle other clients", func(t *testing.T) {
+		rec := chat("/api/chat", "session-3", "192.0.2.2:40000")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("allows the client again after the retry delay", func(t *testing.T) {
+		clock.Advance(30 * time.Second)
+		rec := chat("/api/chat", "session-1", "192.0.2.1:40000")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestWebBFF_ChatRateLimiting_KeysOnAuthenticatedUser(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+	bff.SetAuthenticator(NewStaticTokenAuthenticator(map[string]string{"alice-token": "alice", "bob-token": "bob"}))
+	bff.SetRateLimiter(NewTokenBucketLimiterWithClock(RateLimitConfig{RequestsPerMinute: 2, Burst: 1}, clock.Now))
+	handler := bff.CreateWebServer(":0").Handler
+
+	chat := func(token, sessionID, remoteAddr string) int {
+		body, err := json.Marshal(ChatRequest{SessionID: sessionID, Message: "Hello"})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/chat", bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, chat("alice-token", "alice-1", "192.0.2.1:40000"))
+	assert.Equal(t, http.StatusTooManyRequests, chat("alice-token", "alice-2", "198.51.100.7:40000"))
+	assert.Equal(t, http.StatusOK, chat("bob-token", "bob-1", "192.0.2.1:40000"))
+}
+
+func TestTokenBucketLimiter_EvictsIdleBuckets(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := NewTokenBucketLimiterWithClock(RateLimitConfig{RequestsPerMinute: 6, Burst: 2}, clock.Now)
+
+	for _, key := range []string{"addr:192.0.2.1", "addr:192.0.2.2", "addr:192.0.2.3"} {
+		allowed, _ := limiter.Allow(key)
+		require.True(t, allowed)
+	}
+	assert.Len(t, limiter.buckets, 3)
+
+	// Two tokens at six per minute refill in 20 seconds; until then every bucket is kept
+	clock.Advance(19 * time.Second)
+	limiter.Allow("addr:192.0.2.1")
+	assert.Len(t, limiter.buckets, 3)
+
+	clock.Advance(10 * time.Second)
+	limiter.Allow("addr:192.0.2.4")
+	assert.Len(t, limiter.buckets, 2)
+	assert.Contains(t, limiter.buckets, "addr:192.0.2.1")
+	assert.Contains(t, limiter.buckets, "addr:192.0.2.4")
+}
@@ -0,0 +1,153 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conversationApp "neuromesh/internal/conversation/application"
+	conversationDomain "neuromesh/internal/conversation/domain"
+	conversationInfra "neuromesh/internal/conversation/infrastructure"
+	"neuromesh/internal/logging"
+	userApp "neuromesh/internal/user/application"
+	userInfra "neuromesh/internal/user/infrastructure"
+	"neuromesh/testHelpers"
+)
+
+func TestConversationAwareWebBFF_SessionKeepsOneConversation(t *testing.T) {
+	ctx := context.Background()
+	testGraph := testHelpers.NewCleanMockGraph()
+	conversationService := conversationApp.NewConversationService(conversationInfra.NewGraphConversationRepository(testGraph))
+	userService := userApp.NewUserService(userInfra.NewGraphUserRepository(testGraph))
+
+	bff := NewConversationAwareWebBFF(&MockAIOrchestrator{}, conversationService, userService, logging.NewNoOpLogger())
+	bff.SetAuthenticator(NewStaticTokenAuthenticator(map[string]string{"user-1-token": "user-1"}))
+	handler := bff.CreateWebServer(":0").Handler
+
+	chat := func(sessionID, message string) WebResponse {
+		body, err := json.Marshal(ChatRequest{SessionID: sessionID, Message: message})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/chat", bytes.NewBuffer(body))
+		req.Header.Set("Authorization", "Bearer user-1-token")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var response WebResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		return response
+	}
+
+	first := chat("web-user-1", "Hello")
+	second := chat("web-user-1", "Count words in hello world")
+	other := chat("web-user-2", "Hello")
+
+	require.NotEmpty(t, first.ConversationID)
+	assert.Equal(t, first.ConversationID, second.ConversationID)
+	assert.NotEqual(t, first.ConversationID, other.ConversationID)
+
+	conversation, err := conversationService.GetConversationWithMessages(ctx, first.ConversationID)
+	require.NoError(t, err)
+	require.Len(t, conversation.Messages, 4)
+	assert.Equal(t, "web-user-1", conversation.SessionID)
+
+	roles := map[conversationDomain.MessageRole]int{}
+	contents := map[string]bool{}
+	for _, message := range conversation.Messages {
+		roles[message.Role]++
+		contents[message.Content] = true
+	}
+	assert.Equal(t, 2, roles[conversationDomain.MessageRoleUser])
+	assert.Equal(t, 2, roles[conversationDomain.MessageRoleAssistant])
+	assert.True(t, contents["Hello"])
+	assert.True(t, contents["Count words in hello world"])
+
+	t.Run("reloads the session conversation on reconnect", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/web-user-1/conversation", nil)
+		req.Header.Set("Authorization", "Bearer user-1-token")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var history SessionConversationResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &history))
+		assert.Equal(t, first.ConversationID, history.ConversationID)
+		assert.Len(t, history.Messages, 4)
+	})
+
+	t.Run("returns 404 for a session without conversation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/web-user-unknown/conversation", nil)
+		req.Header.Set("Authorization", "Bearer user-1-token")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("rejects unauthenticated history reads", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/web-user-1/conversation", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestWebBFF_SessionConversationHandler_RequiresAuthenticator(t *testing.T) {
+	testGraph := testHelpers.NewCleanMockGraph()
+	conversationService := conversationApp.NewConversationService(conversationInfra.NewGraphConversationRepository(testGraph))
+	userService := userApp.NewUserService(userInfra.NewGraphUserRepository(testGraph))
+	bff := NewConversationAwareWebBFF(&MockAIOrchestrator{}, conversationService, userService, logging.NewNoOpLogger())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/sessions/web-user-1/conversation", nil)
+	rec := httptest.NewRecorder()
+	bff.CreateWebServer(":0").Handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestWebBFF_SessionHandler(t *testing.T) {
+	bff := NewWebBFF(&MockAIOrchestrator{}, logging.NewNoOpLogger())
+	bff.SetAuthenticator(NewStaticTokenAuthenticator(map[string]string{"alice-token": "alice"}))
+	handler := bff.CreateWebServer(":0").Handler
+
+	createSession := func(authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/sessions", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("issues unique random session IDs bound to the user", func(t *testing.T) {
+		issued := make(map[string]bool)
+		for i := 0; i < 3; i++ {
+			rec := createSession("Bearer alice-token")
+			require.Equal(t, http.StatusCreated, rec.Code)
+
+			var response SessionResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			_, err := uuid.Parse(response.SessionID)
+			require.NoError(t, err)
+			assert.True(t, sessionIDPattern.MatchString(response.SessionID))
+			assert.False(t, issued[response.SessionID])
+			issued[response.SessionID] = true
+
+			bff.sessionMutex.RLock()
+			session := bff.sessions[response.SessionID]
+			bff.sessionMutex.RUnlock()
+			require.NotNil(t, session)
+			assert.Equal(t, "alice", session.UserID)
+		}
+	})
+
+	t.Run("requires credentials", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, createSession("").Code)
+	})
+}
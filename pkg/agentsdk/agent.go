@@ -0,0 +1,331 @@
+// Package agentsdk connects agents to the NeuroMesh orchestrator
+// The SDK owns registration, heartbeats, status updates and the conversation stream, so an agent only implements Handler
+package agentsdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	pb "neuromesh/internal/api/grpc/api"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultHeartbeatInterval is how often an agent reports that it is alive
+	DefaultHeartbeatInterval = 30 * time.Second
+	// DefaultReconnectInterval is how long an agent waits before re-opening a dropped conversation stream
+	DefaultReconnectInterval = 5 * time.Second
+	// DefaultMaxReconnectInterval caps the backoff between consecutive failed reconnects
+	DefaultMaxReconnectInterval = time.Minute
+	// DefaultMaxConcurrentInstructions is how many instructions an agent handles at once before reporting itself busy
+	DefaultMaxConcurrentInstructions = 10
+)
+
+// Config configures an agent's connection to the orchestrator
+type Config struct {
+	AgentID                   string
+	Name                      string
+	Type                      string
+	Version                   string
+	OrchestratorAddress       string
+	HeartbeatInterval         time.Duration     // Defaults to DefaultHeartbeatInterval
+	ReconnectInterval         time.Duration     // Defaults to DefaultReconnectInterval
+	MaxReconnectInterval      time.Duration     // Defaults to DefaultMaxReconnectInterval
+	MaxConcurrentInstructions int               // Defaults to DefaultMaxConcurrentInstructions
+	DialOptions               []grpc.DialOption // Defaults to insecure transport credentials
+}
+
+// Capability declares a task the agent can perform
+type Capability struct {
+	Name        string
+	Description string
+	Inputs      []string      // Required input parameter names
+	Outputs     []string      // Output names the capability produces
+	Timeout     time.Duration // How long the orchestrator waits for a result, in whole seconds; zero uses its default
+}
+
+// Instruction is a task sent to the agent by the orchestrator
+type Instruction struct {
+	ID            string
+	CorrelationID string
+	Content       string                 // Natural language instruction
+	Parameters    map[string]interface{} // Structured inputs, empty for legacy instructions
+	Context       map[string]interface{} // Remaining instruction context, e.g. plan and step IDs
+}
+
+// Result is the agent's answer to an instruction
+type Result struct {
+	Content string                 // Natural language result
+	Data    map[string]interface{} // Optional structured result values
+}
+
+// Handler implements what an agent does; the SDK handles everything else
+type Handler interface {
+	// Capabilities returns the capabilities registered with the orchestrator
+	Capabilities() []Capability
+	// HandleInstruction performs an instruction; a returned error is reported to the orchestrator as a failure
+	HandleInstruction(ctx context.Context, instruction Instruction) (Result, error)
+}
+
+// Agent connects a Handler to the orchestrator
+type Agent struct {
+	config  Config
+	handler Handler
+	client  pb.OrchestrationServiceClient
+	conn    *grpc.ClientConn
+
+	mu            sync.Mutex
+	sessionID     string
+	active        int            // Instructions being handled
+	status        pb.AgentStatus // Status derived from the workload
+	statusChanged chan struct{}  // Signals runStatus to report a status transition
+
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+}
+
+// New creates an agent serving handler with the given configuration
+func New(config Config, handler Handler) *Agent {
+	if config.HeartbeatInterval <= 0 {
+		config.HeartbeatInterval = DefaultHeartbeatInterval
+	}
+	if config.ReconnectInterval <= 0 {
+		config.ReconnectInterval = DefaultReconnectInterval
+	}
+	if config.MaxReconnectInterval < config.ReconnectInterval {
+		config.MaxReconnectInterval = max(DefaultMaxReconnectInterval, config.ReconnectInterval)
+	}
+	if config.MaxConcurrentInstructions <= 0 {
+		config.MaxConcurrentInstructions = DefaultMaxConcurrentInstructions
+	}
+	if len(config.DialOptions) == 0 {
+		config.DialOptions = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	return &Agent{
+		config:        config,
+		handler:       handler,
+		status:        pb.AgentStatus_AGENT_STATUS_HEALTHY,
+		statusChanged: make(chan struct{}, 1),
+	}
+}
+
+// Config returns the agent's configuration with defaults applied
+func (a *Agent) Config() Config {
+	return a.config
+}
+
+// SessionID returns the session assigned at registration, or an empty string while unregistered
+func (a *Agent) SessionID() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.sessionID
+}
+
+// Start connects to the orchestrator, registers the agent and serves instructions in the background
+// The agent runs until Stop is called or ctx is cancelled
+func (a *Agent) Start(ctx context.Context) error {
+	if a.client == nil {
+		conn, err := grpc.NewClient(a.config.OrchestratorAddress, a.config.DialOptions...)
+		if err != nil {
+			return fmt.Errorf("failed to connect to orchestrator: %w", err)
+		}
+		a.conn = conn
+		a.client = pb.NewOrchestrationServiceClient(conn)
+	}
+
+	if err := a.register(ctx); err != nil {
+		return fmt.Errorf("failed to register: %w", err)
+	}
+
+	ctx, a.cancel = context.WithCancel(ctx)
+	a.running.Add(3)
+	go a.runHeartbeats(ctx)
+	go a.runStatus(ctx)
+	go a.runConversation(ctx)
+
+	log.Printf("✅ Agent %s started with %d capabilities", a.config.AgentID, len(a.handler.Capabilities()))
+	return nil
+}
+
+// Stop stops serving instructions, unregisters the agent and closes the connection
+func (a *Agent) Stop(ctx context.Context) error {
+	if a.cancel != nil {
+		a.cancel()
+		a.running.Wait()
+	}
+
+	var err error
+	if a.SessionID() != "" {
+		err = a.unregister(ctx)
+	}
+	if a.conn != nil {
+		if closeErr := a.conn.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// register registers the agent and its capabilities, replacing any previous session
+func (a *Agent) register(ctx context.Context) error {
+	capabilities := a.handler.Capabilities()
+	pbCapabilities := make([]*pb.AgentCapability, 0, len(capabilities))
+	for _, capability := range capabilities {
+		pbCapabilities = append(pbCapabilities, &pb.AgentCapability{
+			Name:        capability.Name,
+			Description: capability.Description,
+			Inputs:      capability.Inputs,
+			Outputs:     capability.Outputs,
+			// Round up so a sub-second timeout is not sent as the orchestrator default
+			TimeoutSeconds: int32((capability.Timeout + time.Second - 1) / time.Second),
+		})
+	}
+
+	resp, err := a.client.RegisterAgent(ctx, &pb.RegisterAgentRequest{
+		AgentId:      a.config.AgentID,
+		Name:         a.config.Name,
+		Type:         a.config.Type,
+		Capabilities: pbCapabilities,
+		Version:      a.config.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("registration failed: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("registration rejected: %s", resp.Message)
+	}
+
+	a.mu.Lock()
+	a.sessionID = resp.SessionId
+	a.mu.Unlock()
+
+	log.Printf("🎯 Agent %s registered with session ID: %s", a.config.AgentID, resp.SessionId)
+	return nil
+}
+
+// unregister removes the agent from the orchestrator
+func (a *Agent) unregister(ctx context.Context) error {
+	_, err := a.client.UnregisterAgent(ctx, &pb.UnregisterAgentRequest{
+		AgentId:   a.config.AgentID,
+		SessionId: a.SessionID(),
+		Reason:    "Graceful shutdown",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unregister agent %s: %w", a.config.AgentID, err)
+	}
+
+	a.mu.Lock()
+	a.sessionID = ""
+	a.mu.Unlock()
+	return nil
+}
+
+// runHeartbeats reports the agent alive immediately and then every HeartbeatInterval
+func (a *Agent) runHeartbeats(ctx context.Context) {
+	defer a.running.Done()
+
+	ticker := time.NewTicker(a.config.HeartbeatInterval)
+	defer ticker.Stop()
+
+	a.sendHeartbeat(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			a.sendHeartbeat(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sendHeartbeat reports the agent alive; failures are logged and retried on the next tick
+func (a *Agent) sendHeartbeat(ctx context.Context) {
+	_, err := a.client.Heartbeat(ctx, &pb.HeartbeatRequest{
+		AgentId:   a.config.AgentID,
+		SessionId: a.SessionID(),
+		Status:    a.currentStatus(),
+	})
+	if err != nil && ctx.Err() == nil {
+		log.Printf("❌ Heartbeat failed for agent %s: %v", a.config.AgentID, err)
+		if isAgentUnknown(err) {
+			a.reregister(ctx)
+		}
+	}
+}
+
+// reregister registers the agent again after the orchestrator reported it unknown, e.g. after an orchestrator restart
+func (a *Agent) reregister(ctx context.Context) {
+	if err := a.register(ctx); err != nil && ctx.Err() == nil {
+		log.Printf("❌ Re-registration failed for agent %s: %v", a.config.AgentID, err)
+	}
+}
+
+// isAgentUnknown reports whether the orchestrator rejected a call because the agent is not registered
+func isAgentUnknown(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
+
+// runStatus reports the agent's status once it is serving and again on every workload transition
+func (a *Agent) runStatus(ctx context.Context) {
+	defer a.running.Done()
+
+	a.sendStatus(ctx, a.currentStatus())
+	for {
+		select {
+		case <-a.statusChanged:
+			a.sendStatus(ctx, a.currentStatus())
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// currentStatus returns the status derived from the agent's workload
+func (a *Agent) currentStatus() pb.AgentStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.status
+}
+
+// trackInstruction adjusts the number of instructions in flight by delta
+// The agent is busy while more than MaxConcurrentInstructions are in flight and healthy again once back within the limit
+func (a *Agent) trackInstruction(delta int) {
+	a.mu.Lock()
+	a.active += delta
+	status := pb.AgentStatus_AGENT_STATUS_HEALTHY
+	if a.active > a.config.MaxConcurrentInstructions {
+		status = pb.AgentStatus_AGENT_STATUS_BUSY
+	}
+	changed := status != a.status
+	a.status = status
+	a.mu.Unlock()
+
+	if changed {
+		// runStatus reads the latest status, so a pending signal already covers this transition
+		select {
+		case a.statusChanged <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// sendStatus reports the agent's status through the dedicated status endpoint
+func (a *Agent) sendStatus(ctx context.Context, status pb.AgentStatus) {
+	_, err := a.client.UpdateAgentStatus(ctx, &pb.UpdateAgentStatusRequest{
+		AgentId:   a.config.AgentID,
+		SessionId: a.SessionID(),
+		Status:    status,
+		Timestamp: timestamppb.Now(),
+	})
+	if err != nil && ctx.Err() == nil {
+		log.Printf("❌ Status update failed for agent %s: %v", a.config.AgentID, err)
+	}
+}
@@ -0,0 +1,451 @@
+package agentsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pb "neuromesh/internal/api/grpc/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// fakeOrchestrationClient records lifecycle calls and serves a single conversation stream
+type fakeOrchestrationClient struct {
+	pb.OrchestrationServiceClient
+
+	mu            sync.Mutex
+	registrations []*pb.RegisterAgentRequest
+	unregistered  []*pb.UnregisterAgentRequest
+	statuses      []pb.AgentStatus
+	opened        int
+
+	heartbeats chan *pb.HeartbeatRequest
+	stream     *fakeConversationStream
+}
+
+func newFakeOrchestrationClient() *fakeOrchestrationClient {
+	return &fakeOrchestrationClient{
+		heartbeats: make(chan *pb.HeartbeatRequest, 100),
+		stream: &fakeConversationStream{
+			incoming: make(chan *pb.ConversationMessage, 10),
+			sent:     make(chan *pb.ConversationMessage, 10),
+			failures: make(chan error, 10),
+		},
+	}
+}
+
+func (c *fakeOrchestrationClient) RegisterAgent(ctx context.Context, in *pb.RegisterAgentRequest, opts ...grpc.CallOption) (*pb.RegisterAgentResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.registrations = append(c.registrations, in)
+	return &pb.RegisterAgentResponse{Success: true, SessionId: "session-" + in.AgentId}, nil
+}
+
+func (c *fakeOrchestrationClient) UnregisterAgent(ctx context.Context, in *pb.UnregisterAgentRequest, opts ...grpc.CallOption) (*pb.UnregisterAgentResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.unregistered = append(c.unregistered, in)
+	return &pb.UnregisterAgentResponse{Success: true}, nil
+}
+
+func (c *fakeOrchestrationClient) Heartbeat(ctx context.Context, in *pb.HeartbeatRequest, opts ...grpc.CallOption) (*pb.HeartbeatResponse, error) {
+	c.heartbeats <- in
+	return &pb.HeartbeatResponse{Success: true}, nil
+}
+
+func (c *fakeOrchestrationClient) UpdateAgentStatus(ctx context.Context, in *pb.UpdateAgentStatusRequest, opts ...grpc.CallOption) (*pb.UpdateAgentStatusResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.statuses = append(c.statuses, in.Status)
+	return &pb.UpdateAgentStatusResponse{Success: true}, nil
+}
+
+func (c *fakeOrchestrationClient) OpenConversation(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[pb.ConversationMessage, pb.ConversationMessage], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.opened++
+	c.stream.ctx = ctx
+	return c.stream, nil
+}
+
+// statusUpdates returns the statuses reported so far
+func (c *fakeOrchestrationClient) statusUpdates() []pb.AgentStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]pb.AgentStatus(nil), c.statuses...)
+}
+
+// counts returns how often the agent registered and opened its conversation stream
+func (c *fakeOrchestrationClient) counts() (registrations, opened int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.registrations), c.opened
+}
+
+// fakeConversationStream delivers incoming messages to the agent and captures its replies
+// An error pushed to failures breaks the stream once, as a dropped connection would
+type fakeConversationStream struct {
+	grpc.ClientStream
+
+	ctx      context.Context
+	incoming chan *pb.ConversationMessage
+	sent     chan *pb.ConversationMessage
+	failures chan error
+}
+
+func (s *fakeConversationStream) Recv() (*pb.ConversationMessage, error) {
+	select {
+	case msg := <-s.incoming:
+		return msg, nil
+	case err := <-s.failures:
+		return nil, err
+	case <-s.ctx.Done():
+		return nil, io.EOF
+	}
+}
+
+func (s *fakeConversationStream) Send(msg *pb.ConversationMessage) error {
+	s.sent <- msg
+	return nil
+}
+
+func (s *fakeConversationStream) CloseSend() error {
+	return nil
+}
+
+// wordCountHandler counts the words of the "text" parameter and fails instructions without one
+type wordCountHandler struct{}
+
+func (wordCountHandler) Capabilities() []Capability {
+	return []Capability{{Name: "word-count", Description: "Counts words", Inputs: []string{"text"}, Outputs: []string{"word_count"}, Timeout: 90 * time.Second}}
+}
+
+func (wordCountHandler) HandleInstruction(ctx context.Context, instruction Instruction) (Result, error) {
+	text, ok := instruction.Parameters["text"].(string)
+	if !ok {
+		return Result{}, errors.New("text parameter is required")
+	}
+	count := len(strings.Fields(text))
+	return Result{Content: "counted", Data: map[string]interface{}{"word_count": count, "step_id": instruction.Context["step_id"]}}, nil
+}
+
+// blockingHandler holds every instruction until release is closed
+type blockingHandler struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (blockingHandler) Capabilities() []Capability {
+	return []Capability{{Name: "slow-task", Description: "Takes its time"}}
+}
+
+func (h blockingHandler) HandleInstruction(ctx context.Context, instruction Instruction) (Result, error) {
+	h.started <- struct{}{}
+	<-h.release
+	return Result{Content: "done"}, nil
+}
+
+// startTestAgent starts an agent against a fake orchestrator and stops it when the test ends
+func startTestAgent(t *testing.T, config Config) (*Agent, *fakeOrchestrationClient) {
+	t.Helper()
+	return startTestAgentWithHandler(t, config, wordCountHandler{})
+}
+
+// startTestAgentWithHandler starts an agent serving handler against a fake orchestrator
+func startTestAgentWithHandler(t *testing.T, config Config, handler Handler) (*Agent, *fakeOrchestrationClient) {
+	t.Helper()
+	client := newFakeOrchestrationClient()
+	agent := New(config, handler)
+	agent.client = client
+
+	require.NoError(t, agent.Start(context.Background()))
+	t.Cleanup(func() { agent.Stop(context.Background()) })
+	return agent, client
+}
+
+// receiveReply waits for the agent's next reply on the conversation stream
+func receiveReply(t *testing.T, client *fakeOrchestrationClient) *pb.ConversationMessage {
+	t.Helper()
+	select {
+	case reply := <-client.stream.sent:
+		return reply
+	case <-time.After(time.Second):
+		t.Fatal("agent did not reply")
+		return nil
+	}
+}
+
+func TestAgent_DispatchesInstructions(t *testing.T) {
+	agent, client := startTestAgent(t, Config{AgentID: "text-processor", Name: "Text Processor", Type: "text-processor"})
+
+	require.Len(t, client.registrations, 1)
+	assert.Equal(t, "word-count", client.registrations[0].Capabilities[0].Name)
+	assert.Equal(t, []string{"text"}, client.registrations[0].Capabilities[0].Inputs)
+	assert.Equal(t, int32(90), client.registrations[0].Capabilities[0].TimeoutSeconds)
+	assert.Equal(t, "session-text-processor", agent.SessionID())
+
+	t.Run("replies to an instruction with its completion", func(t *testing.T) {
+		instructionContext, err := structpb.NewStruct(map[string]interface{}{
+			"step_id":            "step-1",
+			parametersContextKey: map[string]interface{}{"text": "hello big world"},
+		})
+		require.NoError(t, err)
+		client.stream.incoming <- &pb.ConversationMessage{
+			MessageId:     "msg-1",
+			CorrelationId: "corr-1",
+			Type:          pb.MessageType_MESSAGE_TYPE_INSTRUCTION,
+			Content:       "Count the words",
+			Context:       instructionContext,
+		}
+
+		reply := receiveReply(t, client)
+		assert.Equal(t, pb.MessageType_MESSAGE_TYPE_COMPLETION, reply.Type)
+		assert.Equal(t, "corr-1", reply.CorrelationId)
+		assert.Equal(t, "text-processor", reply.FromId)
+		assert.Equal(t, orchestratorID, reply.ToId)
+		assert.Equal(t, "counted", reply.Content)
+		assert.Equal(t, map[string]interface{}{"word_count": float64(3), "step_id": "step-1"}, reply.Context.AsMap())
+	})
+
+	t.Run("reports handler failures as errors", func(t *testing.T) {
+		client.stream.incoming <- &pb.ConversationMessage{
+			MessageId:     "msg-2",
+			CorrelationId: "corr-2",
+			Type:          pb.MessageType_MESSAGE_TYPE_INSTRUCTION,
+			Content:       "Count the words",
+		}
+
+		reply := receiveReply(t, client)
+		assert.Equal(t, pb.MessageType_MESSAGE_TYPE_ERROR, reply.Type)
+		assert.Equal(t, "corr-2", reply.CorrelationId)
+		assert.Equal(t, "text parameter is required", reply.Content)
+	})
+
+	t.Run("does not answer messages other than instructions", func(t *testing.T) {
+		client.stream.incoming <- &pb.ConversationMessage{MessageId: "msg-3", CorrelationId: "corr-3", Type: pb.MessageType_MESSAGE_TYPE_STATUS_UPDATE}
+		parameters, err := structpb.NewStruct(map[string]interface{}{parametersContextKey: map[string]interface{}{"text": "next"}})
+		require.NoError(t, err)
+		client.stream.incoming <- &pb.ConversationMessage{MessageId: "msg-4", CorrelationId: "corr-4", Type: pb.MessageType_MESSAGE_TYPE_INSTRUCTION, Context: parameters}
+
+		assert.Equal(t, "corr-4", receiveReply(t, client).CorrelationId)
+	})
+}
+
+func TestAgent_ContinuesOrchestratorTrace(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	// The global provider cannot be reset to its initial delegate, so later tests get a no-op provider
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	_, client := startTestAgent(t, Config{AgentID: "text-processor", Name: "Text Processor", Type: "text-processor"})
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	instructionContext, err := structpb.NewStruct(map[string]interface{}{
+		"traceparent":        "00-" + traceID + "-00f067aa0ba902b7-01",
+		parametersContextKey: map[string]interface{}{"text": "hello world"},
+	})
+	require.NoError(t, err)
+	client.stream.incoming <- &pb.ConversationMessage{
+		MessageId:     "msg-1",
+		CorrelationId: "corr-1",
+		Type:          pb.MessageType_MESSAGE_TYPE_INSTRUCTION,
+		Content:       "Count the words",
+		Context:       instructionContext,
+	}
+	reply := receiveReply(t, client)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "agent.handle_instruction", spans[0].Name)
+	assert.Equal(t, traceID, spans[0].SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent.SpanID().String())
+
+	// The reply hands the agent's span back so the orchestrator continues the same trace
+	assert.Equal(t, "00-"+traceID+"-"+spans[0].SpanContext.SpanID().String()+"-01", reply.Context.AsMap()["traceparent"])
+	assert.Equal(t, float64(2), reply.Context.AsMap()["word_count"])
+}
+
+func TestAgent_SchedulesHeartbeats(t *testing.T) {
+	t.Run("sends a heartbeat as soon as it starts", func(t *testing.T) {
+		_, client := startTestAgent(t, Config{AgentID: "slow-agent", HeartbeatInterval: time.Hour})
+
+		select {
+		case heartbeat := <-client.heartbeats:
+			assert.Equal(t, "slow-agent", heartbeat.AgentId)
+			assert.Equal(t, "session-slow-agent", heartbeat.SessionId)
+			assert.Equal(t, pb.AgentStatus_AGENT_STATUS_HEALTHY, heartbeat.Status)
+		case <-time.After(time.Second):
+			t.Fatal("no heartbeat was sent on start")
+		}
+	})
+
+	t.Run("repeats heartbeats every interval until stopped", func(t *testing.T) {
+		agent, client := startTestAgent(t, Config{AgentID: "fast-agent", HeartbeatInterval: 10 * time.Millisecond})
+
+		for i := 0; i < 3; i++ {
+			select {
+			case <-client.heartbeats:
+			case <-time.After(time.Second):
+				t.Fatalf("expected at least 3 heartbeats, got %d", i)
+			}
+		}
+
+		require.NoError(t, agent.Stop(context.Background()))
+		for len(client.heartbeats) > 0 {
+			<-client.heartbeats
+		}
+		time.Sleep(30 * time.Millisecond)
+		assert.Empty(t, client.heartbeats, "no heartbeats may be sent after Stop")
+
+		require.Len(t, client.unregistered, 1)
+		assert.Equal(t, "session-fast-agent", client.unregistered[0].SessionId)
+		assert.Empty(t, agent.SessionID())
+		assert.Contains(t, client.statuses, pb.AgentStatus_AGENT_STATUS_HEALTHY)
+	})
+}
+
+func TestAgent_ReconnectsDroppedStreams(t *testing.T) {
+	// countWords waits for the stream to be re-opened and checks that it serves instructions again
+	countWords := func(t *testing.T, client *fakeOrchestrationClient, correlationID string) {
+		t.Helper()
+		require.Eventually(t, func() bool {
+			_, opened := client.counts()
+			return opened == 2
+		}, time.Second, time.Millisecond, "agent did not re-open its stream")
+
+		parameters, err := structpb.NewStruct(map[string]interface{}{parametersContextKey: map[string]interface{}{"text": "still here"}})
+		require.NoError(t, err)
+		client.stream.incoming <- &pb.ConversationMessage{MessageId: "msg-" + correlationID, CorrelationId: correlationID, Type: pb.MessageType_MESSAGE_TYPE_INSTRUCTION, Context: parameters}
+
+		reply := receiveReply(t, client)
+		assert.Equal(t, pb.MessageType_MESSAGE_TYPE_COMPLETION, reply.Type)
+		assert.Equal(t, correlationID, reply.CorrelationId)
+	}
+
+	t.Run("re-opens the stream after it errors", func(t *testing.T) {
+		_, client := startTestAgent(t, Config{AgentID: "flaky-agent", ReconnectInterval: time.Millisecond})
+
+		client.stream.failures <- status.Error(codes.Unavailable, "connection reset")
+		countWords(t, client, "corr-after-drop")
+
+		registrations, _ := client.counts()
+		assert.Equal(t, 1, registrations, "a known agent must not register again")
+	})
+
+	t.Run("registers again when the orchestrator no longer knows the agent", func(t *testing.T) {
+		agent, client := startTestAgent(t, Config{AgentID: "forgotten-agent", ReconnectInterval: time.Millisecond})
+
+		client.stream.failures <- status.Error(codes.NotFound, "agent not found")
+		countWords(t, client, "corr-after-restart")
+
+		registrations, _ := client.counts()
+		assert.Equal(t, 2, registrations)
+		assert.Equal(t, "session-forgotten-agent", agent.SessionID())
+	})
+}
+
+func TestAgent_ReconnectDelay(t *testing.T) {
+	agent := New(Config{AgentID: "backoff-agent", ReconnectInterval: time.Second, MaxReconnectInterval: 5 * time.Second}, wordCountHandler{})
+
+	for _, tc := range []struct {
+		failures int
+		ceiling  time.Duration
+	}{
+		{failures: 1, ceiling: time.Second},
+		{failures: 2, ceiling: 2 * time.Second},
+		{failures: 3, ceiling: 4 * time.Second},
+		{failures: 4, ceiling: 5 * time.Second},
+		{failures: 50, ceiling: 5 * time.Second},
+	} {
+		for i := 0; i < 20; i++ {
+			delay := agent.reconnectDelay(tc.failures)
+			assert.GreaterOrEqual(t, delay, tc.ceiling/2, "failure %d", tc.failures)
+			assert.LessOrEqual(t, delay, tc.ceiling, "failure %d", tc.failures)
+		}
+	}
+}
+
+func TestAgent_ReportsWorkloadStatus(t *testing.T) {
+	handler := blockingHandler{started: make(chan struct{}, 3), release: make(chan struct{})}
+	agent, client := startTestAgentWithHandler(t, Config{AgentID: "busy-agent", MaxConcurrentInstructions: 2}, handler)
+
+	for i := 0; i < 3; i++ {
+		client.stream.incoming <- &pb.ConversationMessage{MessageId: fmt.Sprintf("msg-%d", i), CorrelationId: fmt.Sprintf("corr-%d", i), Type: pb.MessageType_MESSAGE_TYPE_INSTRUCTION}
+		select {
+		case <-handler.started:
+		case <-time.After(time.Second):
+			t.Fatalf("instruction %d was not handled concurrently", i)
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		statuses := client.statusUpdates()
+		return len(statuses) > 0 && statuses[len(statuses)-1] == pb.AgentStatus_AGENT_STATUS_BUSY
+	}, time.Second, time.Millisecond, "agent over its concurrency limit must report busy")
+	assert.Equal(t, pb.AgentStatus_AGENT_STATUS_BUSY, agent.currentStatus())
+
+	close(handler.release)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, pb.MessageType_MESSAGE_TYPE_COMPLETION, receiveReply(t, client).Type)
+	}
+
+	require.Eventually(t, func() bool {
+		statuses := client.statusUpdates()
+		return statuses[len(statuses)-1] == pb.AgentStatus_AGENT_STATUS_HEALTHY
+	}, time.Second, time.Millisecond, "drained agent must report healthy again")
+	assert.Equal(t, pb.AgentStatus_AGENT_STATUS_HEALTHY, agent.currentStatus())
+}
+
+// progressHandler reports two updates before its result
+type progressHandler struct{}
+
+func (progressHandler) Capabilities() []Capability {
+	return []Capability{{Name: "long-task", Description: "Reports progress"}}
+}
+
+func (progressHandler) HandleInstruction(ctx context.Context, instruction Instruction) (Result, error) {
+	for _, update := range []string{"started", "half way"} {
+		if err := ReportProgress(ctx, update); err != nil {
+			return Result{}, err
+		}
+	}
+	return Result{Content: "done"}, nil
+}
+
+func TestAgent_ReportsProgress(t *testing.T) {
+	_, client := startTestAgentWithHandler(t, Config{AgentID: "worker", Name: "Worker", Type: "worker"}, progressHandler{})
+
+	client.stream.incoming <- &pb.ConversationMessage{MessageId: "msg-1", CorrelationId: "corr-1", Type: pb.MessageType_MESSAGE_TYPE_INSTRUCTION}
+
+	for i, content := range []string{"started", "half way"} {
+		update := receiveReply(t, client)
+		assert.Equal(t, pb.MessageType_MESSAGE_TYPE_COMPLETION, update.Type)
+		assert.Equal(t, "corr-1", update.CorrelationId)
+		assert.Equal(t, content, update.Content)
+		assert.Equal(t, map[string]interface{}{replyStatusContextKey: replyStatusInProgress, "sequence": float64(i + 1)}, update.Context.AsMap())
+	}
+
+	final := receiveReply(t, client)
+	assert.Equal(t, "done", final.Content)
+	assert.Nil(t, final.Context)
+
+	t.Run("requires an instruction context", func(t *testing.T) {
+		assert.Error(t, ReportProgress(context.Background(), "orphan"))
+	})
+}
@@ -0,0 +1,234 @@
+package agentsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "neuromesh/internal/api/grpc/api"
+	"neuromesh/internal/tracing"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// parametersContextKey is the instruction context key carrying the orchestrator's structured parameters
+const parametersContextKey = "parameters"
+
+// orchestratorID addresses replies on the conversation stream
+const orchestratorID = "orchestrator"
+
+// runConversation serves the conversation stream, re-opening it with jittered exponential backoff whenever it drops
+// An agent the orchestrator no longer knows is registered again before the stream is re-opened
+func (a *Agent) runConversation(ctx context.Context) {
+	defer a.running.Done()
+
+	failures := 0
+	for {
+		opened, err := a.converse(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if opened {
+			failures = 0
+		}
+		failures++
+
+		delay := a.reconnectDelay(failures)
+		log.Printf("❌ Conversation stream for agent %s dropped: %v; reconnecting in %s", a.config.AgentID, err, delay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		if isAgentUnknown(err) {
+			a.reregister(ctx)
+		}
+	}
+}
+
+// reconnectDelay doubles ReconnectInterval for each consecutive failure up to MaxReconnectInterval
+// The delay is jittered between half and all of that value so agents dropped together do not reconnect together
+func (a *Agent) reconnectDelay(failures int) time.Duration {
+	delay := a.config.ReconnectInterval
+	for i := 1; i < failures && delay < a.config.MaxReconnectInterval; i++ {
+		delay *= 2
+	}
+	delay = min(delay, a.config.MaxReconnectInterval)
+
+	half := delay / 2
+	return half + time.Duration(rand.Int64N(int64(delay-half)+1))
+}
+
+// converse opens the conversation stream and answers instructions until the stream fails
+// opened reports whether the stream was established, which resets the reconnect backoff
+func (a *Agent) converse(ctx context.Context) (opened bool, err error) {
+	// The orchestrator identifies the stream's agent from metadata
+	streamCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("agent-id", a.config.AgentID))
+	stream, err := a.client.OpenConversation(streamCtx)
+	if err != nil {
+		return false, fmt.Errorf("failed to open conversation stream: %w", err)
+	}
+
+	log.Printf("✅ Conversation stream established for agent %s", a.config.AgentID)
+
+	// Instructions are handled concurrently; gRPC streams allow a single sender at a time
+	var sendMutex sync.Mutex
+	defer func() {
+		sendMutex.Lock()
+		defer sendMutex.Unlock()
+		stream.CloseSend()
+	}()
+	send := func(reply *pb.ConversationMessage) error {
+		sendMutex.Lock()
+		defer sendMutex.Unlock()
+		return stream.Send(reply)
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return true, fmt.Errorf("failed to receive message: %w", err)
+		}
+		if msg.Type != pb.MessageType_MESSAGE_TYPE_INSTRUCTION {
+			log.Printf("⚠️ Agent %s ignored unexpected %v message %s", a.config.AgentID, msg.Type, msg.MessageId)
+			continue
+		}
+
+		a.trackInstruction(1)
+		a.running.Add(1)
+		go func() {
+			defer a.running.Done()
+			defer a.trackInstruction(-1)
+
+			response := a.dispatch(ctx, msg, send)
+			if err := send(response); err != nil && ctx.Err() == nil {
+				log.Printf("❌ Agent %s failed to send response to %s: %v", a.config.AgentID, msg.MessageId, err)
+			}
+		}()
+	}
+}
+
+// dispatch hands an instruction to the handler and builds the completion or error reply
+// send delivers the in-progress updates the handler reports before its result
+// The handler runs in a span continuing the orchestrator's trace, whose context travels back with the reply
+func (a *Agent) dispatch(ctx context.Context, msg *pb.ConversationMessage, send func(*pb.ConversationMessage) error) *pb.ConversationMessage {
+	ctx, span := tracing.Start(tracing.Extract(ctx, msg.GetContext().AsMap()), "agent.handle_instruction", trace.WithAttributes(
+		attribute.String("agent.id", a.config.AgentID),
+		attribute.String("correlation.id", msg.CorrelationId),
+	))
+	var err error
+	defer func() { tracing.End(span, err) }()
+
+	ctx = context.WithValue(ctx, progressReporterKey{}, &progressReporter{agent: a, instruction: msg, send: send})
+	result, err := a.handler.HandleInstruction(ctx, newInstruction(msg))
+	if err != nil {
+		return a.reply(msg, pb.MessageType_MESSAGE_TYPE_ERROR, err.Error(), traceOnlyData(ctx))
+	}
+
+	data, err := replyData(ctx, result.Data)
+	if err != nil {
+		return a.reply(msg, pb.MessageType_MESSAGE_TYPE_ERROR, fmt.Sprintf("failed to encode result data: %v", err), traceOnlyData(ctx))
+	}
+	return a.reply(msg, pb.MessageType_MESSAGE_TYPE_COMPLETION, result.Content, data)
+}
+
+// replyData encodes a reply's data together with the trace context of ctx; replies without either carry no context
+func replyData(ctx context.Context, data map[string]interface{}) (*structpb.Struct, error) {
+	withTrace := make(map[string]interface{}, len(data)+2)
+	for key, value := range data {
+		withTrace[key] = value
+	}
+	tracing.Inject(ctx, withTrace)
+	if len(withTrace) == 0 {
+		return nil, nil
+	}
+	return structpb.NewStruct(withTrace)
+}
+
+// traceOnlyData is the context of an error reply, which carries nothing but the trace context
+func traceOnlyData(ctx context.Context) *structpb.Struct {
+	data, _ := replyData(ctx, nil)
+	return data
+}
+
+// reply builds a message answering msg on the conversation stream
+func (a *Agent) reply(msg *pb.ConversationMessage, messageType pb.MessageType, content string, data *structpb.Struct) *pb.ConversationMessage {
+	return &pb.ConversationMessage{
+		MessageId:     uuid.New().String(),
+		CorrelationId: msg.CorrelationId,
+		FromId:        a.config.AgentID,
+		ToId:          orchestratorID,
+		Type:          messageType,
+		Content:       content,
+		Context:       data,
+		Timestamp:     timestamppb.Now(),
+	}
+}
+
+// Agent replies marked with this status are updates; the orchestrator keeps waiting for the final reply
+const (
+	replyStatusContextKey = "status"
+	replyStatusInProgress = "IN_PROGRESS"
+)
+
+type progressReporterKey struct{}
+
+// progressReporter sends the in-progress updates of one instruction, numbering them in order
+type progressReporter struct {
+	agent       *Agent
+	instruction *pb.ConversationMessage
+	send        func(*pb.ConversationMessage) error
+	sequence    atomic.Int64
+}
+
+// ReportProgress sends an in-progress update for the instruction handled with ctx
+// Long-running handlers call it with the context passed to HandleInstruction; the orchestrator records the update
+// and keeps waiting for the instruction's Result
+func ReportProgress(ctx context.Context, content string) error {
+	reporter, ok := ctx.Value(progressReporterKey{}).(*progressReporter)
+	if !ok {
+		return errors.New("progress can only be reported with the context of an instruction")
+	}
+
+	data, err := structpb.NewStruct(map[string]interface{}{
+		replyStatusContextKey: replyStatusInProgress,
+		"sequence":            reporter.sequence.Add(1),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode progress update: %w", err)
+	}
+	if err := reporter.send(reporter.agent.reply(reporter.instruction, pb.MessageType_MESSAGE_TYPE_COMPLETION, content, data)); err != nil {
+		return fmt.Errorf("failed to send progress update: %w", err)
+	}
+	return nil
+}
+
+// newInstruction converts an instruction message, separating its structured parameters from the rest of its context
+func newInstruction(msg *pb.ConversationMessage) Instruction {
+	instructionContext := msg.GetContext().AsMap()
+	parameters, _ := instructionContext[parametersContextKey].(map[string]interface{})
+	delete(instructionContext, parametersContextKey)
+	if parameters == nil {
+		parameters = make(map[string]interface{})
+	}
+
+	return Instruction{
+		ID:            msg.MessageId,
+		CorrelationID: msg.CorrelationId,
+		Content:       msg.Content,
+		Parameters:    parameters,
+		Context:       instructionContext,
+	}
+}
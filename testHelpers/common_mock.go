@@ -3,6 +3,8 @@ package testHelpers
 import (
 	"context"
 
+	"neuromesh/internal/logging"
+
 	"github.com/stretchr/testify/mock"
 )
 
@@ -40,6 +42,11 @@ func (m *MockLogger) Warn(msg string, fields ...interface{}) {
 	m.Called(args...)
 }
 
+// With returns the same mock so expectations keep matching on the call arguments
+func (m *MockLogger) With(fields ...interface{}) logging.Logger {
+	return m
+}
+
 // MockAIOrchestrator provides a testify-based mock for AI orchestrator operations
 type MockAIOrchestrator struct {
 	mock.Mock
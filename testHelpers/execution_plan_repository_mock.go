@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sync"
 
+	"neuromesh/internal/graph"
 	"neuromesh/internal/planning/domain"
 )
 
@@ -13,7 +14,8 @@ type MockExecutionPlanRepository struct {
 	mu            sync.RWMutex
 	plans         map[string]*domain.ExecutionPlan
 	steps         map[string][]*domain.ExecutionStep
-	analysisLinks map[string]string // analysisID -> planID
+	analysisLinks map[string]string                     // analysisID -> planID
+	persisted     map[string]domain.ExecutionPlanStatus // Last written status, as plans are shared with callers
 	calls         []string
 }
 
@@ -23,6 +25,7 @@ func NewMockExecutionPlanRepository() *MockExecutionPlanRepository {
 		plans:         make(map[string]*domain.ExecutionPlan),
 		steps:         make(map[string][]*domain.ExecutionStep),
 		analysisLinks: make(map[string]string),
+		persisted:     make(map[string]domain.ExecutionPlanStatus),
 		calls:         make([]string, 0),
 	}
 }
@@ -34,6 +37,7 @@ func (m *MockExecutionPlanRepository) Create(ctx context.Context, plan *domain.E
 
 	m.calls = append(m.calls, fmt.Sprintf("Create(%s)", plan.ID))
 	m.plans[plan.ID] = plan
+	m.persisted[plan.ID] = plan.Status
 
 	// Store steps separately
 	if len(plan.Steps) > 0 {
@@ -46,29 +50,36 @@ func (m *MockExecutionPlanRepository) Create(ctx context.Context, plan *domain.E
 
 // GetByID retrieves an execution plan by ID
 func (m *MockExecutionPlanRepository) GetByID(ctx context.Context, id string) (*domain.ExecutionPlan, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
 	m.calls = append(m.calls, fmt.Sprintf("GetByID(%s)", id))
 
-	plan, exists := m.plans[id]
+	return m.loadPlan(id)
+}
+
+// loadPlan returns a copy of the stored plan with its steps loaded; callers must hold m.mu
+// Callers get their own copy, like a plan read from the graph, so they never mutate the stored one
+func (m *MockExecutionPlanRepository) loadPlan(id string) (*domain.ExecutionPlan, error) {
+	stored, exists := m.plans[id]
 	if !exists {
-		return nil, fmt.Errorf("execution plan not found: %s", id)
+		return nil, fmt.Errorf("execution plan %s not found: %w", id, graph.ErrNodeNotFound)
 	}
 
-	// Load steps
+	plan := *stored
+	plan.Steps = nil
 	if steps, hasSteps := m.steps[id]; hasSteps {
 		plan.Steps = make([]*domain.ExecutionStep, len(steps))
 		copy(plan.Steps, steps)
 	}
 
-	return plan, nil
+	return &plan, nil
 }
 
 // GetByAnalysisID retrieves an execution plan by analysis ID
 func (m *MockExecutionPlanRepository) GetByAnalysisID(ctx context.Context, analysisID string) (*domain.ExecutionPlan, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
 	m.calls = append(m.calls, fmt.Sprintf("GetByAnalysisID(%s)", analysisID))
 
@@ -77,7 +88,7 @@ func (m *MockExecutionPlanRepository) GetByAnalysisID(ctx context.Context, analy
 		return nil, fmt.Errorf("no execution plan found for analysis: %s", analysisID)
 	}
 
-	return m.GetByID(ctx, planID)
+	return m.loadPlan(planID)
 }
 
 // Update updates an execution plan
@@ -92,9 +103,51 @@ func (m *MockExecutionPlanRepository) Update(ctx context.Context, plan *domain.E
 	}
 
 	m.plans[plan.ID] = plan
+	m.persisted[plan.ID] = plan.Status
 	return nil
 }
 
+// UpdateIfStatus updates an existing plan only if its last written status is expected
+func (m *MockExecutionPlanRepository) UpdateIfStatus(ctx context.Context, plan *domain.ExecutionPlan, expected domain.ExecutionPlanStatus) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, fmt.Sprintf("UpdateIfStatus(%s, %s)", plan.ID, expected))
+
+	if _, exists := m.plans[plan.ID]; !exists {
+		return false, fmt.Errorf("execution plan not found: %s", plan.ID)
+	}
+	if m.persisted[plan.ID] != expected {
+		return false, nil
+	}
+
+	m.plans[plan.ID] = plan
+	m.persisted[plan.ID] = plan.Status
+	return true, nil
+}
+
+// CancelPlan cancels a plan and its unfinished steps
+func (m *MockExecutionPlanRepository) CancelPlan(ctx context.Context, planID string) (*domain.ExecutionPlan, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, fmt.Sprintf("CancelPlan(%s)", planID))
+
+	plan, exists := m.plans[planID]
+	if !exists {
+		return nil, fmt.Errorf("execution plan not found: %s", planID)
+	}
+
+	plan.Steps = make([]*domain.ExecutionStep, len(m.steps[planID]))
+	copy(plan.Steps, m.steps[planID])
+	if err := plan.Cancel(); err != nil {
+		return nil, err
+	}
+	m.persisted[planID] = plan.Status
+
+	return plan, nil
+}
+
 // LinkToAnalysis links an execution plan to an analysis
 func (m *MockExecutionPlanRepository) LinkToAnalysis(ctx context.Context, analysisID, planID string) error {
 	m.mu.Lock()
@@ -107,8 +160,8 @@ func (m *MockExecutionPlanRepository) LinkToAnalysis(ctx context.Context, analys
 
 // GetStepsByPlanID retrieves all steps for a plan
 func (m *MockExecutionPlanRepository) GetStepsByPlanID(ctx context.Context, planID string) ([]*domain.ExecutionStep, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
 	m.calls = append(m.calls, fmt.Sprintf("GetStepsByPlanID(%s)", planID))
 
@@ -122,6 +175,24 @@ func (m *MockExecutionPlanRepository) GetStepsByPlanID(ctx context.Context, plan
 	return result, nil
 }
 
+// GetStepByID retrieves a single step by ID
+func (m *MockExecutionPlanRepository) GetStepByID(ctx context.Context, stepID string) (*domain.ExecutionStep, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, fmt.Sprintf("GetStepByID(%s)", stepID))
+
+	for _, steps := range m.steps {
+		for _, step := range steps {
+			if step.ID == stepID {
+				return step, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("step not found: %s", stepID)
+}
+
 // AddStep adds a step to a plan
 func (m *MockExecutionPlanRepository) AddStep(ctx context.Context, step *domain.ExecutionStep) error {
 	m.mu.Lock()
@@ -179,6 +250,86 @@ func (m *MockExecutionPlanRepository) AssignStepToAgent(ctx context.Context, ste
 	return fmt.Errorf("step not found: %s", stepID)
 }
 
+// GetStepStatusCounts counts the steps of a plan by status
+func (m *MockExecutionPlanRepository) GetStepStatusCounts(ctx context.Context, planID string) (map[domain.ExecutionStepStatus]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, fmt.Sprintf("GetStepStatusCounts(%s)", planID))
+
+	counts := make(map[domain.ExecutionStepStatus]int)
+	for _, step := range m.steps[planID] {
+		counts[step.Status]++
+	}
+
+	return counts, nil
+}
+
+// MarkStepEventPublished sets the step's event-published flag, reporting whether it was previously unset
+func (m *MockExecutionPlanRepository) MarkStepEventPublished(ctx context.Context, stepID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, fmt.Sprintf("MarkStepEventPublished(%s)", stepID))
+
+	for _, steps := range m.steps {
+		for _, step := range steps {
+			if step.ID == stepID {
+				if step.EventPublished {
+					return false, nil
+				}
+				step.EventPublished = true
+				return true, nil
+			}
+		}
+	}
+
+	return false, fmt.Errorf("step not found: %s", stepID)
+}
+
+// GetPlansByStatus returns the plans in any of the given statuses with their steps loaded
+func (m *MockExecutionPlanRepository) GetPlansByStatus(ctx context.Context, statuses []domain.ExecutionPlanStatus) ([]*domain.ExecutionPlan, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, fmt.Sprintf("GetPlansByStatus(%v)", statuses))
+
+	var plans []*domain.ExecutionPlan
+	for id, stored := range m.plans {
+		for _, status := range statuses {
+			if stored.Status == status {
+				plan, err := m.loadPlan(id)
+				if err != nil {
+					return nil, err
+				}
+				plans = append(plans, plan)
+				break
+			}
+		}
+	}
+
+	return plans, nil
+}
+
+// GetActiveStepCountByAgent counts assigned and executing steps per agent
+func (m *MockExecutionPlanRepository) GetActiveStepCountByAgent(ctx context.Context) (map[string]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, "GetActiveStepCountByAgent()")
+
+	counts := make(map[string]int)
+	for _, steps := range m.steps {
+		for _, step := range steps {
+			if step.AssignedAgent != "" && step.Status.IsActive() {
+				counts[step.AssignedAgent]++
+			}
+		}
+	}
+
+	return counts, nil
+}
+
 // GetCalls returns all method calls made to this mock (for testing)
 func (m *MockExecutionPlanRepository) GetCalls() []string {
 	m.mu.RLock()
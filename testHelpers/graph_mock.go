@@ -2,6 +2,11 @@ package testHelpers
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
 
 	"neuromesh/internal/graph"
 
@@ -10,7 +15,31 @@ import (
 
 // MockGraph provides a simple in-memory graph for testing
 type MockGraph struct {
-	nodes map[string]map[string]interface{}
+	nodes  map[string]map[string]interface{}
+	edges  []mockEdge
+	schema map[string]bool // Created constraints and indexes, keyed by schemaKey
+}
+
+// schemaKey identifies a constraint or index on a node property
+func schemaKey(kind, nodeType, property string) string {
+	return kind + ":" + nodeType + "." + property
+}
+
+// recordSchema marks a constraint or index as created
+func (m *MockGraph) recordSchema(kind, nodeType, property string) {
+	if m.schema == nil {
+		m.schema = make(map[string]bool)
+	}
+	m.schema[schemaKey(kind, nodeType, property)] = true
+}
+
+// mockEdge records a directed edge between two node keys
+type mockEdge struct {
+	sourceKey  string
+	targetKey  string
+	targetType string
+	targetID   string
+	edgeType   string
 }
 
 // NewMockGraph creates a new mock graph instance with realistic test data
@@ -110,19 +139,62 @@ func (m *TestifyMockGraph) UpdateNode(ctx context.Context, nodeType, nodeID stri
 	return args.Error(0)
 }
 
+func (m *TestifyMockGraph) UpdateNodeIf(ctx context.Context, nodeType, nodeID string, expected, properties map[string]interface{}) (bool, error) {
+	args := m.Called(ctx, nodeType, nodeID, expected, properties)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *TestifyMockGraph) MergeNode(ctx context.Context, nodeType, nodeID string, properties map[string]interface{}) (bool, error) {
+	args := m.Called(ctx, nodeType, nodeID, properties)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *TestifyMockGraph) MergeNodeProperty(ctx context.Context, nodeType, nodeID, key string, value map[string]interface{}) error {
+	args := m.Called(ctx, nodeType, nodeID, key, value)
+	return args.Error(0)
+}
+
 func (m *TestifyMockGraph) DeleteNode(ctx context.Context, nodeType, nodeID string) error {
 	args := m.Called(ctx, nodeType, nodeID)
 	return args.Error(0)
 }
 
+func (m *TestifyMockGraph) DeleteNodesByFilter(ctx context.Context, nodeType string, filters map[string]interface{}) (int, error) {
+	args := m.Called(ctx, nodeType, filters)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *TestifyMockGraph) DeleteOrphanedNodes(ctx context.Context, nodeType, edgeType string, createdBefore time.Time) (int, error) {
+	args := m.Called(ctx, nodeType, edgeType, createdBefore)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *TestifyMockGraph) QueryNodes(ctx context.Context, nodeType string, filters map[string]interface{}) ([]map[string]interface{}, error) {
 	args := m.Called(ctx, nodeType, filters)
 	return args.Get(0).([]map[string]interface{}), args.Error(1)
 }
 
-func (m *TestifyMockGraph) GetStats() map[string]interface{} {
-	args := m.Called()
-	return args.Get(0).(map[string]interface{})
+func (m *TestifyMockGraph) QueryNodesWithOptions(ctx context.Context, nodeType string, filters map[string]interface{}, opts graph.QueryOptions) ([]map[string]interface{}, error) {
+	args := m.Called(ctx, nodeType, filters, opts)
+	return args.Get(0).([]map[string]interface{}), args.Error(1)
+}
+
+func (m *TestifyMockGraph) QueryNodesAdvanced(ctx context.Context, nodeType string, conditions []graph.Condition) ([]map[string]interface{}, error) {
+	args := m.Called(ctx, nodeType, conditions)
+	return args.Get(0).([]map[string]interface{}), args.Error(1)
+}
+
+func (m *TestifyMockGraph) Aggregate(ctx context.Context, aggregation graph.Aggregation) ([]graph.AggregateGroup, error) {
+	args := m.Called(ctx, aggregation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]graph.AggregateGroup), args.Error(1)
+}
+
+func (m *TestifyMockGraph) GetStats(ctx context.Context) (map[string]interface{}, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(map[string]interface{}), args.Error(1)
 }
 
 // TestifyMockGraph missing methods
@@ -141,6 +213,41 @@ func (m *TestifyMockGraph) GetEdgesWithTargets(ctx context.Context, nodeType, no
 	return args.Get(0).([]map[string]interface{}), args.Error(1)
 }
 
+func (m *TestifyMockGraph) TraversePath(ctx context.Context, nodeType, nodeID string, hops []graph.Hop) ([][]map[string]interface{}, error) {
+	args := m.Called(ctx, nodeType, nodeID, hops)
+	return args.Get(0).([][]map[string]interface{}), args.Error(1)
+}
+
+func (m *TestifyMockGraph) FollowChain(ctx context.Context, nodeType, nodeID, edgeType string) ([]map[string]interface{}, error) {
+	args := m.Called(ctx, nodeType, nodeID, edgeType)
+	return args.Get(0).([]map[string]interface{}), args.Error(1)
+}
+
+func (m *TestifyMockGraph) CountNodes(ctx context.Context, nodeType string, filters map[string]interface{}) (int, error) {
+	args := m.Called(ctx, nodeType, filters)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *TestifyMockGraph) QueryNodesByRelated(ctx context.Context, nodeType, edgeType, targetType string, targetFilters map[string]interface{}) ([]map[string]interface{}, error) {
+	args := m.Called(ctx, nodeType, edgeType, targetType, targetFilters)
+	return args.Get(0).([]map[string]interface{}), args.Error(1)
+}
+
+func (m *TestifyMockGraph) CountRelatedNodesByProperty(ctx context.Context, nodeType, nodeID, edgeType, property string) (map[string]int, error) {
+	args := m.Called(ctx, nodeType, nodeID, edgeType, property)
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *TestifyMockGraph) FullTextSearch(ctx context.Context, nodeType, property, query string, conditions []graph.Condition, limit, offset int) ([]map[string]interface{}, error) {
+	args := m.Called(ctx, nodeType, property, query, conditions, limit, offset)
+	return args.Get(0).([]map[string]interface{}), args.Error(1)
+}
+
+func (m *TestifyMockGraph) CreateFullTextIndex(ctx context.Context, nodeType, property string) error {
+	args := m.Called(ctx, nodeType, property)
+	return args.Error(0)
+}
+
 func (m *TestifyMockGraph) UpdateEdge(ctx context.Context, sourceType, sourceID, targetType, targetID, edgeType string, properties map[string]interface{}) error {
 	args := m.Called(ctx, sourceType, sourceID, targetType, targetID, edgeType, properties)
 	return args.Error(0)
@@ -166,6 +273,11 @@ func (m *TestifyMockGraph) DropIndex(ctx context.Context, nodeType, property str
 	return args.Error(0)
 }
 
+func (m *TestifyMockGraph) DropUniqueConstraint(ctx context.Context, nodeType, property string) error {
+	args := m.Called(ctx, nodeType, property)
+	return args.Error(0)
+}
+
 func (m *TestifyMockGraph) HasUniqueConstraint(ctx context.Context, nodeType, property string) (bool, error) {
 	args := m.Called(ctx, nodeType, property)
 	return args.Bool(0), args.Error(1)
@@ -218,6 +330,46 @@ func (m *MockGraph) UpdateNode(ctx context.Context, nodeType, nodeID string, pro
 	return nil // Always return success (compatible with registry tests)
 }
 
+// UpdateNodeIf sets properties on a mock node only if its current properties equal expected
+func (m *MockGraph) UpdateNodeIf(ctx context.Context, nodeType, nodeID string, expected, properties map[string]interface{}) (bool, error) {
+	node, exists := m.nodes[nodeType+":"+nodeID]
+	if !exists {
+		return false, nil
+	}
+	for k, v := range expected {
+		if node[k] != v {
+			return false, nil
+		}
+	}
+	for k, v := range properties {
+		node[k] = v
+	}
+	return true, nil
+}
+
+// MergeNode adds a node unless one with the same ID exists, reporting whether it did
+func (m *MockGraph) MergeNode(ctx context.Context, nodeType, nodeID string, properties map[string]interface{}) (bool, error) {
+	if _, exists := m.nodes[nodeType+":"+nodeID]; exists {
+		return false, nil
+	}
+	return true, m.AddNode(ctx, nodeType, nodeID, properties)
+}
+
+// MergeNodeProperty deep-merges value into a nested map property of a mock node
+func (m *MockGraph) MergeNodeProperty(ctx context.Context, nodeType, nodeID, key string, value map[string]interface{}) error {
+	node, exists := m.nodes[nodeType+":"+nodeID]
+	if !exists {
+		return fmt.Errorf("%w: %s %s", graph.ErrNodeNotFound, nodeType, nodeID)
+	}
+
+	existing, err := graph.DecodeMapProperty(node[key])
+	if err != nil {
+		return err
+	}
+	node[key] = graph.MergeMaps(existing, value)
+	return nil
+}
+
 // DeleteNode deletes a node from the mock graph
 func (m *MockGraph) DeleteNode(ctx context.Context, nodeType, nodeID string) error {
 	key := nodeType + ":" + nodeID
@@ -225,6 +377,54 @@ func (m *MockGraph) DeleteNode(ctx context.Context, nodeType, nodeID string) err
 	return nil
 }
 
+// DeleteNodesByFilter deletes the mock nodes matching filters, returning how many were deleted
+func (m *MockGraph) DeleteNodesByFilter(ctx context.Context, nodeType string, filters map[string]interface{}) (int, error) {
+	if len(filters) == 0 {
+		return 0, fmt.Errorf("refusing to delete all %s nodes: at least one filter is required", nodeType)
+	}
+
+	matches, err := m.QueryNodes(ctx, nodeType, filters)
+	if err != nil {
+		return 0, err
+	}
+	for _, props := range matches {
+		delete(m.nodes, nodeType+":"+fmt.Sprint(props["id"]))
+	}
+	return len(matches), nil
+}
+
+// DeleteOrphanedNodes deletes the nodeType nodes no edgeType edge points to that were created before createdBefore
+func (m *MockGraph) DeleteOrphanedNodes(ctx context.Context, nodeType, edgeType string, createdBefore time.Time) (int, error) {
+	linked := make(map[string]bool)
+	for _, edge := range m.edges {
+		if edge.edgeType == edgeType {
+			linked[edge.targetKey] = true
+		}
+	}
+
+	deleted := make(map[string]bool)
+	for key, props := range m.nodes {
+		if props["type"] != nodeType || linked[key] {
+			continue
+		}
+		if createdAt, ok := props["created_at"].(time.Time); ok && !createdAt.Before(createdBefore) {
+			continue
+		}
+		delete(m.nodes, key)
+		deleted[key] = true
+	}
+
+	// Detach the deleted nodes' outgoing edges
+	kept := m.edges[:0]
+	for _, edge := range m.edges {
+		if !deleted[edge.sourceKey] {
+			kept = append(kept, edge)
+		}
+	}
+	m.edges = kept
+	return len(deleted), nil
+}
+
 // QueryNodes queries nodes from the mock graph
 func (m *MockGraph) QueryNodes(ctx context.Context, nodeType string, filters map[string]interface{}) ([]map[string]interface{}, error) {
 	var results []map[string]interface{}
@@ -247,6 +447,145 @@ func (m *MockGraph) QueryNodes(ctx context.Context, nodeType string, filters map
 	return results, nil
 }
 
+// CountNodes counts the nodes in the mock graph matching filters
+func (m *MockGraph) CountNodes(ctx context.Context, nodeType string, filters map[string]interface{}) (int, error) {
+	results, err := m.QueryNodes(ctx, nodeType, filters)
+	return len(results), err
+}
+
+// QueryNodesWithOptions queries nodes from the mock graph with ordering and limit
+func (m *MockGraph) QueryNodesWithOptions(ctx context.Context, nodeType string, filters map[string]interface{}, opts graph.QueryOptions) ([]map[string]interface{}, error) {
+	results, err := m.QueryNodes(ctx, nodeType, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.After != nil {
+		if opts.OrderBy == "" {
+			return nil, fmt.Errorf("after requires an order by property")
+		}
+		after := results[:0]
+		for _, props := range results {
+			value := props[opts.OrderBy]
+			if (!opts.Descending && lessValue(opts.After, value)) || (opts.Descending && lessValue(value, opts.After)) {
+				after = append(after, props)
+			}
+		}
+		results = after
+	}
+
+	if opts.OrderBy != "" {
+		sort.SliceStable(results, func(i, j int) bool {
+			a, b := results[i], results[j]
+			if opts.Descending {
+				a, b = b, a
+			}
+			if lessValue(a[opts.OrderBy], b[opts.OrderBy]) {
+				return true
+			}
+			if lessValue(b[opts.OrderBy], a[opts.OrderBy]) || opts.ThenBy == "" {
+				return false
+			}
+			return lessValue(a[opts.ThenBy], b[opts.ThenBy])
+		})
+	}
+
+	if opts.Offset > 0 {
+		if opts.Offset >= len(results) {
+			return []map[string]interface{}{}, nil
+		}
+		results = results[opts.Offset:]
+	}
+
+	if opts.Limit > 0 && len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+
+	return results, nil
+}
+
+// lessValue orders numbers numerically and everything else by string form
+// QueryNodesAdvanced queries nodes from the mock graph matching all conditions
+func (m *MockGraph) QueryNodesAdvanced(ctx context.Context, nodeType string, conditions []graph.Condition) ([]map[string]interface{}, error) {
+	for _, condition := range conditions {
+		if err := condition.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	var results []map[string]interface{}
+	for _, props := range m.nodes {
+		if props["type"] != nodeType {
+			continue
+		}
+		matches := true
+		for _, condition := range conditions {
+			if !matchCondition(props[condition.Field], condition) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			results = append(results, props)
+		}
+	}
+	return results, nil
+}
+
+// matchCondition evaluates a single condition against a property value with Cypher semantics
+func matchCondition(value interface{}, condition graph.Condition) bool {
+	if value == nil {
+		return false
+	}
+
+	switch condition.Operator {
+	case graph.OperatorEquals:
+		return compareValues(value, condition.Value)
+	case graph.OperatorLessThan:
+		return lessValue(value, condition.Value)
+	case graph.OperatorGreaterThan:
+		return lessValue(condition.Value, value)
+	case graph.OperatorIn:
+		candidates := reflect.ValueOf(condition.Value)
+		for i := 0; i < candidates.Len(); i++ {
+			if fmt.Sprint(candidates.Index(i).Interface()) == fmt.Sprint(value) {
+				return true
+			}
+		}
+		return false
+	case graph.OperatorContains:
+		str, ok := value.(string)
+		return ok && strings.Contains(str, condition.Value.(string))
+	}
+	return false
+}
+
+func lessValue(a, b interface{}) bool {
+	if at, ok := a.(time.Time); ok {
+		if bt, ok := b.(time.Time); ok {
+			return at.Before(bt)
+		}
+	}
+	if af, ok := toFloat(a); ok {
+		if bf, ok := toFloat(b); ok {
+			return af < bf
+		}
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
 // compareValues compares two values, handling slices specially
 func compareValues(a, b interface{}) bool {
 	// Handle slice comparisons for capabilities (contains logic)
@@ -320,17 +659,18 @@ func compareValues(a, b interface{}) bool {
 }
 
 // GetStats returns mock statistics with realistic test data
-func (m *MockGraph) GetStats() map[string]interface{} {
+func (m *MockGraph) GetStats(ctx context.Context) (map[string]interface{}, error) {
 	nodesByType := m.getNodesByType()
 	return map[string]interface{}{
 		"implementation": "mock_graph_with_test_data",
 		"total_nodes":    len(m.nodes),
-		"nodes_by_type":  nodesByType,
+		"total_edges":    len(m.edges),
+		"nodes_by_label": nodesByType,
 		"capabilities":   []string{"deploy", "rollback", "canary", "security", "compliance", "audit", "monitoring", "optimization", "scaling"},
 		"active_agents":  nodesByType["agent"],
 		"workflows":      nodesByType["workflow"],
 		"conversations":  nodesByType["conversation"],
-	}
+	}, nil
 }
 
 // Helper method to get nodes by type
@@ -347,6 +687,7 @@ func (m *MockGraph) getNodesByType() map[string]int {
 // Reset clears all data from the mock graph (useful for test cleanup)
 func (m *MockGraph) Reset() {
 	m.nodes = make(map[string]map[string]interface{})
+	m.edges = nil
 }
 
 // GetNodeCount returns the total number of nodes in the mock graph
@@ -370,7 +711,20 @@ func (m *MockGraph) GetAllNodes() map[string]map[string]interface{} {
 
 // Edge operations (minimal implementation for testing)
 func (m *MockGraph) AddEdge(ctx context.Context, sourceType, sourceID, targetType, targetID, edgeType string, properties map[string]interface{}) error {
-	// Simple edge storage for testing - not used by most tests
+	// Like Neo4j's MATCH ... CREATE, nothing is linked unless both nodes exist
+	if _, exists := m.nodes[sourceType+":"+sourceID]; !exists {
+		return nil
+	}
+	if _, exists := m.nodes[targetType+":"+targetID]; !exists {
+		return nil
+	}
+	m.edges = append(m.edges, mockEdge{
+		sourceKey:  sourceType + ":" + sourceID,
+		targetKey:  targetType + ":" + targetID,
+		targetType: targetType,
+		targetID:   targetID,
+		edgeType:   edgeType,
+	})
 	return nil
 }
 
@@ -379,6 +733,241 @@ func (m *MockGraph) GetEdges(ctx context.Context, nodeType, nodeID string) ([]ma
 	return []map[string]interface{}{}, nil
 }
 
+// TraversePath follows hops through the recorded edges, ordering paths like Neo4j would
+func (m *MockGraph) TraversePath(ctx context.Context, nodeType, nodeID string, hops []graph.Hop) ([][]map[string]interface{}, error) {
+	if len(hops) == 0 {
+		return nil, fmt.Errorf("path traversal requires at least one hop")
+	}
+
+	paths := [][]map[string]interface{}{{}}
+	keys := []string{nodeType + ":" + nodeID}
+	for _, hop := range hops {
+		nextPaths := [][]map[string]interface{}{}
+		nextKeys := []string{}
+		for i, key := range keys {
+			for _, edge := range m.edges {
+				if edge.sourceKey != key || edge.edgeType != hop.EdgeType || edge.targetType != hop.TargetType {
+					continue
+				}
+				target, exists := m.nodes[edge.targetKey]
+				if !exists {
+					continue
+				}
+				path := append(append([]map[string]interface{}{}, paths[i]...), target)
+				nextPaths = append(nextPaths, path)
+				nextKeys = append(nextKeys, edge.targetKey)
+			}
+		}
+		paths, keys = nextPaths, nextKeys
+	}
+
+	sort.SliceStable(paths, func(i, j int) bool {
+		for hop := range hops {
+			property := hops[hop].OrderBy
+			if property == "" {
+				continue
+			}
+			a, b := paths[i][hop][property], paths[j][hop][property]
+			if lessValue(a, b) {
+				return true
+			}
+			if lessValue(b, a) {
+				return false
+			}
+		}
+		return false
+	})
+	return paths, nil
+}
+
+// FollowChain walks the recorded edgeType edges from a node, nearest first, stopping at cycles
+func (m *MockGraph) FollowChain(ctx context.Context, nodeType, nodeID, edgeType string) ([]map[string]interface{}, error) {
+	var nodes []map[string]interface{}
+	visited := make(map[string]bool)
+	frontier := []string{nodeType + ":" + nodeID}
+	for len(frontier) > 0 {
+		var next []string
+		for _, key := range frontier {
+			node, exists := m.nodes[key]
+			if !exists || visited[key] {
+				continue
+			}
+			visited[key] = true
+			nodes = append(nodes, node)
+			for _, edge := range m.edges {
+				if edge.sourceKey == key && edge.edgeType == edgeType && edge.targetType == nodeType {
+					next = append(next, edge.targetKey)
+				}
+			}
+		}
+		frontier = next
+	}
+	return nodes, nil
+}
+
+// QueryNodesByRelated returns the distinct nodes with a recorded edge to a target node matching all targetFilters
+func (m *MockGraph) QueryNodesByRelated(ctx context.Context, nodeType, edgeType, targetType string, targetFilters map[string]interface{}) ([]map[string]interface{}, error) {
+	seen := make(map[string]bool)
+	var results []map[string]interface{}
+	for _, edge := range m.edges {
+		if edge.edgeType != edgeType || edge.targetType != targetType || seen[edge.sourceKey] {
+			continue
+		}
+		source, exists := m.nodes[edge.sourceKey]
+		if !exists || source["type"] != nodeType {
+			continue
+		}
+		target, exists := m.nodes[edge.targetKey]
+		if !exists {
+			continue
+		}
+		matches := true
+		for k, v := range targetFilters {
+			if !compareValues(target[k], v) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			seen[edge.sourceKey] = true
+			results = append(results, source)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockGraph) CountRelatedNodesByProperty(ctx context.Context, nodeType, nodeID, edgeType, property string) (map[string]int, error) {
+	counts := make(map[string]int)
+	sourceKey := nodeType + ":" + nodeID
+	for _, edge := range m.edges {
+		if edge.sourceKey != sourceKey || edge.edgeType != edgeType {
+			continue
+		}
+		target, exists := m.nodes[edge.targetKey]
+		if !exists {
+			continue
+		}
+		value := ""
+		if v, ok := target[property]; ok && v != nil {
+			value = fmt.Sprintf("%v", v)
+		}
+		counts[value]++
+	}
+	return counts, nil
+}
+
+// Aggregate groups the matching mock nodes with their optional related node, counting and averaging each group like Cypher
+func (m *MockGraph) Aggregate(ctx context.Context, aggregation graph.Aggregation) ([]graph.AggregateGroup, error) {
+	if err := aggregation.Validate(); err != nil {
+		return nil, err
+	}
+	nodes, err := m.QueryNodesAdvanced(ctx, aggregation.NodeType, aggregation.Conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	type accumulator struct {
+		group  graph.AggregateGroup
+		sum    float64
+		values int // Nodes with a numeric average property; avg ignores the rest
+	}
+	var order []string
+	groups := make(map[string]*accumulator)
+	for _, node := range nodes {
+		values := make(map[string]interface{}, len(aggregation.GroupBy)+len(aggregation.RelatedGroupBy))
+		for _, property := range aggregation.GroupBy {
+			values[property] = node[property]
+		}
+		if aggregation.RelatedType != "" {
+			related := m.incomingNode(aggregation.NodeType+":"+fmt.Sprint(node["id"]), aggregation.RelatedEdgeType, aggregation.RelatedType)
+			for _, property := range aggregation.RelatedGroupBy {
+				values[graph.RelatedPrefix+property] = related[property]
+			}
+		}
+
+		key := fmt.Sprint(values)
+		acc, ok := groups[key]
+		if !ok {
+			acc = &accumulator{group: graph.AggregateGroup{Values: values}}
+			groups[key] = acc
+			order = append(order, key)
+		}
+		acc.group.Count++
+		if value, ok := toFloat(node[aggregation.AverageProperty]); ok {
+			acc.sum += value
+			acc.values++
+		}
+	}
+
+	results := make([]graph.AggregateGroup, 0, len(order))
+	for _, key := range order {
+		acc := groups[key]
+		if acc.values > 0 {
+			acc.group.Average = acc.sum / float64(acc.values)
+		}
+		results = append(results, acc.group)
+	}
+	return results, nil
+}
+
+// incomingNode returns the properties of a relatedType node with an edgeType edge to targetKey, or nil when none exists
+func (m *MockGraph) incomingNode(targetKey, edgeType, relatedType string) map[string]interface{} {
+	for _, edge := range m.edges {
+		if edge.targetKey != targetKey || edge.edgeType != edgeType {
+			continue
+		}
+		if source, exists := m.nodes[edge.sourceKey]; exists && source["type"] == relatedType {
+			return source
+		}
+	}
+	return nil
+}
+
+// FullTextSearch scores the nodes matching conditions by case-insensitive occurrences of the query terms in property
+func (m *MockGraph) FullTextSearch(ctx context.Context, nodeType, property, query string, conditions []graph.Condition, limit, offset int) ([]map[string]interface{}, error) {
+	matching, err := m.QueryNodesAdvanced(ctx, nodeType, conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := strings.Fields(strings.ToLower(query))
+	var results []map[string]interface{}
+	for _, node := range matching {
+		text, _ := node[property].(string)
+		text = strings.ToLower(text)
+		score := 0
+		for _, term := range terms {
+			score += strings.Count(text, term)
+		}
+		if score == 0 {
+			continue
+		}
+		hit := make(map[string]interface{}, len(node)+1)
+		for k, v := range node {
+			hit[k] = v
+		}
+		hit["score"] = float64(score)
+		results = append(results, hit)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i]["score"] != results[j]["score"] {
+			return results[i]["score"].(float64) > results[j]["score"].(float64)
+		}
+		return fmt.Sprint(results[i]["id"]) < fmt.Sprint(results[j]["id"])
+	})
+	if offset > 0 {
+		if offset >= len(results) {
+			return nil, nil
+		}
+		results = results[offset:]
+	}
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
 func (m *MockGraph) UpdateEdge(ctx context.Context, sourceType, sourceID, targetType, targetID, edgeType string, properties map[string]interface{}) error {
 	// Simple edge update for testing
 	return nil
@@ -390,28 +979,38 @@ func (m *MockGraph) DeleteEdge(ctx context.Context, sourceType, sourceID, target
 }
 
 func (m *MockGraph) CreateIndex(ctx context.Context, nodeType, property string) error {
-	// No-op create index for testing
+	m.recordSchema("index", nodeType, property)
+	return nil
+}
+
+func (m *MockGraph) CreateFullTextIndex(ctx context.Context, nodeType, property string) error {
+	// No-op create full-text index for testing
 	return nil
 }
 
 func (m *MockGraph) CreateUniqueConstraint(ctx context.Context, nodeType, property string) error {
-	// No-op create constraint for testing
+	m.recordSchema("constraint", nodeType, property)
 	return nil
 }
 
 func (m *MockGraph) DropIndex(ctx context.Context, nodeType, property string) error {
-	// No-op drop index for testing
+	delete(m.schema, schemaKey("index", nodeType, property))
 	return nil
 }
 
+func (m *MockGraph) DropUniqueConstraint(ctx context.Context, nodeType, property string) error {
+	delete(m.schema, schemaKey("constraint", nodeType, property))
+	return nil
+}
+
+// HasUniqueConstraint reports whether the constraint was created on this mock
 func (m *MockGraph) HasUniqueConstraint(ctx context.Context, nodeType, property string) (bool, error) {
-	// Return false for testing
-	return false, nil
+	return m.schema[schemaKey("constraint", nodeType, property)], nil
 }
 
+// HasIndex reports whether the index was created on this mock
 func (m *MockGraph) HasIndex(ctx context.Context, nodeType, property string) (bool, error) {
-	// Return false for testing
-	return false, nil
+	return m.schema[schemaKey("index", nodeType, property)], nil
 }
 
 func (m *MockGraph) HasRelationshipType(ctx context.Context, relationshipType string) (bool, error) {
@@ -425,6 +1024,21 @@ func (m *MockGraph) Close(ctx context.Context) error {
 }
 
 func (m *MockGraph) GetEdgesWithTargets(ctx context.Context, nodeType, nodeID string) ([]map[string]interface{}, error) {
-	// Return empty edges for testing - could be enhanced to return test data if needed
-	return []map[string]interface{}{}, nil
+	sourceKey := nodeType + ":" + nodeID
+	edges := []map[string]interface{}{}
+	for _, edge := range m.edges {
+		if edge.sourceKey != sourceKey {
+			continue
+		}
+		// Like Neo4j, only edges whose target node exists are returned
+		if _, exists := m.nodes[edge.targetKey]; !exists {
+			continue
+		}
+		edges = append(edges, map[string]interface{}{
+			"type":        edge.edgeType,
+			"target_id":   edge.targetID,
+			"target_type": edge.targetType,
+		})
+	}
+	return edges, nil
 }
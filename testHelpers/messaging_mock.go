@@ -104,3 +104,13 @@ func (m *MockAIMessageBus) PrepareAgentQueue(ctx context.Context, agentID string
 	args := m.Called(ctx, agentID)
 	return args.Error(0)
 }
+
+func (m *MockAIMessageBus) PublishAgentCompletedEvent(ctx context.Context, event *messaging.AgentCompletedEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockAIMessageBus) PublishPlanCompletedEvent(ctx context.Context, event *messaging.PlanCompletedEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"neuromesh/internal/execution/domain"
+	learningDomain "neuromesh/internal/learning/domain"
 	orchestratorDomain "neuromesh/internal/orchestrator/domain"
 	planningDomain "neuromesh/internal/planning/domain"
 
@@ -80,6 +81,24 @@ func (m *MockLearningService) UpdatePattern(ctx context.Context, pattern *orches
 	return args.Error(0)
 }
 
+func (m *MockLearningService) RecordOutcome(ctx context.Context, decisionID string, outcome learningDomain.Outcome) error {
+	args := m.Called(ctx, decisionID, outcome)
+	return args.Error(0)
+}
+
+func (m *MockLearningService) GetSimilarOutcomes(ctx context.Context, userInput string) ([]*learningDomain.Outcome, error) {
+	args := m.Called(ctx, userInput)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*learningDomain.Outcome), args.Error(1)
+}
+
+func (m *MockLearningService) RecordFeedback(ctx context.Context, feedback *learningDomain.Feedback) error {
+	args := m.Called(ctx, feedback)
+	return args.Error(0)
+}
+
 // MockExecutionService provides a testify-based mock for execution service operations
 type MockExecutionService struct {
 	mock.Mock